@@ -164,6 +164,11 @@ type state struct {
 	// the proxy has already been started when placing in this var. This struct
 	// will take the responsibility of closing the proxy.
 	proxy jujuproxy.Proxier
+
+	// traceParent holds the trace context sent with each request, in
+	// the W3C traceparent format. It is empty if requests aren't
+	// traced.
+	traceParent string
 }
 
 // RedirectError is returned from Open when the controller
@@ -288,6 +293,7 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 		tlsConfig:    dialResult.tlsConfig,
 		bakeryClient: bakeryClient,
 		modelTag:     info.ModelTag,
+		traceParent:  opts.TraceContext.TraceParent(),
 	}
 	if !info.SkipLogin {
 		if err := loginWithContext(dialCtx, st, info); err != nil {
@@ -1238,10 +1244,11 @@ var apiCallRetryStrategy = retry.LimitTime(10*time.Second,
 func (s *state) APICall(facade string, vers int, id, method string, args, response interface{}) error {
 	for a := retry.Start(apiCallRetryStrategy, s.clock); a.Next(); {
 		err := s.client.Call(rpc.Request{
-			Type:    facade,
-			Version: vers,
-			Id:      id,
-			Action:  method,
+			Type:        facade,
+			Version:     vers,
+			Id:          id,
+			Action:      method,
+			TraceParent: s.traceParent,
 		}, args, response)
 		if err == nil {
 			return nil
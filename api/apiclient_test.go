@@ -33,6 +33,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/tracing"
 	jjtesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
@@ -1105,6 +1106,35 @@ func (s *apiclientSuite) TestAPICallError(c *gc.C) {
 	c.Check(clock.waits, gc.HasLen, 0)
 }
 
+func (s *apiclientSuite) TestAPICallSendsTraceContext(c *gc.C) {
+	traceContext, err := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	c.Assert(err, jc.ErrorIsNil)
+	rpcConn := newRPCConnection()
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: rpcConn,
+		Clock:         &fakeClock{},
+		TraceContext:  traceContext,
+	})
+
+	err = conn.APICall("facade", 1, "id", "method", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rpcConn.requests, gc.HasLen, 1)
+	c.Assert(rpcConn.requests[0].TraceParent, gc.Equals, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+}
+
+func (s *apiclientSuite) TestAPICallWithoutTraceContext(c *gc.C) {
+	rpcConn := newRPCConnection()
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: rpcConn,
+		Clock:         &fakeClock{},
+	})
+
+	err := conn.APICall("facade", 1, "id", "method", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rpcConn.requests, gc.HasLen, 1)
+	c.Assert(rpcConn.requests[0].TraceParent, gc.Equals, "")
+}
+
 func (s *apiclientSuite) TestPing(c *gc.C) {
 	clock := &fakeClock{}
 	rpcConn := newRPCConnection()
@@ -1268,6 +1298,7 @@ func newRPCConnection(errs ...error) *fakeRPCConnection {
 type fakeRPCConnection struct {
 	stub     testing.Stub
 	response interface{}
+	requests []rpc.Request
 }
 
 func (f *fakeRPCConnection) Dead() <-chan struct{} {
@@ -1280,6 +1311,7 @@ func (f *fakeRPCConnection) Close() error {
 
 func (f *fakeRPCConnection) Call(req rpc.Request, params, response interface{}) error {
 	f.stub.AddCall(req.Type+"."+req.Action, req.Version, params)
+	f.requests = append(f.requests, req)
 	if f.response != nil {
 		rv := reflect.ValueOf(response)
 		target := reflect.Indirect(rv)
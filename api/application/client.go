@@ -853,6 +853,94 @@ func (c *Client) ResolveUnitErrors(units []string, all, retry bool) error {
 	return errors.Trace(results.Combine())
 }
 
+// CancelHook asks the agents of one or more units to interrupt the hooks
+// they are running.
+func (c *Client) CancelHook(units []string) error {
+	if c.BestAPIVersion() < 14 {
+		return errors.NotSupportedf("cancelling hooks on this controller")
+	}
+	if len(units) != set.NewStrings(units...).Size() {
+		return errors.New("duplicate unit specified")
+	}
+	entities := make([]params.Entity, len(units))
+	for i, unit := range units {
+		if !names.IsValidUnit(unit) {
+			return errors.NotValidf("unit name %q", unit)
+		}
+		entities[i].Tag = names.NewUnitTag(unit).String()
+	}
+
+	results := new(params.ErrorResults)
+	err := c.facade.FacadeCall("CancelHook", params.Entities{Entities: entities}, results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.Combine())
+}
+
+// HookRun describes a hook run by a unit's agent.
+type HookRun struct {
+	Hook     string
+	Relation string
+	Started  time.Time
+	Finished time.Time
+	ExitCode int
+	Stderr   string
+}
+
+// HookHistoryFilter restricts the hook runs returned by HookHistory.
+// The zero value matches every run.
+type HookHistoryFilter struct {
+	Hook       string
+	Relation   string
+	Since      *time.Time
+	FailedOnly bool
+	Limit      int
+}
+
+// HookHistory returns the hooks run by the unit which match the
+// filter, most recent first.
+func (c *Client) HookHistory(unit string, filter HookHistoryFilter) ([]HookRun, error) {
+	if c.BestAPIVersion() < 15 {
+		return nil, errors.NotSupportedf("hook history on this controller")
+	}
+	if !names.IsValidUnit(unit) {
+		return nil, errors.NotValidf("unit name %q", unit)
+	}
+	args := params.HookHistoryArgs{Args: []params.HookHistoryArg{{
+		Tag: names.NewUnitTag(unit).String(),
+		Filter: params.HookHistoryFilter{
+			Hook:       filter.Hook,
+			Relation:   filter.Relation,
+			Since:      filter.Since,
+			FailedOnly: filter.FailedOnly,
+			Size:       filter.Limit,
+		},
+	}}}
+	var results params.HookHistoryResults
+	if err := c.facade.FacadeCall("HookHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	runs := make([]HookRun, len(results.Results[0].Runs))
+	for i, run := range results.Results[0].Runs {
+		runs[i] = HookRun{
+			Hook:     run.Hook,
+			Relation: run.Relation,
+			Started:  run.Started,
+			Finished: run.Finished,
+			ExitCode: run.ExitCode,
+			Stderr:   run.Stderr,
+		}
+	}
+	return runs, nil
+}
+
 func validateApplicationScale(scale, scaleChange int) error {
 	if scale < 0 && scaleChange == 0 {
 		return errors.NotValidf("scale < 0")
@@ -903,12 +991,20 @@ type UnitInfo struct {
 	Charm           string
 	Leader          bool
 	RelationData    []EndpointRelationData
+	Timers          map[string]UnitTimer
 
 	// The following are for CAAS models.
 	ProviderId string
 	Address    string
 }
 
+// UnitTimer holds a timer scheduled by a unit's charm.
+type UnitTimer struct {
+	Next   time.Time
+	Every  time.Duration
+	Jitter time.Duration
+}
+
 // RelationData holds information about a unit's relation.
 type RelationData struct {
 	InScope  bool
@@ -992,5 +1088,15 @@ func unitInfoFromParams(in params.UnitInfoResult) UnitInfo {
 		}
 		info.RelationData = append(info.RelationData, erd)
 	}
+	if len(in.Result.Timers) > 0 {
+		info.Timers = make(map[string]UnitTimer)
+		for name, t := range in.Result.Timers {
+			info.Timers[name] = UnitTimer{
+				Next:   t.Next,
+				Every:  t.Every,
+				Jitter: t.Jitter,
+			}
+		}
+	}
 	return info
 }
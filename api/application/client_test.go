@@ -895,6 +895,103 @@ func (s *applicationSuite) TestResolveUnitErrors(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestCancelHook(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "CancelHook")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{
+				{Tag: "unit-mysql-0"},
+				{Tag: "unit-mysql-1"},
+			},
+		})
+
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	client := application.NewClient(basetesting.BestVersionCaller{apiCaller, 14})
+	err := client.CancelHook([]string{"mysql/0", "mysql/1"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestCancelHookInvalidUnit(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	})
+	client := application.NewClient(basetesting.BestVersionCaller{apiCaller, 14})
+	err := client.CancelHook([]string{"mysql"})
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+}
+
+func (s *applicationSuite) TestCancelHookNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	})
+	err := client.CancelHook([]string{"mysql/0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestHookHistory(c *gc.C) {
+	started := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Check(request, gc.Equals, "HookHistory")
+		c.Assert(a, jc.DeepEquals, params.HookHistoryArgs{Args: []params.HookHistoryArg{{
+			Tag:    "unit-mysql-0",
+			Filter: params.HookHistoryFilter{Hook: "install", FailedOnly: true, Size: 3},
+		}}})
+		result := response.(*params.HookHistoryResults)
+		result.Results = []params.HookHistoryResult{{
+			Runs: []params.HookRun{{
+				Hook:     "install",
+				Started:  started,
+				Finished: started.Add(time.Second),
+				ExitCode: 1,
+				Stderr:   "oops",
+			}},
+		}}
+		return nil
+	})
+	client := application.NewClient(basetesting.BestVersionCaller{apiCaller, 15})
+	runs, err := client.HookHistory("mysql/0", application.HookHistoryFilter{
+		Hook:       "install",
+		FailedOnly: true,
+		Limit:      3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, jc.DeepEquals, []application.HookRun{{
+		Hook:     "install",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		ExitCode: 1,
+		Stderr:   "oops",
+	}})
+}
+
+func (s *applicationSuite) TestHookHistoryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+		result := response.(*params.HookHistoryResults)
+		result.Results = []params.HookHistoryResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	client := application.NewClient(basetesting.BestVersionCaller{apiCaller, 15})
+	_, err := client.HookHistory("mysql/0", application.HookHistoryFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestHookHistoryNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	})
+	_, err := client.HookHistory("mysql/0", application.HookHistoryFilter{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestResolveUnitErrorsUnitsAll(c *gc.C) {
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
 		c.Fail()
@@ -1265,6 +1362,12 @@ func (s *applicationSuite) TestUnitsInfo(c *gc.C) {
 							},
 						},
 					}},
+					Timers: map[string]params.UnitTimer{
+						"backup": {
+							Next:  time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+							Every: time.Hour,
+						},
+					},
 					ProviderId: "provider-id",
 					Address:    "192.168.1.1",
 				}},
@@ -1303,6 +1406,12 @@ func (s *applicationSuite) TestUnitsInfo(c *gc.C) {
 					},
 				},
 			}},
+			Timers: map[string]application.UnitTimer{
+				"backup": {
+					Next:  time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+					Every: time.Hour,
+				},
+			},
 			ProviderId: "provider-id",
 			Address:    "192.168.1.1",
 		},
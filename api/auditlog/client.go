@@ -0,0 +1,34 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the audit logs recorded by the
+// controller nodes.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new AuditLog client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the conversations from the audit logs of all of the
+// controller nodes which match the query, oldest first.
+func (c *Client) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResult, error) {
+	var result params.AuditLogQueryResult
+	if err := c.facade.FacadeCall("Query", args, &result); err != nil {
+		return params.AuditLogQueryResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Query")
+			c.Check(a, jc.DeepEquals, params.AuditLogQueryArgs{User: "bob", ErrorsOnly: true})
+
+			result, ok := response.(*params.AuditLogQueryResult)
+			c.Assert(ok, jc.IsTrue)
+			result.Conversations = []params.AuditLogConversation{{
+				ControllerID:   "0",
+				ConversationID: "abc",
+				Who:            "bob",
+			}}
+			return nil
+		})
+	client := auditlog.NewClient(apiCaller)
+	result, err := client.Query(params.AuditLogQueryArgs{User: "bob", ErrorsOnly: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result.Conversations, jc.DeepEquals, []params.AuditLogConversation{{
+		ControllerID:   "0",
+		ConversationID: "abc",
+		Who:            "bob",
+	}})
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			return errors.New("boom")
+		})
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
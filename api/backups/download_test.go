@@ -27,7 +27,8 @@ func (s *downloadSuite) TestSuccessfulRequest(c *gc.C) {
 		*state.State
 		*state.Model
 	}{s.State, s.Model}
-	store := backups.NewStorage(db)
+	store, err := backups.NewStorage(db)
+	c.Assert(err, jc.ErrorIsNil)
 	defer store.Close()
 	backupsState := backups.NewBackups(store)
 
@@ -0,0 +1,23 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Schedule returns the controller's backup schedule and retention
+// policy, and the outcome of the most recent scheduled backup.
+func (c *Client) Schedule() (*params.BackupsScheduleResult, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("backup schedule on this version of Juju")
+	}
+	var result params.BackupsScheduleResult
+	if err := c.facade.FacadeCall("Schedule", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
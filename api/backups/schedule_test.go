@@ -0,0 +1,48 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+)
+
+type scheduleSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) TestSchedule(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"backup-schedule":    "@daily",
+		"backup-keep-weekly": 2,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.client.Schedule()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Schedule, gc.Equals, "@daily")
+	c.Check(result.KeepDaily, gc.Equals, 7)
+	c.Check(result.KeepWeekly, gc.Equals, 2)
+	c.Check(result.Next, gc.NotNil)
+	c.Check(result.LastResult, gc.IsNil)
+}
+
+func (s *scheduleSuite) TestScheduleNotSupported(c *gc.C) {
+	// The patched facade caller reports version 0.
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %q", req)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.Schedule()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
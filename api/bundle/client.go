@@ -74,3 +74,24 @@ func (c *Client) ExportBundle() (string, error) {
 
 	return result.Result, nil
 }
+
+// ExportCompleteBundle exports the current model configuration with
+// everything needed to reproduce the model, returning warnings for the
+// parts of the model which can't be represented in a bundle.
+func (c *Client) ExportCompleteBundle() (string, []string, error) {
+	var result params.ExportBundleResult
+	if bestVer := c.BestAPIVersion(); bestVer < 5 {
+		return "", nil, errors.Errorf("this controller version does not support complete bundle export feature.")
+	}
+
+	args := params.ExportBundleParams{Complete: true}
+	if err := c.facade.FacadeCall("ExportBundle", args, &result); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+
+	if result.Error != nil {
+		return "", nil, errors.Trace(result.Error)
+	}
+
+	return result.Result, result.Warnings, nil
+}
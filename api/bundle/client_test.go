@@ -361,3 +361,45 @@ func (s *bundleMockSuite) TestExportBundleFailNoParamsErrorv2(c *gc.C) {
 	c.Assert(result, jc.DeepEquals, "")
 	c.Check(err.Error(), gc.Matches, "foo")
 }
+
+func (s *bundleMockSuite) TestFailExportCompleteBundlev4(c *gc.C) {
+	client := newClient(
+		func(objType string, version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Fail()
+			return nil
+		}, 4,
+	)
+	result, warnings, err := client.ExportCompleteBundle()
+	c.Assert(err, gc.ErrorMatches, "this controller version does not support complete bundle export feature.")
+	c.Assert(result, gc.Equals, "")
+	c.Assert(warnings, gc.HasLen, 0)
+}
+
+func (s *bundleMockSuite) TestExportCompleteBundlev5(c *gc.C) {
+	client := newClient(
+		func(objType string, version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(request, gc.Equals, "ExportBundle")
+			c.Check(args, jc.DeepEquals, params.ExportBundleParams{Complete: true})
+			*(response.(*params.ExportBundleResult)) = params.ExportBundleResult{
+				Result:   "applications: {}\n",
+				Warnings: []string{"a warning"},
+			}
+			return nil
+		}, 5,
+	)
+	result, warnings, err := client.ExportCompleteBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, "applications: {}\n")
+	c.Assert(warnings, jc.DeepEquals, []string{"a warning"})
+}
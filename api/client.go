@@ -50,8 +50,15 @@ type Client struct {
 
 // Status returns the status of the juju model.
 func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
+	return c.StatusWithQuery(patterns, "")
+}
+
+// StatusWithQuery returns the status of the juju model, filtered by the
+// patterns and by a status query. Controllers which don't support status
+// queries ignore the query, so the caller needs to filter the result too.
+func (c *Client) StatusWithQuery(patterns []string, query string) (*params.FullStatus, error) {
 	var result params.FullStatus
-	p := params.StatusParams{Patterns: patterns}
+	p := params.StatusParams{Patterns: patterns, Query: query}
 	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
 		return nil, err
 	}
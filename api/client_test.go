@@ -663,6 +663,41 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	})
 }
 
+func (s *clientSuite) TestWatchDebugLogFilterParamsEncoded(c *gc.C) {
+	catcher := urlCatcher{}
+	s.PatchValue(api.WebsocketDial, catcher.recordLocation)
+
+	params := common.DebugLogParams{
+		IncludeApplication: []string{"a", "b"},
+		ExcludeApplication: []string{"c"},
+		IncludeLabel:       []string{"d"},
+		ExcludeLabel:       []string{"e", "f"},
+		MessageRegex:       "hook .* failed",
+		Retained:           true,
+		StartTime:          time.Date(2016, 11, 30, 11, 48, 0, 0, time.UTC),
+		EndTime:            time.Date(2016, 11, 30, 12, 48, 0, 0, time.UTC),
+	}
+
+	client := s.APIState.Client()
+	_, err := client.WatchDebugLog(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	connectURL, err := url.Parse(catcher.location)
+	c.Assert(err, jc.ErrorIsNil)
+
+	values := connectURL.Query()
+	c.Assert(values, jc.DeepEquals, url.Values{
+		"includeApplication": params.IncludeApplication,
+		"excludeApplication": params.ExcludeApplication,
+		"includeLabel":       params.IncludeLabel,
+		"excludeLabel":       params.ExcludeLabel,
+		"messageRegex":       {"hook .* failed"},
+		"retained":           {"true"},
+		"startTime":          {"2016-11-30T11:48:00Z"},
+		"endTime":            {"2016-11-30T12:48:00Z"},
+	})
+}
+
 func (s *clientSuite) TestConnectStreamAtUUIDPath(c *gc.C) {
 	catcher := urlCatcher{}
 	s.PatchValue(api.WebsocketDial, catcher.recordLocation)
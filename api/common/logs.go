@@ -34,6 +34,20 @@ type DebugLogParams struct {
 	// ExcludeModule lists logging modules to exclude from the resposne. If a
 	// module is specified, all the submodules are also excluded.
 	ExcludeModule []string
+	// IncludeApplication lists applications to include in the response.
+	// Lines from any of the application's units, and from the application
+	// itself, are included.
+	IncludeApplication []string
+	// ExcludeApplication lists applications to exclude from the response.
+	ExcludeApplication []string
+	// IncludeLabel lists labels to include in the response. Lines with any
+	// of the labels are included.
+	IncludeLabel []string
+	// ExcludeLabel lists labels to exclude from the response.
+	ExcludeLabel []string
+	// MessageRegex is a regular expression which the message of a line
+	// must match for the line to be included.
+	MessageRegex string
 	// Limit defines the maximum number of lines to return. Once this many
 	// have been sent, the socket is closed.  If zero, all filtered lines are
 	// sent down the connection until the client closes the connection.
@@ -53,6 +67,14 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, means only records with a log time before EndTime
+	// will be returned. Once EndTime has passed the server stops waiting
+	// for new logs.
+	EndTime time.Time
+	// Retained tells the server to read the records kept according to the
+	// model's log retention policy, instead of the model's recent logs.
+	// Only WARNING records and above are retained.
+	Retained bool
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -61,6 +83,14 @@ func (args DebugLogParams) URLQuery() url.Values {
 		"includeModule": args.IncludeModule,
 		"excludeEntity": args.ExcludeEntity,
 		"excludeModule": args.ExcludeModule,
+
+		"includeApplication": args.IncludeApplication,
+		"excludeApplication": args.ExcludeApplication,
+		"includeLabel":       args.IncludeLabel,
+		"excludeLabel":       args.ExcludeLabel,
+	}
+	if args.MessageRegex != "" {
+		attrs.Set("messageRegex", args.MessageRegex)
 	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
@@ -68,6 +98,9 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if args.NoTail {
 		attrs.Set("noTail", fmt.Sprint(args.NoTail))
 	}
+	if args.Retained {
+		attrs.Set("retained", fmt.Sprint(args.Retained))
+	}
 	if args.Limit > 0 {
 		attrs.Set("maxLines", fmt.Sprint(args.Limit))
 	}
@@ -80,6 +113,9 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
 	return attrs
 }
 
@@ -91,6 +127,7 @@ type LogMessage struct {
 	Module    string
 	Location  string
 	Message   string
+	Labels    []string
 }
 
 // StreamDebugLog requests the specified debug log records from the
@@ -128,6 +165,7 @@ func StreamDebugLog(source base.StreamConnector, args DebugLogParams) (<-chan Lo
 				Module:    msg.Module,
 				Location:  msg.Location,
 				Message:   msg.Message,
+				Labels:    msg.Labels,
 			}
 		}
 	}()
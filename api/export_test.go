@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/rpc/jsoncodec"
 )
 
@@ -73,6 +74,7 @@ type TestingStateParams struct {
 	RPCConnection  RPCConnection
 	Clock          clock.Clock
 	Broken, Closed chan struct{}
+	TraceContext   tracing.SpanContext
 }
 
 // NewTestingState creates an api.State object that can be used for testing. It
@@ -98,6 +100,7 @@ func NewTestingState(params TestingStateParams) Connection {
 		serverRootAddress: params.ServerRoot,
 		broken:            params.Broken,
 		closed:            params.Closed,
+		traceParent:       params.TraceContext.TraceParent(),
 	}
 	return st
 }
@@ -18,12 +18,13 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  13,
+	"Application":                  15,
 	"ApplicationOffers":            3,
 	"ApplicationScaler":            1,
-	"Backups":                      3,
+	"AuditLog":                     1,
+	"Backups":                      4,
 	"Block":                        2,
-	"Bundle":                       4,
+	"Bundle":                       5,
 	"CAASAgent":                    1,
 	"CAASAdmission":                1,
 	"CAASApplication":              1,
@@ -67,6 +68,7 @@ var facadeVersions = map[string]int{
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
 	"Logger":                       1,
+	"LogPruner":                    1,
 	"MachineActions":               1,
 	"MachineManager":               6,
 	"MachineUndertaker":            1,
@@ -101,6 +103,8 @@ var facadeVersions = map[string]int{
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Secrets":                      1,
+	"SecretsManager":               1,
 	"Singular":                     2,
 	"Spaces":                       6,
 	"SSHClient":                    2,
@@ -111,7 +115,7 @@ var facadeVersions = map[string]int{
 	"Subnets":                      4,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       17,
+	"Uniter":                       19,
 	"Upgrader":                     1,
 	"UpgradeSeries":                3,
 	"UpgradeSteps":                 2,
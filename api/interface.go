@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/api/upgrader"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/proxy"
 	"github.com/juju/juju/rpc/jsoncodec"
 )
@@ -190,6 +191,11 @@ type DialOpts struct {
 	// automatically verified. If the callback returns a non-nil error then
 	// the connection attempt will be aborted.
 	VerifyCA func(host, endpoint string, caCert *x509.Certificate) error
+
+	// TraceContext, if valid, is sent with every API request made
+	// on the connection so that the controller can record the
+	// requests as part of the caller's trace.
+	TraceContext tracing.SpanContext
 }
 
 // IPAddrResolver implements a resolved from host name to the
@@ -0,0 +1,40 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logpruner
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const apiName = "LogPruner"
+
+// Client allows calls to "LogPruner" endpoints.
+type Client struct {
+	facade base.FacadeCaller
+	*common.ModelWatcher
+}
+
+// NewClient returns a "LogPruner" Client.
+func NewClient(caller base.APICaller) *Client {
+	facadeCaller := base.NewFacadeCaller(caller, apiName)
+	return &Client{facade: facadeCaller, ModelWatcher: common.NewModelWatcher(facadeCaller)}
+}
+
+// Prune calls "LogPruner.Prune"
+func (c *Client) Prune(retention map[loggo.Level]time.Duration, maxSizeMB int) error {
+	p := params.LogPruneArgs{
+		MaxAge:    make(map[string]time.Duration),
+		MaxSizeMB: maxSizeMB,
+	}
+	for level, maxAge := range retention {
+		p.MaxAge[level.String()] = maxAge
+	}
+	return c.facade.FacadeCall("Prune", p, nil)
+}
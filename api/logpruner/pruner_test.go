@@ -0,0 +1,52 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logpruner_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/logpruner"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type prunerSuite struct {
+}
+
+var _ = gc.Suite(&prunerSuite{})
+
+func (s *prunerSuite) TestPrune(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Assert(objType, gc.Equals, "LogPruner")
+			c.Assert(request, gc.Equals, "Prune")
+			c.Assert(a, jc.DeepEquals, params.LogPruneArgs{
+				MaxAge: map[string]time.Duration{
+					"WARNING": time.Hour,
+					"ERROR":   24 * time.Hour,
+				},
+				MaxSizeMB: 1024,
+			})
+			c.Assert(result, gc.IsNil)
+			called = true
+			return nil
+		},
+	)
+	client := logpruner.NewClient(apiCaller)
+	err := client.Prune(map[loggo.Level]time.Duration{
+		loggo.WARNING: time.Hour,
+		loggo.ERROR:   24 * time.Hour,
+	}, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
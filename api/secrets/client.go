@@ -0,0 +1,73 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the client used by model users to inspect the
+// secrets created by charms.
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	coresecrets "github.com/juju/juju/core/secrets"
+)
+
+// Client is the api client for the Secrets facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a secrets api client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Secrets")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// SecretDetails holds a secret's metadata, and its value if it was
+// asked for.
+type SecretDetails struct {
+	Metadata coresecrets.SecretMetadata
+	Value    coresecrets.SecretValue
+	Error    string
+}
+
+// ListSecrets lists the secrets in the model, including their values
+// if showSecrets is true.
+func (c *Client) ListSecrets(showSecrets bool) ([]SecretDetails, error) {
+	var results params.ListSecretResults
+	args := params.ListSecretsArgs{ShowSecrets: showSecrets}
+	if err := c.facade.FacadeCall("ListSecrets", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]SecretDetails, len(results.Results))
+	for i, r := range results.Results {
+		uri, err := coresecrets.ParseURI(r.URI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		details := SecretDetails{
+			Metadata: coresecrets.SecretMetadata{
+				URI:            uri,
+				OwnerTag:       r.OwnerTag,
+				Description:    r.Description,
+				Label:          r.Label,
+				RotatePolicy:   coresecrets.RotatePolicy(r.RotatePolicy),
+				NextRotateTime: r.NextRotateTime,
+				LatestRevision: r.LatestRevision,
+				CreateTime:     r.CreateTime,
+				UpdateTime:     r.UpdateTime,
+			},
+		}
+		if r.Value != nil {
+			if r.Value.Error != nil {
+				details.Error = r.Value.Error.Error()
+			} else {
+				details.Value = coresecrets.NewSecretValue(r.Value.Data)
+			}
+		}
+		result[i] = details
+	}
+	return result, nil
+}
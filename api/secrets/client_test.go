@@ -0,0 +1,63 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	coresecrets "github.com/juju/juju/core/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	uri := coresecrets.NewURI()
+	now := time.Now()
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "ListSecrets")
+		c.Check(arg, jc.DeepEquals, params.ListSecretsArgs{ShowSecrets: true})
+		*(result.(*params.ListSecretResults)) = params.ListSecretResults{
+			Results: []params.ListSecretResult{{
+				URI:            uri.String(),
+				OwnerTag:       "application-mysql",
+				RotatePolicy:   "hourly",
+				LatestRevision: 2,
+				CreateTime:     now,
+				UpdateTime:     now,
+				Value:          &params.SecretValueResult{Data: map[string]string{"password": "s3cret"}},
+			}, {
+				URI:   uri.String(),
+				Value: &params.SecretValueResult{Error: &params.Error{Message: "boom"}},
+			}},
+		}
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 2)
+	c.Assert(result[0].Metadata, jc.DeepEquals, coresecrets.SecretMetadata{
+		URI:            uri,
+		OwnerTag:       "application-mysql",
+		RotatePolicy:   coresecrets.RotateHourly,
+		LatestRevision: 2,
+		CreateTime:     now,
+		UpdateTime:     now,
+	})
+	c.Assert(result[0].Value.Values(), jc.DeepEquals, map[string]string{"password": "s3cret"})
+	c.Assert(result[1].Value, gc.IsNil)
+	c.Assert(result[1].Error, gc.Equals, "boom")
+}
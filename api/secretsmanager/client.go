@@ -0,0 +1,212 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secretsmanager provides the client used by unit agents to
+// create, read and share the secrets of their charms.
+package secretsmanager
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Client is the api client for the SecretsManager facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a secrets manager api client.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, "SecretsManager")}
+}
+
+// SecretRevisionInfo holds the latest revision of a secret read by a
+// unit, and the label the unit gave it.
+type SecretRevisionInfo struct {
+	Revision int
+	Label    string
+}
+
+// Create creates a new secret owned by the unit's application.
+func (c *Client) Create(arg params.CreateSecretArg) (*secrets.URI, error) {
+	var results params.StringResults
+	args := params.CreateSecretArgs{Args: []params.CreateSecretArg{arg}}
+	if err := c.facade.FacadeCall("CreateSecrets", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secrets.ParseURI(results.Results[0].Result)
+}
+
+// Update updates a secret owned by the unit's application.
+func (c *Client) Update(arg params.UpdateSecretArg) error {
+	var results params.ErrorResults
+	args := params.UpdateSecretArgs{Args: []params.UpdateSecretArg{arg}}
+	if err := c.facade.FacadeCall("UpdateSecrets", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// GetValue returns the value of a secret, identified by its URI or by
+// the label the unit gave it. peek returns the latest revision without
+// tracking it, and update tracks the latest revision from now on.
+func (c *Client) GetValue(uri *secrets.URI, label string, peek, update bool) (secrets.SecretValue, error) {
+	arg := params.GetSecretValueArg{
+		Label:  label,
+		Peek:   peek,
+		Update: update,
+	}
+	if uri != nil {
+		arg.URI = uri.String()
+	}
+	var results params.SecretValueResults
+	args := params.GetSecretValueArgs{Args: []params.GetSecretValueArg{arg}}
+	if err := c.facade.FacadeCall("GetSecretValues", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secrets.NewSecretValue(results.Results[0].Data), nil
+}
+
+// GetSecretMetadata returns the metadata of the secrets owned by the
+// unit's application.
+func (c *Client) GetSecretMetadata() ([]secrets.SecretMetadata, error) {
+	var results params.ListSecretResults
+	if err := c.facade.FacadeCall("GetSecretMetadata", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]secrets.SecretMetadata, len(results.Results))
+	for i, r := range results.Results {
+		uri, err := secrets.ParseURI(r.URI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = secrets.SecretMetadata{
+			URI:            uri,
+			OwnerTag:       r.OwnerTag,
+			Description:    r.Description,
+			Label:          r.Label,
+			RotatePolicy:   secrets.RotatePolicy(r.RotatePolicy),
+			NextRotateTime: r.NextRotateTime,
+			LatestRevision: r.LatestRevision,
+			CreateTime:     r.CreateTime,
+			UpdateTime:     r.UpdateTime,
+		}
+	}
+	return result, nil
+}
+
+// GetLatestSecretsRevisionInfo returns the latest revisions of the
+// secrets read by the unit, keyed by secret URI.
+func (c *Client) GetLatestSecretsRevisionInfo(unitName string, uris []string) (map[string]SecretRevisionInfo, error) {
+	var results params.SecretConsumerInfoResults
+	args := params.GetSecretConsumerInfoArgs{
+		ConsumerTag: names.NewUnitTag(unitName).String(),
+		URIs:        uris,
+	}
+	if err := c.facade.FacadeCall("GetLatestSecretsRevisionInfo", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(uris) {
+		return nil, errors.Errorf("expected %d results, got %d", len(uris), len(results.Results))
+	}
+	info := make(map[string]SecretRevisionInfo)
+	for i, uri := range uris {
+		if err := results.Results[i].Error; err != nil {
+			if params.IsCodeNotFound(err) {
+				continue
+			}
+			return nil, errors.Annotatef(err, "getting latest revision of %q", uri)
+		}
+		info[uri] = SecretRevisionInfo{
+			Revision: results.Results[i].Revision,
+			Label:    results.Results[i].Label,
+		}
+	}
+	return info, nil
+}
+
+// Grant gives the applications or units access to a secret.
+func (c *Client) Grant(uri *secrets.URI, subjectTags []string, role secrets.SecretRole) error {
+	return c.grantRevoke("GrantSecret", uri, subjectTags, role)
+}
+
+// Revoke removes the access the applications or units have to a secret.
+func (c *Client) Revoke(uri *secrets.URI, subjectTags []string) error {
+	return c.grantRevoke("RevokeSecret", uri, subjectTags, secrets.RoleNone)
+}
+
+func (c *Client) grantRevoke(method string, uri *secrets.URI, subjectTags []string, role secrets.SecretRole) error {
+	var results params.ErrorResults
+	args := params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			URI:         uri.String(),
+			SubjectTags: subjectTags,
+			Role:        string(role),
+		}},
+	}
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// SecretRotated records when a secret was rotated.
+func (c *Client) SecretRotated(uri string, when time.Time) error {
+	var results params.ErrorResults
+	args := params.SecretRotatedArgs{
+		Args: []params.SecretRotatedArg{{URI: uri, When: when}},
+	}
+	if err := c.facade.FacadeCall("SecretsRotated", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// WatchConsumedSecretsChanges returns a watcher which notifies of the
+// URIs of the secrets read by the unit which have new revisions.
+func (c *Client) WatchConsumedSecretsChanges(unitName string) (watcher.StringsWatcher, error) {
+	return c.watch("WatchConsumedSecretsChanges", names.NewUnitTag(unitName))
+}
+
+// WatchSecretsRotationChanges returns a watcher which notifies of the
+// URIs of the secrets owned by the application whose rotation schedule
+// may have changed.
+func (c *Client) WatchSecretsRotationChanges(appName string) (watcher.StringsWatcher, error) {
+	return c.watch("WatchSecretsRotationChanges", names.NewApplicationTag(appName))
+}
+
+func (c *Client) watch(method string, tag names.Tag) (watcher.StringsWatcher, error) {
+	var results params.StringsWatchResults
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secretsmanager"
+	"github.com/juju/juju/apiserver/params"
+	coresecrets "github.com/juju/juju/core/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) TestCreate(c *gc.C) {
+	uri := coresecrets.NewURI()
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecretsManager")
+		c.Check(request, gc.Equals, "CreateSecrets")
+		c.Check(arg, jc.DeepEquals, params.CreateSecretArgs{
+			Args: []params.CreateSecretArg{{
+				Label: "password",
+				Data:  map[string]string{"password": "s3cret"},
+			}},
+		})
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Result: uri.String()}},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	result, err := client.Create(params.CreateSecretArg{
+		Label: "password",
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, uri)
+}
+
+func (s *SecretsSuite) TestGetValue(c *gc.C) {
+	uri := coresecrets.NewURI()
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "GetSecretValues")
+		c.Check(arg, jc.DeepEquals, params.GetSecretValueArgs{
+			Args: []params.GetSecretValueArg{{URI: uri.String(), Label: "db", Update: true}},
+		})
+		*(result.(*params.SecretValueResults)) = params.SecretValueResults{
+			Results: []params.SecretValueResult{{Data: map[string]string{"password": "s3cret"}}},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	value, err := client.GetValue(uri, "db", false, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value.Values(), jc.DeepEquals, map[string]string{"password": "s3cret"})
+}
+
+func (s *SecretsSuite) TestGetValueError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.SecretValueResults)) = params.SecretValueResults{
+			Results: []params.SecretValueResult{{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}}},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	_, err := client.GetValue(nil, "db", false, false)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsSuite) TestGetLatestSecretsRevisionInfo(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "GetLatestSecretsRevisionInfo")
+		c.Check(arg, jc.DeepEquals, params.GetSecretConsumerInfoArgs{
+			ConsumerTag: "unit-wordpress-0",
+			URIs:        []string{"secret:a", "secret:b"},
+		})
+		*(result.(*params.SecretConsumerInfoResults)) = params.SecretConsumerInfoResults{
+			Results: []params.SecretConsumerInfoResult{
+				{Revision: 3, Label: "db"},
+				{Error: &params.Error{Code: params.CodeNotFound, Message: "not found"}},
+			},
+		}
+		return nil
+	})
+	client := secretsmanager.NewClient(apiCaller)
+	info, err := client.GetLatestSecretsRevisionInfo("wordpress/0", []string{"secret:a", "secret:b"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, map[string]secretsmanager.SecretRevisionInfo{
+		"secret:a": {Revision: 3, Label: "db"},
+	})
+}
@@ -25,6 +25,8 @@ type Unit struct {
 	life         life.Value
 	resolvedMode params.ResolvedMode
 	providerID   string
+
+	hookCancelRequested bool
 }
 
 // Tag returns the unit's tag.
@@ -57,6 +59,12 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// HookCancelRequested returns whether cancellation of the hook the unit
+// is running has been requested.
+func (u *Unit) HookCancelRequested() bool {
+	return u.hookCancelRequested
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -85,6 +93,7 @@ func (u *Unit) Refresh() error {
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
 	u.providerID = result.ProviderID
+	u.hookCancelRequested = result.HookCancelRequested
 	return nil
 }
 
@@ -564,6 +573,41 @@ func (u *Unit) ClearResolved() error {
 	return result.OneError()
 }
 
+// ClearHookCancel removes any request to cancel the hook the unit is
+// running.
+func (u *Unit) ClearHookCancel() error {
+	if u.st.facade.BestAPIVersion() < 18 {
+		// Older controllers can't request cancellation.
+		return nil
+	}
+	var result params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("ClearHookCancel", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// RecordHookRun adds the hook run to the unit's hook history.
+func (u *Unit) RecordHookRun(run params.HookRun) error {
+	if u.st.facade.BestAPIVersion() < 19 {
+		// Older controllers don't keep hook history.
+		return nil
+	}
+	var result params.ErrorResults
+	args := params.RecordHookRunArgs{
+		Args: []params.RecordHookRunArg{{Tag: u.tag.String(), Run: run}},
+	}
+	err := u.st.facade.FacadeCall("RecordHookRuns", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // WatchConfigSettingsHash returns a watcher for observing changes to
 // the unit's charm configuration settings (with a hash of the
 // settings content so we can determine whether it has changed since
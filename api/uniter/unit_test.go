@@ -196,9 +196,10 @@ func (s *unitSuite) TestRefresh(c *gc.C) {
 		c.Assert(result, gc.FitsTypeOf, &params.UnitRefreshResults{})
 		*(result.(*params.UnitRefreshResults)) = params.UnitRefreshResults{
 			Results: []params.UnitRefreshResult{{
-				Life:       life.Dying,
-				Resolved:   params.ResolvedRetryHooks,
-				ProviderID: "666",
+				Life:                life.Dying,
+				Resolved:            params.ResolvedRetryHooks,
+				ProviderID:          "666",
+				HookCancelRequested: true,
 			}},
 		}
 		return nil
@@ -211,6 +212,7 @@ func (s *unitSuite) TestRefresh(c *gc.C) {
 	c.Assert(unit.Life(), gc.Equals, life.Dying)
 	c.Assert(unit.Resolved(), gc.Equals, params.ResolvedRetryHooks)
 	c.Assert(unit.Life(), gc.Equals, life.Dying)
+	c.Assert(unit.HookCancelRequested(), jc.IsTrue)
 }
 
 func (s *unitSuite) TestClearResolved(c *gc.C) {
@@ -231,6 +233,73 @@ func (s *unitSuite) TestClearResolved(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "biff")
 }
 
+func (s *unitSuite) TestClearHookCancel(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "ClearHookCancel")
+		c.Assert(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{&params.Error{Message: "biff"}}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 18}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.ClearHookCancel()
+	c.Assert(err, gc.ErrorMatches, "biff")
+}
+
+func (s *unitSuite) TestClearHookCancelOldController(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 17}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.ClearHookCancel()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *unitSuite) TestRecordHookRun(c *gc.C) {
+	run := params.HookRun{Hook: "install", ExitCode: 1, Stderr: "oops"}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "RecordHookRuns")
+		c.Assert(arg, gc.DeepEquals, params.RecordHookRunArgs{
+			Args: []params.RecordHookRunArg{{Tag: "unit-mysql-0", Run: run}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{&params.Error{Message: "biff"}}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 19}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.RecordHookRun(run)
+	c.Assert(err, gc.ErrorMatches, "biff")
+}
+
+func (s *unitSuite) TestRecordHookRunOldController(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 18}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.RecordHookRun(params.HookRun{Hook: "install"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *unitSuite) TestWatch(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		if objType == "NotifyWatcher" {
@@ -45,6 +45,7 @@ import (
 	"github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/pubsub/apiserver"
+	auditlogmsg "github.com/juju/juju/pubsub/auditlog"
 	controllermsg "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
@@ -359,6 +360,11 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		return nil, errors.Annotate(err, "unable to subscribe to restart message")
 	}
 
+	unsubscribeAuditLog, err := cfg.Hub.Subscribe(auditlogmsg.QueryTopic, srv.searchAuditLog)
+	if err != nil {
+		return nil, errors.Annotate(err, "unable to subscribe to audit log queries")
+	}
+
 	ready := make(chan struct{})
 	srv.tomb.Go(func() error {
 		defer srv.dbloggers.dispose()
@@ -366,6 +372,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		defer srv.shared.Close()
 		defer unsubscribe()
 		defer unsubscribeControllerConfig()
+		defer unsubscribeAuditLog()
 		return srv.loop(ready)
 	})
 
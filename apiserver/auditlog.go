@@ -0,0 +1,61 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/auditlog"
+	auditlogmsg "github.com/juju/juju/pubsub/auditlog"
+)
+
+// searchAuditLog handles audit log queries published by the AuditLog
+// facade on any of the controllers. It searches the audit log on this
+// machine and publishes whatever matches back to the hub.
+func (srv *Server) searchAuditLog(topic string, data auditlogmsg.Query, err error) {
+	if err != nil {
+		logger.Criticalf("programming error in %s message data: %v", topic, err)
+		return
+	}
+	results := auditlogmsg.QueryResults{
+		RequestID:    data.RequestID,
+		ControllerID: srv.tag.Id(),
+	}
+	query, err := auditQueryFromMessage(data)
+	if err == nil {
+		results.Conversations, err = auditlog.Search(srv.logDir, query)
+	}
+	if err != nil {
+		logger.Errorf("searching audit log: %v", err)
+		results.Error = err.Error()
+	}
+	if _, err := srv.shared.centralHub.Publish(auditlogmsg.QueryResultsTopic, results); err != nil {
+		logger.Errorf("publishing audit log results: %v", err)
+	}
+}
+
+func auditQueryFromMessage(data auditlogmsg.Query) (auditlog.Query, error) {
+	query := auditlog.Query{
+		User:       data.User,
+		ModelUUID:  data.ModelUUID,
+		Facade:     data.Facade,
+		Method:     data.Method,
+		ErrorsOnly: data.ErrorsOnly,
+		Limit:      data.Limit,
+	}
+	var err error
+	if data.After != "" {
+		if query.After, err = time.Parse(time.RFC3339, data.After); err != nil {
+			return query, errors.NotValidf("after time %q", data.After)
+		}
+	}
+	if data.Before != "" {
+		if query.Before, err = time.Parse(time.RFC3339, data.Before); err != nil {
+			return query, errors.NotValidf("before time %q", data.Before)
+		}
+	}
+	return query, nil
+}
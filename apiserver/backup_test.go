@@ -40,8 +40,8 @@ func (s *backupsCommonSuite) SetUpTest(c *gc.C) {
 	s.backupURL = s.server.URL + fmt.Sprintf("/model/%s/backups", s.State.ModelUUID())
 	s.fake = &backupstesting.FakeBackups{}
 	s.PatchValue(apiserver.NewBackups,
-		func(st *state.State, m *state.Model) (backups.Backups, io.Closer) {
-			return s.fake, ioutil.NopCloser(nil)
+		func(st *state.State, m *state.Model) (backups.Backups, io.Closer, error) {
+			return s.fake, ioutil.NopCloser(nil), nil
 		},
 	)
 }
@@ -193,6 +193,8 @@ func (s *backupsUploadSuite) sendValid(c *gc.C, id string) *http.Response {
 
 	// Set the metadata part.
 	s.meta = backups.NewMetadata()
+	s.meta.Encrypted = true
+	s.meta.Storage = "s3://juju-backups/prod"
 	metaResult := apiserverbackups.CreateResult(s.meta, "test-filename")
 	header := make(textproto.MIMEHeader)
 	header.Set("Content-Disposition", `form-data; name="metadata"`)
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -77,6 +78,14 @@ func newDebugLogHandler(
 //   excludeEntity -> []string - lists entity tags to exclude from the response
 //      - as with include, it may finish with a '*'
 //   excludeModule -> []string - lists logging modules to exclude from the response
+//   includeApplication -> []string - lists applications whose units and
+//      - application agents are included in the response
+//   excludeApplication -> []string - lists applications to exclude from the response
+//   includeLabel -> []string - lists labels to include in the response
+//      - a line is included if it has any of the labels
+//   excludeLabel -> []string - lists labels to exclude from the response
+//   messageRegex -> string - only lines with a message matching this
+//      - regular expression are included
 //   limit -> uint - show *at most* this many lines
 //   backlog -> uint
 //      - go back this many lines from the end before starting to filter
@@ -85,6 +94,12 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC3339 time, only lines logged at or after this time
+//      - are included
+//   endTime -> string - RFC3339 time, only lines logged before this time are
+//      - included; the request finishes once the end time has passed
+//   retained -> string - one of [true, false], if true, read the lines kept
+//      - by the model's log retention policy rather than the recent logs
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -180,16 +195,23 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	startTime          time.Time
+	endTime            time.Time
+	maxLines           uint
+	fromTheStart       bool
+	noTail             bool
+	retained           bool
+	backlog            uint
+	filterLevel        loggo.Level
+	includeEntity      []string
+	excludeEntity      []string
+	includeModule      []string
+	excludeModule      []string
+	includeApplication []string
+	excludeApplication []string
+	includeLabel       []string
+	excludeLabel       []string
+	messageRegex       string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -219,6 +241,14 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.noTail = noTail
 	}
 
+	if value := queryMap.Get("retained"); value != "" {
+		retained, err := strconv.ParseBool(value)
+		if err != nil {
+			return params, errors.Errorf("retained value %q is not a valid boolean", value)
+		}
+		params.retained = retained
+	}
+
 	if value := queryMap.Get("backlog"); value != "" {
 		num, err := strconv.ParseUint(value, 10, 64)
 		if err != nil {
@@ -245,10 +275,32 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if !params.startTime.IsZero() && !endTime.After(params.startTime) {
+			return params, errors.Errorf("end time %q is not after start time %q", value, queryMap.Get("startTime"))
+		}
+		params.endTime = endTime
+	}
+
+	if value := queryMap.Get("messageRegex"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return params, errors.Errorf("message regex %q is not valid: %v", value, err)
+		}
+		params.messageRegex = value
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
+	params.includeApplication = queryMap["includeApplication"]
+	params.excludeApplication = queryMap["excludeApplication"]
+	params.includeLabel = queryMap["includeLabel"]
+	params.excludeLabel = queryMap["excludeLabel"]
 
 	return params, nil
 }
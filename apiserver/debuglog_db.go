@@ -32,6 +32,16 @@ func handleDebugLogDBRequest(
 	stop <-chan struct{},
 ) error {
 	params := makeLogTailerParams(reqParams)
+	if !reqParams.endTime.IsZero() {
+		// There's no point waiting for new lines once the end
+		// time has passed, as they'd be filtered out anyway.
+		untilEnd := reqParams.endTime.Sub(clock.Now())
+		if untilEnd <= 0 {
+			params.NoTail = true
+		} else if untilEnd < maxDuration {
+			maxDuration = untilEnd
+		}
+	}
 	tailer, err := newLogTailer(st, params)
 	if err != nil {
 		return errors.Trace(err)
@@ -69,14 +79,21 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 	params := state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		StartTime:     reqParams.startTime,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		MinLevel:           reqParams.filterLevel,
+		NoTail:             reqParams.noTail,
+		Retained:           reqParams.retained,
+		StartTime:          reqParams.startTime,
+		EndTime:            reqParams.endTime,
+		InitialLines:       int(reqParams.backlog),
+		IncludeEntity:      reqParams.includeEntity,
+		ExcludeEntity:      reqParams.excludeEntity,
+		IncludeModule:      reqParams.includeModule,
+		ExcludeModule:      reqParams.excludeModule,
+		IncludeApplication: reqParams.includeApplication,
+		ExcludeApplication: reqParams.excludeApplication,
+		IncludeLabel:       reqParams.includeLabel,
+		ExcludeLabel:       reqParams.excludeLabel,
+		MessageRegex:       reqParams.messageRegex,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -92,6 +109,7 @@ func formatLogRecord(r *state.LogRecord) *params.LogMessage {
 		Module:    r.Module,
 		Location:  r.Location,
 		Message:   r.Message,
+		Labels:    r.Labels,
 	}
 }
 
@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -96,6 +97,136 @@ func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestParamConversionFilters(c *gc.C) {
+	t1 := s.clock.Now().Add(time.Hour)
+	reqParams := debugLogParams{
+		endTime:            t1,
+		includeApplication: []string{"foo"},
+		excludeApplication: []string{"bar"},
+		includeLabel:       []string{"http"},
+		excludeLabel:       []string{"noisy"},
+		messageRegex:       "hook .* failed",
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+
+		c.Assert(params.EndTime, gc.Equals, t1)
+		c.Assert(params.NoTail, jc.IsFalse)
+		c.Assert(params.IncludeApplication, jc.DeepEquals, []string{"foo"})
+		c.Assert(params.ExcludeApplication, jc.DeepEquals, []string{"bar"})
+		c.Assert(params.IncludeLabel, jc.DeepEquals, []string{"http"})
+		c.Assert(params.ExcludeLabel, jc.DeepEquals, []string{"noisy"})
+		c.Assert(params.MessageRegex, gc.Equals, "hook .* failed")
+
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(s.clock, s.timeout, nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestParamConversionRetained(c *gc.C) {
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+		c.Assert(params.Retained, jc.IsTrue)
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(s.clock, s.timeout, nil, debugLogParams{retained: true}, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestPastEndTimeDisablesTailing(c *gc.C) {
+	reqParams := debugLogParams{
+		endTime: s.clock.Now().Add(-time.Hour),
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+		c.Assert(params.NoTail, jc.IsTrue)
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(s.clock, s.timeout, nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestStopsAtEndTime(c *gc.C) {
+	tailer := newFakeLogTailer()
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		return tailer, nil
+	})
+
+	done := s.runRequest(debugLogParams{endTime: s.clock.Now().Add(10 * time.Second)}, nil)
+	s.assertOutput(c, []string{"ok"})
+
+	s.assertRunning(c, done, tailer)
+	c.Assert(s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+
+	// The request stops well before the maximum duration.
+	s.assertStops(c, done, tailer)
+}
+
+func (s *debugLogDBIntSuite) TestReadFilterParams(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"startTime":          {"2016-11-30T10:00:00Z"},
+		"endTime":            {"2016-11-30T11:00:00Z"},
+		"includeApplication": {"foo", "bar"},
+		"excludeApplication": {"baz"},
+		"includeLabel":       {"http"},
+		"excludeLabel":       {"noisy"},
+		"messageRegex":       {"^hook .* failed$"},
+		"retained":           {"true"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(params.endTime, gc.Equals, time.Date(2016, 11, 30, 11, 0, 0, 0, time.UTC))
+	c.Check(params.includeApplication, jc.DeepEquals, []string{"foo", "bar"})
+	c.Check(params.excludeApplication, jc.DeepEquals, []string{"baz"})
+	c.Check(params.includeLabel, jc.DeepEquals, []string{"http"})
+	c.Check(params.excludeLabel, jc.DeepEquals, []string{"noisy"})
+	c.Check(params.messageRegex, gc.Equals, "^hook .* failed$")
+	c.Check(params.retained, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestReadFilterParamsErrors(c *gc.C) {
+	for i, test := range []struct {
+		values url.Values
+		err    string
+	}{{
+		values: url.Values{"endTime": {"tomorrow"}},
+		err:    `end time "tomorrow" is not a valid time in RFC3339 format`,
+	}, {
+		values: url.Values{
+			"startTime": {"2016-11-30T11:00:00Z"},
+			"endTime":   {"2016-11-30T10:00:00Z"},
+		},
+		err: `end time "2016-11-30T10:00:00Z" is not after start time "2016-11-30T11:00:00Z"`,
+	}, {
+		values: url.Values{"messageRegex": {"("}},
+		err:    `message regex "\(" is not valid: .*`,
+	}, {
+		values: url.Values{"retained": {"maybe"}},
+		err:    `retained value "maybe" is not a valid boolean`,
+	}} {
+		c.Logf("test %d: %v", i, test.values)
+		_, err := readDebugLogParams(test.values)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	// Set up a fake log tailer with a 2 log records ready to send.
 	tailer := newFakeLogTailer()
@@ -92,7 +92,8 @@ type Context interface {
 	Presence() Presence
 
 	// Hub returns the central hub that the API server holds.
-	// At least at this stage, facades only need to publish events.
+	// Facades mostly publish events, but may also subscribe to
+	// collect responses from the other API servers.
 	Hub() Hub
 
 	// ID returns a string that should almost always be "", unless
@@ -211,4 +212,5 @@ type ModelPresence interface {
 // Hub represents the central hub that the API server has.
 type Hub interface {
 	Publish(topic string, data interface{}) (<-chan struct{}, error)
+	Subscribe(topic string, handler interface{}) (func(), error)
 }
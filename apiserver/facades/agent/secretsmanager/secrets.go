@@ -0,0 +1,483 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secretsmanager provides the facade used by unit agents to
+// create, read and share the secrets of their charms.
+package secretsmanager
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// SecretsState defines the state methods used by the facade.
+type SecretsState interface {
+	CreateSecret(*secrets.URI, state.CreateSecretParams) (*secrets.SecretMetadata, error)
+	UpdateSecret(*secrets.URI, state.UpdateSecretParams) (*secrets.SecretMetadata, error)
+	GetSecret(*secrets.URI) (*secrets.SecretMetadata, error)
+	GetSecretValue(*secrets.URI, int) (secrets.SecretValue, error)
+	ListSecrets(state.SecretsFilter) ([]*secrets.SecretMetadata, error)
+	SecretRotated(*secrets.URI, time.Time) error
+
+	GrantSecretAccess(*secrets.URI, state.SecretAccessParams) error
+	RevokeSecretAccess(*secrets.URI, state.SecretAccessParams) error
+	SecretAccess(*secrets.URI, names.Tag) (secrets.SecretRole, error)
+
+	GetSecretConsumer(*secrets.URI, names.Tag) (*state.SecretConsumerMetadata, error)
+	SaveSecretConsumer(*secrets.URI, names.Tag, *state.SecretConsumerMetadata) error
+	GetURIByConsumerLabel(string, names.Tag) (*secrets.URI, error)
+}
+
+// SecretsWatcher defines the state methods used to watch secrets.
+type SecretsWatcher interface {
+	WatchConsumedSecretsChanges(consumer names.Tag) state.StringsWatcher
+	WatchSecretsRotationChanges(owner names.Tag) state.StringsWatcher
+}
+
+// SecretsManagerAPI is the implementation of the SecretsManager facade.
+type SecretsManagerAPI struct {
+	unitTag           names.UnitTag
+	applicationTag    names.ApplicationTag
+	resources         facade.Resources
+	leadershipChecker leadership.Checker
+	secretsState      SecretsState
+	secretsWatcher    SecretsWatcher
+}
+
+// NewSecretManagerAPI creates a SecretsManagerAPI.
+func NewSecretManagerAPI(context facade.Context) (*SecretsManagerAPI, error) {
+	if !context.Auth().AuthUnitAgent() {
+		return nil, apiservererrors.ErrPerm
+	}
+	leadershipChecker, err := context.LeadershipChecker()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newSecretManagerAPI(
+		context.Auth().GetAuthTag(),
+		context.Resources(),
+		leadershipChecker,
+		state.NewSecrets(context.State()),
+		context.State(),
+	)
+}
+
+func newSecretManagerAPI(
+	authTag names.Tag,
+	resources facade.Resources,
+	leadershipChecker leadership.Checker,
+	secretsState SecretsState,
+	secretsWatcher SecretsWatcher,
+) (*SecretsManagerAPI, error) {
+	unitTag, ok := authTag.(names.UnitTag)
+	if !ok {
+		return nil, apiservererrors.ErrPerm
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SecretsManagerAPI{
+		unitTag:           unitTag,
+		applicationTag:    names.NewApplicationTag(appName),
+		resources:         resources,
+		leadershipChecker: leadershipChecker,
+		secretsState:      secretsState,
+		secretsWatcher:    secretsWatcher,
+	}, nil
+}
+
+// checkLeader returns an error if the calling unit isn't the leader of
+// its application. Secrets are owned by applications, so only the
+// leader can change them.
+func (s *SecretsManagerAPI) checkLeader() error {
+	token := s.leadershipChecker.LeadershipCheck(s.applicationTag.Id(), s.unitTag.Id())
+	if err := token.Check(0, nil); err != nil {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// ownedSecret returns the metadata of a secret owned by the calling
+// unit's application.
+func (s *SecretsManagerAPI) ownedSecret(uriStr string) (*secrets.SecretMetadata, error) {
+	uri, err := secrets.ParseURI(uriStr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	md, err := s.secretsState.GetSecret(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if md.OwnerTag != s.applicationTag.String() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return md, nil
+}
+
+// CreateSecrets creates new secrets owned by the calling unit's
+// application.
+func (s *SecretsManagerAPI) CreateSecrets(args params.CreateSecretArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		uri, err := s.createSecret(arg)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = uri.String()
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) createSecret(arg params.CreateSecretArg) (*secrets.URI, error) {
+	if arg.OwnerTag != "" && arg.OwnerTag != s.applicationTag.String() {
+		return nil, apiservererrors.ErrPerm
+	}
+	if err := s.checkLeader(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	uri := secrets.NewURI()
+	_, err := s.secretsState.CreateSecret(uri, state.CreateSecretParams{
+		Owner:        s.applicationTag,
+		Description:  arg.Description,
+		Label:        arg.Label,
+		RotatePolicy: secrets.RotatePolicy(arg.RotatePolicy),
+		Data:         arg.Data,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return uri, nil
+}
+
+// UpdateSecrets updates secrets owned by the calling unit's application.
+func (s *SecretsManagerAPI) UpdateSecrets(args params.UpdateSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		result.Results[i].Error = apiservererrors.ServerError(s.updateSecret(arg))
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) updateSecret(arg params.UpdateSecretArg) error {
+	md, err := s.ownedSecret(arg.URI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.checkLeader(); err != nil {
+		return errors.Trace(err)
+	}
+	p := state.UpdateSecretParams{
+		Description: arg.Description,
+		Label:       arg.Label,
+		Data:        arg.Data,
+	}
+	if arg.RotatePolicy != nil {
+		policy := secrets.RotatePolicy(*arg.RotatePolicy)
+		p.RotatePolicy = &policy
+	}
+	_, err = s.secretsState.UpdateSecret(md.URI, p)
+	return errors.Trace(err)
+}
+
+// GetSecretMetadata returns the metadata of the secrets owned by the
+// calling unit's application.
+func (s *SecretsManagerAPI) GetSecretMetadata() (params.ListSecretResults, error) {
+	var result params.ListSecretResults
+	owner := s.applicationTag.String()
+	mds, err := s.secretsState.ListSecrets(state.SecretsFilter{OwnerTag: &owner})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ListSecretResult, len(mds))
+	for i, md := range mds {
+		result.Results[i] = params.ListSecretResult{
+			URI:            md.URI.String(),
+			OwnerTag:       md.OwnerTag,
+			Description:    md.Description,
+			Label:          md.Label,
+			RotatePolicy:   string(md.RotatePolicy),
+			NextRotateTime: md.NextRotateTime,
+			LatestRevision: md.LatestRevision,
+			CreateTime:     md.CreateTime,
+			UpdateTime:     md.UpdateTime,
+		}
+	}
+	return result, nil
+}
+
+// GetSecretValues returns the values of secrets. The owner of a secret
+// always reads its latest revision; other units read the revision they
+// started tracking when they first read the secret, until they ask to
+// update to the latest one.
+func (s *SecretsManagerAPI) GetSecretValues(args params.GetSecretValueArgs) (params.SecretValueResults, error) {
+	result := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		value, err := s.getSecretValue(arg)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].Data = value.Values()
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) getSecretValue(arg params.GetSecretValueArg) (secrets.SecretValue, error) {
+	uri, err := s.lookupURI(arg.URI, arg.Label)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	md, err := s.secretsState.GetSecret(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if md.OwnerTag == s.applicationTag.String() {
+		return s.secretsState.GetSecretValue(uri, md.LatestRevision)
+	}
+	if err := s.checkCanRead(uri); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	consumer, err := s.secretsState.GetSecretConsumer(uri, s.unitTag)
+	if errors.IsNotFound(err) {
+		// The first read starts tracking the latest revision.
+		consumer = &state.SecretConsumerMetadata{}
+		arg.Update = true
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	revision := consumer.CurrentRevision
+	if arg.Peek || arg.Update {
+		revision = md.LatestRevision
+	}
+	save := false
+	if arg.Update && consumer.CurrentRevision != md.LatestRevision {
+		consumer.CurrentRevision = md.LatestRevision
+		save = true
+	}
+	if arg.Label != "" && arg.Label != consumer.Label {
+		consumer.Label = arg.Label
+		save = true
+	}
+	if save {
+		if err := s.secretsState.SaveSecretConsumer(uri, s.unitTag, consumer); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return s.secretsState.GetSecretValue(uri, revision)
+}
+
+// lookupURI returns the URI of a secret given either as a URI, or as the
+// label the caller gave it.
+func (s *SecretsManagerAPI) lookupURI(uriStr, label string) (*secrets.URI, error) {
+	if uriStr != "" {
+		return secrets.ParseURI(uriStr)
+	}
+	if label == "" {
+		return nil, errors.NotValidf("secret without URI or label")
+	}
+	owner := s.applicationTag.String()
+	owned, err := s.secretsState.ListSecrets(state.SecretsFilter{OwnerTag: &owner})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, md := range owned {
+		if md.Label == label {
+			return md.URI, nil
+		}
+	}
+	return s.secretsState.GetURIByConsumerLabel(label, s.unitTag)
+}
+
+// checkCanRead returns an error if neither the calling unit nor its
+// application have been granted access to the secret.
+func (s *SecretsManagerAPI) checkCanRead(uri *secrets.URI) error {
+	for _, subject := range []names.Tag{s.unitTag, s.applicationTag} {
+		role, err := s.secretsState.SecretAccess(uri, subject)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if role.Allowed(secrets.RoleView) {
+			return nil
+		}
+	}
+	return apiservererrors.ErrPerm
+}
+
+// GetLatestSecretsRevisionInfo returns the latest revisions of the
+// secrets read by the calling unit.
+func (s *SecretsManagerAPI) GetLatestSecretsRevisionInfo(args params.GetSecretConsumerInfoArgs) (params.SecretConsumerInfoResults, error) {
+	result := params.SecretConsumerInfoResults{
+		Results: make([]params.SecretConsumerInfoResult, len(args.URIs)),
+	}
+	if args.ConsumerTag != s.unitTag.String() {
+		return result, apiservererrors.ErrPerm
+	}
+	for i, uriStr := range args.URIs {
+		info, err := s.latestRevisionInfo(uriStr)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i] = info
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) latestRevisionInfo(uriStr string) (params.SecretConsumerInfoResult, error) {
+	uri, err := secrets.ParseURI(uriStr)
+	if err != nil {
+		return params.SecretConsumerInfoResult{}, errors.Trace(err)
+	}
+	consumer, err := s.secretsState.GetSecretConsumer(uri, s.unitTag)
+	if err != nil {
+		return params.SecretConsumerInfoResult{}, errors.Trace(err)
+	}
+	md, err := s.secretsState.GetSecret(uri)
+	if err != nil {
+		return params.SecretConsumerInfoResult{}, errors.Trace(err)
+	}
+	return params.SecretConsumerInfoResult{
+		Revision: md.LatestRevision,
+		Label:    consumer.Label,
+	}, nil
+}
+
+// GrantSecret gives applications or units access to secrets owned by the
+// calling unit's application.
+func (s *SecretsManagerAPI) GrantSecret(args params.GrantRevokeSecretArgs) (params.ErrorResults, error) {
+	return s.grantRevoke(args, s.secretsState.GrantSecretAccess)
+}
+
+// RevokeSecret removes the access applications or units have to secrets
+// owned by the calling unit's application.
+func (s *SecretsManagerAPI) RevokeSecret(args params.GrantRevokeSecretArgs) (params.ErrorResults, error) {
+	return s.grantRevoke(args, s.secretsState.RevokeSecretAccess)
+}
+
+func (s *SecretsManagerAPI) grantRevoke(
+	args params.GrantRevokeSecretArgs, op func(*secrets.URI, state.SecretAccessParams) error,
+) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		result.Results[i].Error = apiservererrors.ServerError(s.grantRevokeOne(arg, op))
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) grantRevokeOne(
+	arg params.GrantRevokeSecretArg, op func(*secrets.URI, state.SecretAccessParams) error,
+) error {
+	md, err := s.ownedSecret(arg.URI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.checkLeader(); err != nil {
+		return errors.Trace(err)
+	}
+	role := secrets.SecretRole(arg.Role)
+	if role == secrets.RoleNone {
+		role = secrets.RoleView
+	}
+	for _, tagStr := range arg.SubjectTags {
+		subject, err := names.ParseTag(tagStr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := op(md.URI, state.SecretAccessParams{Subject: subject, Role: role}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// SecretsRotated records when secrets owned by the calling unit's
+// application were rotated. Only the leader runs the rotate hook, so
+// only it can record a rotation.
+func (s *SecretsManagerAPI) SecretsRotated(args params.SecretRotatedArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		result.Results[i].Error = apiservererrors.ServerError(s.secretRotated(arg))
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) secretRotated(arg params.SecretRotatedArg) error {
+	md, err := s.ownedSecret(arg.URI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.checkLeader(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.secretsState.SecretRotated(md.URI, arg.When))
+}
+
+// WatchConsumedSecretsChanges returns watchers which notify of the URIs
+// of the secrets read by the units which have new revisions.
+func (s *SecretsManagerAPI) WatchConsumedSecretsChanges(args params.Entities) (params.StringsWatchResults, error) {
+	result := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		if entity.Tag != s.unitTag.String() {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		result.Results[i] = s.registerWatcher(s.secretsWatcher.WatchConsumedSecretsChanges(s.unitTag))
+	}
+	return result, nil
+}
+
+// WatchSecretsRotationChanges returns watchers which notify of the URIs
+// of the secrets owned by the applications whose rotation schedule may
+// have changed.
+func (s *SecretsManagerAPI) WatchSecretsRotationChanges(args params.Entities) (params.StringsWatchResults, error) {
+	result := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		if entity.Tag != s.applicationTag.String() {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		result.Results[i] = s.registerWatcher(s.secretsWatcher.WatchSecretsRotationChanges(s.applicationTag))
+	}
+	return result, nil
+}
+
+func (s *SecretsManagerAPI) registerWatcher(w state.StringsWatcher) params.StringsWatchResult {
+	// Consume the initial event.
+	changes, ok := <-w.Changes()
+	if !ok {
+		return params.StringsWatchResult{
+			Error: apiservererrors.ServerError(watcher.EnsureErr(w)),
+		}
+	}
+	return params.StringsWatchResult{
+		StringsWatcherId: s.resources.Register(w),
+		Changes:          changes,
+	}
+}
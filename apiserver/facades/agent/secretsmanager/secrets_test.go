@@ -0,0 +1,361 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretsmanager_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facades/agent/secretsmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type SecretsManagerSuite struct {
+	resources *common.Resources
+	state     *fakeSecretsState
+	leader    bool
+}
+
+var _ = gc.Suite(&SecretsManagerSuite{})
+
+func (s *SecretsManagerSuite) SetUpTest(c *gc.C) {
+	s.resources = common.NewResources()
+	s.state = newFakeSecretsState()
+	s.leader = true
+}
+
+func (s *SecretsManagerSuite) TearDownTest(c *gc.C) {
+	s.resources.StopAll()
+}
+
+func (s *SecretsManagerSuite) newAPI(c *gc.C, unit string) *secretsmanager.SecretsManagerAPI {
+	api, err := secretsmanager.NewTestAPI(
+		names.NewUnitTag(unit), s.resources, fakeLeadershipChecker{&s.leader}, s.state, s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *SecretsManagerSuite) TestNewAPIRequiresUnit(c *gc.C) {
+	_, err := secretsmanager.NewTestAPI(
+		names.NewMachineTag("0"), s.resources, fakeLeadershipChecker{&s.leader}, s.state, s.state)
+	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
+}
+
+func (s *SecretsManagerSuite) createSecret(c *gc.C, api *secretsmanager.SecretsManagerAPI) string {
+	results, err := api.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			Label: "password",
+			Data:  map[string]string{"password": "s3cret"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	return results.Results[0].Result
+}
+
+func (s *SecretsManagerSuite) TestCreateSecrets(c *gc.C) {
+	uri := s.createSecret(c, s.newAPI(c, "mysql/0"))
+	parsed, err := secrets.ParseURI(uri)
+	c.Assert(err, jc.ErrorIsNil)
+	md := s.state.metadata[parsed.ID]
+	c.Assert(md.OwnerTag, gc.Equals, "application-mysql")
+	c.Assert(md.Label, gc.Equals, "password")
+}
+
+func (s *SecretsManagerSuite) TestCreateSecretsNotLeader(c *gc.C) {
+	s.leader = false
+	results, err := s.newAPI(c, "mysql/1").CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{Data: map[string]string{"password": "s3cret"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, jc.DeepEquals, apiservererrors.ServerError(apiservererrors.ErrPerm))
+}
+
+func (s *SecretsManagerSuite) TestUpdateSecretsNotOwner(c *gc.C) {
+	uri := s.createSecret(c, s.newAPI(c, "mysql/0"))
+	results, err := s.newAPI(c, "wordpress/0").UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{URI: uri, Data: map[string]string{"password": "stolen"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsManagerSuite) getValue(c *gc.C, api *secretsmanager.SecretsManagerAPI, arg params.GetSecretValueArg) params.SecretValueResult {
+	results, err := api.GetSecretValues(params.GetSecretValueArgs{Args: []params.GetSecretValueArg{arg}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+func (s *SecretsManagerSuite) TestGetSecretValuesNeedsGrant(c *gc.C) {
+	uri := s.createSecret(c, s.newAPI(c, "mysql/0"))
+	result := s.getValue(c, s.newAPI(c, "wordpress/0"), params.GetSecretValueArg{URI: uri})
+	c.Assert(result.Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsManagerSuite) TestGetSecretValuesTracksRevision(c *gc.C) {
+	owner := s.newAPI(c, "mysql/0")
+	uri := s.createSecret(c, owner)
+	grant, err := owner.GrantSecret(params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{URI: uri, SubjectTags: []string{"application-wordpress"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grant.OneError(), jc.ErrorIsNil)
+
+	consumer := s.newAPI(c, "wordpress/0")
+	result := s.getValue(c, consumer, params.GetSecretValueArg{URI: uri, Label: "db"})
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Data, jc.DeepEquals, map[string]string{"password": "s3cret"})
+
+	update, err := owner.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{URI: uri, Data: map[string]string{"password": "n3w"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(update.OneError(), jc.ErrorIsNil)
+
+	// The owner reads the latest revision, the consumer the one it tracks
+	// until it peeks or updates.
+	c.Assert(s.getValue(c, owner, params.GetSecretValueArg{URI: uri}).Data["password"], gc.Equals, "n3w")
+	c.Assert(s.getValue(c, consumer, params.GetSecretValueArg{Label: "db"}).Data["password"], gc.Equals, "s3cret")
+	c.Assert(s.getValue(c, consumer, params.GetSecretValueArg{Label: "db", Peek: true}).Data["password"], gc.Equals, "n3w")
+	c.Assert(s.getValue(c, consumer, params.GetSecretValueArg{Label: "db"}).Data["password"], gc.Equals, "s3cret")
+	c.Assert(s.getValue(c, consumer, params.GetSecretValueArg{Label: "db", Update: true}).Data["password"], gc.Equals, "n3w")
+	c.Assert(s.getValue(c, consumer, params.GetSecretValueArg{Label: "db"}).Data["password"], gc.Equals, "n3w")
+
+	info, err := consumer.GetLatestSecretsRevisionInfo(params.GetSecretConsumerInfoArgs{
+		ConsumerTag: "unit-wordpress-0",
+		URIs:        []string{uri},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Results, jc.DeepEquals, []params.SecretConsumerInfoResult{{Revision: 2, Label: "db"}})
+}
+
+func (s *SecretsManagerSuite) TestRevokeSecret(c *gc.C) {
+	owner := s.newAPI(c, "mysql/0")
+	uri := s.createSecret(c, owner)
+	args := params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{URI: uri, SubjectTags: []string{"unit-wordpress-0"}}},
+	}
+	_, err := owner.GrantSecret(args)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := owner.RevokeSecret(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	value := s.getValue(c, s.newAPI(c, "wordpress/0"), params.GetSecretValueArg{URI: uri})
+	c.Assert(value.Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsManagerSuite) TestGetSecretMetadata(c *gc.C) {
+	api := s.newAPI(c, "mysql/0")
+	uri := s.createSecret(c, api)
+	result, err := api.GetSecretMetadata()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].URI, gc.Equals, uri)
+
+	result, err = s.newAPI(c, "wordpress/0").GetSecretMetadata()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 0)
+}
+
+func (s *SecretsManagerSuite) TestSecretsRotated(c *gc.C) {
+	api := s.newAPI(c, "mysql/0")
+	uri := s.createSecret(c, api)
+	when := time.Now()
+	result, err := api.SecretsRotated(params.SecretRotatedArgs{
+		Args: []params.SecretRotatedArg{{URI: uri, When: when}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	parsed, _ := secrets.ParseURI(uri)
+	c.Assert(s.state.rotated[parsed.ID], gc.Equals, when)
+}
+
+func (s *SecretsManagerSuite) TestSecretsRotatedNotLeader(c *gc.C) {
+	uri := s.createSecret(c, s.newAPI(c, "mysql/0"))
+	s.leader = false
+	result, err := s.newAPI(c, "mysql/1").SecretsRotated(params.SecretRotatedArgs{
+		Args: []params.SecretRotatedArg{{URI: uri, When: time.Now()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
+	c.Assert(s.state.rotated, gc.HasLen, 0)
+}
+
+func (s *SecretsManagerSuite) TestWatchConsumedSecretsChanges(c *gc.C) {
+	s.state.changes <- []string{"secret:9m4e2mr0ui3e8a215n4g"}
+	result, err := s.newAPI(c, "wordpress/0").WatchConsumedSecretsChanges(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-wordpress-0"}, {Tag: "unit-wordpress-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Changes, jc.DeepEquals, []string{"secret:9m4e2mr0ui3e8a215n4g"})
+	c.Assert(s.resources.Get(result.Results[0].StringsWatcherId), gc.NotNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(s.state.watched, jc.DeepEquals, []string{"unit-wordpress-0"})
+}
+
+func (s *SecretsManagerSuite) TestWatchSecretsRotationChanges(c *gc.C) {
+	s.state.changes <- []string{}
+	result, err := s.newAPI(c, "mysql/0").WatchSecretsRotationChanges(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}, {Tag: "application-wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(s.state.watched, jc.DeepEquals, []string{"application-mysql"})
+}
+
+type fakeLeadershipChecker struct {
+	leader *bool
+}
+
+func (f fakeLeadershipChecker) LeadershipCheck(_, _ string) leadership.Token {
+	return fakeToken{*f.leader}
+}
+
+type fakeToken struct {
+	leader bool
+}
+
+func (t fakeToken) Check(int, interface{}) error {
+	if !t.leader {
+		return errors.New("not the leader")
+	}
+	return nil
+}
+
+// fakeSecretsState is an in-memory implementation of the facade's state.
+type fakeSecretsState struct {
+	metadata  map[string]*secrets.SecretMetadata
+	values    map[string][]map[string]string
+	access    map[string]secrets.SecretRole
+	consumers map[string]*state.SecretConsumerMetadata
+	rotated   map[string]time.Time
+
+	changes chan []string
+	watched []string
+}
+
+func newFakeSecretsState() *fakeSecretsState {
+	return &fakeSecretsState{
+		metadata:  make(map[string]*secrets.SecretMetadata),
+		values:    make(map[string][]map[string]string),
+		access:    make(map[string]secrets.SecretRole),
+		consumers: make(map[string]*state.SecretConsumerMetadata),
+		rotated:   make(map[string]time.Time),
+		changes:   make(chan []string, 1),
+	}
+}
+
+func (f *fakeSecretsState) CreateSecret(uri *secrets.URI, p state.CreateSecretParams) (*secrets.SecretMetadata, error) {
+	f.metadata[uri.ID] = &secrets.SecretMetadata{
+		URI:            uri,
+		OwnerTag:       p.Owner.String(),
+		Label:          p.Label,
+		RotatePolicy:   p.RotatePolicy,
+		LatestRevision: 1,
+	}
+	f.values[uri.ID] = []map[string]string{p.Data}
+	return f.metadata[uri.ID], nil
+}
+
+func (f *fakeSecretsState) UpdateSecret(uri *secrets.URI, p state.UpdateSecretParams) (*secrets.SecretMetadata, error) {
+	md, err := f.GetSecret(uri)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Data) > 0 {
+		f.values[uri.ID] = append(f.values[uri.ID], p.Data)
+		md.LatestRevision++
+	}
+	return md, nil
+}
+
+func (f *fakeSecretsState) GetSecret(uri *secrets.URI) (*secrets.SecretMetadata, error) {
+	md, ok := f.metadata[uri.ID]
+	if !ok {
+		return nil, errors.NotFoundf("secret %s", uri)
+	}
+	return md, nil
+}
+
+func (f *fakeSecretsState) GetSecretValue(uri *secrets.URI, revision int) (secrets.SecretValue, error) {
+	return secrets.NewSecretValue(f.values[uri.ID][revision-1]), nil
+}
+
+func (f *fakeSecretsState) ListSecrets(filter state.SecretsFilter) ([]*secrets.SecretMetadata, error) {
+	var result []*secrets.SecretMetadata
+	for _, md := range f.metadata {
+		if filter.OwnerTag == nil || *filter.OwnerTag == md.OwnerTag {
+			result = append(result, md)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeSecretsState) SecretRotated(uri *secrets.URI, when time.Time) error {
+	f.rotated[uri.ID] = when
+	return nil
+}
+
+func (f *fakeSecretsState) GrantSecretAccess(uri *secrets.URI, p state.SecretAccessParams) error {
+	f.access[uri.ID+p.Subject.String()] = p.Role
+	return nil
+}
+
+func (f *fakeSecretsState) RevokeSecretAccess(uri *secrets.URI, p state.SecretAccessParams) error {
+	delete(f.access, uri.ID+p.Subject.String())
+	return nil
+}
+
+func (f *fakeSecretsState) SecretAccess(uri *secrets.URI, subject names.Tag) (secrets.SecretRole, error) {
+	return f.access[uri.ID+subject.String()], nil
+}
+
+func (f *fakeSecretsState) GetSecretConsumer(uri *secrets.URI, consumer names.Tag) (*state.SecretConsumerMetadata, error) {
+	md, ok := f.consumers[uri.ID+consumer.String()]
+	if !ok {
+		return nil, errors.NotFoundf("consumer")
+	}
+	copied := *md
+	return &copied, nil
+}
+
+func (f *fakeSecretsState) SaveSecretConsumer(uri *secrets.URI, consumer names.Tag, md *state.SecretConsumerMetadata) error {
+	copied := *md
+	f.consumers[uri.ID+consumer.String()] = &copied
+	return nil
+}
+
+func (f *fakeSecretsState) GetURIByConsumerLabel(label string, consumer names.Tag) (*secrets.URI, error) {
+	for id := range f.metadata {
+		if md, ok := f.consumers[id+consumer.String()]; ok && md.Label == label {
+			return &secrets.URI{ID: id}, nil
+		}
+	}
+	return nil, errors.NotFoundf("secret with label %q", label)
+}
+
+func (f *fakeSecretsState) WatchConsumedSecretsChanges(consumer names.Tag) state.StringsWatcher {
+	f.watched = append(f.watched, consumer.String())
+	return statetesting.NewMockStringsWatcher(f.changes)
+}
+
+func (f *fakeSecretsState) WatchSecretsRotationChanges(owner names.Tag) state.StringsWatcher {
+	f.watched = append(f.watched, owner.String())
+	return statetesting.NewMockStringsWatcher(f.changes)
+}
@@ -42,9 +42,8 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 // TODO (manadart 2020-10-21): Remove the ModelUUID method
 // from the next version of this facade.
 
-// UniterAPI implements the latest version (v17) of the Uniter API, which
-// augments the payload of the CommitHookChanges API call and introduces
-// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+// UniterAPI implements the latest version (v19) of the Uniter API, which
+// introduces the RecordHookRuns call.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -83,9 +82,23 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV18 implements version (v18) of the Uniter API, which
+// reports whether cancellation of a unit's hook has been requested and
+// introduces the ClearHookCancel call.
+type UniterAPIV18 struct {
+	UniterAPI
+}
+
+// UniterAPIV17 implements version (v17) of the Uniter API, which
+// augments the payload of the CommitHookChanges API call and introduces
+// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+type UniterAPIV17 struct {
+	UniterAPIV18
+}
+
 // UniterAPIV16 implements version (v16) of the Uniter API.
 type UniterAPIV16 struct {
-	UniterAPI
+	UniterAPIV17
 }
 
 // NewUniterAPI creates a new instance of the core Uniter API.
@@ -181,11 +194,33 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 // NewUniterAPIV16 creates an instance of the V16 uniter API.
 // Deprecated: V16 of the uniter facade retained to allow upgrading from 2.8.9 (LTS).
 func NewUniterAPIV16(context facade.Context) (*UniterAPIV16, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV17(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV16{
+		UniterAPIV17: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV17 creates an instance of the V17 uniter API.
+func NewUniterAPIV17(context facade.Context) (*UniterAPIV17, error) {
+	uniterAPI, err := NewUniterAPIV18(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV17{
+		UniterAPIV18: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV18 creates an instance of the V18 uniter API.
+func NewUniterAPIV18(context facade.Context) (*UniterAPIV18, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV18{
 		UniterAPI: *uniterAPI,
 	}, nil
 }
@@ -509,6 +544,77 @@ func (u *UniterAPI) ClearResolved(args params.Entities) (params.ErrorResults, er
 	return result, nil
 }
 
+// ClearHookCancel removes any request to cancel the hook each given
+// unit is running.
+func (u *UniterAPI) ClearHookCancel(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		err = apiservererrors.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				err = unit.ClearHookCancel()
+			}
+		}
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+// ClearHookCancel isn't on the v17 API.
+func (u *UniterAPIV17) ClearHookCancel(_, _ struct{}) {}
+
+// RecordHookRuns adds the hook runs to the hook history of each
+// given unit.
+func (u *UniterAPI) RecordHookRuns(args params.RecordHookRunArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		err = apiservererrors.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				err = unit.RecordHookRun(state.HookRun{
+					Hook:     arg.Run.Hook,
+					Relation: arg.Run.Relation,
+					Started:  arg.Run.Started,
+					Finished: arg.Run.Finished,
+					ExitCode: arg.Run.ExitCode,
+					Stderr:   arg.Run.Stderr,
+				})
+			}
+		}
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+// RecordHookRuns isn't on the v18 API.
+func (u *UniterAPIV18) RecordHookRuns(_, _ struct{}) {}
+
 // GetPrincipal returns the result of calling PrincipalName() and
 // converting it to a tag, on each given unit.
 func (u *UniterAPI) GetPrincipal(args params.Entities) (params.StringBoolResults, error) {
@@ -1257,6 +1363,7 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 			if unit, err = u.getUnit(tag); err == nil {
 				result.Results[i].Life = life.Value(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				result.Results[i].HookCancelRequested = unit.HookCancelRequested()
 
 				var err1 error
 				result.Results[i].ProviderID, err1 = u.getProviderID(unit)
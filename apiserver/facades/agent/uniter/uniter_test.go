@@ -733,6 +733,65 @@ func (s *uniterSuite) TestClearResolved(c *gc.C) {
 	c.Assert(mode, gc.Equals, state.ResolvedNone)
 }
 
+func (s *uniterSuite) TestClearHookCancel(c *gc.C) {
+	err := s.wordpressUnit.RequestHookCancel()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.ClearHookCancel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.HookCancelRequested(), jc.IsFalse)
+}
+
+func (s *uniterSuite) TestRecordHookRuns(c *gc.C) {
+	started := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	run := params.HookRun{
+		Hook:     "config-changed",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		ExitCode: 1,
+		Stderr:   "oops",
+	}
+	args := params.RecordHookRunArgs{Args: []params.RecordHookRunArg{
+		{Tag: "unit-mysql-0", Run: run},
+		{Tag: "unit-wordpress-0", Run: run},
+		{Tag: "unit-foo-42", Run: run},
+	}}
+	result, err := s.uniter.RecordHookRuns(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	history, err := s.wordpressUnit.HookHistory(state.HookHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookRun{{
+		Hook:     "config-changed",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		ExitCode: 1,
+		Stderr:   "oops",
+	}})
+}
+
 func (s *uniterSuite) TestGetPrincipal(c *gc.C) {
 	// Add a subordinate to wordpressUnit.
 	_, _, subordinate := s.addRelatedApplication(c, "wordpress", "logging", s.wordpressUnit)
@@ -3745,6 +3804,22 @@ func (s *uniterSuite) TestRefresh(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, expect)
 }
 
+func (s *uniterSuite) TestRefreshHookCancelRequested(c *gc.C) {
+	err := s.wordpressUnit.RequestHookCancel()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{s.wordpressUnit.Tag().String()}}}
+	results, err := s.uniter.Refresh(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.UnitRefreshResults{
+		Results: []params.UnitRefreshResult{{
+			Life:                life.Alive,
+			Resolved:            params.ResolvedNone,
+			HookCancelRequested: true,
+		}},
+	})
+}
+
 func (s *uniterSuite) TestRefreshNoArgs(c *gc.C) {
 	results, err := s.uniter.Refresh(params.Entities{Entities: []params.Entity{}})
 	c.Assert(err, jc.ErrorIsNil)
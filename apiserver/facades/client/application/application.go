@@ -45,6 +45,7 @@ import (
 	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/timers"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/feature"
@@ -60,6 +61,18 @@ var logger = loggo.GetLogger("juju.apiserver.application")
 
 // APIv13 provides the Application API facade for version 13.
 type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14. It adds
+// CancelHook.
+type APIv14 struct {
+	*APIv15
+}
+
+// APIv15 provides the Application API facade for version 15. It adds
+// HookHistory.
+type APIv15 struct {
 	*APIBase
 }
 
@@ -93,13 +106,29 @@ type APIBase struct {
 }
 
 func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV14(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv13{api}, nil
 }
 
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := NewFacadeV15(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
+func NewFacadeV15(ctx facade.Context) (*APIv15, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv15{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
@@ -2295,6 +2324,94 @@ func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResul
 	return result, nil
 }
 
+// CancelHook asks the agents of the specified units to interrupt the
+// hooks they are running.
+func (api *APIBase) CancelHook(args params.Entities) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		err = unit.RequestHookCancel()
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+// CancelHook isn't on the v13 API.
+func (*APIv13) CancelHook(_, _ struct{}) {}
+
+// HookHistory returns the hooks run by the specified units which match
+// the supplied filters, most recent first.
+func (api *APIBase) HookHistory(args params.HookHistoryArgs) (params.HookHistoryResults, error) {
+	var result params.HookHistoryResults
+	if err := api.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Results = make([]params.HookHistoryResult, len(args.Args))
+	for i, arg := range args.Args {
+		runs, err := api.hookHistory(arg)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].Runs = runs
+	}
+	return result, nil
+}
+
+func (api *APIBase) hookHistory(arg params.HookHistoryArg) ([]params.HookRun, error) {
+	tag, err := names.ParseUnitTag(arg.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	runs, err := unit.HookHistory(state.HookHistoryFilter{
+		Hook:       arg.Filter.Hook,
+		Relation:   arg.Filter.Relation,
+		Since:      arg.Filter.Since,
+		FailedOnly: arg.Filter.FailedOnly,
+		Size:       arg.Filter.Size,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.HookRun, len(runs))
+	for i, run := range runs {
+		result[i] = params.HookRun{
+			Hook:     run.Hook,
+			Relation: run.Relation,
+			Started:  run.Started,
+			Finished: run.Finished,
+			ExitCode: run.ExitCode,
+			Stderr:   run.Stderr,
+		}
+	}
+	return result, nil
+}
+
+// HookHistory isn't on the v14 API.
+func (*APIv14) HookHistory(_, _ struct{}) {}
+
 // ApplicationsInfo returns applications information.
 func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfoResults, error) {
 	// Get all the space infos before iterating over the application infos.
@@ -2614,12 +2731,45 @@ func (api *APIBase) UnitsInfo(in params.Entities) (params.UnitInfoResults, error
 			out[i].Error = apiservererrors.ServerError(err)
 			continue
 		}
+		result.Timers, err = unitTimers(unit)
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
 
 		out[i].Result = result
 	}
 	return params.UnitInfoResults{out}, nil
 }
 
+// unitTimers returns the timers scheduled by the unit's charm, which
+// the unit agent records in its operation state.
+func unitTimers(unit Unit) (map[string]params.UnitTimer, error) {
+	uniterState, err := unit.UniterState()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	charmTimers, err := timers.ParseUniterState(uniterState)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(charmTimers) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]params.UnitTimer)
+	for name, t := range charmTimers {
+		result[name] = params.UnitTimer{
+			Next:   t.Next,
+			Every:  t.Every,
+			Jitter: t.Jitter,
+		}
+	}
+	return result, nil
+}
+
 // openPortsOnMachineForUnit returns the unique set of opened ports for the
 // specified unit and machine arguments without distinguishing between port
 // ranges across subnets. This method is provided for backwards compatibility
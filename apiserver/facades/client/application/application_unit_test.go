@@ -61,7 +61,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv13
+	api          *application.APIv15
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -92,7 +92,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv13{api}
+	s.api = &application.APIv15{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -1963,6 +1963,81 @@ func (s *ApplicationSuite) TestResolveUnitErrorsPermissionDenied(c *gc.C) {
 	s.application.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestCancelHook(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-postgresql-0"},
+		{Tag: "application-postgresql"},
+	}}
+	result, err := s.api.CancelHook(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.CheckCallNames(c, "RequestHookCancel")
+}
+
+func (s *ApplicationSuite) TestBlockCancelHook(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.CancelHook(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ApplicationSuite) TestCancelHookPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.CancelHook(params.Entities{Entities: []params.Entity{{Tag: "unit-postgresql-0"}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestHookHistory(c *gc.C) {
+	started := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.hookRuns = []state.HookRun{{
+		Hook:     "db-relation-changed",
+		Relation: "db:2",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		ExitCode: 1,
+		Stderr:   "oops",
+	}}
+
+	filter := params.HookHistoryFilter{Hook: "db-relation-changed", FailedOnly: true, Size: 5}
+	result, err := s.api.HookHistory(params.HookHistoryArgs{Args: []params.HookHistoryArg{
+		{Tag: "unit-postgresql-0", Filter: filter},
+		{Tag: "application-postgresql"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0], jc.DeepEquals, params.HookHistoryResult{
+		Runs: []params.HookRun{{
+			Hook:     "db-relation-changed",
+			Relation: "db:2",
+			Started:  started,
+			Finished: started.Add(time.Second),
+			ExitCode: 1,
+			Stderr:   "oops",
+		}},
+	})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+	unit.CheckCall(c, 0, "HookHistory", state.HookHistoryFilter{
+		Hook:       "db-relation-changed",
+		FailedOnly: true,
+		Size:       5,
+	})
+}
+
+func (s *ApplicationSuite) TestHookHistoryPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.HookHistory(params.HookHistoryArgs{Args: []params.HookHistoryArg{{Tag: "unit-postgresql-0"}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestCAASExposeWithoutHostname(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	err := s.api.Expose(params.ApplicationExpose{
@@ -2116,6 +2191,14 @@ func (s *ApplicationSuite) TestApplicationMergeBindingsErr(c *gc.C) {
 
 func (s *ApplicationSuite) TestUnitsInfo(c *gc.C) {
 	s.backend.machines = map[string]*mockMachine{"0": {}}
+	s.backend.applications["postgresql"].units[0].uniterState = `
+timers:
+  backup:
+    due: 2021-06-01T12:00:00Z
+    next: 2021-06-01T12:00:30Z
+    every: 1h0m0s
+    jitter: 1m0s
+`
 
 	entities := []params.Entity{{Tag: "unit-postgresql-0"}, {"unit-mysql-0"}}
 	result, err := s.api.UnitsInfo(params.Entities{entities})
@@ -2142,6 +2225,13 @@ func (s *ApplicationSuite) TestUnitsInfo(c *gc.C) {
 				},
 			},
 		}},
+		Timers: map[string]params.UnitTimer{
+			"backup": {
+				Next:   time.Date(2021, 6, 1, 12, 0, 30, 0, time.UTC),
+				Every:  time.Hour,
+				Jitter: time.Minute,
+			},
+		},
 		ProviderId: "provider-id",
 		Address:    "192.168.1.1",
 	})
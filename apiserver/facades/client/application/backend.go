@@ -171,6 +171,8 @@ type Unit interface {
 	IsPrincipal() bool
 	Life() state.Life
 	Resolve(retryHooks bool) error
+	RequestHookCancel() error
+	HookHistory(state.HookHistoryFilter) ([]state.HookRun, error)
 	AgentTools() (*tools.Tools, error)
 
 	AssignedMachineId() (string, error)
@@ -178,6 +180,7 @@ type Unit interface {
 	AssignWithPolicy(state.AssignmentPolicy) error
 	AssignWithPlacement(*instance.Placement) error
 	ContainerInfo() (state.CloudContainer, error)
+	UniterState() (string, error)
 }
 
 // Model defines a subset of the functionality provided by the
@@ -510,6 +513,17 @@ func (u stateUnitShim) AssignWithPlacement(placement *instance.Placement) error
 	return u.st.AssignUnitWithPlacement(u.Unit, placement)
 }
 
+// UniterState returns the operation state the unit agent has stored
+// on the controller, or an empty string if it hasn't stored any.
+func (u stateUnitShim) UniterState() (string, error) {
+	unitState, err := u.Unit.State()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	uniterState, _ := unitState.UniterState()
+	return uniterState, nil
+}
+
 type Subnet interface {
 	CIDR() string
 	VLANTag() int
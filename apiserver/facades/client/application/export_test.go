@@ -19,6 +19,6 @@ func GetModel(m *state.Model) Model {
 	return modelShim{m}
 }
 
-func SetModelType(api *APIv13, modelType state.ModelType) {
+func SetModelType(api *APIv15, modelType state.ModelType) {
 	api.modelType = modelType
 }
@@ -943,10 +943,12 @@ func (m mockRelationUnit) Settings() (map[string]interface{}, error) {
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag        names.UnitTag
-	machineId  string
-	name       string
-	agentTools *tools.Tools
+	tag         names.UnitTag
+	machineId   string
+	name        string
+	agentTools  *tools.Tools
+	uniterState string
+	hookRuns    []state.HookRun
 }
 
 func (u *mockUnit) Tag() names.Tag {
@@ -983,6 +985,16 @@ func (u *mockUnit) Resolve(retryHooks bool) error {
 	return u.NextErr()
 }
 
+func (u *mockUnit) RequestHookCancel() error {
+	u.MethodCall(u, "RequestHookCancel")
+	return u.NextErr()
+}
+
+func (u *mockUnit) HookHistory(filter state.HookHistoryFilter) ([]state.HookRun, error) {
+	u.MethodCall(u, "HookHistory", filter)
+	return u.hookRuns, u.NextErr()
+}
+
 func (u *mockUnit) AssignedMachineId() (string, error) {
 	u.MethodCall(u, "AssignedMachineId")
 	return u.machineId, u.NextErr()
@@ -1008,6 +1020,11 @@ func (u *mockUnit) ContainerInfo() (state.CloudContainer, error) {
 	return mockCloudContainer{}, nil
 }
 
+func (u *mockUnit) UniterState() (string, error) {
+	u.MethodCall(u, "UniterState")
+	return u.uniterState, u.NextErr()
+}
+
 func (u *mockUnit) AgentTools() (*tools.Tools, error) {
 	u.MethodCall(u, "AgentTools")
 	return u.agentTools, u.NextErr()
@@ -0,0 +1,225 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	"github.com/juju/utils/v2"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/permission"
+	auditlogmsg "github.com/juju/juju/pubsub/auditlog"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.auditlog")
+
+// QueryTimeout is how long the facade waits for all of the controller
+// nodes to respond to a query before returning what it has.
+const QueryTimeout = 30 * time.Second
+
+// Backend defines the state methods the facade needs.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ControllerIds() ([]string, error)
+}
+
+// API implements the AuditLog facade, which searches the audit logs
+// written by each of the controller nodes.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	hub        facade.Hub
+	clock      clock.Clock
+	cancel     <-chan struct{}
+}
+
+// NewFacade creates a new AuditLog facade from the facade context.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(ctx.State(), ctx.Auth(), ctx.Hub(), clock.WallClock, ctx.Cancel())
+}
+
+// NewAPI returns a new AuditLog facade. Only controller superusers
+// can query the audit log.
+func NewAPI(
+	backend Backend,
+	authorizer facade.Authorizer,
+	hub facade.Hub,
+	clock clock.Clock,
+	cancel <-chan struct{},
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		hub:        hub,
+		clock:      clock,
+		cancel:     cancel,
+	}, nil
+}
+
+// Query searches the audit logs on all of the controller nodes and
+// returns the matching conversations, oldest first. Controller nodes
+// that don't respond in time are reported as unavailable rather than
+// failing the whole query.
+func (api *API) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResult, error) {
+	var result params.AuditLogQueryResult
+	controllerIDs, err := api.backend.ControllerIds()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	requestID := utils.MustNewUUID().String()
+
+	responses := make(chan auditlogmsg.QueryResults, len(controllerIDs))
+	unsubscribe, err := api.hub.Subscribe(
+		auditlogmsg.QueryResultsTopic,
+		func(topic string, data auditlogmsg.QueryResults, err error) {
+			if err != nil {
+				logger.Criticalf("programming error in %s message data: %v", topic, err)
+				return
+			}
+			if data.RequestID != requestID {
+				return
+			}
+			select {
+			case responses <- data:
+			default:
+				logger.Warningf("dropping unexpected audit log results from controller %q", data.ControllerID)
+			}
+		})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer unsubscribe()
+
+	if _, err := api.hub.Publish(auditlogmsg.QueryTopic, queryMessage(requestID, args)); err != nil {
+		return result, errors.Annotate(err, "publishing audit log query")
+	}
+
+	pending := set.NewStrings(controllerIDs...)
+	timeout := api.clock.After(QueryTimeout)
+	for !pending.IsEmpty() {
+		select {
+		case response := <-responses:
+			if !pending.Contains(response.ControllerID) {
+				continue
+			}
+			pending.Remove(response.ControllerID)
+			if response.Error != "" {
+				result.Unavailable = append(result.Unavailable, params.AuditLogUnavailable{
+					ControllerID: response.ControllerID,
+					Reason:       response.Error,
+				})
+				continue
+			}
+			for _, entry := range response.Conversations {
+				result.Conversations = append(result.Conversations, conversationResult(response.ControllerID, entry))
+			}
+		case <-timeout:
+			for _, id := range pending.SortedValues() {
+				result.Unavailable = append(result.Unavailable, params.AuditLogUnavailable{
+					ControllerID: id,
+					Reason:       "timed out waiting for results",
+				})
+			}
+			pending = set.NewStrings()
+		case <-api.cancel:
+			return result, errors.New("audit log query cancelled")
+		}
+	}
+
+	sort.SliceStable(result.Conversations, func(i, j int) bool {
+		return result.Conversations[i].When.Before(result.Conversations[j].When)
+	})
+	if args.Limit > 0 && len(result.Conversations) > args.Limit {
+		result.Conversations = result.Conversations[len(result.Conversations)-args.Limit:]
+	}
+	sort.Slice(result.Unavailable, func(i, j int) bool {
+		return result.Unavailable[i].ControllerID < result.Unavailable[j].ControllerID
+	})
+	return result, nil
+}
+
+func queryMessage(requestID string, args params.AuditLogQueryArgs) auditlogmsg.Query {
+	msg := auditlogmsg.Query{
+		RequestID:  requestID,
+		User:       args.User,
+		ModelUUID:  args.ModelUUID,
+		Facade:     args.Facade,
+		Method:     args.Method,
+		ErrorsOnly: args.ErrorsOnly,
+		Limit:      args.Limit,
+	}
+	if args.After != nil {
+		msg.After = args.After.UTC().Format(time.RFC3339)
+	}
+	if args.Before != nil {
+		msg.Before = args.Before.UTC().Format(time.RFC3339)
+	}
+	return msg
+}
+
+func conversationResult(controllerID string, entry auditlog.ConversationEntry) params.AuditLogConversation {
+	c := entry.Conversation
+	result := params.AuditLogConversation{
+		ControllerID:   controllerID,
+		ConversationID: c.ConversationID,
+		ConnectionID:   c.ConnectionID,
+		Who:            c.Who,
+		What:           c.What,
+		When:           parseTime(c.When),
+		ModelName:      c.ModelName,
+		ModelUUID:      c.ModelUUID,
+	}
+	for _, r := range entry.Requests {
+		request := params.AuditLogRequest{
+			RequestID: r.Request.RequestID,
+			When:      parseTime(r.Request.When),
+			Facade:    r.Request.Facade,
+			Method:    r.Request.Method,
+			Version:   r.Request.Version,
+			Args:      r.Request.Args,
+		}
+		for _, err := range r.Errors {
+			if err == nil {
+				continue
+			}
+			request.Errors = append(request.Errors, params.AuditLogError{
+				Message: err.Message,
+				Code:    err.Code,
+			})
+		}
+		result.Requests = append(result.Requests, request)
+	}
+	return result
+}
+
+// parseTime converts the RFC3339 times written to the audit log. Badly
+// formatted times are left as the zero time rather than losing the
+// entry.
+func parseTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
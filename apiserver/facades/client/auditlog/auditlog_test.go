@@ -0,0 +1,208 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/names/v4"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	auditlogmsg "github.com/juju/juju/pubsub/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	testing.IsolationSuite
+
+	backend    *fakeBackend
+	authorizer apiservertesting.FakeAuthorizer
+	hub        *pubsub.StructuredHub
+	clock      *testclock.Clock
+	queries    chan auditlogmsg.Query
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{controllerIDs: []string{"0", "1"}}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser-bob"),
+	}
+	s.hub = pubsub.NewStructuredHub(nil)
+	s.clock = testclock.NewClock(time.Now())
+	s.queries = make(chan auditlogmsg.Query, 10)
+	unsubscribe, err := s.hub.Subscribe(auditlogmsg.QueryTopic, func(topic string, data auditlogmsg.Query, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		s.queries <- data
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { unsubscribe() })
+}
+
+func (s *auditLogSuite) newAPI(c *gc.C) *auditlog.API {
+	api, err := auditlog.NewAPI(s.backend, s.authorizer, s.hub, s.clock, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+// respond publishes results for the next query as if they came from
+// the given controller node.
+func (s *auditLogSuite) respond(c *gc.C, controllerID string, results auditlogmsg.QueryResults) auditlogmsg.Query {
+	var query auditlogmsg.Query
+	select {
+	case query = <-s.queries:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for query")
+	}
+	results.RequestID = query.RequestID
+	results.ControllerID = controllerID
+	_, err := s.hub.Publish(auditlogmsg.QueryResultsTopic, results)
+	c.Assert(err, jc.ErrorIsNil)
+	s.queries <- query
+	return query
+}
+
+func conversation(id, when string, errs ...*coreauditlog.Error) coreauditlog.ConversationEntry {
+	return coreauditlog.ConversationEntry{
+		Conversation: coreauditlog.Conversation{
+			Who:            "bob",
+			What:           "juju deploy",
+			When:           when,
+			ModelUUID:      "model-uuid",
+			ConversationID: id,
+		},
+		Requests: []coreauditlog.RequestEntry{{
+			Request: coreauditlog.Request{
+				ConversationID: id,
+				RequestID:      1,
+				When:           when,
+				Facade:         "Application",
+				Method:         "Deploy",
+				Version:        13,
+			},
+			Errors: errs,
+		}},
+	}
+}
+
+func (s *auditLogSuite) TestNotSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read-bob")
+	_, err := auditlog.NewAPI(s.backend, s.authorizer, s.hub, s.clock, nil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *auditLogSuite) TestNotClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(s.backend, s.authorizer, s.hub, s.clock, nil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *auditLogSuite) TestQueryMergesControllerResults(c *gc.C) {
+	api := s.newAPI(c)
+	after := time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	done := make(chan params.AuditLogQueryResult)
+	go func() {
+		result, err := api.Query(params.AuditLogQueryArgs{
+			User:       "bob",
+			After:      &after,
+			ErrorsOnly: true,
+		})
+		c.Check(err, jc.ErrorIsNil)
+		done <- result
+	}()
+
+	query := s.respond(c, "1", auditlogmsg.QueryResults{
+		Conversations: []coreauditlog.ConversationEntry{
+			conversation("c2", "2021-03-01T11:00:00Z", nil, &coreauditlog.Error{Message: "boom", Code: "bad"}),
+		},
+	})
+	c.Assert(query.User, gc.Equals, "bob")
+	c.Assert(query.After, gc.Equals, "2021-03-01T09:00:00Z")
+	c.Assert(query.ErrorsOnly, jc.IsTrue)
+	s.respond(c, "0", auditlogmsg.QueryResults{
+		Conversations: []coreauditlog.ConversationEntry{
+			conversation("c1", "2021-03-01T10:00:00Z", &coreauditlog.Error{Message: "oops"}),
+		},
+	})
+
+	var result params.AuditLogQueryResult
+	select {
+	case result = <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for query results")
+	}
+	c.Assert(result.Unavailable, gc.HasLen, 0)
+	c.Assert(result.Conversations, gc.HasLen, 2)
+	c.Assert(result.Conversations[0].ConversationID, gc.Equals, "c1")
+	c.Assert(result.Conversations[0].ControllerID, gc.Equals, "0")
+	c.Assert(result.Conversations[1].ConversationID, gc.Equals, "c2")
+	c.Assert(result.Conversations[1].ControllerID, gc.Equals, "1")
+	c.Assert(result.Conversations[1].When, gc.Equals, time.Date(2021, 3, 1, 11, 0, 0, 0, time.UTC))
+	c.Assert(result.Conversations[1].Requests, jc.DeepEquals, []params.AuditLogRequest{{
+		RequestID: 1,
+		When:      time.Date(2021, 3, 1, 11, 0, 0, 0, time.UTC),
+		Facade:    "Application",
+		Method:    "Deploy",
+		Version:   13,
+		Errors:    []params.AuditLogError{{Message: "boom", Code: "bad"}},
+	}})
+}
+
+func (s *auditLogSuite) TestQueryReportsUnavailableControllers(c *gc.C) {
+	s.backend.controllerIDs = []string{"0", "1", "2"}
+	api := s.newAPI(c)
+
+	done := make(chan params.AuditLogQueryResult)
+	go func() {
+		result, err := api.Query(params.AuditLogQueryArgs{Limit: 1})
+		c.Check(err, jc.ErrorIsNil)
+		done <- result
+	}()
+
+	s.respond(c, "0", auditlogmsg.QueryResults{
+		Conversations: []coreauditlog.ConversationEntry{
+			conversation("c1", "2021-03-01T10:00:00Z"),
+			conversation("c3", "2021-03-01T12:00:00Z"),
+		},
+	})
+	s.respond(c, "1", auditlogmsg.QueryResults{Error: "disk on fire"})
+	err := s.clock.WaitAdvance(auditlog.QueryTimeout, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var result params.AuditLogQueryResult
+	select {
+	case result = <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for query results")
+	}
+	c.Assert(result.Conversations, gc.HasLen, 1)
+	c.Assert(result.Conversations[0].ConversationID, gc.Equals, "c3")
+	c.Assert(result.Unavailable, jc.DeepEquals, []params.AuditLogUnavailable{
+		{ControllerID: "1", Reason: "disk on fire"},
+		{ControllerID: "2", Reason: "timed out waiting for results"},
+	})
+}
+
+type fakeBackend struct {
+	controllerIDs []string
+}
+
+func (b *fakeBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *fakeBackend) ControllerIds() ([]string, error) {
+	return b.controllerIDs, nil
+}
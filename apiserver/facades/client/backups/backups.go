@@ -6,6 +6,7 @@ package backups
 import (
 	"io"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/mgo/v2"
 	"github.com/juju/names/v4"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
@@ -34,19 +36,27 @@ type Backend interface {
 	ControllerConfig() (controller.Config, error)
 	StateServingInfo() (controller.StateServingInfo, error)
 	ControllerNodes() ([]state.ControllerNode, error)
+	BackupStatusHistory(status.StatusHistoryFilter) ([]status.StatusInfo, error)
 }
 
 // API provides backup-specific API methods.
 type API struct {
 	backend Backend
 	paths   *backups.Paths
+	clock   clock.Clock
 
 	// machineID is the ID of the machine where the API server is running.
 	machineID string
 }
 
+// APIv3 provides the Backups API facade version 3, which has no
+// Schedule method.
+type APIv3 struct {
+	*API
+}
+
 // NewAPI creates a new instance of the Backups API facade.
-func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer, clock clock.Clock) (*API, error) {
 	isControllerAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
 	if err != nil && !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
@@ -95,6 +105,7 @@ func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Autho
 	b := API{
 		backend:   backend,
 		paths:     &paths,
+		clock:     clock,
 		machineID: machineID,
 	}
 	return &b, nil
@@ -113,9 +124,8 @@ func extractResourceValue(resources facade.Resources, key string) (string, error
 	return strRes.String(), nil
 }
 
-var newBackups = func(backend Backend) (backups.Backups, io.Closer) {
-	stor := backups.NewStorage(backend)
-	return backups.NewBackups(stor), stor
+var newBackups = func(backend Backend) (backups.Backups, io.Closer, error) {
+	return backups.NewControllerBackups(backend)
 }
 
 // CreateResult updates the result with the information in the
@@ -149,6 +159,8 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 	result.HANodes = meta.Controller.HANodes
 	result.ControllerMachineID = meta.Controller.MachineID
 	result.ControllerMachineInstanceID = meta.Controller.MachineInstanceID
+	result.Encrypted = meta.Encrypted
+	result.Storage = meta.Storage
 	result.Filename = filename
 
 	return result
@@ -176,6 +188,8 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 		MachineInstanceID: result.ControllerMachineInstanceID,
 		HANodes:           result.HANodes,
 	}
+	meta.Encrypted = result.Encrypted
+	meta.Storage = result.Storage
 	_ = meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
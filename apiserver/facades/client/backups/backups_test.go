@@ -6,7 +6,9 @@ package backups_test
 import (
 	"io"
 	"io/ioutil"
+	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
@@ -30,6 +32,7 @@ type backupsSuite struct {
 	api        *backupsAPI.API
 	meta       *backups.Metadata
 	machineTag names.MachineTag
+	clock      *testclock.Clock
 }
 
 var _ = gc.Suite(&backupsSuite{})
@@ -64,7 +67,8 @@ func (s *backupsSuite) SetUpTest(c *gc.C) {
 		controllerNodesF: func() ([]state.ControllerNode, error) { return nil, nil },
 		machineF:         func(id string) (backupsAPI.Machine, error) { return &testMachine{}, nil },
 	}
-	s.api, err = backupsAPI.NewAPI(shim, s.resources, s.authorizer)
+	s.clock = testclock.NewClock(time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC))
+	s.api, err = backupsAPI.NewAPI(shim, s.resources, s.authorizer, s.clock)
 	c.Assert(err, jc.ErrorIsNil)
 	s.meta = backupstesting.NewMetadataStarted()
 }
@@ -81,21 +85,21 @@ func (s *backupsSuite) setBackups(c *gc.C, meta *backups.Metadata, err string) *
 		fake.Error = errors.Errorf(err)
 	}
 	s.PatchValue(backupsAPI.NewBackups,
-		func(backupsAPI.Backend) (backups.Backups, io.Closer) {
-			return &fake, ioutil.NopCloser(nil)
+		func(backupsAPI.Backend) (backups.Backups, io.Closer, error) {
+			return &fake, ioutil.NopCloser(nil), nil
 		},
 	)
 	return &fake
 }
 
 func (s *backupsSuite) TestNewAPIOkay(c *gc.C) {
-	_, err := backupsAPI.NewAPI(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	_, err := backupsAPI.NewAPI(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer, s.clock)
 	c.Check(err, jc.ErrorIsNil)
 }
 
 func (s *backupsSuite) TestNewAPINotAuthorized(c *gc.C) {
 	s.authorizer.Tag = names.NewApplicationTag("eggs")
-	_, err := backupsAPI.NewAPI(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	_, err := backupsAPI.NewAPI(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer, s.clock)
 	c.Check(errors.Cause(err), gc.Equals, apiservererrors.ErrPerm)
 }
 
@@ -104,7 +108,7 @@ func (s *backupsSuite) TestNewAPIHostedEnvironmentFails(c *gc.C) {
 	defer otherState.Close()
 	otherModel, err := otherState.Model()
 	c.Assert(err, jc.ErrorIsNil)
-	_, err = backupsAPI.NewAPI(&stateShim{State: otherState, Model: otherModel}, s.resources, s.authorizer)
+	_, err = backupsAPI.NewAPI(&stateShim{State: otherState, Model: otherModel}, s.resources, s.authorizer, s.clock)
 	c.Check(err, gc.ErrorMatches, "backups are only supported from the controller model\nUse juju switch to select the controller model")
 }
 
@@ -115,6 +119,6 @@ func (s *backupsSuite) TestBackupsCAASFails(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	isController := true
-	_, err = backupsAPI.NewAPI(&stateShim{State: otherState, Model: otherModel, isController: &isController}, s.resources, s.authorizer)
+	_, err = backupsAPI.NewAPI(&stateShim{State: otherState, Model: otherModel, isController: &isController}, s.resources, s.authorizer, s.clock)
 	c.Assert(err, gc.ErrorMatches, "backups on kubernetes controllers not supported")
 }
@@ -16,7 +16,10 @@ var waitUntilReady = replicaset.WaitUntilReady
 // Create is the API method that requests juju to create a new backup
 // of its state.
 func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
-	backupsMethods, closer := newBackups(a.backend)
+	backupsMethods, closer, err := newBackups(a.backend)
+	if err != nil {
+		return params.BackupsMetadataResult{}, errors.Trace(err)
+	}
 	defer closer.Close()
 
 	session := a.backend.MongoSession().Copy()
@@ -24,7 +27,7 @@ func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResul
 
 	result := params.BackupsMetadataResult{}
 	// Don't go if HA isn't ready.
-	err := waitUntilReady(session, 60)
+	err = waitUntilReady(session, 60)
 	if err != nil {
 		return result, errors.Annotatef(err, "HA not ready; try again later")
 	}
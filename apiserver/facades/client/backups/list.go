@@ -13,7 +13,10 @@ import (
 func (a *API) List(args params.BackupsListArgs) (params.BackupsListResult, error) {
 	var result params.BackupsListResult
 
-	backups, closer := newBackups(a.backend)
+	backups, closer, err := newBackups(a.backend)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
 	defer closer.Close()
 
 	metaList, err := backups.List()
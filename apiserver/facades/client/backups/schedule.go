@@ -0,0 +1,53 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/core/status"
+)
+
+// Schedule returns the schedule and retention policy for backups
+// created by the controller, along with the outcome of the most
+// recent scheduled backup.
+func (a *API) Schedule() (params.BackupsScheduleResult, error) {
+	cfg, err := a.backend.ControllerConfig()
+	if err != nil {
+		return params.BackupsScheduleResult{}, errors.Trace(err)
+	}
+	result := params.BackupsScheduleResult{
+		Schedule:   cfg.BackupSchedule(),
+		KeepDaily:  cfg.BackupKeepDaily(),
+		KeepWeekly: cfg.BackupKeepWeekly(),
+	}
+	if result.Schedule != "" {
+		schedule, err := cron.Parse(result.Schedule)
+		if err != nil {
+			return params.BackupsScheduleResult{}, errors.Trace(err)
+		}
+		next := schedule.Next(a.clock.Now())
+		result.Next = &next
+	}
+
+	history, err := a.backend.BackupStatusHistory(status.StatusHistoryFilter{Size: 1})
+	if err != nil && !errors.IsNotFound(err) {
+		return params.BackupsScheduleResult{}, errors.Trace(err)
+	}
+	if len(history) > 0 {
+		last := history[0]
+		result.LastResult = &params.EntityStatus{
+			Status: last.Status,
+			Info:   last.Message,
+			Data:   last.Data,
+			Since:  last.Since,
+		}
+	}
+	return result, nil
+}
+
+// Schedule isn't on the v3 API.
+func (*APIv3) Schedule(_, _ struct{}) {}
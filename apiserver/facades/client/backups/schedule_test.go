@@ -0,0 +1,50 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+func (s *backupsSuite) TestScheduleDisabled(c *gc.C) {
+	result, err := s.api.Schedule()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.BackupsScheduleResult{
+		KeepDaily:  7,
+		KeepWeekly: 4,
+	})
+}
+
+func (s *backupsSuite) TestSchedule(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"backup-schedule":   "30 2 * * *",
+		"backup-keep-daily": 3,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	since := time.Date(2021, 3, 1, 2, 30, 0, 0, time.UTC)
+	err = s.State.RecordBackupStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "while creating backup archive: boom",
+		Since:   &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.Schedule()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Schedule, gc.Equals, "30 2 * * *")
+	c.Check(result.KeepDaily, gc.Equals, 3)
+	c.Check(result.KeepWeekly, gc.Equals, 4)
+	c.Assert(result.Next, gc.NotNil)
+	c.Check(*result.Next, gc.Equals, time.Date(2021, 3, 2, 2, 30, 0, 0, time.UTC))
+	c.Assert(result.LastResult, gc.NotNil)
+	c.Check(result.LastResult.Status, gc.Equals, status.Error)
+	c.Check(result.LastResult.Info, gc.Equals, "while creating backup archive: boom")
+	c.Check(result.LastResult.Since.Equal(since), jc.IsTrue)
+}
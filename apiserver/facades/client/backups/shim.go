@@ -4,6 +4,7 @@
 package backups
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -35,12 +36,21 @@ func (s *stateShim) MachineSeries(id string) (string, error) {
 }
 
 // NewFacadeV3 provides the required signature for facade registration.
-func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	api, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
+// NewFacadeV4 provides the required signature for facade registration.
+func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	model, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return NewAPI(&stateShim{st, model}, resources, authorizer)
+	return NewAPI(&stateShim{st, model}, resources, authorizer, clock.WallClock)
 }
 
 // ControllerTag disambiguates the ControllerTag method pending further
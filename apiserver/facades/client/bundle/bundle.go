@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/bundlechanges/v5"
 	"github.com/juju/charm/v9"
+	charmresource "github.com/juju/charm/v9/resource"
 	"github.com/juju/collections/set"
 	"github.com/juju/description/v2"
 	"github.com/juju/errors"
@@ -59,6 +60,14 @@ type APIv4 struct {
 	*BundleAPI
 }
 
+// APIv5 provides the Bundle API facade for version 5. It is otherwise
+// identical to V4 with the exception that the V5 ExportBundle can export a
+// complete bundle, reporting the parts of the model which can't be
+// represented.
+type APIv5 struct {
+	*BundleAPI
+}
+
 // BundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
 type BundleAPI struct {
@@ -108,6 +117,16 @@ func NewFacadeV4(ctx facade.Context) (*APIv4, error) {
 	return &APIv4{api}, nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration
+// for version 5.
+func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api}, nil
+}
+
 // NewFacade provides the required signature for facade registration.
 func newFacade(ctx facade.Context) (*BundleAPI, error) {
 	authorizer := ctx.Auth()
@@ -355,41 +374,57 @@ func getChangesMapArgs(
 
 // ExportBundle exports the current model configuration as bundle.
 func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
-	fail := func(failErr error) (params.StringResult, error) {
-		return params.StringResult{}, apiservererrors.ServerError(failErr)
+	output, _, err := b.exportBundle(false)
+	if err != nil {
+		return params.StringResult{}, apiservererrors.ServerError(err)
 	}
+	return params.StringResult{Result: output}, nil
+}
 
+// ExportBundle exports the current model configuration as bundle. If a
+// complete bundle is requested, everything needed to reproduce the model
+// is exported, and the parts which can't be represented are returned as
+// warnings.
+func (b *APIv5) ExportBundle(args params.ExportBundleParams) (params.ExportBundleResult, error) {
+	output, warnings, err := b.exportBundle(args.Complete)
+	if err != nil {
+		return params.ExportBundleResult{}, apiservererrors.ServerError(err)
+	}
+	return params.ExportBundleResult{
+		Result:   output,
+		Warnings: warnings,
+	}, nil
+}
+
+func (b *BundleAPI) exportBundle(complete bool) (string, []string, error) {
 	if err := b.checkCanRead(); err != nil {
-		return fail(err)
+		return "", nil, errors.Trace(err)
 	}
 
 	exportConfig := b.backend.GetExportConfig()
 	model, err := b.backend.ExportPartial(exportConfig)
 	if err != nil {
-		return fail(err)
+		return "", nil, errors.Trace(err)
 	}
 
 	// Fill it in charm.BundleData data structure.
-	bundleData, err := b.fillBundleData(model)
+	bundleData, warnings, err := b.fillBundleData(model, complete)
 	if err != nil {
-		return fail(err)
+		return "", nil, errors.Trace(err)
 	}
 
 	// Split the bundle into a base and overlay bundle and encode as a
 	// yaml multi-doc.
 	base, overlay, err := charm.ExtractBaseAndOverlayParts(bundleData)
 	if err != nil {
-		return fail(err)
+		return "", nil, errors.Trace(err)
 	}
 
 	// First create a bundle output from the bundle data.
 	var buf bytes.Buffer
 	enc := yaml.NewEncoder(&buf)
-	if err != nil {
-		return fail(err)
-	}
 	if err = enc.Encode(bundleOutputFromBundleData(base)); err != nil {
-		return fail(err)
+		return "", nil, errors.Trace(err)
 	}
 
 	// Secondly create an output from the overlay. We do it this way, so we can
@@ -397,9 +432,9 @@ func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
 	output := buf.String()
 	buf.Reset()
 	if err = enc.Encode(overlay); err != nil {
-		return fail(err)
+		return "", nil, errors.Trace(err)
 	} else if err = enc.Close(); err != nil {
-		return fail(err)
+		return "", nil, errors.Trace(err)
 	}
 	overlayOutput := buf.String()
 
@@ -413,11 +448,11 @@ func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
 			overlayOutput = strings.Replace(overlayOutput, "---", "--- # overlay.yaml", 1)
 			output += overlayOutput
 		} else {
-			return fail(errors.Errorf("expected yaml encoder to delineate multiple documents with \"---\" separator"))
+			return "", nil, errors.Errorf("expected yaml encoder to delineate multiple documents with \"---\" separator")
 		}
 	}
 
-	return params.StringResult{Result: output}, nil
+	return output, warnings, nil
 }
 
 // bundleOutput has the same top level keys as the charm.BundleData
@@ -449,7 +484,7 @@ func bundleOutputFromBundleData(bd *charm.BundleData) *bundleOutput {
 // Mask the new method from V1 API.
 func (u *APIv1) ExportBundle() (_, _ struct{}) { return }
 
-func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData, error) {
+func (b *BundleAPI) fillBundleData(model description.Model, complete bool) (*charm.BundleData, []string, error) {
 	cfg := model.Config()
 	value, ok := cfg["default-series"]
 	if !ok {
@@ -472,15 +507,21 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 	}
 
 	if len(model.Applications()) == 0 {
-		return nil, errors.Errorf("nothing to export as there are no applications")
+		return nil, nil, errors.Errorf("nothing to export as there are no applications")
 	}
 
 	allSpacesInfoLookup, err := b.backend.AllSpaceInfos()
 	if err != nil {
-		return nil, errors.Annotate(err, "unable to retrieve all space information")
+		return nil, nil, errors.Annotate(err, "unable to retrieve all space information")
 	}
 
 	printEndpointBindingSpaceNames := b.printSpaceNamesInEndpointBindings(model.Applications())
+	var warnings []string
+	if complete {
+		// Bundles have no place for model config, so it has to be
+		// carried over to the new model separately.
+		warnings = append(warnings, `model config is not exported and must be set with "juju model-config" before deploying the bundle`)
+	}
 	machineIds := set.NewStrings()
 	usedSeries := set.NewStrings()
 	for _, application := range model.Applications() {
@@ -490,7 +531,7 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 
 		endpointsWithSpaceNames, err := b.endpointBindings(application.EndpointBindings(), allSpacesInfoLookup, printEndpointBindingSpaceNames)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 
 		// For security purposes we do not allow both the expose flag
@@ -501,7 +542,7 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 		// made accessible from 0.0.0.0/0.
 		exposedEndpoints, err := mapExposedEndpoints(application.ExposedEndpoints(), allSpacesInfoLookup)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 		exposedFlag := application.Exposed() && len(exposedEndpoints) == 0
 
@@ -510,7 +551,7 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 		// representation, in that only the application name should be rendered.
 		curl, err := charm.ParseURL(application.CharmURL())
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 
 		charmURL := application.CharmURL()
@@ -590,6 +631,14 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 			newApplication.RequiresTrust = appConfig[appFacade.TrustConfigOptionName] == true
 		}
 
+		if complete {
+			appWarnings, err := b.fillCompleteApplication(application, curl, newApplication)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			warnings = append(warnings, appWarnings...)
+		}
+
 		// Populate offer list
 		if offerList := application.Offers(); offerList != nil {
 			newApplication.Offers = make(map[string]*charm.OfferSpec)
@@ -630,7 +679,15 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 		data.Machines[machine.Id()] = newMachine
 	}
 
+	consumers := set.NewStrings()
 	for _, application := range model.RemoteApplications() {
+		// The consumers of offers made from this model live in other
+		// models, so they can't be deployed as part of the bundle.
+		if complete && application.IsConsumerProxy() {
+			consumers.Add(application.Name())
+			warnings = append(warnings, fmt.Sprintf("offer consumer %q and its relations are not exported as it is deployed in another model", application.Name()))
+			continue
+		}
 		newSaas := &charm.SaasSpec{
 			URL: application.URL(),
 		}
@@ -664,20 +721,100 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 	}
 
 	for _, relation := range model.Relations() {
-		var endpointRelation []string
+		var (
+			endpointRelation []string
+			consumed         bool
+		)
 		for _, endpoint := range relation.Endpoints() {
 			// skipping the 'peer' role which is not of concern in exporting the current model configuration.
 			if endpoint.Role() == "peer" {
 				continue
 			}
+			consumed = consumed || consumers.Contains(endpoint.ApplicationName())
 			endpointRelation = append(endpointRelation, endpoint.ApplicationName()+":"+endpoint.Name())
 		}
-		if len(endpointRelation) != 0 {
+		if len(endpointRelation) != 0 && !consumed {
 			data.Relations = append(data.Relations, endpointRelation)
 		}
 	}
 
-	return data, nil
+	return data, warnings, nil
+}
+
+// fillCompleteApplication adds the storage directives, device constraints
+// and resource revisions of an application to its bundle spec, which are
+// only exported in a complete bundle. Any part of the application which
+// can't be represented in the bundle is returned as a warning.
+func (b *BundleAPI) fillCompleteApplication(
+	application description.Application, curl *charm.URL, spec *charm.ApplicationSpec,
+) ([]string, error) {
+	var warnings []string
+	appName := application.Name()
+
+	switch {
+	case curl.Schema == "local":
+		warnings = append(warnings, fmt.Sprintf("application %q uses local charm %q which must be made available to deploy the bundle", appName, application.CharmURL()))
+	case charm.CharmHub.Matches(curl.Schema) && curl.Revision >= 0:
+		warnings = append(warnings, fmt.Sprintf("application %q charm revision %d can't be pinned, deploying the bundle uses the latest revision in its channel", appName, curl.Revision))
+	}
+
+	if storageCons := application.StorageConstraints(); len(storageCons) > 0 {
+		spec.Storage = make(map[string]string, len(storageCons))
+		for name, cons := range storageCons {
+			spec.Storage[name] = storageDirective(cons)
+		}
+	}
+
+	deviceCons, err := b.backend.DeviceConstraints(appName)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting device constraints for application %q", appName)
+	}
+	if len(deviceCons) > 0 {
+		spec.Devices = make(map[string]string, len(deviceCons))
+		for name, cons := range deviceCons {
+			spec.Devices[name] = deviceDirective(cons)
+		}
+	}
+
+	for _, res := range application.Resources() {
+		revision := res.ApplicationRevision()
+		switch revision.Origin() {
+		case charmresource.OriginStore.String():
+			if spec.Resources == nil {
+				spec.Resources = make(map[string]interface{})
+			}
+			spec.Resources[res.Name()] = revision.Revision()
+		case charmresource.OriginUpload.String():
+			warnings = append(warnings, fmt.Sprintf("resource %q of application %q was uploaded and must be supplied to deploy the bundle", res.Name(), appName))
+		}
+	}
+	return warnings, nil
+}
+
+// storageDirective returns the storage constraints in the form accepted
+// by the storage section of a bundle application.
+func storageDirective(cons description.StorageConstraint) string {
+	var fields []string
+	if cons.Pool() != "" {
+		fields = append(fields, cons.Pool())
+	}
+	fields = append(fields, strconv.FormatUint(cons.Count(), 10), fmt.Sprintf("%dM", cons.Size()))
+	return strings.Join(fields, ",")
+}
+
+// deviceDirective returns the device constraints in the form accepted
+// by the devices section of a bundle application.
+func deviceDirective(cons state.DeviceConstraints) string {
+	directive := fmt.Sprintf("%d,%s", cons.Count, cons.Type)
+	if len(cons.Attributes) == 0 {
+		return directive
+	}
+	attrs := make([]string, 0, len(cons.Attributes))
+	for key, value := range cons.Attributes {
+		attrs = append(attrs, key+"="+value)
+	}
+	sort.Strings(attrs)
+	return directive + "," + strings.Join(attrs, ";")
 }
 
 // mapExposedEndpoints converts the description package representation of the
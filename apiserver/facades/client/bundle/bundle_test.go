@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/juju/charm/v9"
 	"github.com/juju/description/v2"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
@@ -18,6 +19,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -60,6 +62,11 @@ func (s *bundleSuite) makeAPIv1(c *gc.C) *bundle.APIv1 {
 	return &bundle.APIv1{api}
 }
 
+func (s *bundleSuite) makeAPIv5(c *gc.C) *bundle.APIv5 {
+	api := s.makeAPI(c)
+	return &bundle.APIv5{api.BundleAPI}
+}
+
 func (s *bundleSuite) TestGetChangesBundleContentError(c *gc.C) {
 	args := params.BundleChangesParams{
 		BundleDataYAML: ":",
@@ -2059,3 +2066,133 @@ applications:
 		c.Assert(result, gc.Equals, exp)
 	}
 }
+
+func (s *bundleSuite) TestExportCompleteBundle(c *gc.C) {
+	s.st.model = description.NewModel(description.ModelArgs{Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name": "awesome",
+			"uuid": "some-uuid",
+		},
+		CloudRegion: "some-region"})
+
+	appArgs := s.minimalApplicationArgs(description.IAAS)
+	appArgs.StorageConstraints = map[string]description.StorageConstraintArgs{
+		"data": {Pool: "ebs", Size: 10240, Count: 2},
+	}
+	app := s.st.model.AddApplication(appArgs)
+	app.SetStatus(minimalStatusArgs())
+	storeResource := app.AddResource(description.ResourceArgs{Name: "store-resource"})
+	storeResource.SetApplicationRevision(description.ResourceRevisionArgs{
+		Revision: 3,
+		Type:     "file",
+		Origin:   "store",
+	})
+	uploadResource := app.AddResource(description.ResourceArgs{Name: "upload-resource"})
+	uploadResource.SetApplicationRevision(description.ResourceRevisionArgs{
+		Type:   "file",
+		Origin: "upload",
+	})
+
+	u := app.AddUnit(minimalUnitArgs(app.Type()))
+	u.SetAgentStatus(minimalStatusArgs())
+
+	s.st.devices = map[string]map[string]state.DeviceConstraints{
+		"ubuntu": {
+			"bitcoinminer": {
+				Type:       "nvidia.com/gpu",
+				Count:      2,
+				Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
+			},
+		},
+	}
+
+	remoteApp := s.st.model.AddRemoteApplication(description.RemoteApplicationArgs{
+		Tag:             names.NewApplicationTag("consumer"),
+		IsConsumerProxy: true,
+	})
+	remoteApp.SetStatus(minimalStatusArgs())
+	rel := s.st.model.AddRelation(description.RelationArgs{
+		Id:  1,
+		Key: "consumer:info ubuntu:juju-info",
+	})
+	rel.AddEndpoint(description.EndpointArgs{ApplicationName: "ubuntu", Name: "juju-info"})
+	rel.AddEndpoint(description.EndpointArgs{ApplicationName: "consumer", Name: "info"})
+
+	s.st.model.SetStatus(description.StatusArgs{Value: "available"})
+
+	api := s.makeAPIv5(c)
+	result, err := api.ExportBundle(params.ExportBundleParams{Complete: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Warnings, jc.DeepEquals, []string{
+		`model config is not exported and must be set with "juju model-config" before deploying the bundle`,
+		`resource "upload-resource" of application "ubuntu" was uploaded and must be supplied to deploy the bundle`,
+		`offer consumer "consumer" and its relations are not exported as it is deployed in another model`,
+	})
+
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Saas, gc.HasLen, 0)
+	c.Assert(data.Relations, gc.HasLen, 0)
+	spec := data.Applications["ubuntu"]
+	c.Assert(spec, gc.NotNil)
+	c.Check(spec.Storage, jc.DeepEquals, map[string]string{"data": "ebs,2,10240M"})
+	c.Check(spec.Devices, jc.DeepEquals, map[string]string{"bitcoinminer": "2,nvidia.com/gpu,gpu=nvidia-tesla-p100"})
+	c.Check(spec.Resources, jc.DeepEquals, map[string]interface{}{"store-resource": 3})
+	s.st.CheckCall(c, 1, "DeviceConstraints", "ubuntu")
+}
+
+func (s *bundleSuite) TestExportCompleteBundleWarnsAboutCharms(c *gc.C) {
+	s.st.model = description.NewModel(description.ModelArgs{Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name": "awesome",
+			"uuid": "some-uuid",
+		},
+		CloudRegion: "some-region"})
+
+	appArgs := s.minimalApplicationArgs(description.IAAS)
+	appArgs.CharmURL = "local:trusty/ubuntu-1"
+	app := s.st.model.AddApplication(appArgs)
+	app.SetStatus(minimalStatusArgs())
+
+	appArgs = s.minimalApplicationArgs(description.IAAS)
+	appArgs.Tag = names.NewApplicationTag("postgresql")
+	appArgs.CharmURL = "ch:postgresql-42"
+	app = s.st.model.AddApplication(appArgs)
+	app.SetStatus(minimalStatusArgs())
+
+	s.st.model.SetStatus(description.StatusArgs{Value: "available"})
+
+	api := s.makeAPIv5(c)
+	result, err := api.ExportBundle(params.ExportBundleParams{Complete: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Warnings, jc.SameContents, []string{
+		`model config is not exported and must be set with "juju model-config" before deploying the bundle`,
+		`application "ubuntu" uses local charm "local:trusty/ubuntu-1" which must be made available to deploy the bundle`,
+		`application "postgresql" charm revision 42 can't be pinned, deploying the bundle uses the latest revision in its channel`,
+	})
+}
+
+func (s *bundleSuite) TestExportBundleNotCompleteByDefault(c *gc.C) {
+	s.st.model = description.NewModel(description.ModelArgs{Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name": "awesome",
+			"uuid": "some-uuid",
+		},
+		CloudRegion: "some-region"})
+
+	appArgs := s.minimalApplicationArgs(description.IAAS)
+	appArgs.StorageConstraints = map[string]description.StorageConstraintArgs{
+		"data": {Pool: "ebs", Size: 10240, Count: 2},
+	}
+	app := s.st.model.AddApplication(appArgs)
+	app.SetStatus(minimalStatusArgs())
+
+	s.st.model.SetStatus(description.StatusArgs{Value: "available"})
+
+	api := s.makeAPIv5(c)
+	result, err := api.ExportBundle(params.ExportBundleParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Warnings, gc.HasLen, 0)
+	c.Assert(result.Result, gc.Not(jc.Contains), "storage:")
+	s.st.CheckCallNames(c, "ExportPartial")
+}
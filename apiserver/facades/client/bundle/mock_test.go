@@ -15,8 +15,9 @@ import (
 type mockState struct {
 	testing.Stub
 	bundle.Backend
-	model  description.Model
-	Spaces map[string]string
+	model   description.Model
+	Spaces  map[string]string
+	devices map[string]map[string]state.DeviceConstraints
 }
 
 func (m *mockState) ExportPartial(config state.ExportConfig) (description.Model, error) {
@@ -40,6 +41,14 @@ func (m *mockState) GetExportConfig() state.ExportConfig {
 	}
 }
 
+func (m *mockState) DeviceConstraints(application string) (map[string]state.DeviceConstraints, error) {
+	m.MethodCall(m, "DeviceConstraints", application)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.devices[application], nil
+}
+
 func (m *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
 	result := make(network.SpaceInfos, len(m.Spaces))
 	i := 0
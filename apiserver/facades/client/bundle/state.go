@@ -5,12 +5,15 @@ package bundle
 
 import (
 	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/state"
 )
 
 type Backend interface {
 	ExportPartial(cfg state.ExportConfig) (description.Model, error)
 	GetExportConfig() state.ExportConfig
+	DeviceConstraints(application string) (map[string]state.DeviceConstraints, error)
 	state.EndpointBinding
 }
 
@@ -37,6 +40,15 @@ func (m *stateShim) GetExportConfig() state.ExportConfig {
 	return cfg
 }
 
+// DeviceConstraints implements Backend.DeviceConstraints.
+func (m *stateShim) DeviceConstraints(application string) (map[string]state.DeviceConstraints, error) {
+	app, err := m.State.Application(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.DeviceConstraints()
+}
+
 // NewStateShim creates new state shim to be used by bundle Facade.
 func NewStateShim(st *state.State) Backend {
 	return &stateShim{st}
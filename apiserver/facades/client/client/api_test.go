@@ -324,6 +324,7 @@ var scenarioStatus = &params.FullStatus{
 				Status: "joining",
 				Info:   "",
 			},
+			UnitCount: 2,
 		},
 	},
 	Branches: map[string]params.BranchStatus{},
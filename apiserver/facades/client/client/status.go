@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/statusquery"
 	"github.com/juju/juju/state"
 )
 
@@ -408,7 +409,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err != nil {
 		return noStatus, errors.Annotate(err, "cannot determine model status")
 	}
-	return params.FullStatus{
+	fullStatus := params.FullStatus{
 		Model:               modelStatus,
 		Machines:            context.processMachines(),
 		Applications:        context.processApplications(),
@@ -417,7 +418,19 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		Relations:           context.processRelations(),
 		ControllerTimestamp: context.controllerTimestamp,
 		Branches:            context.processBranches(),
-	}, nil
+	}
+	if args.Query == "" {
+		return fullStatus, nil
+	}
+	filter, err := statusquery.Parse(args.Query)
+	if err != nil {
+		return noStatus, errors.NewNotValid(err, "")
+	}
+	filtered, err := filter.Apply(&fullStatus)
+	if err != nil {
+		return noStatus, errors.Trace(err)
+	}
+	return *filtered, nil
 }
 
 func filterBranches(ctxBranches map[string]cache.Branch, matchedApps, matchedForBranches set.Strings) map[string]cache.Branch {
@@ -1123,6 +1136,7 @@ func (context *statusContext) processRelations() []params.RelationStatus {
 			Interface: relationInterface,
 			Scope:     string(scope),
 			Endpoints: eps,
+			UnitCount: relation.UnitCount(),
 		}
 		rStatus, err := relation.Status()
 		populateStatusFromStatusInfoAndErr(&relStatus.Status, rStatus, err)
@@ -4,6 +4,7 @@
 package client_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -108,6 +109,33 @@ func (s *statusSuite) TestFullStatusUnitLeadership(c *gc.C) {
 	c.Assert(unit.Leader, jc.IsTrue)
 }
 
+func (s *statusSuite) TestFullStatusQuery(c *gc.C) {
+	u := s.Factory.MakeUnit(c, nil)
+	s.addMachine(c)
+	client := s.APIState.Client()
+
+	status, err := client.StatusWithQuery(nil, fmt.Sprintf("name == %q", u.Name()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Applications, gc.HasLen, 1)
+	c.Check(status.Applications[u.ApplicationName()].Units, gc.HasLen, 1)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Machines, gc.HasLen, 1)
+	c.Check(status.Machines[machineId].Id, gc.Equals, machineId)
+
+	status, err = client.StatusWithQuery(nil, `workload-status == "error"`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Applications, gc.HasLen, 0)
+	c.Check(status.Machines, gc.HasLen, 0)
+}
+
+func (s *statusSuite) TestFullStatusQueryInvalid(c *gc.C) {
+	s.Factory.MakeUnit(c, nil)
+	client := s.APIState.Client()
+	_, err := client.StatusWithQuery(nil, "workload-staus == 1")
+	c.Assert(err, gc.ErrorMatches, `running query "workload-staus == 1" on unit .*: Runtime Error: identifier "workload-staus" not found on Unit: invalid identifier`)
+}
+
 func (s *statusSuite) TestFullStatusUnitScaling(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, nil)
 	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
@@ -0,0 +1,104 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the facade used by model users to inspect the
+// secrets created by charms.
+package secrets
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+)
+
+// SecretsState defines the state methods used by the facade.
+type SecretsState interface {
+	ListSecrets(state.SecretsFilter) ([]*coresecrets.SecretMetadata, error)
+	GetSecretValue(*coresecrets.URI, int) (coresecrets.SecretValue, error)
+}
+
+// SecretsAPI is the implementation of the Secrets facade.
+type SecretsAPI struct {
+	authorizer   facade.Authorizer
+	modelTag     names.ModelTag
+	secretsState SecretsState
+}
+
+// NewSecretsAPI creates a SecretsAPI.
+func NewSecretsAPI(context facade.Context) (*SecretsAPI, error) {
+	return newSecretsAPI(
+		context.Auth(),
+		names.NewModelTag(context.State().ModelUUID()),
+		state.NewSecrets(context.State()),
+	)
+}
+
+func newSecretsAPI(authorizer facade.Authorizer, modelTag names.ModelTag, secretsState SecretsState) (*SecretsAPI, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &SecretsAPI{
+		authorizer:   authorizer,
+		modelTag:     modelTag,
+		secretsState: secretsState,
+	}, nil
+}
+
+func (s *SecretsAPI) checkPermission(perm permission.Access) error {
+	allowed, err := s.authorizer.HasPermission(perm, s.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// ListSecrets lists the secrets in the model. Reading the values of the
+// secrets requires admin access to the model.
+func (s *SecretsAPI) ListSecrets(arg params.ListSecretsArgs) (params.ListSecretResults, error) {
+	result := params.ListSecretResults{}
+	perm := permission.ReadAccess
+	if arg.ShowSecrets {
+		perm = permission.AdminAccess
+	}
+	if err := s.checkPermission(perm); err != nil {
+		return result, errors.Trace(err)
+	}
+	mds, err := s.secretsState.ListSecrets(state.SecretsFilter{})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ListSecretResult, len(mds))
+	for i, md := range mds {
+		secretResult := params.ListSecretResult{
+			URI:            md.URI.String(),
+			OwnerTag:       md.OwnerTag,
+			Description:    md.Description,
+			Label:          md.Label,
+			RotatePolicy:   string(md.RotatePolicy),
+			NextRotateTime: md.NextRotateTime,
+			LatestRevision: md.LatestRevision,
+			CreateTime:     md.CreateTime,
+			UpdateTime:     md.UpdateTime,
+		}
+		if arg.ShowSecrets {
+			secretResult.Value = &params.SecretValueResult{}
+			value, err := s.secretsState.GetSecretValue(md.URI, md.LatestRevision)
+			if err != nil {
+				secretResult.Value.Error = apiservererrors.ServerError(err)
+			} else {
+				secretResult.Value.Data = value.Values()
+			}
+		}
+		result.Results[i] = secretResult
+	}
+	return result, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facades/client/secrets"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	uri *coresecrets.URI
+	now time.Time
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.uri = coresecrets.NewURI()
+	s.now = time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *SecretsSuite) newAPI(c *gc.C, user string) *secrets.SecretsAPI {
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag(user),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	api, err := secrets.NewTestAPI(authorizer, coretesting.ModelTag, fakeSecretsState{s.uri, s.now})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *SecretsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := secrets.NewTestAPI(apiservertesting.FakeAuthorizer{
+		Tag: names.NewUnitTag("mysql/0"),
+	}, coretesting.ModelTag, fakeSecretsState{})
+	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	result, err := s.newAPI(c, "read").ListSecrets(params.ListSecretsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ListSecretResult{{
+		URI:            s.uri.String(),
+		OwnerTag:       "application-mysql",
+		Label:          "password",
+		RotatePolicy:   "never",
+		LatestRevision: 2,
+		CreateTime:     s.now,
+		UpdateTime:     s.now,
+	}})
+}
+
+func (s *SecretsSuite) TestListSecretsShowSecretsNeedsAdmin(c *gc.C) {
+	_, err := s.newAPI(c, "read").ListSecrets(params.ListSecretsArgs{ShowSecrets: true})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsSuite) TestListSecretsShowSecrets(c *gc.C) {
+	result, err := s.newAPI(c, "admin").ListSecrets(params.ListSecretsArgs{ShowSecrets: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Value, jc.DeepEquals, &params.SecretValueResult{
+		Data: map[string]string{"password": "revision-2"},
+	})
+}
+
+type fakeSecretsState struct {
+	uri *coresecrets.URI
+	now time.Time
+}
+
+func (f fakeSecretsState) ListSecrets(state.SecretsFilter) ([]*coresecrets.SecretMetadata, error) {
+	return []*coresecrets.SecretMetadata{{
+		URI:            f.uri,
+		OwnerTag:       "application-mysql",
+		Label:          "password",
+		RotatePolicy:   coresecrets.RotateNever,
+		LatestRevision: 2,
+		CreateTime:     f.now,
+		UpdateTime:     f.now,
+	}}, nil
+}
+
+func (f fakeSecretsState) GetSecretValue(_ *coresecrets.URI, revision int) (coresecrets.SecretValue, error) {
+	return coresecrets.NewSecretValue(map[string]string{
+		"password": fmt.Sprintf("revision-%d", revision),
+	}), nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logpruner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.logpruner")
+
+// API is the concrete implementation of the LogPruner endpoint.
+type API struct {
+	*common.ModelWatcher
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns an API Instance.
+func NewAPI(ctx facade.Context) (*API, error) {
+	m, err := Model(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(m, ctx.Resources(), ctx.Auth()),
+		st:           ctx.State(),
+		authorizer:   ctx.Auth(),
+	}, nil
+}
+
+// Model returns the model for a context (override for tests).
+var Model = func(ctx facade.Context) (state.ModelAccessor, error) {
+	return ctx.State().Model()
+}
+
+// Prune performs the retained log pruner operation (override for tests).
+var Prune = func(st *state.State, retention map[loggo.Level]time.Duration, maxSizeMB int) (int, error) {
+	return state.PruneRetainedLogs(st, time.Now(), retention, maxSizeMB)
+}
+
+// Prune endpoint removes the retained log records that are older than
+// p.MaxAge allows for their severity, and then the oldest of the least
+// severe records until they fit within p.MaxSizeMB.
+func (api *API) Prune(p params.LogPruneArgs) error {
+	if !api.authorizer.AuthController() {
+		return apiservererrors.ErrPerm
+	}
+	retention := make(map[loggo.Level]time.Duration)
+	for severity, maxAge := range p.MaxAge {
+		level, ok := loggo.ParseLevel(severity)
+		if !ok {
+			return errors.NotValidf("severity %q", severity)
+		}
+		retention[level] = maxAge
+	}
+	if p.MaxSizeMB < 0 {
+		return errors.NotValidf("negative max size %d", p.MaxSizeMB)
+	}
+	removed, err := Prune(api.st, retention, p.MaxSizeMB)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("pruned %d retained log record(s)", removed)
+	return nil
+}
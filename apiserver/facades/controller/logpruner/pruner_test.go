@@ -0,0 +1,92 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logpruner_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/controller/logpruner"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&LogPrunerSuite{})
+
+type LogPrunerSuite struct {
+	coretesting.BaseSuite
+
+	context facadetest.Context
+	api     *logpruner.API
+}
+
+func (s *LogPrunerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.PatchValue(&logpruner.Model, func(_ facade.Context) (state.ModelAccessor, error) {
+		return nil, nil
+	})
+	s.context.Auth_ = testing.FakeAuthorizer{Controller: true}
+
+	var err error
+	s.api, err = logpruner.NewAPI(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LogPrunerSuite) TestPruneNonController(c *gc.C) {
+	s.context.Auth_ = testing.FakeAuthorizer{}
+	api, err := logpruner.NewAPI(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.Prune(params.LogPruneArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *LogPrunerSuite) TestPrune(c *gc.C) {
+	called := false
+	s.PatchValue(&logpruner.Prune, func(st *state.State, retention map[loggo.Level]time.Duration, maxSizeMB int) (int, error) {
+		c.Assert(retention, jc.DeepEquals, map[loggo.Level]time.Duration{
+			loggo.WARNING: time.Hour,
+			loggo.ERROR:   24 * time.Hour,
+		})
+		c.Assert(maxSizeMB, gc.Equals, 1024)
+		called = true
+		return 2, nil
+	})
+	err := s.api.Prune(params.LogPruneArgs{
+		MaxAge: map[string]time.Duration{
+			"WARNING": time.Hour,
+			"ERROR":   24 * time.Hour,
+		},
+		MaxSizeMB: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *LogPrunerSuite) TestPruneInvalidSeverity(c *gc.C) {
+	s.PatchValue(&logpruner.Prune, func(*state.State, map[loggo.Level]time.Duration, int) (int, error) {
+		c.Fatalf("unexpected prune")
+		return 0, nil
+	})
+	err := s.api.Prune(params.LogPruneArgs{
+		MaxAge: map[string]time.Duration{"LOUD": time.Hour},
+	})
+	c.Assert(err, gc.ErrorMatches, `severity "LOUD" not valid`)
+}
+
+func (s *LogPrunerSuite) TestPruneNegativeMaxSize(c *gc.C) {
+	s.PatchValue(&logpruner.Prune, func(*state.State, map[loggo.Level]time.Duration, int) (int, error) {
+		c.Fatalf("unexpected prune")
+		return 0, nil
+	})
+	err := s.api.Prune(params.LogPruneArgs{MaxSizeMB: -1})
+	c.Assert(err, gc.ErrorMatches, `negative max size -1 not valid`)
+}
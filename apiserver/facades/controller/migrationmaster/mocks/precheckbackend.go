@@ -142,6 +142,21 @@ func (mr *MockPrecheckBackendMockRecorder) ControllerBackend() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControllerBackend", reflect.TypeOf((*MockPrecheckBackend)(nil).ControllerBackend))
 }
 
+// HasSecrets mocks base method
+func (m *MockPrecheckBackend) HasSecrets() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasSecrets")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasSecrets indicates an expected call of HasSecrets
+func (mr *MockPrecheckBackendMockRecorder) HasSecrets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSecrets", reflect.TypeOf((*MockPrecheckBackend)(nil).HasSecrets))
+}
+
 // IsMigrationActive mocks base method
 func (m *MockPrecheckBackend) IsMigrationActive(arg0 string) (bool, error) {
 	m.ctrl.T.Helper()
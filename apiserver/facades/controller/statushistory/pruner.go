@@ -43,12 +43,19 @@ var Model = func(ctx facade.Context) (state.ModelAccessor, error) {
 // Prune performs the status history pruner operation (override for tests).
 var Prune = state.PruneStatusHistory
 
+// PruneHooks performs the hook history pruner operation (override for tests).
+var PruneHooks = state.PruneHookHistory
+
 // Prune endpoint removes status history entries until
 // only the ones newer than now - p.MaxHistoryTime remain and
-// the history is smaller than p.MaxHistoryMB.
+// the history is smaller than p.MaxHistoryMB. Hook history
+// is pruned in the same way.
 func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
 	if !api.authorizer.AuthController() {
 		return apiservererrors.ErrPerm
 	}
-	return Prune(api.cancel, api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	if err := Prune(api.cancel, api.st, p.MaxHistoryTime, p.MaxHistoryMB); err != nil {
+		return errors.Trace(err)
+	}
+	return PruneHooks(api.cancel, api.st, p.MaxHistoryTime, p.MaxHistoryMB)
 }
@@ -6,6 +6,7 @@ package statushistory_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -49,11 +50,17 @@ func (s *StatusHistoryPrunerSuite) TestPruneNonController(c *gc.C) {
 }
 
 func (s *StatusHistoryPrunerSuite) TestPrune(c *gc.C) {
-	called := false
+	var called []string
 	s.PatchValue(&statushistory.Prune, func(_ <-chan struct{}, st *state.State, maxHistoryTime time.Duration, maxHistoryMB int) error {
 		c.Assert(maxHistoryTime, gc.Equals, time.Hour)
 		c.Assert(maxHistoryMB, gc.Equals, 666)
-		called = true
+		called = append(called, "status")
+		return nil
+	})
+	s.PatchValue(&statushistory.PruneHooks, func(_ <-chan struct{}, st *state.State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+		c.Assert(maxHistoryTime, gc.Equals, time.Hour)
+		c.Assert(maxHistoryMB, gc.Equals, 666)
+		called = append(called, "hooks")
 		return nil
 	})
 	err := s.api.Prune(params.StatusHistoryPruneArgs{
@@ -61,5 +68,17 @@ func (s *StatusHistoryPrunerSuite) TestPrune(c *gc.C) {
 		MaxHistoryMB:   666,
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(called, jc.IsTrue)
+	c.Assert(called, jc.DeepEquals, []string{"status", "hooks"})
+}
+
+func (s *StatusHistoryPrunerSuite) TestPruneStatusError(c *gc.C) {
+	s.PatchValue(&statushistory.Prune, func(_ <-chan struct{}, st *state.State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+		return errors.New("boom")
+	})
+	s.PatchValue(&statushistory.PruneHooks, func(_ <-chan struct{}, st *state.State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+		c.Fatalf("hook history pruned after error")
+		return nil
+	})
+	err := s.api.Prune(params.StatusHistoryPruneArgs{MaxHistoryTime: time.Hour})
+	c.Assert(err, gc.ErrorMatches, "boom")
 }
@@ -12,15 +12,15 @@ import (
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
 	"github.com/juju/errors"
-	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 	"gopkg.in/macaroon.v2"
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
+	corelogger "github.com/juju/juju/core/logger"
 )
 
-var logger = loggo.GetLogger("juju.apiserver.httpcontext")
+var logger = corelogger.GetLoggerWithLabels("juju.apiserver.httpcontext", corelogger.HTTP)
 
 // LocalMacaroonAuthenticator extends Authenticator with a method of
 // creating a local login macaroon. The authenticator is expected to
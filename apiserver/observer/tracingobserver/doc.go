@@ -0,0 +1,7 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tracingobserver provides an implementation of
+// apiserver/observer.ObserverFactory that records a span for each
+// API request that carries a sampled trace context.
+package tracingobserver
@@ -0,0 +1,129 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracingobserver_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/observer/tracingobserver"
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/rpc"
+)
+
+const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+type observerSuite struct {
+	testing.IsolationSuite
+	clock    *testclock.Clock
+	exporter *fakeExporter
+}
+
+var _ = gc.Suite(&observerSuite{})
+
+func (s *observerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Unix(1614600000, 0))
+	s.exporter = &fakeExporter{}
+}
+
+func (s *observerSuite) newObserver(c *gc.C) observer.Observer {
+	factory, err := tracingobserver.NewObserverFactory(tracingobserver.Config{
+		Clock:    s.clock,
+		Exporter: s.exporter,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	o := factory()
+	o.Join(&http.Request{}, 42)
+	o.Login(
+		names.NewUserTag("bob"),
+		names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+		false, "",
+	)
+	return o
+}
+
+func (s *observerSuite) TestValidateConfig(c *gc.C) {
+	_, err := tracingobserver.NewObserverFactory(tracingobserver.Config{})
+	c.Check(err, gc.ErrorMatches, "validating config: nil Clock not valid")
+	_, err = tracingobserver.NewObserverFactory(tracingobserver.Config{Clock: s.clock})
+	c.Check(err, gc.ErrorMatches, "validating config: nil Exporter not valid")
+}
+
+func (s *observerSuite) TestRecordsTracedRequest(c *gc.C) {
+	o := s.newObserver(c).RPCObserver()
+	req := rpc.Request{
+		Type:        "Application",
+		Version:     13,
+		Action:      "Deploy",
+		TraceParent: traceParent,
+	}
+	o.ServerRequest(&rpc.Header{RequestId: 7, Request: req}, nil)
+	s.clock.Advance(1500 * time.Millisecond)
+	o.ServerReply(req, &rpc.Header{
+		RequestId: 7,
+		Error:     "boom",
+		ErrorCode: "not found",
+	}, nil)
+
+	c.Assert(s.exporter.spans, gc.HasLen, 1)
+	span := s.exporter.spans[0]
+	parent, err := tracing.ParseTraceParent(traceParent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(span.Context.TraceID, gc.Equals, parent.TraceID)
+	c.Check(span.Context.SpanID.IsValid(), jc.IsTrue)
+	c.Check(span.Context.SpanID, gc.Not(gc.Equals), parent.SpanID)
+	c.Check(span.Context.Sampled, jc.IsTrue)
+	span.Context = tracing.SpanContext{}
+	c.Check(span, jc.DeepEquals, tracing.Span{
+		Name:         "Application.Deploy",
+		ParentSpanID: parent.SpanID,
+		Kind:         tracing.SpanKindServer,
+		Start:        time.Unix(1614600000, 0),
+		End:          time.Unix(1614600001, 500000000),
+		Attributes: []tracing.Attribute{
+			tracing.StringAttribute("rpc.system", "juju"),
+			tracing.StringAttribute("rpc.service", "Application"),
+			tracing.StringAttribute("rpc.method", "Deploy"),
+			tracing.IntAttribute("juju.facade.version", 13),
+			tracing.IntAttribute("juju.request.id", 7),
+			tracing.IntAttribute("juju.connection.id", 42),
+			tracing.StringAttribute("juju.entity", "user-bob"),
+			tracing.StringAttribute("juju.model.uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+			tracing.StringAttribute("juju.error.code", "not found"),
+		},
+		Error: "boom",
+	})
+}
+
+func (s *observerSuite) TestIgnoresUntracedRequests(c *gc.C) {
+	o := s.newObserver(c)
+	for i, traceParent := range []string{
+		"",
+		"not-a-trace-parent",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+	} {
+		c.Logf("test %d: %q", i, traceParent)
+		rpcObserver := o.RPCObserver()
+		req := rpc.Request{Type: "Client", Version: 3, Action: "FullStatus", TraceParent: traceParent}
+		rpcObserver.ServerRequest(&rpc.Header{RequestId: 1, Request: req}, nil)
+		rpcObserver.ServerReply(req, &rpc.Header{RequestId: 1}, nil)
+	}
+	c.Assert(s.exporter.spans, gc.HasLen, 0)
+}
+
+type fakeExporter struct {
+	spans []tracing.Span
+}
+
+func (e *fakeExporter) ExportSpan(span tracing.Span) {
+	e.spans = append(e.spans, span)
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracingobserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/core/tracing"
+	"github.com/juju/juju/rpc"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.observer.tracingobserver")
+
+// Config contains the configuration for an Observer.
+type Config struct {
+	// Clock is the clock to use for all time-related operations.
+	Clock clock.Clock
+
+	// Exporter is sent the spans recorded for API requests.
+	Exporter tracing.Exporter
+}
+
+// Validate validates the observer factory configuration.
+func (cfg Config) Validate() error {
+	if cfg.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if cfg.Exporter == nil {
+		return errors.NotValidf("nil Exporter")
+	}
+	return nil
+}
+
+// NewObserverFactory returns a function that, when called, returns a
+// new Observer for an API connection.
+func NewObserverFactory(config Config) (observer.ObserverFactory, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating config")
+	}
+	return func() observer.Observer {
+		return &Observer{
+			clock:    config.Clock,
+			exporter: config.Exporter,
+		}
+	}, nil
+}
+
+// Observer is an API server observer that records a span for each
+// traced request made over a connection.
+type Observer struct {
+	clock    clock.Clock
+	exporter tracing.Exporter
+
+	// The following fields are set when the connection is opened and
+	// the client logs in, before any requests are observed.
+	connectionID uint64
+	entity       string
+	modelUUID    string
+}
+
+// Login is part of the observer.Observer interface.
+func (o *Observer) Login(entity names.Tag, model names.ModelTag, _ bool, _ string) {
+	if entity != nil {
+		o.entity = entity.String()
+	}
+	o.modelUUID = model.Id()
+}
+
+// Join is part of the observer.Observer interface.
+func (o *Observer) Join(req *http.Request, connectionID uint64) {
+	o.connectionID = connectionID
+}
+
+// Leave is part of the observer.Observer interface.
+func (*Observer) Leave() {}
+
+// RPCObserver is part of the observer.Observer interface.
+func (o *Observer) RPCObserver() rpc.Observer {
+	return &rpcObserver{
+		clock:        o.clock,
+		exporter:     o.exporter,
+		connectionID: o.connectionID,
+		entity:       o.entity,
+		modelUUID:    o.modelUUID,
+	}
+}
+
+type rpcObserver struct {
+	clock        clock.Clock
+	exporter     tracing.Exporter
+	connectionID uint64
+	entity       string
+	modelUUID    string
+
+	parent       tracing.SpanContext
+	requestStart time.Time
+}
+
+// ServerRequest is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
+	o.requestStart = o.clock.Now()
+	if hdr.Request.TraceParent == "" {
+		return
+	}
+	parent, err := tracing.ParseTraceParent(hdr.Request.TraceParent)
+	if err != nil {
+		logger.Debugf("ignoring trace context of request %d: %v", hdr.RequestId, err)
+		return
+	}
+	o.parent = parent
+}
+
+// ServerReply is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}) {
+	if !o.parent.IsValid() || !o.parent.Sampled {
+		return
+	}
+	attrs := []tracing.Attribute{
+		tracing.StringAttribute("rpc.system", "juju"),
+		tracing.StringAttribute("rpc.service", req.Type),
+		tracing.StringAttribute("rpc.method", req.Action),
+		tracing.IntAttribute("juju.facade.version", int64(req.Version)),
+		tracing.IntAttribute("juju.request.id", int64(hdr.RequestId)),
+		tracing.IntAttribute("juju.connection.id", int64(o.connectionID)),
+	}
+	if o.entity != "" {
+		attrs = append(attrs, tracing.StringAttribute("juju.entity", o.entity))
+	}
+	if o.modelUUID != "" {
+		attrs = append(attrs, tracing.StringAttribute("juju.model.uuid", o.modelUUID))
+	}
+	if hdr.ErrorCode != "" {
+		attrs = append(attrs, tracing.StringAttribute("juju.error.code", hdr.ErrorCode))
+	}
+	o.exporter.ExportSpan(tracing.Span{
+		Name:         req.Type + "." + req.Action,
+		Context:      o.parent.NewChild(),
+		ParentSpanID: o.parent.SpanID,
+		Kind:         tracing.SpanKindServer,
+		Start:        o.requestStart,
+		End:          o.clock.Now(),
+		Attributes:   attrs,
+		Error:        hdr.Error,
+	})
+}
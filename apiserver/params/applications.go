@@ -472,12 +472,61 @@ type UnitResult struct {
 	Charm           string                 `json:"charm"`
 	Leader          bool                   `json:"leader,omitempty"`
 	RelationData    []EndpointRelationData `json:"relation-data,omitempty"`
+	Timers          map[string]UnitTimer   `json:"timers,omitempty"`
 
 	// The following are for CAAS models.
 	ProviderId string `json:"provider-id,omitempty"`
 	Address    string `json:"address,omitempty"`
 }
 
+// UnitTimer holds a timer scheduled by a unit's charm.
+type UnitTimer struct {
+	Next   time.Time     `json:"next"`
+	Every  time.Duration `json:"every,omitempty"`
+	Jitter time.Duration `json:"jitter,omitempty"`
+}
+
+// HookRun describes a hook run by a unit agent.
+type HookRun struct {
+	Hook     string    `json:"hook"`
+	Relation string    `json:"relation,omitempty"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	ExitCode int       `json:"exit-code"`
+	Stderr   string    `json:"stderr,omitempty"`
+}
+
+// HookHistoryFilter restricts the hook runs returned by HookHistory.
+type HookHistoryFilter struct {
+	Hook       string     `json:"hook,omitempty"`
+	Relation   string     `json:"relation,omitempty"`
+	Since      *time.Time `json:"since,omitempty"`
+	FailedOnly bool       `json:"failed-only,omitempty"`
+	Size       int        `json:"size,omitempty"`
+}
+
+// HookHistoryArg holds a unit whose hook history is requested.
+type HookHistoryArg struct {
+	Tag    string            `json:"tag"`
+	Filter HookHistoryFilter `json:"filter"`
+}
+
+// HookHistoryArgs holds the units whose hook history is requested.
+type HookHistoryArgs struct {
+	Args []HookHistoryArg `json:"args"`
+}
+
+// HookHistoryResult holds the hooks run by a unit, most recent first.
+type HookHistoryResult struct {
+	Runs  []HookRun `json:"runs,omitempty"`
+	Error *Error    `json:"error,omitempty"`
+}
+
+// HookHistoryResults holds the results of HookHistory.
+type HookHistoryResults struct {
+	Results []HookHistoryResult `json:"results"`
+}
+
 // UnitInfoResults holds an unit info result or a retrieval error.
 type UnitInfoResult struct {
 	Result *UnitResult `json:"result,omitempty"`
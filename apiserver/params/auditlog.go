@@ -0,0 +1,68 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AuditLogQueryArgs holds the args for the AuditLog Query method.
+// Empty fields match every conversation.
+type AuditLogQueryArgs struct {
+	User       string     `json:"user,omitempty"`
+	ModelUUID  string     `json:"model-uuid,omitempty"`
+	Facade     string     `json:"facade,omitempty"`
+	Method     string     `json:"method,omitempty"`
+	After      *time.Time `json:"after,omitempty"`
+	Before     *time.Time `json:"before,omitempty"`
+	ErrorsOnly bool       `json:"errors-only,omitempty"`
+	Limit      int        `json:"limit,omitempty"`
+}
+
+// AuditLogQueryResult holds the conversations matching an audit log
+// query, from all of the controller nodes.
+type AuditLogQueryResult struct {
+	Conversations []AuditLogConversation `json:"conversations"`
+
+	// Unavailable lists the controller nodes which didn't
+	// answer the query, so the results may be incomplete.
+	Unavailable []AuditLogUnavailable `json:"unavailable,omitempty"`
+}
+
+// AuditLogUnavailable describes a controller node whose audit log
+// couldn't be searched.
+type AuditLogUnavailable struct {
+	ControllerID string `json:"controller-id"`
+	Reason       string `json:"reason"`
+}
+
+// AuditLogConversation is a conversation recorded in the audit log,
+// joined with the requests made as part of it.
+type AuditLogConversation struct {
+	ControllerID   string            `json:"controller-id"`
+	ConversationID string            `json:"conversation-id"`
+	ConnectionID   string            `json:"connection-id"`
+	Who            string            `json:"who"`
+	What           string            `json:"what"`
+	When           time.Time         `json:"when"`
+	ModelName      string            `json:"model-name"`
+	ModelUUID      string            `json:"model-uuid"`
+	Requests       []AuditLogRequest `json:"requests"`
+}
+
+// AuditLogRequest is an API request recorded in the audit log,
+// joined with any errors returned in response.
+type AuditLogRequest struct {
+	RequestID uint64          `json:"request-id"`
+	When      time.Time       `json:"when"`
+	Facade    string          `json:"facade"`
+	Method    string          `json:"method"`
+	Version   int             `json:"version"`
+	Args      string          `json:"args,omitempty"`
+	Errors    []AuditLogError `json:"errors,omitempty"`
+}
+
+// AuditLogError is an error returned from an audited API request.
+type AuditLogError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
@@ -88,4 +88,31 @@ type BackupsMetadataResult struct {
 
 	// HANodes reflects HA configuration: number of controller nodes in HA.
 	HANodes int64 `json:"ha-nodes"`
+
+	// Encrypted is true if the backup archive is encrypted.
+	Encrypted bool `json:"encrypted,omitempty"`
+
+	// Storage is the location where the backup archive is stored. It
+	// is empty if the archive is stored in the controller database.
+	Storage string `json:"storage,omitempty"`
+}
+
+// BackupsScheduleResult holds the controller's backup schedule, as
+// returned by the API Schedule method.
+type BackupsScheduleResult struct {
+	// Schedule is the cron-like schedule on which backups are
+	// created, or empty if scheduled backups are disabled.
+	Schedule string `json:"schedule,omitempty"`
+
+	// Next is when the next scheduled backup will be created.
+	Next *time.Time `json:"next,omitempty"`
+
+	// KeepDaily and KeepWeekly make up the retention policy for
+	// scheduled backups.
+	KeepDaily  int `json:"keep-daily"`
+	KeepWeekly int `json:"keep-weekly"`
+
+	// LastResult records the outcome of the most recent scheduled
+	// backup, if there has been one.
+	LastResult *EntityStatus `json:"last-result,omitempty"`
 }
@@ -909,6 +909,18 @@ type LogMessage struct {
 	Module    string    `json:"mod"`
 	Location  string    `json:"loc"`
 	Message   string    `json:"msg"`
+	Labels    []string  `json:"lab,omitempty"`
+}
+
+// LogPruneArgs holds arguments for the retained log pruning process.
+type LogPruneArgs struct {
+	// MaxAge holds, keyed by severity, how long records at that
+	// severity and above are retained for.
+	MaxAge map[string]time.Duration `json:"max-age"`
+
+	// MaxSizeMB is the size the retained records are pruned to once
+	// they grow larger. Zero means the size isn't limited.
+	MaxSizeMB int `json:"max-size-mb,omitempty"`
 }
 
 // ResourceUploadResult is used to return some details about an
@@ -927,10 +939,11 @@ type ResourceUploadResult struct {
 // UnitRefreshResult is used to return the latest values for attributes
 // on a unit.
 type UnitRefreshResult struct {
-	Life       life.Value
-	Resolved   ResolvedMode
-	Error      *Error
-	ProviderID string `json:"provider-id,omitempty"`
+	Life                life.Value
+	Resolved            ResolvedMode
+	Error               *Error
+	ProviderID          string `json:"provider-id,omitempty"`
+	HookCancelRequested bool   `json:"hook-cancel-requested,omitempty"`
 }
 
 // UnitRefreshResults holds the results for any API call which ends
@@ -939,6 +952,18 @@ type UnitRefreshResults struct {
 	Results []UnitRefreshResult
 }
 
+// RecordHookRunArg holds a hook run to add to a unit's hook history.
+type RecordHookRunArg struct {
+	Tag string  `json:"tag"`
+	Run HookRun `json:"run"`
+}
+
+// RecordHookRunArgs holds the arguments for recording the hooks
+// run by units.
+type RecordHookRunArgs struct {
+	Args []RecordHookRunArg `json:"args"`
+}
+
 // EntityString holds an entity tag and a string value.
 type EntityString struct {
 	Tag   string `json:"tag"`
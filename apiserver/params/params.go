@@ -1012,6 +1012,7 @@ type LogRecord struct {
 	Level    string    `json:"v"`
 	Message  string    `json:"x"`
 	Entity   string    `json:"e,omitempty"`
+	Labels   []string  `json:"c,omitempty"`
 }
 
 // PubSubMessage is used to propagate pubsub messages from one api server to the
@@ -1077,6 +1078,25 @@ type BundleChangesMapArgs struct {
 	Requires []string `json:"requires"`
 }
 
+// ExportBundleParams holds the arguments for the Bundle.ExportBundle call.
+type ExportBundleParams struct {
+	// Complete requests that everything needed to reproduce the model is
+	// exported, including storage directives, device constraints and
+	// resource revisions, with a warning for anything which can't be
+	// represented in a bundle.
+	Complete bool `json:"complete,omitempty"`
+}
+
+// ExportBundleResult holds the result of the Bundle.ExportBundle call.
+type ExportBundleResult struct {
+	// Result holds the exported bundle YAML.
+	Result string `json:"result"`
+	// Warnings holds the parts of the model which couldn't be
+	// represented in the exported bundle.
+	Warnings []string `json:"warnings,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
 type MongoVersion struct {
 	Major         int    `json:"major"`
 	Minor         int    `json:"minor"`
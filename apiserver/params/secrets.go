@@ -0,0 +1,134 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// CreateSecretArgs holds the args for creating secrets.
+type CreateSecretArgs struct {
+	Args []CreateSecretArg `json:"args"`
+}
+
+// CreateSecretArg holds the args for creating a secret.
+type CreateSecretArg struct {
+	// OwnerTag is the application or unit owning the secret.
+	OwnerTag     string            `json:"owner-tag"`
+	Description  string            `json:"description,omitempty"`
+	Label        string            `json:"label,omitempty"`
+	RotatePolicy string            `json:"rotate-policy,omitempty"`
+	Data         map[string]string `json:"data"`
+}
+
+// UpdateSecretArgs holds the args for updating secrets.
+type UpdateSecretArgs struct {
+	Args []UpdateSecretArg `json:"args"`
+}
+
+// UpdateSecretArg holds the args for updating a secret. Only the set
+// attributes are changed, and a new revision is added if Data is set.
+type UpdateSecretArg struct {
+	URI          string            `json:"uri"`
+	Description  *string           `json:"description,omitempty"`
+	Label        *string           `json:"label,omitempty"`
+	RotatePolicy *string           `json:"rotate-policy,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+// GetSecretValueArgs holds the args for getting secret values.
+type GetSecretValueArgs struct {
+	Args []GetSecretValueArg `json:"args"`
+}
+
+// GetSecretValueArg holds the args for getting the value of a secret,
+// identified by its URI or by the label the caller gave it.
+type GetSecretValueArg struct {
+	URI   string `json:"uri,omitempty"`
+	Label string `json:"label,omitempty"`
+
+	// Peek returns the latest revision without tracking it.
+	Peek bool `json:"peek,omitempty"`
+	// Update tracks the latest revision from now on.
+	Update bool `json:"update,omitempty"`
+}
+
+// SecretValueResults holds secret value results.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// SecretValueResult is the result of getting a secret value.
+type SecretValueResult struct {
+	Data  map[string]string `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// GrantRevokeSecretArgs holds the args for granting and revoking access
+// to secrets.
+type GrantRevokeSecretArgs struct {
+	Args []GrantRevokeSecretArg `json:"args"`
+}
+
+// GrantRevokeSecretArg holds the args for granting or revoking access to
+// a secret.
+type GrantRevokeSecretArg struct {
+	URI         string   `json:"uri"`
+	SubjectTags []string `json:"subject-tags"`
+	Role        string   `json:"role,omitempty"`
+}
+
+// GetSecretConsumerInfoArgs holds the args for getting the latest
+// revisions of the secrets read by a consumer.
+type GetSecretConsumerInfoArgs struct {
+	ConsumerTag string   `json:"consumer-tag"`
+	URIs        []string `json:"uris"`
+}
+
+// SecretConsumerInfoResults holds secret consumer info results.
+type SecretConsumerInfoResults struct {
+	Results []SecretConsumerInfoResult `json:"results"`
+}
+
+// SecretConsumerInfoResult holds the latest revision of a secret read
+// by a consumer, and the label the consumer gave it.
+type SecretConsumerInfoResult struct {
+	Revision int    `json:"revision"`
+	Label    string `json:"label,omitempty"`
+	Error    *Error `json:"error,omitempty"`
+}
+
+// SecretRotatedArgs holds the args for recording secret rotations.
+type SecretRotatedArgs struct {
+	Args []SecretRotatedArg `json:"args"`
+}
+
+// SecretRotatedArg records when a secret was rotated.
+type SecretRotatedArg struct {
+	URI  string    `json:"uri"`
+	When time.Time `json:"when"`
+}
+
+// ListSecretsArgs holds the args for listing secrets.
+type ListSecretsArgs struct {
+	// ShowSecrets includes the values of the secrets in the results.
+	ShowSecrets bool `json:"show-secrets"`
+}
+
+// ListSecretResults holds secret metadata results.
+type ListSecretResults struct {
+	Results []ListSecretResult `json:"results"`
+}
+
+// ListSecretResult describes a secret.
+type ListSecretResult struct {
+	URI            string             `json:"uri"`
+	OwnerTag       string             `json:"owner-tag"`
+	Description    string             `json:"description,omitempty"`
+	Label          string             `json:"label,omitempty"`
+	RotatePolicy   string             `json:"rotate-policy,omitempty"`
+	NextRotateTime *time.Time         `json:"next-rotate-time,omitempty"`
+	LatestRevision int                `json:"latest-revision"`
+	CreateTime     time.Time          `json:"create-time"`
+	UpdateTime     time.Time          `json:"update-time"`
+	Value          *SecretValueResult `json:"value,omitempty"`
+}
@@ -18,6 +18,10 @@ import (
 // StatusParams holds parameters for the Status call.
 type StatusParams struct {
 	Patterns []string `json:"patterns"`
+
+	// Query, if set, filters the status to the units for which the
+	// status query is true.
+	Query string `json:"query,omitempty"`
 }
 
 // TODO(ericsnow) Add FullStatusResult.
@@ -234,6 +238,9 @@ type RelationStatus struct {
 	Scope     string           `json:"scope"`
 	Endpoints []EndpointStatus `json:"endpoints"`
 	Status    DetailedStatus   `json:"status"`
+
+	// UnitCount is the number of units in the relation's scope.
+	UnitCount int `json:"unit-count,omitempty"`
 }
 
 // EndpointStatus holds status info about a single endpoint.
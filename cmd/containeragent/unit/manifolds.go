@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
+	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/utils/proxy"
@@ -200,7 +201,7 @@ func Manifolds(config manifoldsConfig) dependency.Manifolds {
 		// Kubernetes. It provides a mux that is used by the caas prober to
 		// register handlers.
 		probeHTTPServerName: muxhttpserver.Manifold(muxhttpserver.ManifoldConfig{
-			Logger: loggo.GetLogger("juju.worker.probehttpserver"),
+			Logger: corelogger.GetLoggerWithLabels("juju.worker.probehttpserver", corelogger.HTTP),
 			Port:   config.ProbePort,
 		}),
 
@@ -255,6 +256,7 @@ func Manifolds(config manifoldsConfig) dependency.Manifolds {
 			Embedded:                     true,
 			EnforcedCharmModifiedVersion: config.CharmModifiedVersion,
 			ContainerNames:               config.ContainerNames,
+			PrometheusRegisterer:         config.PrometheusRegisterer,
 		})),
 	}
 }
@@ -6,6 +6,7 @@ package application
 import (
 	"net"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/crossmodel"
 )
 
@@ -167,6 +169,10 @@ Examples:
     # by specifying the routes used for relation data.
     juju add-relation wordpress automation/prod.mysql --via 192.168.0.0/16,10.0.0.0/8
 
+    # Relate the wordpress and mysql applications, printing the endpoints
+    # which were related, for use in scripts.
+    juju relate wordpress mysql --format json
+
 
 See also:
 
@@ -192,6 +198,7 @@ type addRelationCommand struct {
 	remoteEndpoint    *crossmodel.OfferURL
 	addRelationAPI    applicationAddRelationAPI
 	consumeDetailsAPI applicationConsumeDetailsAPI
+	out               cmd.Output
 }
 
 func (c *addRelationCommand) Info() *cmd.Info {
@@ -223,6 +230,7 @@ func (c *addRelationCommand) Init(args []string) error {
 
 func (c *addRelationCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.viaValue, "via", "", "for cross model relations, specify the egress subnets for outbound traffic")
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
 }
 
 // applicationAddRelationAPI defines the API methods that application add relation command uses.
@@ -277,17 +285,42 @@ func (c *addRelationCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
-	_, err = client.AddRelation(c.endpoints, c.viaCIDRs)
+	results, err := client.AddRelation(c.endpoints, c.viaCIDRs)
 	if params.IsCodeUnauthorized(err) {
 		common.PermissionsMessage(ctx.Stderr, "add a relation")
 	}
+	out := addedRelation{Endpoints: c.endpoints}
 	if params.IsCodeAlreadyExists(err) {
 		// It's not a real error, mention about it, log it and move along
 		logger.Infof("%s", err)
 		ctx.Infof("%s", err)
+		out.AlreadyExists = true
 		err = nil
 	}
-	return block.ProcessBlockedError(err, block.BlockChange)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if c.out.Name() == output.HumanFormat {
+		return nil
+	}
+	if results != nil && len(results.Endpoints) > 0 {
+		out.Endpoints = nil
+		for appName, rel := range results.Endpoints {
+			out.Endpoints = append(out.Endpoints, appName+":"+rel.Name)
+			out.Interface = rel.Interface
+		}
+		sort.Strings(out.Endpoints)
+	}
+	return c.out.Write(ctx, out)
+}
+
+// addedRelation is written by add-relation when a structured format is
+// requested. The endpoints are those given on the command line if the
+// relation already existed.
+type addedRelation struct {
+	Endpoints     []string `yaml:"endpoints" json:"endpoints"`
+	Interface     string   `yaml:"interface,omitempty" json:"interface,omitempty"`
+	AlreadyExists bool     `yaml:"already-exists,omitempty" json:"already-exists,omitempty"`
 }
 
 func (c *addRelationCommand) maybeConsumeOffer(targetClient applicationAddRelationAPI) error {
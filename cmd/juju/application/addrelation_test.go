@@ -29,8 +29,8 @@ func (s *AddRelationSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.mockAPI = &mockAddAPI{Stub: &testing.Stub{}}
 	s.mockAPI.addRelationFunc = func(endpoints, viaCIDRs []string) (*params.AddRelationResults, error) {
-		// At the moment, cmd implementation ignores the return values,
-		// so nil is an acceptable return for testing purposes.
+		// The return values are only used when a structured format
+		// is requested, so nil is an acceptable return otherwise.
 		return nil, s.mockAPI.NextErr()
 	}
 }
@@ -65,6 +65,37 @@ func (s *AddRelationSuite) TestAddRelationSuccess(c *gc.C) {
 	s.mockAPI.CheckCall(c, 1, "Close")
 }
 
+func (s *AddRelationSuite) TestAddRelationFormat(c *gc.C) {
+	s.mockAPI.addRelationFunc = func(endpoints, viaCIDRs []string) (*params.AddRelationResults, error) {
+		return &params.AddRelationResults{Endpoints: map[string]params.CharmRelation{
+			"wordpress": {Name: "db", Role: "requirer", Interface: "mysql"},
+			"mysql":     {Name: "server", Role: "provider", Interface: "mysql"},
+		}}, nil
+	}
+	cmd := application.NewAddRelationCommandForTest(s.mockAPI, s.mockAPI)
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "wordpress", "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+endpoints:
+- mysql:server
+- wordpress:db
+interface: mysql
+`[1:])
+}
+
+func (s *AddRelationSuite) TestAddRelationExistsFormat(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{
+		Message: `relation wordpress:db mysql:server already exists`,
+		Code:    params.CodeAlreadyExists,
+	})
+	cmd := application.NewAddRelationCommandForTest(s.mockAPI, s.mockAPI)
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "wordpress", "mysql", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"endpoints":["wordpress","mysql"],"already-exists":true}`+"\n")
+}
+
 func (s *AddRelationSuite) TestAddRelationFail(c *gc.C) {
 	msg := "fail add-relation call at API"
 	s.mockAPI.SetErrors(errors.New(msg))
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 )
@@ -86,6 +87,10 @@ Add a unit of mysql to LXD container on a new machine:
 
     juju add-unit mysql --to lxd
 
+Print the names of the units added, for use in scripts:
+
+    juju add-unit mysql -n 2 --format json
+
 See also:
     remove-unit
 `[1:]
@@ -144,6 +149,7 @@ type addUnitCommand struct {
 	UnitCommandBase
 	ApplicationName string
 	api             applicationAddUnitAPI
+	out             cmd.Output
 
 	unknownModel bool
 }
@@ -160,6 +166,7 @@ func (c *addUnitCommand) Info() *cmd.Info {
 func (c *addUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.UnitCommandBase.SetFlags(f)
 	f.IntVar(&c.NumUnits, "n", 1, "Number of units to add")
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
 }
 
 func (c *addUnitCommand) Init(args []string) error {
@@ -236,12 +243,16 @@ func (c *addUnitCommand) Run(ctx *cmd.Context) error {
 	}
 
 	if modelType == model.CAAS {
-		_, err = apiclient.ScaleApplication(application.ScaleApplicationParams{
+		result, err := apiclient.ScaleApplication(application.ScaleApplicationParams{
 			ApplicationName: c.ApplicationName,
 			ScaleChange:     c.NumUnits,
 		})
 		if err == nil {
-			return nil
+			out := addUnitResult{Application: c.ApplicationName}
+			if result.Info != nil {
+				out.Scale = result.Info.Scale
+			}
+			return c.write(ctx, out)
 		}
 		if params.IsCodeNotSupported(err) {
 			return errors.Annotate(err, "can not add unit")
@@ -258,16 +269,38 @@ func (c *addUnitCommand) Run(ctx *cmd.Context) error {
 		}
 		c.Placement[i] = p
 	}
-	_, err = apiclient.AddUnits(application.AddUnitsParams{
+	units, err := apiclient.AddUnits(application.AddUnitsParams{
 		ApplicationName: c.ApplicationName,
 		NumUnits:        c.NumUnits,
 		Placement:       c.Placement,
 		AttachStorage:   c.AttachStorage,
 	})
-	if params.IsCodeUnauthorized(err) {
-		common.PermissionsMessage(ctx.Stderr, "add a unit")
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "add a unit")
+		}
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return c.write(ctx, addUnitResult{
+		Application: c.ApplicationName,
+		Units:       units,
+	})
+}
+
+// addUnitResult is written by add-unit when a structured format is
+// requested. For k8s models only the new scale of the application is
+// known, as the units are created later.
+type addUnitResult struct {
+	Application string   `yaml:"application" json:"application"`
+	Units       []string `yaml:"units,omitempty" json:"units,omitempty"`
+	Scale       int      `yaml:"scale,omitempty" json:"scale,omitempty"`
+}
+
+func (c *addUnitCommand) write(ctx *cmd.Context, result addUnitResult) error {
+	if c.out.Name() == output.HumanFormat {
+		return nil
 	}
-	return block.ProcessBlockedError(err, block.BlockChange)
+	return c.out.Write(ctx, result)
 }
 
 // deployTarget describes the format a machine or container target must match to be valid.
@@ -4,6 +4,7 @@
 package application_test
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/juju/cmd/cmdtesting"
@@ -55,10 +56,14 @@ func (f *fakeApplicationAddUnitAPI) AddUnits(args apiapplication.AddUnitsParams)
 		return nil, errors.NotFoundf("application %q", args.ApplicationName)
 	}
 
+	var units []string
+	for i := 0; i < args.NumUnits; i++ {
+		units = append(units, fmt.Sprintf("%s/%d", f.application, f.numUnits+i))
+	}
 	f.numUnits += args.NumUnits
 	f.placement = args.Placement
 	f.attachStorage = args.AttachStorage
-	return nil, nil
+	return units, nil
 }
 
 func (f *fakeApplicationAddUnitAPI) ScaleApplication(args apiapplication.ScaleApplicationParams) (params.ScaleApplicationResult, error) {
@@ -69,7 +74,9 @@ func (f *fakeApplicationAddUnitAPI) ScaleApplication(args apiapplication.ScaleAp
 		return params.ScaleApplicationResult{}, errors.NotFoundf("application %q", args.ApplicationName)
 	}
 	f.numUnits += args.ScaleChange
-	return params.ScaleApplicationResult{}, nil
+	return params.ScaleApplicationResult{
+		Info: &params.ScaleApplicationInfo{Scale: f.numUnits},
+	}, nil
 }
 
 func (f *fakeApplicationAddUnitAPI) ModelGet() (map[string]interface{}, error) {
@@ -147,6 +154,34 @@ func (s *AddUnitSuite) TestAddUnit(c *gc.C) {
 	c.Assert(s.fake.numUnits, gc.Equals, 4)
 }
 
+func (s *AddUnitSuite) TestAddUnitFormat(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewAddUnitCommandForTest(s.fake, s.store),
+		"some-application-name", "-n", "2", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		`{"application":"some-application-name","units":["some-application-name/1","some-application-name/2"]}`+"\n")
+}
+
+func (s *AddUnitSuite) TestAddUnitDefaultFormatPrintsNothing(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewAddUnitCommandForTest(s.fake, s.store), "some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
+
+func (s *AddUnitSuite) TestCAASAddUnitFormat(c *gc.C) {
+	m := s.store.Models["arthur"].Models["king/sword"]
+	m.ModelType = model.CAAS
+	s.store.Models["arthur"].Models["king/sword"] = m
+
+	ctx, err := cmdtesting.RunCommand(c, application.NewAddUnitCommandForTest(s.fake, s.store),
+		"some-application-name", "-n", "2", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+application: some-application-name
+scale: 3
+`[1:])
+}
+
 func (s *AddUnitSuite) TestAddUnitWithPlacement(c *gc.C) {
 	err := s.runAddUnit(c, "some-application-name")
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,473 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/bundlechanges/v5"
+	"github.com/juju/charm/v9"
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/applicationoffers"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/arch"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/jujuclient"
+)
+
+const applyDoc = `
+Apply converges a model to a bundle. It compares the bundle with the
+model, in the same way as diff-bundle, shows the plan and then deploys
+the bundle onto the model's existing machines, adding and updating
+applications, relations, machines and offers as necessary.
+
+Applications, relations, offers and machines in the model but not in the
+bundle are only removed when --prune is specified, after the plan has
+been confirmed. Use --yes to skip the confirmation, and --force to remove
+machines that still host units. Pruned applications' storage is kept.
+Offers which are still in use by other models are not removed.
+
+Use --dry-run to show the plan without changing the model.
+
+The bundle can be a local bundle file or the name of a bundle in the
+charm store or charm hub, and can be combined with overlays as for the
+deploy command. The map-machines and trust options work as for deploy,
+but existing is always assumed for map-machines. As deploy resolves the
+bundle for the model's default series and constraints, the series and
+arch options are not supported.
+
+Examples:
+    juju apply localbundle.yaml
+    juju apply --dry-run --prune localbundle.yaml
+    juju apply --prune --yes cs:canonical-kubernetes --overlay local-config.yaml
+    juju apply localbundle.yaml --map-machines 3=4
+
+See also:
+    deploy
+    diff-bundle
+    remove-application
+    remove-machine
+    remove-relation
+`
+
+const applyPruneMsg = `
+WARNING! This command will remove from the model:
+%s
+Continue [y/N]? `[1:]
+
+// ApplyPruneAPI defines the API used by apply to remove the
+// applications, relations, offers and machines that aren't in the bundle.
+type ApplyPruneAPI interface {
+	DestroyRelation(force *bool, maxWait *time.Duration, endpoints ...string) error
+	DestroyOffers(force bool, offerURLs ...string) error
+	DestroyApplications(application.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
+	DestroyMachinesWithParams(force, keep bool, maxWait *time.Duration, machines ...string) ([]params.DestroyMachineResult, error)
+}
+
+// NewApplyCommand returns a command to converge the selected model
+// to a bundle.
+func NewApplyCommand() cmd.Command {
+	cmd := &applyCommand{
+		diffBundleCommand: diffBundleCommand{
+			arches: arch.AllArches(),
+		},
+	}
+	cmd.charmAdaptorFn = cmd.charmAdaptor
+	cmd.newAPIRootFn = func() (base.APICallCloser, error) {
+		return cmd.NewAPIRoot()
+	}
+	cmd.newControllerAPIRootFn = func() (base.APICallCloser, error) {
+		return cmd.NewControllerAPIRoot()
+	}
+	cmd.modelConfigClientFunc = func(api base.APICallCloser) ModelConfigClient {
+		return modelconfig.NewClient(api)
+	}
+	cmd.modelConstraintsClientFunc = func() (ModelConstraintsClient, error) {
+		return cmd.NewAPIClient()
+	}
+	cmd.newPruneAPIFn = func(apiRoot, controllerRoot base.APICallCloser) ApplyPruneAPI {
+		return applyPruneAPI{
+			Client:  application.NewClient(apiRoot),
+			machine: machinemanager.NewClient(apiRoot),
+			offers:  applicationoffers.NewClient(controllerRoot),
+		}
+	}
+	cmd.deployBundleFn = cmd.deployBundle
+	return modelcmd.Wrap(cmd)
+}
+
+// applyCommand converges a model to a bundle.
+type applyCommand struct {
+	diffBundleCommand
+
+	dryRun    bool
+	prune     bool
+	force     bool
+	assumeYes bool
+	trust     bool
+
+	// newPruneAPIFn returns the API used to prune the model, given
+	// connections to the model and to its controller.
+	newPruneAPIFn  func(apiRoot, controllerRoot base.APICallCloser) ApplyPruneAPI
+	deployBundleFn func(ctx *cmd.Context, args []string) error
+}
+
+// Info is part of cmd.Command.
+func (c *applyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "apply",
+		Args:    "<bundle file or name>",
+		Purpose: "Converge a model to a bundle.",
+		Doc:     applyDoc,
+	})
+}
+
+// SetFlags is part of cmd.Command.
+func (c *applyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.diffBundleCommand.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Show the plan without changing the model")
+	f.BoolVar(&c.prune, "prune", false, "Remove applications, relations, offers and machines that aren't in the bundle")
+	f.BoolVar(&c.force, "force", false, "Remove pruned machines even if they host units")
+	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
+	f.BoolVar(&c.assumeYes, "yes", false, "")
+	f.BoolVar(&c.trust, "trust", false, "Allows charms in the bundle to run hooks that require access credentials")
+}
+
+// Init is part of cmd.Command.
+func (c *applyCommand) Init(args []string) error {
+	if c.series != "" || c.arch != "" {
+		return errors.New("--series and --arch are not supported by apply, as deploy resolves the bundle for the model's default series and constraints")
+	}
+	return c.diffBundleCommand.Init(args)
+}
+
+// applyPlan holds what apply removes from the model, in the order it
+// is removed.
+type applyPlan struct {
+	relations    [][]string
+	offers       []string
+	applications []string
+	machines     []string
+}
+
+func (p applyPlan) empty() bool {
+	return len(p.relations) == 0 && len(p.offers) == 0 && len(p.applications) == 0 && len(p.machines) == 0
+}
+
+func (p applyPlan) String() string {
+	var lines []string
+	for _, rel := range p.relations {
+		lines = append(lines, fmt.Sprintf(" - relation %s", strings.Join(rel, " ")))
+	}
+	for _, name := range p.offers {
+		lines = append(lines, fmt.Sprintf(" - offer %s", name))
+	}
+	for _, name := range p.applications {
+		lines = append(lines, fmt.Sprintf(" - application %s", name))
+	}
+	for _, id := range p.machines {
+		lines = append(lines, fmt.Sprintf(" - machine %s", id))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Run is part of cmd.Command.
+func (c *applyCommand) Run(ctx *cmd.Context) error {
+	apiRoot, err := c.newAPIRootFn()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = apiRoot.Close() }()
+
+	bundle, model, err := c.readBundleAndModel(ctx, apiRoot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	diff, err := c.buildDiff(bundle, model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	plan := pruneDiff(diff)
+	// The bundle diff doesn't cover offers, so they're compared here.
+	plan.offers = pruneOffers(bundle, model)
+	if diff.Empty() && plan.empty() {
+		ctx.Infof("The model already matches the bundle.")
+		return nil
+	}
+
+	if !diff.Empty() {
+		ctx.Infof("Differences between the bundle and the model:")
+		encoder := yaml.NewEncoder(ctx.Stdout)
+		err = encoder.Encode(diff)
+		_ = encoder.Close()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	switch {
+	case plan.empty():
+	case c.prune:
+		ctx.Infof("Removals:\n%s", plan)
+	default:
+		ctx.Infof("Use --prune to remove the applications, relations, offers and machines that aren't in the bundle.")
+	}
+	if c.dryRun {
+		return nil
+	}
+
+	prune := c.prune && !plan.empty()
+	if prune && !c.assumeYes {
+		fmt.Fprintf(ctx.Stdout, applyPruneMsg, plan)
+		if err := jujucmd.UserConfirmYes(ctx); err != nil {
+			return errors.Annotate(err, "pruning model")
+		}
+	}
+	if err := c.deployBundleFn(ctx, c.deployArgs()); err != nil {
+		return errors.Annotate(err, "deploying bundle")
+	}
+	if !prune {
+		return nil
+	}
+	controllerRoot, err := c.newControllerAPIRootFn()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = controllerRoot.Close() }()
+	return errors.Trace(c.applyPrune(ctx, c.newPruneAPIFn(apiRoot, controllerRoot), plan))
+}
+
+// pruneDiff returns the plan for removing the applications, relations
+// and machines in the model that aren't in the bundle. Relations to
+// removed applications are removed with them.
+func pruneDiff(diff *bundlechanges.BundleDiff) applyPlan {
+	var plan applyPlan
+	pruned := set.NewStrings()
+	for name, appDiff := range diff.Applications {
+		if appDiff.Missing == bundlechanges.BundleSide {
+			pruned.Add(name)
+		}
+	}
+	plan.applications = pruned.SortedValues()
+	for id, machineDiff := range diff.Machines {
+		if machineDiff.Missing == bundlechanges.BundleSide {
+			plan.machines = append(plan.machines, id)
+		}
+	}
+	sort.Strings(plan.machines)
+	if diff.Relations != nil {
+		for _, rel := range diff.Relations.ModelAdditions {
+			if !relationToAny(rel, pruned) {
+				plan.relations = append(plan.relations, rel)
+			}
+		}
+	}
+	return plan
+}
+
+// pruneOffers returns the names of the offers in the model that aren't
+// in the bundle, including those of applications which aren't in the
+// bundle.
+func pruneOffers(bundle *charm.BundleData, model *bundlechanges.Model) []string {
+	var offers []string
+	for name, app := range model.Applications {
+		var bundleOffers map[string]*charm.OfferSpec
+		if bundleApp, ok := bundle.Applications[name]; ok {
+			bundleOffers = bundleApp.Offers
+		}
+		for _, offerName := range app.Offers {
+			if _, ok := bundleOffers[offerName]; !ok {
+				offers = append(offers, offerName)
+			}
+		}
+	}
+	sort.Strings(offers)
+	return offers
+}
+
+// relationToAny returns whether any of the relation's endpoints
+// belong to one of the applications.
+func relationToAny(rel []string, applications set.Strings) bool {
+	for _, endpoint := range rel {
+		if applications.Contains(strings.SplitN(endpoint, ":", 2)[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyPrune removes everything in the plan. Offers are removed before
+// applications, which can't be removed while they're offered, and
+// relations and applications are removed before machines, so that the
+// machines' units are already dying. Any failures are reported together,
+// once everything has been attempted.
+func (c *applyCommand) applyPrune(ctx *cmd.Context, api ApplyPruneAPI, plan applyPlan) error {
+	var failed []string
+	for _, rel := range plan.relations {
+		if err := api.DestroyRelation(nil, nil, rel...); err != nil {
+			ctx.Infof("ERROR removing relation %s: %v", strings.Join(rel, " "), err)
+			failed = append(failed, "relation "+strings.Join(rel, " "))
+			continue
+		}
+		ctx.Infof("Removed relation %s", strings.Join(rel, " "))
+	}
+
+	if len(plan.offers) > 0 {
+		ownerName, modelName, err := c.qualifiedModelName()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, name := range plan.offers {
+			offerURL := crossmodel.MakeURL(ownerName, modelName, name, "")
+			if err := api.DestroyOffers(false, offerURL); err != nil {
+				ctx.Infof("ERROR removing offer %s: %v", name, err)
+				failed = append(failed, "offer "+name)
+				continue
+			}
+			ctx.Infof("Removed offer %s", name)
+		}
+	}
+
+	if len(plan.applications) > 0 {
+		results, err := api.DestroyApplications(application.DestroyApplicationsParams{
+			Applications: plan.applications,
+		})
+		if err := block.ProcessBlockedError(err, block.BlockRemove); err != nil {
+			return errors.Trace(err)
+		}
+		for i, result := range results {
+			name := plan.applications[i]
+			if result.Error != nil {
+				ctx.Infof("ERROR removing application %s: %v", name, result.Error)
+				failed = append(failed, "application "+name)
+				continue
+			}
+			ctx.Infof("Removing application %s", name)
+		}
+	}
+
+	if len(plan.machines) > 0 {
+		results, err := api.DestroyMachinesWithParams(c.force, false, nil, plan.machines...)
+		if err := block.ProcessBlockedError(err, block.BlockRemove); err != nil {
+			return errors.Trace(err)
+		}
+		for i, result := range results {
+			id := plan.machines[i]
+			if result.Error != nil {
+				ctx.Infof("ERROR removing machine %s: %v", id, result.Error)
+				failed = append(failed, "machine "+id)
+				continue
+			}
+			ctx.Infof("Removing machine %s", id)
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.Errorf("failed to remove %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// qualifiedModelName returns the owner and the unqualified name of the
+// model, as used in offer URLs.
+func (c *applyCommand) qualifiedModelName() (string, string, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	modelName, _, err := c.ModelDetails()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	if !jujuclient.IsQualifiedModelName(modelName) {
+		store := modelcmd.QualifyingClientStore{c.ClientStore()}
+		modelName, err = store.QualifiedModelName(controllerName, modelName)
+		if err != nil {
+			return "", "", errors.Trace(err)
+		}
+	}
+	unqualifiedModelName, ownerTag, err := jujuclient.SplitModelName(modelName)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return ownerTag.Id(), unqualifiedModelName, nil
+}
+
+// deployArgs returns the arguments for the deploy command that adds and
+// updates everything in the bundle, using the model's existing machines.
+func (c *applyCommand) deployArgs() []string {
+	machineMap := "existing"
+	if c.machineMap != "" {
+		machineMap += "," + c.machineMap
+	}
+	args := []string{c.bundle, "--map-machines", machineMap}
+	for _, overlay := range c.bundleOverlays {
+		args = append(args, "--overlay", overlay)
+	}
+	if c.channelStr != "" {
+		args = append(args, "--channel", c.channelStr)
+	}
+	if c.trust {
+		args = append(args, "--trust")
+	}
+	return args
+}
+
+// deployBundle runs the deploy command against the same model.
+func (c *applyCommand) deployBundle(ctx *cmd.Context, args []string) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelName, err := c.ModelIdentifier()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	deployCmd := NewDeployCommand()
+	deployCmd.SetClientStore(c.ClientStore())
+
+	f := gnuflag.NewFlagSetWithFlagKnownAs("deploy", gnuflag.ContinueOnError, "option")
+	f.SetOutput(ctx.Stderr)
+	deployCmd.SetFlags(f)
+	args = append([]string{"-m", controllerName + ":" + modelName}, args...)
+	if err := f.Parse(deployCmd.AllowInterspersedFlags(), args); err != nil {
+		return errors.Trace(err)
+	}
+	if err := deployCmd.Init(f.Args()); err != nil {
+		return errors.Trace(err)
+	}
+	return deployCmd.Run(ctx)
+}
+
+// applyPruneAPI combines the application, application offers and
+// machine manager clients.
+type applyPruneAPI struct {
+	*application.Client
+	machine *machinemanager.Client
+	offers  *applicationoffers.Client
+}
+
+// DestroyOffers is part of ApplyPruneAPI.
+func (a applyPruneAPI) DestroyOffers(force bool, offerURLs ...string) error {
+	return a.offers.DestroyOffers(force, offerURLs...)
+}
+
+// DestroyMachinesWithParams is part of ApplyPruneAPI.
+func (a applyPruneAPI) DestroyMachinesWithParams(force, keep bool, maxWait *time.Duration, machines ...string) ([]params.DestroyMachineResult, error) {
+	return a.machine.DestroyMachinesWithParams(force, keep, maxWait, machines...)
+}
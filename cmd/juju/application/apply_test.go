@@ -0,0 +1,318 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiapplication "github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type applySuite struct {
+	jujutesting.IsolationSuite
+
+	apiRoot     *mockAPIRoot
+	charmStore  *mockCharmStore
+	modelClient *mockModelClient
+	dir         string
+
+	pruneAPI   *mockApplyPruneAPI
+	deployArgs [][]string
+	deployErr  error
+}
+
+var _ = gc.Suite(&applySuite{})
+
+func (s *applySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.apiRoot = &mockAPIRoot{
+		responses:                 makeAPIResponses(),
+		bestFacadeVersion:         make(map[string]int),
+		bestFacadeVersionFallback: 42,
+	}
+	s.charmStore = &mockCharmStore{}
+	s.modelClient = &mockModelClient{
+		constraints: constraints.MustParse("arch=amd64"),
+	}
+	s.dir = c.MkDir()
+	s.pruneAPI = &mockApplyPruneAPI{}
+	s.deployArgs = nil
+	s.deployErr = nil
+}
+
+func (s *applySuite) runApply(c *gc.C, stdin string, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	store.Models["enz"] = &jujuclient.ControllerModels{
+		CurrentModel: "golden/horse",
+		Models: map[string]jujuclient.ModelDetails{"golden/horse": {
+			ModelType: model.IAAS,
+		}},
+	}
+	command := application.NewApplyCommandForTest(s.apiRoot,
+		func(base.APICallCloser, *charm.URL) (application.BundleResolver, error) {
+			return s.charmStore, nil
+		},
+		func() (application.ModelConstraintsClient, error) {
+			return s.modelClient, nil
+		},
+		s.pruneAPI,
+		func(_ *cmd.Context, args []string) error {
+			s.deployArgs = append(s.deployArgs, args)
+			return s.deployErr
+		},
+		store,
+	)
+	ctx := cmdtesting.Context(c)
+	ctx.Dir = s.dir
+	ctx.Stdin = strings.NewReader(stdin)
+	if err := cmdtesting.InitCommand(command, args); err != nil {
+		return ctx, err
+	}
+	return ctx, command.Run(ctx)
+}
+
+func (s *applySuite) writeLocalBundle(c *gc.C, content string) string {
+	path := filepath.Join(s.dir, "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0666)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *applySuite) TestNoArgs(c *gc.C) {
+	_, err := s.runApply(c, "")
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
+
+func (s *applySuite) TestDryRun(c *gc.C) {
+	ctx, err := s.runApply(c, "", "--dry-run", "--prune", s.writeLocalBundle(c, testCharmStoreBundle))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  grafana:
+    missing: bundle
+  prometheus:
+    options:
+      ontology:
+        bundle: anselm
+        model: kant
+    constraints:
+      bundle: cores=4
+      model: cores=3
+machines:
+  "1":
+    missing: bundle
+`[1:])
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, `
+Removals:
+ - application grafana
+ - machine 1
+`[1:])
+	c.Check(s.deployArgs, gc.HasLen, 0)
+	s.pruneAPI.stub.CheckNoCalls(c)
+}
+
+func (s *applySuite) TestApplyWithoutPrune(c *gc.C) {
+	bundlePath := s.writeLocalBundle(c, testCharmStoreBundle)
+	ctx, err := s.runApply(c, "", bundlePath, "--map-machines", "0=1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "Use --prune to remove")
+	c.Check(s.deployArgs, jc.DeepEquals, [][]string{
+		{bundlePath, "--map-machines", "existing,0=1"},
+	})
+	s.pruneAPI.stub.CheckNoCalls(c)
+}
+
+func (s *applySuite) TestApplyPrune(c *gc.C) {
+	bundlePath := s.writeLocalBundle(c, testCharmStoreBundle)
+	ctx, err := s.runApply(c, "y\n", "--prune", bundlePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `
+WARNING! This command will remove from the model:
+ - application grafana
+ - machine 1
+Continue [y/N]? `[1:])
+	c.Check(s.deployArgs, gc.HasLen, 1)
+	s.pruneAPI.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"DestroyApplications", []interface{}{apiapplication.DestroyApplicationsParams{
+			Applications: []string{"grafana"},
+		}}},
+		{"DestroyMachinesWithParams", []interface{}{false, false, (*time.Duration)(nil), []string{"1"}}},
+	})
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "Removing application grafana\nRemoving machine 1\n")
+}
+
+func (s *applySuite) TestApplyPruneOffers(c *gc.C) {
+	status := s.apiRoot.responses["Client.FullStatus"].(params.FullStatus)
+	status.Offers = map[string]params.ApplicationOfferStatus{
+		"dashboards": {OfferName: "dashboards", ApplicationName: "grafana"},
+		"metrics":    {OfferName: "metrics", ApplicationName: "prometheus"},
+		"scrape":     {OfferName: "scrape", ApplicationName: "prometheus"},
+	}
+	s.apiRoot.responses["Client.FullStatus"] = status
+	ctx, err := s.runApply(c, "", "--prune", "--yes", s.writeLocalBundle(c, withOffer))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, `
+Removals:
+ - offer dashboards
+ - offer metrics
+ - application grafana
+ - machine 1
+`[1:])
+	s.pruneAPI.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"DestroyOffers", []interface{}{false, []string{"king/sword.dashboards"}}},
+		{"DestroyOffers", []interface{}{false, []string{"king/sword.metrics"}}},
+		{"DestroyApplications", []interface{}{apiapplication.DestroyApplicationsParams{
+			Applications: []string{"grafana"},
+		}}},
+		{"DestroyMachinesWithParams", []interface{}{false, false, (*time.Duration)(nil), []string{"1"}}},
+	})
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "Removed offer dashboards\nRemoved offer metrics\n")
+}
+
+func (s *applySuite) TestApplyTrust(c *gc.C) {
+	bundlePath := s.writeLocalBundle(c, testCharmStoreBundle)
+	_, err := s.runApply(c, "", bundlePath, "--trust")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.deployArgs, jc.DeepEquals, [][]string{
+		{bundlePath, "--map-machines", "existing", "--trust"},
+	})
+}
+
+func (s *applySuite) TestSeriesAndArchNotSupported(c *gc.C) {
+	bundlePath := s.writeLocalBundle(c, testCharmStoreBundle)
+	_, err := s.runApply(c, "", bundlePath, "--series", "focal")
+	c.Assert(err, gc.ErrorMatches, "--series and --arch are not supported by apply, .*")
+	_, err = s.runApply(c, "", bundlePath, "--arch", "arm64")
+	c.Assert(err, gc.ErrorMatches, "--series and --arch are not supported by apply, .*")
+}
+
+func (s *applySuite) TestApplyPruneAborted(c *gc.C) {
+	_, err := s.runApply(c, "n\n", "--prune", s.writeLocalBundle(c, testCharmStoreBundle))
+	c.Assert(err, gc.ErrorMatches, "pruning model: aborted")
+	c.Check(s.deployArgs, gc.HasLen, 0)
+	s.pruneAPI.stub.CheckNoCalls(c)
+}
+
+func (s *applySuite) TestApplyPruneRelations(c *gc.C) {
+	s.apiRoot.responses = makeAPIResponsesWithRelations([]params.RelationStatus{{
+		Endpoints: []params.EndpointStatus{
+			{ApplicationName: "prometheus", Name: "juju-info"},
+			{ApplicationName: "grafana", Name: "juju-info"},
+		},
+	}})
+	_, err := s.runApply(c, "", "--prune", "--yes", "--force", s.writeLocalBundle(c, withGrafana))
+	c.Assert(err, jc.ErrorIsNil)
+	s.pruneAPI.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"DestroyRelation", []interface{}{(*bool)(nil), (*time.Duration)(nil), []string{"grafana:juju-info", "prometheus:juju-info"}}},
+	})
+}
+
+func (s *applySuite) TestApplyPruneFailures(c *gc.C) {
+	s.pruneAPI.applicationErr = &params.Error{Message: "boom"}
+	ctx, err := s.runApply(c, "", "--prune", "--yes", "--force", s.writeLocalBundle(c, testCharmStoreBundle))
+	c.Assert(err, gc.ErrorMatches, "failed to remove application grafana")
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "ERROR removing application grafana: boom\nRemoving machine 1\n")
+	s.pruneAPI.stub.CheckCall(c, 1, "DestroyMachinesWithParams", true, false, (*time.Duration)(nil), []string{"1"})
+}
+
+func (s *applySuite) TestDeployError(c *gc.C) {
+	s.deployErr = errors.New("boom")
+	_, err := s.runApply(c, "", "--prune", "--yes", s.writeLocalBundle(c, testCharmStoreBundle))
+	c.Assert(err, gc.ErrorMatches, "deploying bundle: boom")
+	s.pruneAPI.stub.CheckNoCalls(c)
+}
+
+type mockApplyPruneAPI struct {
+	stub           jujutesting.Stub
+	applicationErr *params.Error
+}
+
+func (m *mockApplyPruneAPI) DestroyRelation(force *bool, maxWait *time.Duration, endpoints ...string) error {
+	m.stub.AddCall("DestroyRelation", force, maxWait, endpoints)
+	return m.stub.NextErr()
+}
+
+func (m *mockApplyPruneAPI) DestroyOffers(force bool, offerURLs ...string) error {
+	m.stub.AddCall("DestroyOffers", force, offerURLs)
+	return m.stub.NextErr()
+}
+
+func (m *mockApplyPruneAPI) DestroyApplications(in apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+	m.stub.AddCall("DestroyApplications", in)
+	results := make([]params.DestroyApplicationResult, len(in.Applications))
+	for i := range results {
+		results[i].Error = m.applicationErr
+	}
+	return results, m.stub.NextErr()
+}
+
+func (m *mockApplyPruneAPI) DestroyMachinesWithParams(force, keep bool, maxWait *time.Duration, machines ...string) ([]params.DestroyMachineResult, error) {
+	m.stub.AddCall("DestroyMachinesWithParams", force, keep, maxWait, machines)
+	return make([]params.DestroyMachineResult, len(machines)), m.stub.NextErr()
+}
+
+const withOffer = `
+applications:
+  prometheus:
+    charm: 'cs:prometheus2-7'
+    num_units: 1
+    series: xenial
+    options:
+      ontology: anselm
+    constraints: 'cores=4'
+    to:
+      - 0
+    offers:
+      scrape:
+        endpoints:
+          - target
+machines:
+  '0':
+    series: xenial
+`
+
+const withGrafana = `
+applications:
+  prometheus:
+    charm: 'cs:prometheus2-7'
+    num_units: 1
+    series: xenial
+    options:
+      ontology: kant
+    constraints: 'cores=3'
+    to:
+      - 0
+  grafana:
+    charm: 'ch:grafana-19'
+    num_units: 1
+    series: bionic
+    options:
+      ontology: kant
+    constraints: 'cores=3'
+    to:
+      - 1
+machines:
+  '0':
+    series: xenial
+  '1':
+    series: bionic
+`
@@ -0,0 +1,95 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var cancelHookHelpSummary = `
+Cancels the hooks currently running on units.`[1:]
+
+var cancelHookHelpDetails = `
+The hook running on each of the specified units is killed, along with any
+processes it started. The hook is treated as having failed, so the unit
+goes into an error state and can be retried with "juju resolved" once the
+cause of the hang is fixed.
+
+A unit which is not running a hook when asked is left alone.
+
+Hooks can also be stopped automatically with the "hook-timeout" and
+"application-hook-timeouts" model config settings.
+
+Examples:
+    juju cancel-hook mysql/0
+    juju cancel-hook mysql/0 wordpress/1
+
+See also:
+    resolved
+    model-config`
+
+// NewCancelHookCommand returns a command to cancel the hooks running
+// on units.
+func NewCancelHookCommand() cmd.Command {
+	cmd := &cancelHookCommand{}
+	cmd.newAPIFunc = func() (CancelHookAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type cancelHookCommand struct {
+	modelcmd.ModelCommandBase
+	unitNames  []string
+	newAPIFunc func() (CancelHookAPI, error)
+}
+
+func (c *cancelHookCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "cancel-hook",
+		Args:    "<unit> [<unit> ...]",
+		Purpose: cancelHookHelpSummary,
+		Doc:     cancelHookHelpDetails,
+	})
+}
+
+func (c *cancelHookCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit specified")
+	}
+	for _, u := range args {
+		if !names.IsValidUnit(u) {
+			return errors.NotValidf("unit name %q", u)
+		}
+	}
+	c.unitNames = args
+	return nil
+}
+
+// CancelHookAPI defines the API methods that the cancel-hook command uses.
+type CancelHookAPI interface {
+	Close() error
+	CancelHook(units []string) error
+}
+
+func (c *cancelHookCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.CancelHook(c.unitNames)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,78 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CancelHookSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockCancelHookAPI
+}
+
+func (s *CancelHookSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockCancelHookAPI{Stub: &testing.Stub{}}
+}
+
+var _ = gc.Suite(&CancelHookSuite{})
+
+func (s *CancelHookSuite) runCancelHook(c *gc.C, args ...string) error {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, application.NewCancelHookCommandForTest(s.mockAPI, store), args...)
+	return err
+}
+
+func (s *CancelHookSuite) TestCancelHookInvalidArguments(c *gc.C) {
+	err := s.runCancelHook(c)
+	c.Assert(err, gc.ErrorMatches, "no unit specified")
+
+	err = s.runCancelHook(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *CancelHookSuite) TestCancelHook(c *gc.C) {
+	err := s.runCancelHook(c, "mysql/0", "wordpress/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "CancelHook", []string{"mysql/0", "wordpress/1"})
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *CancelHookSuite) TestCancelHookFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	err := s.runCancelHook(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *CancelHookSuite) TestCancelHookBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(apiservererrors.OperationBlockedError("TestCancelHookBlocked"))
+	err := s.runCancelHook(c, "mysql/0")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestCancelHookBlocked.*")
+}
+
+type mockCancelHookAPI struct {
+	*testing.Stub
+}
+
+func (s *mockCancelHookAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockCancelHookAPI) CancelHook(units []string) error {
+	s.MethodCall(s, "CancelHook", units)
+	return s.NextErr()
+}
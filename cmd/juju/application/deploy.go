@@ -259,8 +259,8 @@ type DeployCommand struct {
 	// running an unsupported series.
 	Force bool
 
-	// DryRun is used to specify that the bundle shouldn't actually be
-	// deployed but just output the changes.
+	// DryRun is used to specify that the charm or bundle shouldn't
+	// actually be deployed but just output the changes.
 	DryRun bool
 
 	ApplicationName  string
@@ -337,6 +337,7 @@ type DeployCommand struct {
 	Trust      bool
 	machineMap string
 	flagSet    *gnuflag.FlagSet
+	out        cmd.Output
 
 	unknownModel bool
 }
@@ -517,6 +518,10 @@ for security purposes by allowing only certain configurations and devices. Use
 the '--force' option to bypass this check. Doing so is not recommended as it
 can lead to unexpected behaviour.
 
+Use the '--dry-run' option to show the changes a deploy would make without
+making them. Each change is listed with its ID, the changes it requires and
+its arguments when '--format' is 'yaml' or 'json'.
+
 Further reading: https://jaas.ai/docs/deploying-applications
 
 Examples:
@@ -566,6 +571,10 @@ Deploy to a machine that is in the 'dmz' network space but not in either the
 
     juju deploy haproxy -n 2 --constraints spaces=dmz,^cms,^database
 
+Show the changes needed to deploy a bundle, as YAML:
+
+    juju deploy mybundle --dry-run --format yaml
+
 Deploy a k8s charm that requires a single Nvidia GPU:
 
     juju deploy mycharm --device miner=1,nvidia.com/gpu
@@ -610,7 +619,8 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.BundleOverlayFile), "overlay", "Bundles to overlay on the primary bundle, applied in order")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
-	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the bundle deploy would do")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the charm or bundle deploy would do")
+	c.out.AddFlags(f, "human", deployer.DryRunFormatters())
 	f.BoolVar(&c.Force, "force", false, "Allow a charm/bundle to be deployed which bypasses checks such as supported series or LXD profile allow list")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(devicesFlag{&c.Devices, &c.BundleDevices}, "device", "Charm device constraints")
@@ -659,6 +669,10 @@ func (c *DeployCommand) Init(args []string) error {
 	c.UseExisting = useExisting
 	c.BundleMachines = mapping
 
+	if c.out.Name() != "human" && !c.DryRun {
+		return errors.New("--format is only supported with --dry-run")
+	}
+
 	if err := c.UnitCommandBase.Init(args); err != nil {
 		return err
 	}
@@ -852,6 +866,7 @@ func (c *DeployCommand) getDeployerFactory() (deployer.DeployerFactory, deployer
 		ModelConstraints:  c.ModelConstraints,
 		Devices:           c.Devices,
 		DryRun:            c.DryRun,
+		WriteDryRun:       c.writeDryRun,
 		FlagSet:           c.flagSet,
 		Force:             c.Force,
 		NumUnits:          c.NumUnits,
@@ -866,6 +881,12 @@ func (c *DeployCommand) getDeployerFactory() (deployer.DeployerFactory, deployer
 	return c.NewDeployerFactory(dep), cfg
 }
 
+// writeDryRun writes the changes a dry run would make, in the format
+// chosen with --format.
+func (c *DeployCommand) writeDryRun(ctx *cmd.Context, plan deployer.DryRunPlan) error {
+	return c.out.Write(ctx, plan)
+}
+
 func (c *DeployCommand) getCharmHubURL(apiRoot base.APICallCloser) (string, error) {
 	modelConfigClient := c.NewModelConfigClient(apiRoot)
 	defer func() { _ = modelConfigClient.Close() }()
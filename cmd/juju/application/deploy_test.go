@@ -287,6 +287,9 @@ var initErrorTests = []struct {
 	}, {
 		args: []string{"bundle", "--map-machines", "foo"},
 		err:  `error in --map-machines: expected "existing" or "<bundle-id>=<machine-id>", got "foo"`,
+	}, {
+		args: []string{"charm", "--format", "yaml"},
+		err:  `--format is only supported with --dry-run`,
 	},
 }
 
@@ -1400,7 +1403,7 @@ func (s *DeploySuite) TestDeployFlags(c *gc.C) {
 	c.Assert(command.flagSet, jc.DeepEquals, flagSet)
 	// Add to the slice below if a new flag is introduced which is valid for
 	// both charms and bundles.
-	charmAndBundleFlags := []string{"channel", "storage", "device", "force", "trust"}
+	charmAndBundleFlags := []string{"channel", "storage", "device", "force", "trust", "dry-run", "format", "o"}
 	var allFlags []string
 	flagSet.VisitAll(func(flag *gnuflag.Flag) {
 		allFlags = append(allFlags, flag.Name)
@@ -2506,8 +2509,9 @@ func (m deployerConfigMatcher) Matches(x interface{}) bool {
 	if !ok {
 		return false
 	}
-	// FlagSet validation is not required for these tests.
+	// FlagSet and WriteDryRun validation is not required for these tests.
 	obtained.FlagSet = nil
+	obtained.WriteDryRun = nil
 	m.c.Assert(obtained, jc.DeepEquals, m.expected)
 	return true
 }
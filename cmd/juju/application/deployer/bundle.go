@@ -26,9 +26,10 @@ type deployBundle struct {
 	model ModelCommand
 	steps []DeployStep
 
-	dryRun bool
-	force  bool
-	trust  bool
+	dryRun      bool
+	writeDryRun DryRunWriter
+	force       bool
+	trust       bool
 
 	bundleDataSource  charm.BundleDataSource
 	bundleDir         string
@@ -183,6 +184,7 @@ func (d *deployBundle) makeBundleDeploySpec(ctx *cmd.Context, apiRoot DeployerAP
 		ctx:                  ctx,
 		filesystem:           d.model.Filesystem(),
 		dryRun:               d.dryRun,
+		writeDryRun:          d.writeDryRun,
 		force:                d.force,
 		trust:                d.trust,
 		bundleDataSource:     d.bundleDataSource,
@@ -52,9 +52,10 @@ type bundleDeploySpec struct {
 	ctx        *cmd.Context
 	filesystem modelcmd.Filesystem
 
-	dryRun bool
-	force  bool
-	trust  bool
+	dryRun      bool
+	writeDryRun DryRunWriter
+	force       bool
+	trust       bool
 
 	bundleDataSource  charm.BundleDataSource
 	bundleDir         string
@@ -106,9 +107,10 @@ func bundleDeploy(bundleData *charm.BundleData, spec bundleDeploySpec) (map[char
 
 // bundleHandler provides helpers and the state required to deploy a bundle.
 type bundleHandler struct {
-	dryRun bool
-	force  bool
-	trust  bool
+	dryRun      bool
+	writeDryRun DryRunWriter
+	force       bool
+	trust       bool
 
 	clock jujuclock.Clock
 
@@ -219,6 +221,7 @@ func makeBundleHandler(bundleData *charm.BundleData, spec bundleDeploySpec) *bun
 		clock: jujuclock.WallClock,
 
 		dryRun:               spec.dryRun,
+		writeDryRun:          spec.writeDryRun,
 		force:                spec.force,
 		trust:                spec.trust,
 		bundleDir:            spec.bundleDir,
@@ -486,15 +489,22 @@ func (h *bundleHandler) handleChanges() error {
 
 	if len(h.changes) == 0 {
 		h.ctx.Infof("No changes to apply.")
-		return nil
+		// An empty plan is still written, so that machine readable
+		// output is always produced for a dry run.
+		if !h.dryRun {
+			return nil
+		}
 	}
-
 	if h.dryRun {
-		fmt.Fprintf(h.ctx.Stdout, "Changes to deploy bundle:\n")
-	} else {
-		fmt.Fprintf(h.ctx.Stdout, "Executing changes:\n")
+		plan, err := bundleDryRunPlan(h.changes)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(h.writeDryRun.write(h.ctx, plan))
 	}
 
+	fmt.Fprintf(h.ctx.Stdout, "Executing changes:\n")
+
 	// Deploy the bundle.
 	for i, change := range h.changes {
 		fmt.Fprint(h.ctx.Stdout, fmtChange(change))
@@ -536,9 +546,7 @@ func (h *bundleHandler) handleChanges() error {
 		}
 	}
 
-	if !h.dryRun {
-		h.ctx.Infof("Deploy of bundle completed.")
-	}
+	h.ctx.Infof("Deploy of bundle completed.")
 
 	return nil
 }
@@ -557,9 +565,6 @@ func (h *bundleHandler) isLocalCharm(name string) bool {
 
 // addCharm adds a charm to the environment.
 func (h *bundleHandler) addCharm(change *bundlechanges.AddCharmChange) error {
-	if h.dryRun {
-		return nil
-	}
 	id := change.Id()
 	chParams := change.Params
 
@@ -699,10 +704,6 @@ func (h *bundleHandler) makeResourceMap(meta map[string]resource.Meta, storeReso
 // addApplication deploys an application with no units.
 func (h *bundleHandler) addApplication(change *bundlechanges.AddApplicationChange) error {
 	// TODO: add verbose output for details
-	if h.dryRun {
-		return nil
-	}
-
 	p := change.Params
 	curl, err := resolveCharmURL(resolve(p.Charm, h.results))
 	if err != nil {
@@ -918,10 +919,6 @@ func (h *bundleHandler) writeAddedResources(resNames2IDs map[string]string) {
 
 // scaleApplication updates the number of units for an application.
 func (h *bundleHandler) scaleApplication(change *bundlechanges.ScaleChange) error {
-	if h.dryRun {
-		return nil
-	}
-
 	p := change.Params
 
 	result, err := h.deployAPI.ScaleApplication(application.ScaleApplicationParams{
@@ -950,9 +947,6 @@ func (h *bundleHandler) addMachine(change *bundlechanges.AddMachineChange) error
 	if output := strings.Join(verbose, ", "); output != "" {
 		h.ctx.Verbosef("  %s", output)
 	}
-	if h.dryRun {
-		return nil
-	}
 
 	deployedApps := func() string {
 		apps := h.applicationsForMachineChange(change.Id())
@@ -1030,9 +1024,6 @@ func (h *bundleHandler) addMachine(change *bundlechanges.AddMachineChange) error
 
 // addRelation creates a relationship between two applications.
 func (h *bundleHandler) addRelation(change *bundlechanges.AddRelationChange) error {
-	if h.dryRun {
-		return nil
-	}
 	p := change.Params
 	ep1 := resolveRelation(p.Endpoint1, h.results)
 	ep2 := resolveRelation(p.Endpoint2, h.results)
@@ -1052,10 +1043,6 @@ func (h *bundleHandler) addRelation(change *bundlechanges.AddRelationChange) err
 
 // addUnit adds a single unit to an application already present in the environment.
 func (h *bundleHandler) addUnit(change *bundlechanges.AddUnitChange) error {
-	if h.dryRun {
-		return nil
-	}
-
 	p := change.Params
 	applicationName := resolve(p.Application, h.results)
 	var err error
@@ -1114,10 +1101,6 @@ func (h *bundleHandler) addUnit(change *bundlechanges.AddUnitChange) error {
 
 // upgradeCharm will get the application to use the new charm.
 func (h *bundleHandler) upgradeCharm(change *bundlechanges.UpgradeCharmChange) error {
-	if h.dryRun {
-		return nil
-	}
-
 	p := change.Params
 	resolvedCharm := resolve(p.Charm, h.results)
 	curl, err := resolveCharmURL(resolvedCharm)
@@ -1206,9 +1189,6 @@ func (h *bundleHandler) setOptions(change *bundlechanges.SetOptionsChange) error
 			h.ctx.Verbosef("    %s: %v", key, value)
 		}
 	}
-	if h.dryRun {
-		return nil
-	}
 
 	// We know that there wouldn't be any setOptions if there were no options.
 	cfg, err := yaml.Marshal(map[string]map[string]interface{}{p.Application: p.Options})
@@ -1222,9 +1202,6 @@ func (h *bundleHandler) setOptions(change *bundlechanges.SetOptionsChange) error
 
 // setConstraints updates application constraints.
 func (h *bundleHandler) setConstraints(change *bundlechanges.SetConstraintsChange) error {
-	if h.dryRun {
-		return nil
-	}
 	p := change.Params
 	// We know that p.constraints is a valid constraints type due to the validation.
 	cons, _ := constraints.Parse(p.Constraints)
@@ -1238,10 +1215,6 @@ func (h *bundleHandler) setConstraints(change *bundlechanges.SetConstraintsChang
 
 // exposeApplication exposes an application.
 func (h *bundleHandler) exposeApplication(change *bundlechanges.ExposeChange) error {
-	if h.dryRun {
-		return nil
-	}
-
 	application := resolve(change.Params.Application, h.results)
 	exposedEndpoints := make(map[string]params.ExposedEndpoint)
 	for endpointName, exposeDetails := range change.Params.ExposedEndpoints {
@@ -1264,9 +1237,7 @@ func (h *bundleHandler) setAnnotations(change *bundlechanges.SetAnnotationsChang
 	for key, value := range p.Annotations {
 		h.ctx.Verbosef("    %s: %q", key, value)
 	}
-	if h.dryRun {
-		return nil
-	}
+
 	eid := resolve(p.Id, h.results)
 	var tag string
 	switch p.EntityType {
@@ -1289,10 +1260,6 @@ func (h *bundleHandler) setAnnotations(change *bundlechanges.SetAnnotationsChang
 
 // createOffer creates an offer targeting one or more application endpoints.
 func (h *bundleHandler) createOffer(change *bundlechanges.CreateOfferChange) error {
-	if h.dryRun {
-		return nil
-	}
-
 	p := change.Params
 	result, err := h.deployAPI.Offer(h.targetModelUUID, p.Application, p.Endpoints, p.OfferName, "")
 	if err == nil && len(result) > 0 && result[0].Error != nil {
@@ -1303,10 +1270,6 @@ func (h *bundleHandler) createOffer(change *bundlechanges.CreateOfferChange) err
 
 // consumeOffer consumes an existing offer
 func (h *bundleHandler) consumeOffer(change *bundlechanges.ConsumeOfferChange) error {
-	if h.dryRun {
-		return nil
-	}
-
 	p := change.Params
 	url, err := charm.ParseOfferURL(p.URL)
 	if err != nil {
@@ -1376,10 +1339,6 @@ func (h *bundleHandler) consumeOffer(change *bundlechanges.ConsumeOfferChange) e
 
 // grantOfferAccess grants access to an offer.
 func (h *bundleHandler) grantOfferAccess(change *bundlechanges.GrantOfferAccessChange) error {
-	if h.dryRun {
-		return nil
-	}
-
 	p := change.Params
 
 	offerURL := fmt.Sprintf("%s.%s", h.targetModelName, p.Offer)
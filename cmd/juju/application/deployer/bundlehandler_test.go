@@ -12,7 +12,9 @@ import (
 	charm "github.com/juju/charm/v9"
 	charmresource "github.com/juju/charm/v9/resource"
 	"github.com/juju/cmd"
+	"github.com/juju/description/v2"
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -23,7 +25,9 @@ import (
 	commoncharm "github.com/juju/juju/api/common/charm"
 	apicharms "github.com/juju/juju/api/common/charms"
 	"github.com/juju/juju/api/resources/client"
+	bundleFacade "github.com/juju/juju/apiserver/facades/client/bundle"
 	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cmd/juju/application/deployer/mocks"
 	"github.com/juju/juju/cmd/modelcmd"
 	corecharm "github.com/juju/juju/core/charm"
@@ -31,7 +35,9 @@ import (
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
@@ -482,6 +488,112 @@ relations:
     - ["dashboard4miner:miner", "bitcoin-miner:miner"]
 `
 
+func (s *BundleDeployRepositorySuite) TestDeployExportedBundle(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.expectEmptyModelToStart(c)
+	s.expectWatchAll()
+
+	// Export a model with storage and devices as a complete bundle.
+	source := description.NewModel(description.ModelArgs{
+		Owner:       names.NewUserTag("magic"),
+		Config:      map[string]interface{}{"name": "source", "uuid": "some-uuid"},
+		CloudRegion: "some-region",
+	})
+	source.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("0"),
+		Nonce:  "a-nonce",
+		Series: "bionic",
+		Jobs:   []string{"host-units"},
+	})
+	app := source.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("ubuntu"),
+		Series:   "bionic",
+		Type:     description.IAAS,
+		CharmURL: "cs:ubuntu-4",
+		StorageConstraints: map[string]description.StorageConstraintArgs{
+			"data": {Pool: "ebs", Size: 10240, Count: 2},
+		},
+	})
+	app.SetStatus(description.StatusArgs{Value: "running"})
+	unit := app.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("ubuntu/0"),
+		Type:    description.IAAS,
+		Machine: names.NewMachineTag("0"),
+	})
+	unit.SetAgentStatus(description.StatusArgs{Value: "running"})
+	source.SetStatus(description.StatusArgs{Value: "available"})
+
+	api, err := bundleFacade.NewBundleAPI(&exportBackend{
+		model: source,
+		devices: map[string]map[string]state.DeviceConstraints{
+			"ubuntu": {
+				"bitcoinminer": {
+					Type:       "nvidia.com/gpu",
+					Count:      2,
+					Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
+				},
+			},
+		},
+	}, apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("read")}, names.NewModelTag("some-uuid"))
+	c.Assert(err, jc.ErrorIsNil)
+	exported, err := (&bundleFacade.APIv5{BundleAPI: api}).ExportBundle(params.ExportBundleParams{Complete: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exported.Warnings, jc.DeepEquals, []string{
+		`model config is not exported and must be set with "juju model-config" before deploying the bundle`,
+	})
+
+	// Deploy the exported bundle into an empty model.
+	ubuntuCurl, err := charm.ParseURL("cs:ubuntu-4")
+	c.Assert(err, jc.ErrorIsNil)
+	s.setupCharmUnits([]charmUnit{{
+		curl:            ubuntuCurl,
+		charmMetaSeries: []string{"bionic", "xenial"},
+		machine:         "0",
+		machineSeries:   "bionic",
+	}})
+
+	bundleData, err := charm.ReadBundleData(strings.NewReader(exported.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = bundleDeploy(bundleData, s.bundleDeploySpec())
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("bundle:\n%s", exported.Result))
+	c.Assert(s.deployArgs, gc.HasLen, 1)
+	s.assertDeployArgs(c, ubuntuCurl.String(), "ubuntu", "bionic")
+	s.assertDeployArgsStorage(c, "ubuntu", map[string]storage.Constraints{
+		"data": {Pool: "ebs", Size: 10240, Count: 2},
+	})
+	s.assertDeployArgsDevices(c, "ubuntu", map[string]devices.Constraints{
+		"bitcoinminer": {
+			Type:       "nvidia.com/gpu",
+			Count:      2,
+			Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
+		},
+	})
+}
+
+// exportBackend is the state backing the bundle facade when exporting
+// a bundle to deploy.
+type exportBackend struct {
+	bundleFacade.Backend
+	model   description.Model
+	devices map[string]map[string]state.DeviceConstraints
+}
+
+func (b *exportBackend) ExportPartial(state.ExportConfig) (description.Model, error) {
+	return b.model, nil
+}
+
+func (b *exportBackend) GetExportConfig() state.ExportConfig {
+	return state.ExportConfig{}
+}
+
+func (b *exportBackend) DeviceConstraints(appName string) (map[string]state.DeviceConstraints, error) {
+	return b.devices[appName], nil
+}
+
+func (b *exportBackend) AllSpaceInfos() (network.SpaceInfos, error) {
+	return nil, nil
+}
+
 func (s *BundleDeployRepositorySuite) TestDryRunExistingModel(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 	s.expectEmptyModelToStart(c)
@@ -549,6 +661,46 @@ func (s *BundleDeployRepositorySuite) TestDryRunExistingModel(c *gc.C) {
 	c.Check(s.output.String(), gc.Equals, expectedOutput)
 }
 
+func (s *BundleDeployRepositorySuite) TestDryRunWritesPlan(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.expectEmptyModelToStart(c)
+	s.expectWatchAll()
+	s.expectResolveCharm(nil, 1)
+
+	quickBundle := `
+       series: bionic
+       applications:
+           wp:
+               charm: cs:wordpress-47
+               num_units: 1
+   `
+
+	bundleData, err := charm.ReadBundleData(strings.NewReader(quickBundle))
+	c.Assert(err, jc.ErrorIsNil)
+	spec := s.bundleDeploySpec()
+	spec.dryRun = true
+	var plan DryRunPlan
+	spec.writeDryRun = func(_ *cmd.Context, p DryRunPlan) error {
+		plan = p
+		return nil
+	}
+	_, err = bundleDeploy(bundleData, spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.deployArgs, gc.HasLen, 0)
+
+	c.Check(plan.Title, gc.Equals, "Changes to deploy bundle")
+	c.Assert(plan.Changes, gc.HasLen, 3)
+	c.Check(plan.Changes[0].Id, gc.Equals, "addCharm-0")
+	c.Check(plan.Changes[0].Method, gc.Equals, "addCharm")
+	c.Check(plan.Changes[0].Args["charm"], gc.Equals, "cs:wordpress-47")
+	c.Check(plan.Changes[1].Id, gc.Equals, "deploy-1")
+	c.Check(plan.Changes[1].Method, gc.Equals, "deploy")
+	c.Check(plan.Changes[1].Requires, jc.DeepEquals, []string{"addCharm-0"})
+	c.Check(plan.Changes[2].Id, gc.Equals, "addUnit-2")
+	c.Check(plan.Changes[2].Requires, jc.DeepEquals, []string{"deploy-1"})
+	c.Check(s.output.String(), gc.Not(jc.Contains), "Executing changes:")
+}
+
 func (s *BundleDeployRepositorySuite) TestDeployBundleInvalidMachineContainerType(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 	s.expectEmptyModelToStart(c)
@@ -43,6 +43,8 @@ type deployCharm struct {
 	csMac            *macaroon.Macaroon
 	devices          map[string]devices.Constraints
 	deployResources  resourceadapters.DeployResourcesFunc
+	dryRun           bool
+	writeDryRun      DryRunWriter
 	force            bool
 	id               application.CharmID
 	flagSet          *gnuflag.FlagSet
@@ -267,9 +269,8 @@ func (d *deployCharm) deploy(
 
 var (
 	// BundleOnlyFlags represents what flags are used for bundles only.
-	// TODO(thumper): support dry-run for apps as well as bundles.
 	BundleOnlyFlags = []string{
-		"overlay", "dry-run", "map-machines",
+		"overlay", "map-machines",
 	}
 )
 
@@ -359,6 +360,11 @@ func (d *predeployedLocalCharm) PrepareAndDeploy(ctx *cmd.Context, deployAPI Dep
 	}
 	d.series = userCharmURL.Series
 	d.origin = origin
+	if d.dryRun {
+		// The charm is already in the model, so only the
+		// application needs deploying.
+		return errors.Trace(d.writeDryRun.write(ctx, d.charmDryRunPlan(userCharmURL, origin, false)))
+	}
 	return d.deploy(ctx, deployAPI)
 }
 
@@ -381,12 +387,20 @@ func (l *localCharm) PrepareAndDeploy(ctx *cmd.Context, deployAPI DeployerAPI, _
 		return errors.Trace(err)
 	}
 
-	curl, err := deployAPI.AddLocalCharm(l.curl, l.ch, l.force)
+	platform, err := utils.DeducePlatform(l.constraints, l.curl.Series, l.modelConstraints)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if l.dryRun {
+		origin, err := utils.DeduceOrigin(l.curl, corecharm.Channel{}, platform)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		l.series = l.curl.Series
+		return errors.Trace(l.writeDryRun.write(ctx, l.charmDryRunPlan(l.curl, origin, true)))
+	}
 
-	platform, err := utils.DeducePlatform(l.constraints, l.curl.Series, l.modelConstraints)
+	curl, err := deployAPI.AddLocalCharm(l.curl, l.ch, l.force)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -508,6 +522,11 @@ func (c *repositoryCharm) PrepareAndDeploy(ctx *cmd.Context, deployAPI DeployerA
 		deployableURL = storeCharmOrBundleURL.WithSeries(c.origin.Series)
 	}
 
+	if c.dryRun {
+		c.series = series
+		return errors.Trace(c.writeDryRun.write(ctx, c.charmDryRunPlan(deployableURL, c.origin, true)))
+	}
+
 	// Store the charm in the controller
 	curl, csMac, csOrigin, err := store.AddCharmWithAuthorizationFromURL(deployAPI, macaroonGetter, deployableURL, c.origin, c.force)
 	if err != nil {
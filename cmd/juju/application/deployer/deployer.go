@@ -85,6 +85,7 @@ func (d *factory) setConfig(cfg DeployerConfig) {
 	d.series = cfg.Series
 	d.force = cfg.Force
 	d.dryRun = cfg.DryRun
+	d.writeDryRun = cfg.WriteDryRun
 	d.applicationName = cfg.ApplicationName
 	d.configOptions = cfg.ConfigOptions
 	d.constraints = cfg.Constraints
@@ -133,6 +134,7 @@ type DeployerConfig struct {
 	Devices              map[string]devices.Constraints
 	DeployResources      resourceadapters.DeployResourcesFunc
 	DryRun               bool
+	WriteDryRun          DryRunWriter
 	FlagSet              *gnuflag.FlagSet
 	Force                bool
 	NewConsumeDetailsAPI func(url *charm.OfferURL) (ConsumeDetails, error)
@@ -164,6 +166,7 @@ type factory struct {
 	series            string
 	force             bool
 	dryRun            bool
+	writeDryRun       DryRunWriter
 	applicationName   string
 	configOptions     common.ConfigFlag
 	constraints       constraints.Value
@@ -218,6 +221,8 @@ func (d *factory) newDeployCharm() deployCharm {
 		modelConstraints: d.modelConstraints,
 		devices:          d.devices,
 		deployResources:  d.deployResources,
+		dryRun:           d.dryRun,
+		writeDryRun:      d.writeDryRun,
 		flagSet:          d.flagSet,
 		force:            d.force,
 		model:            d.model,
@@ -282,6 +287,7 @@ func (d *factory) newDeployBundle(ds charm.BundleDataSource) deployBundle {
 		model:                d.model,
 		steps:                d.steps,
 		dryRun:               d.dryRun,
+		writeDryRun:          d.writeDryRun,
 		force:                d.force,
 		trust:                d.trust,
 		bundleDataSource:     ds,
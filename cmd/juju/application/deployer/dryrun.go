@@ -0,0 +1,212 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/bundlechanges/v5"
+	"github.com/juju/charm/v9"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	commoncharm "github.com/juju/juju/api/common/charm"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/storage"
+)
+
+// DryRunPlan holds the changes a deploy or refresh would make, as shown
+// by --dry-run.
+type DryRunPlan struct {
+	// Title introduces the changes in human readable output.
+	Title string `yaml:"-" json:"-"`
+
+	// Changes holds the changes in the order they would be made.
+	Changes []DryRunChange `yaml:"changes" json:"changes"`
+}
+
+// DryRunChange describes a single change in a dry-run plan. Arguments
+// which refer to the result of an earlier change hold its ID prefixed
+// with "$", as in bundle change lists.
+type DryRunChange struct {
+	Id          string                 `yaml:"id" json:"id"`
+	Method      string                 `yaml:"method" json:"method"`
+	Args        map[string]interface{} `yaml:"args,omitempty" json:"args,omitempty"`
+	Requires    []string               `yaml:"requires,omitempty" json:"requires,omitempty"`
+	Description []string               `yaml:"description" json:"description"`
+}
+
+// DryRunFormatters returns the formatters for the --format flag of
+// commands with a dry-run plan. The human format is the default.
+func DryRunFormatters() map[string]cmd.Formatter {
+	return map[string]cmd.Formatter{
+		"human": formatDryRunHuman,
+		"yaml":  cmd.FormatYaml,
+		"json":  cmd.FormatJson,
+	}
+}
+
+// formatDryRunHuman writes the description of each change in a plan.
+// Nothing is written for an empty plan.
+func formatDryRunHuman(writer io.Writer, value interface{}) error {
+	plan, ok := value.(DryRunPlan)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", plan, value)
+	}
+	if len(plan.Changes) == 0 {
+		return nil
+	}
+	fmt.Fprintf(writer, "%s:\n", plan.Title)
+	for _, change := range plan.Changes {
+		for _, desc := range change.Description {
+			fmt.Fprintf(writer, "- %s\n", desc)
+		}
+	}
+	return nil
+}
+
+// DryRunWriter writes a dry-run plan in the format chosen by the user.
+type DryRunWriter func(*cmd.Context, DryRunPlan) error
+
+// write writes the plan, as human readable text if w is nil.
+func (w DryRunWriter) write(ctx *cmd.Context, plan DryRunPlan) error {
+	if w == nil {
+		return formatDryRunHuman(ctx.Stdout, plan)
+	}
+	return w(ctx, plan)
+}
+
+// bundleDryRunPlan returns the plan for the changes needed to deploy a
+// bundle.
+func bundleDryRunPlan(changes []bundlechanges.Change) (DryRunPlan, error) {
+	plan := DryRunPlan{
+		Title:   "Changes to deploy bundle",
+		Changes: make([]DryRunChange, 0, len(changes)),
+	}
+	for _, change := range changes {
+		args, err := change.Args()
+		if err != nil {
+			return DryRunPlan{}, errors.Annotatef(err, "change %q", change.Id())
+		}
+		plan.Changes = append(plan.Changes, DryRunChange{
+			Id:          change.Id(),
+			Method:      change.Method(),
+			Args:        args,
+			Requires:    change.Requires(),
+			Description: change.Description(),
+		})
+	}
+	return plan, nil
+}
+
+// AddCharmDryRunChange returns the change which adds a charm to the
+// model, with the given ID.
+func AddCharmDryRunChange(id string, curl *charm.URL, origin commoncharm.Origin) DryRunChange {
+	args := map[string]interface{}{
+		"charm": curl.String(),
+	}
+	desc := "upload charm " + curl.String()
+	if origin.Series != "" {
+		args["series"] = origin.Series
+		desc += " for series " + origin.Series
+	}
+	if channel := origin.CoreChannel().String(); channel != "" {
+		args["channel"] = channel
+		desc += " from channel " + channel
+	}
+	return DryRunChange{
+		Id:          id,
+		Method:      "addCharm",
+		Args:        args,
+		Description: []string{desc},
+	}
+}
+
+// charmDryRunPlan returns the plan for deploying the charm. The charm
+// is added to the model first, unless it is already there.
+func (d *deployCharm) charmDryRunPlan(curl *charm.URL, origin commoncharm.Origin, addCharm bool) DryRunPlan {
+	plan := DryRunPlan{Title: "Changes to deploy charm"}
+	charmRef := curl.String()
+	var requires []string
+	if addCharm {
+		addCharmChange := AddCharmDryRunChange("addCharm-0", curl, origin)
+		plan.Changes = append(plan.Changes, addCharmChange)
+		charmRef = "$" + addCharmChange.Id
+		requires = []string{addCharmChange.Id}
+	}
+
+	applicationName := d.applicationName
+	if applicationName == "" {
+		applicationName = curl.Name
+	}
+	args := map[string]interface{}{
+		"application": applicationName,
+		"charm":       charmRef,
+		"num-units":   d.numUnits,
+	}
+	desc := fmt.Sprintf("deploy application %s with %d unit", applicationName, d.numUnits)
+	if d.numUnits != 1 {
+		desc += "s"
+	}
+	if d.series != "" {
+		args["series"] = d.series
+		desc += " on " + d.series
+	}
+	desc += " using " + curl.String()
+	if !constraints.IsEmpty(&d.constraints) {
+		args["constraints"] = d.constraints.String()
+	}
+	if d.placementSpec != "" {
+		args["placement"] = d.placementSpec
+	}
+	if len(d.bindings) > 0 {
+		args["endpoint-bindings"] = d.bindings
+	}
+	if len(d.resources) > 0 {
+		args["resources"] = d.resources
+	}
+	if len(d.storage) > 0 {
+		args["storage"] = storageArgs(d.storage)
+	}
+	if len(d.devices) > 0 {
+		devices := make(map[string]string)
+		for name, cons := range d.devices {
+			devices[name] = fmt.Sprintf("%s,%d", cons.Type, cons.Count)
+		}
+		args["devices"] = devices
+	}
+	if d.trust {
+		args["trust"] = true
+	}
+	plan.Changes = append(plan.Changes, DryRunChange{
+		Id:          fmt.Sprintf("deploy-%d", len(plan.Changes)),
+		Method:      "deploy",
+		Args:        args,
+		Requires:    requires,
+		Description: []string{desc},
+	})
+	return plan
+}
+
+// storageArgs returns the storage constraints in the form used on the
+// command line, keyed on storage name.
+func storageArgs(storageCons map[string]storage.Constraints) map[string]string {
+	result := make(map[string]string, len(storageCons))
+	for name, cons := range storageCons {
+		var parts []string
+		if cons.Pool != "" {
+			parts = append(parts, cons.Pool)
+		}
+		if cons.Size > 0 {
+			parts = append(parts, fmt.Sprintf("%dM", cons.Size))
+		}
+		if cons.Count > 0 {
+			parts = append(parts, fmt.Sprintf("%d", cons.Count))
+		}
+		result[name] = strings.Join(parts, ",")
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer
+
+import (
+	"bytes"
+
+	"github.com/juju/charm/v9"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	commoncharm "github.com/juju/juju/api/common/charm"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/storage"
+)
+
+type DryRunSuite struct{}
+
+var _ = gc.Suite(&DryRunSuite{})
+
+func (s *DryRunSuite) TestCharmDryRunPlan(c *gc.C) {
+	d := deployCharm{
+		applicationName: "db",
+		numUnits:        2,
+		series:          "focal",
+		constraints:     constraints.MustParse("mem=4G"),
+		placementSpec:   "0,1",
+		storage: map[string]storage.Constraints{
+			"data": {Pool: "ebs", Size: 1024, Count: 1},
+		},
+		trust: true,
+	}
+	curl := charm.MustParseURL("ch:amd64/focal/postgresql-12")
+	origin := commoncharm.Origin{
+		Source: commoncharm.OriginCharmHub,
+		Risk:   "stable",
+		Series: "focal",
+	}
+
+	plan := d.charmDryRunPlan(curl, origin, true)
+	c.Assert(plan, jc.DeepEquals, DryRunPlan{
+		Title: "Changes to deploy charm",
+		Changes: []DryRunChange{{
+			Id:     "addCharm-0",
+			Method: "addCharm",
+			Args: map[string]interface{}{
+				"charm":   "ch:amd64/focal/postgresql-12",
+				"series":  "focal",
+				"channel": "stable",
+			},
+			Description: []string{"upload charm ch:amd64/focal/postgresql-12 for series focal from channel stable"},
+		}, {
+			Id:     "deploy-1",
+			Method: "deploy",
+			Args: map[string]interface{}{
+				"application": "db",
+				"charm":       "$addCharm-0",
+				"num-units":   2,
+				"series":      "focal",
+				"constraints": "mem=4096M",
+				"placement":   "0,1",
+				"storage":     map[string]string{"data": "ebs,1024M,1"},
+				"trust":       true,
+			},
+			Requires:    []string{"addCharm-0"},
+			Description: []string{"deploy application db with 2 units on focal using ch:amd64/focal/postgresql-12"},
+		}},
+	})
+}
+
+func (s *DryRunSuite) TestCharmDryRunPlanPredeployed(c *gc.C) {
+	d := deployCharm{numUnits: 1}
+	curl := charm.MustParseURL("local:bionic/dummy-1")
+
+	plan := d.charmDryRunPlan(curl, commoncharm.Origin{Source: commoncharm.OriginLocal}, false)
+	c.Assert(plan.Changes, jc.DeepEquals, []DryRunChange{{
+		Id:     "deploy-0",
+		Method: "deploy",
+		Args: map[string]interface{}{
+			"application": "dummy",
+			"charm":       "local:bionic/dummy-1",
+			"num-units":   1,
+		},
+		Description: []string{"deploy application dummy with 1 unit using local:bionic/dummy-1"},
+	}})
+}
+
+func (s *DryRunSuite) TestFormatDryRunHuman(c *gc.C) {
+	var buf bytes.Buffer
+	err := formatDryRunHuman(&buf, DryRunPlan{
+		Title: "Changes to deploy bundle",
+		Changes: []DryRunChange{
+			{Description: []string{"upload charm mysql"}},
+			{Description: []string{"deploy application mysql"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(buf.String(), gc.Equals, "Changes to deploy bundle:\n- upload charm mysql\n- deploy application mysql\n")
+
+	buf.Reset()
+	err = formatDryRunHuman(&buf, DryRunPlan{Title: "Changes to deploy bundle"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(buf.String(), gc.Equals, "")
+}
@@ -197,45 +197,65 @@ func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
 	}
 	defer func() { _ = apiRoot.Close() }()
 
+	diff, err := c.bundleDiff(ctx, apiRoot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	encoder := yaml.NewEncoder(ctx.Stdout)
+	defer func() { _ = encoder.Close() }()
+	err = encoder.Encode(diff)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// bundleDiff reads the bundle, with its includes and overlays, and
+// the model, and returns the differences between them.
+func (c *diffBundleCommand) bundleDiff(ctx *cmd.Context, apiRoot base.APICallCloser) (*bundlechanges.BundleDiff, error) {
+	bundle, model, err := c.readBundleAndModel(ctx, apiRoot)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.buildDiff(bundle, model)
+}
+
+// readBundleAndModel reads the bundle, with its includes and overlays,
+// and the model.
+func (c *diffBundleCommand) readBundleAndModel(ctx *cmd.Context, apiRoot base.APICallCloser) (*charm.BundleData, *bundlechanges.Model, error) {
 	// Load up the bundle data, with includes and overlays.
 	baseSrc, err := c.bundleDataSource(ctx, apiRoot)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	bundle, err := appbundle.ComposeAndVerifyBundle(baseSrc, c.bundleOverlays)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	if err = c.warnForMissingRelationEndpoints(ctx, bundle); err != nil {
-		return errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	// Extract the information from the current model.
 	model, err := c.readModel(apiRoot)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
-	// Get the differences between them.
+	return bundle, model, nil
+}
+
+// buildDiff returns the differences between the bundle and the model.
+func (c *diffBundleCommand) buildDiff(bundle *charm.BundleData, model *bundlechanges.Model) (*bundlechanges.BundleDiff, error) {
 	diff, err := bundlechanges.BuildDiff(bundlechanges.DiffConfig{
 		Bundle:             bundle,
 		Model:              model,
 		Logger:             logger,
 		IncludeAnnotations: c.annotations,
 	})
-
-	if err != nil {
-		return errors.Trace(err)
-	}
-
-	encoder := yaml.NewEncoder(ctx.Stdout)
-	defer func() { _ = encoder.Close() }()
-	err = encoder.Encode(diff)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	return nil
+	return diff, errors.Trace(err)
 }
 
 func (c *diffBundleCommand) warnForMissingRelationEndpoints(ctx *cmd.Context, bundle *charm.BundleData) error {
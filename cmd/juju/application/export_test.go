@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/charm/v9"
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	gc "gopkg.in/check.v1"
@@ -178,6 +179,25 @@ func NewSuspendRelationCommandForTest(api SetRelationSuspendedAPI, store jujucli
 	return modelcmd.Wrap(cmd)
 }
 
+// NewCancelHookCommandForTest returns a cancelHookCommand with the api provided as specified.
+func NewCancelHookCommandForTest(api CancelHookAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &cancelHookCommand{newAPIFunc: func() (CancelHookAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowHookHistoryCommandForTest returns a showHookHistoryCommand with the api
+// and clock provided as specified.
+func NewShowHookHistoryCommandForTest(api HookHistoryAPI, clock clock.Clock, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &showHookHistoryCommand{clock: clock, newAPIFunc: func() (HookHistoryAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewResumeRelationCommandForTest returns a ResumeRelationCommand with the api provided as specified.
 func NewResumeRelationCommandForTest(api SetRelationSuspendedAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &resumeRelationCommand{newAPIFunc: func() (SetRelationSuspendedAPI, error) {
@@ -228,6 +248,33 @@ func NewDiffBundleCommandForTest(api base.APICallCloser,
 	return modelcmd.Wrap(cmd)
 }
 
+func NewApplyCommandForTest(api base.APICallCloser,
+	charmStoreFn func(base.APICallCloser, *charm.URL) (BundleResolver, error),
+	modelConsFn func() (ModelConstraintsClient, error),
+	pruneAPI ApplyPruneAPI,
+	deployFn func(*cmd.Context, []string) error,
+	store jujuclient.ClientStore,
+) modelcmd.ModelCommand {
+	cmd := &applyCommand{
+		diffBundleCommand: diffBundleCommand{
+			newAPIRootFn: func() (base.APICallCloser, error) {
+				return api, nil
+			},
+			newControllerAPIRootFn: func() (base.APICallCloser, error) {
+				return api, nil
+			},
+			charmAdaptorFn:             charmStoreFn,
+			modelConstraintsClientFunc: modelConsFn,
+		},
+		newPruneAPIFn: func(base.APICallCloser, base.APICallCloser) ApplyPruneAPI {
+			return pruneAPI
+		},
+		deployBundleFn: deployFn,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewShowCommandForTest(api ApplicationsInfoAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showApplicationCommand{newAPIFunc: func() (ApplicationsInfoAPI, error) {
 		return api, nil
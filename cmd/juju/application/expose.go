@@ -15,6 +15,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/network/firewall"
 )
 
@@ -64,6 +65,11 @@ juju to only allow access to ports opened for the "logs" endpoint from CIDR
 juju expose apache2 --endpoints logs --to-cidrs 10.0.0.0/24
 juju expose apache2 --endpoints logs --to-cidrs 192.168.0.0/24
 
+With --format yaml or json, the application and the expose settings which
+were applied to each of its endpoints are written to stdout:
+
+juju expose apache2 --endpoints logs --to-spaces public --format json
+
 See also: 
     unexpose`[1:]
 
@@ -79,6 +85,7 @@ type exposeCommand struct {
 	ExposedEndpointsList string
 	ExposeToSpacesList   string
 	ExposeToCIDRsList    string
+	out                  cmd.Output
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -95,6 +102,7 @@ func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.ExposedEndpointsList, "endpoints", "", "Expose only the ports that charms have opened for this comma-delimited list of endpoints")
 	f.StringVar(&c.ExposeToSpacesList, "to-spaces", "", "A comma-delimited list of spaces that should be able to access the application ports once exposed")
 	f.StringVar(&c.ExposeToCIDRsList, "to-cidrs", "", "A comma-delimited list of CIDRs that should be able to access the application ports once exposed")
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
 }
 
 func (c *exposeCommand) Init(args []string) error {
@@ -121,7 +129,7 @@ func (c *exposeCommand) getAPI() (applicationExposeAPI, error) {
 
 // Run changes the juju-managed firewall to expose any
 // ports that were also explicitly marked by units as open.
-func (c *exposeCommand) Run(_ *cmd.Context) error {
+func (c *exposeCommand) Run(ctx *cmd.Context) error {
 	client, err := c.getAPI()
 	if err != nil {
 		return err
@@ -129,7 +137,31 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 	defer client.Close()
 
 	exposedEndpoints := c.buildExposedEndpoints()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName, exposedEndpoints), block.BlockChange)
+	if err := client.Expose(c.ApplicationName, exposedEndpoints); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if c.out.Name() == output.HumanFormat {
+		return nil
+	}
+	out := exposedApplication{Application: c.ApplicationName}
+	if len(exposedEndpoints) != 0 {
+		out.ExposedEndpoints = make(map[string]ExposedEndpoint, len(exposedEndpoints))
+		for endpoint, exposeDetails := range exposedEndpoints {
+			out.ExposedEndpoints[endpoint] = ExposedEndpoint{
+				ExposeToSpaces: exposeDetails.ExposeToSpaces,
+				ExposeToCIDRs:  exposeDetails.ExposeToCIDRs,
+			}
+		}
+	}
+	return c.out.Write(ctx, out)
+}
+
+// exposedApplication is written by expose when a structured format is
+// requested. No exposed endpoints means that all the application's
+// ports are open to 0.0.0.0/0.
+type exposedApplication struct {
+	Application      string                     `yaml:"application" json:"application"`
+	ExposedEndpoints map[string]ExposedEndpoint `yaml:"exposed-endpoints,omitempty" json:"exposed-endpoints,omitempty"`
 }
 
 func (c *exposeCommand) buildExposedEndpoints() map[string]params.ExposedEndpoint {
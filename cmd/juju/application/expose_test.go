@@ -55,6 +55,22 @@ func (s *ExposeSuite) TestExpose(c *gc.C) {
 	})
 }
 
+func (s *ExposeSuite) TestExposeFormat(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "some-application-name"})
+
+	ctx, err := cmdtesting.RunCommand(c, NewExposeCommand(), "some-application-name",
+		"--to-cidrs", "10.0.0.0/24", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+application: some-application-name
+exposed-endpoints:
+  "":
+    expose-to-cidrs:
+    - 10.0.0.0/24
+`[1:])
+}
+
 func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "some-application-name"})
 
@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmhub"
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/application/deployer"
 	"github.com/juju/juju/cmd/juju/application/refresher"
 	"github.com/juju/juju/cmd/juju/application/store"
 	"github.com/juju/juju/cmd/juju/application/utils"
@@ -158,6 +159,11 @@ type refreshCommand struct {
 	CharmPath   string
 	Revision    int // defaults to -1 (latest)
 
+	// DryRun shows the changes the refresh would make, without making
+	// them.
+	DryRun bool
+	out    cmd.Output
+
 	BindToSpaces string
 	Bindings     map[string]string
 
@@ -258,6 +264,12 @@ unexpected behavior.
 --force option for LXD Profiles is not generally recommended when upgrading an 
 application; overriding profiles on the container may cause unexpected 
 behavior. 
+
+The --dry-run option shows the charm the application would be refreshed to,
+without refreshing it. Use --format=yaml or --format=json to list each change
+with its ID, requirements and arguments.
+
+  juju refresh foo --dry-run --format yaml
 `
 
 func (c *refreshCommand) Info() *cmd.Info {
@@ -283,6 +295,8 @@ func (c *refreshCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the refresh would do")
+	c.out.AddFlags(f, "human", deployer.DryRunFormatters())
 }
 
 func (c *refreshCommand) Init(args []string) error {
@@ -306,6 +320,9 @@ func (c *refreshCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.out.Name() != "human" && !c.DryRun {
+		return errors.Errorf("--format is only supported with --dry-run")
+	}
 	return nil
 }
 
@@ -390,6 +407,7 @@ func (c *refreshCommand) Run(ctx *cmd.Context) error {
 		ForceSeries:     c.ForceSeries,
 		Switch:          c.SwitchURL != "",
 		Logger:          ctx,
+		DryRun:          c.DryRun,
 	}
 	factory, err := c.getRefresherFactory(apiRoot)
 	if err != nil {
@@ -402,6 +420,9 @@ func (c *refreshCommand) Run(ctx *cmd.Context) error {
 		}
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
+	if c.DryRun {
+		return errors.Trace(c.out.Write(ctx, c.dryRunPlan(charmID)))
+	}
 	// The current charm URL that's been found and selected.
 	curl := charmID.URL
 	channel := ""
@@ -473,6 +494,35 @@ func (c *refreshCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// dryRunPlan returns the changes needed to refresh the application to the
+// given charm.
+func (c *refreshCommand) dryRunPlan(charmID *refresher.CharmID) deployer.DryRunPlan {
+	addCharm := deployer.AddCharmDryRunChange("addCharm-0", charmID.URL, commoncharm.CoreCharmOrigin(charmID.Origin))
+	args := map[string]interface{}{
+		"application": c.ApplicationName,
+		"charm":       "$" + addCharm.Id,
+	}
+	if c.Force {
+		args["force"] = true
+	}
+	if c.ForceSeries {
+		args["force-series"] = true
+	}
+	if c.ForceUnits {
+		args["force-units"] = true
+	}
+	return deployer.DryRunPlan{
+		Title: "Changes to refresh charm",
+		Changes: []deployer.DryRunChange{addCharm, {
+			Id:          "upgradeCharm-1",
+			Method:      "upgradeCharm",
+			Args:        args,
+			Requires:    []string{addCharm.Id},
+			Description: []string{fmt.Sprintf("upgrade %s to use charm %s", c.ApplicationName, charmID.URL)},
+		}},
+	}
+}
+
 func (c *refreshCommand) isCharmHubWithRevision(source commoncharm.OriginSource) bool {
 	if source == commoncharm.OriginCharmHub && c.Revision > -1 {
 		return true
@@ -254,6 +254,38 @@ func (s *RefreshSuite) TestStorageConstraints(c *gc.C) {
 	})
 }
 
+func (s *RefreshSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.runRefresh(c, "foo", "--dry-run", "--force-units", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAdder.CheckNoCalls(c)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURLOrigin", "Get")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, fmt.Sprintf(`
+changes:
+- id: addCharm-0
+  method: addCharm
+  args:
+    channel: stable
+    charm: %[1]s
+  description:
+  - upload charm %[1]s from channel stable
+- id: upgradeCharm-1
+  method: upgradeCharm
+  args:
+    application: foo
+    charm: $addCharm-0
+    force-units: true
+  requires:
+  - addCharm-0
+  description:
+  - upgrade foo to use charm %[1]s
+`[1:], s.resolvedCharmURL))
+}
+
+func (s *RefreshSuite) TestFormatWithoutDryRun(c *gc.C) {
+	_, err := s.runRefresh(c, "foo", "--format", "json")
+	c.Assert(err, gc.ErrorMatches, "--format is only supported with --dry-run")
+}
+
 func (s *RefreshSuite) TestUseConfiguredCharmStoreURL(c *gc.C) {
 	_, err := s.runRefresh(c, "foo")
 	c.Assert(err, jc.ErrorIsNil)
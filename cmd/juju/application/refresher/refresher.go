@@ -42,6 +42,10 @@ type RefresherConfig struct {
 	ForceSeries     bool
 	Switch          bool
 	Logger          CommandLogger
+
+	// DryRun resolves the charm to refresh to without adding it to the
+	// model.
+	DryRun bool
 }
 
 // RefresherFn defines a function alias to create a Refresher from a given
@@ -114,6 +118,7 @@ func (d *factory) maybeReadLocal(charmAdder store.CharmAdder, charmRepo CharmRep
 			deployedSeries: cfg.DeployedSeries,
 			force:          cfg.Force,
 			forceSeries:    cfg.ForceSeries,
+			dryRun:         cfg.DryRun,
 		}, nil
 	}
 }
@@ -132,6 +137,7 @@ func (d *factory) maybeCharmStore(authorizer store.MacaroonGetter, charmAdder st
 				deployedSeries:  cfg.DeployedSeries,
 				force:           cfg.Force,
 				forceSeries:     cfg.ForceSeries,
+				dryRun:          cfg.DryRun,
 				logger:          cfg.Logger,
 			},
 			authorizer: authorizer,
@@ -153,6 +159,7 @@ func (d *factory) maybeCharmHub(charmAdder store.CharmAdder, charmResolver Charm
 				deployedSeries:  cfg.DeployedSeries,
 				force:           cfg.Force,
 				forceSeries:     cfg.ForceSeries,
+				dryRun:          cfg.DryRun,
 				logger:          cfg.Logger,
 			},
 		}, nil
@@ -167,6 +174,7 @@ type localCharmRefresher struct {
 	deployedSeries string
 	force          bool
 	forceSeries    bool
+	dryRun         bool
 }
 
 // Allowed will attempt to check if a local charm is allowed to be refreshed.
@@ -185,6 +193,11 @@ func (d *localCharmRefresher) Refresh() (*CharmID, error) {
 		if newName != d.charmURL.Name {
 			return nil, errors.Errorf("cannot refresh %q to %q", d.charmURL.Name, newName)
 		}
+		if d.dryRun {
+			return &CharmID{
+				URL: newURL,
+			}, nil
+		}
 		addedURL, err := d.charmAdder.AddLocalCharm(newURL, ch, d.force)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -226,6 +239,7 @@ type baseRefresher struct {
 	deployedSeries  string
 	force           bool
 	forceSeries     bool
+	dryRun          bool
 	logger          CommandLogger
 }
 
@@ -329,6 +343,13 @@ func (r *charmStoreRefresher) Refresh() (*CharmID, error) {
 		origin.Series = r.deployedSeries
 	}
 
+	if r.dryRun {
+		return &CharmID{
+			URL:    newURL,
+			Origin: origin.CoreCharmOrigin(),
+		}, nil
+	}
+
 	curl, csMac, _, err := store.AddCharmWithAuthorizationFromURL(r.charmAdder, r.authorizer, newURL, origin, r.force)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -413,6 +434,13 @@ func (r *charmHubRefresher) Refresh() (*CharmID, error) {
 		origin.Series = r.deployedSeries
 	}
 
+	if r.dryRun {
+		return &CharmID{
+			URL:    newURL,
+			Origin: origin.CoreCharmOrigin(),
+		}, nil
+	}
+
 	curl, _, err := store.AddCharmFromURL(r.charmAdder, newURL, origin, r.force)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -356,6 +356,40 @@ func (s *charmHubCharmRefresherSuite) TestRefresh(c *gc.C) {
 	})
 }
 
+func (s *charmHubCharmRefresherSuite) TestRefreshDryRun(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	ref := "ch:meshuggah"
+	curl := charm.MustParseURL(ref)
+	newCurl := charm.MustParseURL(fmt.Sprintf("%s-1", ref))
+	origin := commoncharm.Origin{
+		Source: commoncharm.OriginCharmHub,
+		Series: "bionic",
+	}
+
+	// The charm isn't added to the model during a dry run.
+	charmAdder := NewMockCharmAdder(ctrl)
+
+	charmResolver := NewMockCharmResolver(ctrl)
+	charmResolver.EXPECT().ResolveCharm(curl, origin).Return(newCurl, origin, []string{}, nil)
+
+	cfg := refresherConfigWithOrigin(curl, ref, "bionic")
+	cfg.DeployedSeries = "bionic"
+	cfg.DryRun = true
+
+	refresher := (&factory{}).maybeCharmHub(charmAdder, charmResolver)
+	task, err := refresher(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmID, err := task.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmID, gc.DeepEquals, &CharmID{
+		URL:    newCurl,
+		Origin: origin.CoreCharmOrigin(),
+	})
+}
+
 func (s *charmHubCharmRefresherSuite) TestRefreshWithNoUpdates(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
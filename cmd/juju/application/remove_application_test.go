@@ -119,6 +119,34 @@ func (s *RemoveApplicationCmdSuite) TestHandlingNotSupportedMultipleApps(c *gc.C
 	s.api.CheckCallNames(c, "DestroyApplications", "Close")
 }
 
+func (s *RemoveApplicationCmdSuite) TestFormat(c *gc.C) {
+	s.api.destroyApplications = func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+		return []params.DestroyApplicationResult{{
+			Info: &params.DestroyApplicationInfo{
+				DestroyedUnits:   []params.Entity{{Tag: "unit-real-app-0"}, {Tag: "unit-real-app-1"}},
+				DestroyedStorage: []params.Entity{{Tag: "storage-data-0"}},
+				DetachedStorage:  []params.Entity{{Tag: "storage-logs-1"}},
+			},
+		}, {
+			Error: &params.Error{Message: `application "missing" not found`, Code: params.CodeNotFound},
+		}}, nil
+	}
+	ctx, err := s.runRemoveApplication(c, "real-app", "missing", "--format", "yaml")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+missing:
+  error: application "missing" not found
+real-app:
+  destroyed-units:
+  - real-app/0
+  - real-app/1
+  destroyed-storage:
+  - data/0
+  detached-storage:
+  - logs/1
+`[1:])
+}
+
 type testApplicationRemoveUnitAPI struct {
 	*jujutesting.Stub
 
@@ -18,6 +18,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // NewRemoveApplicationCommand returns a command which removes an application.
@@ -40,6 +41,7 @@ type removeApplicationCommand struct {
 	Force            bool
 	NoWait           bool
 	fs               *gnuflag.FlagSet
+	out              cmd.Output
 }
 
 var helpSummaryRmApp = `
@@ -67,11 +69,15 @@ proceed to a next step until the current step has finished.
 However, when using --force, users can also specify --no-wait to progress through steps 
 without delay waiting for each step to complete.
 
+With --format yaml or json, the units and storage removed or detached for
+each application, and any error removing it, are written to stdout.
+
 Examples:
     juju remove-application hadoop
     juju remove-application --force hadoop
     juju remove-application --force --no-wait hadoop
-    juju remove-application -m test-model mariadb`[1:]
+    juju remove-application -m test-model mariadb
+    juju remove-application --format json hadoop`[1:]
 
 func (c *removeApplicationCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
@@ -87,6 +93,7 @@ func (c *removeApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.DestroyStorage, "destroy-storage", false, "Destroy storage attached to application units")
 	f.BoolVar(&c.Force, "force", false, "Completely remove an application and all its dependencies")
 	f.BoolVar(&c.NoWait, "no-wait", false, "Rush through application removal without waiting for each individual step to complete")
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
 	c.fs = f
 }
 
@@ -211,6 +218,7 @@ func (c *removeApplicationCommand) removeApplications(
 		return errors.Trace(err)
 	}
 	anyFailed := false
+	removed := make(map[string]removedApplication)
 	for i, name := range c.ApplicationNames {
 		result := results[i]
 		if result.Error != nil {
@@ -220,9 +228,11 @@ func (c *removeApplicationCommand) removeApplications(
 				err = errors.New("another user was updating application; please try again").Error()
 			}
 			ctx.Infof("removing application %s failed: %s", name, err)
+			removed[name] = removedApplication{Error: err}
 			continue
 		}
 		ctx.Infof("removing application %s", name)
+		var app removedApplication
 		for _, entity := range result.Info.DestroyedUnits {
 			unitTag, err := names.ParseUnitTag(entity.Tag)
 			if err != nil {
@@ -230,6 +240,7 @@ func (c *removeApplicationCommand) removeApplications(
 				continue
 			}
 			ctx.Verbosef("- will remove %s", names.ReadableString(unitTag))
+			app.DestroyedUnits = append(app.DestroyedUnits, unitTag.Id())
 		}
 		for _, entity := range result.Info.DestroyedStorage {
 			storageTag, err := names.ParseStorageTag(entity.Tag)
@@ -238,6 +249,7 @@ func (c *removeApplicationCommand) removeApplications(
 				continue
 			}
 			ctx.Infof("- will remove %s", names.ReadableString(storageTag))
+			app.DestroyedStorage = append(app.DestroyedStorage, storageTag.Id())
 		}
 		for _, entity := range result.Info.DetachedStorage {
 			storageTag, err := names.ParseStorageTag(entity.Tag)
@@ -246,6 +258,13 @@ func (c *removeApplicationCommand) removeApplications(
 				continue
 			}
 			ctx.Infof("- will detach %s", names.ReadableString(storageTag))
+			app.DetachedStorage = append(app.DetachedStorage, storageTag.Id())
+		}
+		removed[name] = app
+	}
+	if c.out.Name() != output.HumanFormat {
+		if err := c.out.Write(ctx, removed); err != nil {
+			return errors.Trace(err)
 		}
 	}
 	if anyFailed {
@@ -253,3 +272,12 @@ func (c *removeApplicationCommand) removeApplications(
 	}
 	return nil
 }
+
+// removedApplication is written for each application by
+// remove-application when a structured format is requested.
+type removedApplication struct {
+	DestroyedUnits   []string `yaml:"destroyed-units,omitempty" json:"destroyed-units,omitempty"`
+	DestroyedStorage []string `yaml:"destroyed-storage,omitempty" json:"destroyed-storage,omitempty"`
+	DetachedStorage  []string `yaml:"detached-storage,omitempty" json:"detached-storage,omitempty"`
+	Error            string   `yaml:"error,omitempty" json:"error,omitempty"`
+}
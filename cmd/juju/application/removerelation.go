@@ -15,6 +15,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 var helpSummary = `
@@ -45,6 +46,7 @@ Examples:
     juju remove-relation mysql wordpress
     juju remove-relation 4
     juju remove-relation 4 --force
+    juju remove-relation mysql wordpress --format json
 
 In the case of multiple relations, the relation name should be specified
 at least once - the following examples will all have the same effect:
@@ -80,6 +82,7 @@ type removeRelationCommand struct {
 	Force      bool
 	NoWait     bool
 	fs         *gnuflag.FlagSet
+	out        cmd.Output
 }
 
 func (c *removeRelationCommand) Info() *cmd.Info {
@@ -108,6 +111,7 @@ func (c *removeRelationCommand) Init(args []string) (err error) {
 func (c *removeRelationCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.Force, "force", false, "Force remove a relation")
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
 	c.fs = f
 }
 
@@ -118,7 +122,7 @@ type ApplicationDestroyRelationAPI interface {
 	DestroyRelationId(relationId int, force *bool, maxWait *time.Duration) error
 }
 
-func (c *removeRelationCommand) Run(_ *cmd.Context) error {
+func (c *removeRelationCommand) Run(ctx *cmd.Context) error {
 	noWaitSet := false
 	forceSet := false
 	c.fs.Visit(func(flag *gnuflag.Flag) {
@@ -146,10 +150,27 @@ func (c *removeRelationCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
+	var out removedRelation
 	if len(c.Endpoints) > 0 {
 		err = client.DestroyRelation(force, maxWait, c.Endpoints...)
+		out.Endpoints = c.Endpoints
 	} else {
 		err = client.DestroyRelationId(c.RelationId, force, maxWait)
+		out.RelationId = &c.RelationId
 	}
-	return block.ProcessBlockedError(err, block.BlockRemove)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	if c.out.Name() == output.HumanFormat {
+		return nil
+	}
+	return c.out.Write(ctx, out)
+}
+
+// removedRelation is written by remove-relation when a structured format
+// is requested. It identifies the relation as it was given on the
+// command line.
+type removedRelation struct {
+	Endpoints  []string `yaml:"endpoints,omitempty" json:"endpoints,omitempty"`
+	RelationId *int     `yaml:"relation-id,omitempty" json:"relation-id,omitempty"`
 }
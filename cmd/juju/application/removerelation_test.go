@@ -66,6 +66,17 @@ func (s *RemoveRelationSuite) TestRemoveRelationIdSuccess(c *gc.C) {
 	s.mockAPI.CheckCall(c, 1, "Close")
 }
 
+func (s *RemoveRelationSuite) TestRemoveRelationFormat(c *gc.C) {
+	store := jujuclienttesting.MinimalStore()
+	ctx, err := cmdtesting.RunCommand(c, NewRemoveRelationCommandForTest(s.mockAPI, store), "0", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "relation-id: 0\n")
+
+	ctx, err = cmdtesting.RunCommand(c, NewRemoveRelationCommandForTest(s.mockAPI, store), "mysql", "wordpress", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"endpoints":["mysql","wordpress"]}`+"\n")
+}
+
 func (s *RemoveRelationSuite) TestRemoveRelationFail(c *gc.C) {
 	msg := "fail remove-relation at API"
 	s.mockAPI.SetErrors(errors.New(msg))
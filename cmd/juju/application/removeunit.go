@@ -18,6 +18,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/model"
 )
 
@@ -38,6 +39,7 @@ type removeUnitCommand struct {
 	Force        bool
 	NoWait       bool
 	fs           *gnuflag.FlagSet
+	out          cmd.Output
 }
 
 const removeUnitDoc = `
@@ -80,6 +82,10 @@ proceed to a next step until the current step has finished.
 However, when using --force, users can also specify --no-wait to progress through steps
 without delay waiting for each step to complete.
 
+With --format yaml or json, the storage removed or detached for each unit,
+and any error removing it, are written to stdout. For k8s models the new
+scale of the application is written instead.
+
 Examples:
 
     juju remove-unit wordpress/2 wordpress/3 wordpress/4
@@ -90,6 +96,8 @@ Examples:
 
     juju remove-unit wordpress/2 --force --no-wait
 
+    juju remove-unit wordpress/2 wordpress/3 --format json
+
 See also:
     remove-application
     scale-application
@@ -110,6 +118,7 @@ func (c *removeUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.DestroyStorage, "destroy-storage", false, "Destroy storage attached to the unit")
 	f.BoolVar(&c.Force, "force", false, "Completely remove an application and all its dependencies")
 	f.BoolVar(&c.NoWait, "no-wait", false, "Rush through application removal without waiting for each individual step to complete")
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
 	c.fs = f
 }
 
@@ -280,14 +289,17 @@ func (c *removeUnitCommand) removeUnits(ctx *cmd.Context, client RemoveApplicati
 		return block.ProcessBlockedError(err, block.BlockRemove)
 	}
 	anyFailed := false
+	removed := make(map[string]removedUnit)
 	for i, name := range c.EntityNames {
 		result := results[i]
 		if result.Error != nil {
 			anyFailed = true
 			ctx.Infof("removing unit %s failed: %s", name, result.Error)
+			removed[name] = removedUnit{Error: result.Error.Error()}
 			continue
 		}
 		ctx.Infof("removing unit %s", name)
+		var unit removedUnit
 		for _, entity := range result.Info.DestroyedStorage {
 			storageTag, err := names.ParseStorageTag(entity.Tag)
 			if err != nil {
@@ -295,6 +307,7 @@ func (c *removeUnitCommand) removeUnits(ctx *cmd.Context, client RemoveApplicati
 				continue
 			}
 			ctx.Infof("- will remove %s", names.ReadableString(storageTag))
+			unit.DestroyedStorage = append(unit.DestroyedStorage, storageTag.Id())
 		}
 		for _, entity := range result.Info.DetachedStorage {
 			storageTag, err := names.ParseStorageTag(entity.Tag)
@@ -303,6 +316,13 @@ func (c *removeUnitCommand) removeUnits(ctx *cmd.Context, client RemoveApplicati
 				continue
 			}
 			ctx.Infof("- will detach %s", names.ReadableString(storageTag))
+			unit.DetachedStorage = append(unit.DetachedStorage, storageTag.Id())
+		}
+		removed[name] = unit
+	}
+	if c.out.Name() != output.HumanFormat {
+		if err := c.out.Write(ctx, removed); err != nil {
+			return errors.Trace(err)
 		}
 	}
 	if anyFailed {
@@ -324,5 +344,19 @@ func (c *removeUnitCommand) removeCaasUnits(ctx *cmd.Context, client RemoveAppli
 		return block.ProcessBlockedError(err, block.BlockRemove)
 	}
 	ctx.Infof("scaling down to %d units", result.Info.Scale)
-	return nil
+	if c.out.Name() == output.HumanFormat {
+		return nil
+	}
+	return c.out.Write(ctx, scaledApplication{
+		Application: c.EntityNames[0],
+		Scale:       result.Info.Scale,
+	})
+}
+
+// removedUnit is written for each unit by remove-unit when a structured
+// format is requested.
+type removedUnit struct {
+	DestroyedStorage []string `yaml:"destroyed-storage,omitempty" json:"destroyed-storage,omitempty"`
+	DetachedStorage  []string `yaml:"detached-storage,omitempty" json:"detached-storage,omitempty"`
+	Error            string   `yaml:"error,omitempty" json:"error,omitempty"`
 }
@@ -142,6 +142,21 @@ removing unit unit/2 failed: unit "unit/2" does not exist
 `[1:])
 }
 
+func (s *RemoveUnitSuite) TestRemoveUnitFormat(c *gc.C) {
+	ctx, err := s.runRemoveUnit(c, "unit/0", "unit/1", "unit/2", "--destroy-storage", "--format", "yaml")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+unit/0:
+  destroyed-storage:
+  - data/0
+unit/1:
+  destroyed-storage:
+  - data/1
+unit/2:
+  error: unit "unit/2" does not exist
+`[1:])
+}
+
 func (s *RemoveUnitSuite) TestRemoveUnitNoWaitWithoutForce(c *gc.C) {
 	_, err := s.runRemoveUnit(c, "unit/0", "--no-wait")
 	c.Assert(err, gc.ErrorMatches, `--no-wait without --force not valid`)
@@ -171,6 +186,16 @@ scaling down to 3 units
 `[1:])
 }
 
+func (s *RemoveUnitSuite) TestCAASRemoveUnitFormat(c *gc.C) {
+	m := s.store.Models["arthur"].Models["king/sword"]
+	m.ModelType = model.CAAS
+	s.store.Models["arthur"].Models["king/sword"] = m
+
+	ctx, err := s.runRemoveUnit(c, "some-application-name", "--num-units", "2", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"application":"some-application-name","scale":3}`+"\n")
+}
+
 func (s *RemoveUnitSuite) TestCAASRemoveUnitNotSupported(c *gc.C) {
 	m := s.store.Models["arthur"].Models["king/sword"]
 	m.ModelType = model.CAAS
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api/application"
@@ -15,6 +16,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // NewScaleApplicationCommand returns a command which scales an application's units.
@@ -38,6 +40,7 @@ type scaleApplicationCommand struct {
 	newAPIFunc      func() (scaleApplicationAPI, error)
 	applicationName string
 	scale           int
+	out             cmd.Output
 }
 
 const scaleApplicationDoc = `
@@ -48,6 +51,7 @@ allowing both scale up and scale down.
 Examples:
 
     juju scale-application mariadb 2
+    juju scale-application mariadb 3 --format json
 `
 
 // Info implements cmd.Command.
@@ -60,6 +64,12 @@ func (c *scaleApplicationCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *scaleApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
+}
+
 func (c *scaleApplicationCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.Errorf("no application specified")
@@ -107,5 +117,18 @@ func (c *scaleApplicationCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	ctx.Infof("%v scaled to %d units", c.applicationName, result.Info.Scale)
-	return nil
+	if c.out.Name() == output.HumanFormat {
+		return nil
+	}
+	return c.out.Write(ctx, scaledApplication{
+		Application: c.applicationName,
+		Scale:       result.Info.Scale,
+	})
+}
+
+// scaledApplication is written by scale-application, and by remove-unit
+// for k8s models, when a structured format is requested.
+type scaledApplication struct {
+	Application string `yaml:"application" json:"application"`
+	Scale       int    `yaml:"scale" json:"scale"`
 }
@@ -67,6 +67,15 @@ func (s *ScaleApplicationSuite) TestScaleApplication(c *gc.C) {
 	c.Assert(out, gc.Equals, `foo scaled to 2 units`)
 }
 
+func (s *ScaleApplicationSuite) TestScaleApplicationFormat(c *gc.C) {
+	ctx, err := s.runScaleApplication(c, "foo", "2", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+application: foo
+scale: 2
+`[1:])
+}
+
 func (s *ScaleApplicationSuite) TestScaleApplicationBlocked(c *gc.C) {
 	s.mockAPI.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
 	_, err := s.runScaleApplication(c, "foo", "2")
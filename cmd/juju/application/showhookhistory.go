@@ -0,0 +1,244 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/juju/osenv"
+)
+
+var showHookHistoryHelpSummary = `
+Shows the hooks recently run by a unit.`[1:]
+
+var showHookHistoryHelpDetails = `
+The hooks run by the unit's agent are listed most recent first, along
+with how long each ran, its exit code and the end of what it wrote to
+stderr. Hooks which the charm doesn't implement are not listed.
+
+The history is pruned along with the model's status history, according
+to the "max-status-history-age" and "max-status-history-size" model
+config settings.
+
+The --since option takes either a duration, such as "2h", meaning that
+long ago, or a date and time in RFC3339 format or a date as YYYY-MM-DD.
+
+Examples:
+    juju show-hook-history mysql/0
+    juju show-hook-history mysql/0 --failed
+    juju show-hook-history mysql/0 --hook config-changed -n 5
+    juju show-hook-history wordpress/1 --relation db:2 --since 2h
+
+See also:
+    show-status-log
+    debug-log`
+
+// NewShowHookHistoryCommand returns a command to show the hooks run
+// by a unit.
+func NewShowHookHistoryCommand() cmd.Command {
+	cmd := &showHookHistoryCommand{clock: clock.WallClock}
+	cmd.newAPIFunc = func() (HookHistoryAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type showHookHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	unitName   string
+	hook       string
+	relation   string
+	since      string
+	sinceTime  *time.Time
+	failedOnly bool
+	limit      int
+	isoTime    bool
+
+	clock      clock.Clock
+	newAPIFunc func() (HookHistoryAPI, error)
+}
+
+func (c *showHookHistoryCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-hook-history",
+		Args:    "<unit>",
+		Purpose: showHookHistoryHelpSummary,
+		Doc:     showHookHistoryHelpDetails,
+	})
+}
+
+func (c *showHookHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.hook, "hook", "", "Only show runs of the named hook")
+	f.StringVar(&c.relation, "relation", "", "Only show hooks run for the relation, eg db:2")
+	f.StringVar(&c.since, "since", "", "Only show hooks started since the given duration ago or time")
+	f.BoolVar(&c.failedOnly, "failed", false, "Only show hooks which failed")
+	f.IntVar(&c.limit, "n", 20, "Show at most N hook runs (0 for all)")
+	f.IntVar(&c.limit, "limit", 20, "")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+func (c *showHookHistoryCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no unit specified")
+	case 1:
+	default:
+		return errors.New("only one unit may be specified")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.NotValidf("unit name %q", args[0])
+	}
+	c.unitName = args[0]
+	if c.limit < 0 {
+		return errors.NotValidf("negative limit %d", c.limit)
+	}
+	if c.since != "" {
+		since, err := c.parseSince(c.since)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.sinceTime = &since
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		var err error
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return nil
+}
+
+// parseSince parses the value of the --since option, which is either
+// a duration before now or an absolute time.
+func (c *showHookHistoryCommand) parseSince(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, errors.Errorf("invalid --since value %q, duration cannot be negative", value)
+		}
+		return c.clock.Now().Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("invalid --since value %q, expected a duration, RFC3339 time or YYYY-MM-DD date", value)
+}
+
+// HookHistoryAPI defines the API methods that the show-hook-history
+// command uses.
+type HookHistoryAPI interface {
+	Close() error
+	HookHistory(unit string, filter application.HookHistoryFilter) ([]application.HookRun, error)
+}
+
+// HookRunInfo defines the serialization behaviour of a hook run.
+type HookRunInfo struct {
+	Hook     string    `yaml:"hook" json:"hook"`
+	Relation string    `yaml:"relation,omitempty" json:"relation,omitempty"`
+	Started  time.Time `yaml:"started" json:"started"`
+	Duration string    `yaml:"duration" json:"duration"`
+	ExitCode int       `yaml:"exit-code" json:"exit-code"`
+	Stderr   string    `yaml:"stderr,omitempty" json:"stderr,omitempty"`
+}
+
+func (c *showHookHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	runs, err := client.HookHistory(c.unitName, application.HookHistoryFilter{
+		Hook:       c.hook,
+		Relation:   c.relation,
+		Since:      c.sinceTime,
+		FailedOnly: c.failedOnly,
+		Limit:      c.limit,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(runs) == 0 {
+		ctx.Infof("No hook history to display.")
+		return nil
+	}
+
+	history := make([]HookRunInfo, len(runs))
+	for i, run := range runs {
+		history[i] = HookRunInfo{
+			Hook:     run.Hook,
+			Relation: run.Relation,
+			Started:  run.Started,
+			Duration: run.Finished.Sub(run.Started).String(),
+			ExitCode: run.ExitCode,
+			Stderr:   run.Stderr,
+		}
+	}
+	return c.out.Write(ctx, history)
+}
+
+func (c *showHookHistoryCommand) formatTabular(writer io.Writer, value interface{}) error {
+	history, ok := value.([]HookRunInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", history, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("Started", "Duration", "Hook", "Relation", "Exit", "Stderr")
+	for _, run := range history {
+		started := run.Started
+		w.Println(
+			common.FormatTime(&started, c.isoTime),
+			run.Duration,
+			run.Hook,
+			run.Relation,
+			run.ExitCode,
+			lastLine(run.Stderr),
+		)
+	}
+	return tw.Flush()
+}
+
+// lastLine returns the last non-empty line of s.
+func lastLine(s string) string {
+	s = strings.TrimRight(s, "\n")
+	if i := strings.LastIndex(s, "\n"); i >= 0 {
+		s = s[i+1:]
+	}
+	return s
+}
@@ -0,0 +1,171 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiapplication "github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ShowHookHistorySuite struct {
+	testing.IsolationSuite
+	mockAPI *mockHookHistoryAPI
+	clock   *testclock.Clock
+	started time.Time
+}
+
+var _ = gc.Suite(&ShowHookHistorySuite{})
+
+func (s *ShowHookHistorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.started = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.clock = testclock.NewClock(s.started.Add(time.Hour))
+	s.mockAPI = &mockHookHistoryAPI{
+		Stub: &testing.Stub{},
+		runs: []apiapplication.HookRun{{
+			Hook:     "db-relation-changed",
+			Relation: "db:2",
+			Started:  s.started.Add(time.Minute),
+			Finished: s.started.Add(time.Minute + 1500*time.Millisecond),
+			ExitCode: 1,
+			Stderr:   "connecting\ncannot connect to db\n",
+		}, {
+			Hook:     "install",
+			Started:  s.started,
+			Finished: s.started.Add(30 * time.Second),
+		}},
+	}
+}
+
+func (s *ShowHookHistorySuite) runShowHookHistory(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewShowHookHistoryCommandForTest(s.mockAPI, s.clock, store), args...)
+}
+
+func (s *ShowHookHistorySuite) TestInvalidArguments(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no unit specified",
+	}, {
+		args: []string{"mysql/0", "mysql/1"},
+		err:  "only one unit may be specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"mysql/0", "-n", "-1"},
+		err:  "negative limit -1 not valid",
+	}, {
+		args: []string{"mysql/0", "--since", "yesterday"},
+		err:  `invalid --since value "yesterday", expected a duration, RFC3339 time or YYYY-MM-DD date`,
+	}, {
+		args: []string{"mysql/0", "--since", "-1h"},
+		err:  `invalid --since value "-1h", duration cannot be negative`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runShowHookHistory(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *ShowHookHistorySuite) TestDefaultFilter(c *gc.C) {
+	_, err := s.runShowHookHistory(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "HookHistory", "mysql/0", apiapplication.HookHistoryFilter{Limit: 20})
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *ShowHookHistorySuite) TestFilters(c *gc.C) {
+	_, err := s.runShowHookHistory(c, "mysql/0",
+		"--hook", "db-relation-changed", "--relation", "db:2", "--failed", "--since", "2h", "-n", "5")
+	c.Assert(err, jc.ErrorIsNil)
+	since := s.started.Add(-time.Hour)
+	s.mockAPI.CheckCall(c, 0, "HookHistory", "mysql/0", apiapplication.HookHistoryFilter{
+		Hook:       "db-relation-changed",
+		Relation:   "db:2",
+		Since:      &since,
+		FailedOnly: true,
+		Limit:      5,
+	})
+}
+
+func (s *ShowHookHistorySuite) TestSinceTime(c *gc.C) {
+	_, err := s.runShowHookHistory(c, "mysql/0", "--since", "2021-05-31", "--limit", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	since := time.Date(2021, 5, 31, 0, 0, 0, 0, time.UTC)
+	s.mockAPI.CheckCall(c, 0, "HookHistory", "mysql/0", apiapplication.HookHistoryFilter{Since: &since})
+}
+
+func (s *ShowHookHistorySuite) TestTabular(c *gc.C) {
+	ctx, err := s.runShowHookHistory(c, "mysql/0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Started               Duration  Hook                 Relation  Exit  Stderr
+2021-06-01 12:01:00Z  1.5s      db-relation-changed  db:2      1     cannot connect to db
+2021-06-01 12:00:00Z  30s       install                        0     
+`[1:])
+}
+
+func (s *ShowHookHistorySuite) TestYAML(c *gc.C) {
+	ctx, err := s.runShowHookHistory(c, "mysql/0", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- hook: db-relation-changed
+  relation: db:2
+  started: 2021-06-01T12:01:00Z
+  duration: 1.5s
+  exit-code: 1
+  stderr: |
+    connecting
+    cannot connect to db
+- hook: install
+  started: 2021-06-01T12:00:00Z
+  duration: 30s
+  exit-code: 0
+`[1:])
+}
+
+func (s *ShowHookHistorySuite) TestNoHistory(c *gc.C) {
+	s.mockAPI.runs = nil
+	ctx, err := s.runShowHookHistory(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No hook history to display.\n")
+}
+
+func (s *ShowHookHistorySuite) TestAPIError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runShowHookHistory(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+type mockHookHistoryAPI struct {
+	*testing.Stub
+	runs []apiapplication.HookRun
+}
+
+func (s *mockHookHistoryAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockHookHistoryAPI) HookHistory(unit string, filter apiapplication.HookHistoryFilter) ([]apiapplication.HookRun, error) {
+	s.MethodCall(s, "HookHistory", unit, filter)
+	return s.runs, s.NextErr()
+}
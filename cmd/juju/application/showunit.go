@@ -5,6 +5,7 @@ package application
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -29,6 +30,9 @@ Examples:
     juju show-unit mysql/0 --app
     juju show-unit mysql/0 --endpoint db
     juju show-unit mysql/0 --related-unit wordpress/2
+
+Timers scheduled by the unit's charm are shown along with when each
+next fires.
 `
 
 // NewShowUnitCommand returns a command that displays unit info.
@@ -193,15 +197,24 @@ type RelationData struct {
 	Data                    map[string]UnitRelationData `yaml:"related-units,omitempty" json:"related-units,omitempty"`
 }
 
+// UnitTimer defines the serialization behaviour of a timer scheduled
+// by the unit's charm.
+type UnitTimer struct {
+	Next   string `yaml:"next" json:"next"`
+	Every  string `yaml:"every,omitempty" json:"every,omitempty"`
+	Jitter string `yaml:"jitter,omitempty" json:"jitter,omitempty"`
+}
+
 // ApplicationInfo defines the serialization behaviour of the application information.
 type UnitInfo struct {
-	WorkloadVersion string         `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
-	Machine         string         `yaml:"machine,omitempty" json:"machine,omitempty"`
-	OpenedPorts     []string       `yaml:"opened-ports" json:"opened-ports"`
-	PublicAddress   string         `yaml:"public-address,omitempty" json:"public-address,omitempty"`
-	Charm           string         `yaml:"charm" json:"charm"`
-	Leader          bool           `yaml:"leader" json:"leader"`
-	RelationData    []RelationData `yaml:"relation-info,omitempty" json:"relation-info,omitempty"`
+	WorkloadVersion string               `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
+	Machine         string               `yaml:"machine,omitempty" json:"machine,omitempty"`
+	OpenedPorts     []string             `yaml:"opened-ports" json:"opened-ports"`
+	PublicAddress   string               `yaml:"public-address,omitempty" json:"public-address,omitempty"`
+	Charm           string               `yaml:"charm" json:"charm"`
+	Leader          bool                 `yaml:"leader" json:"leader"`
+	RelationData    []RelationData       `yaml:"relation-info,omitempty" json:"relation-info,omitempty"`
+	Timers          map[string]UnitTimer `yaml:"timers,omitempty" json:"timers,omitempty"`
 
 	// The following are for CAAS models.
 	ProviderId string `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
@@ -224,6 +237,19 @@ func (c *showUnitCommand) createUnitInfo(details application.UnitInfo) (names.Un
 		ProviderId:      details.ProviderId,
 		Address:         details.Address,
 	}
+	if len(details.Timers) > 0 {
+		info.Timers = make(map[string]UnitTimer)
+		for name, t := range details.Timers {
+			timer := UnitTimer{Next: t.Next.Format(time.RFC3339)}
+			if t.Every > 0 {
+				timer.Every = t.Every.String()
+			}
+			if t.Jitter > 0 {
+				timer.Jitter = t.Jitter.String()
+			}
+			info.Timers[name] = timer
+		}
+	}
 	for _, rdparams := range details.RelationData {
 		if c.endpoint != "" && rdparams.Endpoint != c.endpoint {
 			continue
@@ -6,6 +6,7 @@ package application_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -209,6 +210,46 @@ wordpress/0:
 	})
 }
 
+func (s *ShowUnitSuite) TestShowTimers(c *gc.C) {
+	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]apiapplication.UnitInfo, error) {
+		info := s.createTestUnitInfo("wordpress", "")
+		info.RelationData = nil
+		info.Timers = map[string]apiapplication.UnitTimer{
+			"backup": {
+				Next:   time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+				Every:  time.Hour,
+				Jitter: 5 * time.Minute,
+			},
+			"cleanup": {
+				Next: time.Date(2021, 6, 1, 13, 30, 0, 0, time.UTC),
+			},
+		}
+		return []apiapplication.UnitInfo{info}, nil
+	}
+	s.assertRunShow(c, showUnitTest{
+		args: []string{"wordpress/0"},
+		stdout: `
+wordpress/0:
+  workload-version: "666"
+  machine: "0"
+  opened-ports:
+  - 100-102/ip
+  public-address: 10.0.0.1
+  charm: charm-wordpress
+  leader: true
+  timers:
+    backup:
+      next: "2021-06-01T12:00:00Z"
+      every: 1h0m0s
+      jitter: 5m0s
+    cleanup:
+      next: "2021-06-01T13:30:00Z"
+  provider-id: provider-id
+  address: 192.168.1.1
+`[1:],
+	})
+}
+
 func (s *ShowUnitSuite) TestShowAppOnly(c *gc.C) {
 	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]apiapplication.UnitInfo, error) {
 		return []apiapplication.UnitInfo{
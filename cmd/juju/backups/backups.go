@@ -132,7 +132,9 @@ created on host:       {{.Hostname}}
 
 checksum:              {{.Checksum}} 
 checksum format:       {{.ChecksumFormat}} 
-size (B):              {{.Size}} 
+size (B):              {{.Size}} {{if .Encrypted}}
+encrypted:             yes {{end}}{{if .Storage}}
+storage:               {{.Storage}} {{end}}
 stored:                {{.Stored}} 
 started:               {{.Started}} 
 finished:              {{.Finished}} 
@@ -157,6 +159,8 @@ type MetadataParams struct {
 	Hostname       string
 	JujuVersion    version.Number
 	Series         string
+	Encrypted      bool
+	Storage        string
 }
 
 func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
@@ -177,6 +181,8 @@ func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
 		result.Hostname,
 		result.Version,
 		result.Series,
+		result.Encrypted,
+		result.Storage,
 	}
 	t := template.Must(template.New("template").Parse(backupMetadataTemplate))
 	content := bytes.Buffer{}
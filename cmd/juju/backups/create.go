@@ -37,6 +37,11 @@ Use --keep-copy option to store a copy of backup remotely on the controller.
 
 Use --verbose to see extra information about backup.
 
+The key encrypting charm secrets and secret backend credentials in the
+controller database is not included in the backup, so that they can't be
+read from the backup alone. They can only be read again once the backup is
+restored to the controller it was taken from.
+
 To access remote backups stored on the controller, see 'juju download-backup'.
 
 Examples:
@@ -165,7 +170,7 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 
 	// Handle download.
 	if !c.NoDownload {
-		filename := c.decideFilename(ctx, c.Filename, metadataResult.Started)
+		filename := c.decideFilename(ctx, c.Filename, metadataResult.Started, metadataResult.Encrypted)
 		if err := c.download(ctx, client, copyFrom, filename); err != nil {
 			return errors.Trace(err)
 		}
@@ -174,12 +179,16 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
-func (c *createCommand) decideFilename(ctx *cmd.Context, filename string, timestamp time.Time) string {
+func (c *createCommand) decideFilename(ctx *cmd.Context, filename string, timestamp time.Time, encrypted bool) string {
 	if filename != notset {
 		return filename
 	}
 	// Downloading but no filename given, so generate one.
-	return timestamp.Format(backups.FilenameTemplate)
+	filename = timestamp.Format(backups.FilenameTemplate)
+	if encrypted {
+		filename += backups.EncryptedSuffix
+	}
+	return filename
 }
 
 func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom string, archiveFilename string) error {
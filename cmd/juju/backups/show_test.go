@@ -34,6 +34,20 @@ func (s *showSuite) TestOkay(c *gc.C) {
 	c.Check(cmdtesting.Stdout(ctx), gc.Equals, MetaResultString)
 }
 
+func (s *showSuite) TestEncrypted(c *gc.C) {
+	s.metaresult.Encrypted = true
+	s.metaresult.Storage = "s3://juju-backups/prod"
+	s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, s.metaresult.ID)
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `
+size (B):              0 
+encrypted:             yes 
+storage:               s3://juju-backups/prod 
+stored:`[1:])
+}
+
 func (s *showSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := cmdtesting.RunCommand(c, s.subcommand, s.metaresult.ID)
@@ -0,0 +1,154 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/version"
+
+	jujucmd "github.com/juju/juju/cmd"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+const verifyDoc = `
+verify-backup checks that a backup archive file could be restored,
+without connecting to a controller.
+
+The archive is unpacked into a temporary directory, and every document
+in its database dump and the controller agent config are read. The
+command fails if the archive is truncated or corrupt, or if any of these
+are missing. Otherwise, the Juju version that created the backup and the
+models it contains are reported.
+
+The checksum of the archive is always reported, and can be compared to
+the checksum shown by show-backup. Use --checksum to check it instead.
+
+Encrypted archives must be decrypted with "gpg --decrypt" first.
+
+Examples:
+    juju verify-backup juju-backup-20210301-023000.tar.gz
+    juju verify-backup --checksum jg7JNzXhHYzt5NKmAsUuH5ooYAs= backup.tar.gz
+
+See also:
+    create-backup
+    download-backup
+    show-backup
+`
+
+// NewVerifyCommand returns a command used to verify a backup archive
+// file.
+func NewVerifyCommand() cmd.Command {
+	return &verifyCommand{}
+}
+
+// verifyCommand is the sub-command for verifying a backup archive.
+type verifyCommand struct {
+	cmd.CommandBase
+	out cmd.Output
+
+	// Filename is where to find the archive to verify.
+	Filename string
+
+	// Checksum is the expected checksum of the archive.
+	Checksum string
+}
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "verify-backup",
+		Args:    "<filename>",
+		Purpose: "Check that a backup archive file could be restored.",
+		Doc:     verifyDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.Checksum, "checksum", "", "The expected checksum of the archive")
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("backup filename not specified")
+	}
+	filename, args := args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.Filename = filename
+	return nil
+}
+
+// verifiedArchive is the serialised form of a verified backup archive.
+type verifiedArchive struct {
+	Checksum       string          `yaml:"checksum" json:"checksum"`
+	Size           int64           `yaml:"size" json:"size"`
+	Started        time.Time       `yaml:"started" json:"started"`
+	JujuVersion    version.Number  `yaml:"juju-version" json:"juju-version"`
+	ControllerUUID string          `yaml:"controller-uuid,omitempty" json:"controller-uuid,omitempty"`
+	Agents         []string        `yaml:"agents" json:"agents"`
+	Databases      []string        `yaml:"databases" json:"databases"`
+	Models         []verifiedModel `yaml:"models" json:"models"`
+}
+
+type verifiedModel struct {
+	Name  string `yaml:"name" json:"name"`
+	Owner string `yaml:"owner" json:"owner"`
+	UUID  string `yaml:"uuid" json:"uuid"`
+}
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) error {
+	if strings.HasSuffix(c.Filename, statebackups.EncryptedSuffix) {
+		return errors.Errorf("backup archive %q is encrypted, decrypt it with \"gpg --decrypt\" first", c.Filename)
+	}
+	archive, err := os.Open(ctx.AbsPath(c.Filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+
+	result, err := statebackups.VerifyArchive(archive)
+	if err != nil {
+		return errors.Annotatef(err, "verifying %q", c.Filename)
+	}
+	if c.Checksum != "" && c.Checksum != result.Checksum {
+		return errors.Errorf("backup archive %q has checksum %q, expected %q", c.Filename, result.Checksum, c.Checksum)
+	}
+
+	out := verifiedArchive{
+		Checksum:       result.Checksum,
+		Size:           result.Size,
+		Started:        result.Metadata.Started,
+		JujuVersion:    result.Metadata.Origin.Version,
+		ControllerUUID: result.Metadata.Controller.UUID,
+		Agents:         result.Agents,
+		Databases:      result.Databases,
+	}
+	for _, model := range result.Models {
+		out.Models = append(out.Models, verifiedModel{
+			Name:  model.Name,
+			Owner: model.Owner,
+			UUID:  model.UUID,
+		})
+	}
+	if err := c.out.Write(ctx, out); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Backup archive %q verified.", c.Filename)
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/base64"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/mgo/v2/bson"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/controller"
+	statebackups "github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type verifySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+type archiveFile struct {
+	name string
+	data []byte
+}
+
+func writeTar(c *gc.C, files []archiveFile) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, file := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     file.name,
+			Mode:     0600,
+			Size:     int64(len(file.data)),
+			Typeflag: tar.TypeReg,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = tw.Write(file.data)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+// writeArchive writes a backup archive of a controller with a single
+// model, and returns its filename and checksum.
+func (s *verifySuite) writeArchive(c *gc.C) (string, string) {
+	meta := statebackups.NewMetadata()
+	meta.Started = time.Date(2021, 3, 1, 2, 30, 0, 0, time.UTC)
+	meta.Controller.UUID = testing.ControllerTag.Id()
+	metaJSON, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	metaData, err := ioutil.ReadAll(metaJSON)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := bson.Marshal(bson.M{
+		"_id":   testing.ModelTag.Id(),
+		"name":  "controller",
+		"owner": "admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	config, err := agent.NewStateMachineConfig(agent.AgentConfigParams{
+		Paths:             agent.Paths{DataDir: "/var/lib/juju"},
+		Tag:               names.NewMachineTag("0"),
+		UpgradedToVersion: jujuversion.Current,
+		Password:          "sekrit",
+		CACert:            "ca cert",
+		APIAddresses:      []string{"localhost:17070"},
+		Nonce:             "a nonce",
+		Controller:        testing.ControllerTag,
+		Model:             testing.ModelTag,
+	}, controller.StateServingInfo{
+		Cert:         "cert",
+		PrivateKey:   "key",
+		CAPrivateKey: "ca key",
+		StatePort:    37017,
+		APIPort:      17070,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	configData, err := config.Render()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var archive bytes.Buffer
+	gzw := gzip.NewWriter(&archive)
+	_, err = gzw.Write(writeTar(c, []archiveFile{
+		{"juju-backup/metadata.json", metaData},
+		{"juju-backup/dump/juju/models.bson", model},
+		{"juju-backup/root.tar", writeTar(c, []archiveFile{
+			{"var/lib/juju/agents/machine-0/agent.conf", configData},
+		})},
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+
+	filename := filepath.Join(c.MkDir(), "juju-backup.tar.gz")
+	err = ioutil.WriteFile(filename, archive.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	sum := sha1.Sum(archive.Bytes())
+	return filename, base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	filename, checksum := s.writeArchive(c)
+
+	ctx, err := cmdtesting.RunCommand(c, backups.NewVerifyCommand(), "--format", "json", filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `"checksum":"`+checksum+`"`)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `"juju-version":"`+jujuversion.Current.String()+`"`)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `"agents":["machine-0"],"databases":["juju"]`)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains,
+		`"models":[{"name":"controller","owner":"admin","uuid":"`+testing.ModelTag.Id()+`"}]`)
+	c.Check(cmdtesting.Stderr(ctx), gc.Matches, `Backup archive ".*" verified.\n`)
+}
+
+func (s *verifySuite) TestVerifyChecksum(c *gc.C) {
+	filename, checksum := s.writeArchive(c)
+
+	_, err := cmdtesting.RunCommand(c, backups.NewVerifyCommand(), "--checksum", checksum, filename)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, backups.NewVerifyCommand(), "--checksum", "bad", filename)
+	c.Assert(err, gc.ErrorMatches, `backup archive ".*" has checksum ".*", expected "bad"`)
+}
+
+func (s *verifySuite) TestVerifyTruncated(c *gc.C) {
+	filename, _ := s.writeArchive(c)
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filename, data[:len(data)-10], 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, backups.NewVerifyCommand(), filename)
+	c.Assert(err, gc.ErrorMatches, `verifying ".*": backup archive is truncated`)
+}
+
+func (s *verifySuite) TestVerifyEncrypted(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, backups.NewVerifyCommand(), "backup.tar.gz.gpg")
+	c.Assert(err, gc.ErrorMatches, `backup archive "backup.tar.gz.gpg" is encrypted, decrypt it with "gpg --decrypt" first`)
+}
+
+func (s *verifySuite) TestVerifyNoFilename(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, backups.NewVerifyCommand())
+	c.Assert(err, gc.ErrorMatches, "backup filename not specified")
+}
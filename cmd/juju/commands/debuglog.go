@@ -6,6 +6,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -52,13 +53,39 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-application' and '--exclude-application' options filter by
+application name, matching messages from any unit of the application as
+well as from the application itself.
+
+The '--include-label' and '--exclude-label' options filter by the labels
+attached to log messages. A message is included if it has any of the
+included labels, and excluded if it has any of the excluded labels.
+
+The '--message' option only shows messages matching the given regular
+expression (RE2 syntax).
+
+The '--after' and '--before' options restrict the messages shown to a time
+window. Times may be given as RFC3339 timestamps, dates in YYYY-MM-DD form
+(midnight UTC), or durations such as 90m or 2h, which are measured back from
+now. Once the '--before' time has passed no further messages are shown.
+
+The '--retained' option shows the WARNING and ERROR messages kept according
+to the model's "log-retention" policy, rather than the recent messages of
+all levels. Older messages may still be found this way, after they have
+been dropped from the recent logs. The oldest of them are pruned early if
+they grow larger than the model's "max-retained-log-size".
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* All --include-application options are logically ORed together.
+* All --exclude-application options are logically ORed together.
+* All --include-label options are logically ORed together.
+* All --exclude-label options are logically ORed together.
+* The combined selections above, along with --message, --after, --before
+  and --level, are logically ANDed to form the complete filter.
 
 Examples:
 
@@ -101,6 +128,22 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show messages from the last two hours from any mysql unit that mention a
+failed hook, and then stop:
+
+    juju debug-log --no-tail --after 2h \
+        --include-application mysql \
+        --message 'hook .* failed'
+
+Show the errors retained from the past month:
+
+    juju debug-log --retained --replay --no-tail --level ERROR --after 720h
+
+Show the messages logged on a particular day, except those labelled "http":
+
+    juju debug-log --after 2021-03-01 --before 2021-03-02 \
+        --exclude-label http
+
 See also:
     status
     ssh`
@@ -127,6 +170,8 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	after  string
+	before string
 	params common.DebugLogParams
 
 	utc      bool
@@ -150,6 +195,14 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeApplication), "include-application", "Only show log messages for these applications")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeApplication), "exclude-application", "Do not show log messages for these applications")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeLabel), "include-label", "Only show log messages with these labels")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeLabel), "exclude-label", "Do not show log messages with these labels")
+	f.StringVar(&c.params.MessageRegex, "message", "", "Only show log messages matching this regular expression")
+	f.StringVar(&c.after, "after", "", "Only show log messages logged at or after this time")
+	f.StringVar(&c.before, "before", "", "Only show log messages logged before this time")
+	f.BoolVar(&c.params.Retained, "retained", false, "Show log messages kept by the model's log retention policy")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -181,6 +234,30 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
+	if c.params.MessageRegex != "" {
+		if _, err := regexp.Compile(c.params.MessageRegex); err != nil {
+			return errors.Annotate(err, "invalid --message")
+		}
+	}
+	for _, app := range append(c.params.IncludeApplication, c.params.ExcludeApplication...) {
+		if !names.IsValidApplication(app) {
+			return errors.NotValidf("application name %q", app)
+		}
+	}
+	now := time.Now()
+	after, err := parseDebugLogTime(c.after, now)
+	if err != nil {
+		return errors.Annotate(err, "invalid --after")
+	}
+	before, err := parseDebugLogTime(c.before, now)
+	if err != nil {
+		return errors.Annotate(err, "invalid --before")
+	}
+	if !after.IsZero() && !before.IsZero() && !after.Before(before) {
+		return errors.New("--after must be earlier than --before")
+	}
+	c.params.StartTime = after
+	c.params.EndTime = before
 	if c.utc {
 		c.tz = time.UTC
 	}
@@ -202,6 +279,24 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// parseDebugLogTime parses a time given as an RFC3339 timestamp, a
+// date, or a duration before now. An empty value gives the zero time.
+func parseDebugLogTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, errors.Errorf("expected an RFC3339 time, a YYYY-MM-DD date or a duration, got %q", value)
+}
+
 func (c *debugLogCommand) processEntities(isCAAS bool, entities []string) []string {
 	if entities == nil {
 		return nil
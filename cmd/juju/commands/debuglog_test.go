@@ -104,6 +104,54 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{
+				"--include-application", "mysql",
+				"--include-application", "wordpress",
+				"--exclude-application", "ubuntu"},
+			expected: common.DebugLogParams{
+				IncludeApplication: []string{"mysql", "wordpress"},
+				ExcludeApplication: []string{"ubuntu"},
+				Backlog:            10,
+			},
+		}, {
+			args:     []string{"--include-application", "mysql/0"},
+			errMatch: `application name "mysql/0" not valid`,
+		}, {
+			args: []string{"--include-label", "http", "--exclude-label", "noisy"},
+			expected: common.DebugLogParams{
+				IncludeLabel: []string{"http"},
+				ExcludeLabel: []string{"noisy"},
+				Backlog:      10,
+			},
+		}, {
+			args: []string{"--message", "hook .* failed"},
+			expected: common.DebugLogParams{
+				MessageRegex: "hook .* failed",
+				Backlog:      10,
+			},
+		}, {
+			args: []string{"--retained"},
+			expected: common.DebugLogParams{
+				Retained: true,
+				Backlog:  10,
+			},
+		}, {
+			args:     []string{"--message", "("},
+			errMatch: `invalid --message: error parsing regexp: .*`,
+		}, {
+			args: []string{"--after", "2021-03-01", "--before", "2021-03-01T12:30:00Z"},
+			expected: common.DebugLogParams{
+				StartTime: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2021, 3, 1, 12, 30, 0, 0, time.UTC),
+				Backlog:   10,
+			},
+		}, {
+			args:     []string{"--after", "yesterday"},
+			errMatch: `invalid --after: expected an RFC3339 time, a YYYY-MM-DD date or a duration, got "yesterday"`,
+		}, {
+			args:     []string{"--after", "2021-03-02", "--before", "2021-03-01"},
+			errMatch: `--after must be earlier than --before`,
 		},
 	} {
 		c.Logf("test %v", i)
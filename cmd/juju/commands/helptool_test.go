@@ -73,6 +73,11 @@ Currently available charm hook tools are:
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
     relation-set             set relation settings
+    secret-add               add a new secret
+    secret-get               get the content of a secret
+    secret-grant             grant access to a secret
+    secret-revoke            revoke access to a secret
+    secret-set               update an existing secret
     state-delete             delete server-side-state key value pair
     state-get                print server-side-state value
     state-set                set server-side-state values
@@ -81,6 +86,8 @@ Currently available charm hook tools are:
     storage-add              add storage instances
     storage-get              print information for storage instance with specified id
     storage-list             list storage attached to the unit
+    timer-delete             cancel a timer
+    timer-set                schedule a timer
     unit-get                 print public-address or private-address
 
 Examples:
@@ -126,6 +133,11 @@ var expectedCommands = []string{
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"secret-add",
+	"secret-get",
+	"secret-grant",
+	"secret-revoke",
+	"secret-set",
 	"state-delete",
 	"state-get",
 	"state-set",
@@ -134,6 +146,8 @@ var expectedCommands = []string{
 	"storage-add",
 	"storage-get",
 	"storage-list",
+	"timer-delete",
+	"timer-set",
 	"unit-get",
 }
 
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/loggo"
 	proxyutils "github.com/juju/proxy"
 
+	"github.com/juju/juju/api"
 	cloudfile "github.com/juju/juju/cloud"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/action"
@@ -36,11 +37,13 @@ import (
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/cmd/juju/resource"
 	rcmd "github.com/juju/juju/cmd/juju/romulus/commands"
+	"github.com/juju/juju/cmd/juju/secrets"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/juju/subnet"
+	"github.com/juju/juju/cmd/juju/top"
 	"github.com/juju/juju/cmd/juju/user"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/feature"
@@ -187,6 +190,11 @@ func (m jujuMain) Run(args []string) int {
 	if repl || showHelp {
 		return cmd.Main(newReplCommand(showHelp), ctx, nil)
 	}
+	// Run the commands of a script through a single juju process:
+	// $ juju --script file
+	if len(args) > 1 && isScriptArg(args[1]) {
+		return cmd.Main(newScriptCommand(), ctx, args[1:])
+	}
 	// We have registered a juju "version" command to replace the inbuilt one.
 	// There's special processing to call the inbuilt version command if the
 	// --version flag is set. But we want to invoke the juju version command.
@@ -226,6 +234,21 @@ func NewJujuCommand(ctx *cmd.Context, jujuMsg string) cmd.Command {
 // NewJujuCommandWithStore creates the "juju" super command with the specified parameters.
 func NewJujuCommandWithStore(
 	ctx *cmd.Context, store jujuclient.ClientStore, log *cmd.Log, jujuMsg, helpHint string, whitelist []string, embedded bool,
+) cmd.Command {
+	return newJujuCommand(ctx, store, log, jujuMsg, helpHint, whitelist, embedded, nil)
+}
+
+// newJujuCommandWithAPIOpen creates the "juju" super command, with the
+// API connections of its commands opened by apiOpen.
+func newJujuCommandWithAPIOpen(
+	ctx *cmd.Context, store jujuclient.ClientStore, log *cmd.Log, jujuMsg, helpHint string, apiOpen api.OpenFunc,
+) cmd.Command {
+	return newJujuCommand(ctx, store, log, jujuMsg, helpHint, nil, false, apiOpen)
+}
+
+func newJujuCommand(
+	ctx *cmd.Context, store jujuclient.ClientStore, log *cmd.Log, jujuMsg, helpHint string, whitelist []string, embedded bool,
+	apiOpen api.OpenFunc,
 ) cmd.Command {
 	var jcmd *cmd.SuperCommand
 	var jujuRegistry *jujuCommandRegistry
@@ -267,6 +290,7 @@ func NewJujuCommandWithStore(
 		whitelist:       set.NewStrings(whitelist...),
 		excluded:        set.NewStrings(),
 		embedded:        embedded,
+		apiOpen:         apiOpen,
 	}
 	registerCommands(jujuRegistry)
 	return jcmd
@@ -297,6 +321,10 @@ type hasClientStore interface {
 	SetClientStore(store jujuclient.ClientStore)
 }
 
+type hasAPIOpen interface {
+	SetAPIOpen(apiOpen api.OpenFunc)
+}
+
 type jujuCommandRegistry struct {
 	commandRegistry
 
@@ -304,6 +332,7 @@ type jujuCommandRegistry struct {
 	whitelist set.Strings
 	excluded  set.Strings
 	embedded  bool
+	apiOpen   api.OpenFunc
 }
 
 // Register adds a command to the registry so it can be used.
@@ -322,6 +351,11 @@ func (r jujuCommandRegistry) Register(c cmd.Command) {
 	if csc, ok := c.(hasClientStore); ok {
 		csc.SetClientStore(r.store)
 	}
+	if r.apiOpen != nil {
+		if ao, ok := c.(hasAPIOpen); ok {
+			ao.SetAPIOpen(r.apiOpen)
+		}
+	}
 	r.commandRegistry.Register(c)
 }
 
@@ -360,6 +394,9 @@ func registerCommands(r commandRegistry) {
 	r.Register(firewall.NewSetFirewallRuleCommand())
 	r.Register(firewall.NewListFirewallRulesCommand())
 
+	// Secrets commands.
+	r.Register(secrets.NewListSecretsCommand())
+
 	// Destruction commands.
 	r.Register(application.NewRemoveRelationCommand())
 	r.Register(application.NewRemoveApplicationCommand())
@@ -376,6 +413,8 @@ func registerCommands(r commandRegistry) {
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil, nil))
 	r.Register(application.NewResolvedCommand())
+	r.Register(application.NewCancelHookCommand())
+	r.Register(application.NewShowHookHistoryCommand())
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
 	r.Register(newDebugCodeCommand(nil))
@@ -403,6 +442,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(backups.NewListCommand())
 	r.Register(backups.NewRemoveCommand())
 	r.Register(backups.NewUploadCommand())
+	r.Register(backups.NewVerifyCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -475,6 +515,7 @@ func registerCommands(r commandRegistry) {
 
 	// Manage and control applications
 	r.Register(application.NewAddUnitCommand())
+	r.Register(application.NewApplyCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
@@ -527,6 +568,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -565,6 +607,9 @@ func registerCommands(r commandRegistry) {
 	r.Register(dashboard.NewDashboardCommand())
 	r.Register(dashboard.NewUpgradeDashboardCommand())
 
+	// Terminal dashboard commands.
+	r.Register(top.NewTopCommand())
+
 	// Resource commands
 	r.Register(resource.NewUploadCommand(resource.UploadDeps{
 		NewClient: func(c *resource.UploadCommand) (resource.UploadClient, error) {
@@ -300,15 +300,18 @@ var commandNames = []string{
 	"add-user",
 	"agree",
 	"agreements",
+	"apply",
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bind",
 	"bootstrap",
 	"budget",
 	"cached-images",
+	"cancel-hook",
 	"cancel-task",
 	"change-user-password",
 	"charm",
@@ -380,6 +383,7 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-secrets",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -435,6 +439,7 @@ var commandNames = []string{
 	"run",
 	"scale-application",
 	"scp",
+	"secrets",
 	"set-credential",
 	"set-constraints",
 	"set-default-credential",
@@ -452,6 +457,7 @@ var commandNames = []string{
 	"show-controller",
 	"show-credential",
 	"show-credentials",
+	"show-hook-history",
 	"show-machine",
 	"show-model",
 	"show-offer",
@@ -476,6 +482,7 @@ var commandNames = []string{
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"top",
 	"trust",
 	"unexpose",
 	"unregister",
@@ -493,6 +500,7 @@ var commandNames = []string{
 	"upgrade-series",
 	"upload-backup",
 	"users",
+	"verify-backup",
 	"version",
 	"wallets",
 	"whoami",
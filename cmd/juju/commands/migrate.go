@@ -10,6 +10,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 	"gopkg.in/macaroon.v2"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -35,6 +37,7 @@ func newMigrateCommand() modelcmd.ModelCommand {
 type migrateCommand struct {
 	modelcmd.ModelCommandBase
 	targetController string
+	out              cmd.Output
 
 	// Overridden by tests
 	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
@@ -80,7 +83,9 @@ for details of how to do this.
 
 This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
-"status" command and by consulting the logs.
+"status" command and by consulting the logs. With --format yaml or json,
+the model, the target controller and the ID of the migration are written
+to stdout, so that scripts can track it.
 
 See also:
     login
@@ -98,6 +103,12 @@ func (c *migrateCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -150,7 +161,22 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	ctx.Infof("Migration started with ID %q", id)
-	return nil
+	if c.out.Name() == output.HumanFormat {
+		return nil
+	}
+	return c.out.Write(ctx, migrateResult{
+		Model:            modelName,
+		TargetController: c.targetController,
+		MigrationID:      id,
+	})
+}
+
+// migrateResult is written by migrate when a structured format is
+// requested.
+type migrateResult struct {
+	Model            string `yaml:"model" json:"model"`
+	TargetController string `yaml:"target-controller" json:"target-controller"`
+	MigrationID      string `yaml:"migration-id" json:"migration-id"`
 }
 
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
@@ -262,6 +262,15 @@ func (s *MigrateSuite) TestSuccess(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestSuccessFormat(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "target", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Matches,
+		`{"model":"(.*/)?model","target-controller":"target","migration-id":"uuid:0"}\n`)
+	c.Check(s.api.specSeen.ModelUUID, gc.Equals, modelUUID)
+}
+
 func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	err := s.store.UpdateAccount("target", jujuclient.AccountDetails{
 		User:     "targetuser",
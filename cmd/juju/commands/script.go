@@ -0,0 +1,329 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/kballard/go-shellquote"
+
+	"github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+const scriptDoc = `
+Run the Juju commands in a script one after the other, reusing the API
+connections between them to avoid logging in for each command. The
+script is read from the given file, or from stdin if the file is "-".
+
+Each line of the script holds a command and its arguments, as they would
+be typed after "juju"; arguments can be quoted as in a shell. Empty lines
+and lines starting with "#" are ignored.
+
+The JSON output of a command can be captured in a variable, and used in
+the arguments of later commands:
+
+    units := add-unit mysql -n 2 --format json
+    ssh ${units.units.0} uptime
+
+The path after the variable name selects a value inside the output, with
+object keys and array indexes separated by dots.
+
+The script stops at the first command which fails, unless --keep-going is
+specified.
+
+Examples:
+
+    juju --script deploy.juju
+    cat deploy.juju | juju --script - --keep-going
+`
+
+// isScriptArg returns whether the first argument given to juju asks for a
+// script to be run.
+func isScriptArg(arg string) bool {
+	return arg == "--script" || strings.HasPrefix(arg, "--script=") || arg == "--keep-going"
+}
+
+// scriptCommand runs a script of juju commands.
+type scriptCommand struct {
+	cmd.CommandBase
+
+	store      jujuclient.ClientStore
+	scriptPath string
+	keepGoing  bool
+
+	apiOpen         api.OpenFunc
+	execJujuCommand func(cmd.Command, *cmd.Context, []string) int
+}
+
+func newScriptCommand() cmd.Command {
+	return &scriptCommand{
+		store:           jujuclient.NewFileClientStore(),
+		apiOpen:         api.Open,
+		execJujuCommand: cmd.Main,
+	}
+}
+
+// Info implements Command.
+func (c *scriptCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "juju",
+		Args:    "--script <file>",
+		Purpose: "Run a script of Juju commands",
+		Doc:     scriptDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *scriptCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.scriptPath, "script", "", `The script to run, or "-" to read it from stdin`)
+	f.BoolVar(&c.keepGoing, "keep-going", false, "Run the rest of the script when a command fails")
+}
+
+// Init implements Command.
+func (c *scriptCommand) Init(args []string) error {
+	if c.scriptPath == "" {
+		return errors.New("no script specified")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.
+func (c *scriptCommand) Run(ctx *cmd.Context) error {
+	var in io.Reader = ctx.Stdin
+	if c.scriptPath != "-" {
+		f, err := os.Open(ctx.AbsPath(c.scriptPath))
+		if err != nil {
+			return errors.Annotate(err, "opening script")
+		}
+		defer f.Close()
+		in = f
+	}
+
+	conns := newSharedConnections(c.apiOpen)
+	defer conns.closeAll()
+
+	vars := make(map[string]interface{})
+	failed := 0
+	scanner := bufio.NewScanner(in)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if quitCommands.Contains(strings.ToLower(line)) {
+			break
+		}
+		if err := c.runLine(ctx, conns, vars, line); err != nil {
+			if errors.Cause(err) != cmd.ErrSilent {
+				fmt.Fprintf(ctx.Stderr, "ERROR line %d: %v\n", lineNum, err)
+			}
+			failed++
+			if !c.keepGoing {
+				return errors.Errorf("script stopped at line %d", lineNum)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Annotate(err, "reading script")
+	}
+	if failed > 0 {
+		return errors.Errorf("%d command(s) in the script failed", failed)
+	}
+	return nil
+}
+
+// runLine runs a single line of the script, capturing the output of the
+// command into vars if asked to. cmd.ErrSilent is returned if the command
+// failed, as it has already reported the error.
+func (c *scriptCommand) runLine(ctx *cmd.Context, conns *sharedConnections, vars map[string]interface{}, line string) error {
+	words, err := shellquote.Split(line)
+	if err != nil {
+		return errors.Annotate(err, "parsing line")
+	}
+	var capture string
+	if len(words) > 2 && words[1] == ":=" {
+		capture, words = words[0], words[2:]
+		if !validVariableName.MatchString(capture) {
+			return errors.NotValidf("variable name %q", capture)
+		}
+	}
+	args := make([]string, len(words))
+	for i, word := range words {
+		if args[i], err = expandVariables(word, vars); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	cmdCtx := ctx
+	var out bytes.Buffer
+	if capture != "" {
+		cmdCtx = &cmd.Context{
+			Dir:    ctx.Dir,
+			Env:    ctx.Env,
+			Stdin:  ctx.Stdin,
+			Stdout: &out,
+			Stderr: ctx.Stderr,
+		}
+	}
+	jujuCmd := newJujuCommandWithAPIOpen(cmdCtx, c.store, jujucmd.DefaultLog, "", cliHelpHint, conns.open)
+	if code := c.execJujuCommand(jujuCmd, cmdCtx, args); code != 0 {
+		return cmd.ErrSilent
+	}
+
+	if capture != "" {
+		var value interface{}
+		if err := json.Unmarshal(out.Bytes(), &value); err != nil {
+			return errors.Errorf("cannot capture output of %q as JSON (is --format json missing?): %v", args[0], err)
+		}
+		vars[capture] = value
+	}
+	return nil
+}
+
+var (
+	validVariableName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+	variableReference = regexp.MustCompile(`\$\{([^}]*)\}`)
+)
+
+// expandVariables replaces the references to variables in a word of the
+// script with their values.
+func expandVariables(word string, vars map[string]interface{}) (string, error) {
+	var err error
+	result := variableReference.ReplaceAllStringFunc(word, func(ref string) string {
+		if err != nil {
+			return ""
+		}
+		var value interface{}
+		value, err = lookupVariable(ref[2:len(ref)-1], vars)
+		if err != nil {
+			return ""
+		}
+		var s string
+		s, err = formatVariable(value)
+		return s
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return result, nil
+}
+
+// lookupVariable returns the value selected by a variable name followed
+// by a path of object keys and array indexes separated by dots.
+func lookupVariable(ref string, vars map[string]interface{}) (interface{}, error) {
+	parts := strings.Split(ref, ".")
+	value, ok := vars[parts[0]]
+	if !ok {
+		return nil, errors.NotFoundf("variable %q", parts[0])
+	}
+	for i, part := range parts[1:] {
+		path := strings.Join(parts[:i+2], ".")
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if value, ok = v[part]; !ok {
+				return nil, errors.NotFoundf("%q", path)
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, errors.NotFoundf("%q", path)
+			}
+			value = v[index]
+		default:
+			return nil, errors.NotFoundf("%q", path)
+		}
+	}
+	return value, nil
+}
+
+// formatVariable returns the value of a variable as it is substituted
+// into the script. Objects and arrays are substituted as JSON.
+func formatVariable(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// sharedConnections opens API connections on behalf of the commands in a
+// script, so that commands using the same controller, model and user
+// share a single logged in connection.
+type sharedConnections struct {
+	apiOpen api.OpenFunc
+	conns   map[string]api.Connection
+}
+
+func newSharedConnections(apiOpen api.OpenFunc) *sharedConnections {
+	return &sharedConnections{
+		apiOpen: apiOpen,
+		conns:   make(map[string]api.Connection),
+	}
+}
+
+// open implements api.OpenFunc.
+func (s *sharedConnections) open(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+	var user string
+	if info.Tag != nil {
+		user = info.Tag.String()
+	}
+	key := strings.Join([]string{info.CACert, info.ModelTag.Id(), user}, "\n")
+	if conn, ok := s.conns[key]; ok {
+		if !conn.IsBroken() {
+			return sharedConnection{conn}, nil
+		}
+		_ = conn.Close()
+		delete(s.conns, key)
+	}
+	conn, err := s.apiOpen(info, opts)
+	if err != nil {
+		return nil, err
+	}
+	s.conns[key] = conn
+	return sharedConnection{conn}, nil
+}
+
+// closeAll closes the connections opened for the script.
+func (s *sharedConnections) closeAll() {
+	for key, conn := range s.conns {
+		if err := conn.Close(); err != nil {
+			logger.Errorf("closing API connection: %v", err)
+		}
+		delete(s.conns, key)
+	}
+}
+
+// sharedConnection is a connection which stays open when a command
+// closes it, so that later commands in the script can use it.
+type sharedConnection struct {
+	api.Connection
+}
+
+// Close implements api.Connection.
+func (sharedConnection) Close() error {
+	return nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&ScriptSuite{})
+
+type ScriptSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+
+	ran     [][]string
+	outputs map[string]string
+	fail    map[string]bool
+}
+
+func (s *ScriptSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.ran = nil
+	s.outputs = make(map[string]string)
+	s.fail = make(map[string]bool)
+}
+
+func (s *ScriptSuite) execJujuCommand(_ cmd.Command, ctx *cmd.Context, args []string) int {
+	s.ran = append(s.ran, args)
+	if s.fail[args[0]] {
+		fmt.Fprintf(ctx.Stderr, "ERROR %s failed\n", args[0])
+		return 1
+	}
+	fmt.Fprint(ctx.Stdout, s.outputs[args[0]])
+	return 0
+}
+
+func (s *ScriptSuite) newCommand() *scriptCommand {
+	return &scriptCommand{
+		store:           jujuclient.NewMemStore(),
+		apiOpen:         api.Open,
+		execJujuCommand: s.execJujuCommand,
+	}
+}
+
+func (s *ScriptSuite) writeScript(c *gc.C, script string) string {
+	path := filepath.Join(c.MkDir(), "script.juju")
+	err := ioutil.WriteFile(path, []byte(script), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *ScriptSuite) TestInitRequiresScript(c *gc.C) {
+	err := cmdtesting.InitCommand(s.newCommand(), []string{"--keep-going"})
+	c.Assert(err, gc.ErrorMatches, "no script specified")
+}
+
+func (s *ScriptSuite) TestRunScript(c *gc.C) {
+	path := s.writeScript(c, `
+# Deploy a database.
+deploy mysql --config "name=my db"
+
+status --format json
+`)
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--script", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.ran, jc.DeepEquals, [][]string{
+		{"deploy", "mysql", "--config", "name=my db"},
+		{"status", "--format", "json"},
+	})
+}
+
+func (s *ScriptSuite) TestRunScriptFromStdin(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("status\nquit\nmodels\n")
+	command := s.newCommand()
+	err := cmdtesting.InitCommand(command, []string{"--script", "-"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.ran, jc.DeepEquals, [][]string{{"status"}})
+}
+
+func (s *ScriptSuite) TestCaptureVariables(c *gc.C) {
+	s.outputs["add-unit"] = `{"application":"mysql","units":["mysql/1","mysql/2"]}`
+	path := s.writeScript(c, `
+units := add-unit mysql -n 2 --format json
+ssh ${units.units.1} uptime
+show-unit "${units.units}"
+`)
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--script", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.ran, jc.DeepEquals, [][]string{
+		{"add-unit", "mysql", "-n", "2", "--format", "json"},
+		{"ssh", "mysql/2", "uptime"},
+		{"show-unit", `["mysql/1","mysql/2"]`},
+	})
+	// The captured output is not printed.
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
+
+func (s *ScriptSuite) TestCaptureNeedsJSON(c *gc.C) {
+	s.outputs["add-unit"] = "added\n"
+	path := s.writeScript(c, "units := add-unit mysql\n")
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--script", path)
+	c.Assert(err, gc.ErrorMatches, "script stopped at line 1")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `cannot capture output of "add-unit" as JSON (is --format json missing?)`)
+}
+
+func (s *ScriptSuite) TestUndefinedVariable(c *gc.C) {
+	s.outputs["add-unit"] = `{"units":["mysql/1"]}`
+	path := s.writeScript(c, `
+units := add-unit mysql --format json
+ssh ${units.units.3}
+ssh ${machines.0}
+`)
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--script", path, "--keep-going")
+	c.Assert(err, gc.ErrorMatches, `2 command\(s\) in the script failed`)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+ERROR line 3: "units.units.3" not found
+ERROR line 4: variable "machines" not found
+`[1:])
+	c.Assert(s.ran, gc.HasLen, 1)
+}
+
+func (s *ScriptSuite) TestStopsOnFirstError(c *gc.C) {
+	s.fail["deploy"] = true
+	path := s.writeScript(c, "status\ndeploy mysql\nmodels\n")
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--script", path)
+	c.Assert(err, gc.ErrorMatches, "script stopped at line 2")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "ERROR deploy failed\n")
+	c.Assert(s.ran, jc.DeepEquals, [][]string{{"status"}, {"deploy", "mysql"}})
+}
+
+func (s *ScriptSuite) TestKeepGoing(c *gc.C) {
+	s.fail["deploy"] = true
+	path := s.writeScript(c, "status\ndeploy mysql\nmodels\n")
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--script", path, "--keep-going")
+	c.Assert(err, gc.ErrorMatches, `1 command\(s\) in the script failed`)
+	c.Assert(s.ran, jc.DeepEquals, [][]string{{"status"}, {"deploy", "mysql"}, {"models"}})
+}
+
+func (s *ScriptSuite) TestInvalidVariableName(c *gc.C) {
+	path := s.writeScript(c, "a.b := status --format json\n")
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--script", path)
+	c.Assert(err, gc.ErrorMatches, "script stopped at line 1")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "ERROR line 1: variable name \"a.b\" not valid\n")
+}
+
+func (s *ScriptSuite) TestIsScriptArg(c *gc.C) {
+	c.Check(isScriptArg("--script"), jc.IsTrue)
+	c.Check(isScriptArg("--script=deploy.juju"), jc.IsTrue)
+	c.Check(isScriptArg("--keep-going"), jc.IsTrue)
+	c.Check(isScriptArg("status"), jc.IsFalse)
+}
+
+type fakeConnection struct {
+	api.Connection
+	broken bool
+	closed bool
+}
+
+func (f *fakeConnection) IsBroken() bool {
+	return f.broken
+}
+
+func (f *fakeConnection) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (s *ScriptSuite) TestSharedConnections(c *gc.C) {
+	var opened []*fakeConnection
+	conns := newSharedConnections(func(*api.Info, api.DialOpts) (api.Connection, error) {
+		conn := &fakeConnection{}
+		opened = append(opened, conn)
+		return conn, nil
+	})
+	model := &api.Info{CACert: "cert", ModelTag: names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"), Tag: names.NewUserTag("bob")}
+	controller := &api.Info{CACert: "cert", Tag: names.NewUserTag("bob")}
+
+	conn, err := conns.open(model, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conn.Close(), jc.ErrorIsNil)
+	_, err = conns.open(model, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = conns.open(controller, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opened, gc.HasLen, 2)
+	c.Assert(opened[0].closed, jc.IsFalse)
+
+	// A broken connection is replaced.
+	opened[0].broken = true
+	_, err = conns.open(model, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opened, gc.HasLen, 3)
+	c.Assert(opened[0].closed, jc.IsTrue)
+
+	conns.closeAll()
+	c.Assert(opened[1].closed, jc.IsTrue)
+	c.Assert(opened[2].closed, jc.IsTrue)
+}
@@ -51,10 +51,13 @@ specified:
 The command will abort if an upgrade is in progress. It will also abort if
 a previous upgrade was not fully completed (e.g.: if one of the
 controllers in a high availability model failed to upgrade).
+With --format yaml or json, the current and chosen versions, and whether
+the upgrade was started, are written to stdout.
 
 Examples:
     juju upgrade-controller --dry-run
     juju upgrade-controller --agent-version 2.0.1
+    juju upgrade-controller --format json
     
 See also: 
     upgrade-model`
@@ -253,6 +256,10 @@ func (c *upgradeControllerCommand) upgradeCAASController(ctx *cmd.Context) error
 	if err := context.validate(); err != nil {
 		return err
 	}
+	result := upgradeResult{
+		CurrentVersion: currentAgentVersion.String(),
+		ChosenVersion:  context.chosen.String(),
+	}
 	ctx.Verbosef("available agent images:\n%s", formatVersions(context.packagedAgents))
 	fmt.Fprintf(ctx.Stderr, "best version:\n    %v\n", context.chosen)
 	if warnCompat {
@@ -261,9 +268,14 @@ func (c *upgradeControllerCommand) upgradeCAASController(ctx *cmd.Context) error
 	if c.DryRun {
 		c.upgradeMessage = "upgrade to this version by running\n    juju upgrade-controller"
 		fmt.Fprintf(ctx.Stderr, "%s\n", c.upgradeMessage)
-		return nil
+		result.DryRun = true
+		return c.writeResult(ctx, result)
+	}
+	if err := c.notifyControllerUpgrade(ctx, client, context); err != nil {
+		return err
 	}
-	return c.notifyControllerUpgrade(ctx, client, context)
+	result.Started = true
+	return c.writeResult(ctx, result)
 }
 
 // initCAASVersions collects state relevant to an upgrade decision. The returned
@@ -340,6 +352,7 @@ func (c *upgradeControllerCommand) upgradeIAASController(ctx *cmd.Context, contr
 	if c.AssumeYes {
 		args = append(args, "--yes")
 	}
+	args = append(args, "--format", c.out.Name())
 	code := cmd.Main(wrapped, ctx, args)
 	if code == 0 {
 		return nil
@@ -28,6 +28,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs"
@@ -69,11 +70,14 @@ the lifetime of this upgrade using --agent-stream
 If a failed upgrade has been resolved, '--reset-previous-upgrade' can be
 used to allow the upgrade to proceed.
 Backups are recommended prior to upgrading.
+With --format yaml or json, the current and chosen versions, and whether
+the upgrade was started, are written to stdout.
 
 Examples:
     juju upgrade-model --dry-run
     juju upgrade-model --agent-version 2.0.1
     juju upgrade-model --agent-stream proposed
+    juju upgrade-model --format json
     
 See also: 
     sync-agent-binaries`
@@ -120,6 +124,7 @@ type baseUpgradeCommand struct {
 
 	rawArgs        []string
 	upgradeMessage string
+	out            cmd.Output
 
 	modelConfigAPI  modelConfigAPI
 	modelManagerAPI modelManagerAPI
@@ -136,6 +141,35 @@ func (c *baseUpgradeCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.AssumeYes, "yes", false, "")
 	f.BoolVar(&c.IgnoreAgentVersions, "ignore-agent-versions", false,
 		"Don't check if all agents have already reached the current version")
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
+}
+
+// upgradeResult is written by the upgrade commands when a structured
+// format is requested.
+type upgradeResult struct {
+	CurrentVersion string `yaml:"current-version" json:"current-version"`
+	ChosenVersion  string `yaml:"chosen-version,omitempty" json:"chosen-version,omitempty"`
+	UpToDate       bool   `yaml:"up-to-date,omitempty" json:"up-to-date,omitempty"`
+	DryRun         bool   `yaml:"dry-run,omitempty" json:"dry-run,omitempty"`
+	Started        bool   `yaml:"started" json:"started"`
+}
+
+// writeResult writes the result of the upgrade if a structured format
+// was requested.
+func (c *baseUpgradeCommand) writeResult(ctx *cmd.Context, result upgradeResult) error {
+	if c.out.Name() == output.HumanFormat {
+		return nil
+	}
+	return c.out.Write(ctx, result)
+}
+
+// messageWriter returns the writer for messages to the user, which go to
+// stderr when stdout is reserved for a structured result.
+func (c *baseUpgradeCommand) messageWriter(ctx *cmd.Context) io.Writer {
+	if c.out.Name() == output.HumanFormat {
+		return ctx.Stdout
+	}
+	return ctx.Stderr
 }
 
 func (c *baseUpgradeCommand) Init(args []string) error {
@@ -409,10 +443,12 @@ func (c *upgradeJujuCommand) upgradeModel(ctx *cmd.Context, implicitUploadAllowe
 		return err
 	}
 	defer controllerClient.Close()
+	var result upgradeResult
 	defer func() {
 		if err == errUpToDate {
 			ctx.Infof(err.Error())
-			err = nil
+			result.UpToDate = true
+			err = c.writeResult(ctx, result)
 		}
 	}()
 
@@ -452,6 +488,7 @@ func (c *upgradeJujuCommand) upgradeModel(ctx *cmd.Context, implicitUploadAllowe
 		// Can't happen. In theory.
 		return errors.New("incomplete model configuration")
 	}
+	result.CurrentVersion = agentVersion.String()
 
 	warnCompat, err := c.precheckVersion(ctx, agentVersion)
 	if err != nil {
@@ -506,7 +543,7 @@ func (c *upgradeJujuCommand) upgradeModel(ctx *cmd.Context, implicitUploadAllowe
 		if c.BuildAgent {
 			builtMsg = " (built from source)"
 		}
-		fmt.Fprintf(ctx.Stdout, "no prepackaged agent binaries available, using local agent binary %v%s\n", upgradeCtx.chosen, builtMsg)
+		fmt.Fprintf(c.messageWriter(ctx), "no prepackaged agent binaries available, using local agent binary %v%s\n", upgradeCtx.chosen, builtMsg)
 		packagedAgentErr = nil
 	}
 	if packagedAgentErr != nil {
@@ -516,6 +553,7 @@ func (c *upgradeJujuCommand) upgradeModel(ctx *cmd.Context, implicitUploadAllowe
 	if err := upgradeCtx.validate(); err != nil {
 		return err
 	}
+	result.ChosenVersion = upgradeCtx.chosen.String()
 	ctx.Verbosef("available agent binaries:\n%s", formatVersions(upgradeCtx.packagedAgents))
 	fmt.Fprintf(ctx.Stderr, "best version:\n    %v\n", upgradeCtx.chosen)
 	if warnCompat {
@@ -527,10 +565,14 @@ func (c *upgradeJujuCommand) upgradeModel(ctx *cmd.Context, implicitUploadAllowe
 		} else {
 			fmt.Fprintf(ctx.Stderr, "%s\n", c.upgradeMessage)
 		}
-	} else {
-		return c.notifyControllerUpgrade(ctx, client, upgradeCtx)
+		result.DryRun = true
+		return c.writeResult(ctx, result)
 	}
-	return nil
+	if err := c.notifyControllerUpgrade(ctx, client, upgradeCtx); err != nil {
+		return err
+	}
+	result.Started = true
+	return c.writeResult(ctx, result)
 }
 
 func (c *baseUpgradeCommand) notifyControllerUpgrade(ctx *cmd.Context, client upgradeJujuAPI, upgradeCtx *upgradeContext) error {
@@ -556,7 +598,7 @@ func (c *baseUpgradeCommand) notifyControllerUpgrade(ctx *cmd.Context, client up
 		}
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
-	fmt.Fprintf(ctx.Stdout, "started upgrade to %s\n", upgradeCtx.chosen)
+	fmt.Fprintf(c.messageWriter(ctx), "started upgrade to %s\n", upgradeCtx.chosen)
 	return nil
 }
 
@@ -755,7 +797,7 @@ func (c *baseUpgradeCommand) confirmResetPreviousUpgrade(ctx *cmd.Context) (bool
 	if c.AssumeYes {
 		return true, nil
 	}
-	fmt.Fprint(ctx.Stdout, resetPreviousUpgradeMessage)
+	fmt.Fprint(c.messageWriter(ctx), resetPreviousUpgradeMessage)
 	scanner := bufio.NewScanner(ctx.Stdin)
 	scanner.Scan()
 	err := scanner.Err()
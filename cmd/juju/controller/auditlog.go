@@ -0,0 +1,322 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/v2"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// defaultAuditLogLimit is the number of conversations shown unless
+// --limit says otherwise.
+const defaultAuditLogLimit = 50
+
+var auditLogDoc = `
+Searches the audit logs recorded by every controller node and shows the
+matching conversations. A conversation is a single client command (such
+as "juju deploy") along with the API requests it made and any errors
+returned in response.
+
+Times given to --after and --before may be RFC3339 timestamps, dates in
+YYYY-MM-DD form (midnight UTC), or durations such as 90m or 2h, which are
+measured back from now.
+
+Only controller superusers can search the audit log, and audit logging
+must be enabled with the "auditing-enabled" controller config setting.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --after 2h
+    juju audit-log --facade Application --method Deploy --errors
+    juju audit-log --model-uuid 6f1c5a2d-... --after 2021-03-01 --format yaml
+
+See also:
+    controller-config
+`
+
+// NewAuditLogCommand returns a command to search the controller audit
+// logs.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{})
+}
+
+// AuditLogAPI defines the API methods used by the audit-log command.
+type AuditLogAPI interface {
+	Close() error
+	Query(params.AuditLogQueryArgs) (params.AuditLogQueryResult, error)
+}
+
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	api   AuditLogAPI
+	clock clock.Clock
+
+	user       string
+	modelUUID  string
+	facade     string
+	method     string
+	after      string
+	before     string
+	errorsOnly bool
+	limit      int
+	showArgs   bool
+
+	query params.AuditLogQueryArgs
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Searches the controller audit logs.",
+		Doc:     auditLogDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Only show conversations started by this user")
+	f.StringVar(&c.modelUUID, "model-uuid", "", "Only show conversations with the model with this UUID")
+	f.StringVar(&c.facade, "facade", "", "Only show requests to this API facade")
+	f.StringVar(&c.method, "method", "", "Only show requests to this API method")
+	f.StringVar(&c.after, "after", "", "Only show conversations started at or after this time")
+	f.StringVar(&c.before, "before", "", "Only show conversations started before this time")
+	f.BoolVar(&c.errorsOnly, "errors", false, "Only show requests which returned errors")
+	f.IntVar(&c.limit, "limit", defaultAuditLogLimit, "The maximum number of conversations to show, keeping the most recent (0 for no limit)")
+	f.BoolVar(&c.showArgs, "show-args", false, "Include the recorded API request arguments")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	if c.modelUUID != "" && !utils.IsValidUUIDString(c.modelUUID) {
+		return errors.NotValidf("model UUID %q", c.modelUUID)
+	}
+	if c.limit < 0 {
+		return errors.NotValidf("negative limit %d", c.limit)
+	}
+	if c.clock == nil {
+		c.clock = clock.WallClock
+	}
+	now := c.clock.Now()
+	after, err := parseAuditLogTime(c.after, now)
+	if err != nil {
+		return errors.Annotate(err, "invalid --after")
+	}
+	before, err := parseAuditLogTime(c.before, now)
+	if err != nil {
+		return errors.Annotate(err, "invalid --before")
+	}
+	if after != nil && before != nil && !after.Before(*before) {
+		return errors.New("--after must be earlier than --before")
+	}
+	c.query = params.AuditLogQueryArgs{
+		User:       c.user,
+		ModelUUID:  c.modelUUID,
+		Facade:     c.facade,
+		Method:     c.method,
+		After:      after,
+		Before:     before,
+		ErrorsOnly: c.errorsOnly,
+		Limit:      c.limit,
+	}
+	return nil
+}
+
+// parseAuditLogTime parses a time given as an RFC3339 timestamp, a
+// date, or a duration before now.
+func parseAuditLogTime(value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return &t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		t := now.Add(-d)
+		return &t, nil
+	}
+	return nil, errors.Errorf("expected an RFC3339 time, a YYYY-MM-DD date or a duration, got %q", value)
+}
+
+func (c *auditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.Query(c.query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, u := range result.Unavailable {
+		ctx.Warningf("audit log for controller %s not searched: %s", u.ControllerID, u.Reason)
+	}
+	conversations := make([]AuditLogConversation, len(result.Conversations))
+	for i, conv := range result.Conversations {
+		conversations[i] = c.formatConversation(conv)
+	}
+	if len(conversations) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No matching audit log entries.")
+		return nil
+	}
+	return errors.Trace(c.out.Write(ctx, conversations))
+}
+
+// AuditLogConversation is the formatted view of a conversation from
+// the audit log.
+type AuditLogConversation struct {
+	Controller     string            `yaml:"controller" json:"controller"`
+	ConversationID string            `yaml:"conversation-id" json:"conversation-id"`
+	ConnectionID   string            `yaml:"connection-id" json:"connection-id"`
+	Who            string            `yaml:"who" json:"who"`
+	What           string            `yaml:"what" json:"what"`
+	When           string            `yaml:"when" json:"when"`
+	Model          string            `yaml:"model,omitempty" json:"model,omitempty"`
+	ModelUUID      string            `yaml:"model-uuid,omitempty" json:"model-uuid,omitempty"`
+	Requests       []AuditLogRequest `yaml:"requests,omitempty" json:"requests,omitempty"`
+}
+
+// AuditLogRequest is the formatted view of an API request from the
+// audit log.
+type AuditLogRequest struct {
+	RequestID uint64          `yaml:"request-id" json:"request-id"`
+	When      string          `yaml:"when" json:"when"`
+	Facade    string          `yaml:"facade" json:"facade"`
+	Method    string          `yaml:"method" json:"method"`
+	Version   int             `yaml:"version" json:"version"`
+	Args      string          `yaml:"args,omitempty" json:"args,omitempty"`
+	Errors    []AuditLogError `yaml:"errors,omitempty" json:"errors,omitempty"`
+}
+
+// AuditLogError is the formatted view of an error returned from an
+// API request.
+type AuditLogError struct {
+	Message string `yaml:"message" json:"message"`
+	Code    string `yaml:"code,omitempty" json:"code,omitempty"`
+}
+
+func (c *auditLogCommand) formatConversation(conv params.AuditLogConversation) AuditLogConversation {
+	result := AuditLogConversation{
+		Controller:     conv.ControllerID,
+		ConversationID: conv.ConversationID,
+		ConnectionID:   conv.ConnectionID,
+		Who:            conv.Who,
+		What:           conv.What,
+		When:           formatAuditLogTime(conv.When),
+		Model:          conv.ModelName,
+		ModelUUID:      conv.ModelUUID,
+	}
+	for _, req := range conv.Requests {
+		request := AuditLogRequest{
+			RequestID: req.RequestID,
+			When:      formatAuditLogTime(req.When),
+			Facade:    req.Facade,
+			Method:    req.Method,
+			Version:   req.Version,
+		}
+		if c.showArgs {
+			request.Args = req.Args
+		}
+		for _, e := range req.Errors {
+			request.Errors = append(request.Errors, AuditLogError{
+				Message: e.Message,
+				Code:    e.Code,
+			})
+		}
+		result.Requests = append(result.Requests, request)
+	}
+	return result
+}
+
+func formatAuditLogTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatAuditLogTabular writes one line per request, with the
+// conversation details only shown on the first request of each
+// conversation.
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	conversations, ok := value.([]AuditLogConversation)
+	if !ok {
+		return errors.Errorf("expected value of type []AuditLogConversation, got %T", value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Controller", "User", "Model", "Command", "Request", "Error")
+	for _, conv := range conversations {
+		model := conv.Model
+		if model == "" {
+			model = conv.ModelUUID
+		}
+		row := []interface{}{conv.When, conv.Controller, conv.Who, model, conv.What}
+		if len(conv.Requests) == 0 {
+			w.Println(row...)
+			continue
+		}
+		for i, req := range conv.Requests {
+			if i > 0 {
+				row = []interface{}{"", "", "", "", ""}
+			}
+			request := fmt.Sprintf("%s.%s(%d)", req.Facade, req.Method, req.Version)
+			w.Println(append(row, request, formatAuditLogErrors(req.Errors))...)
+		}
+	}
+	return tw.Flush()
+}
+
+func formatAuditLogErrors(errs []AuditLogError) string {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Message
+		if e.Code != "" {
+			messages[i] = fmt.Sprintf("%s (%s)", e.Message, e.Code)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,214 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+)
+
+type auditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+var auditLogNow = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(auditLogNow)
+	s.api = &fakeAuditLogAPI{
+		result: params.AuditLogQueryResult{
+			Conversations: []params.AuditLogConversation{{
+				ControllerID:   "0",
+				ConversationID: "abc",
+				ConnectionID:   "1F",
+				Who:            "bob",
+				What:           "juju deploy mysql",
+				When:           time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+				ModelName:      "admin/default",
+				ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Requests: []params.AuditLogRequest{{
+					RequestID: 1,
+					When:      time.Date(2021, 3, 1, 10, 0, 1, 0, time.UTC),
+					Facade:    "Application",
+					Method:    "Deploy",
+					Version:   13,
+					Args:      `{"applications":[]}`,
+				}, {
+					RequestID: 2,
+					When:      time.Date(2021, 3, 1, 10, 0, 2, 0, time.UTC),
+					Facade:    "Application",
+					Method:    "SetConstraints",
+					Version:   13,
+					Errors:    []params.AuditLogError{{Message: "boom", Code: "not found"}},
+				}},
+			}},
+		},
+	}
+}
+
+func (s *auditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *auditLogSuite) TestQueryArgs(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model-uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"--facade", "Application",
+		"--method", "Deploy",
+		"--after", "2h",
+		"--before", "2021-03-01T11:30:00Z",
+		"--errors",
+		"--limit", "10",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	before := time.Date(2021, 3, 1, 11, 30, 0, 0, time.UTC)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Query", []interface{}{params.AuditLogQueryArgs{
+			User:       "bob",
+			ModelUUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Facade:     "Application",
+			Method:     "Deploy",
+			After:      &after,
+			Before:     &before,
+			ErrorsOnly: true,
+			Limit:      10,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *auditLogSuite) TestDefaultLimitAndDate(c *gc.C) {
+	_, err := s.run(c, "--after", "2021-02-28")
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)
+	s.api.CheckCall(c, 0, "Query", params.AuditLogQueryArgs{
+		After: &after,
+		Limit: 50,
+	})
+}
+
+func (s *auditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--model-uuid", "nope"},
+		err:  `model UUID "nope" not valid`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  `negative limit -1 not valid`,
+	}, {
+		args: []string{"--after", "yesterday"},
+		err:  `invalid --after: expected an RFC3339 time, a YYYY-MM-DD date or a duration, got "yesterday"`,
+	}, {
+		args: []string{"--after", "1h", "--before", "2h"},
+		err:  `--after must be earlier than --before`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *auditLogSuite) TestTabular(c *gc.C) {
+	s.api.result.Unavailable = []params.AuditLogUnavailable{{
+		ControllerID: "2",
+		Reason:       "timed out waiting for results",
+	}}
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  Controller  User  Model          Command            Request                         Error
+2021-03-01T10:00:00Z  0           bob   admin/default  juju deploy mysql  Application.Deploy(13)          
+                                                                          Application.SetConstraints(13)  boom (not found)
+`[1:])
+}
+
+func (s *auditLogSuite) TestTabularNoResults(c *gc.C) {
+	s.api.result = params.AuditLogQueryResult{}
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No matching audit log entries.\n")
+}
+
+func (s *auditLogSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml", "--show-args")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- controller: "0"
+  conversation-id: abc
+  connection-id: 1F
+  who: bob
+  what: juju deploy mysql
+  when: "2021-03-01T10:00:00Z"
+  model: admin/default
+  model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  requests:
+  - request-id: 1
+    when: "2021-03-01T10:00:01Z"
+    facade: Application
+    method: Deploy
+    version: 13
+    args: '{"applications":[]}'
+  - request-id: 2
+    when: "2021-03-01T10:00:02Z"
+    facade: Application
+    method: SetConstraints
+    version: 13
+    errors:
+    - message: boom
+      code: not found
+`[1:])
+}
+
+func (s *auditLogSuite) TestJSON(c *gc.C) {
+	s.api.result.Conversations[0].Requests = nil
+	ctx, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `[{"controller":"0","conversation-id":"abc","connection-id":"1F","who":"bob","what":"juju deploy mysql","when":"2021-03-01T10:00:00Z","model":"admin/default","model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d"}]`+"\n")
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	s.api.SetErrors(errors.New("permission denied"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	testing.Stub
+	result params.AuditLogQueryResult
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.AddCall("Close")
+	return f.NextErr()
+}
+
+func (f *fakeAuditLogAPI) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResult, error) {
+	f.AddCall("Query", args)
+	return f.result, f.NextErr()
+}
@@ -28,10 +28,15 @@ func NewListControllersCommandForTest(testStore jujuclient.ClientStore, api func
 
 // NewShowControllerCommandForTest returns a showControllerCommand with the clientstore provided
 // as specified.
-func NewShowControllerCommandForTest(testStore jujuclient.ClientStore, api func(string) ControllerAccessAPI) *showControllerCommand {
+func NewShowControllerCommandForTest(
+	testStore jujuclient.ClientStore,
+	api func(string) ControllerAccessAPI,
+	backupsAPI func(controllerName, modelName string) BackupScheduleAPI,
+) *showControllerCommand {
 	return &showControllerCommand{
-		store: testStore,
-		api:   api,
+		store:      testStore,
+		api:        api,
+		backupsAPI: backupsAPI,
 	}
 }
 
@@ -171,6 +176,14 @@ func NewConfigCommandForTest(api controllerAPI, store jujuclient.ClientStore) cm
 	return modelcmd.WrapController(c)
 }
 
+// NewAuditLogCommandForTest returns an audit-log command with the API
+// and clock provided as specified.
+func NewAuditLogCommandForTest(api AuditLogAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	c := &auditLogCommand{api: api, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 type CtrData ctrData
 type ModelData modelData
 
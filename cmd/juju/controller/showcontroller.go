@@ -6,12 +6,14 @@ package controller
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/api/backups"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/apiserver/params"
@@ -29,8 +31,9 @@ var usageShowControllerSummary = `
 Shows detailed information of a controller.`[1:]
 
 var usageShowControllerDetails = `
-Shows extended information about a controller(s) as well as related models
-and user login details.
+Shows extended information about a controller(s) as well as related models,
+user login details and, if the controller creates scheduled backups, the
+backup schedule and the outcome of the last scheduled backup.
 
 Examples:
     juju show-controller
@@ -47,6 +50,10 @@ type showControllerCommand struct {
 	mu    sync.Mutex
 	api   func(controllerName string) ControllerAccessAPI
 
+	// backupsAPI is used in tests in place of connecting to the
+	// controller model to read the backup schedule.
+	backupsAPI func(controllerName, modelName string) BackupScheduleAPI
+
 	controllerNames []string
 	showPasswords   bool
 }
@@ -102,6 +109,29 @@ type ControllerAccessAPI interface {
 	Close() error
 }
 
+// BackupScheduleAPI defines the subset of the api/backups/Client API
+// used to show the controller's backup schedule.
+type BackupScheduleAPI interface {
+	Schedule() (*params.BackupsScheduleResult, error)
+	Close() error
+}
+
+func (c *showControllerCommand) getBackupsAPI(controllerName, modelName string) (BackupScheduleAPI, error) {
+	if c.backupsAPI != nil {
+		return c.backupsAPI(controllerName, modelName), nil
+	}
+	root, err := c.NewAPIRoot(c.store, controllerName, modelName)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	client, err := backups.NewClient(root)
+	if err != nil {
+		_ = root.Close()
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
 func (c *showControllerCommand) getAPI(controllerName string) (ControllerAccessAPI, error) {
 	if c.api != nil {
 		return c.api(controllerName), nil
@@ -208,6 +238,15 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 			modelTags[i] = names.NewModelTag(m.UUID)
 			if m.Name == bootstrap.ControllerModelName {
 				controllerModelUUID = m.UUID
+				// Backups are only supported on IAAS controllers.
+				if m.Type != model.CAAS {
+					modelName := jujuclient.JoinOwnerModelName(names.NewUserTag(m.Owner), m.Name)
+					schedule, err := c.backupSchedule(controllerName, modelName)
+					if err != nil {
+						details.Errors = append(details.Errors, err.Error())
+					}
+					details.Backups = schedule
+				}
 			}
 		}
 		modelStatusResults, err := client.ModelStatus(modelTags...)
@@ -244,6 +283,44 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, controllers)
 }
 
+// backupSchedule returns the details of the controller's scheduled
+// backups, or nil if the controller doesn't schedule backups.
+func (c *showControllerCommand) backupSchedule(controllerName, modelName string) (*BackupScheduleDetails, error) {
+	client, err := c.getBackupsAPI(controllerName, modelName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.Schedule()
+	if errors.IsNotSupported(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting backup schedule")
+	}
+	if result.Schedule == "" && result.LastResult == nil {
+		return nil, nil
+	}
+	details := &BackupScheduleDetails{
+		Schedule:   result.Schedule,
+		KeepDaily:  result.KeepDaily,
+		KeepWeekly: result.KeepWeekly,
+	}
+	if result.Next != nil {
+		details.NextBackup = result.Next.UTC().Format(time.RFC3339)
+	}
+	if last := result.LastResult; last != nil {
+		details.LastBackup = &BackupResultDetails{
+			Status:  string(last.Status),
+			Message: last.Info,
+		}
+		if last.Since != nil {
+			details.LastBackup.Since = last.Since.UTC().Format(time.RFC3339)
+		}
+	}
+	return details, nil
+}
+
 func (c *showControllerCommand) userAccess(client ControllerAccessAPI, ctx *cmd.Context, user string) string {
 	var access string
 	userAccess, err := client.GetControllerAccess(user)
@@ -296,6 +373,9 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// Backups holds details of the backups scheduled by this controller.
+	Backups *BackupScheduleDetails `yaml:"backups,omitempty" json:"backups,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -345,6 +425,37 @@ type ControllerDetails struct {
 	CACert string `yaml:"ca-cert" json:"ca-cert"`
 }
 
+// BackupScheduleDetails holds details of a controller's scheduled
+// backups to show.
+type BackupScheduleDetails struct {
+	// Schedule is the cron schedule on which backups are created.
+	Schedule string `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+
+	// NextBackup is when the next scheduled backup will be created.
+	NextBackup string `yaml:"next-backup,omitempty" json:"next-backup,omitempty"`
+
+	// KeepDaily is the number of days for which a scheduled backup is kept.
+	KeepDaily int `yaml:"keep-daily" json:"keep-daily"`
+
+	// KeepWeekly is the number of weeks for which a scheduled backup is kept.
+	KeepWeekly int `yaml:"keep-weekly" json:"keep-weekly"`
+
+	// LastBackup holds the outcome of the most recent scheduled backup.
+	LastBackup *BackupResultDetails `yaml:"last-backup,omitempty" json:"last-backup,omitempty"`
+}
+
+// BackupResultDetails holds the outcome of a scheduled backup to show.
+type BackupResultDetails struct {
+	// Status is "available" if the backup was created, or "error".
+	Status string `yaml:"status" json:"status"`
+
+	// Message describes the outcome.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+
+	// Since is when the backup was attempted.
+	Since string `yaml:"since,omitempty" json:"since,omitempty"`
+}
+
 // ModelDetails holds details of a model to show.
 type MachineDetails struct {
 	// ID holds the id of the machine.
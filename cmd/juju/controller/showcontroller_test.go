@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -15,9 +16,11 @@ import (
 
 	"github.com/juju/juju/api/base"
 	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 )
@@ -26,6 +29,7 @@ type ShowControllerSuite struct {
 	baseControllerSuite
 	fakeController *fakeController
 	api            func(string) controller.ControllerAccessAPI
+	backupsAPI     func(string, string) controller.BackupScheduleAPI
 	setAccess      func(permission.Access)
 }
 
@@ -58,6 +62,10 @@ func (s *ShowControllerSuite) SetUpTest(c *gc.C) {
 		s.fakeController.controllerName = controllerName
 		return s.fakeController
 	}
+	s.backupsAPI = func(controllerName, modelName string) controller.BackupScheduleAPI {
+		s.fakeController.backupsModel = modelName
+		return s.fakeController
+	}
 	s.setAccess = func(access permission.Access) {
 		s.fakeController.access = access
 	}
@@ -601,7 +609,7 @@ mallards:
 	s.assertShowController(c, "mallards", "--show-password")
 }
 func (s *ShowControllerSuite) runShowController(c *gc.C, args ...string) (*cmd.Context, error) {
-	return cmdtesting.RunCommand(c, controller.NewShowControllerCommandForTest(s.store, s.api), args...)
+	return cmdtesting.RunCommand(c, controller.NewShowControllerCommandForTest(s.store, s.api, s.backupsAPI), args...)
 }
 
 func (s *ShowControllerSuite) assertShowControllerFailed(c *gc.C, args ...string) {
@@ -659,6 +667,48 @@ aws-test:
 	s.assertShowController(c, "aws-test")
 }
 
+func (s *ShowControllerSuite) TestShowControllerBackupSchedule(c *gc.C) {
+	_ = s.createTestClientStore(c)
+	next := time.Date(2021, 3, 2, 2, 30, 0, 0, time.UTC)
+	since := time.Date(2021, 3, 1, 2, 30, 0, 0, time.UTC)
+	s.fakeController.backupSchedule = params.BackupsScheduleResult{
+		Schedule:   "30 2 * * *",
+		Next:       &next,
+		KeepDaily:  7,
+		KeepWeekly: 4,
+		LastResult: &params.EntityStatus{
+			Status: status.Error,
+			Info:   "cannot create backup archive",
+			Since:  &since,
+		},
+	}
+
+	ctx, err := s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fakeController.backupsModel, gc.Equals, "admin/controller")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `
+  backups:
+    schedule: 30 2 * * *
+    next-backup: "2021-03-02T02:30:00Z"
+    keep-daily: 7
+    keep-weekly: 4
+    last-backup:
+      status: error
+      message: cannot create backup archive
+      since: "2021-03-01T02:30:00Z"
+`[1:])
+}
+
+func (s *ShowControllerSuite) TestShowControllerBackupScheduleNotSupported(c *gc.C) {
+	_ = s.createTestClientStore(c)
+	s.fakeController.bestAPIVersion = 3
+	s.fakeController.backupSchedule = params.BackupsScheduleResult{Schedule: "@daily"}
+
+	ctx, err := s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "backups:")
+}
+
 type fakeController struct {
 	controllerName    string
 	machines          map[string][]base.Machine
@@ -668,6 +718,8 @@ type fakeController struct {
 	bestAPIVersion    int
 	identityURL       string
 	controllerVersion apicontroller.ControllerVersion
+	backupSchedule    params.BackupsScheduleResult
+	backupsModel      string
 }
 
 func (c *fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -728,6 +780,13 @@ func (c *fakeController) ControllerVersion() (apicontroller.ControllerVersion, e
 	return c.controllerVersion, nil
 }
 
+func (c *fakeController) Schedule() (*params.BackupsScheduleResult, error) {
+	if c.bestAPIVersion < 4 {
+		return nil, errors.NotSupportedf("backup schedule")
+	}
+	return &c.backupSchedule, nil
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
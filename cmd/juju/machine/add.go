@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
@@ -128,6 +129,9 @@ Examples:
 	# Allocate a machine to the model. Note: specific to MAAS.
 	juju add-machine host.internal
 
+	# Print the ids of the machines added, for use in scripts
+	juju add-machine -n 2 --format json
+
 
 Further reading:
 	https://juju.is/docs/reference/commands/add-machine
@@ -162,6 +166,8 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+
+	out cmd.Output
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -179,6 +185,7 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Machine constraints that overwrite those available from 'juju get-model-constraints' and provider's defaults")
 	f.Var(disksFlag{&c.Disks}, "disks", "Storage constraints for disks to attach to the machine(s)")
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -349,13 +356,16 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	var out addMachineResult
 	errs := []error{}
 	for _, machineInfo := range results {
 		if machineInfo.Error != nil {
 			errs = append(errs, machineInfo.Error)
+			out.Errors = append(out.Errors, machineInfo.Error.Error())
 			continue
 		}
 		machineId := machineInfo.Machine
+		out.Machines = append(out.Machines, machineId)
 
 		if names.IsContainerMachine(machineId) {
 			ctx.Infof("created container %v", machineId)
@@ -363,6 +373,9 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 			ctx.Infof("created machine %v", machineId)
 		}
 	}
+	if err := c.write(ctx, out); err != nil {
+		return errors.Trace(err)
+	}
 	if len(errs) == 1 {
 		fmt.Fprint(ctx.Stderr, "failed to create 1 machine\n")
 		return errs[0]
@@ -423,7 +436,21 @@ func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Con
 		return errors.Trace(err)
 	}
 	ctx.Infof("created machine %v", machineId)
-	return nil
+	return c.write(ctx, addMachineResult{Machines: []string{machineId}})
+}
+
+// addMachineResult is written by add-machine when a structured format
+// is requested.
+type addMachineResult struct {
+	Machines []string `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Errors   []string `yaml:"errors,omitempty" json:"errors,omitempty"`
+}
+
+func (c *addCommand) write(ctx *cmd.Context, result addMachineResult) error {
+	if c.out.Name() == output.HumanFormat {
+		return nil
+	}
+	return c.out.Write(ctx, result)
 }
 
 func (c *addCommand) provisionWinRM(args manual.ProvisionMachineArgs) (string, error) {
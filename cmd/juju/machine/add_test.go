@@ -187,6 +187,34 @@ failed to create 2 machines
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, expectedOutput)
 }
 
+func (s *AddMachineSuite) TestAddMachineFormatJSON(c *gc.C) {
+	context, err := s.run(c, "-n", "2", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `{"machines":["0","1"]}`+"\n")
+}
+
+func (s *AddMachineSuite) TestAddThreeMachinesWithTwoFailuresFormatYAML(c *gc.C) {
+	s.fakeMachineManager.successOrder = []bool{true, false, false}
+	context, err := s.run(c, "-n", "3", "--format", "yaml")
+	c.Assert(err, gc.ErrorMatches, "something went wrong, something went wrong")
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+machines:
+- "0"
+errors:
+- something went wrong
+- something went wrong
+`[1:])
+}
+
+func (s *AddMachineSuite) TestSSHPlacementFormatYAML(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		return "42", nil
+	})
+	context, err := s.run(c, "ssh:10.1.2.3", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "machines:\n- \"42\"\n")
+}
+
 func (s *AddMachineSuite) TestBlockedError(c *gc.C) {
 	s.fakeMachineManager.addError = apiservererrors.OperationBlockedError("TestBlockedError")
 	_, err := s.run(c)
@@ -17,6 +17,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // NewRemoveCommand returns a command used to remove a specified machine.
@@ -34,6 +35,7 @@ type removeCommand struct {
 	KeepInstance bool
 	NoWait       bool
 	fs           *gnuflag.FlagSet
+	out          cmd.Output
 }
 
 const destroyMachineDoc = `
@@ -54,12 +56,16 @@ proceed to a next step until the current step has finished.
 However, when using --force, users can also specify --no-wait to progress through steps 
 without delay waiting for each step to complete.
 
+With --format yaml or json, the units and storage removed or detached for
+each machine, and any error removing it, are written to stdout.
+
 Examples:
 
     juju remove-machine 5
     juju remove-machine 6 --force
     juju remove-machine 6 --force --no-wait
     juju remove-machine 7 --keep-instance
+    juju remove-machine 8 --format json
 
 See also:
     add-machine
@@ -81,6 +87,7 @@ func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Force, "force", false, "Completely remove a machine and all its dependencies")
 	f.BoolVar(&c.KeepInstance, "keep-instance", false, "Do not stop the running cloud instance")
 	f.BoolVar(&c.NoWait, "no-wait", false, "Rush through machine removal without waiting for each individual step to complete")
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
 	c.fs = f
 }
 
@@ -193,13 +200,16 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 	}
 
 	anyFailed := false
+	removed := make(map[string]removedMachine)
 	for i, id := range c.MachineIds {
 		result := results[i]
 		if result.Error != nil {
 			anyFailed = true
 			ctx.Infof("removing machine %s failed: %s", id, result.Error)
+			removed[id] = removedMachine{Error: result.Error.Error()}
 			continue
 		}
+		var machine removedMachine
 		if c.KeepInstance {
 			ctx.Infof("removing machine %s (but retaining cloud instance)", id)
 		} else {
@@ -212,6 +222,7 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 				continue
 			}
 			ctx.Infof("- will remove %s", names.ReadableString(unitTag))
+			machine.DestroyedUnits = append(machine.DestroyedUnits, unitTag.Id())
 		}
 		for _, entity := range result.Info.DestroyedStorage {
 			storageTag, err := names.ParseStorageTag(entity.Tag)
@@ -220,6 +231,7 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 				continue
 			}
 			ctx.Infof("- will remove %s", names.ReadableString(storageTag))
+			machine.DestroyedStorage = append(machine.DestroyedStorage, storageTag.Id())
 		}
 		for _, entity := range result.Info.DetachedStorage {
 			storageTag, err := names.ParseStorageTag(entity.Tag)
@@ -228,6 +240,13 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 				continue
 			}
 			ctx.Infof("- will detach %s", names.ReadableString(storageTag))
+			machine.DetachedStorage = append(machine.DetachedStorage, storageTag.Id())
+		}
+		removed[id] = machine
+	}
+	if c.out.Name() != output.HumanFormat {
+		if err := c.out.Write(ctx, removed); err != nil {
+			return errors.Trace(err)
 		}
 	}
 
@@ -236,3 +255,12 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 	}
 	return nil
 }
+
+// removedMachine is written for each machine by remove-machine when a
+// structured format is requested.
+type removedMachine struct {
+	DestroyedUnits   []string `yaml:"destroyed-units,omitempty" json:"destroyed-units,omitempty"`
+	DestroyedStorage []string `yaml:"destroyed-storage,omitempty" json:"destroyed-storage,omitempty"`
+	DetachedStorage  []string `yaml:"detached-storage,omitempty" json:"detached-storage,omitempty"`
+	Error            string   `yaml:"error,omitempty" json:"error,omitempty"`
+}
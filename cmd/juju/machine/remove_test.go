@@ -125,6 +125,33 @@ removing machine 2/lxd/1
 `[1:])
 }
 
+func (s *RemoveMachineSuite) TestRemoveFormat(c *gc.C) {
+	s.fake.results = []params.DestroyMachineResult{{
+		Error: &params.Error{
+			Message: "oy vey",
+		},
+	}, {
+		Info: &params.DestroyMachineInfo{
+			DestroyedUnits:   []params.Entity{{"unit-foo-0"}},
+			DestroyedStorage: []params.Entity{{"storage-bar-1"}},
+			DetachedStorage:  []params.Entity{{"storage-baz-2"}},
+		},
+	}}
+	ctx, err := s.run(c, "1", "2/lxd/1", "--format", "yaml")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"1":
+  error: oy vey
+2/lxd/1:
+  destroyed-units:
+  - foo/0
+  destroyed-storage:
+  - bar/1
+  detached-storage:
+  - baz/2
+`[1:])
+}
+
 func (s *RemoveMachineSuite) TestRemoveOutputKeep(c *gc.C) {
 	ctx, err := s.run(c, "--keep-instance", "1", "2")
 	c.Assert(err, jc.ErrorIsNil)
@@ -69,6 +69,7 @@ type destroyCommand struct {
 	Force  bool
 	NoWait bool
 	fs     *gnuflag.FlagSet
+	out    cmd.Output
 }
 
 var destroyDoc = `
@@ -94,6 +95,10 @@ proceed to a next step until the current step has finished.
 However, when using --force, users can also specify --no-wait to progress through steps 
 without delay waiting for each step to complete.
 
+With --format yaml or json, the model which was destroyed and what was done
+with its storage are written to stdout once the model has gone. The
+confirmation prompt is then written to stderr.
+
 Examples:
 
     juju destroy-model test
@@ -102,6 +107,7 @@ Examples:
     juju destroy-model -y mymodel --release-storage
     juju destroy-model -y mymodel --force
     juju destroy-model -y mymodel --force --no-wait
+    juju destroy-model -y mymodel --format json
 
 See also:
     destroy-controller
@@ -155,6 +161,7 @@ func (c *destroyCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.releaseStorage, "release-storage", false, "Release all storage instances from the model, and management of the controller, without destroying them")
 	f.BoolVar(&c.Force, "force", false, "Force destroy model ignoring any errors")
 	f.BoolVar(&c.NoWait, "no-wait", false, "Rush through model destruction without waiting for each individual step to complete")
+	c.out.AddFlags(f, output.HumanFormat, output.ChangeFormatters)
 	c.fs = f
 }
 
@@ -250,7 +257,11 @@ func (c *destroyCommand) Run(ctx *cmd.Context) error {
 		if modelType == model.CAAS {
 			msg = destroyCAASModelMsg
 		}
-		fmt.Fprintf(ctx.Stdout, msg, modelName)
+		promptWriter := ctx.Stdout
+		if c.out.Name() != output.HumanFormat {
+			promptWriter = ctx.Stderr
+		}
+		fmt.Fprintf(promptWriter, msg, modelName)
 
 		if err := jujucmd.UserConfirmYes(ctx); err != nil {
 			return errors.Annotate(err, "model destruction")
@@ -358,7 +369,27 @@ upgrade the controller to version 2.3 or greater.
 	}
 
 	c.RemoveModelFromClientStore(store, controllerName, modelName)
-	return nil
+	if c.out.Name() == output.HumanFormat {
+		return nil
+	}
+	out := destroyedModel{
+		Model: modelName,
+		UUID:  modelDetails.ModelUUID,
+	}
+	if c.destroyStorage {
+		out.Storage = "destroyed"
+	} else if c.releaseStorage {
+		out.Storage = "released"
+	}
+	return c.out.Write(ctx, out)
+}
+
+// destroyedModel is written by destroy-model when a structured format
+// is requested.
+type destroyedModel struct {
+	Model   string `yaml:"model" json:"model"`
+	UUID    string `yaml:"uuid" json:"uuid"`
+	Storage string `yaml:"storage,omitempty" json:"storage,omitempty"`
 }
 
 func (c *destroyCommand) removeModelBudget(uuid string) error {
@@ -308,6 +308,17 @@ func (s *DestroySuite) TestDestroyReleaseStorage(c *gc.C) {
 	})
 }
 
+func (s *DestroySuite) TestDestroyFormat(c *gc.C) {
+	ctx, err := s.runDestroyCommand(c, "test2", "-y", "--release-storage", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	checkModelRemovedFromStore(c, "test1:admin/test2", s.store)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+model: test2
+uuid: test2-uuid
+storage: released
+`[1:])
+}
+
 func (s *DestroySuite) TestDestroyDestroyReleaseStorageFlagsMutuallyExclusive(c *gc.C) {
 	_, err := s.runDestroyCommand(c, "test2", "-y", "--destroy-storage", "--release-storage")
 	c.Assert(err, gc.ErrorMatches, "--destroy-storage and --release-storage cannot both be specified")
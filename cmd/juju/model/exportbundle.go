@@ -35,6 +35,7 @@ type exportBundleCommand struct {
 	out        cmd.Output
 	newAPIFunc func() (ExportBundleAPI, ConfigAPI, error)
 	Filename   string
+	Complete   bool
 }
 
 const exportBundleHelpDoc = `
@@ -43,10 +44,19 @@ Exports the current model configuration as a reusable bundle.
 If --filename is not used, the configuration is printed to stdout.
  --filename specifies an output file.
 
+By default, storage directives, device constraints and resource revisions
+are not exported. With --complete, everything needed to reproduce the model
+is exported, so that deploying the bundle into an empty model results in an
+equivalent model, which can be checked by running diff-bundle against it.
+Anything which can't be represented in a bundle, such as model config,
+uploaded resources, local charms or the consumers of offers made from the
+model, is reported as a warning.
+
 Examples:
 
     juju export-bundle
     juju export-bundle --filename mymodel.yaml
+    juju export-bundle --complete --filename mymodel.yaml
 
 `
 
@@ -63,6 +73,7 @@ func (c *exportBundleCommand) Info() *cmd.Info {
 func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "filename", "", "Bundle file")
+	f.BoolVar(&c.Complete, "complete", false, "Export everything needed to reproduce the model, warning about anything which can't be")
 }
 
 // Init implements Command.
@@ -75,6 +86,7 @@ type ExportBundleAPI interface {
 	BestAPIVersion() int
 	Close() error
 	ExportBundle() (string, error)
+	ExportCompleteBundle() (string, []string, error)
 }
 
 // ConfigAPI specifies the used function calls of the ApplicationFacade.
@@ -103,8 +115,16 @@ func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
 		_ = cfgClient.Close()
 	}()
 
-	result, err := bundleClient.ExportBundle()
-	if err != nil {
+	var result string
+	if c.Complete {
+		var warnings []string
+		if result, warnings, err = bundleClient.ExportCompleteBundle(); err != nil {
+			return err
+		}
+		for _, warning := range warnings {
+			ctx.Warningf("%s", warning)
+		}
+	} else if result, err = bundleClient.ExportBundle(); err != nil {
 		return err
 	}
 
@@ -11,6 +11,7 @@ import (
 	"sort"
 
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -296,9 +297,39 @@ func (s *ExportBundleCommandSuite) TestPatchOfExportedBundleToExposeTrustFlag(c
 		"series: bionic\n")
 }
 
+func (s *ExportBundleCommandSuite) TestExportCompleteBundle(c *gc.C) {
+	s.fakeBundle.bestAPIVersion = 5
+	s.fakeBundle.result = "applications:\n" +
+		"  mysql:\n" +
+		"    charm: cs:mysql\n" +
+		"    num_units: 1\n" +
+		"    storage:\n" +
+		"      database: ebs,1,10240M\n"
+	s.fakeBundle.warnings = []string{
+		`resource "data" of application "mysql" was uploaded and must be supplied to deploy the bundle`,
+	}
+
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fakeBundle, s.fakeConfig, s.store), "--complete")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fakeBundle.CheckCalls(c, []jujutesting.StubCall{
+		{"ExportCompleteBundle", nil},
+	})
+
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, s.fakeBundle.result)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, `(?s).*resource "data" of application "mysql" was uploaded and must be supplied to deploy the bundle\n`)
+}
+
+func (s *ExportBundleCommandSuite) TestExportCompleteBundleError(c *gc.C) {
+	s.fakeBundle.SetErrors(errors.New("this controller version does not support complete bundle export feature."))
+
+	_, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fakeBundle, s.fakeConfig, s.store), "--complete")
+	c.Assert(err, gc.ErrorMatches, "this controller version does not support complete bundle export feature.")
+}
+
 type fakeExportBundleClient struct {
 	*jujutesting.Stub
 	result         string
+	warnings       []string
 	filename       string
 	bestAPIVersion int
 }
@@ -316,6 +347,15 @@ func (f *fakeExportBundleClient) ExportBundle() (string, error) {
 	return f.result, f.NextErr()
 }
 
+func (f *fakeExportBundleClient) ExportCompleteBundle() (string, []string, error) {
+	f.MethodCall(f, "ExportCompleteBundle")
+	if err := f.NextErr(); err != nil {
+		return "", nil, err
+	}
+
+	return f.result, f.warnings, nil
+}
+
 type fakeConfigClient struct {
 	*jujutesting.Stub
 	result map[string]*params.ApplicationGetResults
@@ -0,0 +1,175 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	apisecrets "github.com/juju/juju/api/secrets"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var listSecretsHelpSummary = `
+Lists secrets available in the model.`[1:]
+
+var listSecretsHelpDetails = `
+Displays the secrets created by the charms deployed in the model.
+Secret values are only shown when --show-secrets is specified.
+
+Examples:
+    juju secrets
+    juju secrets --format yaml
+    juju secrets --show-secrets --format json
+`
+
+// ListSecretsAPI defines the API methods the list secrets command uses.
+type ListSecretsAPI interface {
+	ListSecrets(showSecrets bool) ([]apisecrets.SecretDetails, error)
+	Close() error
+}
+
+// NewListSecretsCommand returns a command to list secrets metadata.
+func NewListSecretsCommand() cmd.Command {
+	c := &listSecretsCommand{}
+	c.newAPIFunc = func() (ListSecretsAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return apisecrets.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+type listSecretsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	showSecrets bool
+	newAPIFunc  func() (ListSecretsAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *listSecretsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secrets",
+		Purpose: listSecretsHelpSummary,
+		Doc:     listSecretsHelpDetails,
+		Aliases: []string{"list-secrets"},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *listSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.showSecrets, "show-secrets", false, "Show secret values")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSecretsTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *listSecretsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// secretValueDetails holds a secret value for display.
+type secretValueDetails struct {
+	Data  map[string]string `json:"data,omitempty" yaml:"data,omitempty"`
+	Error string            `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// secretDisplayDetails holds a secret's metadata, and maybe its value,
+// for display.
+type secretDisplayDetails struct {
+	URI            string              `json:"uri" yaml:"uri"`
+	Owner          string              `json:"owner" yaml:"owner"`
+	Description    string              `json:"description,omitempty" yaml:"description,omitempty"`
+	Label          string              `json:"label,omitempty" yaml:"label,omitempty"`
+	RotatePolicy   string              `json:"rotate-policy,omitempty" yaml:"rotate-policy,omitempty"`
+	NextRotateTime *time.Time          `json:"next-rotate-time,omitempty" yaml:"next-rotate-time,omitempty"`
+	Revision       int                 `json:"revision" yaml:"revision"`
+	CreateTime     time.Time           `json:"created" yaml:"created"`
+	UpdateTime     time.Time           `json:"updated" yaml:"updated"`
+	Value          *secretValueDetails `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// Run implements cmd.Command.
+func (c *listSecretsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	result, err := api.ListSecrets(c.showSecrets)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	details := make([]secretDisplayDetails, len(result))
+	for i, s := range result {
+		details[i] = secretDisplayDetails{
+			URI:            s.Metadata.URI.String(),
+			Owner:          ownerName(s.Metadata.OwnerTag),
+			Description:    s.Metadata.Description,
+			Label:          s.Metadata.Label,
+			RotatePolicy:   string(s.Metadata.RotatePolicy),
+			NextRotateTime: s.Metadata.NextRotateTime,
+			Revision:       s.Metadata.LatestRevision,
+			CreateTime:     s.Metadata.CreateTime,
+			UpdateTime:     s.Metadata.UpdateTime,
+		}
+		if s.Error != "" {
+			details[i].Value = &secretValueDetails{Error: s.Error}
+		} else if s.Value != nil {
+			details[i].Value = &secretValueDetails{Data: s.Value.Values()}
+		}
+	}
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].URI < details[j].URI
+	})
+	return c.out.Write(ctx, details)
+}
+
+// ownerName returns the id of the owner tag, or the tag itself if it
+// can't be parsed.
+func ownerName(ownerTag string) string {
+	tag, err := names.ParseTag(ownerTag)
+	if err != nil {
+		return ownerTag
+	}
+	return tag.Id()
+}
+
+// formatSecretsTabular writes a tabular summary of secret information.
+func formatSecretsTabular(writer io.Writer, value interface{}) error {
+	secrets, ok := value.([]secretDisplayDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("URI", "Owner", "Label", "Rotation", "Revision", "Last updated")
+	for _, s := range secrets {
+		rotation := s.RotatePolicy
+		if rotation == "" {
+			rotation = "never"
+		}
+		w.Println(s.URI, s.Owner, s.Label, rotation, s.Revision, s.UpdateTime.UTC().Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
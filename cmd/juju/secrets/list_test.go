@@ -0,0 +1,104 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apisecrets "github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/cmd/juju/secrets"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ListSuite struct {
+	coretesting.BaseSuite
+	api *mockListAPI
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	uri, err := coresecrets.ParseURI("secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30")
+	c.Assert(err, jc.ErrorIsNil)
+	when := time.Date(2021, 4, 1, 10, 0, 0, 0, time.UTC)
+	s.api = &mockListAPI{
+		secrets: []apisecrets.SecretDetails{{
+			Metadata: coresecrets.SecretMetadata{
+				URI:            uri,
+				OwnerTag:       "application-mysql",
+				Label:          "password",
+				RotatePolicy:   coresecrets.RotateDaily,
+				LatestRevision: 2,
+				CreateTime:     when,
+				UpdateTime:     when,
+			},
+		}},
+	}
+}
+
+func (s *ListSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListCommandForTest(jujuclienttesting.MinimalStore(), s.api), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *ListSuite) TestListTabular(c *gc.C) {
+	out, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+URI                                          Owner  Label     Rotation  Revision  Last updated
+secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30  mysql  password  daily     2         2021-04-01T10:00:00Z
+`[1:])
+	c.Assert(s.api.showSecrets, jc.IsFalse)
+}
+
+func (s *ListSuite) TestListYAMLWithValues(c *gc.C) {
+	s.api.secrets[0].Value = coresecrets.NewSecretValue(map[string]string{"password": "s3cret"})
+	out, err := s.run(c, "--show-secrets", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+- uri: secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30
+  owner: mysql
+  label: password
+  rotate-policy: daily
+  revision: 2
+  created: 2021-04-01T10:00:00Z
+  updated: 2021-04-01T10:00:00Z
+  value:
+    data:
+      password: s3cret
+`[1:])
+	c.Assert(s.api.showSecrets, jc.IsTrue)
+}
+
+func (s *ListSuite) TestListError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockListAPI struct {
+	secrets     []apisecrets.SecretDetails
+	showSecrets bool
+	err         error
+}
+
+func (m *mockListAPI) ListSecrets(showSecrets bool) ([]apisecrets.SecretDetails, error) {
+	m.showSecrets = showSecrets
+	return m.secrets, m.err
+}
+
+func (m *mockListAPI) Close() error {
+	return nil
+}
@@ -0,0 +1,413 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/core/status"
+)
+
+// statusHistoryAPI is implemented by status APIs which can report the
+// status history of entities, used to reconstruct a past status.
+type statusHistoryAPI interface {
+	StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error)
+}
+
+// historySize is the number of status history entries fetched for each
+// entity when reconstructing a past status.
+const historySize = 100
+
+// statusChange describes a single difference between two statuses.
+type statusChange struct {
+	// Kind is the kind of entity which changed: model, machine,
+	// application or unit.
+	Kind string `yaml:"kind" json:"kind"`
+
+	// Name is the name of the entity.
+	Name string `yaml:"name" json:"name"`
+
+	// Change is one of "added", "removed" or "changed".
+	Change string `yaml:"change" json:"change"`
+
+	// Field is the field of a changed entity which changed.
+	Field string `yaml:"field,omitempty" json:"field,omitempty"`
+	Old   string `yaml:"old,omitempty" json:"old,omitempty"`
+	New   string `yaml:"new,omitempty" json:"new,omitempty"`
+}
+
+// statusDiff holds the differences between a past and the current
+// status, as reported by "status --diff".
+type statusDiff struct {
+	// Since describes the snapshot or time compared against.
+	Since   string         `yaml:"since" json:"since"`
+	Changes []statusChange `yaml:"changes" json:"changes"`
+}
+
+// formatDiffTabular writes a line for each change in a status diff.
+func formatDiffTabular(writer io.Writer, value interface{}) error {
+	diff, ok := value.(statusDiff)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", diff, value)
+	}
+	for _, change := range diff.Changes {
+		if change.Change != "changed" {
+			fmt.Fprintf(writer, "%s %s %s\n", change.Kind, change.Name, change.Change)
+			continue
+		}
+		fmt.Fprintf(writer, "%s %s %s changed from %q to %q\n",
+			change.Kind, change.Name, change.Field, change.Old, change.New)
+	}
+	return nil
+}
+
+// saveSnapshot writes the status to the snapshot file, in the same YAML
+// format as "status --format=yaml".
+func (c *statusCommand) saveSnapshot(formatted formattedStatus) error {
+	data, err := yaml.Marshal(formatted)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(c.save, data, 0644); err != nil {
+		return errors.Annotate(err, "saving status snapshot")
+	}
+	return nil
+}
+
+// readSnapshot reads a status saved with --save or written by
+// "status --format=yaml".
+func readSnapshot(path string) (formattedStatus, error) {
+	var snapshot formattedStatus
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return snapshot, errors.Annotate(err, "reading status snapshot")
+	}
+	if err := yaml.Unmarshal(data, &snapshot); err != nil {
+		return snapshot, errors.Annotatef(err, "parsing status snapshot %q", path)
+	}
+	return snapshot, nil
+}
+
+// parseDiffTime parses the time given to --diff, either as a duration
+// before now or as an RFC3339 timestamp.
+func parseDiffTime(value string, now time.Time) (time.Time, bool) {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), true
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// diffStatus reports the differences between the current status and the
+// snapshot or point in time given to --diff.
+func (c *statusCommand) diffStatus(ctx *cmd.Context, current formattedStatus) error {
+	var (
+		past  formattedStatus
+		since string
+		err   error
+	)
+	if _, statErr := os.Stat(c.diff); statErr == nil {
+		since = c.diff
+		past, err = readSnapshot(c.diff)
+	} else if t, ok := parseDiffTime(c.diff, c.clock.Now()); ok {
+		since = common.FormatTime(&t, c.isoTime)
+		past, err = c.historySnapshot(current, t)
+	} else {
+		return errors.NotValidf("--diff value %q: not a snapshot file, duration or RFC3339 time", c.diff)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	diff := statusDiff{
+		Since:   since,
+		Changes: diffFormattedStatus(past, current),
+	}
+	if c.out.Name() == "tabular" {
+		err = formatDiffTabular(ctx.Stdout, diff)
+	} else {
+		err = c.out.Write(ctx, diff)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(diff.Changes) == 0 {
+		ctx.Infof("No changes since %s.", since)
+	}
+	return nil
+}
+
+// historySnapshot reconstructs the status at the given time from the
+// status history of the machines and units in the current status. Only
+// statuses are kept in status history, so everything else is assumed to
+// be unchanged. Entities whose history starts after the time are taken
+// to have been added since; entities removed since can't be known.
+func (c *statusCommand) historySnapshot(current formattedStatus, t time.Time) (formattedStatus, error) {
+	historyAPI, ok := c.statusAPI.(statusHistoryAPI)
+	if !ok {
+		return formattedStatus{}, errors.NotSupportedf("reading status history")
+	}
+	past := current
+	past.Machines = make(map[string]machineStatus)
+	for id, m := range current.Machines {
+		m, existed, err := pastMachine(historyAPI, id, m, t)
+		if err != nil {
+			return formattedStatus{}, errors.Trace(err)
+		}
+		if existed {
+			past.Machines[id] = m
+		}
+	}
+	past.Applications = make(map[string]applicationStatus)
+	for appName, app := range current.Applications {
+		units := make(map[string]unitStatus)
+		for unitName, u := range app.Units {
+			u, existed, err := pastUnit(historyAPI, unitName, u, t)
+			if err != nil {
+				return formattedStatus{}, errors.Trace(err)
+			}
+			if existed {
+				units[unitName] = u
+			}
+		}
+		app.Units = units
+		past.Applications[appName] = app
+	}
+	return past, nil
+}
+
+func pastMachine(api statusHistoryAPI, id string, m machineStatus, t time.Time) (machineStatus, bool, error) {
+	agent, existed, err := pastStatus(api, status.KindMachine, names.NewMachineTag(id), t)
+	if err != nil || !existed {
+		return m, existed, errors.Trace(err)
+	}
+	if agent != nil {
+		m.JujuStatus.Current = agent.Status
+		m.JujuStatus.Message = agent.Info
+	}
+	containers := make(map[string]machineStatus)
+	for cid, container := range m.Containers {
+		container, existed, err := pastMachine(api, cid, container, t)
+		if err != nil {
+			return m, false, errors.Trace(err)
+		}
+		if existed {
+			containers[cid] = container
+		}
+	}
+	m.Containers = containers
+	return m, true, nil
+}
+
+func pastUnit(api statusHistoryAPI, name string, u unitStatus, t time.Time) (unitStatus, bool, error) {
+	tag := names.NewUnitTag(name)
+	workload, existed, err := pastStatus(api, status.KindWorkload, tag, t)
+	if err != nil || !existed {
+		return u, existed, errors.Trace(err)
+	}
+	if workload != nil {
+		u.WorkloadStatusInfo.Current = workload.Status
+		u.WorkloadStatusInfo.Message = workload.Info
+	}
+	agent, _, err := pastStatus(api, status.KindUnitAgent, tag, t)
+	if err != nil {
+		return u, false, errors.Trace(err)
+	}
+	if agent != nil {
+		u.JujuStatusInfo.Current = agent.Status
+		u.JujuStatusInfo.Message = agent.Info
+	}
+	subordinates := make(map[string]unitStatus)
+	for subName, sub := range u.Subordinates {
+		sub, existed, err := pastUnit(api, subName, sub, t)
+		if err != nil {
+			return u, false, errors.Trace(err)
+		}
+		if existed {
+			subordinates[subName] = sub
+		}
+	}
+	u.Subordinates = subordinates
+	return u, true, nil
+}
+
+// pastStatus returns the status of the entity at the given time, and
+// whether the entity existed then. A nil status is returned when the
+// history doesn't go back far enough to tell.
+func pastStatus(api statusHistoryAPI, kind status.HistoryKind, tag names.Tag, t time.Time) (*status.DetailedStatus, bool, error) {
+	history, err := api.StatusHistory(kind, tag, status.StatusHistoryFilter{Size: historySize})
+	if err != nil {
+		return nil, false, errors.Annotatef(err, "reading status history of %s", names.ReadableString(tag))
+	}
+	var found *status.DetailedStatus
+	for i, entry := range history {
+		if entry.Since != nil && !entry.Since.After(t) {
+			if found == nil || entry.Since.After(*found.Since) {
+				found = &history[i]
+			}
+		}
+	}
+	if found == nil && len(history) < historySize {
+		// The whole history is after t, so the entity was added since.
+		return nil, false, nil
+	}
+	return found, true, nil
+}
+
+// diffFormattedStatus returns the changes from the past to the current
+// status, sorted by kind and name.
+func diffFormattedStatus(past, current formattedStatus) []statusChange {
+	var changes []statusChange
+	changed := func(kind, name, field, old, new string) {
+		if old != new {
+			changes = append(changes, statusChange{
+				Kind: kind, Name: name, Change: "changed",
+				Field: field, Old: old, New: new,
+			})
+		}
+	}
+
+	changed("model", current.Model.Name, "version", past.Model.Version, current.Model.Version)
+	changed("model", current.Model.Name, "status", past.Model.Status.String(), current.Model.Status.String())
+
+	pastMachines, currentMachines := flattenMachines(past.Machines), flattenMachines(current.Machines)
+	changes = append(changes, addedRemoved("machine", machineIds(pastMachines), machineIds(currentMachines))...)
+	for id, m := range currentMachines {
+		old, ok := pastMachines[id]
+		if !ok {
+			continue
+		}
+		changed("machine", id, "juju-status", old.JujuStatus.String(), m.JujuStatus.String())
+		changed("machine", id, "agent-version", old.JujuStatus.Version, m.JujuStatus.Version)
+		changed("machine", id, "machine-status", old.MachineStatus.String(), m.MachineStatus.String())
+		changed("machine", id, "dns-name", old.DNSName, m.DNSName)
+		changed("machine", id, "ip-addresses", strings.Join(old.IPAddresses, ","), strings.Join(m.IPAddresses, ","))
+	}
+
+	pastApps, currentApps := set.NewStrings(), set.NewStrings()
+	for name := range past.Applications {
+		pastApps.Add(name)
+	}
+	for name := range current.Applications {
+		currentApps.Add(name)
+	}
+	changes = append(changes, addedRemoved("application", pastApps, currentApps)...)
+	for name, app := range current.Applications {
+		old, ok := past.Applications[name]
+		if !ok {
+			continue
+		}
+		changed("application", name, "charm", old.Charm, app.Charm)
+		changed("application", name, "charm-rev", fmt.Sprint(old.CharmRev), fmt.Sprint(app.CharmRev))
+		changed("application", name, "version", old.Version, app.Version)
+		changed("application", name, "application-status", old.StatusInfo.String(), app.StatusInfo.String())
+		changed("application", name, "address", old.Address, app.Address)
+	}
+
+	pastUnits, currentUnits := flattenUnits(past.Applications), flattenUnits(current.Applications)
+	changes = append(changes, addedRemoved("unit", unitNames(pastUnits), unitNames(currentUnits))...)
+	for name, u := range currentUnits {
+		old, ok := pastUnits[name]
+		if !ok {
+			continue
+		}
+		changed("unit", name, "workload-status", old.WorkloadStatusInfo.String(), u.WorkloadStatusInfo.String())
+		changed("unit", name, "juju-status", old.JujuStatusInfo.String(), u.JujuStatusInfo.String())
+		changed("unit", name, "agent-version", old.JujuStatusInfo.Version, u.JujuStatusInfo.Version)
+		changed("unit", name, "machine", old.Machine, u.Machine)
+		changed("unit", name, "public-address", old.PublicAddress, u.PublicAddress)
+		changed("unit", name, "address", old.Address, u.Address)
+	}
+
+	kindOrder := map[string]int{"model": 0, "machine": 1, "application": 2, "unit": 3}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return kindOrder[changes[i].Kind] < kindOrder[changes[j].Kind]
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// addedRemoved returns the changes for entities only in one of the past
+// and current sets of names.
+func addedRemoved(kind string, past, current set.Strings) []statusChange {
+	var changes []statusChange
+	for _, name := range current.Difference(past).SortedValues() {
+		changes = append(changes, statusChange{Kind: kind, Name: name, Change: "added"})
+	}
+	for _, name := range past.Difference(current).SortedValues() {
+		changes = append(changes, statusChange{Kind: kind, Name: name, Change: "removed"})
+	}
+	return changes
+}
+
+func machineIds(machines map[string]machineStatus) set.Strings {
+	ids := set.NewStrings()
+	for id := range machines {
+		ids.Add(id)
+	}
+	return ids
+}
+
+func unitNames(units map[string]unitStatus) set.Strings {
+	names := set.NewStrings()
+	for name := range units {
+		names.Add(name)
+	}
+	return names
+}
+
+// flattenMachines returns the machines and their containers keyed on
+// machine ID.
+func flattenMachines(machines map[string]machineStatus) map[string]machineStatus {
+	result := make(map[string]machineStatus)
+	for id, m := range machines {
+		result[id] = m
+		for cid, container := range flattenMachines(m.Containers) {
+			result[cid] = container
+		}
+	}
+	return result
+}
+
+// flattenUnits returns the units of the applications, including
+// subordinates, keyed on unit name.
+func flattenUnits(applications map[string]applicationStatus) map[string]unitStatus {
+	result := make(map[string]unitStatus)
+	var add func(map[string]unitStatus)
+	add = func(units map[string]unitStatus) {
+		for name, u := range units {
+			result[name] = u
+			add(u.Subordinates)
+		}
+	}
+	for _, app := range applications {
+		add(app.Units)
+	}
+	return result
+}
+
+// String returns the status and its message, if any.
+func (s statusInfoContents) String() string {
+	if s.Message == "" {
+		return string(s.Current)
+	}
+	return fmt.Sprintf("%s: %s", s.Current, s.Message)
+}
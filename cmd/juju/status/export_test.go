@@ -18,3 +18,8 @@ func NewTestStatusCommand(statusapi statusAPI, storageapi storage.StorageListAPI
 	return modelcmd.Wrap(
 		&statusCommand{statusAPI: statusapi, storageAPI: storageapi, clock: clock})
 }
+
+func NewTestStatusWatchCommand(statusapi statusAPI, watchapi allWatcherAPI, clock Clock) cmd.Command {
+	return modelcmd.Wrap(
+		&statusCommand{statusAPI: statusapi, watchAPI: watchapi, clock: clock})
+}
@@ -238,34 +238,7 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	if appOS == os.Unknown && sf.status.Model.Type == "caas" {
 		osInfo = application.Series
 	}
-	var (
-		charmAlias  = ""
-		charmOrigin = ""
-		charmName   = ""
-		charmRev    = 0
-	)
-	if curl, err := charm.ParseURL(application.Charm); err != nil {
-		// We should never fail to parse a charm url sent back
-		// but if we do, don't crash.
-		logger.Errorf("failed to parse charm: %v", err)
-	} else {
-		switch curl.Schema {
-		case "ch":
-			charmOrigin = "charmhub"
-			charmAlias = curl.Name
-		case "cs":
-			charmOrigin = "charmstore"
-			charmAlias = application.Charm
-		case "local":
-			charmOrigin = "local"
-			charmAlias = application.Charm
-		default:
-			charmOrigin = "unknown"
-			charmAlias = application.Charm
-		}
-		charmRev = curl.Revision
-		charmName = curl.Name
-	}
+	charmAlias, charmOrigin, charmName, charmRev := formatCharmURL(application.Charm)
 
 	out := applicationStatus{
 		Err:              typedNilCheck(application.Err),
@@ -305,6 +278,33 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	return out
 }
 
+// formatCharmURL returns the charm alias, origin, name and revision
+// shown for an application's charm URL.
+func formatCharmURL(charmURL string) (alias, origin, name string, rev int) {
+	curl, err := charm.ParseURL(charmURL)
+	if err != nil {
+		// We should never fail to parse a charm url sent back
+		// but if we do, don't crash.
+		logger.Errorf("failed to parse charm: %v", err)
+		return "", "", "", 0
+	}
+	switch curl.Schema {
+	case "ch":
+		origin = "charmhub"
+		alias = curl.Name
+	case "cs":
+		origin = "charmstore"
+		alias = charmURL
+	case "local":
+		origin = "local"
+		alias = charmURL
+	default:
+		origin = "unknown"
+		alias = charmURL
+	}
+	return alias, origin, curl.Name, curl.Revision
+}
+
 func (sf *statusFormatter) formatRemoteApplication(name string, application params.RemoteApplicationStatus) remoteApplicationStatus {
 	out := remoteApplicationStatus{
 		Err:        typedNilCheck(application.Err),
@@ -23,6 +23,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/statusquery"
 	"github.com/juju/juju/juju/osenv"
 )
 
@@ -33,6 +34,12 @@ type statusAPI interface {
 	Close() error
 }
 
+// queryStatusAPI is implemented by status APIs which can filter the
+// status with a status query on the controller.
+type queryStatusAPI interface {
+	StatusWithQuery(patterns []string, query string) (*params.FullStatus, error)
+}
+
 // NewStatusCommand returns a new command, which reports on the
 // runtime state of various system entities.
 func NewStatusCommand() cmd.Command {
@@ -43,6 +50,7 @@ func NewStatusCommand() cmd.Command {
 
 // Clock defines the methods needed for the status command.
 type Clock interface {
+	Now() time.Time
 	After(time.Duration) <-chan time.Time
 }
 
@@ -53,8 +61,12 @@ type statusCommand struct {
 	isoTime    bool
 	statusAPI  statusAPI
 	storageAPI storage.StorageListAPI
+	watchAPI   allWatcherAPI
 	clock      Clock
 
+	// formatters holds the output formatters, keyed by name.
+	formatters map[string]cmd.Formatter
+
 	retryCount int
 	retryDelay time.Duration
 
@@ -68,6 +80,20 @@ type statusCommand struct {
 
 	// storage indicates if 'storage' section is displayed
 	storage bool
+
+	// watch indicates if the status is followed until interrupted
+	watch bool
+
+	// query holds the status query used to filter units, if any.
+	query  string
+	filter statusquery.Filter
+
+	// save holds the file to save a snapshot of the status to, if any.
+	save string
+
+	// diff holds the snapshot file or past time to compare the
+	// status against, if any.
+	diff string
 }
 
 var usageSummary = `
@@ -117,6 +143,54 @@ The '--format' option allows you to specify how the status report is formatted.
                     Provide information in a JSON or YAML formats for 
                     programmatic use.
 
+Watching the status
+
+The '--watch' option keeps following changes to the model until interrupted,
+instead of reporting its status once. Changes are streamed from the
+controller as they happen, rather than the full status being fetched again.
+On a terminal, the report is redrawn after each change, and the lines that
+changed are highlighted. Otherwise, a line is written for each change to a
+machine, application, unit, relation, offer or the model itself.
+
+When selectors are present, changes to the entities already in the report
+and to new units of the applications in it are followed; new machines and
+applications are not added. The '--watch' option can't be used with the
+JSON and YAML formats.
+
+Filtering with a query
+
+The '--query' option filters the report to the units for which a query is
+true, along with their applications and machines. Queries are written in the
+same language as those of 'juju wait-for'. Besides the fields of the unit,
+those of its machine and application can be used, prefixed with 'machine.'
+and 'application.' respectively.
+
+  Unit fields:        name, application, principal, subordinate, machine,
+                      workload-status, workload-message, agent-status,
+                      workload-version, charm, leader, public-address
+  Machine fields:     id, status, instance-status, instance-id, series,
+                      dns-name, az, constraints, hardware
+  Application fields: name, status, charm, charm-channel, series, exposed,
+                      life, workload-version, subordinate
+
+The query is run by the controller when it supports it, so that only the
+matching entities are sent to the client.
+
+Comparing with a past status
+
+The '--save' option saves a snapshot of the status to a file, in the same
+format as '--format=yaml', as well as reporting it. The '--diff' option
+reports the changes since a snapshot instead of the status. Added and
+removed machines, applications and units are reported, along with changes
+to their statuses, versions and addresses. Snapshots can be compared with
+any status, so the same selectors and query should be used for both.
+
+Instead of a snapshot, '--diff' accepts a time, either as a duration before
+now, such as '2h', or as an RFC3339 timestamp. The past statuses of the
+machines and units are then read from their status history. Only statuses
+are kept in the history, so other changes are not reported, and neither
+are entities removed since.
+
 Examples:
 
     # Report the status of units hosted on machine 0
@@ -134,6 +208,19 @@ Examples:
     # Provide output as valid JSON
     juju status --format=json
 
+    # Follow changes to the status of the mysql application
+    juju status --watch mysql
+
+    # Report the units which aren't active in the us-east-1a zone
+    juju status --query 'workload-status != "active" && machine.az == "us-east-1a"'
+
+    # Save a snapshot of the status, then report what changed since
+    juju status --save before.yaml
+    juju status --diff before.yaml
+
+    # Report the status changes in the last 30 minutes
+    juju status --diff 30m
+
 Further reading:
 
     https://juju.is/docs/command/status
@@ -167,6 +254,10 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	f.IntVar(&c.retryCount, "retry-count", 3, "Number of times to retry API failures")
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
+	f.BoolVar(&c.watch, "watch", false, "Keep following changes to the status until interrupted")
+	f.StringVar(&c.query, "query", "", "Only report the units for which the query is true")
+	f.StringVar(&c.save, "save", "", "Save a snapshot of the status to a file")
+	f.StringVar(&c.diff, "diff", "", "Report the changes since a saved snapshot or a past time")
 
 	c.checkProvidedIgnoredFlagF = func() set.Strings {
 		ignoredFlagForNonTabularFormat := set.NewStrings(
@@ -185,7 +276,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	defaultFormat := "tabular"
 
-	c.out.AddFlags(f, defaultFormat, map[string]cmd.Formatter{
+	c.formatters = map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"short":   FormatOneline,
@@ -193,11 +284,37 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 		"line":    FormatOneline,
 		"tabular": c.FormatTabular,
 		"summary": FormatSummary,
-	})
+	}
+	c.out.AddFlags(f, defaultFormat, c.formatters)
 }
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.watch {
+		switch c.out.Name() {
+		case "json", "yaml":
+			return errors.Errorf("--watch is not supported with the %s format", c.out.Name())
+		}
+		if c.query != "" {
+			return errors.New("--watch and --query can't be used together")
+		}
+		if c.save != "" || c.diff != "" {
+			return errors.New("--watch can't be used with --save or --diff")
+		}
+	}
+	if c.diff != "" {
+		switch c.out.Name() {
+		case "tabular", "json", "yaml":
+		default:
+			return errors.Errorf("--diff is not supported with the %s format", c.out.Name())
+		}
+	}
+	if c.query != "" {
+		var err error
+		if c.filter, err = statusquery.Parse(c.query); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -254,7 +371,26 @@ func (c *statusCommand) getStatus() (*params.FullStatus, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return apiclient.Status(c.patterns)
+	if c.query == "" {
+		return apiclient.Status(c.patterns)
+	}
+
+	var status *params.FullStatus
+	if queryAPI, ok := apiclient.(queryStatusAPI); ok {
+		status, err = queryAPI.StatusWithQuery(c.patterns, c.query)
+	} else {
+		status, err = apiclient.Status(c.patterns)
+	}
+	if status == nil {
+		return nil, err
+	}
+	// Older controllers ignore the query, so it's always applied here
+	// too; filtering an already filtered status changes nothing.
+	filtered, filterErr := c.filter.Apply(status)
+	if filterErr != nil {
+		return nil, errors.Trace(filterErr)
+	}
+	return filtered, err
 }
 
 func (c *statusCommand) getStorageInfo(ctx *cmd.Context) (*storage.CombinedStorage, error) {
@@ -355,6 +491,18 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	if c.watch {
+		return c.watchStatus(ctx, formatted)
+	}
+	if c.save != "" {
+		if err := c.saveSnapshot(formatted); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if c.diff != "" {
+		return c.diffStatus(ctx, formatted)
+	}
+
 	if err = c.out.Write(ctx, formatted); err != nil {
 		return err
 	}
@@ -362,6 +510,10 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	if !status.IsEmpty() {
 		return nil
 	}
+	if c.query != "" {
+		ctx.Infof("Nothing matched the query.")
+		return nil
+	}
 	if len(c.patterns) == 0 {
 		modelName, err := c.ModelIdentifier()
 		if err != nil {
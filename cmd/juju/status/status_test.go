@@ -5,10 +5,13 @@ package status_test
 
 import (
 	"errors"
+	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -130,6 +133,150 @@ func (s *MinimalStatusSuite) TestRetryCountOfZero(c *gc.C) {
 	c.Assert(s.clock.waits, gc.HasLen, 0)
 }
 
+func (s *MinimalStatusSuite) TestQuery(c *gc.C) {
+	s.statusapi.result.Machines = map[string]params.MachineStatus{
+		"0": {Id: "0"},
+		"1": {Id: "1"},
+	}
+	s.statusapi.result.Applications = map[string]params.ApplicationStatus{
+		"mysql": {
+			Charm: "cs:mysql-1",
+			Units: map[string]params.UnitStatus{
+				"mysql/0": {
+					Machine:        "0",
+					WorkloadStatus: params.DetailedStatus{Status: "active"},
+				},
+				"mysql/1": {
+					Machine:        "1",
+					WorkloadStatus: params.DetailedStatus{Status: "blocked"},
+				},
+			},
+		},
+	}
+
+	ctx, err := s.runStatus(c, "--format", "oneline", "--query", `workload-status != "active"`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "mysql/1")
+	c.Check(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "mysql/0")
+
+	ctx, err = s.runStatus(c, "--query", `workload-status == "error"`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Nothing matched the query.\n")
+}
+
+func (s *MinimalStatusSuite) TestQueryInvalid(c *gc.C) {
+	_, err := s.runStatus(c, "--query", `workload-status == "active`)
+	c.Assert(err, gc.ErrorMatches, `parsing query "workload-status == \\"active": .*`)
+
+	_, err = s.runStatus(c, "--watch", "--query", `leader`)
+	c.Assert(err, gc.ErrorMatches, "--watch and --query can't be used together")
+}
+
+func (s *MinimalStatusSuite) setUnits(units map[string]params.UnitStatus) {
+	s.statusapi.result.Applications = map[string]params.ApplicationStatus{
+		"mysql": {
+			Charm:  "cs:mysql-1",
+			Status: params.DetailedStatus{Status: "active"},
+			Units:  units,
+		},
+	}
+}
+
+func (s *MinimalStatusSuite) TestSaveAndDiff(c *gc.C) {
+	s.setUnits(map[string]params.UnitStatus{
+		"mysql/0": {WorkloadStatus: params.DetailedStatus{Status: "active"}},
+	})
+	snapshot := filepath.Join(c.MkDir(), "snapshot.yaml")
+	_, err := s.runStatus(c, "--save", snapshot)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := s.runStatus(c, "--diff", snapshot)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, fmt.Sprintf("No changes since %s.\n", snapshot))
+
+	s.setUnits(map[string]params.UnitStatus{
+		"mysql/0": {WorkloadStatus: params.DetailedStatus{Status: "blocked", Info: "need db"}},
+		"mysql/1": {WorkloadStatus: params.DetailedStatus{Status: "active"}},
+	})
+	ctx, err = s.runStatus(c, "--diff", snapshot)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+unit mysql/0 workload-status changed from "active" to "blocked: need db"
+unit mysql/1 added
+`[1:])
+
+	ctx, err = s.runStatus(c, "--diff", snapshot, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, fmt.Sprintf(`
+since: %s
+changes:
+- kind: unit
+  name: mysql/0
+  change: changed
+  field: workload-status
+  old: active
+  new: 'blocked: need db'
+- kind: unit
+  name: mysql/1
+  change: added
+`[1:], snapshot))
+}
+
+func (s *MinimalStatusSuite) TestDiffHistory(c *gc.C) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.clock.now = now
+	s.setUnits(map[string]params.UnitStatus{
+		"mysql/0": {
+			WorkloadStatus: params.DetailedStatus{Status: "active"},
+			AgentStatus:    params.DetailedStatus{Status: "idle"},
+		},
+		"mysql/1": {
+			WorkloadStatus: params.DetailedStatus{Status: "active"},
+			AgentStatus:    params.DetailedStatus{Status: "idle"},
+		},
+	})
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+	api := &fakeStatusHistoryAPI{
+		fakeStatusAPI: s.statusapi,
+		history: map[string]corestatus.History{
+			"workload unit-mysql-0": {
+				{Status: "maintenance", Since: ago(3 * time.Hour)},
+				{Status: "active", Since: ago(time.Hour)},
+			},
+			"juju-unit unit-mysql-0": {
+				{Status: "idle", Since: ago(3 * time.Hour)},
+			},
+			"workload unit-mysql-1": {
+				{Status: "active", Since: ago(10 * time.Minute)},
+			},
+		},
+	}
+	ctx, err := cmdtesting.RunCommand(c, status.NewTestStatusCommand(api, s.storageapi, s.clock), "--diff", "2h", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+unit mysql/0 workload-status changed from "maintenance" to "active"
+unit mysql/1 added
+`[1:])
+}
+
+func (s *MinimalStatusSuite) TestDiffInvalid(c *gc.C) {
+	_, err := s.runStatus(c, "--diff", "yesterday")
+	c.Assert(err, gc.ErrorMatches, `--diff value "yesterday": not a snapshot file, duration or RFC3339 time not valid`)
+
+	_, err = s.runStatus(c, "--diff", "2h")
+	c.Assert(err, gc.ErrorMatches, "reading status history not supported")
+
+	_, err = s.runStatus(c, "--diff", "2h", "--format", "oneline")
+	c.Assert(err, gc.ErrorMatches, "--diff is not supported with the oneline format")
+
+	_, err = s.runStatus(c, "--watch", "--save", "snapshot.yaml")
+	c.Assert(err, gc.ErrorMatches, "--watch can't be used with --save or --diff")
+}
+
 type fakeStatusAPI struct {
 	result *params.FullStatus
 	errors []error
@@ -148,9 +295,23 @@ func (*fakeStatusAPI) Close() error {
 	return nil
 }
 
+type fakeStatusHistoryAPI struct {
+	*fakeStatusAPI
+	history map[string]corestatus.History
+}
+
+func (f *fakeStatusHistoryAPI) StatusHistory(kind corestatus.HistoryKind, tag names.Tag, filter corestatus.StatusHistoryFilter) (corestatus.History, error) {
+	return f.history[fmt.Sprintf("%s %s", kind, tag)], nil
+}
+
 type timeRecorder struct {
 	waits  []time.Duration
 	result chan time.Time
+	now    time.Time
+}
+
+func (r *timeRecorder) Now() time.Time {
+	return r.now
 }
 
 func (r *timeRecorder) After(d time.Duration) <-chan time.Time {
@@ -0,0 +1,606 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/juju/ansiterm"
+	"github.com/juju/charm/v9"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/mattn/go-isatty"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/network"
+)
+
+// allWatcherAPI is the API used by "status --watch" to follow changes
+// to the model.
+type allWatcherAPI interface {
+	WatchAll() (api.AllWatch, error)
+}
+
+// clientAllWatcher adapts the API client to allWatcherAPI.
+type clientAllWatcher struct {
+	client *api.Client
+}
+
+// WatchAll is part of allWatcherAPI.
+func (c clientAllWatcher) WatchAll() (api.AllWatch, error) {
+	w, err := c.client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+var newAllWatcherForStatus = func(c *statusCommand) (api.AllWatch, error) {
+	if c.watchAPI == nil {
+		client, ok := c.statusAPI.(*api.Client)
+		if !ok {
+			return nil, errors.NotSupportedf("watching status")
+		}
+		c.watchAPI = clientAllWatcher{client}
+	}
+	return c.watchAPI.WatchAll()
+}
+
+// clearScreen moves the cursor to the top left corner of the terminal
+// and clears it, ready for the next frame.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// changedHighlight is used to show the lines of a frame that changed
+// since the previous one.
+var changedHighlight = ansiterm.Styles(ansiterm.Bold)
+
+func isTerminal(f interface{}) bool {
+	f_, ok := f.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f_.Fd())
+}
+
+// watchStatus follows the model's AllWatcher, applying its deltas to the
+// formatted status. On a terminal, the status is re-rendered after
+// each set of deltas, highlighting the lines that changed; otherwise,
+// only a line for each change is written. It returns when the command
+// is interrupted or the model is removed.
+func (c *statusCommand) watchStatus(ctx *cmd.Context, formatted formattedStatus) error {
+	watcher, err := newAllWatcherForStatus(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer watcher.Stop()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	type nextResult struct {
+		deltas []params.Delta
+		err    error
+	}
+	results := make(chan nextResult)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			select {
+			case results <- nextResult{deltas, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	updater := &statusUpdater{
+		status:        &formatted,
+		isoTime:       c.isoTime,
+		filtered:      len(c.patterns) > 0,
+		showRelations: c.relations || c.out.Name() != "tabular",
+	}
+	formatter := c.formatters[c.out.Name()]
+	frames := isTerminal(ctx.Stdout)
+	var lines []string
+	if frames {
+		if lines, err = c.writeFrame(ctx, formatter, formatted, nil); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case result := <-results:
+			if result.err != nil {
+				return errors.Annotate(result.err, "watching model")
+			}
+			changes := updater.apply(result.deltas)
+			if frames {
+				if len(changes) > 0 {
+					if lines, err = c.writeFrame(ctx, formatter, formatted, lines); err != nil {
+						return errors.Trace(err)
+					}
+				}
+			} else {
+				now := c.clock.Now()
+				timestamp := common.FormatTimeAsTimestamp(&now, c.isoTime)
+				for _, change := range changes {
+					fmt.Fprintf(ctx.Stdout, "%s %s\n", timestamp, change)
+				}
+			}
+			if updater.modelRemoved {
+				ctx.Infof("Model %q has been removed.", formatted.Model.Name)
+				return nil
+			}
+		}
+	}
+}
+
+// writeFrame clears the terminal and writes the formatted status,
+// highlighting the lines which aren't in the previous frame. It
+// returns the lines written.
+func (c *statusCommand) writeFrame(
+	ctx *cmd.Context, formatter cmd.Formatter, formatted formattedStatus, previous []string,
+) ([]string, error) {
+	var buf bytes.Buffer
+	if err := formatter(&buf, formatted); err != nil {
+		return nil, errors.Trace(err)
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+
+	seen := make(map[string]int)
+	for _, line := range previous {
+		seen[line]++
+	}
+	w := ansiterm.NewWriter(ctx.Stdout)
+	if c.color {
+		w.SetColorCapable(true)
+	}
+	fmt.Fprint(w, clearScreen)
+	for _, line := range lines {
+		if previous == nil || seen[line] > 0 {
+			seen[line]--
+			fmt.Fprintln(w, line)
+			continue
+		}
+		changedHighlight.Fprint(w, line)
+		fmt.Fprintln(w)
+	}
+	return lines, nil
+}
+
+// statusUpdater applies AllWatcher deltas to a formatted status, so
+// that it doesn't need to be fetched from the controller again.
+type statusUpdater struct {
+	status  *formattedStatus
+	isoTime bool
+
+	// filtered is set when the status only holds the entities
+	// matching a selector. New machines, applications and offers
+	// aren't added to it, as they may not match.
+	filtered bool
+
+	// showRelations is set when the status holds the relations.
+	showRelations bool
+
+	// modelRemoved is set once the model has been removed.
+	modelRemoved bool
+}
+
+// apply updates the status from the deltas, and returns a description
+// of each visible change.
+func (u *statusUpdater) apply(deltas []params.Delta) []string {
+	var changes []string
+	for _, delta := range deltas {
+		var change string
+		switch info := delta.Entity.(type) {
+		case *params.ModelUpdate:
+			change = u.updateModel(info, delta.Removed)
+		case *params.MachineInfo:
+			change = u.updateMachine(info, delta.Removed)
+		case *params.ApplicationInfo:
+			change = u.updateApplication(info, delta.Removed)
+		case *params.UnitInfo:
+			change = u.updateUnit(info, delta.Removed)
+		case *params.RelationInfo:
+			change = u.updateRelation(info, delta.Removed)
+		case *params.RemoteApplicationUpdate:
+			change = u.updateRemoteApplication(info, delta.Removed)
+		case *params.ApplicationOfferInfo:
+			change = u.updateOffer(info, delta.Removed)
+		}
+		if change != "" {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func (u *statusUpdater) updateModel(info *params.ModelUpdate, removed bool) string {
+	if removed {
+		u.modelRemoved = true
+		return describeChange("model", u.status.Model.Name, "removed")
+	}
+	model := u.status.Model
+	model.Status = u.statusInfo(info.Status)
+	model.Status.Life = formatLife(info.Life)
+	model.SLA = info.SLA.Level
+	if statusInfoEqual(model.Status, u.status.Model.Status) && model.SLA == u.status.Model.SLA {
+		return ""
+	}
+	u.status.Model = model
+	return describeChange("model", model.Name, string(model.Status.Current), model.Status.Message)
+}
+
+func (u *statusUpdater) updateMachine(info *params.MachineInfo, removed bool) string {
+	machines := machinesHolding(u.status.Machines, info.Id)
+	if machines == nil {
+		return ""
+	}
+	old, found := machines[info.Id]
+	if removed {
+		if !found {
+			return ""
+		}
+		delete(machines, info.Id)
+		return describeChange("machine", info.Id, "removed")
+	}
+	if !found && u.filtered {
+		return ""
+	}
+
+	m := old
+	if !found {
+		m = machineStatus{
+			Id:                info.Id,
+			NetworkInterfaces: make(map[string]networkInterface),
+			Containers:        make(map[string]machineStatus),
+			LXDProfiles:       make(map[string]lxdProfileContents),
+		}
+	}
+	m.JujuStatus = u.statusInfo(info.AgentStatus)
+	m.JujuStatus.Life = formatLife(info.Life)
+	m.MachineStatus = u.statusInfo(info.InstanceStatus)
+	m.InstanceId = instance.Id(info.InstanceId)
+	m.Series = info.Series
+	if hc := info.HardwareCharacteristics; hc != nil {
+		m.Hardware = hc.String()
+	}
+	if address := machineAddress(info.Addresses); address != "" {
+		m.DNSName = address
+	}
+	if found &&
+		statusInfoEqual(m.JujuStatus, old.JujuStatus) &&
+		statusInfoEqual(m.MachineStatus, old.MachineStatus) &&
+		m.InstanceId == old.InstanceId &&
+		m.DNSName == old.DNSName &&
+		m.Series == old.Series &&
+		m.Hardware == old.Hardware {
+		return ""
+	}
+	machines[info.Id] = m
+	return describeChange("machine", info.Id,
+		string(m.JujuStatus.Current), string(m.MachineStatus.Current), m.MachineStatus.Message)
+}
+
+// machinesHolding returns the map that holds the machine with the
+// given id, which for a container is its parent's containers. It
+// returns nil if the parent isn't known.
+func machinesHolding(machines map[string]machineStatus, id string) map[string]machineStatus {
+	if !names.IsContainerMachine(id) {
+		return machines
+	}
+	parentId := names.NewMachineTag(id).Parent().Id()
+	parentMachines := machinesHolding(machines, parentId)
+	if parentMachines == nil {
+		return nil
+	}
+	parent, ok := parentMachines[parentId]
+	if !ok {
+		return nil
+	}
+	return parent.Containers
+}
+
+// machineAddress returns the address shown for a machine, preferring
+// a public address to a cloud-local one.
+func machineAddress(addresses []params.Address) string {
+	for _, scope := range []network.Scope{network.ScopePublic, network.ScopeCloudLocal} {
+		for _, address := range addresses {
+			if address.Scope == string(scope) {
+				return address.Value
+			}
+		}
+	}
+	return ""
+}
+
+func (u *statusUpdater) updateApplication(info *params.ApplicationInfo, removed bool) string {
+	old, found := u.status.Applications[info.Name]
+	if removed {
+		if !found {
+			return ""
+		}
+		delete(u.status.Applications, info.Name)
+		return describeChange("application", info.Name, "removed")
+	}
+	if !found && u.filtered {
+		return ""
+	}
+
+	app := old
+	if !found {
+		app.Units = make(map[string]unitStatus)
+	}
+	app.Charm, app.CharmOrigin, app.CharmName, app.CharmRev = formatCharmURL(info.CharmURL)
+	app.Exposed = info.Exposed
+	app.Life = formatLife(info.Life)
+	app.StatusInfo = u.statusInfo(info.Status)
+	app.Version = info.WorkloadVersion
+	if found &&
+		statusInfoEqual(app.StatusInfo, old.StatusInfo) &&
+		app.Charm == old.Charm &&
+		app.CharmRev == old.CharmRev &&
+		app.Exposed == old.Exposed &&
+		app.Life == old.Life &&
+		app.Version == old.Version {
+		return ""
+	}
+	u.status.Applications[info.Name] = app
+	return describeChange("application", info.Name, string(app.StatusInfo.Current), app.StatusInfo.Message)
+}
+
+func (u *statusUpdater) updateUnit(info *params.UnitInfo, removed bool) string {
+	units := u.unitsHolding(info)
+	if units == nil {
+		return ""
+	}
+	old, found := units[info.Name]
+	if removed {
+		if !found {
+			return ""
+		}
+		delete(units, info.Name)
+		return describeChange("unit", info.Name, "removed")
+	}
+
+	unit := old
+	if !found {
+		unit.Subordinates = make(map[string]unitStatus)
+	}
+	unit.WorkloadStatusInfo = u.statusInfo(info.WorkloadStatus)
+	unit.JujuStatusInfo = u.statusInfo(info.AgentStatus)
+	unit.JujuStatusInfo.Life = formatLife(info.Life)
+	unit.Machine = info.MachineId
+	unit.PublicAddress = info.PublicAddress
+	unit.Address = info.PrivateAddress
+	unit.OpenedPorts = nil
+	for _, portRange := range info.PortRanges {
+		unit.OpenedPorts = append(unit.OpenedPorts, portRange.NetworkPortRange().String())
+	}
+	if found &&
+		statusInfoEqual(unit.WorkloadStatusInfo, old.WorkloadStatusInfo) &&
+		statusInfoEqual(unit.JujuStatusInfo, old.JujuStatusInfo) &&
+		unit.Machine == old.Machine &&
+		unit.PublicAddress == old.PublicAddress &&
+		unit.Address == old.Address &&
+		strings.Join(unit.OpenedPorts, ",") == strings.Join(old.OpenedPorts, ",") {
+		return ""
+	}
+	units[info.Name] = unit
+	return describeChange("unit", info.Name,
+		string(unit.WorkloadStatusInfo.Current), string(unit.JujuStatusInfo.Current), unit.WorkloadStatusInfo.Message)
+}
+
+// unitsHolding returns the map that holds the unit, which for a
+// subordinate is its principal's subordinates. It returns nil if the
+// application or principal unit isn't known.
+func (u *statusUpdater) unitsHolding(info *params.UnitInfo) map[string]unitStatus {
+	if info.Principal == "" {
+		app, ok := u.status.Applications[info.Application]
+		if !ok {
+			return nil
+		}
+		return app.Units
+	}
+	if _, ok := u.status.Applications[info.Application]; !ok {
+		return nil
+	}
+	principalApp, err := names.UnitApplication(info.Principal)
+	if err != nil {
+		return nil
+	}
+	principal, ok := u.status.Applications[principalApp].Units[info.Principal]
+	if !ok {
+		return nil
+	}
+	return principal.Subordinates
+}
+
+func (u *statusUpdater) updateRelation(info *params.RelationInfo, removed bool) string {
+	rel := formatRelationInfo(info)
+	for _, ep := range info.Endpoints {
+		if _, ok := u.status.Applications[ep.ApplicationName]; ok {
+			continue
+		}
+		if _, ok := u.status.RemoteApplications[ep.ApplicationName]; !ok {
+			return ""
+		}
+	}
+
+	index := -1
+	for i, existing := range u.status.Relations {
+		if existing.Provider == rel.Provider && existing.Requirer == rel.Requirer {
+			index = i
+			break
+		}
+	}
+	name := rel.Provider
+	if rel.Requirer != rel.Provider {
+		name += " " + rel.Requirer
+	}
+	if removed {
+		if u.showRelations && index >= 0 {
+			u.status.Relations = append(u.status.Relations[:index], u.status.Relations[index+1:]...)
+		}
+		return describeChange("relation", name, "removed")
+	}
+	if index >= 0 || !u.showRelations {
+		// The relation's status isn't in the delta, so there's
+		// nothing to update.
+		return ""
+	}
+	u.status.Relations = append(u.status.Relations, rel)
+	return describeChange("relation", name, "added")
+}
+
+// formatRelationInfo returns the relation status for a relation in
+// the AllWatcher, as formatRelation does for one in the full status.
+func formatRelationInfo(info *params.RelationInfo) relationStatus {
+	var provider, requirer params.Endpoint
+	var scope, iface string
+	for _, ep := range info.Endpoints {
+		switch charm.RelationRole(ep.Relation.Role) {
+		case charm.RolePeer:
+			provider = ep
+			requirer = ep
+		case charm.RoleProvider:
+			provider = ep
+		case charm.RoleRequirer:
+			requirer = ep
+		}
+		if ep.Relation.Scope == string(charm.ScopeContainer) {
+			scope = ep.Relation.Scope
+		}
+		iface = ep.Relation.Interface
+	}
+	var relType string
+	switch {
+	case scope != "":
+		relType = "subordinate"
+	case provider.ApplicationName == requirer.ApplicationName:
+		relType = "peer"
+	default:
+		relType = "regular"
+	}
+	return relationStatus{
+		Provider:  fmt.Sprintf("%s:%s", provider.ApplicationName, provider.Relation.Name),
+		Requirer:  fmt.Sprintf("%s:%s", requirer.ApplicationName, requirer.Relation.Name),
+		Interface: iface,
+		Type:      relType,
+	}
+}
+
+func (u *statusUpdater) updateRemoteApplication(info *params.RemoteApplicationUpdate, removed bool) string {
+	old, found := u.status.RemoteApplications[info.Name]
+	if removed {
+		if !found {
+			return ""
+		}
+		delete(u.status.RemoteApplications, info.Name)
+		return describeChange("saas", info.Name, "removed")
+	}
+	if !found && u.filtered {
+		return ""
+	}
+
+	app := old
+	app.OfferURL = info.OfferURL
+	app.Life = formatLife(info.Life)
+	app.StatusInfo = u.statusInfo(info.Status)
+	if found &&
+		statusInfoEqual(app.StatusInfo, old.StatusInfo) &&
+		app.OfferURL == old.OfferURL &&
+		app.Life == old.Life {
+		return ""
+	}
+	u.status.RemoteApplications[info.Name] = app
+	return describeChange("saas", info.Name, string(app.StatusInfo.Current), app.StatusInfo.Message)
+}
+
+func (u *statusUpdater) updateOffer(info *params.ApplicationOfferInfo, removed bool) string {
+	old, found := u.status.Offers[info.OfferName]
+	if removed {
+		if !found {
+			return ""
+		}
+		delete(u.status.Offers, info.OfferName)
+		return describeChange("offer", info.OfferName, "removed")
+	}
+	if !found && u.filtered {
+		return ""
+	}
+
+	offer := old
+	offer.OfferName = info.OfferName
+	offer.ApplicationName = info.ApplicationName
+	offer.ActiveConnectedCount = info.ActiveConnectedCount
+	offer.TotalConnectedCount = info.TotalConnectedCount
+	if found &&
+		offer.ActiveConnectedCount == old.ActiveConnectedCount &&
+		offer.TotalConnectedCount == old.TotalConnectedCount {
+		return ""
+	}
+	u.status.Offers[info.OfferName] = offer
+	return describeChange("offer", info.OfferName,
+		fmt.Sprintf("%d/%d", offer.ActiveConnectedCount, offer.TotalConnectedCount))
+}
+
+func (u *statusUpdater) statusInfo(info params.StatusInfo) statusInfoContents {
+	out := statusInfoContents{
+		Err:     info.Err,
+		Current: info.Current,
+		Message: info.Message,
+		Version: info.Version,
+	}
+	if info.Since != nil {
+		out.Since = common.FormatTime(info.Since, u.isoTime)
+	}
+	return out
+}
+
+// formatLife returns the life shown for an entity, which is omitted
+// while the entity is alive, as in the full status.
+func formatLife(value life.Value) string {
+	if value == life.Alive {
+		return ""
+	}
+	return string(value)
+}
+
+// statusInfoEqual reports whether the statuses are the same, ignoring
+// when they were set.
+func statusInfoEqual(a, b statusInfoContents) bool {
+	return a.Current == b.Current &&
+		a.Message == b.Message &&
+		a.Version == b.Version &&
+		a.Life == b.Life
+}
+
+// describeChange returns a line describing a change to an entity,
+// omitting any empty details.
+func describeChange(kind, name string, details ...string) string {
+	parts := []string{kind, name}
+	for _, detail := range details {
+		if detail != "" {
+			parts = append(parts, detail)
+		}
+	}
+	return strings.Join(parts, " ")
+}
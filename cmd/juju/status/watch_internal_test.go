@@ -0,0 +1,179 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type watchSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&watchSuite{})
+
+func (s *watchSuite) formattedStatus() formattedStatus {
+	return formattedStatus{
+		Model: modelStatus{
+			Name:       "test",
+			Controller: "ctrl",
+			Cloud:      "foo",
+			Version:    "2.9.0",
+		},
+		Machines: map[string]machineStatus{
+			"0": {
+				Id:            "0",
+				JujuStatus:    statusInfoContents{Current: status.Started},
+				MachineStatus: statusInfoContents{Current: status.Running},
+				Containers:    make(map[string]machineStatus),
+			},
+		},
+		Applications: map[string]applicationStatus{
+			"mysql": {
+				CharmName:   "mysql",
+				CharmOrigin: "charmstore",
+				CharmRev:    1,
+				Units: map[string]unitStatus{
+					"mysql/0": {
+						WorkloadStatusInfo: statusInfoContents{Current: status.Waiting},
+						JujuStatusInfo:     statusInfoContents{Current: status.Executing},
+						Machine:            "0",
+						Subordinates:       make(map[string]unitStatus),
+					},
+				},
+			},
+		},
+		RemoteApplications: make(map[string]remoteApplicationStatus),
+		Offers:             make(map[string]offerStatus),
+	}
+}
+
+func (s *watchSuite) TestApplyDeltas(c *gc.C) {
+	fs := s.formattedStatus()
+	updater := &statusUpdater{status: &fs, showRelations: true}
+	changes := updater.apply([]params.Delta{{
+		Entity: &params.MachineInfo{
+			Id:             "0/lxd/0",
+			AgentStatus:    params.StatusInfo{Current: status.Pending},
+			InstanceStatus: params.StatusInfo{Current: status.Provisioning},
+			Addresses: []params.Address{
+				{Value: "10.0.0.1", Scope: "local-cloud"},
+				{Value: "1.2.3.4", Scope: "public"},
+			},
+		},
+	}, {
+		Entity: &params.ApplicationInfo{
+			Name:     "mysql",
+			CharmURL: "cs:mysql-2",
+			Status:   params.StatusInfo{Current: status.Active},
+		},
+	}, {
+		Entity: &params.UnitInfo{
+			Name:           "logging/0",
+			Application:    "logging",
+			Principal:      "mysql/0",
+			WorkloadStatus: params.StatusInfo{Current: status.Active},
+		},
+	}, {
+		Entity: &params.ApplicationInfo{
+			Name:     "logging",
+			CharmURL: "cs:logging-3",
+		},
+	}, {
+		Entity: &params.UnitInfo{
+			Name:           "logging/0",
+			Application:    "logging",
+			Principal:      "mysql/0",
+			WorkloadStatus: params.StatusInfo{Current: status.Active},
+			AgentStatus:    params.StatusInfo{Current: status.Idle},
+		},
+	}, {
+		Entity: &params.RelationInfo{
+			Key: "logging:juju-info mysql:juju-info",
+			Endpoints: []params.Endpoint{{
+				ApplicationName: "logging",
+				Relation: params.CharmRelation{
+					Name: "juju-info", Role: "requirer", Interface: "juju-info", Scope: "container",
+				},
+			}, {
+				ApplicationName: "mysql",
+				Relation: params.CharmRelation{
+					Name: "juju-info", Role: "provider", Interface: "juju-info", Scope: "global",
+				},
+			}},
+		},
+	}})
+	c.Check(changes, jc.DeepEquals, []string{
+		"machine 0/lxd/0 pending allocating",
+		"application mysql active",
+		"application logging",
+		"unit logging/0 active idle",
+		"relation mysql:juju-info logging:juju-info added",
+	})
+
+	container := fs.Machines["0"].Containers["0/lxd/0"]
+	c.Check(container.DNSName, gc.Equals, "1.2.3.4")
+	c.Check(fs.Applications["mysql"].CharmRev, gc.Equals, 2)
+	subordinate := fs.Applications["mysql"].Units["mysql/0"].Subordinates["logging/0"]
+	c.Check(subordinate.JujuStatusInfo.Current, gc.Equals, status.Idle)
+	c.Check(fs.Relations, jc.DeepEquals, []relationStatus{{
+		Provider:  "mysql:juju-info",
+		Requirer:  "logging:juju-info",
+		Interface: "juju-info",
+		Type:      "subordinate",
+	}})
+
+	changes = updater.apply([]params.Delta{{
+		Removed: true,
+		Entity:  &params.UnitInfo{Name: "logging/0", Application: "logging", Principal: "mysql/0"},
+	}, {
+		Removed: true,
+		Entity:  &params.MachineInfo{Id: "0/lxd/0"},
+	}})
+	c.Check(changes, jc.DeepEquals, []string{
+		"unit logging/0 removed",
+		"machine 0/lxd/0 removed",
+	})
+	c.Check(fs.Applications["mysql"].Units["mysql/0"].Subordinates, gc.HasLen, 0)
+	c.Check(fs.Machines["0"].Containers, gc.HasLen, 0)
+}
+
+func (s *watchSuite) TestWriteFrameHighlightsChanges(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	statusCmd := &statusCommand{color: true}
+	fs := s.formattedStatus()
+
+	lines, err := statusCmd.writeFrame(ctx, statusCmd.FormatTabular, fs, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	first := cmdtesting.Stdout(ctx)
+	c.Check(strings.HasPrefix(first, clearScreen+"Model "), jc.IsTrue)
+	c.Check(first, gc.Not(gc.Matches), `(?s).*\x1b\[[0-9;]*mmysql/0 .*`)
+
+	updater := &statusUpdater{status: &fs}
+	updater.apply([]params.Delta{{
+		Entity: &params.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: params.StatusInfo{Current: status.Active, Message: "ready"},
+			AgentStatus:    params.StatusInfo{Current: status.Idle},
+		},
+	}})
+	_, err = statusCmd.writeFrame(ctx, statusCmd.FormatTabular, fs, lines)
+	c.Assert(err, jc.ErrorIsNil)
+	second := strings.TrimPrefix(cmdtesting.Stdout(ctx), first)
+
+	// The model hasn't changed, but the unit has.
+	c.Check(strings.HasPrefix(second, clearScreen+"Model "), jc.IsTrue)
+	c.Check(second, gc.Matches, `(?s).*\x1b\[[0-9;]*mmysql/0 .*ready.*`)
+	c.Check(second, gc.Matches, `(?s).*\nMachine .*`)
+}
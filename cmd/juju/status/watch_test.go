@@ -0,0 +1,188 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/status"
+	corestatus "github.com/juju/juju/core/status"
+	"github.com/juju/juju/testing"
+)
+
+type WatchStatusSuite struct {
+	testing.BaseSuite
+
+	statusapi *fakeStatusAPI
+	watcher   *fakeAllWatcher
+	clock     *timeRecorder
+}
+
+var _ = gc.Suite(&WatchStatusSuite{})
+
+func (s *WatchStatusSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.statusapi = &fakeStatusAPI{
+		result: &params.FullStatus{
+			Model: params.ModelStatusInfo{
+				Name:     "test",
+				CloudTag: "cloud-foo",
+			},
+			Machines: map[string]params.MachineStatus{
+				"0": {
+					Id:             "0",
+					AgentStatus:    params.DetailedStatus{Status: "started"},
+					InstanceStatus: params.DetailedStatus{Status: "running"},
+				},
+			},
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Charm:  "cs:mysql-1",
+					Series: "focal",
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {
+							Machine:        "0",
+							WorkloadStatus: params.DetailedStatus{Status: "waiting"},
+							AgentStatus:    params.DetailedStatus{Status: "executing"},
+						},
+					},
+				},
+			},
+		},
+	}
+	s.watcher = &fakeAllWatcher{stop: make(chan struct{})}
+	s.clock = &timeRecorder{now: time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)}
+	s.SetModelAndController(c, "test", "admin/test")
+}
+
+func (s *WatchStatusSuite) runStatus(c *gc.C, args ...string) (string, string, error) {
+	statusCmd := status.NewTestStatusWatchCommand(s.statusapi, s, s.clock)
+	ctx, err := cmdtesting.RunCommand(c, statusCmd, args...)
+	return cmdtesting.Stdout(ctx), cmdtesting.Stderr(ctx), err
+}
+
+// WatchAll is part of the status command's allWatcherAPI.
+func (s *WatchStatusSuite) WatchAll() (api.AllWatch, error) {
+	return s.watcher, nil
+}
+
+func (s *WatchStatusSuite) TestWatchChanges(c *gc.C) {
+	s.watcher.deltas = [][]params.Delta{{{
+		Entity: &params.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: params.StatusInfo{Current: corestatus.Active, Message: "ready"},
+			AgentStatus:    params.StatusInfo{Current: corestatus.Idle},
+		},
+	}, {
+		// Units of applications that aren't shown are ignored.
+		Entity: &params.UnitInfo{
+			Name:        "wordpress/0",
+			Application: "wordpress",
+		},
+	}, {
+		Entity: &params.MachineInfo{
+			Id:             "1",
+			AgentStatus:    params.StatusInfo{Current: corestatus.Pending},
+			InstanceStatus: params.StatusInfo{Current: corestatus.Provisioning},
+		},
+	}}, {{
+		// Deltas that don't change what's shown are ignored.
+		Entity: &params.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: params.StatusInfo{Current: corestatus.Active, Message: "ready"},
+			AgentStatus:    params.StatusInfo{Current: corestatus.Idle},
+		},
+	}, {
+		Removed: true,
+		Entity:  &params.MachineInfo{Id: "1"},
+	}, {
+		Removed: true,
+		Entity:  &params.ModelUpdate{Name: "test"},
+	}}}
+
+	stdout, stderr, err := s.runStatus(c, "--watch", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stdout, gc.Equals, `
+12:00:00 unit mysql/0 active idle ready
+12:00:00 machine 1 pending allocating
+12:00:00 machine 1 removed
+12:00:00 model test removed
+`[1:])
+	c.Check(stderr, gc.Equals, "Model \"test\" has been removed.\n")
+	c.Check(s.watcher.stopped, jc.IsTrue)
+}
+
+func (s *WatchStatusSuite) TestWatchSelector(c *gc.C) {
+	s.watcher.deltas = [][]params.Delta{{{
+		// New applications aren't shown when a selector is used.
+		Entity: &params.ApplicationInfo{Name: "wordpress"},
+	}, {
+		Entity: &params.UnitInfo{
+			Name:           "mysql/1",
+			Application:    "mysql",
+			WorkloadStatus: params.StatusInfo{Current: corestatus.Waiting},
+			AgentStatus:    params.StatusInfo{Current: corestatus.Allocating},
+		},
+	}, {
+		Removed: true,
+		Entity:  &params.ModelUpdate{Name: "test"},
+	}}}
+
+	stdout, _, err := s.runStatus(c, "--watch", "--utc", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stdout, gc.Equals, `
+12:00:00 unit mysql/1 waiting allocating
+12:00:00 model test removed
+`[1:])
+}
+
+func (s *WatchStatusSuite) TestWatchError(c *gc.C) {
+	s.watcher.err = errors.New("boom")
+	_, _, err := s.runStatus(c, "--watch")
+	c.Assert(err, gc.ErrorMatches, "watching model: boom")
+}
+
+func (s *WatchStatusSuite) TestWatchFormatNotSupported(c *gc.C) {
+	_, _, err := s.runStatus(c, "--watch", "--format", "json")
+	c.Assert(err, gc.ErrorMatches, "--watch is not supported with the json format")
+}
+
+type fakeAllWatcher struct {
+	deltas  [][]params.Delta
+	err     error
+	stop    chan struct{}
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]params.Delta, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	if len(w.deltas) > 0 {
+		deltas := w.deltas[0]
+		w.deltas = w.deltas[1:]
+		return deltas, nil
+	}
+	<-w.stop
+	return nil, errors.New("watcher was stopped")
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	if !w.stopped {
+		w.stopped = true
+		close(w.stop)
+	}
+	return nil
+}
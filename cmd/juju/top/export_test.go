@@ -0,0 +1,31 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package top
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewTopCommandForTest returns a top command using the given APIs and
+// terminal.
+func NewTopCommandForTest(api TopAPI, actionAPI ActionAPI, term Terminal, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	c := &topCommand{
+		newAPIFunc: func() (TopAPI, error) {
+			return api, nil
+		},
+		newActionAPIFunc: func() (ActionAPI, error) {
+			return actionAPI, nil
+		},
+		newTerminalFunc: func(*cmd.Context) (Terminal, error) {
+			return term, nil
+		},
+		clock: clock,
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package top
+
+import (
+	"unicode/utf8"
+)
+
+// key identifies a key pressed on the terminal.
+type key int
+
+const (
+	keyRune key = iota
+	keyUp
+	keyDown
+	keyEnter
+	keyEscape
+	keyBackspace
+	keyInterrupt
+)
+
+// keyPress is a single key pressed on the terminal. The rune is only set
+// for keyRune.
+type keyPress struct {
+	key  key
+	rune rune
+}
+
+// parseKeys converts the bytes read from a terminal in raw mode into the
+// keys they represent. Escape sequences which aren't recognised are
+// dropped.
+func parseKeys(b []byte) []keyPress {
+	var keys []keyPress
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			if len(b) >= 3 && (b[1] == '[' || b[1] == 'O') {
+				switch b[2] {
+				case 'A':
+					keys = append(keys, keyPress{key: keyUp})
+				case 'B':
+					keys = append(keys, keyPress{key: keyDown})
+				}
+				b = b[3:]
+				continue
+			}
+			keys = append(keys, keyPress{key: keyEscape})
+		case c == '\r' || c == '\n':
+			keys = append(keys, keyPress{key: keyEnter})
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, keyPress{key: keyBackspace})
+		case c == 0x03 || c == 0x04:
+			keys = append(keys, keyPress{key: keyInterrupt})
+		case c < 0x20:
+			// Ignore any other control characters.
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, keyPress{key: keyRune, rune: r})
+			b = b[size:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package top
+
+import (
+	"github.com/juju/names/v4"
+	"github.com/juju/naturalsort"
+
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// modelState holds the state of the model shown by the top command, as
+// reported by the AllWatcher and the debug log.
+type modelState struct {
+	model        params.ModelUpdate
+	applications map[string]params.ApplicationInfo
+	units        map[string]params.UnitInfo
+	machines     map[string]params.MachineInfo
+
+	logs    []common.LogMessage
+	maxLogs int
+}
+
+func newModelState(maxLogs int) *modelState {
+	return &modelState{
+		applications: make(map[string]params.ApplicationInfo),
+		units:        make(map[string]params.UnitInfo),
+		machines:     make(map[string]params.MachineInfo),
+		maxLogs:      maxLogs,
+	}
+}
+
+// apply updates the state with the deltas reported by the AllWatcher.
+func (s *modelState) apply(deltas []params.Delta) {
+	for _, delta := range deltas {
+		switch entity := delta.Entity.(type) {
+		case *params.ModelUpdate:
+			if !delta.Removed {
+				s.model = *entity
+			}
+		case *params.ApplicationInfo:
+			if delta.Removed {
+				delete(s.applications, entity.Name)
+			} else {
+				s.applications[entity.Name] = *entity
+			}
+		case *params.UnitInfo:
+			if delta.Removed {
+				delete(s.units, entity.Name)
+			} else {
+				s.units[entity.Name] = *entity
+			}
+		case *params.MachineInfo:
+			if delta.Removed {
+				delete(s.machines, entity.Id)
+			} else {
+				s.machines[entity.Id] = *entity
+			}
+		}
+	}
+}
+
+// addLog records a debug log message, dropping the oldest message once
+// the maximum number of messages is reached.
+func (s *modelState) addLog(msg common.LogMessage) {
+	s.logs = append(s.logs, msg)
+	if len(s.logs) > s.maxLogs {
+		s.logs = s.logs[len(s.logs)-s.maxLogs:]
+	}
+}
+
+// unitLogs returns the recorded log messages of the given unit, or all
+// of them if no unit is given.
+func (s *modelState) unitLogs(unit string) []common.LogMessage {
+	if unit == "" {
+		return s.logs
+	}
+	entity := names.NewUnitTag(unit).String()
+	var result []common.LogMessage
+	for _, msg := range s.logs {
+		if msg.Entity == entity {
+			result = append(result, msg)
+		}
+	}
+	return result
+}
+
+func (s *modelState) applicationNames() []string {
+	result := make([]string, 0, len(s.applications))
+	for name := range s.applications {
+		result = append(result, name)
+	}
+	return naturalsort.Sort(result)
+}
+
+func (s *modelState) unitNames() []string {
+	result := make([]string, 0, len(s.units))
+	for name := range s.units {
+		result = append(result, name)
+	}
+	return naturalsort.Sort(result)
+}
+
+func (s *modelState) machineIds() []string {
+	result := make([]string, 0, len(s.machines))
+	for id := range s.machines {
+		result = append(result, id)
+	}
+	return naturalsort.Sort(result)
+}
+
+// applicationUnitCount returns the number of units of an application.
+func (s *modelState) applicationUnitCount(application string) int {
+	var count int
+	for _, unit := range s.units {
+		if unit.Application == application {
+			count++
+		}
+	}
+	return count
+}
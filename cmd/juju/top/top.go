@@ -0,0 +1,366 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package top provides a terminal dashboard showing the current state of
+// a model as it changes.
+package top
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/action"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/status"
+)
+
+// AllWatcher defines the methods used on the AllWatcher.
+type AllWatcher interface {
+	Next() ([]params.Delta, error)
+	Stop() error
+}
+
+// TopAPI defines the API methods used by the top command.
+type TopAPI interface {
+	WatchAll() (AllWatcher, error)
+	WatchDebugLog(args common.DebugLogParams) (<-chan common.LogMessage, error)
+	StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error)
+	Close() error
+}
+
+// ActionAPI defines the API methods used to run actions from the top
+// command.
+type ActionAPI interface {
+	EnqueueOperation(actions []action.Action) (action.EnqueuedActions, error)
+	Close() error
+}
+
+// Terminal defines the terminal the top command draws on.
+type Terminal interface {
+	// Size returns the width and height of the terminal.
+	Size() (int, int, error)
+	// Restore returns the terminal to the state it was in before the
+	// command started.
+	Restore() error
+}
+
+// NewTopCommand returns a command which shows the state of a model in the
+// terminal.
+func NewTopCommand() cmd.Command {
+	command := &topCommand{
+		clock: clock.WallClock,
+	}
+	command.newAPIFunc = func() (TopAPI, error) {
+		client, err := command.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return topAPIShim{Client: client}, nil
+	}
+	command.newActionAPIFunc = func() (ActionAPI, error) {
+		root, err := command.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return action.NewClient(root), nil
+	}
+	command.newTerminalFunc = newTerminal
+	return modelcmd.Wrap(command)
+}
+
+// topAPIShim adapts the api client to TopAPI.
+type topAPIShim struct {
+	*api.Client
+}
+
+// WatchAll implements TopAPI.
+func (s topAPIShim) WatchAll() (AllWatcher, error) {
+	return s.Client.WatchAll()
+}
+
+const topDoc = `
+Show the applications, units, machines and recent log lines of a model, and
+keep them up to date as the model changes.
+
+The units can be selected with the arrow keys, and the following keys are
+available:
+
+    enter    show the details, status history and log lines of the unit
+    a        run an action on the unit
+    l        tail the log lines of the model, or of the unit
+    h        refresh the status history of the unit
+    esc      go back to the previous screen
+    q        quit
+
+Actions are run without parameters; use the run command to run actions
+with parameters, and show-task to see their results.
+
+Examples:
+
+    juju top
+    juju top -m mymodel --log-lines 500
+`
+
+// topCommand shows the state of a model in the terminal.
+type topCommand struct {
+	modelcmd.ModelCommandBase
+
+	newAPIFunc       func() (TopAPI, error)
+	newActionAPIFunc func() (ActionAPI, error)
+	newTerminalFunc  func(*cmd.Context) (Terminal, error)
+	clock            clock.Clock
+
+	logLines     uint
+	historySize  int
+	refreshDelay time.Duration
+}
+
+// Info implements Command.
+func (c *topCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "top",
+		Purpose: "Shows the state of a model in an interactive terminal dashboard.",
+		Doc:     topDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *topCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.UintVar(&c.logLines, "log-lines", 200, "Number of recent log lines to keep")
+	f.IntVar(&c.historySize, "history", 50, "Number of status history entries to show for a unit")
+	f.DurationVar(&c.refreshDelay, "refresh", time.Second, "How often to redraw the screen when nothing changes")
+}
+
+// Init implements Command.
+func (c *topCommand) Init(args []string) error {
+	if c.logLines == 0 {
+		return errors.NotValidf("--log-lines of 0")
+	}
+	if c.historySize <= 0 {
+		return errors.NotValidf("--history of %d", c.historySize)
+	}
+	if c.refreshDelay <= 0 {
+		return errors.NotValidf("--refresh of %v", c.refreshDelay)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.
+func (c *topCommand) Run(ctx *cmd.Context) error {
+	term, err := c.newTerminalFunc(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = term.Restore() }()
+
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "watching model")
+	}
+	defer func() { _ = watcher.Stop() }()
+
+	logs, err := client.WatchDebugLog(common.DebugLogParams{
+		Backlog: c.logLines,
+	})
+	if err != nil {
+		return errors.Annotate(err, "streaming debug log")
+	}
+
+	fmt.Fprint(ctx.Stdout, enterScreen)
+	defer fmt.Fprint(ctx.Stdout, exitScreen)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	deltas := make(chan []params.Delta)
+	watchErr := make(chan error, 1)
+	go func() {
+		for {
+			d, err := watcher.Next()
+			if err != nil {
+				watchErr <- err
+				return
+			}
+			select {
+			case deltas <- d:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	keys := make(chan []byte)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 64)
+		for {
+			n, err := ctx.Stdin.Read(buf)
+			if n > 0 {
+				select {
+				case keys <- append([]byte(nil), buf[:n]...):
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	view := newUI(newModelState(int(c.logLines)))
+	for {
+		width, height, err := term.Size()
+		if err != nil {
+			return errors.Annotate(err, "getting terminal size")
+		}
+		draw(ctx.Stdout, view.render(width, height))
+
+		select {
+		case d := <-deltas:
+			view.state.apply(d)
+		case err := <-watchErr:
+			return errors.Annotate(err, "watching model")
+		case msg, ok := <-logs:
+			if !ok {
+				// The log stream has ended, stop waiting on it.
+				logs = nil
+				continue
+			}
+			view.state.addLog(msg)
+		case b, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			for _, k := range parseKeys(b) {
+				req := view.handleKey(k)
+				if req.kind == quitRequest {
+					return nil
+				}
+				c.handleRequest(view, client, req)
+			}
+		case <-c.clock.After(c.refreshDelay):
+		}
+	}
+}
+
+// handleRequest does the work requested by the ui, reporting any failure
+// on the screen rather than stopping the command.
+func (c *topCommand) handleRequest(view *ui, client TopAPI, req request) {
+	switch req.kind {
+	case historyRequest:
+		history, err := client.StatusHistory(status.KindUnit, names.NewUnitTag(req.unit), status.StatusHistoryFilter{
+			Size: c.historySize,
+		})
+		if err != nil {
+			view.message = fmt.Sprintf("Cannot get status history of %s: %v", req.unit, err)
+			return
+		}
+		view.history = history
+	case actionRequest:
+		id, err := c.enqueueAction(req.unit, req.action)
+		if err != nil {
+			view.message = fmt.Sprintf("Cannot run action %s on %s: %v", req.action, req.unit, err)
+			return
+		}
+		view.message = fmt.Sprintf("Running action %s on %s as task %s", req.action, req.unit, id)
+	}
+}
+
+func (c *topCommand) enqueueAction(unit, name string) (string, error) {
+	client, err := c.newActionAPIFunc()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	results, err := client.EnqueueOperation([]action.Action{{
+		Receiver: names.NewUnitTag(unit).String(),
+		Name:     name,
+	}})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Actions) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Actions))
+	}
+	result := results.Actions[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Action.ID, nil
+}
+
+const (
+	// enterScreen switches to the alternate screen and hides the cursor.
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	// exitScreen shows the cursor and switches back to the main screen.
+	exitScreen = "\x1b[?25h\x1b[?1049l"
+	// clearScreen moves the cursor home and clears the screen.
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+// draw replaces the contents of the screen with the given lines. In raw
+// mode a carriage return is needed to start each line at the first column.
+func draw(out io.Writer, lines []string) {
+	fmt.Fprint(out, clearScreen+strings.Join(lines, "\r\n"))
+}
+
+// rawTerminal is a terminal switched to raw mode, so that key presses are
+// read as they are typed.
+type rawTerminal struct {
+	in       int
+	out      int
+	oldState *terminal.State
+}
+
+func newTerminal(ctx *cmd.Context) (Terminal, error) {
+	in, ok := ctx.Stdin.(*os.File)
+	if !ok || !terminal.IsTerminal(int(in.Fd())) {
+		return nil, errors.New("juju top requires an interactive terminal")
+	}
+	out, ok := ctx.Stdout.(*os.File)
+	if !ok || !terminal.IsTerminal(int(out.Fd())) {
+		return nil, errors.New("juju top requires an interactive terminal")
+	}
+	oldState, err := terminal.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return nil, errors.Annotate(err, "setting terminal to raw mode")
+	}
+	return &rawTerminal{
+		in:       int(in.Fd()),
+		out:      int(out.Fd()),
+		oldState: oldState,
+	}, nil
+}
+
+// Size implements Terminal.
+func (t *rawTerminal) Size() (int, int, error) {
+	return terminal.GetSize(t.out)
+}
+
+// Restore implements Terminal.
+func (t *rawTerminal) Restore() error {
+	return terminal.Restore(t.in, t.oldState)
+}
@@ -0,0 +1,216 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package top_test
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/action"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/top"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type topSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&topSuite{})
+
+func (s *topSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *topSuite) TestInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"foo"},
+		err:  `unrecognized args: \["foo"\]`,
+	}, {
+		args: []string{"--log-lines", "0"},
+		err:  `--log-lines of 0 not valid`,
+	}, {
+		args: []string{"--history", "0"},
+		err:  `--history of 0 not valid`,
+	}, {
+		args: []string{"--refresh", "0s"},
+		err:  `--refresh of 0s not valid`,
+	}} {
+		cmd := top.NewTopCommandForTest(nil, nil, nil, nil, s.store)
+		err := cmdtesting.InitCommand(cmd, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *topSuite) TestRun(c *gc.C) {
+	stub := &jujutesting.Stub{}
+	watcher := &fakeAllWatcher{
+		deltas: []params.Delta{{
+			Entity: &params.ModelUpdate{Name: "mymodel"},
+		}, {
+			Entity: &params.ApplicationInfo{Name: "mysql", CharmURL: "cs:mysql-42"},
+		}, {
+			Entity: &params.UnitInfo{
+				Name:           "mysql/0",
+				Application:    "mysql",
+				MachineId:      "0",
+				WorkloadStatus: params.StatusInfo{Current: status.Active, Message: "ready"},
+			},
+		}},
+		consumed: make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	logs := make(chan common.LogMessage, 1)
+	logs <- common.LogMessage{Entity: "unit-mysql-0", Severity: "INFO", Message: "all good"}
+	api := &fakeTopAPI{Stub: stub, watcher: watcher, logs: logs}
+	actionAPI := &fakeActionAPI{Stub: stub}
+
+	cmd := top.NewTopCommandForTest(api, actionAPI, fakeTerminal{stub}, testclock.NewClock(time.Time{}), s.store)
+	err := cmdtesting.InitCommand(cmd, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	// Once the deltas have been consumed, drill into the selected unit,
+	// run an action on it and quit.
+	ctx.Stdin = &waitingReader{
+		wait:   watcher.consumed,
+		chunks: []string{"\radoit\r", "q"},
+	}
+
+	err = cmd.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCallNames(c, "WatchAll", "WatchDebugLog", "StatusHistory", "EnqueueOperation", "Restore")
+	stub.CheckCall(c, 1, "WatchDebugLog", common.DebugLogParams{Backlog: 200})
+	stub.CheckCall(c, 2, "StatusHistory", status.KindUnit, names.NewUnitTag("mysql/0"), status.StatusHistoryFilter{Size: 50})
+	stub.CheckCall(c, 3, "EnqueueOperation", []action.Action{{Receiver: "unit-mysql-0", Name: "doit"}})
+
+	out := cmdtesting.Stdout(ctx)
+	c.Check(out, jc.Contains, "Model: mymodel  applications: 1  units: 1  machines: 0")
+	c.Check(out, jc.Contains, ">  mysql/0  active")
+	c.Check(out, jc.Contains, "Running action doit on mysql/0 as task 2")
+}
+
+type fakeTopAPI struct {
+	*jujutesting.Stub
+	watcher *fakeAllWatcher
+	logs    chan common.LogMessage
+}
+
+func (f *fakeTopAPI) WatchAll() (top.AllWatcher, error) {
+	f.MethodCall(f, "WatchAll")
+	return f.watcher, f.NextErr()
+}
+
+func (f *fakeTopAPI) WatchDebugLog(args common.DebugLogParams) (<-chan common.LogMessage, error) {
+	f.MethodCall(f, "WatchDebugLog", args)
+	return f.logs, f.NextErr()
+}
+
+func (f *fakeTopAPI) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	f.MethodCall(f, "StatusHistory", kind, tag, filter)
+	return status.History{{Kind: status.KindWorkload, Status: status.Active, Info: "ready"}}, f.NextErr()
+}
+
+func (f *fakeTopAPI) Close() error {
+	return nil
+}
+
+type fakeAllWatcher struct {
+	deltas   []params.Delta
+	consumed chan struct{}
+	stopped  chan struct{}
+}
+
+func (w *fakeAllWatcher) Next() ([]params.Delta, error) {
+	if w.deltas != nil {
+		deltas := w.deltas
+		w.deltas = nil
+		return deltas, nil
+	}
+	// Asking for more deltas means the first ones have been consumed.
+	close(w.consumed)
+	<-w.stopped
+	return nil, errors.New("watcher stopped")
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	close(w.stopped)
+	return nil
+}
+
+type fakeActionAPI struct {
+	*jujutesting.Stub
+}
+
+func (f *fakeActionAPI) EnqueueOperation(actions []action.Action) (action.EnqueuedActions, error) {
+	f.MethodCall(f, "EnqueueOperation", actions)
+	return action.EnqueuedActions{
+		OperationID: "1",
+		Actions: []action.ActionResult{{
+			Action: &action.Action{ID: "2"},
+		}},
+	}, f.NextErr()
+}
+
+func (f *fakeActionAPI) Close() error {
+	return nil
+}
+
+type fakeTerminal struct {
+	*jujutesting.Stub
+}
+
+func (f fakeTerminal) Size() (int, int, error) {
+	return 120, 40, nil
+}
+
+func (f fakeTerminal) Restore() error {
+	f.MethodCall(f, "Restore")
+	return f.NextErr()
+}
+
+// waitingReader returns a chunk of data for each read once the wait
+// channel is closed.
+type waitingReader struct {
+	wait   <-chan struct{}
+	chunks []string
+}
+
+func (r *waitingReader) Read(p []byte) (int, error) {
+	<-r.wait
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.chunks[0])
+	r.chunks = r.chunks[1:]
+	return n, nil
+}
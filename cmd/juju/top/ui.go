@@ -0,0 +1,402 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package top
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/core/status"
+)
+
+// view identifies the screen shown by the top command.
+type view int
+
+const (
+	// modelView shows the applications, units, machines and recent log
+	// lines of the model.
+	modelView view = iota
+	// unitView shows the status and status history of a single unit.
+	unitView
+	// logView tails the log lines of the model or of a single unit.
+	logView
+)
+
+// requestKind identifies work the command has to do in response to a key
+// press, which the ui can't do itself.
+type requestKind int
+
+const (
+	noRequest requestKind = iota
+	quitRequest
+	historyRequest
+	actionRequest
+)
+
+// request is work the command has to do for the ui.
+type request struct {
+	kind   requestKind
+	unit   string
+	action string
+}
+
+// ui holds the navigation state of the top command and renders it.
+type ui struct {
+	state *modelState
+
+	view     view
+	selected int
+	unit     string
+	history  status.History
+
+	prompting bool
+	input     string
+	message   string
+}
+
+func newUI(state *modelState) *ui {
+	return &ui{state: state}
+}
+
+// selectedUnit returns the name of the unit selected in the model view.
+func (u *ui) selectedUnit() string {
+	names := u.state.unitNames()
+	if len(names) == 0 {
+		return ""
+	}
+	if u.selected >= len(names) {
+		u.selected = len(names) - 1
+	}
+	return names[u.selected]
+}
+
+// currentUnit returns the unit the keys apply to in the current view.
+func (u *ui) currentUnit() string {
+	if u.view == modelView {
+		return u.selectedUnit()
+	}
+	return u.unit
+}
+
+// handleKey updates the navigation state for a key press and returns any
+// work the command has to do in response.
+func (u *ui) handleKey(k keyPress) request {
+	if k.key == keyInterrupt {
+		return request{kind: quitRequest}
+	}
+	u.message = ""
+	if u.prompting {
+		return u.handlePromptKey(k)
+	}
+
+	switch k.key {
+	case keyUp:
+		if u.view == modelView && u.selected > 0 {
+			u.selected--
+		}
+	case keyDown:
+		if u.view == modelView && u.selected < len(u.state.units)-1 {
+			u.selected++
+		}
+	case keyEnter:
+		if u.view == modelView {
+			if unit := u.selectedUnit(); unit != "" {
+				u.view = unitView
+				u.unit = unit
+				u.history = nil
+				return request{kind: historyRequest, unit: unit}
+			}
+		}
+	case keyEscape, keyBackspace:
+		u.back()
+	case keyRune:
+		switch k.rune {
+		case 'q':
+			return request{kind: quitRequest}
+		case 'h':
+			if u.view == unitView {
+				return request{kind: historyRequest, unit: u.unit}
+			}
+		case 'l':
+			switch u.view {
+			case modelView:
+				u.unit = ""
+				u.view = logView
+			case unitView:
+				u.view = logView
+			}
+		case 'a':
+			if unit := u.currentUnit(); unit != "" {
+				u.unit = unit
+				u.prompting = true
+				u.input = ""
+			}
+		}
+	}
+	return request{}
+}
+
+func (u *ui) handlePromptKey(k keyPress) request {
+	switch k.key {
+	case keyEscape:
+		u.prompting = false
+	case keyBackspace:
+		if runes := []rune(u.input); len(runes) > 0 {
+			u.input = string(runes[:len(runes)-1])
+		}
+	case keyEnter:
+		u.prompting = false
+		if action := strings.TrimSpace(u.input); action != "" {
+			return request{kind: actionRequest, unit: u.unit, action: action}
+		}
+	case keyRune:
+		u.input += string(k.rune)
+	}
+	return request{}
+}
+
+// back returns to the previous view.
+func (u *ui) back() {
+	switch u.view {
+	case unitView:
+		u.view = modelView
+	case logView:
+		if u.unit != "" {
+			u.view = unitView
+		} else {
+			u.view = modelView
+		}
+	}
+}
+
+// render returns the lines of the screen for the current view, fitted to
+// the given size.
+func (u *ui) render(width, height int) []string {
+	if height < 3 {
+		return nil
+	}
+	header := []string{u.header(), ""}
+	footer := u.footer()
+
+	body := height - len(header) - 1
+	var lines []string
+	switch u.view {
+	case modelView:
+		lines = u.renderModel(body)
+	case unitView:
+		lines = u.renderUnit(body)
+	case logView:
+		lines = tail(formatLogs(u.state.unitLogs(u.unit)), body)
+	}
+	for len(lines) < body {
+		lines = append(lines, "")
+	}
+
+	result := append(header, lines[:body]...)
+	result = append(result, footer)
+	for i, line := range result {
+		result[i] = truncate(line, width)
+	}
+	return result
+}
+
+func (u *ui) header() string {
+	model := u.state.model
+	header := fmt.Sprintf("Model: %s", model.Name)
+	if model.Status.Current != "" {
+		header += fmt.Sprintf(" (%s)", model.Status.Current)
+	}
+	return fmt.Sprintf("%s  applications: %d  units: %d  machines: %d",
+		header, len(u.state.applications), len(u.state.units), len(u.state.machines))
+}
+
+func (u *ui) footer() string {
+	switch {
+	case u.prompting:
+		return fmt.Sprintf("Action to run on %s: %s_", u.unit, u.input)
+	case u.message != "":
+		return u.message
+	}
+	switch u.view {
+	case unitView:
+		return "h: refresh history  l: logs  a: run action  esc: back  q: quit"
+	case logView:
+		return "esc: back  q: quit"
+	}
+	return "up/down: select unit  enter: unit details  l: logs  a: run action  q: quit"
+}
+
+func (u *ui) renderModel(height int) []string {
+	// Share the space between the sections, giving the log lines any
+	// space the other sections don't need.
+	limit := height / 4
+	if limit < 3 {
+		limit = 3
+	}
+
+	var lines []string
+	lines = append(lines, u.applicationLines(limit)...)
+	lines = append(lines, "")
+	lines = append(lines, u.unitLines(limit)...)
+	lines = append(lines, "")
+	lines = append(lines, u.machineLines(limit)...)
+	lines = append(lines, "")
+	if remaining := height - len(lines) - 1; remaining > 0 {
+		lines = append(lines, "Logs:")
+		lines = append(lines, tail(formatLogs(u.state.logs), remaining)...)
+	}
+	return lines
+}
+
+func (u *ui) applicationLines(limit int) []string {
+	rows := [][]string{{"App", "Status", "Units", "Charm", "Message"}}
+	for _, name := range u.state.applicationNames() {
+		app := u.state.applications[name]
+		rows = append(rows, []string{
+			name,
+			string(app.Status.Current),
+			fmt.Sprint(u.state.applicationUnitCount(name)),
+			app.CharmURL,
+			app.Status.Message,
+		})
+	}
+	return window(table(rows), limit, 0)
+}
+
+func (u *ui) unitLines(limit int) []string {
+	rows := [][]string{{"", "Unit", "Workload", "Agent", "Machine", "Message"}}
+	selected := u.selectedUnit()
+	selectedRow := 0
+	for i, name := range u.state.unitNames() {
+		unit := u.state.units[name]
+		marker := ""
+		if name == selected {
+			marker = ">"
+			selectedRow = i + 1
+		}
+		rows = append(rows, []string{
+			marker,
+			name,
+			string(unit.WorkloadStatus.Current),
+			string(unit.AgentStatus.Current),
+			unit.MachineId,
+			unit.WorkloadStatus.Message,
+		})
+	}
+	return window(table(rows), limit, selectedRow)
+}
+
+func (u *ui) machineLines(limit int) []string {
+	rows := [][]string{{"Machine", "State", "Instance", "Series", "Message"}}
+	for _, id := range u.state.machineIds() {
+		machine := u.state.machines[id]
+		rows = append(rows, []string{
+			id,
+			string(machine.AgentStatus.Current),
+			machine.InstanceId,
+			machine.Series,
+			machine.InstanceStatus.Message,
+		})
+	}
+	return window(table(rows), limit, 0)
+}
+
+func (u *ui) renderUnit(height int) []string {
+	unit, ok := u.state.units[u.unit]
+	if !ok {
+		return []string{fmt.Sprintf("Unit %s has been removed.", u.unit)}
+	}
+	lines := []string{
+		fmt.Sprintf("Unit:     %s", unit.Name),
+		fmt.Sprintf("Workload: %s %s", unit.WorkloadStatus.Current, unit.WorkloadStatus.Message),
+		fmt.Sprintf("Agent:    %s %s", unit.AgentStatus.Current, unit.AgentStatus.Message),
+		fmt.Sprintf("Machine:  %s", unit.MachineId),
+		fmt.Sprintf("Address:  %s", unit.PublicAddress),
+		"",
+		"Status history:",
+	}
+
+	rows := [][]string{{"Time", "Type", "Status", "Message"}}
+	for _, entry := range u.history {
+		var since string
+		if entry.Since != nil {
+			since = entry.Since.Local().Format("2006-01-02 15:04:05")
+		}
+		rows = append(rows, []string{since, string(entry.Kind), string(entry.Status), entry.Info})
+	}
+	limit := height / 2
+	if limit < 3 {
+		limit = 3
+	}
+	// Show the most recent history, which is at the end.
+	history := table(rows)
+	if len(history) > limit {
+		history = append(history[:1], tail(history[1:], limit-1)...)
+	}
+	lines = append(lines, history...)
+	lines = append(lines, "")
+
+	if remaining := height - len(lines) - 1; remaining > 0 {
+		lines = append(lines, "Logs:")
+		lines = append(lines, tail(formatLogs(u.state.unitLogs(u.unit)), remaining)...)
+	}
+	return lines
+}
+
+// table formats the rows in aligned columns.
+func table(rows [][]string) []string {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 1, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	_ = tw.Flush()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines
+}
+
+// window returns at most limit lines of a table, always including its
+// heading and scrolling the rest so that the given row is shown.
+func window(lines []string, limit, row int) []string {
+	if len(lines) <= limit {
+		return lines
+	}
+	rows := limit - 1
+	start := 1
+	if row >= start+rows {
+		start = row - rows + 1
+	}
+	return append([]string{lines[0]}, lines[start:start+rows]...)
+}
+
+// tail returns the last n lines.
+func tail(lines []string, n int) []string {
+	if len(lines) > n {
+		return lines[len(lines)-n:]
+	}
+	return lines
+}
+
+func formatLogs(logs []common.LogMessage) []string {
+	lines := make([]string, len(logs))
+	for i, msg := range logs {
+		lines[i] = fmt.Sprintf("%s %s %s %s %s",
+			msg.Timestamp.Local().Format("15:04:05"), msg.Entity, msg.Severity, msg.Module, msg.Message)
+	}
+	return lines
+}
+
+// truncate shortens a line to fit the width of the screen.
+func truncate(line string, width int) string {
+	if runes := []rune(line); len(runes) > width {
+		return string(runes[:width])
+	}
+	return line
+}
@@ -0,0 +1,173 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package top
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+type uiSuite struct{}
+
+var _ = gc.Suite(&uiSuite{})
+
+func (s *uiSuite) TestParseKeys(c *gc.C) {
+	keys := parseKeys([]byte("a\x1b[A\x1b[B\x1bOA\r\x7f\x1b\x03é\x01"))
+	c.Assert(keys, jc.DeepEquals, []keyPress{
+		{key: keyRune, rune: 'a'},
+		{key: keyUp},
+		{key: keyDown},
+		{key: keyUp},
+		{key: keyEnter},
+		{key: keyBackspace},
+		{key: keyEscape},
+		{key: keyInterrupt},
+		{key: keyRune, rune: 'é'},
+	})
+}
+
+func (s *uiSuite) TestApplyDeltas(c *gc.C) {
+	state := newModelState(10)
+	state.apply([]params.Delta{
+		{Entity: &params.ApplicationInfo{Name: "mysql"}},
+		{Entity: &params.UnitInfo{Name: "mysql/10", Application: "mysql"}},
+		{Entity: &params.UnitInfo{Name: "mysql/2", Application: "mysql"}},
+		{Entity: &params.MachineInfo{Id: "0"}},
+	})
+	c.Assert(state.applicationNames(), jc.DeepEquals, []string{"mysql"})
+	c.Assert(state.unitNames(), jc.DeepEquals, []string{"mysql/2", "mysql/10"})
+	c.Assert(state.machineIds(), jc.DeepEquals, []string{"0"})
+	c.Assert(state.applicationUnitCount("mysql"), gc.Equals, 2)
+
+	state.apply([]params.Delta{
+		{Entity: &params.UnitInfo{Name: "mysql/2", Application: "mysql"}, Removed: true},
+		{Entity: &params.MachineInfo{Id: "0"}, Removed: true},
+	})
+	c.Assert(state.unitNames(), jc.DeepEquals, []string{"mysql/10"})
+	c.Assert(state.machineIds(), gc.HasLen, 0)
+}
+
+func (s *uiSuite) TestAddLog(c *gc.C) {
+	state := newModelState(2)
+	state.addLog(common.LogMessage{Entity: "unit-mysql-0", Message: "one"})
+	state.addLog(common.LogMessage{Entity: "machine-0", Message: "two"})
+	state.addLog(common.LogMessage{Entity: "unit-mysql-0", Message: "three"})
+
+	c.Assert(state.unitLogs(""), gc.HasLen, 2)
+	logs := state.unitLogs("mysql/0")
+	c.Assert(logs, gc.HasLen, 1)
+	c.Assert(logs[0].Message, gc.Equals, "three")
+}
+
+func (s *uiSuite) newUI() *ui {
+	state := newModelState(10)
+	state.apply([]params.Delta{
+		{Entity: &params.ModelUpdate{Name: "mymodel", Status: params.StatusInfo{Current: status.Available}}},
+		{Entity: &params.ApplicationInfo{Name: "mysql", CharmURL: "cs:mysql-42", Status: params.StatusInfo{Current: status.Active}}},
+		{Entity: &params.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: params.StatusInfo{Current: status.Active},
+			AgentStatus:    params.StatusInfo{Current: status.Idle},
+		}},
+		{Entity: &params.UnitInfo{
+			Name:           "mysql/1",
+			Application:    "mysql",
+			MachineId:      "1",
+			WorkloadStatus: params.StatusInfo{Current: status.Blocked, Message: "needs a relation"},
+			AgentStatus:    params.StatusInfo{Current: status.Idle},
+		}},
+		{Entity: &params.MachineInfo{Id: "0", Series: "focal", AgentStatus: params.StatusInfo{Current: status.Started}}},
+		{Entity: &params.MachineInfo{Id: "1", Series: "focal", AgentStatus: params.StatusInfo{Current: status.Started}}},
+	})
+	state.addLog(common.LogMessage{Entity: "unit-mysql-1", Severity: "ERROR", Module: "juju.worker", Message: "boom"})
+	return newUI(state)
+}
+
+func (s *uiSuite) TestRenderModel(c *gc.C) {
+	u := s.newUI()
+	lines := u.render(80, 20)
+	c.Assert(lines, gc.HasLen, 20)
+	c.Check(lines[0], gc.Equals, "Model: mymodel (available)  applications: 1  units: 2  machines: 2")
+	screen := strings.Join(lines, "\n")
+	c.Check(screen, jc.Contains, "mysql  active  2      cs:mysql-42")
+	c.Check(screen, jc.Contains, ">  mysql/0  active    idle   0")
+	c.Check(screen, jc.Contains, "   mysql/1  blocked   idle   1        needs a relation")
+	c.Check(screen, jc.Contains, "0        started            focal")
+	c.Check(screen, gc.Matches, "(?s).*unit-mysql-1 ERROR juju.worker boom.*")
+	c.Check(lines[19], gc.Equals, "up/down: select unit  enter: unit details  l: logs  a: run action  q: quit")
+}
+
+func (s *uiSuite) TestRenderTruncates(c *gc.C) {
+	u := s.newUI()
+	for _, line := range u.render(10, 5) {
+		c.Check(len([]rune(line)) <= 10, jc.IsTrue)
+	}
+	c.Check(u.render(10, 2), gc.HasLen, 0)
+}
+
+func (s *uiSuite) TestNavigateToUnit(c *gc.C) {
+	u := s.newUI()
+	c.Assert(u.handleKey(keyPress{key: keyDown}), gc.Equals, request{})
+	c.Assert(u.selectedUnit(), gc.Equals, "mysql/1")
+	c.Assert(u.handleKey(keyPress{key: keyDown}), gc.Equals, request{})
+	c.Assert(u.selectedUnit(), gc.Equals, "mysql/1")
+
+	req := u.handleKey(keyPress{key: keyEnter})
+	c.Assert(req, gc.Equals, request{kind: historyRequest, unit: "mysql/1"})
+	c.Assert(u.view, gc.Equals, unitView)
+
+	u.history = status.History{{Kind: status.KindWorkload, Status: status.Blocked, Info: "needs a relation"}}
+	screen := strings.Join(u.render(80, 24), "\n")
+	c.Check(screen, jc.Contains, "Unit:     mysql/1")
+	c.Check(screen, jc.Contains, "workload  blocked  needs a relation")
+	c.Check(screen, jc.Contains, "boom")
+
+	c.Assert(u.handleKey(keyPress{key: keyRune, rune: 'h'}), gc.Equals, request{kind: historyRequest, unit: "mysql/1"})
+
+	u.handleKey(keyPress{key: keyRune, rune: 'l'})
+	c.Assert(u.view, gc.Equals, logView)
+	u.handleKey(keyPress{key: keyEscape})
+	c.Assert(u.view, gc.Equals, unitView)
+	u.handleKey(keyPress{key: keyEscape})
+	c.Assert(u.view, gc.Equals, modelView)
+}
+
+func (s *uiSuite) TestRunAction(c *gc.C) {
+	u := s.newUI()
+	u.handleKey(keyPress{key: keyRune, rune: 'a'})
+	c.Assert(u.prompting, jc.IsTrue)
+	for _, r := range "backupx" {
+		c.Assert(u.handleKey(keyPress{key: keyRune, rune: r}), gc.Equals, request{})
+	}
+	u.handleKey(keyPress{key: keyBackspace})
+	lines := u.render(80, 10)
+	c.Assert(lines[9], gc.Equals, "Action to run on mysql/0: backup_")
+
+	req := u.handleKey(keyPress{key: keyEnter})
+	c.Assert(req, gc.Equals, request{kind: actionRequest, unit: "mysql/0", action: "backup"})
+	c.Assert(u.prompting, jc.IsFalse)
+}
+
+func (s *uiSuite) TestCancelAction(c *gc.C) {
+	u := s.newUI()
+	u.handleKey(keyPress{key: keyRune, rune: 'a'})
+	u.handleKey(keyPress{key: keyRune, rune: 'q'})
+	c.Assert(u.handleKey(keyPress{key: keyEscape}), gc.Equals, request{})
+	c.Assert(u.prompting, jc.IsFalse)
+	c.Assert(u.handleKey(keyPress{key: keyRune, rune: 'q'}), gc.Equals, request{kind: quitRequest})
+}
+
+func (s *uiSuite) TestNewTerminalNeedsTerminal(c *gc.C) {
+	_, err := newTerminal(cmdtesting.Context(c))
+	c.Assert(err, gc.ErrorMatches, "juju top requires an interactive terminal")
+}
@@ -286,6 +286,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewExecClient:                  config.NewExecClient,
 			NewContainerStartWatcherClient: config.NewContainerStartWatcherClient,
 			RunListenerSocket:              config.RunListenerSocket,
+			PrometheusRegisterer:           config.PrometheusRegisterer,
 		})),
 	}
 }
@@ -40,6 +40,7 @@ var (
 		"firewaller",
 		"instance-mutater",
 		"instance-poller",
+		"log-pruner",              // tertiary dependency: will be inactive because migration workers will be inactive
 		"logging-config-updater",  // tertiary dependency: will be inactive because migration workers will be inactive
 		"machine-undertaker",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"metric-worker",           // tertiary dependency: will be inactive because migration workers will be inactive
@@ -64,6 +65,7 @@ var (
 		"instance-mutater",
 		"instance-poller",
 		"log-forwarder",
+		"log-pruner",
 		"logging-config-updater",
 		"machine-undertaker",
 		"metric-worker",
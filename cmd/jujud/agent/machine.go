@@ -1096,6 +1096,7 @@ func (a *MachineAgent) startModelWorkers(cfg modelworkermanager.NewModelConfig)
 		CharmRevisionUpdateInterval: 24 * time.Hour,
 		StatusHistoryPrunerInterval: 5 * time.Minute,
 		ActionPrunerInterval:        24 * time.Hour,
+		LogPrunerInterval:           5 * time.Minute,
 		Mux:                         cfg.Mux,
 		NewEnvironFunc:              newEnvirons,
 		NewContainerBrokerFunc:      newCAASBroker,
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	containerbroker "github.com/juju/juju/container/broker"
 	"github.com/juju/juju/container/lxd"
+	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/raftlease"
@@ -43,6 +44,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/caasupgrader"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
@@ -674,7 +676,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Clock:                config.Clock,
 			MuxShutdownWait:      config.MuxShutdownWait,
 			LogDir:               agentConfig.LogDir(),
-			Logger:               loggo.GetLogger("juju.worker.httpserver"),
+			Logger:               corelogger.GetLoggerWithLabels("juju.worker.httpserver", corelogger.HTTP),
 			GetControllerConfig:  httpserver.GetControllerConfig,
 			NewTLSConfig:         httpserver.NewTLSConfig,
 			NewWorker:            httpserver.NewWorkerShim,
@@ -860,6 +862,20 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Clock:         config.Clock,
 		})),
 
+		// The backup scheduler creates backups of the controller on
+		// the schedule set in controller config. Backups are only
+		// supported on machine controllers.
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName:  agentName,
+				ClockName:  clockName,
+				StateName:  stateName,
+				NewBackups: backupscheduler.NewStateBackups,
+				NewWorker:  backupscheduler.NewWorker,
+				Logger:     loggo.GetLogger("juju.worker.backupscheduler"),
+			},
+		))),
+
 		certificateUpdaterName: ifFullyUpgraded(certupdater.Manifold(certupdater.ManifoldConfig{
 			AgentName:                agentName,
 			AuthorityName:            certificateWatcherName,
@@ -942,6 +958,8 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			UnitEngineConfig: config.UnitEngineConfig,
 			SetupLogging:     config.SetupLogging,
 			NewDeployContext: config.NewDeployContext,
+
+			PrometheusRegisterer: config.PrometheusRegisterer,
 		})),
 
 		// The reboot manifold manages a worker which will reboot the
@@ -1118,6 +1136,7 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	instanceMutaterName           = "instance-mutater"
 	txnPrunerName                 = "transaction-pruner"
+	backupSchedulerName           = "backup-scheduler"
 	certificateWatcherName        = "certificate-watcher"
 	modelCacheName                = "model-cache"
 	modelCacheInitializedFlagName = "model-cache-initialized-flag"
@@ -51,6 +51,7 @@ import (
 	"github.com/juju/juju/worker/logforwarder"
 	"github.com/juju/juju/worker/logforwarder/sinks"
 	"github.com/juju/juju/worker/logger"
+	"github.com/juju/juju/worker/logpruner"
 	"github.com/juju/juju/worker/machineundertaker"
 	"github.com/juju/juju/worker/metricworker"
 	"github.com/juju/juju/worker/migrationflag"
@@ -114,6 +115,10 @@ type ManifoldsConfig struct {
 	// worker is run.
 	ActionPrunerInterval time.Duration
 
+	// LogPrunerInterval controls the rate at which the retained log
+	// pruner worker is run.
+	LogPrunerInterval time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -299,11 +304,19 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			PruneInterval: config.ActionPrunerInterval,
 			Logger:        config.LoggingContext.GetLogger("juju.worker.pruner.action"),
 		})),
+		logPrunerName: ifNotMigrating(logpruner.Manifold(logpruner.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			NewWorker:     logpruner.New,
+			NewClient:     logpruner.NewClient,
+			PruneInterval: config.LogPrunerInterval,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.logpruner"),
+		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
+				OpenFn: sinks.Open,
 			}},
 			Logger: config.LoggingContext.GetLogger("juju.worker.logforwarder"),
 		})),
@@ -680,6 +693,7 @@ const (
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	logPrunerName            = "log-pruner"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
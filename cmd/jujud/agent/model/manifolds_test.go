@@ -48,6 +48,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"instance-poller",
 		"is-responsible-flag",
 		"log-forwarder",
+		"log-pruner",
 		"logging-config-updater",
 		"machine-undertaker",
 		"metric-worker",
@@ -97,6 +98,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"clock",
 		"is-responsible-flag",
 		"log-forwarder",
+		"log-pruner",
 		"logging-config-updater",
 		"migration-fortress",
 		"migration-inactive-flag",
@@ -316,6 +318,16 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"is-responsible-flag",
 		"not-dead-flag"},
 
+	"log-pruner": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"logging-config-updater": {
 		"agent",
 		"api-caller",
@@ -515,6 +527,17 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"not-dead-flag",
 	},
 
+	"log-pruner": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+	},
+
 	"logging-config-updater": {
 		"agent",
 		"api-caller",
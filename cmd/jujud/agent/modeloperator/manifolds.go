@@ -12,6 +12,7 @@ import (
 	coreagent "github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/caas"
+	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -119,7 +120,7 @@ func Manifolds(config ManifoldConfig) dependency.Manifolds {
 		modelHTTPServerName: muxhttpserver.Manifold(
 			muxhttpserver.ManifoldConfig{
 				AuthorityName: certificateWatcherName,
-				Logger:        loggo.GetLogger("juju.worker.muxhttpserver"),
+				Logger:        corelogger.GetLoggerWithLabels("juju.worker.muxhttpserver", corelogger.HTTP),
 				Port:          config.Port,
 			},
 		),
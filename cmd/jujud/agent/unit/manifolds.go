@@ -328,6 +328,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			HookRetryStrategyName: hookRetryStrategyName,
 			TranslateResolverErr:  uniter.TranslateFortressErrors,
 			Logger:                loggo.GetLogger("juju.worker.uniter"),
+			PrometheusRegisterer:  config.PrometheusRegisterer,
 		})),
 
 		// TODO (mattyw) should be added to machine agent.
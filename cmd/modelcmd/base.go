@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/httpbakery"
 	"github.com/juju/cmd"
@@ -26,6 +27,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/tracing"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
@@ -569,6 +571,7 @@ func newAPIConnectionParams(
 	}
 	dialOpts := api.DefaultDialOpts()
 	dialOpts.BakeryClient = bakery
+	dialOpts.TraceContext = commandTraceContext()
 
 	// Embedded clients with macaroons cannot discharge.
 	if accountDetails != nil && !embedded {
@@ -588,6 +591,30 @@ func newAPIConnectionParams(
 	}, nil
 }
 
+var (
+	commandTraceOnce sync.Once
+	commandTrace     tracing.SpanContext
+)
+
+// commandTraceContext returns the trace context attached to all the
+// API requests made by this process. It is taken from the
+// JUJU_TRACEPARENT environment variable if set, so a command can be
+// traced as part of a larger operation, and is otherwise a new trace.
+func commandTraceContext() tracing.SpanContext {
+	commandTraceOnce.Do(func() {
+		sc, ok, err := tracing.FromEnvironment()
+		if err != nil {
+			logger.Warningf("ignoring trace context: %v", err)
+		}
+		if !ok {
+			sc = tracing.NewRootContext()
+		}
+		logger.Debugf("tracing API requests with trace ID %s", sc.TraceID)
+		commandTrace = sc
+	})
+	return commandTrace
+}
+
 // NewGetBootstrapConfigParamsFunc returns a function that, given a controller name,
 // returns the params needed to bootstrap a fresh copy of that controller in the given client store.
 func NewGetBootstrapConfigParamsFunc(
@@ -20,6 +20,27 @@ var DefaultFormatters = map[string]cmd.Formatter{
 	"json": cmd.FormatJson,
 }
 
+// HumanFormat is the default format of commands which change the model.
+// With it, the commands print their usual messages rather than a
+// structured result.
+const HumanFormat = "human"
+
+// ChangeFormatters holds the formatters that can be specified with the
+// --format flag of commands which change the model. The structured
+// formats write the entities the command created or affected, along with
+// any errors for each of them.
+var ChangeFormatters = map[string]cmd.Formatter{
+	HumanFormat: formatNothing,
+	"yaml":      cmd.FormatYaml,
+	"json":      cmd.FormatJson,
+}
+
+// formatNothing is the formatter for HumanFormat, as the commands print
+// their messages as they go.
+func formatNothing(io.Writer, interface{}) error {
+	return nil
+}
+
 // TabWriter returns a new tab writer with common layout definition.
 func TabWriter(writer io.Writer) *ansiterm.TabWriter {
 	const (
@@ -0,0 +1,235 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/plugins/juju-wait-for/api"
+	"github.com/juju/juju/core/query"
+)
+
+func newActionCommand() cmd.Command {
+	cmd := &actionCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return watchAllAPIShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const actionCommandDoc = `
+Wait for a given action to reach a goal state.
+
+Once the action has finished, by completing, failing or being cancelled or
+aborted, its state no longer changes. If the goal state hasn't been reached
+by then, the command fails instead of waiting until the timeout.
+
+The results of the action can be queried by key:
+
+    juju wait-for action 42 --query='status=="completed" && results["code"]=="0"'
+
+arguments:
+id
+   action id
+
+options:
+--query (= 'status=="completed"')
+   query represents the goal state of a given action
+`
+
+// actionCommand defines a command for waiting for actions.
+type actionCommand struct {
+	waitForCommandBase
+
+	id      string
+	query   string
+	timeout time.Duration
+	found   bool
+	summary bool
+
+	actionInfo params.ActionInfo
+}
+
+// Info implements Command.Info.
+func (c *actionCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "action",
+		Args:    "[<id>]",
+		Purpose: "wait for an action to reach a goal state",
+		Doc:     actionCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *actionCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `status=="completed"`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the action query on exit")
+}
+
+// Init implements Command.Init.
+func (c *actionCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("action id must be supplied when waiting for an action")
+	}
+	if len(args) != 1 {
+		return errors.New("only one action id can be supplied as an argument to this command")
+	}
+	if ok := names.IsValidAction(args[0]); !ok {
+		return errors.Errorf("%q is not valid action id", args[0])
+	}
+	c.id = args[0]
+
+	return nil
+}
+
+func (c *actionCommand) Run(ctx *cmd.Context) error {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		if !c.summary || !c.found {
+			return
+		}
+
+		ctx.Infof("Action %q is %s", c.id, c.actionInfo.Status)
+		outputActionSummary(ctx.Stdout, scopedContext, &c.actionInfo)
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	err := strategy.Run(c.id, c.query, c.waitFor(scopedContext))
+	return errors.Trace(err)
+}
+
+func (c *actionCommand) waitFor(ctx ScopeContext) func(string, []params.Delta, query.Query) (bool, error) {
+	return func(id string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Tracef("delta %T: %v", delta.Entity, delta.Entity)
+
+			switch entityInfo := delta.Entity.(type) {
+			case *params.ActionInfo:
+				if entityInfo.Id != id {
+					break
+				}
+
+				if delta.Removed {
+					return false, errors.Errorf("action %v removed", id)
+				}
+
+				c.actionInfo = *entityInfo
+				c.found = true
+
+				scope := MakeActionScope(ctx, entityInfo)
+				if done, err := runQuery(q, scope); err != nil {
+					return false, errors.Trace(err)
+				} else if done {
+					return true, nil
+				}
+
+				if isActionFinished(entityInfo.Status) {
+					return false, errors.Errorf("action %v %s without reaching the goal state", id, entityInfo.Status)
+				}
+			}
+		}
+
+		if !c.found {
+			logger.Infof("action %q not found, waiting...", id)
+			return false, nil
+		}
+
+		logger.Infof("action %q found, waiting...", id)
+		return false, nil
+	}
+}
+
+// isActionFinished returns whether an action with the given status will
+// no longer change.
+func isActionFinished(status string) bool {
+	switch status {
+	case params.ActionCompleted, params.ActionFailed, params.ActionCancelled, params.ActionAborted:
+		return true
+	}
+	return false
+}
+
+// ActionScope allows the query to introspect an action entity.
+type ActionScope struct {
+	ctx        ScopeContext
+	ActionInfo *params.ActionInfo
+}
+
+// MakeActionScope creates an ActionScope from an ActionInfo
+func MakeActionScope(ctx ScopeContext, info *params.ActionInfo) ActionScope {
+	return ActionScope{
+		ctx:        ctx,
+		ActionInfo: info,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m ActionScope) GetIdents() []string {
+	return append(getIdents(m.ActionInfo), "parameters", "results")
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m ActionScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "id":
+		return query.NewString(m.ActionInfo.Id), nil
+	case "receiver":
+		return query.NewString(m.ActionInfo.Receiver), nil
+	case "name":
+		return query.NewString(m.ActionInfo.Name), nil
+	case "status":
+		return query.NewString(m.ActionInfo.Status), nil
+	case "message":
+		return query.NewString(m.ActionInfo.Message), nil
+	case "parameters":
+		return query.NewMapStringInterface(m.ActionInfo.Parameters), nil
+	case "results":
+		return query.NewMapStringInterface(m.ActionInfo.Results), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name), "Runtime Error: identifier %q not found on ActionInfo", name)
+}
+
+func outputActionSummary(writer io.Writer, scopedContext ScopeContext, actionInfo *params.ActionInfo) {
+	result := struct {
+		Elements map[string]interface{} `yaml:"properties"`
+	}{
+		Elements: make(map[string]interface{}),
+	}
+
+	idents := scopedContext.RecordedIdents()
+	for _, ident := range idents {
+		scope := MakeActionScope(scopedContext, actionInfo)
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Elements[ident] = box.Value()
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
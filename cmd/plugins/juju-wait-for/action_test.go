@@ -0,0 +1,101 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/query"
+)
+
+type actionScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&actionScopeSuite{})
+
+func (s *actionScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field      string
+		ActionInfo *params.ActionInfo
+		Expected   query.Box
+	}{{
+		Field:      "id",
+		ActionInfo: &params.ActionInfo{Id: "42"},
+		Expected:   query.NewString("42"),
+	}, {
+		Field:      "receiver",
+		ActionInfo: &params.ActionInfo{Receiver: "mysql/0"},
+		Expected:   query.NewString("mysql/0"),
+	}, {
+		Field:      "name",
+		ActionInfo: &params.ActionInfo{Name: "backup"},
+		Expected:   query.NewString("backup"),
+	}, {
+		Field:      "status",
+		ActionInfo: &params.ActionInfo{Status: params.ActionCompleted},
+		Expected:   query.NewString("completed"),
+	}, {
+		Field:      "message",
+		ActionInfo: &params.ActionInfo{Message: "done"},
+		Expected:   query.NewString("done"),
+	}, {
+		Field:      "results",
+		ActionInfo: &params.ActionInfo{Results: map[string]interface{}{"code": "0"}},
+		Expected:   query.NewMapStringInterface(map[string]interface{}{"code": "0"}),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := ActionScope{
+			ctx:        MakeScopeContext(),
+			ActionInfo: test.ActionInfo,
+		}
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *actionScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := ActionScope{
+		ctx:        MakeScopeContext(),
+		ActionInfo: &params.ActionInfo{},
+	}
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `Runtime Error: identifier "bad" not found on ActionInfo: invalid identifier`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *actionScopeSuite) TestWaitForResult(c *gc.C) {
+	cmd := &actionCommand{}
+	q, err := query.Parse(`status=="completed" && results["code"]=="0"`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	done, err := cmd.waitFor(MakeScopeContext())("42", []params.Delta{{
+		Entity: &params.ActionInfo{Id: "42", Status: params.ActionCompleted, Results: map[string]interface{}{"code": "0"}},
+	}}, q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsTrue)
+}
+
+func (s *actionScopeSuite) TestWaitForFinishedWithoutGoal(c *gc.C) {
+	cmd := &actionCommand{}
+	q, err := query.Parse(`status=="completed"`)
+	c.Assert(err, jc.ErrorIsNil)
+	waitFor := cmd.waitFor(MakeScopeContext())
+
+	done, err := waitFor("42", []params.Delta{{
+		Entity: &params.ActionInfo{Id: "42", Status: params.ActionRunning},
+	}}, q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+
+	_, err = waitFor("42", []params.Delta{{
+		Entity: &params.ActionInfo{Id: "42", Status: params.ActionFailed},
+	}}, q)
+	c.Assert(err, gc.ErrorMatches, `action 42 failed without reaching the goal state`)
+}
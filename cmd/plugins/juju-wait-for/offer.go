@@ -0,0 +1,207 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/plugins/juju-wait-for/api"
+	"github.com/juju/juju/core/query"
+)
+
+func newOfferCommand() cmd.Command {
+	cmd := &offerCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return watchAllAPIShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const offerCommandDoc = `
+Wait for a given offer to reach a goal state.
+
+arguments:
+name
+   offer name identifier
+
+options:
+--query (= 'active-connected-count > 0')
+   query represents the goal state of a given offer
+`
+
+// offerCommand defines a command for waiting for offers.
+type offerCommand struct {
+	waitForCommandBase
+
+	name    string
+	query   string
+	timeout time.Duration
+	found   bool
+	summary bool
+
+	offerInfo params.ApplicationOfferInfo
+}
+
+// Info implements Command.Info.
+func (c *offerCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "offer",
+		Args:    "[<name>]",
+		Purpose: "wait for an offer to reach a goal state",
+		Doc:     offerCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `active-connected-count > 0`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the offer query on exit")
+}
+
+// Init implements Command.Init.
+func (c *offerCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("offer name must be supplied when waiting for an offer")
+	}
+	if len(args) != 1 {
+		return errors.New("only one offer name can be supplied as an argument to this command")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *offerCommand) Run(ctx *cmd.Context) error {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		if !c.summary || !c.found {
+			return
+		}
+
+		ctx.Infof("Offer %q is available", c.name)
+		outputOfferSummary(ctx.Stdout, scopedContext, &c.offerInfo)
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	err := strategy.Run(c.name, c.query, c.waitFor(scopedContext))
+	return errors.Trace(err)
+}
+
+func (c *offerCommand) waitFor(ctx ScopeContext) func(string, []params.Delta, query.Query) (bool, error) {
+	return func(name string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Tracef("delta %T: %v", delta.Entity, delta.Entity)
+
+			switch entityInfo := delta.Entity.(type) {
+			case *params.ApplicationOfferInfo:
+				if entityInfo.OfferName != name {
+					break
+				}
+
+				if delta.Removed {
+					return false, errors.Errorf("offer %v removed", name)
+				}
+
+				c.offerInfo = *entityInfo
+				c.found = true
+
+				scope := MakeOfferScope(ctx, entityInfo)
+				if done, err := runQuery(q, scope); err != nil {
+					return false, errors.Trace(err)
+				} else if done {
+					return true, nil
+				}
+			}
+		}
+
+		if !c.found {
+			logger.Infof("offer %q not found, waiting...", name)
+			return false, nil
+		}
+
+		logger.Infof("offer %q found, waiting...", name)
+		return false, nil
+	}
+}
+
+// OfferScope allows the query to introspect an offer entity.
+type OfferScope struct {
+	ctx       ScopeContext
+	OfferInfo *params.ApplicationOfferInfo
+}
+
+// MakeOfferScope creates an OfferScope from an ApplicationOfferInfo
+func MakeOfferScope(ctx ScopeContext, info *params.ApplicationOfferInfo) OfferScope {
+	return OfferScope{
+		ctx:       ctx,
+		OfferInfo: info,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m OfferScope) GetIdents() []string {
+	return getIdents(m.OfferInfo)
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m OfferScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "offer-name":
+		return query.NewString(m.OfferInfo.OfferName), nil
+	case "offer-uuid":
+		return query.NewString(m.OfferInfo.OfferUUID), nil
+	case "application-name":
+		return query.NewString(m.OfferInfo.ApplicationName), nil
+	case "charm-name":
+		return query.NewString(m.OfferInfo.CharmName), nil
+	case "total-connected-count":
+		return query.NewInteger(int64(m.OfferInfo.TotalConnectedCount)), nil
+	case "active-connected-count":
+		return query.NewInteger(int64(m.OfferInfo.ActiveConnectedCount)), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name), "Runtime Error: identifier %q not found on ApplicationOfferInfo", name)
+}
+
+func outputOfferSummary(writer io.Writer, scopedContext ScopeContext, offerInfo *params.ApplicationOfferInfo) {
+	result := struct {
+		Elements map[string]interface{} `yaml:"properties"`
+	}{
+		Elements: make(map[string]interface{}),
+	}
+
+	idents := scopedContext.RecordedIdents()
+	for _, ident := range idents {
+		scope := MakeOfferScope(scopedContext, offerInfo)
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Elements[ident] = box.Value()
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
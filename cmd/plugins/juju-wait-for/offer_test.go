@@ -0,0 +1,87 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/query"
+)
+
+type offerScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&offerScopeSuite{})
+
+func (s *offerScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field     string
+		OfferInfo *params.ApplicationOfferInfo
+		Expected  query.Box
+	}{{
+		Field:     "offer-name",
+		OfferInfo: &params.ApplicationOfferInfo{OfferName: "hosted-mysql"},
+		Expected:  query.NewString("hosted-mysql"),
+	}, {
+		Field:     "application-name",
+		OfferInfo: &params.ApplicationOfferInfo{ApplicationName: "mysql"},
+		Expected:  query.NewString("mysql"),
+	}, {
+		Field:     "charm-name",
+		OfferInfo: &params.ApplicationOfferInfo{CharmName: "mysql"},
+		Expected:  query.NewString("mysql"),
+	}, {
+		Field:     "total-connected-count",
+		OfferInfo: &params.ApplicationOfferInfo{TotalConnectedCount: 3},
+		Expected:  query.NewInteger(3),
+	}, {
+		Field:     "active-connected-count",
+		OfferInfo: &params.ApplicationOfferInfo{ActiveConnectedCount: 2},
+		Expected:  query.NewInteger(2),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := OfferScope{
+			ctx:       MakeScopeContext(),
+			OfferInfo: test.OfferInfo,
+		}
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *offerScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := OfferScope{
+		ctx:       MakeScopeContext(),
+		OfferInfo: &params.ApplicationOfferInfo{},
+	}
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `Runtime Error: identifier "bad" not found on ApplicationOfferInfo: invalid identifier`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *offerScopeSuite) TestWaitForConnections(c *gc.C) {
+	cmd := &offerCommand{}
+	q, err := query.Parse(`active-connected-count > 0`)
+	c.Assert(err, jc.ErrorIsNil)
+	waitFor := cmd.waitFor(MakeScopeContext())
+
+	done, err := waitFor("hosted-mysql", []params.Delta{{
+		Entity: &params.ApplicationOfferInfo{OfferName: "hosted-mysql"},
+	}}, q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+	c.Assert(cmd.found, jc.IsTrue)
+
+	done, err = waitFor("hosted-mysql", []params.Delta{{
+		Entity: &params.ApplicationOfferInfo{OfferName: "hosted-mysql", ActiveConnectedCount: 1},
+	}}, q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsTrue)
+}
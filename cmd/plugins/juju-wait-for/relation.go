@@ -0,0 +1,303 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/query"
+)
+
+// StatusAPI defines the API methods used to poll for the status of
+// entities which aren't reported by the AllWatcher.
+type StatusAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	Close() error
+}
+
+func newRelationCommand() cmd.Command {
+	cmd := &relationCommand{}
+	cmd.newStatusAPIFunc = func() (StatusAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return client, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const relationCommandDoc = `
+Wait for a given relation to reach a goal state.
+
+The relation is identified either by its id, or by the endpoints it relates,
+given as <application>[:<relation name>]. The number of units which have
+joined the relation is available as unit-count:
+
+    juju wait-for relation mysql wordpress:db --query='unit-count >= 2'
+
+arguments:
+id | endpoints
+   relation id or endpoints
+
+options:
+--query (= 'status=="joined"')
+   query represents the goal state of a given relation
+`
+
+// relationCommand defines a command for waiting for relations.
+type relationCommand struct {
+	waitForCommandBase
+
+	newStatusAPIFunc func() (StatusAPI, error)
+
+	name      string
+	id        int
+	endpoints []string
+	query     string
+	timeout   time.Duration
+	found     bool
+	summary   bool
+
+	relationStatus params.RelationStatus
+}
+
+// Info implements Command.Info.
+func (c *relationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "relation",
+		Args:    "<id> | <endpoint> [<endpoint>]",
+		Purpose: "wait for a relation to reach a goal state",
+		Doc:     relationCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *relationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `status=="joined"`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the relation query on exit")
+}
+
+// Init implements Command.Init.
+func (c *relationCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("relation id or endpoints must be supplied when waiting for a relation")
+	}
+	if len(args) > 2 {
+		return errors.New("at most two endpoints can be supplied as arguments to this command")
+	}
+	c.id = -1
+	if len(args) == 1 {
+		if id, err := strconv.Atoi(args[0]); err == nil {
+			if id < 0 {
+				return errors.Errorf("%q is not valid relation id", args[0])
+			}
+			c.id = id
+		}
+	}
+	if c.id < 0 {
+		for _, arg := range args {
+			if arg == "" || strings.HasPrefix(arg, ":") || strings.HasSuffix(arg, ":") {
+				return errors.Errorf("%q is not valid endpoint", arg)
+			}
+		}
+		c.endpoints = args
+	}
+	c.name = strings.Join(args, " ")
+
+	return nil
+}
+
+func (c *relationCommand) Run(ctx *cmd.Context) error {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		if !c.summary || !c.found {
+			return
+		}
+
+		ctx.Infof("Relation %q is %s", c.relationStatus.Key, c.relationStatus.Status.Status)
+		outputRelationSummary(ctx.Stdout, scopedContext, &c.relationStatus)
+	}()
+
+	client, err := c.newStatusAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	strategy := &PollStrategy{
+		Clock:   clock.WallClock,
+		Delay:   5 * time.Second,
+		Timeout: c.timeout,
+	}
+	err = strategy.Run(c.name, c.query, c.waitFor(client, scopedContext))
+	return errors.Trace(err)
+}
+
+func (c *relationCommand) waitFor(client StatusAPI, ctx ScopeContext) PollFunc {
+	return func(name string, q query.Query) (bool, error) {
+		status, err := client.Status(nil)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+
+		relation, err := c.findRelation(status.Relations)
+		if errors.IsNotFound(err) {
+			if c.found {
+				return false, errors.Errorf("relation %v removed", name)
+			}
+			logger.Infof("relation %q not found, waiting...", name)
+			return false, nil
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+
+		c.relationStatus = relation
+		c.found = true
+
+		scope := MakeRelationScope(ctx, &relation)
+		if done, err := runQuery(q, scope); err != nil {
+			return false, errors.Trace(err)
+		} else if done {
+			return true, nil
+		}
+
+		logger.Infof("relation %q found, waiting...", name)
+		return false, nil
+	}
+}
+
+// findRelation returns the relation with the id or endpoints given to
+// the command.
+func (c *relationCommand) findRelation(relations []params.RelationStatus) (params.RelationStatus, error) {
+	var matched []params.RelationStatus
+	for _, relation := range relations {
+		if c.id >= 0 {
+			if relation.Id == c.id {
+				return relation, nil
+			}
+			continue
+		}
+		if endpointsMatch(c.endpoints, relation.Endpoints) {
+			matched = append(matched, relation)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return params.RelationStatus{}, errors.NotFoundf("relation %q", c.name)
+	case 1:
+		return matched[0], nil
+	}
+	return params.RelationStatus{}, errors.Errorf("ambiguous relation %q matches %d relations, use the relation id or add relation names", c.name, len(matched))
+}
+
+// endpointsMatch returns whether each of the endpoint arguments matches a
+// different endpoint of a relation.
+func endpointsMatch(args []string, endpoints []params.EndpointStatus) bool {
+	if len(args) > len(endpoints) {
+		return false
+	}
+	used := make([]bool, len(endpoints))
+	var match func(int) bool
+	match = func(i int) bool {
+		if i == len(args) {
+			return true
+		}
+		application, name := args[i], ""
+		if parts := strings.SplitN(args[i], ":", 2); len(parts) == 2 {
+			application, name = parts[0], parts[1]
+		}
+		for j, ep := range endpoints {
+			if used[j] || ep.ApplicationName != application || (name != "" && ep.Name != name) {
+				continue
+			}
+			used[j] = true
+			if match(i + 1) {
+				return true
+			}
+			used[j] = false
+		}
+		return false
+	}
+	return match(0)
+}
+
+// RelationScope allows the query to introspect a relation entity.
+type RelationScope struct {
+	ctx            ScopeContext
+	RelationStatus *params.RelationStatus
+}
+
+// MakeRelationScope creates a RelationScope from a RelationStatus
+func MakeRelationScope(ctx ScopeContext, status *params.RelationStatus) RelationScope {
+	return RelationScope{
+		ctx:            ctx,
+		RelationStatus: status,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m RelationScope) GetIdents() []string {
+	return []string{"id", "key", "interface", "scope", "status", "message", "unit-count"}
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m RelationScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "id":
+		return query.NewInteger(int64(m.RelationStatus.Id)), nil
+	case "key":
+		return query.NewString(m.RelationStatus.Key), nil
+	case "interface":
+		return query.NewString(m.RelationStatus.Interface), nil
+	case "scope":
+		return query.NewString(m.RelationStatus.Scope), nil
+	case "status":
+		return query.NewString(m.RelationStatus.Status.Status), nil
+	case "message":
+		return query.NewString(m.RelationStatus.Status.Info), nil
+	case "unit-count":
+		return query.NewInteger(int64(m.RelationStatus.UnitCount)), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name), "Runtime Error: identifier %q not found on RelationStatus", name)
+}
+
+func outputRelationSummary(writer io.Writer, scopedContext ScopeContext, relationStatus *params.RelationStatus) {
+	result := struct {
+		Elements map[string]interface{} `yaml:"properties"`
+	}{
+		Elements: make(map[string]interface{}),
+	}
+
+	idents := scopedContext.RecordedIdents()
+	for _, ident := range idents {
+		scope := MakeRelationScope(scopedContext, relationStatus)
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Elements[ident] = box.Value()
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
@@ -0,0 +1,171 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/query"
+)
+
+type relationScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&relationScopeSuite{})
+
+func (s *relationScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field          string
+		RelationStatus *params.RelationStatus
+		Expected       query.Box
+	}{{
+		Field:          "id",
+		RelationStatus: &params.RelationStatus{Id: 3},
+		Expected:       query.NewInteger(3),
+	}, {
+		Field:          "key",
+		RelationStatus: &params.RelationStatus{Key: "mysql:server wordpress:db"},
+		Expected:       query.NewString("mysql:server wordpress:db"),
+	}, {
+		Field:          "interface",
+		RelationStatus: &params.RelationStatus{Interface: "mysql"},
+		Expected:       query.NewString("mysql"),
+	}, {
+		Field:          "scope",
+		RelationStatus: &params.RelationStatus{Scope: "global"},
+		Expected:       query.NewString("global"),
+	}, {
+		Field:          "status",
+		RelationStatus: &params.RelationStatus{Status: params.DetailedStatus{Status: "joined"}},
+		Expected:       query.NewString("joined"),
+	}, {
+		Field:          "message",
+		RelationStatus: &params.RelationStatus{Status: params.DetailedStatus{Info: "broken"}},
+		Expected:       query.NewString("broken"),
+	}, {
+		Field:          "unit-count",
+		RelationStatus: &params.RelationStatus{UnitCount: 2},
+		Expected:       query.NewInteger(2),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := RelationScope{
+			ctx:            MakeScopeContext(),
+			RelationStatus: test.RelationStatus,
+		}
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *relationScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := RelationScope{
+		ctx:            MakeScopeContext(),
+		RelationStatus: &params.RelationStatus{},
+	}
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `Runtime Error: identifier "bad" not found on RelationStatus: invalid identifier`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *relationScopeSuite) TestInit(c *gc.C) {
+	cmd := &relationCommand{}
+	err := cmdtesting.InitCommand(cmd, []string{"3"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmd.id, gc.Equals, 3)
+	c.Check(cmd.endpoints, gc.HasLen, 0)
+
+	cmd = &relationCommand{}
+	err = cmdtesting.InitCommand(cmd, []string{"mysql", "wordpress:db"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmd.id, gc.Equals, -1)
+	c.Check(cmd.endpoints, jc.DeepEquals, []string{"mysql", "wordpress:db"})
+
+	err = cmdtesting.InitCommand(&relationCommand{}, nil)
+	c.Assert(err, gc.ErrorMatches, "relation id or endpoints must be supplied when waiting for a relation")
+	err = cmdtesting.InitCommand(&relationCommand{}, []string{"a", "b", "c"})
+	c.Assert(err, gc.ErrorMatches, "at most two endpoints can be supplied as arguments to this command")
+	err = cmdtesting.InitCommand(&relationCommand{}, []string{"mysql:"})
+	c.Assert(err, gc.ErrorMatches, `"mysql:" is not valid endpoint`)
+}
+
+func (s *relationScopeSuite) TestWaitFor(c *gc.C) {
+	client := &fakeStatusAPI{status: &params.FullStatus{}}
+	cmd := &relationCommand{id: -1, endpoints: []string{"wordpress", "mysql:server"}}
+	q, err := query.Parse(`status=="joined" && unit-count >= 2`)
+	c.Assert(err, jc.ErrorIsNil)
+	waitFor := cmd.waitFor(client, MakeScopeContext())
+
+	done, err := waitFor("wordpress mysql:server", q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+	c.Assert(cmd.found, jc.IsFalse)
+
+	relation := params.RelationStatus{
+		Id:  1,
+		Key: "mysql:server wordpress:db",
+		Endpoints: []params.EndpointStatus{
+			{ApplicationName: "mysql", Name: "server"},
+			{ApplicationName: "wordpress", Name: "db"},
+		},
+		Status:    params.DetailedStatus{Status: "joined"},
+		UnitCount: 1,
+	}
+	client.status.Relations = []params.RelationStatus{relation}
+	done, err = waitFor("wordpress mysql:server", q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+	c.Assert(cmd.found, jc.IsTrue)
+
+	client.status.Relations[0].UnitCount = 2
+	done, err = waitFor("wordpress mysql:server", q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsTrue)
+
+	client.status.Relations = nil
+	_, err = waitFor("wordpress mysql:server", q)
+	c.Assert(err, gc.ErrorMatches, `relation wordpress mysql:server removed`)
+}
+
+func (s *relationScopeSuite) TestWaitForAmbiguous(c *gc.C) {
+	client := &fakeStatusAPI{status: &params.FullStatus{
+		Relations: []params.RelationStatus{{
+			Id: 1,
+			Endpoints: []params.EndpointStatus{
+				{ApplicationName: "mysql", Name: "server"},
+				{ApplicationName: "wordpress", Name: "db"},
+			},
+		}, {
+			Id: 2,
+			Endpoints: []params.EndpointStatus{
+				{ApplicationName: "mysql", Name: "server-admin"},
+				{ApplicationName: "wordpress", Name: "admin-db"},
+			},
+		}},
+	}}
+	cmd := &relationCommand{id: -1, name: "mysql wordpress", endpoints: []string{"mysql", "wordpress"}}
+	q, err := query.Parse(`status=="joined"`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmd.waitFor(client, MakeScopeContext())("mysql wordpress", q)
+	c.Assert(err, gc.ErrorMatches, `ambiguous relation "mysql wordpress" matches 2 relations, use the relation id or add relation names`)
+}
+
+type fakeStatusAPI struct {
+	status *params.FullStatus
+}
+
+func (f *fakeStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return f.status, nil
+}
+
+func (f *fakeStatusAPI) Close() error {
+	return nil
+}
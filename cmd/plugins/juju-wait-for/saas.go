@@ -0,0 +1,218 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/plugins/juju-wait-for/api"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/query"
+)
+
+func newSAASCommand() cmd.Command {
+	cmd := &saasCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return watchAllAPIShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const saasCommandDoc = `
+Wait for a given SAAS (an offer consumed by the model) to reach a goal state.
+
+arguments:
+name
+   SAAS name identifier
+
+options:
+--query (= 'life=="alive" && status=="active"')
+   query represents the goal state of a given SAAS
+`
+
+// saasCommand defines a command for waiting for consumed offers.
+type saasCommand struct {
+	waitForCommandBase
+
+	name    string
+	query   string
+	timeout time.Duration
+	found   bool
+	summary bool
+
+	remoteAppInfo params.RemoteApplicationUpdate
+}
+
+// Info implements Command.Info.
+func (c *saasCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "saas",
+		Args:    "[<name>]",
+		Purpose: "wait for a SAAS to reach a goal state",
+		Doc:     saasCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *saasCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `life=="alive" && status=="active"`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the SAAS query on exit")
+}
+
+// Init implements Command.Init.
+func (c *saasCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("SAAS name must be supplied when waiting for a SAAS")
+	}
+	if len(args) != 1 {
+		return errors.New("only one SAAS name can be supplied as an argument to this command")
+	}
+	if ok := names.IsValidApplication(args[0]); !ok {
+		return errors.Errorf("%q is not valid SAAS name", args[0])
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *saasCommand) Run(ctx *cmd.Context) error {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		if !c.summary {
+			return
+		}
+
+		switch c.remoteAppInfo.Life {
+		case life.Dead:
+			ctx.Infof("SAAS %q has been removed", c.name)
+		case life.Dying:
+			ctx.Infof("SAAS %q is being removed", c.name)
+		default:
+			ctx.Infof("SAAS %q is running", c.name)
+			outputSAASSummary(ctx.Stdout, scopedContext, &c.remoteAppInfo)
+		}
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	err := strategy.Run(c.name, c.query, c.waitFor(scopedContext))
+	return errors.Trace(err)
+}
+
+func (c *saasCommand) waitFor(ctx ScopeContext) func(string, []params.Delta, query.Query) (bool, error) {
+	return func(name string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Tracef("delta %T: %v", delta.Entity, delta.Entity)
+
+			switch entityInfo := delta.Entity.(type) {
+			case *params.RemoteApplicationUpdate:
+				if entityInfo.Name != name {
+					break
+				}
+
+				if delta.Removed {
+					return false, errors.Errorf("SAAS %v removed", name)
+				}
+
+				c.remoteAppInfo = *entityInfo
+
+				scope := MakeSAASScope(ctx, entityInfo)
+				if done, err := runQuery(q, scope); err != nil {
+					return false, errors.Trace(err)
+				} else if done {
+					return true, nil
+				}
+
+				c.found = entityInfo.Life != life.Dead
+			}
+		}
+
+		if !c.found {
+			logger.Infof("SAAS %q not found, waiting...", name)
+			return false, nil
+		}
+
+		logger.Infof("SAAS %q found, waiting...", name)
+		return false, nil
+	}
+}
+
+// SAASScope allows the query to introspect a remote application entity.
+type SAASScope struct {
+	ctx           ScopeContext
+	RemoteAppInfo *params.RemoteApplicationUpdate
+}
+
+// MakeSAASScope creates a SAASScope from a RemoteApplicationUpdate
+func MakeSAASScope(ctx ScopeContext, info *params.RemoteApplicationUpdate) SAASScope {
+	return SAASScope{
+		ctx:           ctx,
+		RemoteAppInfo: info,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m SAASScope) GetIdents() []string {
+	return getIdents(m.RemoteAppInfo)
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m SAASScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "name":
+		return query.NewString(m.RemoteAppInfo.Name), nil
+	case "offer-uuid":
+		return query.NewString(m.RemoteAppInfo.OfferUUID), nil
+	case "offer-url":
+		return query.NewString(m.RemoteAppInfo.OfferURL), nil
+	case "life":
+		return query.NewString(string(m.RemoteAppInfo.Life)), nil
+	case "status":
+		return query.NewString(string(m.RemoteAppInfo.Status.Current)), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name), "Runtime Error: identifier %q not found on RemoteApplicationUpdate", name)
+}
+
+func outputSAASSummary(writer io.Writer, scopedContext ScopeContext, remoteAppInfo *params.RemoteApplicationUpdate) {
+	result := struct {
+		Elements map[string]interface{} `yaml:"properties"`
+	}{
+		Elements: make(map[string]interface{}),
+	}
+
+	idents := scopedContext.RecordedIdents()
+	for _, ident := range idents {
+		scope := MakeSAASScope(scopedContext, remoteAppInfo)
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Elements[ident] = box.Value()
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/query"
+	"github.com/juju/juju/core/status"
+)
+
+type saasScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&saasScopeSuite{})
+
+func (s *saasScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field         string
+		RemoteAppInfo *params.RemoteApplicationUpdate
+		Expected      query.Box
+	}{{
+		Field:         "name",
+		RemoteAppInfo: &params.RemoteApplicationUpdate{Name: "mysql"},
+		Expected:      query.NewString("mysql"),
+	}, {
+		Field:         "offer-url",
+		RemoteAppInfo: &params.RemoteApplicationUpdate{OfferURL: "admin/default.mysql"},
+		Expected:      query.NewString("admin/default.mysql"),
+	}, {
+		Field:         "life",
+		RemoteAppInfo: &params.RemoteApplicationUpdate{Life: life.Alive},
+		Expected:      query.NewString("alive"),
+	}, {
+		Field: "status",
+		RemoteAppInfo: &params.RemoteApplicationUpdate{Status: params.StatusInfo{
+			Current: status.Active,
+		}},
+		Expected: query.NewString("active"),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := SAASScope{
+			ctx:           MakeScopeContext(),
+			RemoteAppInfo: test.RemoteAppInfo,
+		}
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *saasScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := SAASScope{
+		ctx:           MakeScopeContext(),
+		RemoteAppInfo: &params.RemoteApplicationUpdate{},
+	}
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `Runtime Error: identifier "bad" not found on RemoteApplicationUpdate: invalid identifier`)
+	c.Assert(result, gc.IsNil)
+}
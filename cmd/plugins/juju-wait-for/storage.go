@@ -0,0 +1,240 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"gopkg.in/yaml.v2"
+
+	storageapi "github.com/juju/juju/api/storage"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/query"
+)
+
+// StorageAPI defines the API methods used to poll for the details of
+// storage instances.
+type StorageAPI interface {
+	StorageDetails(tags []names.StorageTag) ([]params.StorageDetailsResult, error)
+	Close() error
+}
+
+func newStorageCommand() cmd.Command {
+	cmd := &storageCommand{}
+	cmd.newStorageAPIFunc = func() (StorageAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return storageapi.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const storageCommandDoc = `
+Wait for a given storage instance to reach a goal state.
+
+arguments:
+id
+   storage instance id
+
+options:
+--query (= 'status=="attached"')
+   query represents the goal state of a given storage instance
+`
+
+// storageCommand defines a command for waiting for storage instances.
+type storageCommand struct {
+	waitForCommandBase
+
+	newStorageAPIFunc func() (StorageAPI, error)
+
+	id      string
+	query   string
+	timeout time.Duration
+	found   bool
+	summary bool
+
+	storageDetails params.StorageDetails
+}
+
+// Info implements Command.Info.
+func (c *storageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "storage",
+		Args:    "[<id>]",
+		Purpose: "wait for a storage instance to reach a goal state",
+		Doc:     storageCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *storageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `status=="attached"`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the storage query on exit")
+}
+
+// Init implements Command.Init.
+func (c *storageCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("storage id must be supplied when waiting for a storage instance")
+	}
+	if len(args) != 1 {
+		return errors.New("only one storage id can be supplied as an argument to this command")
+	}
+	if ok := names.IsValidStorage(args[0]); !ok {
+		return errors.Errorf("%q is not valid storage id", args[0])
+	}
+	c.id = args[0]
+
+	return nil
+}
+
+func (c *storageCommand) Run(ctx *cmd.Context) error {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		if !c.summary || !c.found {
+			return
+		}
+
+		ctx.Infof("Storage %q is %s", c.id, c.storageDetails.Status.Status)
+		outputStorageSummary(ctx.Stdout, scopedContext, &c.storageDetails)
+	}()
+
+	client, err := c.newStorageAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	strategy := &PollStrategy{
+		Clock:   clock.WallClock,
+		Delay:   5 * time.Second,
+		Timeout: c.timeout,
+	}
+	err = strategy.Run(c.id, c.query, c.waitFor(client, scopedContext))
+	return errors.Trace(err)
+}
+
+func (c *storageCommand) waitFor(client StorageAPI, ctx ScopeContext) PollFunc {
+	return func(id string, q query.Query) (bool, error) {
+		results, err := client.StorageDetails([]names.StorageTag{names.NewStorageTag(id)})
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if len(results) != 1 {
+			return false, errors.Errorf("expected 1 result, got %d", len(results))
+		}
+		if err := results[0].Error; err != nil {
+			if params.IsCodeNotFound(err) {
+				if c.found {
+					return false, errors.Errorf("storage %v removed", id)
+				}
+				logger.Infof("storage %q not found, waiting...", id)
+				return false, nil
+			}
+			return false, errors.Trace(err)
+		}
+
+		c.storageDetails = *results[0].Result
+		c.found = true
+
+		scope := MakeStorageScope(ctx, &c.storageDetails)
+		if done, err := runQuery(q, scope); err != nil {
+			return false, errors.Trace(err)
+		} else if done {
+			return true, nil
+		}
+
+		logger.Infof("storage %q found, waiting...", id)
+		return false, nil
+	}
+}
+
+// StorageScope allows the query to introspect a storage entity.
+type StorageScope struct {
+	ctx            ScopeContext
+	StorageDetails *params.StorageDetails
+}
+
+// MakeStorageScope creates a StorageScope from a StorageDetails
+func MakeStorageScope(ctx ScopeContext, details *params.StorageDetails) StorageScope {
+	return StorageScope{
+		ctx:            ctx,
+		StorageDetails: details,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m StorageScope) GetIdents() []string {
+	return []string{"id", "kind", "owner", "status", "message", "life", "persistent", "attachment-count"}
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m StorageScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "id":
+		return query.NewString(tagId(m.StorageDetails.StorageTag)), nil
+	case "kind":
+		return query.NewString(m.StorageDetails.Kind.String()), nil
+	case "owner":
+		return query.NewString(tagId(m.StorageDetails.OwnerTag)), nil
+	case "status":
+		return query.NewString(string(m.StorageDetails.Status.Status)), nil
+	case "message":
+		return query.NewString(m.StorageDetails.Status.Info), nil
+	case "life":
+		return query.NewString(string(m.StorageDetails.Life)), nil
+	case "persistent":
+		return query.NewBool(m.StorageDetails.Persistent), nil
+	case "attachment-count":
+		return query.NewInteger(int64(len(m.StorageDetails.Attachments))), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name), "Runtime Error: identifier %q not found on StorageDetails", name)
+}
+
+// tagId returns the id of the entity with the given tag, or the tag
+// itself if it isn't valid.
+func tagId(tag string) string {
+	t, err := names.ParseTag(tag)
+	if err != nil {
+		return tag
+	}
+	return t.Id()
+}
+
+func outputStorageSummary(writer io.Writer, scopedContext ScopeContext, storageDetails *params.StorageDetails) {
+	result := struct {
+		Elements map[string]interface{} `yaml:"properties"`
+	}{
+		Elements: make(map[string]interface{}),
+	}
+
+	idents := scopedContext.RecordedIdents()
+	for _, ident := range idents {
+		scope := MakeStorageScope(scopedContext, storageDetails)
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Elements[ident] = box.Value()
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/query"
+)
+
+type storageScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&storageScopeSuite{})
+
+func (s *storageScopeSuite) TestGetIdentValue(c *gc.C) {
+	tests := []struct {
+		Field          string
+		StorageDetails *params.StorageDetails
+		Expected       query.Box
+	}{{
+		Field:          "id",
+		StorageDetails: &params.StorageDetails{StorageTag: "storage-data-0"},
+		Expected:       query.NewString("data/0"),
+	}, {
+		Field:          "kind",
+		StorageDetails: &params.StorageDetails{Kind: params.StorageKindFilesystem},
+		Expected:       query.NewString("filesystem"),
+	}, {
+		Field:          "owner",
+		StorageDetails: &params.StorageDetails{OwnerTag: "unit-postgresql-0"},
+		Expected:       query.NewString("postgresql/0"),
+	}, {
+		Field:          "status",
+		StorageDetails: &params.StorageDetails{Status: params.EntityStatus{Status: "attached"}},
+		Expected:       query.NewString("attached"),
+	}, {
+		Field:          "message",
+		StorageDetails: &params.StorageDetails{Status: params.EntityStatus{Info: "waiting for machine"}},
+		Expected:       query.NewString("waiting for machine"),
+	}, {
+		Field:          "life",
+		StorageDetails: &params.StorageDetails{Life: life.Alive},
+		Expected:       query.NewString("alive"),
+	}, {
+		Field:          "persistent",
+		StorageDetails: &params.StorageDetails{Persistent: true},
+		Expected:       query.NewBool(true),
+	}, {
+		Field: "attachment-count",
+		StorageDetails: &params.StorageDetails{Attachments: map[string]params.StorageAttachmentDetails{
+			"unit-postgresql-0": {},
+		}},
+		Expected: query.NewInteger(1),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := StorageScope{
+			ctx:            MakeScopeContext(),
+			StorageDetails: test.StorageDetails,
+		}
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *storageScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := StorageScope{
+		ctx:            MakeScopeContext(),
+		StorageDetails: &params.StorageDetails{},
+	}
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `Runtime Error: identifier "bad" not found on StorageDetails: invalid identifier`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *storageScopeSuite) TestWaitFor(c *gc.C) {
+	client := &fakeStorageAPI{
+		result: params.StorageDetailsResult{
+			Error: &params.Error{Code: params.CodeNotFound, Message: "storage data/0 not found"},
+		},
+	}
+	cmd := &storageCommand{}
+	q, err := query.Parse(`status=="attached"`)
+	c.Assert(err, jc.ErrorIsNil)
+	waitFor := cmd.waitFor(client, MakeScopeContext())
+
+	done, err := waitFor("data/0", q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+	c.Assert(cmd.found, jc.IsFalse)
+	c.Assert(client.tags, jc.DeepEquals, []names.StorageTag{names.NewStorageTag("data/0")})
+
+	client.result = params.StorageDetailsResult{Result: &params.StorageDetails{
+		StorageTag: "storage-data-0",
+		Status:     params.EntityStatus{Status: "pending"},
+	}}
+	done, err = waitFor("data/0", q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+	c.Assert(cmd.found, jc.IsTrue)
+
+	client.result.Result.Status.Status = "attached"
+	done, err = waitFor("data/0", q)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsTrue)
+}
+
+type fakeStorageAPI struct {
+	tags   []names.StorageTag
+	result params.StorageDetailsResult
+}
+
+func (f *fakeStorageAPI) StorageDetails(tags []names.StorageTag) ([]params.StorageDetailsResult, error) {
+	f.tags = tags
+	return []params.StorageDetailsResult{f.result}, nil
+}
+
+func (f *fakeStorageAPI) Close() error {
+	return nil
+}
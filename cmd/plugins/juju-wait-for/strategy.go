@@ -15,7 +15,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/plugins/juju-wait-for/api"
-	"github.com/juju/juju/cmd/plugins/juju-wait-for/query"
+	"github.com/juju/juju/core/query"
 	"github.com/juju/juju/rpc"
 )
 
@@ -114,6 +114,54 @@ func (s *Strategy) run(q query.Query, name string, input string, fn StrategyFunc
 	}
 }
 
+// PollFunc defines a way to fetch the state of an entity and run the query
+// against it, reporting whether the goal state was reached.
+type PollFunc func(string, query.Query) (bool, error)
+
+// errGoalNotReached is returned by a poll which didn't reach the goal state,
+// so that it is retried.
+var errGoalNotReached = errors.New("goal state not reached")
+
+// PollStrategy defines a series of instructions to run for a given wait for
+// plan, for entities which aren't reported by the AllWatcher. Their state is
+// polled instead.
+type PollStrategy struct {
+	Clock   clock.Clock
+	Delay   time.Duration
+	Timeout time.Duration
+}
+
+// Run the strategy until the goal state is reached or the timeout expires.
+func (s *PollStrategy) Run(name string, input string, fn PollFunc) error {
+	q, err := query.Parse(input)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = retry.Call(retry.CallArgs{
+		Clock:       s.Clock,
+		Delay:       s.Delay,
+		MaxDuration: s.Timeout,
+		Func: func() error {
+			done, err := fn(name, q)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !done {
+				return errGoalNotReached
+			}
+			return nil
+		},
+		IsFatalError: func(err error) bool {
+			return errors.Cause(err) != errGoalNotReached
+		},
+	})
+	if retry.IsDurationExceeded(err) {
+		return errors.Errorf("timed out waiting for %q to reach goal state", name)
+	}
+	return errors.Trace(err)
+}
+
 func (s *Strategy) dispatch(event EventType) {
 	for _, fn := range s.subscribers {
 		fn(event)
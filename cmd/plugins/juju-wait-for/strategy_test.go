@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/clock"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -14,7 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/plugins/juju-wait-for/api"
 	"github.com/juju/juju/cmd/plugins/juju-wait-for/api/mocks"
-	"github.com/juju/juju/cmd/plugins/juju-wait-for/query"
+	"github.com/juju/juju/core/query"
 )
 
 type strategySuite struct {
@@ -112,6 +114,46 @@ func (s *strategySuite) TestRunWithInvalidQuery(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `Syntax Error:<:1:7> invalid character '<UNKNOWN>' found`)
 }
 
+func (s *strategySuite) TestPollRun(c *gc.C) {
+	var polls int
+	strategy := PollStrategy{
+		Clock:   clock.WallClock,
+		Delay:   time.Millisecond,
+		Timeout: time.Minute,
+	}
+	err := strategy.Run("generic", `life=="active"`, func(name string, _ query.Query) (bool, error) {
+		c.Check(name, gc.Equals, "generic")
+		polls++
+		return polls == 3, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(polls, gc.Equals, 3)
+}
+
+func (s *strategySuite) TestPollRunError(c *gc.C) {
+	strategy := PollStrategy{
+		Clock:   clock.WallClock,
+		Delay:   time.Millisecond,
+		Timeout: time.Minute,
+	}
+	err := strategy.Run("generic", `life=="active"`, func(string, query.Query) (bool, error) {
+		return false, errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *strategySuite) TestPollRunTimeout(c *gc.C) {
+	strategy := PollStrategy{
+		Clock:   clock.WallClock,
+		Delay:   time.Millisecond,
+		Timeout: 10 * time.Millisecond,
+	}
+	err := strategy.Run("generic", `life=="active"`, func(string, query.Query) (bool, error) {
+		return false, nil
+	})
+	c.Assert(err, gc.ErrorMatches, `timed out waiting for "generic" to reach goal state`)
+}
+
 type MockEntityInfo struct {
 	Name    string `json:"name"`
 	Integer int    `json:"int"`
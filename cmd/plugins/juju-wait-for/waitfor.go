@@ -47,9 +47,14 @@ func NewSuperCommand() cmd.Command {
 		Purpose:     "tools for generating and validating image and tools metadata",
 		Log:         &cmd.Log{}})
 
+	waitFor.Register(newActionCommand())
 	waitFor.Register(newApplicationCommand())
 	waitFor.Register(newMachineCommand())
 	waitFor.Register(newModelCommand())
+	waitFor.Register(newOfferCommand())
+	waitFor.Register(newRelationCommand())
+	waitFor.Register(newSAASCommand())
+	waitFor.Register(newStorageCommand())
 	waitFor.Register(newUnitCommand())
 	return waitFor
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
@@ -17,8 +18,11 @@ import (
 	"github.com/juju/romulus"
 	"github.com/juju/schema"
 	"github.com/juju/utils/v2"
+	"golang.org/x/crypto/openpgp"
 	"gopkg.in/juju/environschema.v1"
 
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/cron"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/pki"
 )
@@ -82,6 +86,52 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogSyslogAddress is the address of a syslog server that
+	// audit log entries are also sent to, eg "tcp://10.0.0.1:601".
+	AuditLogSyslogAddress = "audit-log-syslog-address"
+
+	// AuditLogWebhookURL is the http(s) URL that batches of audit log
+	// entries are also posted to.
+	AuditLogWebhookURL = "audit-log-webhook-url"
+
+	// AuditLogWebhookBatchSize is the maximum number of audit log
+	// entries posted to the webhook in a single request.
+	AuditLogWebhookBatchSize = "audit-log-webhook-batch-size"
+
+	// AuditLogWebhookBufferSize is the maximum size of the on-disk
+	// buffer of audit log entries waiting to be posted to the
+	// webhook, eg "100M".
+	AuditLogWebhookBufferSize = "audit-log-webhook-buffer-size"
+
+	// TracingOTLPEndpoint is the http(s) URL of an OpenTelemetry
+	// collector that spans recording traced API requests are sent to.
+	TracingOTLPEndpoint = "tracing-otlp-endpoint"
+
+	// BackupSchedule is the cron-like schedule, eg "30 2 * * *", on
+	// which the controller creates backups of itself. Scheduled
+	// backups are disabled if it is empty.
+	BackupSchedule = "backup-schedule"
+
+	// BackupKeepDaily is the number of days for which the newest
+	// scheduled backup of the day is kept.
+	BackupKeepDaily = "backup-keep-daily"
+
+	// BackupKeepWeekly is the number of weeks for which the newest
+	// scheduled backup of the week is kept.
+	BackupKeepWeekly = "backup-keep-weekly"
+
+	// BackupStorage is the URL of the location where backup archives
+	// are stored, eg "file:///srv/juju-backups" for a local or NFS
+	// directory, or "s3://bucket/prefix?endpoint=host:port&region=r"
+	// for an S3-compatible object store. Archives are stored in the
+	// controller database if it is empty.
+	BackupStorage = "backup-storage"
+
+	// BackupEncryptionPublicKey is an armored OpenPGP public key ring.
+	// If it is set, backup archives are encrypted so that they can be
+	// decrypted with the private key of any of its keys.
+	BackupEncryptionPublicKey = "backup-encryption-public-key"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -221,6 +271,23 @@ const (
 	// roll the audit log file.
 	DefaultAuditLogMaxSizeMB = 300
 
+	// DefaultAuditLogWebhookBatchSize is the default maximum number
+	// of audit log entries posted to the webhook in one request.
+	DefaultAuditLogWebhookBatchSize = 100
+
+	// DefaultAuditLogWebhookBufferSizeMB is the default maximum size
+	// in MB of the buffer of entries waiting to be posted to the
+	// webhook.
+	DefaultAuditLogWebhookBufferSizeMB = 100
+
+	// DefaultBackupKeepDaily is the default number of daily scheduled
+	// backups to keep.
+	DefaultBackupKeepDaily = 7
+
+	// DefaultBackupKeepWeekly is the default number of weekly
+	// scheduled backups to keep.
+	DefaultBackupKeepWeekly = 4
+
 	// DefaultAuditLogMaxBackups is the default number of files to
 	// keep.
 	DefaultAuditLogMaxBackups = 10
@@ -358,6 +425,16 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogSyslogAddress,
+		AuditLogWebhookURL,
+		AuditLogWebhookBatchSize,
+		AuditLogWebhookBufferSize,
+		TracingOTLPEndpoint,
+		BackupSchedule,
+		BackupKeepDaily,
+		BackupKeepWeekly,
+		BackupStorage,
+		BackupEncryptionPublicKey,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
@@ -389,6 +466,16 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		AuditLogSyslogAddress,
+		AuditLogWebhookURL,
+		AuditLogWebhookBatchSize,
+		AuditLogWebhookBufferSize,
+		TracingOTLPEndpoint,
+		BackupSchedule,
+		BackupKeepDaily,
+		BackupKeepWeekly,
+		BackupStorage,
+		BackupEncryptionPublicKey,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
 		ControllerAPIPort,
@@ -626,6 +713,68 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogSyslogAddress returns the address of the syslog server that
+// audit log entries are sent to, or "" if there isn't one.
+func (c Config) AuditLogSyslogAddress() string {
+	return c.asString(AuditLogSyslogAddress)
+}
+
+// AuditLogWebhookURL returns the URL that audit log entries are posted
+// to, or "" if there isn't one.
+func (c Config) AuditLogWebhookURL() string {
+	return c.asString(AuditLogWebhookURL)
+}
+
+// AuditLogWebhookBatchSize returns the maximum number of audit log
+// entries posted to the webhook in a single request.
+func (c Config) AuditLogWebhookBatchSize() int {
+	return c.intOrDefault(AuditLogWebhookBatchSize, DefaultAuditLogWebhookBatchSize)
+}
+
+// AuditLogWebhookBufferSizeMB returns the maximum size in MB of the
+// buffer of audit log entries waiting to be posted to the webhook.
+func (c Config) AuditLogWebhookBufferSizeMB() int {
+	return c.sizeMBOrDefault(AuditLogWebhookBufferSize, DefaultAuditLogWebhookBufferSizeMB)
+}
+
+// TracingOTLPEndpoint returns the URL of the OpenTelemetry collector
+// that API request spans are sent to, or "" if tracing is disabled.
+func (c Config) TracingOTLPEndpoint() string {
+	return c.asString(TracingOTLPEndpoint)
+}
+
+// BackupSchedule returns the cron-like schedule on which the
+// controller creates backups, or "" if scheduled backups are
+// disabled.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupKeepDaily returns the number of daily scheduled backups to
+// keep.
+func (c Config) BackupKeepDaily() int {
+	return c.intOrDefault(BackupKeepDaily, DefaultBackupKeepDaily)
+}
+
+// BackupKeepWeekly returns the number of weekly scheduled backups to
+// keep.
+func (c Config) BackupKeepWeekly() int {
+	return c.intOrDefault(BackupKeepWeekly, DefaultBackupKeepWeekly)
+}
+
+// BackupStorage returns the URL of the location where backup archives
+// are stored, or "" if they are stored in the controller database.
+func (c Config) BackupStorage() string {
+	return c.asString(BackupStorage)
+}
+
+// BackupEncryptionPublicKey returns the armored OpenPGP public key
+// ring that backup archives are encrypted to, or "" if backups are
+// not encrypted.
+func (c Config) BackupEncryptionPublicKey() string {
+	return c.asString(BackupEncryptionPublicKey)
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -1010,6 +1159,66 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[AuditLogSyslogAddress].(string); ok && v != "" {
+		if _, _, err := auditlog.ParseSyslogAddress(v); err != nil {
+			return errors.Annotate(err, "invalid audit log syslog address")
+		}
+	}
+
+	if v, ok := c[AuditLogWebhookURL].(string); ok && v != "" {
+		if u, err := url.Parse(v); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Errorf("invalid audit log webhook url: expected an http or https URL, got %q", v)
+		}
+	}
+
+	if v, ok := c[TracingOTLPEndpoint].(string); ok && v != "" {
+		if u, err := url.Parse(v); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Errorf("invalid tracing OTLP endpoint: expected an http or https URL, got %q", v)
+		}
+	}
+
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := cron.Parse(v); err != nil {
+			return errors.Annotate(err, "invalid backup schedule")
+		}
+	}
+
+	if v, ok := c[BackupKeepDaily].(int); ok && v < 0 {
+		return errors.Errorf("invalid backup keep daily: should not be negative, got %d", v)
+	}
+
+	if v, ok := c[BackupKeepWeekly].(int); ok && v < 0 {
+		return errors.Errorf("invalid backup keep weekly: should not be negative, got %d", v)
+	}
+
+	if v, ok := c[BackupStorage].(string); ok && v != "" {
+		if err := validateBackupStorage(v); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if v, ok := c[BackupEncryptionPublicKey].(string); ok && v != "" {
+		keys, err := openpgp.ReadArmoredKeyRing(strings.NewReader(v))
+		if err != nil {
+			return errors.Annotate(err, "invalid backup encryption public key")
+		}
+		if len(keys) == 0 {
+			return errors.New("invalid backup encryption public key: no keys found")
+		}
+	}
+
+	if v, ok := c[AuditLogWebhookBatchSize].(int); ok && v <= 0 {
+		return errors.Errorf("invalid audit log webhook batch size: should be a positive number, got %d", v)
+	}
+
+	if v, ok := c[AuditLogWebhookBufferSize].(string); ok {
+		if size, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid audit log webhook buffer size in configuration")
+		} else if size == 0 {
+			return errors.Errorf("invalid audit log webhook buffer size: can't be 0")
+		}
+	}
+
 	if v, ok := c[ControllerAPIPort].(int); ok {
 		// TODO: change the validation so 0 is invalid and --reset is used.
 		// However that doesn't exist yet.
@@ -1075,6 +1284,28 @@ func (c Config) validateSpaceConfig(key, topic string) error {
 	return nil
 }
 
+// validateBackupStorage checks that the backup storage is a file URL
+// with an absolute path, or an S3 URL naming a bucket.
+func validateBackupStorage(storage string) error {
+	u, err := url.Parse(storage)
+	if err != nil {
+		return errors.Annotate(err, "invalid backup storage")
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+			return errors.Errorf("invalid backup storage: expected an absolute path, eg \"file:///srv/juju-backups\", got %q", storage)
+		}
+	case "s3":
+		if u.Host == "" {
+			return errors.Errorf("invalid backup storage: missing S3 bucket in %q", storage)
+		}
+	default:
+		return errors.Errorf("invalid backup storage: expected a file or s3 URL, got %q", storage)
+	}
+	return nil
+}
+
 // AsSpaceConstraints checks to see whether config has spaces names populated
 // for management and/or HA (Mongo).
 // Non-empty values are merged with any input spaces and returned as a new
@@ -1109,87 +1340,107 @@ func (c Config) AsSpaceConstraints(spaces *[]string) *[]string {
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AgentRateLimitMax:        schema.ForceInt(),
-	AgentRateLimitRate:       schema.TimeDuration(),
-	AuditingEnabled:          schema.Bool(),
-	AuditLogCaptureArgs:      schema.Bool(),
-	AuditLogMaxSize:          schema.String(),
-	AuditLogMaxBackups:       schema.ForceInt(),
-	AuditLogExcludeMethods:   schema.List(schema.String()),
-	APIPort:                  schema.ForceInt(),
-	APIPortOpenDelay:         schema.String(),
-	ControllerAPIPort:        schema.ForceInt(),
-	ControllerName:           schema.String(),
-	StatePort:                schema.ForceInt(),
-	IdentityURL:              schema.String(),
-	IdentityPublicKey:        schema.String(),
-	SetNUMAControlPolicyKey:  schema.Bool(),
-	AutocertURLKey:           schema.String(),
-	AutocertDNSNameKey:       schema.String(),
-	AllowModelAccessKey:      schema.Bool(),
-	MongoMemoryProfile:       schema.String(),
-	JujuDBSnapChannel:        schema.String(),
-	MaxDebugLogDuration:      schema.TimeDuration(),
-	MaxTxnLogSize:            schema.String(),
-	MaxPruneTxnBatchSize:     schema.ForceInt(),
-	MaxPruneTxnPasses:        schema.ForceInt(),
-	ModelLogfileMaxBackups:   schema.ForceInt(),
-	ModelLogfileMaxSize:      schema.String(),
-	ModelLogsSize:            schema.String(),
-	PruneTxnQueryCount:       schema.ForceInt(),
-	PruneTxnSleepTime:        schema.String(),
-	PublicDNSAddress:         schema.String(),
-	JujuHASpace:              schema.String(),
-	JujuManagementSpace:      schema.String(),
-	CAASOperatorImagePath:    schema.String(),
-	CAASImageRepo:            schema.String(),
-	Features:                 schema.List(schema.String()),
-	CharmStoreURL:            schema.String(),
-	MeteringURL:              schema.String(),
-	MaxCharmStateSize:        schema.ForceInt(),
-	MaxAgentStateSize:        schema.ForceInt(),
-	NonSyncedWritesToRaftLog: schema.Bool(),
+	AgentRateLimitMax:         schema.ForceInt(),
+	AgentRateLimitRate:        schema.TimeDuration(),
+	AuditingEnabled:           schema.Bool(),
+	AuditLogCaptureArgs:       schema.Bool(),
+	AuditLogMaxSize:           schema.String(),
+	AuditLogMaxBackups:        schema.ForceInt(),
+	AuditLogExcludeMethods:    schema.List(schema.String()),
+	AuditLogSyslogAddress:     schema.String(),
+	AuditLogWebhookURL:        schema.String(),
+	AuditLogWebhookBatchSize:  schema.ForceInt(),
+	AuditLogWebhookBufferSize: schema.String(),
+	TracingOTLPEndpoint:       schema.String(),
+	BackupSchedule:            schema.String(),
+	BackupKeepDaily:           schema.ForceInt(),
+	BackupKeepWeekly:          schema.ForceInt(),
+	BackupStorage:             schema.String(),
+	BackupEncryptionPublicKey: schema.String(),
+	APIPort:                   schema.ForceInt(),
+	APIPortOpenDelay:          schema.String(),
+	ControllerAPIPort:         schema.ForceInt(),
+	ControllerName:            schema.String(),
+	StatePort:                 schema.ForceInt(),
+	IdentityURL:               schema.String(),
+	IdentityPublicKey:         schema.String(),
+	SetNUMAControlPolicyKey:   schema.Bool(),
+	AutocertURLKey:            schema.String(),
+	AutocertDNSNameKey:        schema.String(),
+	AllowModelAccessKey:       schema.Bool(),
+	MongoMemoryProfile:        schema.String(),
+	JujuDBSnapChannel:         schema.String(),
+	MaxDebugLogDuration:       schema.TimeDuration(),
+	MaxTxnLogSize:             schema.String(),
+	MaxPruneTxnBatchSize:      schema.ForceInt(),
+	MaxPruneTxnPasses:         schema.ForceInt(),
+	ModelLogfileMaxBackups:    schema.ForceInt(),
+	ModelLogfileMaxSize:       schema.String(),
+	ModelLogsSize:             schema.String(),
+	PruneTxnQueryCount:        schema.ForceInt(),
+	PruneTxnSleepTime:         schema.String(),
+	PublicDNSAddress:          schema.String(),
+	JujuHASpace:               schema.String(),
+	JujuManagementSpace:       schema.String(),
+	CAASOperatorImagePath:     schema.String(),
+	CAASImageRepo:             schema.String(),
+	Features:                  schema.List(schema.String()),
+	CharmStoreURL:             schema.String(),
+	MeteringURL:               schema.String(),
+	MaxCharmStateSize:         schema.ForceInt(),
+	MaxAgentStateSize:         schema.ForceInt(),
+	NonSyncedWritesToRaftLog:  schema.Bool(),
 }, schema.Defaults{
-	AgentRateLimitMax:        schema.Omit,
-	AgentRateLimitRate:       schema.Omit,
-	APIPort:                  DefaultAPIPort,
-	APIPortOpenDelay:         DefaultAPIPortOpenDelay,
-	ControllerAPIPort:        schema.Omit,
-	ControllerName:           schema.Omit,
-	AuditingEnabled:          DefaultAuditingEnabled,
-	AuditLogCaptureArgs:      DefaultAuditLogCaptureArgs,
-	AuditLogMaxSize:          fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
-	AuditLogMaxBackups:       DefaultAuditLogMaxBackups,
-	AuditLogExcludeMethods:   DefaultAuditLogExcludeMethods,
-	StatePort:                DefaultStatePort,
-	IdentityURL:              schema.Omit,
-	IdentityPublicKey:        schema.Omit,
-	SetNUMAControlPolicyKey:  DefaultNUMAControlPolicy,
-	AutocertURLKey:           schema.Omit,
-	AutocertDNSNameKey:       schema.Omit,
-	AllowModelAccessKey:      schema.Omit,
-	MongoMemoryProfile:       DefaultMongoMemoryProfile,
-	JujuDBSnapChannel:        DefaultJujuDBSnapChannel,
-	MaxDebugLogDuration:      DefaultMaxDebugLogDuration,
-	MaxTxnLogSize:            fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
-	MaxPruneTxnBatchSize:     DefaultMaxPruneTxnBatchSize,
-	MaxPruneTxnPasses:        DefaultMaxPruneTxnPasses,
-	ModelLogfileMaxBackups:   DefaultModelLogfileMaxBackups,
-	ModelLogfileMaxSize:      fmt.Sprintf("%vM", DefaultModelLogfileMaxSize),
-	ModelLogsSize:            fmt.Sprintf("%vM", DefaultModelLogsSizeMB),
-	PruneTxnQueryCount:       DefaultPruneTxnQueryCount,
-	PruneTxnSleepTime:        DefaultPruneTxnSleepTime,
-	PublicDNSAddress:         schema.Omit,
-	JujuHASpace:              schema.Omit,
-	JujuManagementSpace:      schema.Omit,
-	CAASOperatorImagePath:    schema.Omit,
-	CAASImageRepo:            schema.Omit,
-	Features:                 schema.Omit,
-	CharmStoreURL:            csclient.ServerURL,
-	MeteringURL:              romulus.DefaultAPIRoot,
-	MaxCharmStateSize:        DefaultMaxCharmStateSize,
-	MaxAgentStateSize:        DefaultMaxAgentStateSize,
-	NonSyncedWritesToRaftLog: DefaultNonSyncedWritesToRaftLog,
+	AgentRateLimitMax:         schema.Omit,
+	AgentRateLimitRate:        schema.Omit,
+	APIPort:                   DefaultAPIPort,
+	APIPortOpenDelay:          DefaultAPIPortOpenDelay,
+	ControllerAPIPort:         schema.Omit,
+	ControllerName:            schema.Omit,
+	AuditingEnabled:           DefaultAuditingEnabled,
+	AuditLogCaptureArgs:       DefaultAuditLogCaptureArgs,
+	AuditLogMaxSize:           fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
+	AuditLogMaxBackups:        DefaultAuditLogMaxBackups,
+	AuditLogExcludeMethods:    DefaultAuditLogExcludeMethods,
+	AuditLogSyslogAddress:     schema.Omit,
+	AuditLogWebhookURL:        schema.Omit,
+	AuditLogWebhookBatchSize:  schema.Omit,
+	AuditLogWebhookBufferSize: schema.Omit,
+	TracingOTLPEndpoint:       schema.Omit,
+	BackupSchedule:            schema.Omit,
+	BackupKeepDaily:           schema.Omit,
+	BackupKeepWeekly:          schema.Omit,
+	BackupStorage:             schema.Omit,
+	BackupEncryptionPublicKey: schema.Omit,
+	StatePort:                 DefaultStatePort,
+	IdentityURL:               schema.Omit,
+	IdentityPublicKey:         schema.Omit,
+	SetNUMAControlPolicyKey:   DefaultNUMAControlPolicy,
+	AutocertURLKey:            schema.Omit,
+	AutocertDNSNameKey:        schema.Omit,
+	AllowModelAccessKey:       schema.Omit,
+	MongoMemoryProfile:        DefaultMongoMemoryProfile,
+	JujuDBSnapChannel:         DefaultJujuDBSnapChannel,
+	MaxDebugLogDuration:       DefaultMaxDebugLogDuration,
+	MaxTxnLogSize:             fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	MaxPruneTxnBatchSize:      DefaultMaxPruneTxnBatchSize,
+	MaxPruneTxnPasses:         DefaultMaxPruneTxnPasses,
+	ModelLogfileMaxBackups:    DefaultModelLogfileMaxBackups,
+	ModelLogfileMaxSize:       fmt.Sprintf("%vM", DefaultModelLogfileMaxSize),
+	ModelLogsSize:             fmt.Sprintf("%vM", DefaultModelLogsSizeMB),
+	PruneTxnQueryCount:        DefaultPruneTxnQueryCount,
+	PruneTxnSleepTime:         DefaultPruneTxnSleepTime,
+	PublicDNSAddress:          schema.Omit,
+	JujuHASpace:               schema.Omit,
+	JujuManagementSpace:       schema.Omit,
+	CAASOperatorImagePath:     schema.Omit,
+	CAASImageRepo:             schema.Omit,
+	Features:                  schema.Omit,
+	CharmStoreURL:             csclient.ServerURL,
+	MeteringURL:               romulus.DefaultAPIRoot,
+	MaxCharmStateSize:         DefaultMaxCharmStateSize,
+	MaxAgentStateSize:         DefaultMaxAgentStateSize,
+	NonSyncedWritesToRaftLog:  DefaultNonSyncedWritesToRaftLog,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -1224,6 +1475,46 @@ var ConfigSchema = environschema.Fields{
 		Type:        environschema.FieldType("list of strings"),
 		Description: "The list of Facade.Method names that aren't interesting for audit logging purposes.",
 	},
+	AuditLogSyslogAddress: {
+		Type:        environschema.Tstring,
+		Description: `The address of a syslog server that audit log entries are also sent to, eg "tcp://10.0.0.1:601"`,
+	},
+	AuditLogWebhookURL: {
+		Type:        environschema.Tstring,
+		Description: "The http or https URL that batches of audit log entries are also posted to",
+	},
+	AuditLogWebhookBatchSize: {
+		Type:        environschema.Tint,
+		Description: "The maximum number of audit log entries posted to the webhook in a single request",
+	},
+	AuditLogWebhookBufferSize: {
+		Type:        environschema.Tstring,
+		Description: "The maximum size of the on-disk buffer of audit log entries waiting to be posted to the webhook",
+	},
+	TracingOTLPEndpoint: {
+		Type:        environschema.Tstring,
+		Description: `The http or https URL of an OpenTelemetry collector that traced API requests are sent to, eg "http://10.0.0.1:4318"`,
+	},
+	BackupSchedule: {
+		Type:        environschema.Tstring,
+		Description: `The cron-like schedule on which the controller creates backups of itself, eg "30 2 * * *" or "@daily" (times are in UTC)`,
+	},
+	BackupKeepDaily: {
+		Type:        environschema.Tint,
+		Description: "The number of days for which the newest scheduled backup of the day is kept (if both backup-keep-daily and backup-keep-weekly are 0, no backups are removed)",
+	},
+	BackupKeepWeekly: {
+		Type:        environschema.Tint,
+		Description: "The number of weeks for which the newest scheduled backup of the week is kept (if both backup-keep-daily and backup-keep-weekly are 0, no backups are removed)",
+	},
+	BackupStorage: {
+		Type:        environschema.Tstring,
+		Description: `The location where backup archives are stored, either a directory "file:///srv/juju-backups" or an S3-compatible bucket "s3://bucket/prefix?endpoint=host:port&region=region" (S3 credentials are taken from the environment, shared credentials file or instance profile of the controller machines); archives are stored in the controller database if empty`,
+	},
+	BackupEncryptionPublicKey: {
+		Type:        environschema.Tstring,
+		Description: "An armored OpenPGP public key ring; if set, backup archives are encrypted so they can be decrypted by the private key of any of its keys",
+	},
 	APIPort: {
 		Type:        environschema.Tint,
 		Description: "The port used for api connections",
@@ -15,6 +15,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/juju/keys"
 	"github.com/juju/juju/testing"
 )
 
@@ -155,6 +156,78 @@ var newConfigTests = []struct {
 		controller.AuditLogExcludeMethods: []interface{}{"Dap.Kings", "ReadOnlyMethods", "Sharon Jones"},
 	},
 	expectError: `invalid audit log exclude methods: should be a list of "Facade.Method" names \(or "ReadOnlyMethods"\), got "Sharon Jones" at position 3`,
+}, {
+	about: "invalid audit log syslog address",
+	config: controller.Config{
+		controller.AuditLogSyslogAddress: "10.0.0.1:601",
+	},
+	expectError: `invalid audit log syslog address: syslog address "10.0.0.1:601" \(expected tcp:// or udp://\) not valid`,
+}, {
+	about: "invalid audit log webhook url",
+	config: controller.Config{
+		controller.AuditLogWebhookURL: "ftp://audit.example.com",
+	},
+	expectError: `invalid audit log webhook url: expected an http or https URL, got "ftp://audit.example.com"`,
+}, {
+	about: "invalid audit log webhook batch size",
+	config: controller.Config{
+		controller.AuditLogWebhookBatchSize: 0,
+	},
+	expectError: `invalid audit log webhook batch size: should be a positive number, got 0`,
+}, {
+	about: "zero audit log webhook buffer size",
+	config: controller.Config{
+		controller.AuditLogWebhookBufferSize: "0M",
+	},
+	expectError: `invalid audit log webhook buffer size: can't be 0`,
+}, {
+	about: "invalid tracing OTLP endpoint",
+	config: controller.Config{
+		controller.TracingOTLPEndpoint: "10.0.0.1:4318",
+	},
+	expectError: `invalid tracing OTLP endpoint: expected an http or https URL, got "10.0.0.1:4318"`,
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.BackupSchedule: "30 2 * *",
+	},
+	expectError: `invalid backup schedule: schedule "30 2 \* \*" with 4 fields not valid`,
+}, {
+	about: "negative backup keep daily",
+	config: controller.Config{
+		controller.BackupKeepDaily: -1,
+	},
+	expectError: `invalid backup keep daily: should not be negative, got -1`,
+}, {
+	about: "negative backup keep weekly",
+	config: controller.Config{
+		controller.BackupKeepWeekly: -1,
+	},
+	expectError: `invalid backup keep weekly: should not be negative, got -1`,
+}, {
+	about: "relative backup storage directory",
+	config: controller.Config{
+		controller.BackupStorage: "file://backups",
+	},
+	expectError: `invalid backup storage: expected an absolute path, eg "file:///srv/juju-backups", got "file://backups"`,
+}, {
+	about: "backup storage missing bucket",
+	config: controller.Config{
+		controller.BackupStorage: "s3:///prefix",
+	},
+	expectError: `invalid backup storage: missing S3 bucket in "s3:///prefix"`,
+}, {
+	about: "unsupported backup storage",
+	config: controller.Config{
+		controller.BackupStorage: "ftp://10.0.0.1/backups",
+	},
+	expectError: `invalid backup storage: expected a file or s3 URL, got "ftp://10.0.0.1/backups"`,
+}, {
+	about: "invalid backup encryption public key",
+	config: controller.Config{
+		controller.BackupEncryptionPublicKey: "not a key",
+	},
+	expectError: `invalid backup encryption public key: .*`,
 }, {
 	about: "invalid model log max size",
 	config: controller.Config{
@@ -515,6 +588,90 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 	))
 }
 
+func (s *ConfigSuite) TestAuditLogRemoteDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSyslogAddress(), gc.Equals, "")
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "")
+	c.Assert(cfg.AuditLogWebhookBatchSize(), gc.Equals, 100)
+	c.Assert(cfg.AuditLogWebhookBufferSizeMB(), gc.Equals, 100)
+}
+
+func (s *ConfigSuite) TestAuditLogRemoteValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"audit-log-syslog-address":      "tcp://10.0.0.1:601",
+			"audit-log-webhook-url":         "https://audit.example.com/ingest",
+			"audit-log-webhook-batch-size":  50,
+			"audit-log-webhook-buffer-size": "1G",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSyslogAddress(), gc.Equals, "tcp://10.0.0.1:601")
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "https://audit.example.com/ingest")
+	c.Assert(cfg.AuditLogWebhookBatchSize(), gc.Equals, 50)
+	c.Assert(cfg.AuditLogWebhookBufferSizeMB(), gc.Equals, 1024)
+}
+
+func (s *ConfigSuite) TestTracingOTLPEndpoint(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingOTLPEndpoint(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"tracing-otlp-endpoint": "http://10.0.0.1:4318",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingOTLPEndpoint(), gc.Equals, "http://10.0.0.1:4318")
+}
+
+func (s *ConfigSuite) TestBackupSchedule(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "")
+	c.Assert(cfg.BackupKeepDaily(), gc.Equals, controller.DefaultBackupKeepDaily)
+	c.Assert(cfg.BackupKeepWeekly(), gc.Equals, controller.DefaultBackupKeepWeekly)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule":    "30 2 * * *",
+			"backup-keep-daily":  3,
+			"backup-keep-weekly": 0,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "30 2 * * *")
+	c.Assert(cfg.BackupKeepDaily(), gc.Equals, 3)
+	c.Assert(cfg.BackupKeepWeekly(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestBackupStorage(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupStorage(), gc.Equals, "")
+	c.Assert(cfg.BackupEncryptionPublicKey(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-storage":               "s3://juju-backups/prod?endpoint=10.0.0.1:9000",
+			"backup-encryption-public-key": keys.JujuPublicKey,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupStorage(), gc.Equals, "s3://juju-backups/prod?endpoint=10.0.0.1:9000")
+	c.Assert(cfg.BackupEncryptionPublicKey(), gc.Equals, keys.JujuPublicKey)
+}
+
 func (s *ConfigSuite) TestAuditLogExcludeMethodsType(c *gc.C) {
 	_, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
@@ -0,0 +1,223 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+const segmentSuffix = ".jsonl"
+
+// diskBuffer is a bounded, on-disk FIFO of JSON-encoded records. The
+// records are stored in numbered segment files holding up to
+// segmentSize records each; the newest segment is the only one that
+// is written to. When the buffer grows beyond maxBytes, the oldest
+// segments are dropped.
+type diskBuffer struct {
+	dir         string
+	segmentSize int
+	maxBytes    int64
+
+	mu sync.Mutex
+	// segments holds the sequence numbers of the segments on disk,
+	// oldest first. The last one is the current segment.
+	segments []uint64
+	sizes    map[uint64]int64
+	total    int64
+	// current is the open current segment (or nil), and
+	// currentCount the number of records written to it.
+	current      *os.File
+	currentCount int
+}
+
+func openDiskBuffer(dir string, segmentSize int, maxBytes int64) (*diskBuffer, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	b := &diskBuffer{
+		dir:         dir,
+		segmentSize: segmentSize,
+		maxBytes:    maxBytes,
+		sizes:       make(map[uint64]int64),
+	}
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		b.segments = append(b.segments, seq)
+		b.sizes[seq] = info.Size()
+		b.total += info.Size()
+	}
+	sort.Slice(b.segments, func(i, j int) bool {
+		return b.segments[i] < b.segments[j]
+	})
+	// Segments left over from a previous run are complete; new
+	// records always go into a fresh segment.
+	b.segments = append(b.segments, b.nextSeq())
+	return b, nil
+}
+
+func (b *diskBuffer) path(seq uint64) string {
+	return filepath.Join(b.dir, fmt.Sprintf("%020d%s", seq, segmentSuffix))
+}
+
+func (b *diskBuffer) nextSeq() uint64 {
+	if len(b.segments) == 0 {
+		return 1
+	}
+	return b.segments[len(b.segments)-1] + 1
+}
+
+func (b *diskBuffer) currentSeq() uint64 {
+	return b.segments[len(b.segments)-1]
+}
+
+// append adds a record to the buffer, reporting whether the current
+// segment is now full.
+func (b *diskBuffer) append(record []byte) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.current == nil {
+		f, err := os.OpenFile(b.path(b.currentSeq()), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		b.current = f
+	}
+	line := append(record, '\n')
+	if _, err := b.current.Write(line); err != nil {
+		return false, errors.Trace(err)
+	}
+	b.currentCount++
+	b.sizes[b.currentSeq()] += int64(len(line))
+	b.total += int64(len(line))
+
+	full := b.currentCount >= b.segmentSize
+	if full {
+		if err := b.rotate(); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+	b.enforceLimit()
+	return full, nil
+}
+
+// rotate closes the current segment and starts a new one. It must be
+// called with the lock held.
+func (b *diskBuffer) rotate() error {
+	if b.current != nil {
+		if err := b.current.Close(); err != nil {
+			return errors.Trace(err)
+		}
+		b.current = nil
+	}
+	b.currentCount = 0
+	b.segments = append(b.segments, b.nextSeq())
+	return nil
+}
+
+// enforceLimit drops the oldest complete segments until the buffer
+// fits within maxBytes. It must be called with the lock held.
+func (b *diskBuffer) enforceLimit() {
+	for b.total > b.maxBytes && len(b.segments) > 1 {
+		seq := b.segments[0]
+		logger.Warningf("audit webhook buffer full: dropping %d bytes of undelivered records", b.sizes[seq])
+		b.removeLocked(seq)
+	}
+}
+
+// oldest returns the sequence number and records of the oldest
+// segment holding any records. If the current segment is the only one
+// with records, it is rotated so that it can't change while it's
+// being delivered.
+func (b *diskBuffer) oldest() (uint64, [][]byte, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for len(b.segments) > 1 || b.currentCount > 0 {
+		seq := b.segments[0]
+		if seq == b.currentSeq() {
+			if err := b.rotate(); err != nil {
+				return 0, nil, false, errors.Trace(err)
+			}
+		}
+		data, err := ioutil.ReadFile(b.path(seq))
+		if os.IsNotExist(err) {
+			b.removeLocked(seq)
+			continue
+		} else if err != nil {
+			return 0, nil, false, errors.Trace(err)
+		}
+		var records [][]byte
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(nil, len(data)+1)
+		for scanner.Scan() {
+			if line := scanner.Bytes(); len(line) > 0 {
+				records = append(records, append([]byte(nil), line...))
+			}
+		}
+		if len(records) == 0 {
+			b.removeLocked(seq)
+			continue
+		}
+		return seq, records, true, nil
+	}
+	return 0, nil, false, nil
+}
+
+// remove deletes a delivered segment.
+func (b *diskBuffer) remove(seq uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removeLocked(seq)
+}
+
+func (b *diskBuffer) removeLocked(seq uint64) {
+	if seq == b.currentSeq() {
+		return
+	}
+	for i, s := range b.segments {
+		if s == seq {
+			b.segments = append(b.segments[:i], b.segments[i+1:]...)
+			break
+		}
+	}
+	if err := os.Remove(b.path(seq)); err != nil && !os.IsNotExist(err) {
+		logger.Warningf("removing audit webhook buffer segment: %v", err)
+	}
+	b.total -= b.sizes[seq]
+	delete(b.sizes, seq)
+}
+
+// close closes the current segment file.
+func (b *diskBuffer) close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.current == nil {
+		return nil
+	}
+	err := b.current.Close()
+	b.current = nil
+	return errors.Trace(err)
+}
@@ -29,6 +29,10 @@ type Config struct {
 	// consists of these method calls we won't log it.
 	ExcludeMethods set.Strings
 
+	// Remote holds the settings for the remote targets that entries
+	// are streamed to as well as the local audit log file.
+	Remote RemoteConfig
+
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
 }
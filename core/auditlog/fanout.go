@@ -0,0 +1,170 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sync"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+// RemoteConfig holds the settings for the remote targets that audit
+// records are streamed to, in addition to the local audit log file.
+type RemoteConfig struct {
+	// SyslogAddress is the address of a syslog server in the form
+	// "tcp://host:port" or "udp://host:port". If empty, records aren't
+	// sent to syslog.
+	SyslogAddress string
+
+	// WebhookURL is the http(s) URL that batches of records are
+	// posted to. If empty, records aren't posted anywhere.
+	WebhookURL string
+
+	// WebhookBatchSize is the maximum number of records posted to
+	// the webhook in a single request.
+	WebhookBatchSize int
+
+	// WebhookBufferSizeMB is the maximum size of the on-disk buffer
+	// holding records that haven't been posted to the webhook yet.
+	WebhookBufferSizeMB int
+}
+
+// RemoteOpener opens the remote targets described by the config.
+type RemoteOpener func(RemoteConfig) ([]AuditLog, error)
+
+// Fanout is an AuditLog that writes every record to a local target
+// and to a set of remote targets. Since API connections keep hold of
+// the AuditLog they were given at login, the remote targets can be
+// reconfigured while the Fanout is in use.
+type Fanout struct {
+	local AuditLog
+	open  RemoteOpener
+
+	mu           sync.RWMutex
+	remoteConfig RemoteConfig
+	remote       []AuditLog
+}
+
+// NewFanout returns a Fanout writing to the local target, using open
+// to create remote targets when it is configured.
+func NewFanout(local AuditLog, open RemoteOpener) *Fanout {
+	return &Fanout{
+		local: local,
+		open:  open,
+	}
+}
+
+// Configure replaces the remote targets with those described by the
+// config. Nothing is done if the config hasn't changed.
+func (f *Fanout) Configure(cfg RemoteConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if cfg == f.remoteConfig {
+		return nil
+	}
+	// The old targets need to be closed before the new ones are
+	// opened, as they may share resources (like a buffer directory).
+	for _, target := range f.remote {
+		if err := target.Close(); err != nil {
+			logger.Warningf("closing remote audit log target: %v", err)
+		}
+	}
+	f.remote = nil
+	f.remoteConfig = RemoteConfig{}
+
+	remote, err := f.open(cfg)
+	if err != nil {
+		return errors.Annotate(err, "opening remote audit log targets")
+	}
+	f.remote = remote
+	f.remoteConfig = cfg
+	return nil
+}
+
+// AddConversation implements AuditLog.
+func (f *Fanout) AddConversation(c Conversation) error {
+	return errors.Trace(f.each(func(target AuditLog) error {
+		return target.AddConversation(c)
+	}))
+}
+
+// AddRequest implements AuditLog.
+func (f *Fanout) AddRequest(r Request) error {
+	return errors.Trace(f.each(func(target AuditLog) error {
+		return target.AddRequest(r)
+	}))
+}
+
+// AddResponse implements AuditLog.
+func (f *Fanout) AddResponse(r ResponseErrors) error {
+	return errors.Trace(f.each(func(target AuditLog) error {
+		return target.AddResponse(r)
+	}))
+}
+
+// Close implements AuditLog.
+func (f *Fanout) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, target := range f.remote {
+		if err := target.Close(); err != nil {
+			logger.Warningf("closing remote audit log target: %v", err)
+		}
+	}
+	f.remote = nil
+	return errors.Trace(f.local.Close())
+}
+
+// each calls add for the local target and then every remote target.
+// Only errors from the local target are returned - a remote target
+// failing shouldn't cause API requests to fail, so those errors are
+// logged instead.
+func (f *Fanout) each(add func(AuditLog) error) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	err := add(f.local)
+	for _, target := range f.remote {
+		if remoteErr := add(target); remoteErr != nil {
+			logger.Warningf("writing to remote audit log target: %v", remoteErr)
+		}
+	}
+	return errors.Trace(err)
+}
+
+// NewRemoteOpener returns a RemoteOpener that opens syslog and
+// webhook targets. Records waiting to be posted to the webhook are
+// buffered in bufferDir.
+func NewRemoteOpener(bufferDir string, clock clock.Clock) RemoteOpener {
+	return func(cfg RemoteConfig) ([]AuditLog, error) {
+		var targets []AuditLog
+		if cfg.SyslogAddress != "" {
+			target, err := NewSyslog(SyslogConfig{
+				Address: cfg.SyslogAddress,
+				Clock:   clock,
+			})
+			if err != nil {
+				return nil, errors.Annotate(err, "opening syslog target")
+			}
+			targets = append(targets, target)
+		}
+		if cfg.WebhookURL != "" {
+			target, err := NewWebhook(WebhookConfig{
+				URL:             cfg.WebhookURL,
+				BatchSize:       cfg.WebhookBatchSize,
+				BufferDir:       bufferDir,
+				MaxBufferSizeMB: cfg.WebhookBufferSizeMB,
+				Clock:           clock,
+			})
+			if err != nil {
+				for _, opened := range targets {
+					_ = opened.Close()
+				}
+				return nil, errors.Annotate(err, "opening webhook target")
+			}
+			targets = append(targets, target)
+		}
+		return targets, nil
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type FanoutSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FanoutSuite{})
+
+func (s *FanoutSuite) TestWritesToAllTargets(c *gc.C) {
+	var local, remote1, remote2 fakeLog
+	fanout := auditlog.NewFanout(&local, func(auditlog.RemoteConfig) ([]auditlog.AuditLog, error) {
+		return []auditlog.AuditLog{&remote1, &remote2}, nil
+	})
+	err := fanout.Configure(auditlog.RemoteConfig{SyslogAddress: "udp://somewhere:514"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(fanout.AddConversation(auditlog.Conversation{Who: "death-grips"}), jc.ErrorIsNil)
+	c.Assert(fanout.AddRequest(auditlog.Request{RequestID: 1}), jc.ErrorIsNil)
+	c.Assert(fanout.AddResponse(auditlog.ResponseErrors{RequestID: 1}), jc.ErrorIsNil)
+
+	for _, log := range []*fakeLog{&local, &remote1, &remote2} {
+		log.stub.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse")
+	}
+}
+
+func (s *FanoutSuite) TestRemoteErrorsIgnored(c *gc.C) {
+	var local, remote fakeLog
+	remote.stub.SetErrors(errors.New("network down"))
+	fanout := auditlog.NewFanout(&local, func(auditlog.RemoteConfig) ([]auditlog.AuditLog, error) {
+		return []auditlog.AuditLog{&remote}, nil
+	})
+	err := fanout.Configure(auditlog.RemoteConfig{WebhookURL: "http://somewhere"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = fanout.AddConversation(auditlog.Conversation{Who: "death-grips"})
+	c.Assert(err, jc.ErrorIsNil)
+	remote.stub.CheckCallNames(c, "AddConversation")
+}
+
+func (s *FanoutSuite) TestLocalErrorsReturned(c *gc.C) {
+	var local fakeLog
+	local.stub.SetErrors(errors.New("disk full"))
+	fanout := auditlog.NewFanout(&local, nil)
+
+	err := fanout.AddRequest(auditlog.Request{RequestID: 1})
+	c.Assert(err, gc.ErrorMatches, "disk full")
+}
+
+func (s *FanoutSuite) TestConfigureReplacesRemotes(c *gc.C) {
+	var local, remote1, remote2 fakeLog
+	var opened []auditlog.RemoteConfig
+	remotes := []*fakeLog{&remote1, &remote2}
+	fanout := auditlog.NewFanout(&local, func(cfg auditlog.RemoteConfig) ([]auditlog.AuditLog, error) {
+		opened = append(opened, cfg)
+		return []auditlog.AuditLog{remotes[len(opened)-1]}, nil
+	})
+	cfg1 := auditlog.RemoteConfig{SyslogAddress: "udp://one:514"}
+	cfg2 := auditlog.RemoteConfig{SyslogAddress: "udp://two:514"}
+	c.Assert(fanout.Configure(cfg1), jc.ErrorIsNil)
+	// Configuring with the same settings doesn't reopen anything.
+	c.Assert(fanout.Configure(cfg1), jc.ErrorIsNil)
+	c.Assert(fanout.Configure(cfg2), jc.ErrorIsNil)
+	c.Assert(opened, jc.DeepEquals, []auditlog.RemoteConfig{cfg1, cfg2})
+
+	c.Assert(fanout.AddRequest(auditlog.Request{RequestID: 1}), jc.ErrorIsNil)
+	remote1.stub.CheckCallNames(c, "Close")
+	remote2.stub.CheckCallNames(c, "AddRequest")
+
+	c.Assert(fanout.Close(), jc.ErrorIsNil)
+	remote2.stub.CheckCallNames(c, "AddRequest", "Close")
+	local.stub.CheckCallNames(c, "AddRequest", "Close")
+}
+
+func (s *FanoutSuite) TestConfigureOpenError(c *gc.C) {
+	var local fakeLog
+	fanout := auditlog.NewFanout(&local, func(auditlog.RemoteConfig) ([]auditlog.AuditLog, error) {
+		return nil, errors.New("boom")
+	})
+	err := fanout.Configure(auditlog.RemoteConfig{WebhookURL: "http://somewhere"})
+	c.Assert(err, gc.ErrorMatches, "opening remote audit log targets: boom")
+
+	// The local target is still written to.
+	c.Assert(fanout.AddRequest(auditlog.Request{RequestID: 1}), jc.ErrorIsNil)
+	local.stub.CheckCallNames(c, "AddRequest")
+}
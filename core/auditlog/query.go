@@ -0,0 +1,388 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// maxRecordSize is the largest single line we expect to find in an
+// audit log file; API args can make requests quite large.
+const maxRecordSize = 4 * 1024 * 1024
+
+// Query describes the conversations that should be returned when
+// searching an audit log. Empty fields match everything.
+type Query struct {
+	// User only matches conversations started by this user.
+	User string
+
+	// ModelUUID only matches conversations with this model.
+	ModelUUID string
+
+	// Facade and Method only match conversations containing a
+	// request to this facade and/or method. Only the matching
+	// requests are included in the results.
+	Facade string
+	Method string
+
+	// After and Before restrict the conversations to those started
+	// in the half-open interval [After, Before).
+	After  time.Time
+	Before time.Time
+
+	// ErrorsOnly only matches conversations where at least one
+	// request failed, and only those requests are included in the
+	// results.
+	ErrorsOnly bool
+
+	// Limit, if positive, is the maximum number of conversations
+	// returned. The most recent conversations are kept.
+	Limit int
+}
+
+// ConversationEntry joins a conversation with the requests made as
+// part of it.
+type ConversationEntry struct {
+	Conversation Conversation
+	Requests     []RequestEntry
+}
+
+// RequestEntry joins a request with the errors returned in response.
+type RequestEntry struct {
+	Request Request
+	Errors  []*Error
+}
+
+// HasErrors returns whether the response to the request contained
+// any errors.
+func (e RequestEntry) HasErrors() bool {
+	for _, err := range e.Errors {
+		if err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Search looks through the audit log file in logDir, along with any
+// rotated backups of it, and returns the conversations matching the
+// query, oldest first. The files are streamed, and with a limit only
+// the conversations which could still be returned are held in memory.
+func Search(logDir string, q Query) ([]ConversationEntry, error) {
+	paths, err := logFiles(logDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := newSearcher(q)
+	for _, path := range paths {
+		if err := s.readFile(path); err != nil {
+			return nil, errors.Annotatef(err, "reading %q", path)
+		}
+	}
+	return s.results(), nil
+}
+
+// logFiles returns the audit log files in logDir in the order they
+// were written. Backups rotated by lumberjack are named
+// audit-<timestamp>.log(.gz), so they sort chronologically by name.
+func logFiles(logDir string) ([]string, error) {
+	backups, err := filepath.Glob(filepath.Join(logDir, "audit-*.log*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(backups)
+	current := filepath.Join(logDir, "audit.log")
+	if _, err := os.Stat(current); err == nil {
+		backups = append(backups, current)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Trace(err)
+	}
+	return backups, nil
+}
+
+type searcher struct {
+	query Query
+
+	// filterRequests is set when a conversation only matches once one
+	// of its requests does.
+	filterRequests bool
+
+	// seq numbers the matching conversations in the order they
+	// started.
+	seq int64
+
+	// live holds the conversations which are either in the results
+	// or may still become part of them, and pending holds the IDs of
+	// those which haven't matched yet, in the order they started.
+	live    map[string]*liveConversation
+	pending []string
+
+	// matched holds the most recent matching conversations.
+	matched conversationRing
+}
+
+type liveConversation struct {
+	seq      int64
+	entry    *ConversationEntry
+	requests map[uint64]int
+	matched  bool
+}
+
+func newSearcher(q Query) *searcher {
+	return &searcher{
+		query:          q,
+		filterRequests: q.Facade != "" || q.Method != "" || q.ErrorsOnly,
+		live:           make(map[string]*liveConversation),
+		matched:        conversationRing{limit: q.Limit},
+	}
+}
+
+func (s *searcher) readFile(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		// The file was rotated away while we were looking.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+
+	var source io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer gz.Close()
+		source = gz
+	}
+
+	scanner := bufio.NewScanner(source)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// A partially written line shouldn't stop the search.
+			logger.Debugf("skipping unreadable audit record in %q: %v", path, err)
+			continue
+		}
+		s.add(record)
+	}
+	return errors.Trace(scanner.Err())
+}
+
+func (s *searcher) add(record Record) {
+	switch {
+	case record.Conversation != nil:
+		c := *record.Conversation
+		if !s.matchConversation(c) {
+			return
+		}
+		if conv, found := s.live[c.ConversationID]; found {
+			conv.entry.Conversation = c
+			return
+		}
+		s.seq++
+		conv := &liveConversation{
+			seq:      s.seq,
+			entry:    &ConversationEntry{Conversation: c},
+			requests: make(map[uint64]int),
+		}
+		s.live[c.ConversationID] = conv
+		if !s.filterRequests {
+			s.addMatch(conv)
+			return
+		}
+		s.pending = append(s.pending, c.ConversationID)
+	case record.Request != nil:
+		r := *record.Request
+		conv, found := s.live[r.ConversationID]
+		if !found || !s.matchRequest(r) {
+			return
+		}
+		conv.requests[r.RequestID] = len(conv.entry.Requests)
+		conv.entry.Requests = append(conv.entry.Requests, RequestEntry{Request: r})
+		if !s.query.ErrorsOnly {
+			s.addMatch(conv)
+		}
+	case record.Errors != nil:
+		r := *record.Errors
+		conv, found := s.live[r.ConversationID]
+		if !found {
+			return
+		}
+		index, found := conv.requests[r.RequestID]
+		if !found {
+			return
+		}
+		request := &conv.entry.Requests[index]
+		request.Errors = r.Errors
+		if request.HasErrors() {
+			s.addMatch(conv)
+		}
+	}
+}
+
+// addMatch records that the conversation matches the query, dropping
+// any conversations which are then too old to be in the results.
+func (s *searcher) addMatch(conv *liveConversation) {
+	if conv.matched {
+		return
+	}
+	conv.matched = true
+	evicted, ok := s.matched.add(conv)
+	if !ok {
+		delete(s.live, conv.entry.Conversation.ConversationID)
+		return
+	}
+	if evicted != nil {
+		delete(s.live, evicted.entry.Conversation.ConversationID)
+	}
+	if !s.matched.full() {
+		return
+	}
+	// Conversations which started before the oldest one kept can't
+	// be in the results, even if they match later.
+	oldest := s.matched.oldest()
+	for len(s.pending) > 0 {
+		id := s.pending[0]
+		pending, found := s.live[id]
+		if found && pending.matched {
+			break
+		}
+		if found && pending.seq >= oldest {
+			break
+		}
+		if found {
+			delete(s.live, id)
+		}
+		s.pending = s.pending[1:]
+	}
+}
+
+func (s *searcher) matchConversation(c Conversation) bool {
+	q := s.query
+	if q.User != "" && c.Who != q.User {
+		return false
+	}
+	if q.ModelUUID != "" && c.ModelUUID != q.ModelUUID {
+		return false
+	}
+	if q.After.IsZero() && q.Before.IsZero() {
+		return true
+	}
+	when, err := time.Parse(time.RFC3339, c.When)
+	if err != nil {
+		return false
+	}
+	if !q.After.IsZero() && when.Before(q.After) {
+		return false
+	}
+	if !q.Before.IsZero() && !when.Before(q.Before) {
+		return false
+	}
+	return true
+}
+
+func (s *searcher) matchRequest(r Request) bool {
+	q := s.query
+	if q.Facade != "" && r.Facade != q.Facade {
+		return false
+	}
+	if q.Method != "" && r.Method != q.Method {
+		return false
+	}
+	return true
+}
+
+func (s *searcher) results() []ConversationEntry {
+	q := s.query
+	var results []ConversationEntry
+	for _, conv := range s.matched.conversations() {
+		entry := *conv.entry
+		if q.ErrorsOnly {
+			var failed []RequestEntry
+			for _, r := range entry.Requests {
+				if r.HasErrors() {
+					failed = append(failed, r)
+				}
+			}
+			entry.Requests = failed
+		}
+		if s.filterRequests && len(entry.Requests) == 0 {
+			continue
+		}
+		results = append(results, entry)
+	}
+	return results
+}
+
+// conversationRing holds the most recently started conversations
+// added to it, up to its limit. A limit of zero or less keeps them
+// all.
+type conversationRing struct {
+	limit int
+	items []*liveConversation
+	start int
+}
+
+func (r *conversationRing) full() bool {
+	return r.limit > 0 && len(r.items) == r.limit
+}
+
+func (r *conversationRing) at(i int) int {
+	return (r.start + i) % len(r.items)
+}
+
+// oldest returns the sequence number of the oldest conversation in
+// the ring, which must not be empty.
+func (r *conversationRing) oldest() int64 {
+	return r.items[r.start].seq
+}
+
+// add puts the conversation into the ring, returning the conversation
+// it evicted, if any. It returns false if the ring is full of more
+// recent conversations.
+func (r *conversationRing) add(conv *liveConversation) (*liveConversation, bool) {
+	var evicted *liveConversation
+	if !r.full() {
+		r.items = append(r.items, conv)
+	} else {
+		if conv.seq < r.oldest() {
+			return nil, false
+		}
+		evicted = r.items[r.start]
+		r.items[r.start] = conv
+		r.start = (r.start + 1) % len(r.items)
+	}
+	// Conversations mostly match in the order they started; move any
+	// which matched late back into place.
+	for i := len(r.items) - 1; i > 0; i-- {
+		cur, prev := r.at(i), r.at(i-1)
+		if r.items[prev].seq < r.items[cur].seq {
+			break
+		}
+		r.items[prev], r.items[cur] = r.items[cur], r.items[prev]
+	}
+	return evicted, true
+}
+
+// conversations returns the conversations in the ring, oldest first.
+func (r *conversationRing) conversations() []*liveConversation {
+	result := make([]*liveConversation, len(r.items))
+	for i := range r.items {
+		result[i] = r.items[r.at(i)]
+	}
+	return result
+}
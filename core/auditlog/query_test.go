@@ -0,0 +1,206 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type QuerySuite struct {
+	testing.IsolationSuite
+
+	dir string
+}
+
+var _ = gc.Suite(&QuerySuite{})
+
+func (s *QuerySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+
+	logFile := auditlog.NewLogFile(s.dir, 300, 10)
+	s.addConversation(c, logFile, "c1", "bob", "model-1", "2021-03-01T10:00:00Z")
+	s.addRequest(c, logFile, "c1", 1, "Application", "Deploy")
+	s.addResponse(c, logFile, "c1", 1)
+	s.addConversation(c, logFile, "c2", "alice", "model-2", "2021-03-01T11:00:00Z")
+	s.addRequest(c, logFile, "c2", 1, "Application", "Deploy")
+	s.addResponse(c, logFile, "c2", 1, &auditlog.Error{Message: "boom", Code: "bad"})
+	s.addRequest(c, logFile, "c2", 2, "Client", "FullStatus")
+	s.addResponse(c, logFile, "c2", 2)
+	s.addConversation(c, logFile, "c3", "bob", "model-2", "2021-03-01T12:00:00Z")
+	s.addRequest(c, logFile, "c3", 1, "Client", "FullStatus")
+	s.addResponse(c, logFile, "c3", 1)
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+}
+
+func (s *QuerySuite) addConversation(c *gc.C, log auditlog.AuditLog, id, who, model, when string) {
+	err := log.AddConversation(auditlog.Conversation{
+		Who:            who,
+		What:           "juju something",
+		When:           when,
+		ModelUUID:      model,
+		ConversationID: id,
+		ConnectionID:   "AC1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *QuerySuite) addRequest(c *gc.C, log auditlog.AuditLog, conversation string, id uint64, facade, method string) {
+	err := log.AddRequest(auditlog.Request{
+		ConversationID: conversation,
+		ConnectionID:   "AC1",
+		RequestID:      id,
+		Facade:         facade,
+		Method:         method,
+		Version:        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *QuerySuite) addResponse(c *gc.C, log auditlog.AuditLog, conversation string, id uint64, errs ...*auditlog.Error) {
+	err := log.AddResponse(auditlog.ResponseErrors{
+		ConversationID: conversation,
+		ConnectionID:   "AC1",
+		RequestID:      id,
+		Errors:         errs,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func conversationIDs(entries []auditlog.ConversationEntry) []string {
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.Conversation.ConversationID)
+	}
+	return ids
+}
+
+func (s *QuerySuite) TestSearchAll(c *gc.C) {
+	results, err := auditlog.Search(s.dir, auditlog.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversationIDs(results), jc.DeepEquals, []string{"c1", "c2", "c3"})
+	c.Assert(results[1].Requests, gc.HasLen, 2)
+	c.Assert(results[1].Requests[0].Request.Method, gc.Equals, "Deploy")
+	c.Assert(results[1].Requests[0].Errors, jc.DeepEquals, []*auditlog.Error{{Message: "boom", Code: "bad"}})
+	c.Assert(results[1].Requests[0].HasErrors(), jc.IsTrue)
+	c.Assert(results[1].Requests[1].HasErrors(), jc.IsFalse)
+}
+
+func (s *QuerySuite) TestSearchByUserAndModel(c *gc.C) {
+	results, err := auditlog.Search(s.dir, auditlog.Query{User: "bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversationIDs(results), jc.DeepEquals, []string{"c1", "c3"})
+
+	results, err = auditlog.Search(s.dir, auditlog.Query{User: "bob", ModelUUID: "model-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversationIDs(results), jc.DeepEquals, []string{"c3"})
+}
+
+func (s *QuerySuite) TestSearchByFacadeAndMethod(c *gc.C) {
+	results, err := auditlog.Search(s.dir, auditlog.Query{Facade: "Client", Method: "FullStatus"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversationIDs(results), jc.DeepEquals, []string{"c2", "c3"})
+	c.Assert(results[0].Requests, gc.HasLen, 1)
+	c.Assert(results[0].Requests[0].Request.RequestID, gc.Equals, uint64(2))
+}
+
+func (s *QuerySuite) TestSearchByTimeRange(c *gc.C) {
+	results, err := auditlog.Search(s.dir, auditlog.Query{
+		After:  time.Date(2021, 3, 1, 11, 0, 0, 0, time.UTC),
+		Before: time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversationIDs(results), jc.DeepEquals, []string{"c2"})
+}
+
+func (s *QuerySuite) TestSearchErrorsOnly(c *gc.C) {
+	results, err := auditlog.Search(s.dir, auditlog.Query{ErrorsOnly: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversationIDs(results), jc.DeepEquals, []string{"c2"})
+	c.Assert(results[0].Requests, gc.HasLen, 1)
+	c.Assert(results[0].Requests[0].Request.Method, gc.Equals, "Deploy")
+}
+
+func (s *QuerySuite) TestSearchLimitKeepsMostRecent(c *gc.C) {
+	results, err := auditlog.Search(s.dir, auditlog.Query{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversationIDs(results), jc.DeepEquals, []string{"c2", "c3"})
+}
+
+func (s *QuerySuite) TestSearchLimitWithFilter(c *gc.C) {
+	results, err := auditlog.Search(s.dir, auditlog.Query{Facade: "Application", Limit: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversationIDs(results), jc.DeepEquals, []string{"c2"})
+}
+
+func (s *QuerySuite) TestSearchLimitKeepsMostRecentlyStarted(c *gc.C) {
+	logFile := auditlog.NewLogFile(s.dir, 300, 10)
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("many-%d", i)
+		s.addConversation(c, logFile, id, "bob", "model-1", "2021-03-01T13:00:00Z")
+		s.addRequest(c, logFile, id, 1, "Client", "FullStatus")
+		s.addResponse(c, logFile, id, 1, &auditlog.Error{Message: "boom"})
+	}
+	// The first of these conversations only fails after the second
+	// one, but is still returned in the order it started.
+	s.addConversation(c, logFile, "c4", "bob", "model-1", "2021-03-01T14:00:00Z")
+	s.addRequest(c, logFile, "c4", 1, "Client", "FullStatus")
+	s.addConversation(c, logFile, "c5", "bob", "model-1", "2021-03-01T14:00:01Z")
+	s.addRequest(c, logFile, "c5", 1, "Client", "FullStatus")
+	s.addResponse(c, logFile, "c5", 1, &auditlog.Error{Message: "boom"})
+	s.addResponse(c, logFile, "c4", 1, &auditlog.Error{Message: "boom"})
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+
+	results, err := auditlog.Search(s.dir, auditlog.Query{ErrorsOnly: true, Limit: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversationIDs(results), jc.DeepEquals, []string{"many-99", "c4", "c5"})
+
+	results, err = auditlog.Search(s.dir, auditlog.Query{Limit: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversationIDs(results), jc.DeepEquals, []string{"c5"})
+}
+
+func (s *QuerySuite) TestSearchReadsCompressedBackups(c *gc.C) {
+	// Move the current log into a compressed backup and start a
+	// new one, the same way lumberjack rotates files.
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, "audit.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	backup, err := os.Create(filepath.Join(s.dir, "audit-2021-03-01T13-00-00.000.log.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	gz := gzip.NewWriter(backup)
+	_, err = gz.Write(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gz.Close(), jc.ErrorIsNil)
+	c.Assert(backup.Close(), jc.ErrorIsNil)
+	c.Assert(os.Remove(filepath.Join(s.dir, "audit.log")), jc.ErrorIsNil)
+
+	logFile := auditlog.NewLogFile(s.dir, 300, 10)
+	// Responses for a conversation started before the rotation are
+	// still joined up.
+	s.addRequest(c, logFile, "c3", 2, "Application", "Deploy")
+	s.addResponse(c, logFile, "c3", 2, &auditlog.Error{Message: "nope"})
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+
+	results, err := auditlog.Search(s.dir, auditlog.Query{ErrorsOnly: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversationIDs(results), jc.DeepEquals, []string{"c2", "c3"})
+	c.Assert(results[1].Requests[0].Errors, jc.DeepEquals, []*auditlog.Error{{Message: "nope"}})
+}
+
+func (s *QuerySuite) TestSearchNoLogFile(c *gc.C) {
+	results, err := auditlog.Search(c.MkDir(), auditlog.Query{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+}
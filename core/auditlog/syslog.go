@@ -0,0 +1,184 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+const (
+	// syslogPriority is the RFC 5424 PRI value used for audit records:
+	// facility 13 (log audit) and severity 6 (informational).
+	syslogPriority = 13*8 + 6
+
+	// syslogAppName is the APP-NAME used for audit records.
+	syslogAppName = "juju-audit"
+
+	// syslogDialTimeout is how long we wait when connecting to the
+	// syslog server.
+	syslogDialTimeout = 10 * time.Second
+)
+
+// SyslogConfig holds the settings for a syslog audit log target.
+type SyslogConfig struct {
+	// Address is the address of the syslog server, in the form
+	// "tcp://host:port" or "udp://host:port".
+	Address string
+
+	// Clock is used to timestamp messages.
+	Clock clock.Clock
+
+	// Dial is used to connect to the syslog server. If nil,
+	// net.DialTimeout is used.
+	Dial func(network, address string, timeout time.Duration) (net.Conn, error)
+}
+
+// Validate checks the syslog target configuration.
+func (cfg SyslogConfig) Validate() error {
+	if _, _, err := ParseSyslogAddress(cfg.Address); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// ParseSyslogAddress splits a syslog address of the form
+// "tcp://host:port" or "udp://host:port" into its network and
+// host:port parts.
+func ParseSyslogAddress(address string) (network, hostPort string, _ error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", "", errors.NewNotValid(err, fmt.Sprintf("syslog address %q", address))
+	}
+	if u.Scheme != "tcp" && u.Scheme != "udp" {
+		return "", "", errors.NotValidf("syslog address %q (expected tcp:// or udp://)", address)
+	}
+	if u.Hostname() == "" || u.Port() == "" {
+		return "", "", errors.NotValidf("syslog address %q (expected host and port)", address)
+	}
+	return u.Scheme, u.Host, nil
+}
+
+type auditLogSyslog struct {
+	network  string
+	hostPort string
+	hostname string
+	clock    clock.Clock
+	dial     func(network, address string, timeout time.Duration) (net.Conn, error)
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslog returns an audit entry sink which sends each record, as
+// JSON, to a remote syslog server (RFC 5424). The connection is made
+// lazily and re-established if it fails; records that can't be
+// delivered are dropped with a warning, so that a syslog outage
+// doesn't break the API server.
+func NewSyslog(cfg SyslogConfig) (AuditLog, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	network, hostPort, _ := ParseSyslogAddress(cfg.Address)
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	dial := cfg.Dial
+	if dial == nil {
+		dial = net.DialTimeout
+	}
+	return &auditLogSyslog{
+		network:  network,
+		hostPort: hostPort,
+		hostname: hostname,
+		clock:    cfg.Clock,
+		dial:     dial,
+	}, nil
+}
+
+// AddConversation implements AuditLog.
+func (a *auditLogSyslog) AddConversation(c Conversation) error {
+	return errors.Trace(a.addRecord(Record{Conversation: &c}))
+}
+
+// AddRequest implements AuditLog.
+func (a *auditLogSyslog) AddRequest(m Request) error {
+	return errors.Trace(a.addRecord(Record{Request: &m}))
+}
+
+// AddResponse implements AuditLog.
+func (a *auditLogSyslog) AddResponse(m ResponseErrors) error {
+	return errors.Trace(a.addRecord(Record{Errors: &m}))
+}
+
+// Close implements AuditLog.
+func (a *auditLogSyslog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.conn == nil {
+		return nil
+	}
+	err := a.conn.Close()
+	a.conn = nil
+	return errors.Trace(err)
+}
+
+func (a *auditLogSyslog) addRecord(r Record) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	msg := a.format(body)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// Try twice, so that a connection which has been dropped by the
+	// server since the last record is transparently re-established.
+	for attempt := 0; attempt < 2; attempt++ {
+		if a.conn == nil {
+			conn, err := a.dial(a.network, a.hostPort, syslogDialTimeout)
+			if err != nil {
+				logger.Warningf("dropping audit record: connecting to syslog %s: %v", a.hostPort, err)
+				return nil
+			}
+			a.conn = conn
+		}
+		if _, err = a.conn.Write(msg); err == nil {
+			return nil
+		}
+		_ = a.conn.Close()
+		a.conn = nil
+	}
+	logger.Warningf("dropping audit record: writing to syslog %s: %v", a.hostPort, err)
+	return nil
+}
+
+// format builds the RFC 5424 message for the record. Messages sent
+// over TCP use octet-counting framing (RFC 6587).
+func (a *auditLogSyslog) format(body []byte) []byte {
+	msg := fmt.Sprintf("<%d>1 %s %s %s %d - - %s",
+		syslogPriority,
+		a.clock.Now().UTC().Format(time.RFC3339),
+		a.hostname,
+		syslogAppName,
+		os.Getpid(),
+		body,
+	)
+	if a.network == "tcp" {
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+	return []byte(msg)
+}
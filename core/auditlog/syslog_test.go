@@ -0,0 +1,144 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type SyslogSuite struct {
+	testing.IsolationSuite
+
+	clock *testclock.Clock
+	dials []string
+	conns []*fakeConn
+}
+
+var _ = gc.Suite(&SyslogSuite{})
+
+func (s *SyslogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	logTime, err := time.Parse(time.RFC3339, "2017-11-27T15:45:23Z")
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock = testclock.NewClock(logTime)
+	s.dials = nil
+	s.conns = nil
+}
+
+func (s *SyslogSuite) dial(network, address string, _ time.Duration) (net.Conn, error) {
+	s.dials = append(s.dials, network+" "+address)
+	conn := &fakeConn{}
+	s.conns = append(s.conns, conn)
+	return conn, nil
+}
+
+func (s *SyslogSuite) TestParseSyslogAddress(c *gc.C) {
+	network, hostPort, err := auditlog.ParseSyslogAddress("tcp://10.0.0.1:601")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(network, gc.Equals, "tcp")
+	c.Check(hostPort, gc.Equals, "10.0.0.1:601")
+
+	_, _, err = auditlog.ParseSyslogAddress("10.0.0.1:601")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	_, _, err = auditlog.ParseSyslogAddress("udp://10.0.0.1")
+	c.Check(err, gc.ErrorMatches, `syslog address "udp://10.0.0.1" \(expected host and port\) not valid`)
+}
+
+func (s *SyslogSuite) TestSendsRecordsOverTCP(c *gc.C) {
+	target, err := auditlog.NewSyslog(auditlog.SyslogConfig{
+		Address: "tcp://10.0.0.1:601",
+		Clock:   s.clock,
+		Dial:    s.dial,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = target.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      25,
+		When:           "2017-12-12T11:34:56Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        4,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target.Close(), jc.ErrorIsNil)
+
+	c.Assert(s.dials, jc.DeepEquals, []string{"tcp 10.0.0.1:601"})
+	c.Assert(s.conns, gc.HasLen, 1)
+	hostname, err := os.Hostname()
+	c.Assert(err, jc.ErrorIsNil)
+	msg := fmt.Sprintf(`<110>1 2017-11-27T15:45:23Z %s juju-audit %d - - `+
+		`{"request":{"conversation-id":"0123456789abcdef","connection-id":"AC1","request-id":25,`+
+		`"when":"2017-12-12T11:34:56Z","facade":"Application","method":"Deploy","version":4}}`,
+		hostname, os.Getpid())
+	c.Assert(s.conns[0].String(), gc.Equals, fmt.Sprintf("%d %s", len(msg), msg))
+	c.Assert(s.conns[0].closed, jc.IsTrue)
+}
+
+func (s *SyslogSuite) TestReconnectsAfterWriteFailure(c *gc.C) {
+	target, err := auditlog.NewSyslog(auditlog.SyslogConfig{
+		Address: "udp://10.0.0.1:514",
+		Clock:   s.clock,
+		Dial:    s.dial,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(target.AddConversation(auditlog.Conversation{Who: "deerhoof"}), jc.ErrorIsNil)
+	s.conns[0].err = errors.New("connection reset")
+	c.Assert(target.AddConversation(auditlog.Conversation{Who: "gojira"}), jc.ErrorIsNil)
+
+	c.Assert(s.dials, gc.HasLen, 2)
+	c.Check(s.conns[0].closed, jc.IsTrue)
+	c.Check(s.conns[1].String(), jc.Contains, `"who":"gojira"`)
+}
+
+func (s *SyslogSuite) TestDialFailureDropsRecord(c *gc.C) {
+	target, err := auditlog.NewSyslog(auditlog.SyslogConfig{
+		Address: "udp://10.0.0.1:514",
+		Clock:   s.clock,
+		Dial: func(string, string, time.Duration) (net.Conn, error) {
+			return nil, errors.New("no route to host")
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = target.AddConversation(auditlog.Conversation{Who: "deerhoof"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type fakeConn struct {
+	net.Conn
+	bytes.Buffer
+	err    error
+	closed bool
+}
+
+func (c *fakeConn) Write(b []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.Buffer.Write(b)
+}
+
+func (c *fakeConn) Read(b []byte) (int, error) {
+	return c.Buffer.Read(b)
+}
+
+func (c *fakeConn) Close() error {
+	c.closed = true
+	return nil
+}
@@ -0,0 +1,291 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+const (
+	// DefaultWebhookBatchSize is the default maximum number of records
+	// posted to a webhook in one request.
+	DefaultWebhookBatchSize = 100
+
+	// DefaultWebhookFlushInterval is the default maximum time a record
+	// waits in the buffer before being posted.
+	DefaultWebhookFlushInterval = 5 * time.Second
+
+	// DefaultWebhookRetryDelay is the default initial delay before
+	// retrying a failed post. The delay doubles after each failure.
+	DefaultWebhookRetryDelay = time.Second
+
+	// DefaultWebhookMaxRetryDelay is the default upper bound of the
+	// delay between retries.
+	DefaultWebhookMaxRetryDelay = 5 * time.Minute
+
+	webhookRequestTimeout = 30 * time.Second
+)
+
+// HTTPDoer sends HTTP requests.
+type HTTPDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// WebhookConfig holds the settings for a webhook audit log target.
+type WebhookConfig struct {
+	// URL is the http(s) endpoint that batches of records are posted
+	// to, as a JSON list.
+	URL string
+
+	// BatchSize is the maximum number of records posted in one
+	// request. Defaults to DefaultWebhookBatchSize.
+	BatchSize int
+
+	// BufferDir is the directory holding records that haven't been
+	// delivered yet. Undelivered records are kept across restarts.
+	BufferDir string
+
+	// MaxBufferSizeMB is the maximum size of the buffer. When it is
+	// exceeded, the oldest undelivered records are dropped.
+	MaxBufferSizeMB int
+
+	// FlushInterval is the maximum time a record waits before being
+	// posted. Defaults to DefaultWebhookFlushInterval.
+	FlushInterval time.Duration
+
+	// RetryDelay and MaxRetryDelay control the exponential backoff
+	// used when a post fails.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+
+	// Clock is used for flushing and retries.
+	Clock clock.Clock
+
+	// Client is used to post records. If nil, a default HTTP client
+	// is used.
+	Client HTTPDoer
+}
+
+// Validate checks the webhook target configuration.
+func (cfg WebhookConfig) Validate() error {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NewNotValid(err, "webhook URL")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NotValidf("webhook URL %q", cfg.URL)
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative BatchSize")
+	}
+	if cfg.BufferDir == "" {
+		return errors.NotValidf("empty BufferDir")
+	}
+	if cfg.MaxBufferSizeMB <= 0 {
+		return errors.NotValidf("non-positive MaxBufferSizeMB")
+	}
+	if cfg.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+func (cfg WebhookConfig) withDefaults() WebhookConfig {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = DefaultWebhookBatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultWebhookFlushInterval
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = DefaultWebhookRetryDelay
+	}
+	if cfg.MaxRetryDelay < cfg.RetryDelay {
+		cfg.MaxRetryDelay = DefaultWebhookMaxRetryDelay
+		if cfg.MaxRetryDelay < cfg.RetryDelay {
+			cfg.MaxRetryDelay = cfg.RetryDelay
+		}
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: webhookRequestTimeout}
+	}
+	return cfg
+}
+
+type auditLogWebhook struct {
+	config WebhookConfig
+	buffer *diskBuffer
+
+	// full is signalled when a segment of the buffer fills up, so it
+	// can be posted without waiting for the flush interval.
+	full chan struct{}
+	stop chan struct{}
+	done chan struct{}
+
+	// ctx is cancelled when the webhook is closed, abandoning any
+	// post in progress.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewWebhook returns an audit entry sink which posts batches of
+// records to an HTTP endpoint. Records are written to a bounded
+// on-disk buffer first and delivered in the background, with failed
+// posts retried (with backoff) until they succeed, so a slow or
+// unavailable endpoint doesn't hold up the API server.
+func NewWebhook(cfg WebhookConfig) (AuditLog, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg = cfg.withDefaults()
+	buffer, err := openDiskBuffer(cfg.BufferDir, cfg.BatchSize, int64(cfg.MaxBufferSizeMB)*1024*1024)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening webhook buffer")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &auditLogWebhook{
+		config: cfg,
+		buffer: buffer,
+		full:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	go w.loop()
+	return w, nil
+}
+
+// AddConversation implements AuditLog.
+func (w *auditLogWebhook) AddConversation(c Conversation) error {
+	return errors.Trace(w.addRecord(Record{Conversation: &c}))
+}
+
+// AddRequest implements AuditLog.
+func (w *auditLogWebhook) AddRequest(m Request) error {
+	return errors.Trace(w.addRecord(Record{Request: &m}))
+}
+
+// AddResponse implements AuditLog.
+func (w *auditLogWebhook) AddResponse(m ResponseErrors) error {
+	return errors.Trace(w.addRecord(Record{Errors: &m}))
+}
+
+// Close implements AuditLog. Any records that haven't been delivered
+// stay in the buffer, to be delivered by the next webhook target
+// using the same buffer directory.
+func (w *auditLogWebhook) Close() error {
+	select {
+	case <-w.stop:
+	default:
+		close(w.stop)
+		w.cancel()
+	}
+	<-w.done
+	return errors.Trace(w.buffer.close())
+}
+
+func (w *auditLogWebhook) addRecord(r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	full, err := w.buffer.append(data)
+	if err != nil {
+		return errors.Annotate(err, "buffering audit record")
+	}
+	if full {
+		select {
+		case w.full <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (w *auditLogWebhook) loop() {
+	defer close(w.done)
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-w.full:
+		case <-w.config.Clock.After(w.config.FlushInterval):
+		}
+		if !w.flush() {
+			return
+		}
+	}
+}
+
+// flush posts everything in the buffer, retrying failures. It returns
+// false if the webhook was closed.
+func (w *auditLogWebhook) flush() bool {
+	delay := w.config.RetryDelay
+	for {
+		seq, records, ok, err := w.buffer.oldest()
+		if err != nil {
+			logger.Errorf("reading audit webhook buffer: %v", err)
+			return true
+		}
+		if !ok {
+			return true
+		}
+		if err := w.post(records); err != nil {
+			logger.Warningf("posting %d audit records (retrying in %s): %v", len(records), delay, err)
+			select {
+			case <-w.stop:
+				return false
+			case <-w.config.Clock.After(delay):
+			}
+			delay *= 2
+			if delay > w.config.MaxRetryDelay {
+				delay = w.config.MaxRetryDelay
+			}
+			continue
+		}
+		w.buffer.remove(seq)
+		delay = w.config.RetryDelay
+
+		select {
+		case <-w.stop:
+			return false
+		default:
+		}
+	}
+}
+
+func (w *auditLogWebhook) post(records [][]byte) error {
+	body := make([]byte, 0, 2+len(records))
+	body = append(body, '[')
+	body = append(body, bytes.Join(records, []byte(","))...)
+	body = append(body, ']')
+
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.config.Client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
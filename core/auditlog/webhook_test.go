@@ -0,0 +1,210 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WebhookSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	dir    string
+	client *fakeDoer
+}
+
+var _ = gc.Suite(&WebhookSuite{})
+
+func (s *WebhookSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.dir = c.MkDir()
+	s.client = &fakeDoer{posts: make(chan []auditlog.Record, 10)}
+}
+
+func (s *WebhookSuite) config() auditlog.WebhookConfig {
+	return auditlog.WebhookConfig{
+		URL:             "https://audit.example.com/ingest",
+		BatchSize:       2,
+		BufferDir:       s.dir,
+		MaxBufferSizeMB: 1,
+		FlushInterval:   time.Minute,
+		RetryDelay:      time.Second,
+		MaxRetryDelay:   4 * time.Second,
+		Clock:           s.clock,
+		Client:          s.client,
+	}
+}
+
+func (s *WebhookSuite) TestValidate(c *gc.C) {
+	cfg := s.config()
+	cfg.URL = "ftp://audit.example.com"
+	_, err := auditlog.NewWebhook(cfg)
+	c.Check(err, gc.ErrorMatches, `webhook URL "ftp://audit.example.com" not valid`)
+
+	cfg = s.config()
+	cfg.MaxBufferSizeMB = 0
+	_, err = auditlog.NewWebhook(cfg)
+	c.Check(err, gc.ErrorMatches, `non-positive MaxBufferSizeMB not valid`)
+}
+
+func (s *WebhookSuite) TestPostsFullBatch(c *gc.C) {
+	target, err := auditlog.NewWebhook(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer target.Close()
+
+	c.Assert(target.AddConversation(auditlog.Conversation{Who: "deerhoof"}), jc.ErrorIsNil)
+	c.Assert(target.AddRequest(auditlog.Request{RequestID: 25, Facade: "Application"}), jc.ErrorIsNil)
+
+	batch := s.nextPost(c)
+	c.Assert(batch, gc.HasLen, 2)
+	c.Check(batch[0].Conversation.Who, gc.Equals, "deerhoof")
+	c.Check(batch[1].Request.Facade, gc.Equals, "Application")
+	c.Check(s.client.contentType, gc.Equals, "application/json")
+}
+
+func (s *WebhookSuite) TestPostsPartialBatchAfterFlushInterval(c *gc.C) {
+	target, err := auditlog.NewWebhook(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer target.Close()
+
+	c.Assert(target.AddConversation(auditlog.Conversation{Who: "deerhoof"}), jc.ErrorIsNil)
+	s.assertNoPost(c)
+
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	batch := s.nextPost(c)
+	c.Assert(batch, gc.HasLen, 1)
+	c.Check(batch[0].Conversation.Who, gc.Equals, "deerhoof")
+}
+
+func (s *WebhookSuite) TestRetriesFailedPost(c *gc.C) {
+	s.client.setErrors(errors.New("connection refused"))
+	target, err := auditlog.NewWebhook(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer target.Close()
+
+	c.Assert(target.AddConversation(auditlog.Conversation{Who: "deerhoof"}), jc.ErrorIsNil)
+	c.Assert(target.AddConversation(auditlog.Conversation{Who: "gojira"}), jc.ErrorIsNil)
+
+	// The first attempt fails, and is retried after the retry delay.
+	failed := s.nextPost(c)
+	c.Assert(failed, gc.HasLen, 2)
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	batch := s.nextPost(c)
+	c.Assert(batch, jc.DeepEquals, failed)
+}
+
+func (s *WebhookSuite) TestUndeliveredRecordsSurviveClose(c *gc.C) {
+	target, err := auditlog.NewWebhook(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target.AddConversation(auditlog.Conversation{Who: "deerhoof"}), jc.ErrorIsNil)
+	c.Assert(target.Close(), jc.ErrorIsNil)
+	s.assertNoPost(c)
+
+	segments, err := filepath.Glob(filepath.Join(s.dir, "*.jsonl"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(segments, gc.HasLen, 1)
+
+	target, err = auditlog.NewWebhook(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer target.Close()
+	// Both the closed target's and the new target's flush timers
+	// are waiting on the clock.
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 2), jc.ErrorIsNil)
+
+	batch := s.nextPost(c)
+	c.Assert(batch, gc.HasLen, 1)
+	c.Check(batch[0].Conversation.Who, gc.Equals, "deerhoof")
+}
+
+func (s *WebhookSuite) TestBufferIsBounded(c *gc.C) {
+	s.client.setErrors(errors.New("connection refused"))
+	cfg := s.config()
+	cfg.BatchSize = 10
+	target, err := auditlog.NewWebhook(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	defer target.Close()
+
+	// Each record is ~100KB, so 1MB of buffer holds about one
+	// segment's worth.
+	what := strings.Repeat("x", 100*1024)
+	for i := 0; i < 30; i++ {
+		c.Assert(target.AddConversation(auditlog.Conversation{What: what}), jc.ErrorIsNil)
+	}
+
+	var total int64
+	segments, err := filepath.Glob(filepath.Join(s.dir, "*.jsonl"))
+	c.Assert(err, jc.ErrorIsNil)
+	for _, segment := range segments {
+		data, err := ioutil.ReadFile(segment)
+		c.Assert(err, jc.ErrorIsNil)
+		total += int64(len(data))
+	}
+	c.Assert(total <= 1024*1024, jc.IsTrue, gc.Commentf("buffer holds %d bytes", total))
+}
+
+func (s *WebhookSuite) nextPost(c *gc.C) []auditlog.Record {
+	select {
+	case batch := <-s.client.posts:
+		return batch
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for post")
+	}
+	return nil
+}
+
+func (s *WebhookSuite) assertNoPost(c *gc.C) {
+	select {
+	case batch := <-s.client.posts:
+		c.Fatalf("unexpected post: %v", batch)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeDoer struct {
+	testing.Stub
+	posts       chan []auditlog.Record
+	contentType string
+}
+
+func (d *fakeDoer) setErrors(errs ...error) {
+	d.Stub.SetErrors(errs...)
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	d.AddCall("Do")
+	d.contentType = req.Header.Get("Content-Type")
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	var batch []auditlog.Record
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return nil, err
+	}
+	d.posts <- batch
+	if err := d.NextErr(); err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron-like schedules, as used to configure
+// periodic controller tasks such as scheduled backups.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// maxSearch is how far ahead Next looks for a matching time. It is
+// long enough to find a 29th of February.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// descriptors are the shorthand schedules that may be used in place
+// of the five fields.
+var descriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+type fieldRange struct {
+	name     string
+	min, max int
+}
+
+var fieldRanges = []fieldRange{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	// Both 0 and 7 mean Sunday.
+	{"day of week", 0, 7},
+}
+
+// Schedule is a parsed cron schedule. All times are matched in UTC.
+type Schedule struct {
+	spec string
+
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny record whether the day fields are "*". As
+	// with cron, when both day fields are restricted a day matches
+	// if either field does.
+	domAny, dowAny bool
+}
+
+// Parse parses a schedule made up of the five standard cron fields,
+// "minute hour day-of-month month day-of-week", eg "30 2 * * *" for
+// 02:30 every day. Each field may be "*", a number, a range "a-b",
+// any of those followed by a step "/n", or a comma separated list of
+// them. The shorthands @hourly, @daily, @midnight, @weekly and
+// @monthly are also accepted.
+func Parse(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 1 {
+		expanded, ok := descriptors[fields[0]]
+		if !ok {
+			return nil, errors.NotValidf("schedule %q", spec)
+		}
+		fields = strings.Fields(expanded)
+	}
+	if len(fields) != len(fieldRanges) {
+		return nil, errors.NotValidf("schedule %q with %d fields", spec, len(fields))
+	}
+	values := make([]uint64, len(fields))
+	for i, field := range fields {
+		bits, err := parseField(field, fieldRanges[i])
+		if err != nil {
+			return nil, errors.Annotatef(err, "schedule %q", spec)
+		}
+		values[i] = bits
+	}
+	s := &Schedule{
+		spec:   strings.Join(strings.Fields(spec), " "),
+		minute: values[0],
+		hour:   values[1],
+		dom:    values[2],
+		month:  values[3],
+		dow:    values[4],
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+	// Fold Sunday as 7 into Sunday as 0.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	if s.Next(time.Time{}).IsZero() {
+		return nil, errors.NewNotValid(nil, fmt.Sprintf("schedule %q never runs", spec))
+	}
+	return s, nil
+}
+
+func parseField(field string, r fieldRange) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, errors.NotValidf("%s step %q", r.name, part[i+1:])
+			}
+			rangePart, step = part[:i], n
+		}
+		low, high := r.min, r.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = parseValue(bounds[0], r); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if high, err = parseValue(bounds[1], r); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if low > high {
+				return 0, errors.NotValidf("%s range %q", r.name, rangePart)
+			}
+		default:
+			value, err := parseValue(rangePart, r)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			low = value
+			if step == 1 {
+				high = value
+			}
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(value string, r fieldRange) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < r.min || n > r.max {
+		return 0, errors.NotValidf("%s %q", r.name, value)
+	}
+	return n, nil
+}
+
+// String returns the schedule as it was parsed.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time matching the schedule that is after t,
+// or the zero time if there isn't one within the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	end := t.Add(maxSearch)
+	for t.Before(end) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if !s.domAny && !s.dowAny {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
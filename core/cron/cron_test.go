@@ -0,0 +1,120 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type cronSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&cronSuite{})
+
+// now is a Monday.
+var now = time.Date(2021, 3, 1, 10, 17, 30, 0, time.UTC)
+
+func (s *cronSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec     string
+		expected time.Time
+	}{{
+		spec:     "30 2 * * *",
+		expected: time.Date(2021, 3, 2, 2, 30, 0, 0, time.UTC),
+	}, {
+		spec:     "*/15 * * * *",
+		expected: time.Date(2021, 3, 1, 10, 30, 0, 0, time.UTC),
+	}, {
+		spec:     "0,45 10-12 * * *",
+		expected: time.Date(2021, 3, 1, 10, 45, 0, 0, time.UTC),
+	}, {
+		spec:     "0 22 * * 1-5",
+		expected: time.Date(2021, 3, 1, 22, 0, 0, 0, time.UTC),
+	}, {
+		spec:     "@weekly",
+		expected: time.Date(2021, 3, 7, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:     "0 0 * * 7",
+		expected: time.Date(2021, 3, 7, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:     "@monthly",
+		expected: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		// When both day fields are restricted either may match.
+		spec:     "0 9 15 * 5",
+		expected: time.Date(2021, 3, 5, 9, 0, 0, 0, time.UTC),
+	}, {
+		spec:     "0 0 29 2 *",
+		expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		schedule, err := cron.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.Next(now), gc.DeepEquals, test.expected)
+	}
+}
+
+func (s *cronSuite) TestNextIsAfterMatchingTime(c *gc.C) {
+	schedule, err := cron.Parse("@hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	t := time.Date(2021, 3, 1, 11, 0, 0, 0, time.UTC)
+	c.Check(schedule.Next(t), gc.DeepEquals, t.Add(time.Hour))
+}
+
+func (s *cronSuite) TestNextUsesUTC(c *gc.C) {
+	schedule, err := cron.Parse("0 12 * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	t := time.Date(2021, 3, 1, 10, 0, 0, 0, time.FixedZone("UTC+5", 5*60*60))
+	c.Check(schedule.Next(t), gc.DeepEquals, time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *cronSuite) TestString(c *gc.C) {
+	schedule, err := cron.Parse(" 30  2 * *   * ")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.String(), gc.Equals, "30 2 * * *")
+}
+
+func (s *cronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `schedule "" with 0 fields not valid`,
+	}, {
+		spec: "* * * *",
+		err:  `schedule "\* \* \* \*" with 4 fields not valid`,
+	}, {
+		spec: "@yearly",
+		err:  `schedule "@yearly" not valid`,
+	}, {
+		spec: "60 * * * *",
+		err:  `schedule "60 \* \* \* \*": minute "60" not valid`,
+	}, {
+		spec: "0 0 0 * *",
+		err:  `schedule "0 0 0 \* \*": day of month "0" not valid`,
+	}, {
+		spec: "5-1 * * * *",
+		err:  `schedule "5-1 \* \* \* \*": minute range "5-1" not valid`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `schedule "\*/0 \* \* \* \*": minute step "0" not valid`,
+	}, {
+		spec: "0 0 30 2 *",
+		err:  `schedule "0 0 30 2 \*" never runs`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := cron.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
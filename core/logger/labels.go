@@ -0,0 +1,58 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logger lets the modules that produce log messages attach
+// labels to them.
+package logger
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/juju/loggo"
+)
+
+// HTTP labels messages about the HTTP requests served and made by
+// the agents.
+const HTTP = "http"
+
+var (
+	mu           sync.RWMutex
+	moduleLabels = make(map[string][]string)
+)
+
+// GetLoggerWithLabels returns the loggo logger for the module, and
+// records the labels to attach to the messages logged by it and its
+// submodules. The version of loggo in use doesn't carry labels with
+// log entries, so they are recorded here for writers to look up.
+func GetLoggerWithLabels(module string, labels ...string) loggo.Logger {
+	logger := loggo.GetLogger(module)
+	// Use the logger's name so that the module matches the one
+	// reported in the entries it writes.
+	module = logger.Name()
+	mu.Lock()
+	defer mu.Unlock()
+	moduleLabels[module] = append([]string(nil), labels...)
+	return logger
+}
+
+// ModuleLabels returns the labels attached to messages logged by the
+// module, which are those of its closest labelled parent module if it
+// has none of its own.
+func ModuleLabels(module string) []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	for {
+		if labels, ok := moduleLabels[module]; ok {
+			if len(labels) == 0 {
+				return nil
+			}
+			return append([]string(nil), labels...)
+		}
+		i := strings.LastIndex(module, ".")
+		if i < 0 {
+			return nil
+		}
+		module = module[:i]
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logger_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/logger"
+)
+
+type labelsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&labelsSuite{})
+
+func (s *labelsSuite) TestGetLoggerWithLabels(c *gc.C) {
+	l := logger.GetLoggerWithLabels("juju.test.Labels", logger.HTTP)
+	c.Assert(l.Name(), gc.Equals, "juju.test.labels")
+	c.Assert(logger.ModuleLabels("juju.test.labels"), jc.DeepEquals, []string{logger.HTTP})
+}
+
+func (s *labelsSuite) TestModuleLabelsInherited(c *gc.C) {
+	logger.GetLoggerWithLabels("juju.test.parent", logger.HTTP)
+	c.Assert(logger.ModuleLabels("juju.test.parent.child"), jc.DeepEquals, []string{logger.HTTP})
+	c.Assert(logger.ModuleLabels("juju.test"), gc.IsNil)
+	c.Assert(logger.ModuleLabels("juju.test.unlabelled"), gc.IsNil)
+}
+
+func (s *labelsSuite) TestModuleLabelsOverridden(c *gc.C) {
+	logger.GetLoggerWithLabels("juju.test.override", logger.HTTP)
+	logger.GetLoggerWithLabels("juju.test.override.child")
+	c.Assert(logger.ModuleLabels("juju.test.override.child.grandchild"), gc.IsNil)
+}
+
+func (s *labelsSuite) TestModuleLabelsCopied(c *gc.C) {
+	logger.GetLoggerWithLabels("juju.test.copied", logger.HTTP)
+	labels := logger.ModuleLabels("juju.test.copied")
+	labels[0] = "mutated"
+	c.Assert(logger.ModuleLabels("juju.test.copied"), jc.DeepEquals, []string{logger.HTTP})
+}
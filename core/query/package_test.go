@@ -9,7 +9,7 @@ import (
 	gc "gopkg.in/check.v1"
 )
 
-//go:generate go run github.com/golang/mock/mockgen -package query -destination scope_mock_test.go github.com/juju/juju/cmd/plugins/juju-wait-for/query FuncScope,Scope
+//go:generate go run github.com/golang/mock/mockgen -package query -destination scope_mock_test.go github.com/juju/juju/core/query FuncScope,Scope
 
 func Test(t *testing.T) {
 	gc.TestingT(t)
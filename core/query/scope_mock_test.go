@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/cmd/plugins/juju-wait-for/query (interfaces: FuncScope,Scope)
+// Source: github.com/juju/juju/core/query (interfaces: FuncScope,Scope)
 
 // Package query is a generated GoMock package.
 package query
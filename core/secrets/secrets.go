@@ -0,0 +1,209 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets holds the types describing the secrets charms use to
+// share sensitive values, such as passwords, with other units.
+package secrets
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+)
+
+// SecretScheme is the scheme of secret URIs.
+const SecretScheme = "secret"
+
+var validSecretID = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// URI identifies a secret, in the form secret:<id>.
+type URI struct {
+	ID string
+}
+
+// NewURI returns a URI for a new secret.
+func NewURI() *URI {
+	return &URI{ID: utils.MustNewUUID().String()}
+}
+
+// ParseURI parses the string form of a secret URI. The scheme is
+// optional, so a bare secret id is accepted.
+func ParseURI(str string) (*URI, error) {
+	id := str
+	if prefix := SecretScheme + ":"; len(str) > len(prefix) && str[:len(prefix)] == prefix {
+		id = str[len(prefix):]
+	}
+	if !validSecretID.MatchString(id) {
+		return nil, errors.NotValidf("secret URI %q", str)
+	}
+	return &URI{ID: id}, nil
+}
+
+// String returns the string form of the URI.
+func (u *URI) String() string {
+	if u == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s", SecretScheme, u.ID)
+}
+
+// RotatePolicy defines how often the owner of a secret is asked to
+// rotate its value.
+type RotatePolicy string
+
+const (
+	RotateNever     RotatePolicy = "never"
+	RotateHourly    RotatePolicy = "hourly"
+	RotateDaily     RotatePolicy = "daily"
+	RotateWeekly    RotatePolicy = "weekly"
+	RotateMonthly   RotatePolicy = "monthly"
+	RotateQuarterly RotatePolicy = "quarterly"
+	RotateYearly    RotatePolicy = "yearly"
+)
+
+var rotateIntervals = map[RotatePolicy]time.Duration{
+	RotateHourly:    time.Hour,
+	RotateDaily:     24 * time.Hour,
+	RotateWeekly:    7 * 24 * time.Hour,
+	RotateMonthly:   30 * 24 * time.Hour,
+	RotateQuarterly: 90 * 24 * time.Hour,
+	RotateYearly:    365 * 24 * time.Hour,
+}
+
+// IsValid returns whether the policy is one of the known policies.
+func (p RotatePolicy) IsValid() bool {
+	_, ok := rotateIntervals[p]
+	return ok || p == RotateNever
+}
+
+// WillRotate returns whether the policy asks for the secret to be
+// rotated.
+func (p RotatePolicy) WillRotate() bool {
+	_, ok := rotateIntervals[p]
+	return ok
+}
+
+// NextRotateTime returns when a secret last rotated at the given time
+// is next due to be rotated, or nil if it is never rotated.
+func (p RotatePolicy) NextRotateTime(lastRotated time.Time) *time.Time {
+	interval, ok := rotateIntervals[p]
+	if !ok {
+		return nil
+	}
+	next := lastRotated.Add(interval)
+	return &next
+}
+
+// SecretRole is the access a unit or application has to a secret.
+type SecretRole string
+
+const (
+	RoleNone   SecretRole = ""
+	RoleView   SecretRole = "view"
+	RoleRotate SecretRole = "rotate"
+	RoleManage SecretRole = "manage"
+)
+
+// IsValid returns whether the role can be granted.
+func (r SecretRole) IsValid() bool {
+	switch r {
+	case RoleView, RoleRotate, RoleManage:
+		return true
+	}
+	return false
+}
+
+// Allowed returns whether the role includes the access of the wanted
+// role.
+func (r SecretRole) Allowed(wanted SecretRole) bool {
+	order := map[SecretRole]int{RoleNone: 0, RoleView: 1, RoleRotate: 2, RoleManage: 3}
+	return order[r] >= order[wanted]
+}
+
+// SecretMetadata describes a secret, without its value.
+type SecretMetadata struct {
+	URI *URI
+
+	// OwnerTag is the tag of the application or unit which created the
+	// secret.
+	OwnerTag string
+
+	Description  string
+	Label        string
+	RotatePolicy RotatePolicy
+
+	// NextRotateTime is when the owner is next asked to rotate the
+	// secret, or nil if it is never rotated.
+	NextRotateTime *time.Time
+
+	// LatestRevision is the revision holding the current value.
+	LatestRevision int
+
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+// SecretValue holds the content of a secret revision as key/value pairs.
+type SecretValue interface {
+	// Values returns a copy of the key/value pairs.
+	Values() map[string]string
+
+	// KeyValue returns the value held under the given key.
+	KeyValue(key string) (string, error)
+
+	// Keys returns the sorted keys of the value.
+	Keys() []string
+
+	// IsEmpty returns whether the value holds no keys.
+	IsEmpty() bool
+}
+
+type secretValue struct {
+	data map[string]string
+}
+
+// NewSecretValue returns a SecretValue holding a copy of the data.
+func NewSecretValue(data map[string]string) SecretValue {
+	v := &secretValue{data: make(map[string]string, len(data))}
+	for k, val := range data {
+		v.data[k] = val
+	}
+	return v
+}
+
+// Values implements SecretValue.
+func (v *secretValue) Values() map[string]string {
+	result := make(map[string]string, len(v.data))
+	for k, val := range v.data {
+		result[k] = val
+	}
+	return result
+}
+
+// KeyValue implements SecretValue.
+func (v *secretValue) KeyValue(key string) (string, error) {
+	val, ok := v.data[key]
+	if !ok {
+		return "", errors.NotFoundf("secret key %q", key)
+	}
+	return val, nil
+}
+
+// Keys implements SecretValue.
+func (v *secretValue) Keys() []string {
+	keys := make([]string, 0, len(v.data))
+	for k := range v.data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsEmpty implements SecretValue.
+func (v *secretValue) IsEmpty() bool {
+	return len(v.data) == 0
+}
@@ -0,0 +1,65 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+type SecretsSuite struct{}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) TestParseURI(c *gc.C) {
+	uri := secrets.NewURI()
+	parsed, err := secrets.ParseURI(uri.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, jc.DeepEquals, uri)
+
+	parsed, err = secrets.ParseURI(uri.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, jc.DeepEquals, uri)
+}
+
+func (s *SecretsSuite) TestParseURIInvalid(c *gc.C) {
+	_, err := secrets.ParseURI("secret:foo")
+	c.Assert(err, gc.ErrorMatches, `secret URI "secret:foo" not valid`)
+}
+
+func (s *SecretsSuite) TestRotatePolicy(c *gc.C) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.Assert(secrets.RotateNever.IsValid(), jc.IsTrue)
+	c.Assert(secrets.RotateNever.WillRotate(), jc.IsFalse)
+	c.Assert(secrets.RotateNever.NextRotateTime(now), gc.IsNil)
+	c.Assert(secrets.RotateDaily.WillRotate(), jc.IsTrue)
+	c.Assert(*secrets.RotateDaily.NextRotateTime(now), gc.Equals, now.Add(24*time.Hour))
+	c.Assert(secrets.RotatePolicy("fortnightly").IsValid(), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestRoleAllowed(c *gc.C) {
+	c.Assert(secrets.RoleManage.Allowed(secrets.RoleView), jc.IsTrue)
+	c.Assert(secrets.RoleView.Allowed(secrets.RoleView), jc.IsTrue)
+	c.Assert(secrets.RoleView.Allowed(secrets.RoleRotate), jc.IsFalse)
+	c.Assert(secrets.RoleNone.Allowed(secrets.RoleView), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestSecretValue(c *gc.C) {
+	data := map[string]string{"password": "secret", "user": "admin"}
+	value := secrets.NewSecretValue(data)
+	data["user"] = "changed"
+	c.Assert(value.Keys(), jc.DeepEquals, []string{"password", "user"})
+	v, err := value.KeyValue("user")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(v, gc.Equals, "admin")
+	_, err = value.KeyValue("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(value.IsEmpty(), jc.IsFalse)
+	c.Assert(secrets.NewSecretValue(nil).IsEmpty(), jc.IsTrue)
+}
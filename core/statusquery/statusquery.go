@@ -0,0 +1,357 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package statusquery filters the status of a model with an expression
+// written in the query language used by juju-wait-for.
+//
+// The expression is evaluated for each unit in the model. The unit's
+// own fields are available directly, and those of the machine hosting
+// it and of its application through the "machine" and "application"
+// prefixes:
+//
+//	workload-status != "active" && machine.az == "us-east-1a"
+package statusquery
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/query"
+)
+
+// Filter holds a parsed status query.
+type Filter struct {
+	src   string
+	query query.Query
+}
+
+// Parse parses the query expression, returning an error if it isn't
+// valid.
+func Parse(src string) (Filter, error) {
+	q, err := query.Parse(src)
+	if err != nil {
+		return Filter{}, errors.Annotatef(err, "parsing query %q", src)
+	}
+	return Filter{
+		src:   src,
+		query: q,
+	}, nil
+}
+
+// String returns the query expression.
+func (f Filter) String() string {
+	return f.src
+}
+
+// Apply returns a copy of the status holding only the units for which
+// the query is true. The applications and machines of those units are
+// kept, along with the relations, offers and remote applications of the
+// kept applications. A principal unit is kept when one of its
+// subordinates matches, so that the subordinate can be shown.
+func (f Filter) Apply(status *params.FullStatus) (*params.FullStatus, error) {
+	result := *status
+	result.Applications = make(map[string]params.ApplicationStatus)
+	machineIds := set.NewStrings()
+
+	keep := func(appName string, app params.ApplicationStatus, unitName string, unit params.UnitStatus) {
+		kept, ok := result.Applications[appName]
+		if !ok {
+			kept = app
+			kept.Units = make(map[string]params.UnitStatus)
+		}
+		if unitName != "" {
+			kept.Units[unitName] = unit
+		}
+		result.Applications[appName] = kept
+		if unit.Machine != "" {
+			machineIds.Add(unit.Machine)
+		}
+	}
+
+	for appName, app := range status.Applications {
+		for unitName, unit := range app.Units {
+			subordinates := make(map[string]params.UnitStatus)
+			for subName, sub := range unit.Subordinates {
+				subAppName := strings.Split(subName, "/")[0]
+				subApp := status.Applications[subAppName]
+				matched, err := f.match(UnitScope{
+					Name:        subName,
+					Application: subAppName,
+					Principal:   unitName,
+					Unit:        sub,
+					App:         subApp,
+					Machine:     findMachine(status.Machines, unit.Machine),
+				})
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if matched {
+					subordinates[subName] = sub
+					// Subordinate units are only listed under their
+					// principal, so the application is kept without any.
+					keep(subAppName, subApp, "", params.UnitStatus{})
+				}
+			}
+
+			matched, err := f.match(UnitScope{
+				Name:        unitName,
+				Application: appName,
+				Unit:        unit,
+				App:         app,
+				Machine:     findMachine(status.Machines, unit.Machine),
+			})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !matched && len(subordinates) == 0 {
+				continue
+			}
+			unit.Subordinates = subordinates
+			keep(appName, app, unitName, unit)
+		}
+	}
+
+	result.Machines = filterMachines(status.Machines, machineIds)
+	result.Relations = nil
+	remoteNames := set.NewStrings()
+	for _, rel := range status.Relations {
+		keepRelation := true
+		for _, ep := range rel.Endpoints {
+			if _, ok := result.Applications[ep.ApplicationName]; ok {
+				continue
+			}
+			if _, ok := status.RemoteApplications[ep.ApplicationName]; ok {
+				remoteNames.Add(ep.ApplicationName)
+				continue
+			}
+			keepRelation = false
+		}
+		if keepRelation {
+			result.Relations = append(result.Relations, rel)
+		}
+	}
+	result.RemoteApplications = make(map[string]params.RemoteApplicationStatus)
+	for name, remote := range status.RemoteApplications {
+		if remoteNames.Contains(name) {
+			result.RemoteApplications[name] = remote
+		}
+	}
+	result.Offers = make(map[string]params.ApplicationOfferStatus)
+	for name, offer := range status.Offers {
+		if _, ok := result.Applications[offer.ApplicationName]; ok {
+			result.Offers[name] = offer
+		}
+	}
+	return &result, nil
+}
+
+func (f Filter) match(scope UnitScope) (bool, error) {
+	matched, err := f.query.BuiltinsRun(scope)
+	if err != nil {
+		return false, errors.Annotatef(err, "running query %q on unit %q", f.src, scope.Name)
+	}
+	return matched, nil
+}
+
+// findMachine returns the status of the machine or container with the
+// given ID, or nil if it isn't found.
+func findMachine(machines map[string]params.MachineStatus, id string) *params.MachineStatus {
+	if id == "" {
+		return nil
+	}
+	top := strings.Split(id, "/")[0]
+	machine, ok := machines[top]
+	if !ok {
+		return nil
+	}
+	for machine.Id != id {
+		found := false
+		for _, container := range machine.Containers {
+			if container.Id == id || strings.HasPrefix(id, container.Id+"/") {
+				machine = container
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	}
+	return &machine
+}
+
+// filterMachines returns the machines hosting units with the given
+// machine IDs. Containers are only kept when they host a unit.
+func filterMachines(machines map[string]params.MachineStatus, ids set.Strings) map[string]params.MachineStatus {
+	result := make(map[string]params.MachineStatus)
+	for id, machine := range machines {
+		if kept, ok := filterMachine(machine, ids); ok {
+			result[id] = kept
+		}
+	}
+	return result
+}
+
+func filterMachine(machine params.MachineStatus, ids set.Strings) (params.MachineStatus, bool) {
+	containers := make(map[string]params.MachineStatus)
+	for id, container := range machine.Containers {
+		if kept, ok := filterMachine(container, ids); ok {
+			containers[id] = kept
+		}
+	}
+	if !ids.Contains(machine.Id) && len(containers) == 0 {
+		return params.MachineStatus{}, false
+	}
+	machine.Containers = containers
+	return machine, true
+}
+
+// UnitScope allows a query to introspect a unit in a model's status,
+// along with its machine and application.
+type UnitScope struct {
+	// Name is the name of the unit.
+	Name string
+
+	// Application is the name of the unit's application.
+	Application string
+
+	// Principal is the name of the principal unit of a subordinate.
+	Principal string
+
+	Unit    params.UnitStatus
+	App     params.ApplicationStatus
+	Machine *params.MachineStatus
+}
+
+var (
+	unitIdents = []string{
+		"name", "application", "principal", "subordinate", "machine",
+		"workload-status", "workload-message", "agent-status",
+		"workload-version", "charm", "leader", "public-address",
+	}
+	machineIdents = []string{
+		"id", "status", "instance-status", "instance-id", "series",
+		"dns-name", "az", "availability-zone", "constraints", "hardware",
+	}
+	applicationIdents = []string{
+		"name", "status", "charm", "charm-channel", "series", "exposed",
+		"life", "workload-version", "subordinate",
+	}
+)
+
+// GetIdents returns the identifiers within the scope.
+func (s UnitScope) GetIdents() []string {
+	idents := append([]string(nil), unitIdents...)
+	for _, ident := range machineIdents {
+		idents = append(idents, "machine."+ident)
+	}
+	for _, ident := range applicationIdents {
+		idents = append(idents, "application."+ident)
+	}
+	return idents
+}
+
+// GetIdentValue returns the value of the identifier in the scope.
+func (s UnitScope) GetIdentValue(name string) (query.Box, error) {
+	if ident := strings.TrimPrefix(name, "machine."); ident != name {
+		return s.machineIdentValue(ident)
+	}
+	if ident := strings.TrimPrefix(name, "application."); ident != name {
+		return s.applicationIdentValue(ident)
+	}
+
+	switch name {
+	case "name":
+		return query.NewString(s.Name), nil
+	case "application":
+		return query.NewString(s.Application), nil
+	case "principal":
+		return query.NewString(s.Principal), nil
+	case "subordinate":
+		return query.NewBool(s.Principal != ""), nil
+	case "machine":
+		return query.NewString(s.Unit.Machine), nil
+	case "workload-status":
+		return query.NewString(s.Unit.WorkloadStatus.Status), nil
+	case "workload-message":
+		return query.NewString(s.Unit.WorkloadStatus.Info), nil
+	case "agent-status":
+		return query.NewString(s.Unit.AgentStatus.Status), nil
+	case "workload-version":
+		return query.NewString(s.Unit.WorkloadVersion), nil
+	case "charm":
+		charm := s.Unit.Charm
+		if charm == "" {
+			charm = s.App.Charm
+		}
+		return query.NewString(charm), nil
+	case "leader":
+		return query.NewBool(s.Unit.Leader), nil
+	case "public-address":
+		return query.NewString(s.Unit.PublicAddress), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name), "Runtime Error: identifier %q not found on Unit", name)
+}
+
+func (s UnitScope) machineIdentValue(name string) (query.Box, error) {
+	// Units without a machine, such as those of k8s models, have
+	// empty values for all the machine fields.
+	var machine params.MachineStatus
+	if s.Machine != nil {
+		machine = *s.Machine
+	}
+
+	switch name {
+	case "id":
+		return query.NewString(machine.Id), nil
+	case "status":
+		return query.NewString(machine.AgentStatus.Status), nil
+	case "instance-status":
+		return query.NewString(machine.InstanceStatus.Status), nil
+	case "instance-id":
+		return query.NewString(string(machine.InstanceId)), nil
+	case "series":
+		return query.NewString(machine.Series), nil
+	case "dns-name":
+		return query.NewString(machine.DNSName), nil
+	case "az", "availability-zone":
+		var zone string
+		if hc, err := instance.ParseHardware(machine.Hardware); err == nil && hc.AvailabilityZone != nil {
+			zone = *hc.AvailabilityZone
+		}
+		return query.NewString(zone), nil
+	case "constraints":
+		return query.NewString(machine.Constraints), nil
+	case "hardware":
+		return query.NewString(machine.Hardware), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier("machine."+name), "Runtime Error: identifier %q not found on Machine", name)
+}
+
+func (s UnitScope) applicationIdentValue(name string) (query.Box, error) {
+	switch name {
+	case "name":
+		return query.NewString(s.Application), nil
+	case "status":
+		return query.NewString(s.App.Status.Status), nil
+	case "charm":
+		return query.NewString(s.App.Charm), nil
+	case "charm-channel":
+		return query.NewString(s.App.CharmChannel), nil
+	case "series":
+		return query.NewString(s.App.Series), nil
+	case "exposed":
+		return query.NewBool(s.App.Exposed), nil
+	case "life":
+		return query.NewString(string(s.App.Life)), nil
+	case "workload-version":
+		return query.NewString(s.App.WorkloadVersion), nil
+	case "subordinate":
+		return query.NewBool(len(s.App.SubordinateTo) > 0), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier("application."+name), "Runtime Error: identifier %q not found on Application", name)
+}
@@ -0,0 +1,190 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statusquery_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/statusquery"
+)
+
+type statusQuerySuite struct{}
+
+var _ = gc.Suite(&statusQuerySuite{})
+
+func (s *statusQuerySuite) TestParseInvalid(c *gc.C) {
+	_, err := statusquery.Parse(`workload-status == "active`)
+	c.Assert(err, gc.ErrorMatches, `parsing query "workload-status == \\"active": .*`)
+}
+
+func (s *statusQuerySuite) TestFilterByWorkloadStatus(c *gc.C) {
+	filter, err := statusquery.Parse(`workload-status == "error"`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(filter.String(), gc.Equals, `workload-status == "error"`)
+
+	result, err := filter.Apply(testStatus())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(appUnits(result), jc.DeepEquals, map[string][]string{
+		"mysql": {"mysql/1"},
+	})
+	c.Check(machineIds(result), jc.SameContents, []string{"1"})
+	c.Check(result.Relations, gc.HasLen, 0)
+	c.Check(result.Offers, gc.HasLen, 1)
+	c.Check(result.RemoteApplications, gc.HasLen, 0)
+}
+
+func (s *statusQuerySuite) TestFilterByMachine(c *gc.C) {
+	filter, err := statusquery.Parse(`machine.az == "us-east-1a"`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := filter.Apply(testStatus())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(appUnits(result), jc.DeepEquals, map[string][]string{
+		"mysql":     {"mysql/0"},
+		"wordpress": {"wordpress/0"},
+		"logging":   nil,
+	})
+	c.Check(machineIds(result), jc.SameContents, []string{"0", "0/lxd/0"})
+	c.Check(result.Machines["0"].Containers, gc.HasLen, 1)
+	c.Check(result.Relations, gc.HasLen, 2)
+	c.Check(result.RemoteApplications, gc.HasLen, 1)
+}
+
+func (s *statusQuerySuite) TestFilterSubordinate(c *gc.C) {
+	filter, err := statusquery.Parse(`subordinate && principal == "mysql/0"`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := filter.Apply(testStatus())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(appUnits(result), jc.DeepEquals, map[string][]string{
+		"mysql":   {"mysql/0"},
+		"logging": nil,
+	})
+	c.Check(result.Applications["mysql"].Units["mysql/0"].Subordinates, gc.HasLen, 1)
+}
+
+func (s *statusQuerySuite) TestFilterNothingMatches(c *gc.C) {
+	filter, err := statusquery.Parse(`application.name == "nope"`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := filter.Apply(testStatus())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Applications, gc.HasLen, 0)
+	c.Check(result.Machines, gc.HasLen, 0)
+	c.Check(result.Relations, gc.HasLen, 0)
+	c.Check(result.Model.Name, gc.Equals, "default")
+}
+
+func (s *statusQuerySuite) TestInvalidIdentifier(c *gc.C) {
+	filter, err := statusquery.Parse(`machine.zone == "a"`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = filter.Apply(testStatus())
+	c.Assert(err, gc.ErrorMatches, `running query "machine.zone == \\"a\\"" on unit .*: Runtime Error: identifier "zone" not found on Machine: invalid identifier`)
+}
+
+func appUnits(status *params.FullStatus) map[string][]string {
+	result := make(map[string][]string)
+	for name, app := range status.Applications {
+		var units []string
+		for unitName := range app.Units {
+			units = append(units, unitName)
+		}
+		result[name] = units
+	}
+	return result
+}
+
+func machineIds(status *params.FullStatus) []string {
+	var ids []string
+	var add func(map[string]params.MachineStatus)
+	add = func(machines map[string]params.MachineStatus) {
+		for _, m := range machines {
+			ids = append(ids, m.Id)
+			add(m.Containers)
+		}
+	}
+	add(status.Machines)
+	return ids
+}
+
+func testStatus() *params.FullStatus {
+	return &params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "default"},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id:       "0",
+				Hardware: "arch=amd64 availability-zone=us-east-1a",
+				Containers: map[string]params.MachineStatus{
+					"0/lxd/0": {
+						Id:       "0/lxd/0",
+						Hardware: "availability-zone=us-east-1a",
+					},
+					"0/lxd/1": {Id: "0/lxd/1"},
+				},
+			},
+			"1": {
+				Id:       "1",
+				Hardware: "arch=amd64 availability-zone=us-east-1b",
+			},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm: "cs:mysql-1",
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						Machine:        "0",
+						WorkloadStatus: params.DetailedStatus{Status: "active"},
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": {
+								WorkloadStatus: params.DetailedStatus{Status: "active"},
+							},
+						},
+					},
+					"mysql/1": {
+						Machine:        "1",
+						WorkloadStatus: params.DetailedStatus{Status: "error"},
+						Subordinates: map[string]params.UnitStatus{
+							"logging/1": {
+								WorkloadStatus: params.DetailedStatus{Status: "active"},
+							},
+						},
+					},
+				},
+			},
+			"wordpress": {
+				Charm: "cs:wordpress-2",
+				Units: map[string]params.UnitStatus{
+					"wordpress/0": {
+						Machine:        "0/lxd/0",
+						WorkloadStatus: params.DetailedStatus{Status: "active"},
+					},
+				},
+			},
+			"logging": {
+				Charm:         "cs:logging-3",
+				SubordinateTo: []string{"mysql"},
+			},
+		},
+		RemoteApplications: map[string]params.RemoteApplicationStatus{
+			"hosted-db": {},
+		},
+		Relations: []params.RelationStatus{{
+			Endpoints: []params.EndpointStatus{
+				{ApplicationName: "mysql"},
+				{ApplicationName: "wordpress"},
+			},
+		}, {
+			Endpoints: []params.EndpointStatus{
+				{ApplicationName: "wordpress"},
+				{ApplicationName: "hosted-db"},
+			},
+		}},
+		Offers: map[string]params.ApplicationOfferStatus{
+			"db": {ApplicationName: "mysql"},
+		},
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package timers
+
+var RandInt63n = &randInt63n
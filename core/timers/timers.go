@@ -0,0 +1,144 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package timers defines the timers charms schedule with the timer-set
+// hook tool. When a timer is due the uniter runs the timer-fired hook
+// for it.
+package timers
+
+import (
+	"math/rand"
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// MinInterval is the shortest interval at which a timer may repeat.
+const MinInterval = time.Minute
+
+var validName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// randInt63n returns the random offsets used to apply jitter.
+var randInt63n = rand.Int63n
+
+// ValidateName returns an error if name is not a valid timer name.
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return errors.NotValidf("timer name %q", name)
+	}
+	return nil
+}
+
+// Timer describes when a charm timer fires.
+type Timer struct {
+	// Due is when the timer is next scheduled to fire, before any
+	// jitter is applied. Repeating timers are rescheduled from it,
+	// so they don't drift.
+	Due time.Time `yaml:"due"`
+
+	// Next is when the timer will next fire: Due, delayed by a
+	// random amount no greater than Jitter.
+	Next time.Time `yaml:"next"`
+
+	// Every is the interval at which the timer repeats, or zero if
+	// it fires once.
+	Every time.Duration `yaml:"every,omitempty"`
+
+	// Jitter is the largest random delay added to each firing, so
+	// that units of the same application don't all fire together.
+	Jitter time.Duration `yaml:"jitter,omitempty"`
+}
+
+// ValidateSchedule returns an error if a timer can't be scheduled to
+// first fire after delay and to repeat at every, with the given jitter.
+func ValidateSchedule(delay, every, jitter time.Duration) error {
+	if delay < 0 {
+		return errors.NotValidf("negative delay %v", delay)
+	}
+	if every != 0 && every < MinInterval {
+		return errors.NotValidf("interval %v shorter than %v", every, MinInterval)
+	}
+	if jitter < 0 {
+		return errors.NotValidf("negative jitter %v", jitter)
+	}
+	if every != 0 && jitter > every {
+		return errors.NotValidf("jitter %v longer than interval %v", jitter, every)
+	}
+	if delay == 0 && every == 0 {
+		return errors.New("timer needs a delay or an interval")
+	}
+	return nil
+}
+
+// New returns a timer which first fires after delay, or after every
+// if delay is zero, and which repeats at every if that is non-zero.
+func New(now time.Time, delay, every, jitter time.Duration) (Timer, error) {
+	if err := ValidateSchedule(delay, every, jitter); err != nil {
+		return Timer{}, errors.Trace(err)
+	}
+	if delay == 0 {
+		delay = every
+	}
+	return Timer{
+		Every:  every,
+		Jitter: jitter,
+	}.scheduled(now.Add(delay)), nil
+}
+
+// Reschedule returns the timer to use once it has fired at now. A
+// repeating timer is next due at the first interval after now, so
+// firings missed while the unit agent was down are not all run. It
+// returns false if the timer doesn't repeat.
+func (t Timer) Reschedule(now time.Time) (Timer, bool) {
+	if t.Every <= 0 {
+		return Timer{}, false
+	}
+	due := t.Due.Add(t.Every)
+	if !due.After(now) {
+		missed := now.Sub(due) / t.Every
+		due = due.Add((missed + 1) * t.Every)
+	}
+	return t.scheduled(due), true
+}
+
+// scheduled returns the timer due at due, with jitter applied.
+func (t Timer) scheduled(due time.Time) Timer {
+	t.Due = due.UTC()
+	t.Next = t.Due
+	if t.Jitter > 0 {
+		t.Next = t.Next.Add(time.Duration(randInt63n(int64(t.Jitter) + 1)))
+	}
+	return t
+}
+
+// Earliest returns the name of the timer which fires first, and
+// false if there are no timers.
+func Earliest(timers map[string]Timer) (string, bool) {
+	var (
+		name  string
+		found bool
+	)
+	for n, t := range timers {
+		if !found || t.Next.Before(timers[name].Next) ||
+			(t.Next.Equal(timers[name].Next) && n < name) {
+			name = n
+			found = true
+		}
+	}
+	return name, found
+}
+
+// ParseUniterState returns the timers recorded in the operation state
+// a unit agent stores on the controller, which holds them under the
+// "timers" key.
+func ParseUniterState(uniterState string) (map[string]Timer, error) {
+	var st struct {
+		Timers map[string]Timer `yaml:"timers"`
+	}
+	if err := yaml.Unmarshal([]byte(uniterState), &st); err != nil {
+		return nil, errors.Annotate(err, "parsing uniter state")
+	}
+	return st.Timers, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package timers_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/timers"
+)
+
+type timersSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&timersSuite{})
+
+var now = time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func (s *timersSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchValue(timers.RandInt63n, func(n int64) int64 { return n - 1 })
+}
+
+func (s *timersSuite) TestValidateName(c *gc.C) {
+	for _, name := range []string{"backup", "log-rotate", "a1-b2"} {
+		c.Check(timers.ValidateName(name), jc.ErrorIsNil)
+	}
+	for _, name := range []string{"", "Backup", "1backup", "log_rotate", "log-", "-log", "log--rotate"} {
+		c.Check(timers.ValidateName(name), gc.ErrorMatches, `timer name ".*" not valid`)
+	}
+}
+
+func (s *timersSuite) TestNewOnce(c *gc.C) {
+	t, err := timers.New(now, 10*time.Minute, 0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t, jc.DeepEquals, timers.Timer{
+		Due:  now.Add(10 * time.Minute),
+		Next: now.Add(10 * time.Minute),
+	})
+}
+
+func (s *timersSuite) TestNewRepeating(c *gc.C) {
+	t, err := timers.New(now, 0, time.Hour, 5*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t, jc.DeepEquals, timers.Timer{
+		Due:    now.Add(time.Hour),
+		Next:   now.Add(time.Hour + 5*time.Minute),
+		Every:  time.Hour,
+		Jitter: 5 * time.Minute,
+	})
+}
+
+func (s *timersSuite) TestNewInvalid(c *gc.C) {
+	for i, test := range []struct {
+		delay, every, jitter time.Duration
+		err                  string
+	}{{
+		err: "timer needs a delay or an interval",
+	}, {
+		delay: -time.Minute,
+		err:   "negative delay -1m0s not valid",
+	}, {
+		every: time.Second,
+		err:   "interval 1s shorter than 1m0s not valid",
+	}, {
+		delay:  time.Minute,
+		jitter: -time.Minute,
+		err:    "negative jitter -1m0s not valid",
+	}, {
+		every:  time.Hour,
+		jitter: 2 * time.Hour,
+		err:    "jitter 2h0m0s longer than interval 1h0m0s not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := timers.New(now, test.delay, test.every, test.jitter)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *timersSuite) TestRescheduleOnce(c *gc.C) {
+	t, err := timers.New(now, time.Minute, 0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := t.Reschedule(now.Add(time.Minute))
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *timersSuite) TestRescheduleRepeating(c *gc.C) {
+	t, err := timers.New(now, 0, time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	// Firing late doesn't move the schedule.
+	next, ok := t.Reschedule(now.Add(time.Hour + time.Minute))
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(next.Due, gc.Equals, now.Add(2*time.Hour))
+	c.Assert(next.Next, gc.Equals, now.Add(2*time.Hour))
+}
+
+func (s *timersSuite) TestRescheduleSkipsMissed(c *gc.C) {
+	t, err := timers.New(now, 0, time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	next, ok := t.Reschedule(now.Add(5 * time.Hour))
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(next.Due, gc.Equals, now.Add(6*time.Hour))
+}
+
+func (s *timersSuite) TestEarliest(c *gc.C) {
+	_, ok := timers.Earliest(nil)
+	c.Assert(ok, jc.IsFalse)
+
+	name, ok := timers.Earliest(map[string]timers.Timer{
+		"c": {Next: now.Add(time.Hour)},
+		"b": {Next: now},
+		"a": {Next: now},
+	})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(name, gc.Equals, "a")
+}
+
+func (s *timersSuite) TestParseUniterState(c *gc.C) {
+	st := `
+op: continue
+opstep: pending
+timers:
+  backup:
+    due: 2021-03-01T10:00:00Z
+    next: 2021-03-01T10:05:00Z
+    every: 3600000000000
+`[1:]
+	result, err := timers.ParseUniterState(st)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, map[string]timers.Timer{
+		"backup": {
+			Due:   now,
+			Next:  now.Add(5 * time.Minute),
+			Every: time.Hour,
+		},
+	})
+}
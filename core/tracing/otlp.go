@@ -0,0 +1,385 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+var logger = loggo.GetLogger("juju.core.tracing")
+
+const (
+	// DefaultBatchSize is the default maximum number of spans sent
+	// to the collector in one request.
+	DefaultBatchSize = 256
+
+	// DefaultQueueSize is the default number of spans waiting to be
+	// exported before new spans are dropped.
+	DefaultQueueSize = 4096
+
+	// DefaultFlushInterval is the default maximum time a span waits
+	// before being exported.
+	DefaultFlushInterval = 5 * time.Second
+
+	otlpRequestTimeout = 30 * time.Second
+
+	// otlpTracesPath is the path the OTLP/HTTP collector accepts
+	// trace exports on.
+	otlpTracesPath = "/v1/traces"
+)
+
+// HTTPDoer sends HTTP requests.
+type HTTPDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// OTLPConfig holds the settings for exporting spans to an
+// OpenTelemetry collector.
+type OTLPConfig struct {
+	// Endpoint is the base http(s) URL of the collector's OTLP/HTTP
+	// receiver, eg "http://10.0.0.1:4318". Spans are posted to the
+	// /v1/traces path below it.
+	Endpoint string
+
+	// ServiceName is reported as the service.name resource
+	// attribute of all spans.
+	ServiceName string
+
+	// ResourceAttributes are added to the resource describing the
+	// process that recorded the spans.
+	ResourceAttributes map[string]string
+
+	// BatchSize is the maximum number of spans sent in one
+	// request. Defaults to DefaultBatchSize.
+	BatchSize int
+
+	// QueueSize is the maximum number of spans waiting to be sent.
+	// Spans are dropped rather than blocking the caller when the
+	// queue is full. Defaults to DefaultQueueSize.
+	QueueSize int
+
+	// FlushInterval is the maximum time a span waits before being
+	// sent. Defaults to DefaultFlushInterval.
+	FlushInterval time.Duration
+
+	// Clock is used for flushing.
+	Clock clock.Clock
+
+	// Client is used to post spans. If nil, a default HTTP client is
+	// used.
+	Client HTTPDoer
+}
+
+// Validate checks the exporter configuration.
+func (cfg OTLPConfig) Validate() error {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return errors.NewNotValid(err, "OTLP endpoint")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NotValidf("OTLP endpoint %q", cfg.Endpoint)
+	}
+	if cfg.ServiceName == "" {
+		return errors.NotValidf("empty ServiceName")
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative BatchSize")
+	}
+	if cfg.QueueSize < 0 {
+		return errors.NotValidf("negative QueueSize")
+	}
+	if cfg.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+func (cfg OTLPConfig) withDefaults() OTLPConfig {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = DefaultQueueSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultFlushInterval
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: otlpRequestTimeout}
+	}
+	return cfg
+}
+
+// OTLPExporter sends spans in batches to an OpenTelemetry collector
+// using the OTLP/HTTP JSON encoding. Spans are exported in the
+// background; failed exports are logged and the spans dropped, as
+// tracing is best effort.
+type OTLPExporter struct {
+	config   OTLPConfig
+	url      string
+	resource otlpResource
+	spans    chan Span
+	dropped  int64
+
+	stop chan struct{}
+	done chan struct{}
+
+	// ctx is cancelled when the exporter is closed, abandoning any
+	// export in progress.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewOTLPExporter returns an exporter that sends spans to the
+// configured collector until it is closed.
+func NewOTLPExporter(cfg OTLPConfig) (*OTLPExporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg = cfg.withDefaults()
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &OTLPExporter{
+		config:   cfg,
+		url:      strings.TrimSuffix(cfg.Endpoint, "/") + otlpTracesPath,
+		resource: newOTLPResource(cfg.ServiceName, cfg.ResourceAttributes),
+		spans:    make(chan Span, cfg.QueueSize),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	go e.loop()
+	return e, nil
+}
+
+// ExportSpan implements Exporter.
+func (e *OTLPExporter) ExportSpan(span Span) {
+	select {
+	case e.spans <- span:
+	default:
+		if atomic.AddInt64(&e.dropped, 1)%1000 == 1 {
+			logger.Warningf("trace export queue full, dropping spans")
+		}
+	}
+}
+
+// Close stops the exporter, abandoning any spans that haven't been
+// sent yet.
+func (e *OTLPExporter) Close() error {
+	select {
+	case <-e.stop:
+	default:
+		close(e.stop)
+		e.cancel()
+	}
+	<-e.done
+	return nil
+}
+
+func (e *OTLPExporter) loop() {
+	defer close(e.done)
+	var (
+		batch []Span
+		flush <-chan time.Time
+	)
+	for {
+		select {
+		case <-e.stop:
+			return
+		case span := <-e.spans:
+			batch = append(batch, span)
+			if len(batch) == 1 {
+				// The flush interval is measured from the
+				// oldest span in the batch.
+				flush = e.config.Clock.After(e.config.FlushInterval)
+			}
+			if len(batch) < e.config.BatchSize {
+				continue
+			}
+		case <-flush:
+		}
+		if err := e.post(batch); err != nil {
+			logger.Warningf("exporting %d spans: %v", len(batch), err)
+		}
+		batch = nil
+		flush = nil
+	}
+}
+
+func (e *OTLPExporter) post(spans []Span) error {
+	body, err := encodeOTLPTraces(e.resource, spans)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req, err := http.NewRequestWithContext(e.ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.config.Client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+// The following types model the subset of the OpenTelemetry trace
+// data model (opentelemetry/proto/collector/trace/v1) needed to
+// export spans using the OTLP/HTTP JSON encoding.
+
+type otlpExportRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+// otlpStatus codes, see opentelemetry/proto/trace/v1 Status.
+const (
+	otlpStatusUnset = 0
+	otlpStatusError = 2
+)
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+// otlpAnyValue holds an attribute value. Only one of the fields is
+// set. Integer values are encoded as strings, as required by the
+// protobuf JSON mapping for 64 bit integers.
+type otlpAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+func newOTLPResource(serviceName string, attributes map[string]string) otlpResource {
+	attrs := []otlpKeyValue{otlpAttribute(StringAttribute("service.name", serviceName))}
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		attrs = append(attrs, otlpAttribute(StringAttribute(key, attributes[key])))
+	}
+	return otlpResource{Attributes: attrs}
+}
+
+func encodeOTLPTraces(resource otlpResource, spans []Span) ([]byte, error) {
+	scope := otlpScopeSpans{
+		Scope: otlpScope{Name: "juju"},
+		Spans: make([]otlpSpan, len(spans)),
+	}
+	for i, span := range spans {
+		scope.Spans[i] = newOTLPSpan(span)
+	}
+	data, err := json.Marshal(otlpExportRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource:   resource,
+			ScopeSpans: []otlpScopeSpans{scope},
+		}},
+	})
+	return data, errors.Trace(err)
+}
+
+func newOTLPSpan(span Span) otlpSpan {
+	out := otlpSpan{
+		TraceID:           span.Context.TraceID.String(),
+		SpanID:            span.Context.SpanID.String(),
+		Name:              span.Name,
+		Kind:              int(span.Kind),
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+		Status:            otlpStatus{Code: otlpStatusUnset},
+	}
+	if span.ParentSpanID.IsValid() {
+		out.ParentSpanID = span.ParentSpanID.String()
+	}
+	for _, attr := range span.Attributes {
+		out.Attributes = append(out.Attributes, otlpAttribute(attr))
+	}
+	if span.Error != "" {
+		out.Status = otlpStatus{Code: otlpStatusError, Message: span.Error}
+	}
+	return out
+}
+
+func otlpAttribute(attr Attribute) otlpKeyValue {
+	var value otlpAnyValue
+	switch v := attr.Value.(type) {
+	case string:
+		value.StringValue = &v
+	case bool:
+		value.BoolValue = &v
+	case int:
+		s := strconv.Itoa(v)
+		value.IntValue = &s
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		value.IntValue = &s
+	case uint64:
+		s := strconv.FormatUint(v, 10)
+		value.IntValue = &s
+	default:
+		s := fmt.Sprint(v)
+		value.StringValue = &s
+	}
+	return otlpKeyValue{Key: attr.Key, Value: value}
+}
@@ -0,0 +1,279 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/tracing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type OTLPSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	client *fakeDoer
+}
+
+var _ = gc.Suite(&OTLPSuite{})
+
+func (s *OTLPSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.client = &fakeDoer{posts: make(chan post, 10)}
+}
+
+func (s *OTLPSuite) config() tracing.OTLPConfig {
+	return tracing.OTLPConfig{
+		Endpoint:    "http://collector.example.com:4318/",
+		ServiceName: "jujud",
+		ResourceAttributes: map[string]string{
+			"juju.controller.uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			"host.name":            "juju-controller-0",
+		},
+		BatchSize:     2,
+		FlushInterval: time.Minute,
+		Clock:         s.clock,
+		Client:        s.client,
+	}
+}
+
+func (s *OTLPSuite) TestValidate(c *gc.C) {
+	cfg := s.config()
+	cfg.Endpoint = "collector:4318"
+	_, err := tracing.NewOTLPExporter(cfg)
+	c.Check(err, gc.ErrorMatches, `OTLP endpoint "collector:4318" not valid`)
+
+	cfg = s.config()
+	cfg.ServiceName = ""
+	_, err = tracing.NewOTLPExporter(cfg)
+	c.Check(err, gc.ErrorMatches, `empty ServiceName not valid`)
+
+	cfg = s.config()
+	cfg.Clock = nil
+	_, err = tracing.NewOTLPExporter(cfg)
+	c.Check(err, gc.ErrorMatches, `nil Clock not valid`)
+}
+
+func (s *OTLPSuite) TestExportsFullBatch(c *gc.C) {
+	exporter, err := tracing.NewOTLPExporter(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer exporter.Close()
+
+	sc, err := tracing.ParseTraceParent(traceParent)
+	c.Assert(err, jc.ErrorIsNil)
+	child := sc.NewChild()
+	start := time.Unix(1614600000, 0)
+	exporter.ExportSpan(tracing.Span{
+		Name:         "Application.Deploy",
+		Context:      child,
+		ParentSpanID: sc.SpanID,
+		Kind:         tracing.SpanKindServer,
+		Start:        start,
+		End:          start.Add(1500 * time.Millisecond),
+		Attributes: []tracing.Attribute{
+			tracing.StringAttribute("rpc.method", "Deploy"),
+			tracing.IntAttribute("juju.facade.version", 13),
+		},
+	})
+	exporter.ExportSpan(tracing.Span{
+		Name:    "Application.SetConstraints",
+		Context: sc.NewChild(),
+		Kind:    tracing.SpanKindServer,
+		Start:   start,
+		End:     start,
+		Error:   "boom",
+	})
+
+	p := s.nextPost(c)
+	c.Check(p.url, gc.Equals, "http://collector.example.com:4318/v1/traces")
+	c.Check(p.contentType, gc.Equals, "application/json")
+	c.Assert(p.body, jc.JSONEquals, map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": []interface{}{
+					stringAttr("service.name", "jujud"),
+					stringAttr("host.name", "juju-controller-0"),
+					stringAttr("juju.controller.uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+				},
+			},
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": map[string]interface{}{"name": "juju"},
+				"spans": []interface{}{
+					map[string]interface{}{
+						"traceId":           "4bf92f3577b34da6a3ce929d0e0e4736",
+						"spanId":            child.SpanID.String(),
+						"parentSpanId":      "00f067aa0ba902b7",
+						"name":              "Application.Deploy",
+						"kind":              2,
+						"startTimeUnixNano": "1614600000000000000",
+						"endTimeUnixNano":   "1614600001500000000",
+						"attributes": []interface{}{
+							stringAttr("rpc.method", "Deploy"),
+							map[string]interface{}{
+								"key":   "juju.facade.version",
+								"value": map[string]interface{}{"intValue": "13"},
+							},
+						},
+						"status": map[string]interface{}{"code": 0},
+					},
+					map[string]interface{}{
+						"traceId":           "4bf92f3577b34da6a3ce929d0e0e4736",
+						"spanId":            p.spanIDs()[1],
+						"name":              "Application.SetConstraints",
+						"kind":              2,
+						"startTimeUnixNano": "1614600000000000000",
+						"endTimeUnixNano":   "1614600000000000000",
+						"status":            map[string]interface{}{"code": 2, "message": "boom"},
+					},
+				},
+			}},
+		}},
+	})
+}
+
+func (s *OTLPSuite) TestExportsPartialBatchAfterFlushInterval(c *gc.C) {
+	exporter, err := tracing.NewOTLPExporter(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer exporter.Close()
+
+	exporter.ExportSpan(tracing.Span{Name: "Client.FullStatus", Context: tracing.NewRootContext()})
+	s.assertNoPost(c)
+
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	p := s.nextPost(c)
+	c.Assert(p.spanIDs(), gc.HasLen, 1)
+}
+
+func (s *OTLPSuite) TestFailedExportIsDropped(c *gc.C) {
+	s.client.SetErrors(errors.New("connection refused"))
+	exporter, err := tracing.NewOTLPExporter(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer exporter.Close()
+
+	for i := 0; i < 4; i++ {
+		exporter.ExportSpan(tracing.Span{Name: "Client.FullStatus", Context: tracing.NewRootContext()})
+	}
+	// The first batch fails, and the second is sent without
+	// retrying it.
+	s.nextPost(c)
+	s.nextPost(c)
+	s.assertNoPost(c)
+	s.client.CheckCallNames(c, "Do", "Do")
+}
+
+func (s *OTLPSuite) TestExportDoesNotBlock(c *gc.C) {
+	cfg := s.config()
+	cfg.BatchSize = 100
+	cfg.QueueSize = 1
+	exporter, err := tracing.NewOTLPExporter(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	defer exporter.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			exporter.ExportSpan(tracing.Span{Name: "Client.FullStatus"})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("ExportSpan blocked")
+	}
+}
+
+func (s *OTLPSuite) nextPost(c *gc.C) post {
+	select {
+	case p := <-s.client.posts:
+		return p
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for export")
+	}
+	return post{}
+}
+
+func (s *OTLPSuite) assertNoPost(c *gc.C) {
+	select {
+	case p := <-s.client.posts:
+		c.Fatalf("unexpected export: %s", p.body)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func stringAttr(key, value string) map[string]interface{} {
+	return map[string]interface{}{
+		"key":   key,
+		"value": map[string]interface{}{"stringValue": value},
+	}
+}
+
+type post struct {
+	url         string
+	contentType string
+	body        string
+}
+
+// spanIDs returns the IDs of the exported spans, in order.
+func (p post) spanIDs() []string {
+	var req struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					SpanID string `json:"spanId"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.Unmarshal([]byte(p.body), &req); err != nil {
+		return nil
+	}
+	var ids []string
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				ids = append(ids, span.SpanID)
+			}
+		}
+	}
+	return ids
+}
+
+type fakeDoer struct {
+	testing.Stub
+	posts chan post
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	d.AddCall("Do")
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	d.posts <- post{
+		url:         req.URL.String(),
+		contentType: req.Header.Get("Content-Type"),
+		body:        string(body),
+	}
+	if err := d.NextErr(); err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}
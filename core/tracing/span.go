@@ -0,0 +1,72 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"time"
+)
+
+// SpanKind describes the relationship between a span and the remote
+// side of the operation it records.
+type SpanKind int
+
+const (
+	// SpanKindInternal is a span for an operation that doesn't
+	// cross a process boundary.
+	SpanKindInternal SpanKind = 1
+
+	// SpanKindServer is a span for the server side handling of a
+	// remote request.
+	SpanKindServer SpanKind = 2
+
+	// SpanKindClient is a span for the client side of a remote
+	// request.
+	SpanKindClient SpanKind = 3
+)
+
+// Span records a single timed operation within a trace.
+type Span struct {
+	// Name describes the operation, eg "Application.Deploy".
+	Name string
+
+	// Context identifies the span and the trace it belongs to.
+	Context SpanContext
+
+	// ParentSpanID identifies the span that caused this one, if
+	// there is one.
+	ParentSpanID SpanID
+
+	Kind  SpanKind
+	Start time.Time
+	End   time.Time
+
+	// Attributes hold details of the operation. Values may be
+	// strings, bools or integers.
+	Attributes []Attribute
+
+	// Error holds the error message if the operation failed.
+	Error string
+}
+
+// Attribute is a key and value describing a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// StringAttribute returns a string valued attribute.
+func StringAttribute(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// IntAttribute returns an integer valued attribute.
+func IntAttribute(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Exporter sends finished spans to be stored.
+type Exporter interface {
+	// ExportSpan queues the span for export. It must not block.
+	ExportSpan(Span)
+}
@@ -0,0 +1,157 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tracing provides the types used to trace API requests
+// across the Juju client and controllers, using the W3C trace
+// context format to propagate traces and OTLP to export spans.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// TraceParentEnvKey is the environment variable which, when set to a
+// W3C traceparent value, makes the Juju client attach its API
+// requests to that trace rather than starting a new one.
+const TraceParentEnvKey = "JUJU_TRACEPARENT"
+
+// TraceID identifies a trace, made up of spans from any number of
+// processes.
+type TraceID [16]byte
+
+// String returns the trace ID as 32 lower case hex digits.
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid returns whether the trace ID is non-zero.
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns the span ID as 16 lower case hex digits.
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid returns whether the span ID is non-zero.
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// SpanContext is the part of a span that is propagated to other
+// processes, so their spans can be attached to the same trace.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+
+	// Sampled is set when the spans in the trace should be
+	// recorded.
+	Sampled bool
+}
+
+// IsValid returns whether the span context identifies a span.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// NewRootContext returns the context for the first span of a new
+// sampled trace.
+func NewRootContext() SpanContext {
+	var sc SpanContext
+	mustReadRandom(sc.TraceID[:])
+	mustReadRandom(sc.SpanID[:])
+	sc.Sampled = true
+	return sc
+}
+
+// NewChild returns the context for a new span in the same trace.
+func (sc SpanContext) NewChild() SpanContext {
+	child := SpanContext{
+		TraceID: sc.TraceID,
+		Sampled: sc.Sampled,
+	}
+	mustReadRandom(child.SpanID[:])
+	return child
+}
+
+// TraceParent returns the span context in the W3C traceparent format,
+// or "" if the span context isn't valid.
+// See https://www.w3.org/TR/trace-context/#traceparent-header.
+func (sc SpanContext) TraceParent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceParent parses a span context in the W3C traceparent
+// format.
+func ParseTraceParent(value string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(value, "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return sc, errors.NotValidf("traceparent %q", value)
+	}
+	// Later versions may add fields, but version 00 has exactly
+	// four.
+	if parts[0] == "00" && len(parts) != 4 {
+		return sc, errors.NotValidf("traceparent %q", value)
+	}
+	if err := decodeHex(sc.TraceID[:], parts[1]); err != nil {
+		return sc, errors.NotValidf("trace ID in traceparent %q", value)
+	}
+	if err := decodeHex(sc.SpanID[:], parts[2]); err != nil {
+		return sc, errors.NotValidf("span ID in traceparent %q", value)
+	}
+	var flags [1]byte
+	if err := decodeHex(flags[:], parts[3]); err != nil {
+		return sc, errors.NotValidf("flags in traceparent %q", value)
+	}
+	sc.Sampled = flags[0]&1 == 1
+	if !sc.IsValid() {
+		return SpanContext{}, errors.NotValidf("zero ID in traceparent %q", value)
+	}
+	return sc, nil
+}
+
+// FromEnvironment returns the span context set in the JUJU_TRACEPARENT
+// environment variable, if any.
+func FromEnvironment() (SpanContext, bool, error) {
+	value := os.Getenv(TraceParentEnvKey)
+	if value == "" {
+		return SpanContext{}, false, nil
+	}
+	sc, err := ParseTraceParent(value)
+	if err != nil {
+		return SpanContext{}, false, errors.Annotatef(err, "parsing %s", TraceParentEnvKey)
+	}
+	return sc, true, nil
+}
+
+func decodeHex(dst []byte, value string) error {
+	if len(value) != hex.EncodedLen(len(dst)) || strings.ToLower(value) != value {
+		return errors.New("bad length or case")
+	}
+	_, err := hex.Decode(dst, []byte(value))
+	return err
+}
+
+func mustReadRandom(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic(errors.Annotate(err, "reading random bytes for trace ID"))
+	}
+}
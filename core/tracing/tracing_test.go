@@ -0,0 +1,105 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/tracing"
+)
+
+type TracingSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TracingSuite{})
+
+const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func (s *TracingSuite) TestParseTraceParent(c *gc.C) {
+	sc, err := tracing.ParseTraceParent(traceParent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sc.TraceID.String(), gc.Equals, "4bf92f3577b34da6a3ce929d0e0e4736")
+	c.Check(sc.SpanID.String(), gc.Equals, "00f067aa0ba902b7")
+	c.Check(sc.Sampled, jc.IsTrue)
+	c.Check(sc.TraceParent(), gc.Equals, traceParent)
+}
+
+func (s *TracingSuite) TestParseTraceParentNotSampled(c *gc.C) {
+	sc, err := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sc.Sampled, jc.IsFalse)
+}
+
+func (s *TracingSuite) TestParseTraceParentFutureVersion(c *gc.C) {
+	sc, err := tracing.ParseTraceParent("cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what-the-future")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sc.TraceParent(), gc.Equals, traceParent)
+}
+
+func (s *TracingSuite) TestParseTraceParentErrors(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "",
+		err:   `traceparent "" not valid`,
+	}, {
+		value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		err:   `traceparent ".*" not valid`,
+	}, {
+		value: traceParent + "-extra",
+		err:   `traceparent ".*" not valid`,
+	}, {
+		value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		err:   `trace ID in traceparent ".*" not valid`,
+	}, {
+		value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902-01",
+		err:   `span ID in traceparent ".*" not valid`,
+	}, {
+		value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-x1",
+		err:   `flags in traceparent ".*" not valid`,
+	}, {
+		value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		err:   `zero ID in traceparent ".*" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.value)
+		_, err := tracing.ParseTraceParent(test.value)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *TracingSuite) TestNewRootContext(c *gc.C) {
+	sc := tracing.NewRootContext()
+	c.Assert(sc.IsValid(), jc.IsTrue)
+	c.Assert(sc.Sampled, jc.IsTrue)
+
+	child := sc.NewChild()
+	c.Assert(child.TraceID, gc.Equals, sc.TraceID)
+	c.Assert(child.SpanID, gc.Not(gc.Equals), sc.SpanID)
+	c.Assert(child.Sampled, jc.IsTrue)
+}
+
+func (s *TracingSuite) TestInvalidContextHasNoTraceParent(c *gc.C) {
+	c.Assert(tracing.SpanContext{}.TraceParent(), gc.Equals, "")
+}
+
+func (s *TracingSuite) TestFromEnvironment(c *gc.C) {
+	s.PatchEnvironment(tracing.TraceParentEnvKey, "")
+	_, ok, err := tracing.FromEnvironment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsFalse)
+
+	s.PatchEnvironment(tracing.TraceParentEnvKey, traceParent)
+	sc, ok, err := tracing.FromEnvironment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(sc.TraceParent(), gc.Equals, traceParent)
+
+	s.PatchEnvironment(tracing.TraceParentEnvKey, "nope")
+	_, _, err = tracing.FromEnvironment()
+	c.Assert(err, gc.ErrorMatches, `parsing JUJU_TRACEPARENT: traceparent "nope" not valid`)
+}
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -26,8 +27,11 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/jsonhttp"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
 	"github.com/juju/juju/network"
+	secretbackend "github.com/juju/juju/secrets/backend"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -195,6 +199,22 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogForwardType sets the kind of log forwarding target, one of
+	// syslog (the default), http or otlp.
+	LogForwardType = "logforward-type"
+
+	// LogFwdHTTPURL sets the URL to which batches of log records are
+	// posted when forwarding over http or otlp.
+	LogFwdHTTPURL = "logforward-http-url"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// http log forwarding server certificate.
+	LogFwdHTTPCACert = "logforward-http-ca-cert"
+
+	// LogFwdHTTPBatchSize sets the maximum number of log records posted
+	// in a single request when forwarding over http or otlp.
+	LogFwdHTTPBatchSize = "logforward-http-batch-size"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -223,6 +243,35 @@ const (
 	// grow to before it is pruned, eg "5M"
 	MaxActionResultsSize = "max-action-results-size"
 
+	// LogRetention is the retention policy for log records at WARNING
+	// and above, eg "ERROR=720h;WARNING=168h"
+	LogRetention = "log-retention"
+
+	// MaxRetainedLogSize is the maximum size the retained log records
+	// can grow to before the oldest are pruned, eg "1G"
+	MaxRetainedLogSize = "max-retained-log-size"
+
+	// SecretBackendKey is the type of backend storing the model's
+	// sensitive content, such as the values of charm secrets.
+	SecretBackendKey = "secret-backend"
+
+	// SecretBackendConfigKey holds the configuration of the secret
+	// backend, as semicolon-separated <key>=<value> pairs, eg
+	// "address=https://vault.example.com:8200;token=s.abc123". The
+	// backend's credentials, such as the token, are stored apart from
+	// the model config.
+	SecretBackendConfigKey = "secret-backend-config"
+
+	// HookTimeout is how long a charm hook may run for before it is
+	// killed and marked as failed, eg "30m". Zero means hooks may run
+	// for as long as they like.
+	HookTimeout = "hook-timeout"
+
+	// ApplicationHookTimeouts overrides the hook timeout for individual
+	// applications, as semicolon-separated <application>=<duration>
+	// pairs, eg "mysql=2h;wordpress=10m"
+	ApplicationHookTimeouts = "application-hook-timeouts"
+
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
@@ -396,8 +445,8 @@ const (
 // "ca-cert" and "ca-private-key" values.  If not specified, CA details
 // will be read from:
 //
-//     ~/.local/share/juju/<name>-cert.pem
-//     ~/.local/share/juju/<name>-private-key.pem
+//	~/.local/share/juju/<name>-cert.pem
+//	~/.local/share/juju/<name>-private-key.pem
 //
 // if $XDG_DATA_HOME is defined it will be used instead of ~/.local/share
 func New(withDefaults Defaulting, attrs map[string]interface{}) (*Config, error) {
@@ -448,6 +497,19 @@ const (
 
 	// DefaultActionResultsSize is the default size of the action results.
 	DefaultActionResultsSize = "5G"
+
+	// DefaultLogRetention is the default value for LogRetention.
+	DefaultLogRetention = "ERROR=720h;WARNING=168h"
+
+	// DefaultRetainedLogSize is the default value for MaxRetainedLogSize.
+	DefaultRetainedLogSize = "1G"
+
+	// DefaultSecretBackend is the default value for SecretBackendKey.
+	DefaultSecretBackend = secretbackend.Internal
+
+	// DefaultHookTimeout is the default value for HookTimeout, which
+	// doesn't limit how long hooks run for.
+	DefaultHookTimeout = "0s"
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -536,6 +598,16 @@ var defaultConfigValues = map[string]interface{}{
 	MaxStatusHistorySize: DefaultStatusHistorySize,
 	MaxActionResultsAge:  DefaultActionResultsAge,
 	MaxActionResultsSize: DefaultActionResultsSize,
+
+	// Log retention settings
+	LogRetention:       DefaultLogRetention,
+	MaxRetainedLogSize: DefaultRetainedLogSize,
+
+	// Secret backend settings
+	SecretBackendKey: DefaultSecretBackend,
+
+	// Hook timeout settings
+	HookTimeout: DefaultHookTimeout,
 }
 
 // defaultLoggingConfig is the default value for logging-config if it is otherwise not set.
@@ -636,9 +708,9 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
-	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
+	if lfCfg, ok := cfg.LogFwdTarget(); ok {
 		if err := lfCfg.Validate(); err != nil {
-			return errors.Annotate(err, "invalid syslog forwarding config")
+			return errors.Annotatef(err, "invalid %s forwarding config", lfCfg.Type)
 		}
 	}
 
@@ -675,6 +747,38 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[LogRetention].(string); ok {
+		if _, err := ParseLogRetention(v); err != nil {
+			return errors.Annotate(err, "invalid log retention in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[MaxRetainedLogSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid max retained log size in model configuration")
+		}
+	}
+
+	if err := cfg.validateSecretBackend(); err != nil {
+		return errors.Annotate(err, "invalid secret backend in model configuration")
+	}
+
+	if v, ok := cfg.defined[HookTimeout].(string); ok {
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid hook timeout in model configuration")
+		}
+		if duration < 0 {
+			return errors.Errorf("hook timeout %v cannot be negative", duration)
+		}
+	}
+
+	if v, ok := cfg.defined[ApplicationHookTimeouts].(string); ok {
+		if _, err := ParseApplicationHookTimeouts(v); err != nil {
+			return errors.Annotate(err, "invalid application hook timeouts in model configuration")
+		}
+	}
+
 	if v, ok := cfg.defined[UpdateStatusHookInterval].(string); ok {
 		duration, err := time.ParseDuration(v)
 		if err != nil {
@@ -1161,6 +1265,78 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogForwardType returns the kind of target that logs are forwarded
+// to. An empty or invalid value is reported as syslog.
+func (c *Config) LogForwardType() target.Type {
+	t, err := target.ParseType(c.asString(LogForwardType))
+	if err != nil {
+		return target.TypeSyslog
+	}
+	return t
+}
+
+// LogFwdHTTP returns the http log forwarding config.
+func (c *Config) LogFwdHTTP() (*jsonhttp.RawConfig, bool) {
+	partial := false
+	var lfCfg jsonhttp.RawConfig
+
+	if s, ok := c.defined[LogForwardEnabled]; ok {
+		partial = true
+		lfCfg.Enabled = s.(bool)
+	}
+
+	if c.LogForwardType() == target.TypeOTLP {
+		lfCfg.Format = jsonhttp.FormatOTLP
+	} else {
+		lfCfg.Format = jsonhttp.FormatJSON
+	}
+
+	if s, ok := c.defined[LogFwdHTTPURL]; ok && s != "" {
+		partial = true
+		lfCfg.URL = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPCACert]; ok && s != "" {
+		partial = true
+		lfCfg.CACert = s.(string)
+	}
+
+	if n, ok := c.defined[LogFwdHTTPBatchSize].(int); ok {
+		partial = true
+		lfCfg.BatchSize = n
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+// LogFwdTarget returns the config for the log forwarding target
+// selected by the logforward-type setting.
+func (c *Config) LogFwdTarget() (*target.RawConfig, bool) {
+	lfCfg := target.RawConfig{
+		Type: c.LogForwardType(),
+	}
+	switch lfCfg.Type {
+	case target.TypeSyslog:
+		syslogCfg, ok := c.LogFwdSyslog()
+		if !ok {
+			return nil, false
+		}
+		lfCfg.Enabled = syslogCfg.Enabled
+		lfCfg.Syslog = *syslogCfg
+	default:
+		httpCfg, ok := c.LogFwdHTTP()
+		if !ok {
+			return nil, false
+		}
+		lfCfg.Enabled = httpCfg.Enabled
+		lfCfg.HTTP = *httpCfg
+	}
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1468,6 +1644,192 @@ func (c *Config) MaxActionResultsSizeMB() uint {
 	return uint(val)
 }
 
+// LogRetention returns how long log records are kept for at each
+// severity that is retained beyond the model's capped logs collection.
+func (c *Config) LogRetention() map[loggo.Level]time.Duration {
+	value, ok := c.defined[LogRetention].(string)
+	if !ok {
+		// Models created before the setting existed.
+		value = DefaultLogRetention
+	}
+	// Value has already been validated.
+	retention, _ := ParseLogRetention(value)
+	return retention
+}
+
+// MaxRetainedLogSizeMB is the maximum size in MiB which the retained
+// log records can grow to before the oldest are pruned.
+func (c *Config) MaxRetainedLogSizeMB() uint {
+	value, ok := c.defined[MaxRetainedLogSize].(string)
+	if !ok {
+		// Models created before the setting existed.
+		value = DefaultRetainedLogSize
+	}
+	// Value has already been validated.
+	val, _ := utils.ParseSize(value)
+	return uint(val)
+}
+
+// MinRetainedLogLevel is the lowest severity of log record that can be
+// kept for longer than the model's capped logs collection allows.
+const MinRetainedLogLevel = loggo.WARNING
+
+// ParseLogRetention parses a log retention policy, which is a list of
+// semicolon-separated <severity>=<duration> pairs, eg
+// "ERROR=720h;WARNING=168h". Records at each severity are kept for the
+// longest duration set for that severity or any lower one.
+func ParseLogRetention(value string) (map[loggo.Level]time.Duration, error) {
+	retention := make(map[loggo.Level]time.Duration)
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("expected <severity>=<duration>, got %q", item)
+		}
+		level, ok := loggo.ParseLevel(strings.TrimSpace(parts[0]))
+		if !ok || level == loggo.UNSPECIFIED {
+			return nil, errors.Errorf("unknown severity %q", parts[0])
+		}
+		if level < MinRetainedLogLevel {
+			return nil, errors.Errorf("cannot retain %s records, only %s and above", level, MinRetainedLogLevel)
+		}
+		if _, ok := retention[level]; ok {
+			return nil, errors.Errorf("severity %s set more than once", level)
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, errors.Annotatef(err, "severity %s", level)
+		}
+		if duration <= 0 {
+			return nil, errors.Errorf("severity %s duration %v must be positive", level, duration)
+		}
+		retention[level] = duration
+	}
+	return retention, nil
+}
+
+// SecretBackend returns the type of backend storing the model's
+// sensitive content.
+func (c *Config) SecretBackend() string {
+	value, _ := c.defined[SecretBackendKey].(string)
+	if value == "" {
+		// Models created before the setting existed.
+		value = DefaultSecretBackend
+	}
+	return value
+}
+
+// SecretBackendConfig returns the configuration of the model's secret
+// backend. Once stored, the config no longer holds the backend's
+// credentials.
+func (c *Config) SecretBackendConfig() map[string]string {
+	value, _ := c.defined[SecretBackendConfigKey].(string)
+	// Value has already been validated.
+	attrs, _ := ParseSecretBackendConfig(value)
+	return attrs
+}
+
+func (c *Config) validateSecretBackend() error {
+	value, _ := c.defined[SecretBackendConfigKey].(string)
+	attrs, err := ParseSecretBackendConfig(value)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return secretbackend.ValidateConfigWithoutCredentials(c.SecretBackend(), attrs)
+}
+
+// ParseSecretBackendConfig parses secret backend configuration, which is
+// a list of semicolon-separated <key>=<value> pairs, eg
+// "address=https://vault.example.com:8200;token=s.abc123".
+func ParseSecretBackendConfig(value string) (map[string]string, error) {
+	attrs := make(map[string]string)
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("expected <key>=<value>, got %q", item)
+		}
+		key := strings.TrimSpace(parts[0])
+		if key == "" {
+			return nil, errors.Errorf("empty key in %q", item)
+		}
+		if _, ok := attrs[key]; ok {
+			return nil, errors.Errorf("key %q set more than once", key)
+		}
+		attrs[key] = strings.TrimSpace(parts[1])
+	}
+	return attrs, nil
+}
+
+// FormatSecretBackendConfig returns the secret backend configuration as
+// parsed by ParseSecretBackendConfig.
+func FormatSecretBackendConfig(attrs map[string]string) string {
+	items := make([]string, 0, len(attrs))
+	for key, value := range attrs {
+		items = append(items, key+"="+value)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ";")
+}
+
+// HookTimeout returns how long the hooks of the named application may
+// run for before they are killed, or zero if they aren't limited.
+func (c *Config) HookTimeout(appName string) time.Duration {
+	// Values have already been validated.
+	value, _ := c.defined[ApplicationHookTimeouts].(string)
+	timeouts, _ := ParseApplicationHookTimeouts(value)
+	if timeout, ok := timeouts[appName]; ok {
+		return timeout
+	}
+	value, _ = c.defined[HookTimeout].(string)
+	if value == "" {
+		// Models created before the setting existed.
+		value = DefaultHookTimeout
+	}
+	timeout, _ := time.ParseDuration(value)
+	return timeout
+}
+
+// ParseApplicationHookTimeouts parses per-application hook timeouts,
+// which are a list of semicolon-separated <application>=<duration>
+// pairs, eg "mysql=2h;wordpress=10m". A zero duration means the
+// application's hooks aren't limited.
+func ParseApplicationHookTimeouts(value string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("expected <application>=<duration>, got %q", item)
+		}
+		appName := strings.TrimSpace(parts[0])
+		if !names.IsValidApplication(appName) {
+			return nil, errors.NotValidf("application name %q", appName)
+		}
+		if _, ok := timeouts[appName]; ok {
+			return nil, errors.Errorf("application %q set more than once", appName)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", appName)
+		}
+		if timeout < 0 {
+			return nil, errors.Errorf("application %q timeout %v cannot be negative", appName, timeout)
+		}
+		timeouts[appName] = timeout
+	}
+	return timeouts, nil
+}
+
 // UpdateStatusHookInterval is how often to run the charm
 // update-status hook.
 func (c *Config) UpdateStatusHookInterval() time.Duration {
@@ -1599,6 +1961,10 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogForwardType:         schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPCACert:       schema.Omit,
+	LogFwdHTTPBatchSize:    schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1653,6 +2019,12 @@ var alwaysOptional = schema.Defaults{
 	MaxStatusHistorySize:          schema.Omit,
 	MaxActionResultsAge:           schema.Omit,
 	MaxActionResultsSize:          schema.Omit,
+	LogRetention:                  schema.Omit,
+	MaxRetainedLogSize:            schema.Omit,
+	SecretBackendKey:              schema.Omit,
+	SecretBackendConfigKey:        schema.Omit,
+	HookTimeout:                   schema.Omit,
+	ApplicationHookTimeouts:       schema.Omit,
 	UpdateStatusHookInterval:      schema.Omit,
 	EgressSubnets:                 schema.Omit,
 	FanConfig:                     schema.Omit,
@@ -2065,6 +2437,27 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogForwardType: {
+		Description: `The kind of log forwarding target - one of syslog, http, otlp (default syslog)`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"syslog", "http", "otlp"},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPURL: {
+		Description: `The URL to which log records are posted when logforward-type is http or otlp.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the http log forwarding server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPBatchSize: {
+		Description: `The maximum number of log records posted in a single request when logforward-type is http or otlp.`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -2148,6 +2541,36 @@ where possible. (default "")`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogRetention: {
+		Description: "How long to keep log records at WARNING and above, as semicolon-separated <severity>=<duration> pairs (eg ERROR=720h;WARNING=168h). These records are kept even when the model's capped logs collection is full",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxRetainedLogSize: {
+		Description: "The maximum size of the log records kept according to log-retention, in human-readable memory format. The oldest records at the lowest severity are pruned first",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SecretBackendKey: {
+		Description: `The backend storing sensitive content, such as the values of charm secrets: "internal" keeps it encrypted in the controller database, "vault" in an external Vault key/value store. Cloud credentials follow the setting of the controller model. An external backend can't be changed while it holds any content`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	SecretBackendConfigKey: {
+		Description: "The configuration of the secret backend, as semicolon-separated <key>=<value> pairs (eg address=https://vault.example.com:8200;token=s.abc123;mount-path=secret). Credentials, such as the vault token, are stored encrypted apart from the model config and are not shown",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	HookTimeout: {
+		Description: "How long a charm hook may run for before it is killed and marked as failed, in human-readable time format (eg 30m). Zero means hooks are not limited",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ApplicationHookTimeouts: {
+		Description: "Hook timeouts for individual applications, overriding hook-timeout, as semicolon-separated <application>=<duration> pairs (eg mysql=2h;wordpress=10m)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	UpdateStatusHookInterval: {
 		Description: "How often to run the charm update-status hook, in human-readable time format (default 5m, range 1-60m)",
 		Type:        environschema.Tstring,
@@ -377,6 +377,85 @@ var configTests = []configTest{
 			"logging-config": "foo=bar",
 		}),
 		err: `unknown severity level "bar"`,
+	}, {
+		about:       "Invalid log retention severity",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"log-retention": "ERROR=720h;DEBUG=1h",
+		}),
+		err: `invalid log retention in model configuration: cannot retain DEBUG records, only WARNING and above`,
+	}, {
+		about:       "Invalid log retention duration",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"log-retention": "ERROR=forever",
+		}),
+		err: `invalid log retention in model configuration: severity ERROR: time: invalid duration "?forever"?`,
+	}, {
+		about:       "Invalid max retained log size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-retained-log-size": "huge",
+		}),
+		err: `invalid max retained log size in model configuration: expected a non-negative number, got "huge"`,
+	}, {
+		about:       "Unknown secret backend",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"secret-backend": "somewhere",
+		}),
+		err: `invalid secret backend in model configuration: secret backend "somewhere" not valid`,
+	}, {
+		about:       "Invalid secret backend config",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"secret-backend":        "vault",
+			"secret-backend-config": "address=http://vault:8200;token",
+		}),
+		err: `invalid secret backend in model configuration: expected <key>=<value>, got "token"`,
+	}, {
+		about:       "Incomplete vault secret backend config",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"secret-backend":        "vault",
+			"secret-backend-config": "address=http://vault:8200",
+		}),
+		err: `invalid secret backend in model configuration: vault secret backend without token not valid`,
+	}, {
+		about:       "Invalid hook timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "forever",
+		}),
+		err: `invalid hook timeout in model configuration: time: invalid duration "?forever"?`,
+	}, {
+		about:       "Negative hook timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "-5m",
+		}),
+		err: `hook timeout -5m0s cannot be negative`,
+	}, {
+		about:       "Invalid application hook timeouts",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"application-hook-timeouts": "mysql=2h;wordpress",
+		}),
+		err: `invalid application hook timeouts in model configuration: expected <application>=<duration>, got "wordpress"`,
+	}, {
+		about:       "Invalid application name in hook timeouts",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"application-hook-timeouts": "MySQL=2h",
+		}),
+		err: `invalid application hook timeouts in model configuration: application name "MySQL" not valid`,
+	}, {
+		about:       "Application hook timeout set twice",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"application-hook-timeouts": "mysql=2h;mysql=1h",
+		}),
+		err: `invalid application hook timeouts in model configuration: application "mysql" set more than once`,
 	}, {
 		about:       "Sample configuration",
 		useDefaults: config.UseDefaults,
@@ -564,6 +643,31 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid http log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":         true,
+			"logforward-type":            "otlp",
+			"logforward-http-url":        "https://collector:4318/v1/logs",
+			"logforward-http-ca-cert":    testing.CACert,
+			"logforward-http-batch-size": 50,
+		}),
+	}, {
+		about:       "Missing http log forwarding url",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-type":    "http",
+		}),
+		err: `invalid http forwarding config: empty URL not valid`,
+	}, {
+		about:       "Invalid log forwarding type",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-type": "carrier-pigeon",
+		}),
+		err: `logforward-type: expected one of \[syslog http otlp\], got "carrier-pigeon"`,
 	}, {
 		about:       "Valid container-inherit-properties",
 		useDefaults: config.UseDefaults,
@@ -708,6 +812,18 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Check(lfCfg.ClientKey, gc.Equals, "")
 	}
 
+	if v, ok := test.attrs["logforward-type"].(string); ok {
+		c.Assert(string(cfg.LogForwardType()), gc.Equals, v)
+		httpCfg, hasHTTPCfg := cfg.LogFwdHTTP()
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		if url, _ := test.attrs["logforward-http-url"].(string); url != "" {
+			c.Assert(httpCfg.URL, gc.Equals, url)
+		}
+		if n, ok := test.attrs["logforward-http-batch-size"].(int); ok {
+			c.Assert(httpCfg.BatchSize, gc.Equals, n)
+		}
+	}
+
 	if v, ok := test.attrs["ssl-hostname-verification"]; ok {
 		c.Assert(cfg.SSLHostnameVerification(), gc.Equals, v)
 	}
@@ -1407,6 +1523,93 @@ func (s *ConfigSuite) TestStatusHistoryConfigValues(c *gc.C) {
 	c.Assert(cfg.MaxStatusHistorySizeMB(), gc.Equals, uint(8192))
 }
 
+func (s *ConfigSuite) TestLogRetentionDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.LogRetention(), jc.DeepEquals, map[loggo.Level]time.Duration{
+		loggo.ERROR:   720 * time.Hour,
+		loggo.WARNING: 168 * time.Hour,
+	})
+}
+
+func (s *ConfigSuite) TestLogRetentionValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"log-retention": "CRITICAL=2160h; WARNING=24h",
+	})
+	c.Assert(cfg.LogRetention(), jc.DeepEquals, map[loggo.Level]time.Duration{
+		loggo.CRITICAL: 2160 * time.Hour,
+		loggo.WARNING:  24 * time.Hour,
+	})
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"log-retention": "",
+	})
+	c.Assert(cfg.LogRetention(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestMaxRetainedLogSize(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MaxRetainedLogSizeMB(), gc.Equals, uint(1024))
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"max-retained-log-size": "256M",
+	})
+	c.Assert(cfg.MaxRetainedLogSizeMB(), gc.Equals, uint(256))
+}
+
+func (s *ConfigSuite) TestSecretBackendDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.SecretBackend(), gc.Equals, "internal")
+	c.Assert(cfg.SecretBackendConfig(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestSecretBackendValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=https://vault.example.com:8200; token=s.abc123",
+	})
+	c.Assert(cfg.SecretBackend(), gc.Equals, "vault")
+	c.Assert(cfg.SecretBackendConfig(), jc.DeepEquals, map[string]string{
+		"address": "https://vault.example.com:8200",
+		"token":   "s.abc123",
+	})
+}
+
+func (s *ConfigSuite) TestSecretBackendConfigWithoutCredentials(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=https://vault.example.com:8200",
+	})
+	c.Assert(cfg.SecretBackendConfig(), jc.DeepEquals, map[string]string{
+		"address": "https://vault.example.com:8200",
+	})
+}
+
+func (s *ConfigSuite) TestFormatSecretBackendConfig(c *gc.C) {
+	value := config.FormatSecretBackendConfig(map[string]string{
+		"mount-path": "secret",
+		"address":    "https://vault.example.com:8200",
+	})
+	c.Assert(value, gc.Equals, "address=https://vault.example.com:8200;mount-path=secret")
+	attrs, err := config.ParseSecretBackendConfig(value)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attrs, gc.HasLen, 2)
+}
+
+func (s *ConfigSuite) TestHookTimeoutDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookTimeout("mysql"), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestHookTimeoutValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"hook-timeout":              "30m",
+		"application-hook-timeouts": "mysql=2h; wordpress=0s",
+	})
+	c.Assert(cfg.HookTimeout("mysql"), gc.Equals, 2*time.Hour)
+	c.Assert(cfg.HookTimeout("wordpress"), gc.Equals, time.Duration(0))
+	c.Assert(cfg.HookTimeout("haproxy"), gc.Equals, 30*time.Minute)
+}
+
 func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigDefault(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{})
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 5*time.Minute)
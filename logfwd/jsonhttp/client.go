@@ -0,0 +1,146 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonhttp
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// requestTimeout is the maximum time allowed for a single batch to
+// be delivered.
+const requestTimeout = 30 * time.Second
+
+// maxErrorBodySize is the maximum amount of a failed response's body
+// that is included in the returned error.
+const maxErrorBodySize = 1024
+
+// Doer exposes the underlying functionality needed by Client.
+type Doer interface {
+	// Do sends the HTTP request and returns the response.
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client posts batches of log records to a remote HTTP endpoint.
+type Client struct {
+	// Doer is the HTTP client used to send requests.
+	Doer Doer
+
+	// URL is the endpoint to which batches of records are posted.
+	URL string
+
+	// Format determines how the records are encoded.
+	Format Format
+
+	// BatchSize is the maximum number of records sent per request.
+	BatchSize int
+}
+
+// Open returns a new client that posts records to the HTTP endpoint
+// described by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+	doer := &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+	}
+	client, err := OpenForDoer(cfg, doer)
+	return client, errors.Trace(err)
+}
+
+// OpenForDoer returns a new client that posts records to the HTTP
+// endpoint described by the config, using the supplied Doer.
+func OpenForDoer(cfg RawConfig, doer Doer) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.URL == "" {
+		return nil, errors.NotValidf("empty URL")
+	}
+	client := &Client{
+		Doer:      doer,
+		URL:       cfg.URL,
+		Format:    cfg.format(),
+		BatchSize: cfg.batchSize(),
+	}
+	return client, nil
+}
+
+// Close releases any idle connections held by the client.
+func (client Client) Close() error {
+	if closer, ok := client.Doer.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+	return nil
+}
+
+// Send posts the records to the remote endpoint, split into batches
+// of at most BatchSize records. Batches are sent in order and Send
+// returns on the first failure.
+func (client Client) Send(records []logfwd.Record) error {
+	batchSize := client.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	for len(records) > 0 {
+		n := batchSize
+		if n > len(records) {
+			n = len(records)
+		}
+		if err := client.sendBatch(records[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (client Client) sendBatch(records []logfwd.Record) error {
+	var (
+		body []byte
+		err  error
+	)
+	switch client.Format {
+	case FormatOTLP:
+		body, err = encodeOTLP(records)
+	default:
+		body, err = encodeJSON(records)
+	}
+	if err != nil {
+		return errors.Annotate(err, "encoding records")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, client.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Doer.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "posting log records")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return errors.Errorf("posting log records: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
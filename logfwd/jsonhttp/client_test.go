@@ -0,0 +1,253 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonhttp_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/jsonhttp"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	server       *httptest.Server
+	methods      []string
+	contentTypes []string
+	bodies       [][]byte
+	status       int
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.methods = nil
+	s.contentTypes = nil
+	s.bodies = nil
+	s.status = http.StatusOK
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		s.methods = append(s.methods, req.Method)
+		s.contentTypes = append(s.contentTypes, req.Header.Get("Content-Type"))
+		s.bodies = append(s.bodies, body)
+		w.WriteHeader(s.status)
+		if s.status != http.StatusOK {
+			_, _ = w.Write([]byte("boom"))
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *ClientSuite) open(c *gc.C, format jsonhttp.Format, batchSize int) *jsonhttp.Client {
+	client, err := jsonhttp.Open(jsonhttp.RawConfig{
+		Enabled:   true,
+		Format:    format,
+		URL:       s.server.URL,
+		BatchSize: batchSize,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) TestOpenDefaults(c *gc.C) {
+	client := s.open(c, "", 0)
+
+	c.Check(client.URL, gc.Equals, s.server.URL)
+	c.Check(client.Format, gc.Equals, jsonhttp.FormatJSON)
+	c.Check(client.BatchSize, gc.Equals, jsonhttp.DefaultBatchSize)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := jsonhttp.Open(jsonhttp.RawConfig{
+		Enabled: true,
+	})
+
+	c.Check(err, gc.ErrorMatches, `empty URL not valid`)
+}
+
+func (s *ClientSuite) TestSendJSON(c *gc.C) {
+	client := s.open(c, jsonhttp.FormatJSON, 0)
+	records := newRecords(c, 2)
+
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.methods, jc.DeepEquals, []string{"POST"})
+	c.Check(s.contentTypes, jc.DeepEquals, []string{"application/json"})
+	c.Assert(s.bodies, gc.HasLen, 1)
+	var batch jsonhttp.Batch
+	err = json.Unmarshal(s.bodies[0], &batch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(batch, jc.DeepEquals, jsonhttp.Batch{
+		Records: []jsonhttp.Record{{
+			ID:        10,
+			Timestamp: records[0].Timestamp,
+			Level:     "ERROR",
+			Message:   "message 0",
+			Module:    "juju.x.y",
+			Source:    "some/file.go:42",
+			Origin: jsonhttp.Origin{
+				ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+				ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+				Hostname:       "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+				Type:           "machine",
+				Name:           "99",
+				Software: jsonhttp.Software{
+					PrivateEnterpriseNumber: 28978,
+					Name:                    "jujud-machine-agent",
+					Version:                 "2.9.0",
+				},
+			},
+		}, {
+			ID:        11,
+			Timestamp: records[1].Timestamp,
+			Level:     "ERROR",
+			Message:   "message 1",
+			Module:    "juju.x.y",
+			Source:    "some/file.go:42",
+			Origin: jsonhttp.Origin{
+				ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+				ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+				Hostname:       "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+				Type:           "machine",
+				Name:           "99",
+				Software: jsonhttp.Software{
+					PrivateEnterpriseNumber: 28978,
+					Name:                    "jujud-machine-agent",
+					Version:                 "2.9.0",
+				},
+			},
+		}},
+	})
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	client := s.open(c, jsonhttp.FormatJSON, 2)
+
+	err := client.Send(newRecords(c, 5))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.bodies, gc.HasLen, 3)
+	var sizes []int
+	for _, body := range s.bodies {
+		var batch jsonhttp.Batch
+		err = json.Unmarshal(body, &batch)
+		c.Assert(err, jc.ErrorIsNil)
+		sizes = append(sizes, len(batch.Records))
+	}
+	c.Check(sizes, jc.DeepEquals, []int{2, 2, 1})
+}
+
+func (s *ClientSuite) TestSendOTLP(c *gc.C) {
+	client := s.open(c, jsonhttp.FormatOTLP, 0)
+	records := newRecords(c, 2)
+
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.bodies, gc.HasLen, 1)
+	var req jsonhttp.OTLPExportRequest
+	err = json.Unmarshal(s.bodies[0], &req)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Both records share an origin, so they belong to one resource.
+	c.Assert(req.ResourceLogs, gc.HasLen, 1)
+	resource := req.ResourceLogs[0]
+	c.Check(attrMap(resource.Resource.Attributes), jc.DeepEquals, map[string]string{
+		"service.name":         "jujud-machine-agent",
+		"service.version":      "2.9.0",
+		"host.name":            "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"juju.controller.uuid": "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"juju.model.uuid":      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"juju.origin.type":     "machine",
+		"juju.origin.name":     "99",
+	})
+	c.Assert(resource.ScopeLogs, gc.HasLen, 1)
+	c.Check(resource.ScopeLogs[0].Scope.Name, gc.Equals, "juju")
+	logRecords := resource.ScopeLogs[0].LogRecords
+	c.Assert(logRecords, gc.HasLen, 2)
+	c.Check(logRecords[0].SeverityNumber, gc.Equals, 17)
+	c.Check(logRecords[0].SeverityText, gc.Equals, "ERROR")
+	c.Check(*logRecords[0].Body.StringValue, gc.Equals, "message 0")
+	c.Check(logRecords[0].TimeUnixNano, gc.Equals, "1464877860000000000")
+	c.Check(attrMap(logRecords[0].Attributes), jc.DeepEquals, map[string]string{
+		"juju.record.id": "10",
+		"code.namespace": "juju.x.y",
+		"code.filepath":  "some/file.go",
+		"code.lineno":    "42",
+	})
+	c.Check(*logRecords[1].Body.StringValue, gc.Equals, "message 1")
+}
+
+func (s *ClientSuite) TestSendFailure(c *gc.C) {
+	s.status = http.StatusServiceUnavailable
+	client := s.open(c, jsonhttp.FormatJSON, 0)
+
+	err := client.Send(newRecords(c, 1))
+
+	c.Check(err, gc.ErrorMatches, `posting log records: 503 Service Unavailable: boom`)
+}
+
+func (s *ClientSuite) TestSendNoRecords(c *gc.C) {
+	client := s.open(c, jsonhttp.FormatJSON, 0)
+
+	err := client.Send(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.bodies, gc.HasLen, 0)
+}
+
+func attrMap(attrs []jsonhttp.OTLPKeyValue) map[string]string {
+	result := make(map[string]string)
+	for _, attr := range attrs {
+		switch {
+		case attr.Value.StringValue != nil:
+			result[attr.Key] = *attr.Value.StringValue
+		case attr.Value.IntValue != nil:
+			result[attr.Key] = *attr.Value.IntValue
+		}
+	}
+	return result
+}
+
+func newRecords(c *gc.C, n int) []logfwd.Record {
+	origin := logfwd.OriginForMachineAgent(
+		names.NewMachineTag("99"),
+		"9f484882-2f18-4fd2-967d-db9663db7bea",
+		"deadbeef-2f18-4fd2-967d-db9663db7bea",
+		version.MustParse("2.9.0"),
+	)
+	records := make([]logfwd.Record, n)
+	for i := range records {
+		records[i] = logfwd.Record{
+			ID:        int64(10 + i),
+			Origin:    origin,
+			Timestamp: time.Unix(1464877860, 0).UTC(),
+			Level:     loggo.ERROR,
+			Location: logfwd.SourceLocation{
+				Module:   "juju.x.y",
+				Filename: "some/file.go",
+				Line:     42,
+			},
+			Message: fmt.Sprintf("message %d", i),
+		}
+		c.Assert(records[i].Validate(), jc.ErrorIsNil)
+	}
+	return records
+}
@@ -0,0 +1,134 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonhttp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2/cert"
+)
+
+// DefaultBatchSize is the maximum number of records sent in a single
+// request when no batch size has been configured.
+const DefaultBatchSize = 100
+
+// Format identifies how a batch of records is encoded in a request body.
+type Format string
+
+const (
+	// FormatJSON encodes records as a JSON document holding a list
+	// of records.
+	FormatJSON Format = "json"
+
+	// FormatOTLP encodes records as an OpenTelemetry logs export
+	// request, using the OTLP/HTTP JSON encoding.
+	FormatOTLP Format = "otlp"
+)
+
+// Validate ensures that the format is supported.
+func (f Format) Validate() error {
+	switch f {
+	case FormatJSON, FormatOTLP:
+		return nil
+	}
+	return errors.NotValidf("format %q", string(f))
+}
+
+// RawConfig holds the raw configuration data for a connection to an
+// HTTP log forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Format determines how records are encoded. If not set then
+	// FormatJSON is used.
+	Format Format
+
+	// URL is the http or https URL to which batches of records
+	// are POSTed.
+	URL string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If not
+	// set then the system roots are used.
+	CACert string
+
+	// BatchSize is the maximum number of records sent in a single
+	// request. If zero then DefaultBatchSize is used.
+	BatchSize int
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.Format != "" {
+		if err := cfg.Format.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := cfg.validateURL(); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative BatchSize %d", cfg.BatchSize)
+	}
+	if cfg.CACert != "" {
+		if _, err := cfg.tlsConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateURL() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NewNotValid(err, "parsing URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("URL %q without host", cfg.URL)
+	}
+	return nil
+}
+
+func (cfg RawConfig) format() Format {
+	if cfg.Format == "" {
+		return FormatJSON
+	}
+	return cfg.Format
+}
+
+func (cfg RawConfig) batchSize() int {
+	if cfg.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+	return cfg.BatchSize
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+
+	return &tls.Config{
+		RootCAs: rootCAs,
+	}, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonhttp_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/jsonhttp"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := jsonhttp.RawConfig{
+		Enabled:   true,
+		Format:    jsonhttp.FormatOTLP,
+		URL:       "https://a.b.c:4318/v1/logs",
+		CACert:    coretesting.CACert,
+		BatchSize: 10,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg jsonhttp.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingURL(c *gc.C) {
+	cfg := jsonhttp.RawConfig{
+		Enabled: true,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty URL not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadScheme(c *gc.C) {
+	cfg := jsonhttp.RawConfig{
+		Enabled: true,
+		URL:     "ftp://a.b.c/logs",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL scheme "ftp" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingHost(c *gc.C) {
+	cfg := jsonhttp.RawConfig{
+		Enabled: true,
+		URL:     "http:///logs",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL "http:///logs" without host not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadFormat(c *gc.C) {
+	cfg := jsonhttp.RawConfig{
+		Enabled: true,
+		Format:  "xml",
+		URL:     "http://a.b.c/logs",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `format "xml" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateNegativeBatchSize(c *gc.C) {
+	cfg := jsonhttp.RawConfig{
+		Enabled:   true,
+		URL:       "http://a.b.c/logs",
+		BatchSize: -1,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `negative BatchSize -1 not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := jsonhttp.RawConfig{
+		Enabled: true,
+		URL:     "https://a.b.c/logs",
+		CACert:  "abc",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: no certificates found`)
+}
@@ -0,0 +1,8 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The jsonhttp package holds the tools needed to perform log forwarding
+// from Juju to a remote HTTP endpoint, with batches of records encoded
+// either as plain JSON documents or as OpenTelemetry (OTLP/HTTP) log
+// export requests.
+package jsonhttp
@@ -0,0 +1,89 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonhttp
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// Batch is the document posted for each batch of records when
+// using FormatJSON.
+type Batch struct {
+	Records []Record `json:"records"`
+}
+
+// Record is the JSON representation of a single logfwd.Record.
+type Record struct {
+	ID        int64     `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+	Module    string    `json:"module,omitempty"`
+	Source    string    `json:"source,omitempty"`
+	Origin    Origin    `json:"origin"`
+}
+
+// Origin is the JSON representation of a logfwd.Origin.
+type Origin struct {
+	ControllerUUID string   `json:"controller-uuid"`
+	ModelUUID      string   `json:"model-uuid"`
+	Hostname       string   `json:"hostname,omitempty"`
+	Type           string   `json:"type"`
+	Name           string   `json:"name,omitempty"`
+	Software       Software `json:"software"`
+}
+
+// Software is the JSON representation of a logfwd.Software.
+type Software struct {
+	PrivateEnterpriseNumber int    `json:"private-enterprise-number,omitempty"`
+	Name                    string `json:"name,omitempty"`
+	Version                 string `json:"version,omitempty"`
+}
+
+func encodeJSON(records []logfwd.Record) ([]byte, error) {
+	batch := Batch{
+		Records: make([]Record, len(records)),
+	}
+	for i, rec := range records {
+		batch.Records[i] = recordFromLogfwd(rec)
+	}
+	data, err := json.Marshal(batch)
+	return data, errors.Trace(err)
+}
+
+func recordFromLogfwd(rec logfwd.Record) Record {
+	return Record{
+		ID:        rec.ID,
+		Timestamp: rec.Timestamp.UTC(),
+		Level:     rec.Level.String(),
+		Message:   rec.Message,
+		Module:    rec.Location.Module,
+		Source:    rec.Location.String(),
+		Origin: Origin{
+			ControllerUUID: rec.Origin.ControllerUUID,
+			ModelUUID:      rec.Origin.ModelUUID,
+			Hostname:       rec.Origin.Hostname,
+			Type:           rec.Origin.Type.String(),
+			Name:           rec.Origin.Name,
+			Software: Software{
+				PrivateEnterpriseNumber: rec.Origin.Software.PrivateEnterpriseNumber,
+				Name:                    rec.Origin.Software.Name,
+				Version:                 softwareVersion(rec.Origin.Software),
+			},
+		},
+	}
+}
+
+func softwareVersion(sw logfwd.Software) string {
+	if sw.Version == version.Zero {
+		return ""
+	}
+	return sw.Version.String()
+}
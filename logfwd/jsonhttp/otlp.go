@@ -0,0 +1,157 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonhttp
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// The following types model the subset of the OpenTelemetry logs
+// data model (opentelemetry/proto/collector/logs/v1) needed to export
+// log records using the OTLP/HTTP JSON encoding.
+
+// OTLPExportRequest is the ExportLogsServiceRequest document posted for
+// each batch of records when using FormatOTLP.
+type OTLPExportRequest struct {
+	ResourceLogs []OTLPResourceLogs `json:"resourceLogs"`
+}
+
+// OTLPResourceLogs holds the records produced by a single resource,
+// which for Juju is a single record origin.
+type OTLPResourceLogs struct {
+	Resource  OTLPResource    `json:"resource"`
+	ScopeLogs []OTLPScopeLogs `json:"scopeLogs"`
+}
+
+// OTLPResource describes the entity that produced a set of records.
+type OTLPResource struct {
+	Attributes []OTLPKeyValue `json:"attributes"`
+}
+
+// OTLPScopeLogs holds the records produced by a single
+// instrumentation scope.
+type OTLPScopeLogs struct {
+	Scope      OTLPScope       `json:"scope"`
+	LogRecords []OTLPLogRecord `json:"logRecords"`
+}
+
+// OTLPScope identifies the instrumentation scope.
+type OTLPScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// OTLPLogRecord is a single OpenTelemetry log record.
+type OTLPLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           OTLPAnyValue   `json:"body"`
+	Attributes     []OTLPKeyValue `json:"attributes,omitempty"`
+}
+
+// OTLPKeyValue is a single attribute.
+type OTLPKeyValue struct {
+	Key   string       `json:"key"`
+	Value OTLPAnyValue `json:"value"`
+}
+
+// OTLPAnyValue holds an attribute or body value. Only one of the
+// fields is set. Integer values are encoded as strings, as required
+// by the protobuf JSON mapping for 64 bit integers.
+type OTLPAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+// OpenTelemetry severity numbers for each of the loggo levels.
+// See https://opentelemetry.io/docs/reference/specification/logs/data-model/#severity-fields.
+var otlpSeverities = map[loggo.Level]int{
+	loggo.TRACE:    1,
+	loggo.DEBUG:    5,
+	loggo.INFO:     9,
+	loggo.WARNING:  13,
+	loggo.ERROR:    17,
+	loggo.CRITICAL: 21,
+}
+
+func encodeOTLP(records []logfwd.Record) ([]byte, error) {
+	var req OTLPExportRequest
+	// Records are grouped by origin, preserving the order in which
+	// each origin was first seen.
+	index := make(map[logfwd.Origin]int)
+	for _, rec := range records {
+		i, ok := index[rec.Origin]
+		if !ok {
+			i = len(req.ResourceLogs)
+			index[rec.Origin] = i
+			req.ResourceLogs = append(req.ResourceLogs, OTLPResourceLogs{
+				Resource: otlpResource(rec.Origin),
+				ScopeLogs: []OTLPScopeLogs{{
+					Scope: OTLPScope{
+						Name:    "juju",
+						Version: softwareVersion(rec.Origin.Software),
+					},
+				}},
+			})
+		}
+		scope := &req.ResourceLogs[i].ScopeLogs[0]
+		scope.LogRecords = append(scope.LogRecords, otlpLogRecord(rec))
+	}
+	data, err := json.Marshal(req)
+	return data, errors.Trace(err)
+}
+
+func otlpResource(origin logfwd.Origin) OTLPResource {
+	var attrs []OTLPKeyValue
+	attrs = appendStringAttr(attrs, "service.name", origin.Software.Name)
+	attrs = appendStringAttr(attrs, "service.version", softwareVersion(origin.Software))
+	attrs = appendStringAttr(attrs, "host.name", origin.Hostname)
+	attrs = appendStringAttr(attrs, "juju.controller.uuid", origin.ControllerUUID)
+	attrs = appendStringAttr(attrs, "juju.model.uuid", origin.ModelUUID)
+	attrs = appendStringAttr(attrs, "juju.origin.type", origin.Type.String())
+	attrs = appendStringAttr(attrs, "juju.origin.name", origin.Name)
+	return OTLPResource{Attributes: attrs}
+}
+
+func otlpLogRecord(rec logfwd.Record) OTLPLogRecord {
+	message := rec.Message
+	out := OTLPLogRecord{
+		TimeUnixNano:   strconv.FormatInt(rec.Timestamp.UnixNano(), 10),
+		SeverityNumber: otlpSeverities[rec.Level],
+		SeverityText:   rec.Level.String(),
+		Body:           OTLPAnyValue{StringValue: &message},
+	}
+	out.Attributes = appendIntAttr(out.Attributes, "juju.record.id", rec.ID)
+	out.Attributes = appendStringAttr(out.Attributes, "code.namespace", rec.Location.Module)
+	out.Attributes = appendStringAttr(out.Attributes, "code.filepath", rec.Location.Filename)
+	if rec.Location.Line > 0 {
+		out.Attributes = appendIntAttr(out.Attributes, "code.lineno", int64(rec.Location.Line))
+	}
+	return out
+}
+
+func appendStringAttr(attrs []OTLPKeyValue, key, value string) []OTLPKeyValue {
+	if value == "" {
+		return attrs
+	}
+	return append(attrs, OTLPKeyValue{
+		Key:   key,
+		Value: OTLPAnyValue{StringValue: &value},
+	})
+}
+
+func appendIntAttr(attrs []OTLPKeyValue, key string, value int64) []OTLPKeyValue {
+	str := strconv.FormatInt(value, 10)
+	return append(attrs, OTLPKeyValue{
+		Key:   key,
+		Value: OTLPAnyValue{IntValue: &str},
+	})
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package target
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/jsonhttp"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// Type identifies the kind of log forwarding target.
+type Type string
+
+const (
+	// TypeSyslog forwards records to a remote syslog (RFC 5424) host.
+	TypeSyslog Type = "syslog"
+
+	// TypeHTTP forwards batches of records, encoded as JSON, to a
+	// remote HTTP endpoint.
+	TypeHTTP Type = "http"
+
+	// TypeOTLP forwards batches of records to an OpenTelemetry
+	// collector, using OTLP/HTTP.
+	TypeOTLP Type = "otlp"
+)
+
+// ParseType converts a string to a Type or fails if not able. An
+// empty string is parsed as TypeSyslog.
+func ParseType(value string) (Type, error) {
+	switch t := Type(value); t {
+	case "":
+		return TypeSyslog, nil
+	case TypeSyslog, TypeHTTP, TypeOTLP:
+		return t, nil
+	}
+	return "", errors.NotValidf("log forwarding type %q", value)
+}
+
+// RawConfig holds the raw configuration data for a log forwarding
+// target. Only the section matching Type is used.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Type is the kind of target that logs are forwarded to.
+	Type Type
+
+	// Syslog holds the config used when Type is TypeSyslog.
+	Syslog syslog.RawConfig
+
+	// HTTP holds the config used when Type is TypeHTTP or TypeOTLP.
+	HTTP jsonhttp.RawConfig
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	switch cfg.Type {
+	case TypeSyslog:
+		return errors.Trace(cfg.Syslog.Validate())
+	case TypeHTTP, TypeOTLP:
+		return errors.Trace(cfg.HTTP.Validate())
+	}
+	return errors.NotValidf("log forwarding type %q", string(cfg.Type))
+}
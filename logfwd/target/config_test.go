@@ -0,0 +1,79 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package target_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/jsonhttp"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestParseType(c *gc.C) {
+	for value, expected := range map[string]target.Type{
+		"":       target.TypeSyslog,
+		"syslog": target.TypeSyslog,
+		"http":   target.TypeHTTP,
+		"otlp":   target.TypeOTLP,
+	} {
+		t, err := target.ParseType(value)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(t, gc.Equals, expected)
+	}
+}
+
+func (s *ConfigSuite) TestParseTypeInvalid(c *gc.C) {
+	_, err := target.ParseType("carrier-pigeon")
+	c.Check(err, gc.ErrorMatches, `log forwarding type "carrier-pigeon" not valid`)
+}
+
+func (s *ConfigSuite) TestValidateSyslog(c *gc.C) {
+	cfg := target.RawConfig{
+		Enabled: true,
+		Type:    target.TypeSyslog,
+		Syslog:  syslog.RawConfig{Enabled: true},
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `Host "" not valid`)
+}
+
+func (s *ConfigSuite) TestValidateHTTPIgnoresSyslog(c *gc.C) {
+	cfg := target.RawConfig{
+		Enabled: true,
+		Type:    target.TypeOTLP,
+		Syslog:  syslog.RawConfig{Enabled: true},
+		HTTP: jsonhttp.RawConfig{
+			Enabled: true,
+			Format:  jsonhttp.FormatOTLP,
+			URL:     "http://collector:4318/v1/logs",
+		},
+	}
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestValidateHTTP(c *gc.C) {
+	cfg := target.RawConfig{
+		Enabled: true,
+		Type:    target.TypeHTTP,
+		HTTP:    jsonhttp.RawConfig{Enabled: true},
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `empty URL not valid`)
+}
+
+func (s *ConfigSuite) TestValidateUnknownType(c *gc.C) {
+	cfg := target.RawConfig{Type: "carrier-pigeon"}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `log forwarding type "carrier-pigeon" not valid`)
+}
@@ -0,0 +1,6 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The target package describes the remote target to which a model's
+// logs are forwarded, independent of the protocol used to reach it.
+package target
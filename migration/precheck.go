@@ -26,6 +26,7 @@ import (
 type PrecheckBackend interface {
 	AgentVersion() (version.Number, error)
 	NeedsCleanup() (bool, error)
+	HasSecrets() (bool, error)
 	Model() (PrecheckModel, error)
 	AllModelUUIDs() ([]string, error)
 	IsUpgrading() (bool, error)
@@ -144,6 +145,14 @@ func SourcePrecheck(
 		return errors.New("cleanup needed")
 	}
 
+	// Secret values are encrypted with a controller specific key and
+	// aren't exported, so models with secrets can't be migrated yet.
+	if hasSecrets, err := backend.HasSecrets(); err != nil {
+		return errors.Annotate(err, "checking secrets")
+	} else if hasSecrets {
+		return errors.New("model has secrets, which cannot be migrated yet")
+	}
+
 	// Check the source controller.
 	controllerBackend, err := backend.ControllerBackend()
 	if err != nil {
@@ -107,6 +107,20 @@ func (*SourcePrecheckSuite) TestCleanupsNeeded(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cleanup needed")
 }
 
+func (*SourcePrecheckSuite) TestSecretsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasSecretsErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking secrets: boom")
+}
+
+func (*SourcePrecheckSuite) TestHasSecrets(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasSecrets = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has secrets, which cannot be migrated yet")
+}
+
 func (s *SourcePrecheckSuite) TestIsUpgradingError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.controllerBackend.isUpgradingErr = errors.New("boom")
@@ -750,6 +764,9 @@ type fakeBackend struct {
 	cleanupNeeded bool
 	cleanupErr    error
 
+	hasSecrets    bool
+	hasSecretsErr error
+
 	isUpgrading    bool
 	isUpgradingErr error
 
@@ -786,6 +803,10 @@ func (b *fakeBackend) NeedsCleanup() (bool, error) {
 	return b.cleanupNeeded, b.cleanupErr
 }
 
+func (b *fakeBackend) HasSecrets() (bool, error) {
+	return b.hasSecrets, b.hasSecretsErr
+}
+
 func (b *fakeBackend) AgentVersion() (version.Number, error) {
 	return backendVersion, b.agentVersionErr
 }
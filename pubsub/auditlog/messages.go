@@ -0,0 +1,50 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import "github.com/juju/juju/core/auditlog"
+
+// QueryTopic is the topic name for the published message when a
+// client asks to search the audit logs. Every API server searches
+// its local audit log and publishes the matches on QueryResultsTopic.
+// data: `Query`
+const QueryTopic = "auditlog.query"
+
+// Query describes an audit log search. The times are RFC3339 strings
+// so that they survive the trip between API servers unchanged.
+type Query struct {
+	// RequestID is used to match the results to the query.
+	RequestID string `yaml:"request-id"`
+
+	User       string `yaml:"user,omitempty"`
+	ModelUUID  string `yaml:"model-uuid,omitempty"`
+	Facade     string `yaml:"facade,omitempty"`
+	Method     string `yaml:"method,omitempty"`
+	After      string `yaml:"after,omitempty"`
+	Before     string `yaml:"before,omitempty"`
+	ErrorsOnly bool   `yaml:"errors-only,omitempty"`
+	Limit      int    `yaml:"limit,omitempty"`
+}
+
+// QueryResultsTopic is the topic name for the published message
+// containing the audit log entries from one API server that match
+// a query.
+// data: `QueryResults`
+const QueryResultsTopic = "auditlog.query-results"
+
+// QueryResults holds the matching conversations from the audit log
+// of a single API server.
+type QueryResults struct {
+	// RequestID is the ID of the query these results are for.
+	RequestID string `yaml:"request-id"`
+
+	// ControllerID is the ID of the controller node that searched
+	// its audit log.
+	ControllerID string `yaml:"controller-id"`
+
+	Conversations []auditlog.ConversationEntry `yaml:"conversations,omitempty"`
+
+	// Error is set if the search failed.
+	Error string `yaml:"error,omitempty"`
+}
@@ -128,6 +128,18 @@ func (*suite) TestRead(c *gc.C) {
 			Version: 1,
 		},
 		expectBody: &value{X: "param"},
+	}, {
+		msg: `{"request-id": 5, "type": "foo", "request": "frob", "traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "params": {"X": "param"}}`,
+		expectHdr: rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:        "foo",
+				Action:      "frob",
+				TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+			Version: 1,
+		},
+		expectBody: &value{X: "param"},
 	}} {
 		c.Logf("test %d", i)
 		codec := jsoncodec.New(&testConn{
@@ -271,6 +283,18 @@ func (*suite) TestWrite(c *gc.C) {
 		},
 		body:   &value{X: "param"},
 		expect: `{"request-id": 4, "type": "foo", "version": 2, "request": "frob", "params": {"X": "param"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:        "foo",
+				Action:      "frob",
+				TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+			Version: 1,
+		},
+		body:   &value{X: "param"},
+		expect: `{"request-id": 5, "type": "foo", "request": "frob", "traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "params": {"X": "param"}}`,
 	}} {
 		c.Logf("test %d", i)
 		var conn testConn
@@ -85,6 +85,11 @@ type Request struct {
 
 	// Action holds the action to perform on the object.
 	Action string
+
+	// TraceParent optionally holds the caller's trace context, in
+	// the W3C traceparent format, so the request can be recorded
+	// as part of the caller's trace.
+	TraceParent string
 }
 
 // IsRequest returns whether the header represents an RPC request.  If
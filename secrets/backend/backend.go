@@ -0,0 +1,118 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backend defines where sensitive content, such as the values
+// of charm secrets and the attributes of cloud credentials, is stored.
+//
+// The internal backend keeps content in the controller database and is
+// implemented by the state package. Other backends keep content in an
+// external store, so that it never reaches the controller database.
+package backend
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/secrets/backend/vault"
+)
+
+const (
+	// Internal is the backend which stores content, encrypted, in the
+	// controller database.
+	Internal = "internal"
+
+	// Vault is the backend which stores content in a key/value store
+	// served over the Vault HTTP API.
+	Vault = "vault"
+)
+
+// Backend stores sensitive content, keyed by path.
+type Backend interface {
+	// Type returns the type of the backend, eg "internal".
+	Type() string
+
+	// SaveContent stores the content at the path, replacing any
+	// content already there.
+	SaveContent(path string, content map[string]string) error
+
+	// GetContent returns the content stored at the path, or an error
+	// satisfying errors.IsNotFound if there is none.
+	GetContent(path string) (map[string]string, error)
+
+	// DeleteContent removes the content stored at the path. It is not
+	// an error if there is none.
+	DeleteContent(path string) error
+}
+
+// CredentialKeys returns the attributes of the backend type's config
+// which are credentials. These are stored apart from the rest of the
+// model config, encrypted, and are never shown.
+func CredentialKeys(backendType string) []string {
+	switch backendType {
+	case Vault:
+		return []string{vault.TokenKey}
+	}
+	return nil
+}
+
+// IsCredentialKey returns whether the attribute is a credential in the
+// config of any backend type.
+func IsCredentialKey(key string) bool {
+	for _, backendType := range []string{Vault} {
+		for _, credentialKey := range CredentialKeys(backendType) {
+			if key == credentialKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateConfig returns an error if the attributes are not valid for
+// the backend type.
+func ValidateConfig(backendType string, attrs map[string]string) error {
+	return validateConfig(backendType, attrs, true)
+}
+
+// ValidateConfigWithoutCredentials returns an error if the attributes
+// are not valid for the backend type, allowing the credentials to be
+// missing.
+func ValidateConfigWithoutCredentials(backendType string, attrs map[string]string) error {
+	return validateConfig(backendType, attrs, false)
+}
+
+func validateConfig(backendType string, attrs map[string]string, withCredentials bool) error {
+	switch backendType {
+	case Internal:
+		if len(attrs) > 0 {
+			return errors.NotValidf("config for %s secret backend", Internal)
+		}
+		return nil
+	case Vault:
+		cfg, err := vault.ConfigFromAttrs(attrs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !withCredentials {
+			return errors.Trace(cfg.ValidateWithoutCredentials())
+		}
+		return errors.Trace(cfg.Validate())
+	}
+	return errors.NotValidf("secret backend %q", backendType)
+}
+
+// NewExternal returns the external backend of the given type, configured
+// with the attributes. The internal backend is created by the state
+// package, which owns the controller database.
+func NewExternal(backendType string, attrs map[string]string) (Backend, error) {
+	switch backendType {
+	case Vault:
+		cfg, err := vault.ConfigFromAttrs(attrs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return vault.NewBackend(cfg)
+	case Internal:
+		return nil, errors.NotValidf("%s secret backend as external", Internal)
+	}
+	return nil, errors.NotValidf("secret backend %q", backendType)
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backend_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/secrets/backend"
+)
+
+type BackendSuite struct{}
+
+var _ = gc.Suite(&BackendSuite{})
+
+func (s *BackendSuite) TestValidateConfig(c *gc.C) {
+	err := backend.ValidateConfig("internal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = backend.ValidateConfig("internal", map[string]string{"address": "http://vault:8200"})
+	c.Assert(err, gc.ErrorMatches, "config for internal secret backend not valid")
+
+	err = backend.ValidateConfig("vault", map[string]string{
+		"address": "http://vault:8200",
+		"token":   "t0ken",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = backend.ValidateConfig("vault", map[string]string{"token": "t0ken"})
+	c.Assert(err, gc.ErrorMatches, "vault secret backend without address not valid")
+
+	err = backend.ValidateConfig("somewhere", nil)
+	c.Assert(err, gc.ErrorMatches, `secret backend "somewhere" not valid`)
+}
+
+func (s *BackendSuite) TestValidateConfigWithoutCredentials(c *gc.C) {
+	err := backend.ValidateConfig("vault", map[string]string{"address": "http://vault:8200"})
+	c.Assert(err, gc.ErrorMatches, "vault secret backend without token not valid")
+	err = backend.ValidateConfigWithoutCredentials("vault", map[string]string{"address": "http://vault:8200"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = backend.ValidateConfigWithoutCredentials("vault", map[string]string{"token": "t0ken"})
+	c.Assert(err, gc.ErrorMatches, "vault secret backend without address not valid")
+}
+
+func (s *BackendSuite) TestCredentialKeys(c *gc.C) {
+	c.Assert(backend.CredentialKeys("vault"), jc.DeepEquals, []string{"token"})
+	c.Assert(backend.CredentialKeys("internal"), gc.HasLen, 0)
+	c.Assert(backend.IsCredentialKey("token"), jc.IsTrue)
+	c.Assert(backend.IsCredentialKey("address"), jc.IsFalse)
+}
+
+func (s *BackendSuite) TestNewExternal(c *gc.C) {
+	b, err := backend.NewExternal("vault", map[string]string{
+		"address": "http://vault:8200",
+		"token":   "t0ken",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(b.Type(), gc.Equals, backend.Vault)
+
+	_, err = backend.NewExternal("internal", nil)
+	c.Assert(err, gc.ErrorMatches, "internal secret backend as external not valid")
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package testing provides an in-memory stand-in for the parts of the
+// Vault HTTP API used by the vault secrets backend.
+package testing
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Server is a stand-in Vault server with a key/value version 2 secrets
+// engine mounted at "secret".
+type Server struct {
+	*httptest.Server
+
+	token string
+
+	mu      sync.Mutex
+	content map[string]map[string]string
+}
+
+// NewServer starts a stand-in Vault server accepting the token. The
+// caller should close it when done.
+func NewServer(token string) *Server {
+	s := &Server{
+		token:   token,
+		content: make(map[string]map[string]string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Content returns the content stored at the path in the secrets engine.
+func (s *Server) Content(path string) (map[string]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	content, ok := s.content[path]
+	return content, ok
+}
+
+// Paths returns the sorted paths holding content in the secrets engine.
+func (s *Server) Paths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	paths := make([]string, 0, len(s.content))
+	for path := range s.content {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("X-Vault-Token") != s.token {
+		writeErrors(w, http.StatusForbidden, "permission denied")
+		return
+	}
+	var path string
+	var isData bool
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/v1/secret/data/"):
+		path, isData = strings.TrimPrefix(req.URL.Path, "/v1/secret/data/"), true
+	case strings.HasPrefix(req.URL.Path, "/v1/secret/metadata/"):
+		path = strings.TrimPrefix(req.URL.Path, "/v1/secret/metadata/")
+	default:
+		writeErrors(w, http.StatusNotFound)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case isData && (req.Method == http.MethodPost || req.Method == http.MethodPut):
+		var body struct {
+			Data map[string]string `json:"data"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeErrors(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.content[path] = body.Data
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{}})
+	case isData && req.Method == http.MethodGet:
+		content, ok := s.content[path]
+		if !ok {
+			writeErrors(w, http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"data": content},
+		})
+	case !isData && req.Method == http.MethodDelete:
+		delete(s.content, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeErrors(w, http.StatusMethodNotAllowed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeErrors(w http.ResponseWriter, status int, errs ...string) {
+	if errs == nil {
+		errs = []string{}
+	}
+	writeJSON(w, status, map[string]interface{}{"errors": errs})
+}
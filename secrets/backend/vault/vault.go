@@ -0,0 +1,225 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package vault implements a secrets backend storing content in a
+// version 2 key/value secrets engine served over the Vault HTTP API.
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// AddressKey is the attribute holding the URL of the Vault server,
+	// eg "https://vault.example.com:8200".
+	AddressKey = "address"
+
+	// TokenKey is the attribute holding the token used to authenticate
+	// with the Vault server.
+	TokenKey = "token"
+
+	// MountPathKey is the attribute holding the path the key/value
+	// secrets engine is mounted at. It defaults to "secret".
+	MountPathKey = "mount-path"
+
+	// NamespaceKey is the attribute holding the Vault namespace to use,
+	// if any.
+	NamespaceKey = "namespace"
+
+	defaultMountPath = "secret"
+	requestTimeout   = 30 * time.Second
+)
+
+// Config holds the configuration of a Vault backend.
+type Config struct {
+	Address   string
+	Token     string
+	MountPath string
+	Namespace string
+
+	// HTTPClient, if set, is used to make requests to the server.
+	HTTPClient *http.Client
+}
+
+// ConfigFromAttrs returns the backend configuration held by the
+// attributes.
+func ConfigFromAttrs(attrs map[string]string) (Config, error) {
+	cfg := Config{MountPath: defaultMountPath}
+	for key, value := range attrs {
+		switch key {
+		case AddressKey:
+			cfg.Address = value
+		case TokenKey:
+			cfg.Token = value
+		case MountPathKey:
+			cfg.MountPath = value
+		case NamespaceKey:
+			cfg.Namespace = value
+		default:
+			return Config{}, errors.NotValidf("vault secret backend attribute %q", key)
+		}
+	}
+	return cfg, nil
+}
+
+// Validate returns an error if the configuration is not valid.
+func (cfg Config) Validate() error {
+	if err := cfg.ValidateWithoutCredentials(); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.Token == "" {
+		return errors.NotValidf("vault secret backend without %s", TokenKey)
+	}
+	return nil
+}
+
+// ValidateWithoutCredentials returns an error if the configuration,
+// other than the token, is not valid.
+func (cfg Config) ValidateWithoutCredentials() error {
+	if cfg.Address == "" {
+		return errors.NotValidf("vault secret backend without %s", AddressKey)
+	}
+	u, err := url.Parse(cfg.Address)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NotValidf("vault address %q", cfg.Address)
+	}
+	if strings.Trim(cfg.MountPath, "/") == "" {
+		return errors.NotValidf("empty vault %s", MountPathKey)
+	}
+	return nil
+}
+
+// Backend stores content in a Vault key/value secrets engine.
+type Backend struct {
+	cfg    Config
+	client *http.Client
+}
+
+// NewBackend returns a backend using the Vault server described by the
+// configuration.
+func NewBackend(cfg Config) (*Backend, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	return &Backend{cfg: cfg, client: client}, nil
+}
+
+// Type implements backend.Backend.
+func (b *Backend) Type() string {
+	return "vault"
+}
+
+// SaveContent implements backend.Backend.
+func (b *Backend) SaveContent(path string, content map[string]string) error {
+	body, err := json.Marshal(map[string]interface{}{"data": content})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := b.do(http.MethodPost, "data", path, body)
+	if err != nil {
+		return errors.Annotatef(err, "saving content at %q", path)
+	}
+	defer resp.Body.Close()
+	return errors.Annotatef(checkResponse(resp), "saving content at %q", path)
+}
+
+// GetContent implements backend.Backend.
+func (b *Backend) GetContent(path string) (map[string]string, error) {
+	resp, err := b.do(http.MethodGet, "data", path, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting content at %q", path)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.NotFoundf("content at %q", path)
+	}
+	if err := checkResponse(resp); err != nil {
+		return nil, errors.Annotatef(err, "getting content at %q", path)
+	}
+	var result struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.Annotatef(err, "decoding content at %q", path)
+	}
+	if result.Data.Data == nil {
+		// The latest version of the content was deleted.
+		return nil, errors.NotFoundf("content at %q", path)
+	}
+	return result.Data.Data, nil
+}
+
+// DeleteContent implements backend.Backend. All versions of the content
+// are removed.
+func (b *Backend) DeleteContent(path string) error {
+	resp, err := b.do(http.MethodDelete, "metadata", path, nil)
+	if err != nil {
+		return errors.Annotatef(err, "deleting content at %q", path)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return errors.Annotatef(checkResponse(resp), "deleting content at %q", path)
+}
+
+func (b *Backend) do(method, kind, path string, body []byte) (*http.Response, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	u := fmt.Sprintf("%s/v1/%s/%s/%s",
+		strings.TrimRight(b.cfg.Address, "/"),
+		strings.Trim(b.cfg.MountPath, "/"),
+		kind,
+		strings.Join(segments, "/"),
+	)
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("X-Vault-Token", b.cfg.Token)
+	if b.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", b.cfg.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return b.client.Do(req)
+}
+
+// checkResponse returns an error holding the messages sent by the
+// server if the request failed.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+	var result struct {
+		Errors []string `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &result); err == nil && len(result.Errors) > 0 {
+		return errors.Errorf("vault: %s (%s)", strings.Join(result.Errors, "; "), resp.Status)
+	}
+	return errors.Errorf("vault: %s", resp.Status)
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package vault_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/secrets/backend/vault"
+	vaulttesting "github.com/juju/juju/secrets/backend/vault/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type VaultSuite struct {
+	coretesting.BaseSuite
+	server  *vaulttesting.Server
+	backend *vault.Backend
+}
+
+var _ = gc.Suite(&VaultSuite{})
+
+func (s *VaultSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.server = vaulttesting.NewServer("t0ken")
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+
+	var err error
+	s.backend, err = vault.NewBackend(vault.Config{
+		Address:   s.server.URL,
+		Token:     "t0ken",
+		MountPath: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VaultSuite) TestConfigFromAttrs(c *gc.C) {
+	cfg, err := vault.ConfigFromAttrs(map[string]string{
+		"address":   "https://vault.example.com:8200",
+		"token":     "t0ken",
+		"namespace": "ns1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, vault.Config{
+		Address:   "https://vault.example.com:8200",
+		Token:     "t0ken",
+		MountPath: "secret",
+		Namespace: "ns1",
+	})
+	c.Assert(cfg.Validate(), jc.ErrorIsNil)
+
+	_, err = vault.ConfigFromAttrs(map[string]string{"foo": "bar"})
+	c.Assert(err, gc.ErrorMatches, `vault secret backend attribute "foo" not valid`)
+}
+
+func (s *VaultSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		cfg vault.Config
+		err string
+	}{{
+		cfg: vault.Config{Token: "t0ken", MountPath: "secret"},
+		err: "vault secret backend without address not valid",
+	}, {
+		cfg: vault.Config{Address: "vault:8200", Token: "t0ken", MountPath: "secret"},
+		err: `vault address "vault:8200" not valid`,
+	}, {
+		cfg: vault.Config{Address: "http://vault:8200", MountPath: "secret"},
+		err: "vault secret backend without token not valid",
+	}, {
+		cfg: vault.Config{Address: "http://vault:8200", Token: "t0ken", MountPath: "/"},
+		err: "empty vault mount-path not valid",
+	}} {
+		c.Logf("test %d", i)
+		c.Check(test.cfg.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *VaultSuite) TestSaveGetDelete(c *gc.C) {
+	c.Assert(s.backend.Type(), gc.Equals, "vault")
+
+	err := s.backend.SaveContent("juju/model/password", map[string]string{"password": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+	stored, ok := s.server.Content("juju/model/password")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(stored, jc.DeepEquals, map[string]string{"password": "s3cret"})
+
+	content, err := s.backend.GetContent("juju/model/password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(content, jc.DeepEquals, map[string]string{"password": "s3cret"})
+
+	err = s.backend.DeleteContent("juju/model/password")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.backend.GetContent("juju/model/password")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Deleting missing content is not an error.
+	err = s.backend.DeleteContent("juju/model/password")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VaultSuite) TestPathEscaped(c *gc.C) {
+	err := s.backend.SaveContent("credentials/aws/bob@external/default", map[string]string{"key": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.server.Content("credentials/aws/bob@external/default")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *VaultSuite) TestPermissionDenied(c *gc.C) {
+	b, err := vault.NewBackend(vault.Config{
+		Address:   s.server.URL,
+		Token:     "wrong",
+		MountPath: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = b.GetContent("juju/model/password")
+	c.Assert(err, gc.ErrorMatches, `getting content at "juju/model/password": vault: permission denied \(403 Forbidden\)`)
+}
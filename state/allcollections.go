@@ -478,6 +478,21 @@ func allCollections() CollectionSchema {
 			}},
 		},
 
+		// hookHistoryC holds a record of each hook run by unit
+		// agents, pruned along with status history.
+		hookHistoryC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "started"},
+			}, {
+				// used for model-specific pruning
+				Key: []string{"model-uuid", "-started", "-_id"},
+			}, {
+				// used for global pruning (after size check)
+				Key: []string{"-started"},
+			}},
+		},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
 			global:  true,
@@ -527,6 +542,29 @@ func allCollections() CollectionSchema {
 		// eg addresses.
 		cloudServicesC: {},
 
+		// secretMetadataC holds the metadata of the secrets created by
+		// charms, and secretRevisionsC their revisions.
+		secretMetadataC: {
+			indexes: []mgo.Index{
+				{Key: []string{"model-uuid", "owner-tag", "label"}},
+			},
+		},
+		secretRevisionsC: {},
+
+		// secretContentC holds the encrypted content stored by the
+		// internal secret backend.
+		secretContentC: {},
+
+		// secretsKeyC holds the controller's key encrypting secret
+		// content at rest. It is kept apart from the controllers
+		// collection so that backups can leave it out.
+		secretsKeyC: {global: true},
+
+		// secretPermissionsC holds the access granted to secrets, and
+		// secretConsumersC the revisions of secrets read by consumers.
+		secretPermissionsC: {},
+		secretConsumersC:   {},
+
 		// ----------------------
 
 		// Raw-access collections
@@ -609,6 +647,7 @@ const (
 	spacesC                    = "spaces"
 	statusesC                  = "statuses"
 	statusesHistoryC           = "statuseshistory"
+	hookHistoryC               = "hookhistory"
 	storageAttachmentsC        = "storageattachments"
 	storageConstraintsC        = "storageconstraints"
 	deviceConstraintsC         = "deviceConstraints"
@@ -639,4 +678,12 @@ const (
 	externalControllersC = "externalControllers"
 	relationNetworksC    = "relationNetworks"
 	firewallRulesC       = "firewallRules"
+
+	// Secrets
+	secretMetadataC    = "secretMetadata"
+	secretRevisionsC   = "secretRevisions"
+	secretPermissionsC = "secretPermissions"
+	secretConsumersC   = "secretConsumers"
+	secretContentC     = "secretContent"
+	secretsKeyC        = "secretsKey"
 )
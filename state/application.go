@@ -656,6 +656,12 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 	}
 	ops = append(ops, charmOps...)
 
+	secretsOps, err := a.st.removeSecretsOps(a.ApplicationTag())
+	if op.FatalError(err) {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretsOps...)
+
 	// By the time we get to here, all units and charm refs have been removed,
 	// so it's safe to do this additional cleanup.
 	ops = append(ops, finalAppCharmRemoveOps(name, curl)...)
@@ -2468,6 +2474,10 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D, op *ForcedOperation
 	if op.FatalError(err) {
 		return nil, errors.Trace(err)
 	}
+	secretsOps, err := a.st.removeSecretsOps(u.Tag())
+	if op.FatalError(err) {
+		return nil, errors.Trace(err)
+	}
 
 	observedFieldsMatch := bson.D{
 		{"charmurl", u.doc.CharmURL},
@@ -2492,6 +2502,7 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D, op *ForcedOperation
 	}
 	ops = append(ops, portsOps...)
 	ops = append(ops, resOps...)
+	ops = append(ops, secretsOps...)
 	ops = append(ops, hostOps...)
 
 	m, err := a.st.Model()
@@ -0,0 +1,323 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2/filestorage"
+)
+
+// defaultS3Region is the region used for S3 storage that doesn't
+// specify one. Most S3-compatible object stores ignore it.
+const defaultS3Region = "us-east-1"
+
+// openArchiveStorage returns the raw file storage for the backup
+// archives stored at the location, which is either empty for the
+// controller database, or a file or s3 URL.
+func openArchiveStorage(dbWrap *storageDBWrapper, location string) (filestorage.RawFileStorage, error) {
+	if location == "" {
+		return newFileStorage(dbWrap, backupStorageRoot), nil
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing backup storage %q", location)
+	}
+	switch u.Scheme {
+	case "file":
+		return &directoryStorage{dir: u.Path}, nil
+	case "s3":
+		stor, err := newS3Storage(u)
+		return stor, errors.Trace(err)
+	}
+	return nil, errors.NotValidf("backup storage %q", location)
+}
+
+//---------------------------
+// location-aware file storage
+
+// archiveStorage stores new backup archives at its location, and
+// reads and removes existing archives at the location recorded in
+// their metadata. This means that archives stored before the
+// backup-storage controller config was changed can still be used.
+type archiveStorage struct {
+	dbWrap   *storageDBWrapper
+	location string
+	target   filestorage.RawFileStorage
+}
+
+func newArchiveStorage(dbWrap *storageDBWrapper, location string) (filestorage.RawFileStorage, error) {
+	target, err := openArchiveStorage(dbWrap, location)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stor := archiveStorage{
+		dbWrap:   dbWrap.Copy(),
+		location: location,
+		target:   target,
+	}
+	return &stor, nil
+}
+
+// storageFor returns the storage holding the identified archive, and
+// whether the caller needs to close it.
+func (s *archiveStorage) storageFor(id string) (filestorage.RawFileStorage, bool, error) {
+	dbWrap := s.dbWrap.Copy()
+	defer dbWrap.Close()
+
+	doc, err := getStorageMetadata(dbWrap, id)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if doc.Storage == s.location {
+		return s.target, false, nil
+	}
+	stor, err := openArchiveStorage(s.dbWrap, doc.Storage)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	return stor, true, nil
+}
+
+// File returns the identified file from storage.
+func (s *archiveStorage) File(id string) (io.ReadCloser, error) {
+	stor, closeStor, err := s.storageFor(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	file, err := stor.File(id)
+	if err != nil {
+		if closeStor {
+			_ = stor.Close()
+		}
+		return nil, errors.Trace(err)
+	}
+	if closeStor {
+		return &storageFile{ReadCloser: file, stor: stor}, nil
+	}
+	return file, nil
+}
+
+// AddFile adds the file to storage.
+func (s *archiveStorage) AddFile(id string, file io.Reader, size int64) error {
+	return errors.Trace(s.target.AddFile(id, file, size))
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *archiveStorage) RemoveFile(id string) error {
+	stor, closeStor, err := s.storageFor(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if closeStor {
+		defer stor.Close()
+	}
+	return errors.Trace(stor.RemoveFile(id))
+}
+
+// Close closes the storage.
+func (s *archiveStorage) Close() error {
+	err := s.target.Close()
+	_ = s.dbWrap.Close()
+	return errors.Trace(err)
+}
+
+// storageFile closes the storage it was read from when it is closed.
+type storageFile struct {
+	io.ReadCloser
+	stor filestorage.RawFileStorage
+}
+
+// Close is part of io.Closer.
+func (f *storageFile) Close() error {
+	err := f.ReadCloser.Close()
+	_ = f.stor.Close()
+	return errors.Trace(err)
+}
+
+//---------------------------
+// directory storage
+
+// directoryStorage stores backup archives as files in a directory,
+// which may be on a network file system.
+type directoryStorage struct {
+	dir string
+}
+
+func (s *directoryStorage) path(id string) (string, error) {
+	if id == "" || filepath.Base(id) != id {
+		return "", errors.NotValidf("backup ID %q", id)
+	}
+	return filepath.Join(s.dir, id), nil
+}
+
+// File returns the identified file from storage.
+func (s *directoryStorage) File(id string) (io.ReadCloser, error) {
+	filename, err := s.path(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	file, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("backup archive %q", id)
+	}
+	return file, errors.Trace(err)
+}
+
+// AddFile adds the file to storage. The file is written to a temporary
+// file first, so a partially written archive is never seen.
+func (s *directoryStorage) AddFile(id string, file io.Reader, size int64) error {
+	filename, err := s.path(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return errors.Annotate(err, "creating backup storage directory")
+	}
+	tmp, err := ioutil.TempFile(s.dir, id+".tmp-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, file)
+	if err != nil {
+		_ = tmp.Close()
+		return errors.Annotatef(err, "writing backup archive %q", id)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Annotatef(err, "writing backup archive %q", id)
+	}
+	if written != size {
+		return errors.Errorf("backup archive %q: expected %d bytes, got %d", id, size, written)
+	}
+	return errors.Trace(os.Rename(tmp.Name(), filename))
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *directoryStorage) RemoveFile(id string) error {
+	filename, err := s.path(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(filename)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("backup archive %q", id)
+	}
+	return errors.Trace(err)
+}
+
+// Close closes the storage.
+func (s *directoryStorage) Close() error {
+	return nil
+}
+
+//---------------------------
+// S3 storage
+
+// s3Storage stores backup archives as objects in an S3-compatible
+// object store.
+type s3Storage struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+	bucket   string
+	prefix   string
+}
+
+// newS3Storage returns storage for the bucket and key prefix in the
+// s3 URL. The "endpoint" and "region" query parameters select the
+// object store; credentials are found the same way as the AWS CLI
+// finds them, optionally using the shared config "profile".
+func newS3Storage(u *url.URL) (*s3Storage, error) {
+	if u.Host == "" {
+		return nil, errors.NotValidf("backup storage %q without a bucket", u)
+	}
+	query := u.Query()
+	cfg := aws.NewConfig().WithRegion(defaultS3Region)
+	if region := query.Get("region"); region != "" {
+		cfg = cfg.WithRegion(region)
+	}
+	if endpoint := query.Get("endpoint"); endpoint != "" {
+		// Object stores other than AWS rarely support virtual
+		// host style bucket addressing.
+		cfg = cfg.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *cfg,
+		Profile:           query.Get("profile"),
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating S3 session")
+	}
+	client := s3.New(sess)
+	return &s3Storage{
+		client:   client,
+		uploader: s3manager.NewUploaderWithClient(client),
+		bucket:   u.Host,
+		prefix:   strings.Trim(u.Path, "/"),
+	}, nil
+}
+
+func (s *s3Storage) key(id string) string {
+	// Object keys always use forward slashes.
+	return path.Join(s.prefix, id)
+}
+
+// File returns the identified file from storage.
+func (s *s3Storage) File(id string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(id)),
+	})
+	if isS3NotFound(err) {
+		return nil, errors.NotFoundf("backup archive %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting backup archive %q", id)
+	}
+	return out.Body, nil
+}
+
+// AddFile adds the file to storage.
+func (s *s3Storage) AddFile(id string, file io.Reader, size int64) error {
+	_, err := s.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(id)),
+		Body:   file,
+	})
+	return errors.Annotatef(err, "uploading backup archive %q", id)
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *s3Storage) RemoveFile(id string) error {
+	_, err := s.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(id)),
+	})
+	return errors.Annotatef(err, "removing backup archive %q", id)
+}
+
+// Close closes the storage.
+func (s *s3Storage) Close() error {
+	return nil
+}
+
+func isS3NotFound(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == s3.ErrCodeNoSuchKey || awsErr.Code() == "NotFound"
+	}
+	return false
+}
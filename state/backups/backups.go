@@ -103,7 +103,8 @@ type Backups interface {
 }
 
 type backups struct {
-	storage filestorage.FileStorage
+	storage   filestorage.FileStorage
+	encrypter *Encrypter
 }
 
 // NewBackups creates a new Backups value using the FileStorage provided.
@@ -114,6 +115,33 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 	return &b
 }
 
+// NewControllerBackups creates a new Backups value for the controller,
+// which stores archives at the location set in the backup-storage
+// controller config, and encrypts them if the
+// backup-encryption-public-key controller config is set. The returned
+// Closer releases the storage.
+func NewControllerBackups(st DB) (Backups, io.Closer, error) {
+	controllerCfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "getting controller config")
+	}
+	var encrypter *Encrypter
+	if key := controllerCfg.BackupEncryptionPublicKey(); key != "" {
+		if encrypter, err = NewEncrypter(key); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
+	stor, err := NewStorage(st)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	b := backups{
+		storage:   stor,
+		encrypter: encrypter,
+	}
+	return &b, stor, nil
+}
+
 // Create creates and stores a new juju backup archive (based on arguments)
 // and updates the provided metadata.  A filename to download the backup is provided.
 func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error) {
@@ -141,7 +169,14 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 		return "", errors.Annotate(err, "while preparing for DB dump")
 	}
 
-	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload}
+	args := createArgs{
+		backupDir:      paths.BackupDir,
+		filesToBackUp:  filesToBackUp,
+		db:             dumper,
+		metadataReader: metadataFile,
+		noDownload:     noDownload,
+		encrypter:      b.encrypter,
+	}
 	result, err := runCreate(&args)
 	if err != nil {
 		return "", errors.Annotate(err, "while creating backup archive")
@@ -149,6 +184,7 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 	defer result.archiveFile.Close()
 
 	// Finalize the metadata.
+	meta.Encrypted = b.encrypter != nil
 	err = finishMeta(meta, result)
 	if err != nil {
 		return "", errors.Annotate(err, "while updating metadata")
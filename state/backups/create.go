@@ -33,6 +33,7 @@ type createArgs struct {
 	db             DBDumper
 	metadataReader io.Reader
 	noDownload     bool
+	encrypter      *Encrypter
 }
 
 type createResult struct {
@@ -50,6 +51,7 @@ func create(args *createArgs) (_ *createResult, err error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	builder.encrypter = args.encrypter
 	defer func() {
 		if cerr := builder.cleanUp(args.noDownload); cerr != nil {
 			cerr.Log(logger)
@@ -105,6 +107,8 @@ type builder struct {
 	// bundleFile is the inner archive file containing all the juju
 	// state-related files gathered during backup.
 	bundleFile io.WriteCloser
+	// encrypter encrypts the archive file, if it is not nil.
+	encrypter *Encrypter
 }
 
 // newBuilder returns a new backup archive builder.  It creates the temp
@@ -312,13 +316,28 @@ func (b *builder) buildArchiveAndChecksum() error {
 	logger.Infof("building archive file %q", b.filename)
 
 	// Build the tarball, writing out to both the archive file and a
-	// SHA1 hash.  The hash will correspond to the gzipped file rather
-	// than to the uncompressed contents of the tarball.  This is so
-	// that users can compare the published checksum against the
-	// checksum of the file without having to decompress it first.
+	// SHA1 hash.  The hash will correspond to the gzipped (and, if
+	// configured, encrypted) file rather than to the uncompressed
+	// contents of the tarball.  This is so that users can compare the
+	// published checksum against the checksum of the file without
+	// having to decompress it first.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
-	if err := b.buildArchive(hasher); err != nil {
-		return errors.Trace(err)
+	if b.encrypter == nil {
+		if err := b.buildArchive(hasher); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		encrypted, err := b.encrypter.Encrypt(hasher)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := b.buildArchive(encrypted); err != nil {
+			_ = encrypted.Close()
+			return errors.Trace(err)
+		}
+		if err := encrypted.Close(); err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
 	}
 
 	// Save the SHA1 checksum.
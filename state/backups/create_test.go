@@ -4,11 +4,15 @@
 package backups_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"os"
 	"path"
 	"runtime"
 
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/state/backups"
@@ -58,6 +62,54 @@ func (s *createSuite) TestLegacy(c *gc.C) {
 	s.checkArchive(c, file, expected)
 }
 
+func (s *createSuite) TestEncrypted(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	entity, err := openpgp.NewEntity("backups", "", "backups@example.com", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	var publicKey bytes.Buffer
+	w, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Serialize(w), jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	encrypter, err := backups.NewEncrypter(publicKey.String())
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	backupDir := c.MkDir()
+	_, testFiles, expected := s.createTestFiles(c)
+
+	dumper := &TestDBDumper{}
+	args := backups.NewTestCreateArgs(backupDir, testFiles, dumper, metadataFile, false)
+	backups.SetCreateArgsEncrypter(args, encrypter)
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archiveFile, size, checksum, _ := backups.ExposeCreateResult(result)
+	file, ok := archiveFile.(*os.File)
+	c.Assert(ok, jc.IsTrue)
+	s.checkSize(c, file, size)
+	s.checkChecksum(c, file, checksum)
+
+	// The archive can only be read once it has been decrypted.
+	_, err = gzip.NewReader(file)
+	c.Assert(err, gc.NotNil)
+	resetFile(c, file)
+	md, err := openpgp.ReadMessage(file, openpgp.EntityList{entity}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	tarFile, err := gzip.NewReader(md.UnverifiedBody)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkTarContents(c, tarFile, []tarContent{
+		{"juju-backup", "", nil},
+		{"juju-backup/dump", "", nil},
+		{"juju-backup/root.tar", "", expected},
+		{"juju-backup/metadata.json", "", nil},
+	})
+}
+
 func (s *createSuite) TestMetadataFileMissing(c *gc.C) {
 	var backupDir string
 	var testFiles []string
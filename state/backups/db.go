@@ -51,6 +51,13 @@ var ignoredDatabases = set.NewStrings(
 	imagestorage.ImagesDB, // note: this is still backed up anyway
 )
 
+// ignoredCollections holds the collections of each database which
+// should not be backed up. The key encrypting secrets at rest is left
+// out, so that the secrets in a backup can't be read from it alone.
+var ignoredCollections = map[string][]string{
+	"juju": {"secretsKey"},
+}
+
 type DBSession interface {
 	DatabaseNames() ([]string, error)
 }
@@ -208,8 +215,27 @@ func (md *mongoDumper) Dump(baseDumpDir string) error {
 
 	// Strip the ignored database from the dump dir.
 	ignored := found.Difference(md.Targets)
-	err = stripIgnored(ignored, baseDumpDir)
-	return errors.Trace(err)
+	if err := stripIgnored(ignored, baseDumpDir); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(stripIgnoredCollections(baseDumpDir))
+}
+
+// stripIgnoredCollections removes the dump files of the ignored
+// collections of each database.
+func stripIgnoredCollections(dumpDir string) error {
+	for dbName, collections := range ignoredCollections {
+		for _, collection := range collections {
+			for _, ext := range []string{".bson", ".metadata.json"} {
+				filename := filepath.Join(dumpDir, dbName, collection+ext)
+				logger.Tracef("stripIgnoredCollections deleting file %q", filename)
+				if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+					return errors.Trace(err)
+				}
+			}
+		}
+	}
+	return nil
 }
 
 // stripIgnored removes the ignored DBs from the mongo dump files.
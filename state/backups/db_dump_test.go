@@ -4,6 +4,7 @@
 package backups_test
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -131,6 +132,25 @@ func (s *dumpSuite) TestDumpStrippedMultiple(c *gc.C) {
 	s.checkStripped(c, "backups")
 }
 
+func (s *dumpSuite) TestDumpStrippedSecretsKey(c *gc.C) {
+	s.patch(c)
+	dumper := s.prep(c, "juju", "admin")
+	for _, name := range []string{
+		"secretsKey.bson", "secretsKey.metadata.json",
+		"secretContent.bson", "secretContent.metadata.json",
+	} {
+		err := ioutil.WriteFile(filepath.Join(s.dumpDir, "juju", name), nil, 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	err := dumper.Dump(s.dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkDBs(c, "juju", "admin", "juju/secretContent.bson", "juju/secretContent.metadata.json")
+	s.checkStripped(c, "juju/secretsKey.bson")
+	s.checkStripped(c, "juju/secretsKey.metadata.json")
+}
+
 func (s *dumpSuite) TestDumpNothingIgnored(c *gc.C) {
 	s.patch(c)
 	dumper := s.prep(c, "juju", "admin")
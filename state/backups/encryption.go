@@ -0,0 +1,60 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/openpgp"
+)
+
+// EncryptedSuffix is added to the filename of encrypted backup
+// archives, which are OpenPGP messages that can be decrypted with
+// "gpg --decrypt".
+const EncryptedSuffix = ".gpg"
+
+// Encrypter encrypts backup archives to a set of OpenPGP public keys.
+type Encrypter struct {
+	recipients openpgp.EntityList
+}
+
+// NewEncrypter returns an Encrypter that encrypts backup archives so
+// they can be decrypted by the private key of any of the keys in the
+// armored OpenPGP public key ring.
+func NewEncrypter(armoredKeyRing string) (*Encrypter, error) {
+	recipients, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredKeyRing))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading backup encryption public key")
+	}
+	if len(recipients) == 0 {
+		return nil, errors.NotValidf("backup encryption public key with no keys")
+	}
+	e := &Encrypter{recipients: recipients}
+
+	// Encrypt an empty message now, so that keys that can't be used
+	// for encryption are reported before any backup is created.
+	w, err := e.Encrypt(ioutil.Discard)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return e, nil
+}
+
+// Encrypt returns a writer that encrypts what is written to it and
+// writes the result to out. The encrypted message is only complete
+// once the returned writer has been closed.
+func (e *Encrypter) Encrypt(out io.Writer) (io.WriteCloser, error) {
+	hints := &openpgp.FileHints{IsBinary: true}
+	w, err := openpgp.Encrypt(out, e.recipients, nil, hints, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "encrypting backup archive")
+	}
+	return w, nil
+}
@@ -31,6 +31,9 @@ var (
 
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
 var _ filestorage.RawFileStorage = (*backupBlobStorage)(nil)
+var _ filestorage.RawFileStorage = (*archiveStorage)(nil)
+var _ filestorage.RawFileStorage = (*directoryStorage)(nil)
+var _ filestorage.RawFileStorage = (*s3Storage)(nil)
 
 func getBackupDBWrapper(st *state.State) *storageDBWrapper {
 	db := st.MongoSession().DB(storageDBName)
@@ -102,6 +105,11 @@ func NewTestCreateArgs(backupDir string, filesToBackUp []string, db DBDumper, me
 	return &args
 }
 
+// SetCreateArgsEncrypter sets the encrypter used by create().
+func SetCreateArgsEncrypter(args *createArgs, encrypter *Encrypter) {
+	args.encrypter = encrypter
+}
+
 // ExposeCreateResult extracts the values in a create() args value.
 func ExposeCreateArgs(args *createArgs) (string, []string, DBDumper) {
 	return args.backupDir, args.filesToBackUp, args.db
@@ -89,6 +89,14 @@ type Metadata struct {
 	// Controller contains metadata about the controller where the backup was taken.
 	Controller ControllerMetadata
 
+	// Encrypted records whether the archive is encrypted.
+	Encrypted bool
+
+	// Storage is the location where the archive is stored, as set in
+	// the backup-storage controller config when it was stored. It is
+	// empty if the archive is stored in the controller database.
+	Storage string
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
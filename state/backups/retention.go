@@ -0,0 +1,80 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ScheduledNotes is recorded as the notes of backups created on the
+// controller's backup schedule. Only backups with these notes are
+// removed by the retention policy.
+const ScheduledNotes = "scheduled backup"
+
+// RetentionPolicy determines which scheduled backups are kept.
+type RetentionPolicy struct {
+	// KeepDaily is the number of days, counting back from the most
+	// recent backup, for which the newest backup of the day is kept.
+	KeepDaily int
+
+	// KeepWeekly is the number of ISO weeks, counting back from the
+	// most recent backup, for which the newest backup of the week is
+	// kept.
+	KeepWeekly int
+}
+
+// Expired returns the backups that the policy doesn't keep, newest
+// first. A backup kept as both a daily and a weekly backup only
+// counts once. If the policy keeps no daily or weekly backups, then
+// nothing expires.
+func (p RetentionPolicy) Expired(metas []*Metadata) []*Metadata {
+	if p.KeepDaily <= 0 && p.KeepWeekly <= 0 {
+		return nil
+	}
+	sorted := make([]*Metadata, len(metas))
+	copy(sorted, metas)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Started.After(sorted[j].Started)
+	})
+
+	keep := make(map[*Metadata]bool)
+	keepNewestPerPeriod(sorted, p.KeepDaily, dayOf, keep)
+	keepNewestPerPeriod(sorted, p.KeepWeekly, weekOf, keep)
+
+	var expired []*Metadata
+	for _, meta := range sorted {
+		if !keep[meta] {
+			expired = append(expired, meta)
+		}
+	}
+	return expired
+}
+
+// keepNewestPerPeriod marks the newest of the sorted backups in each
+// of the n most recent periods as kept.
+func keepNewestPerPeriod(sorted []*Metadata, n int, period func(time.Time) string, keep map[*Metadata]bool) {
+	seen := make(map[string]bool)
+	for _, meta := range sorted {
+		key := period(meta.Started.UTC())
+		if seen[key] {
+			continue
+		}
+		if len(seen) >= n {
+			return
+		}
+		seen[key] = true
+		keep[meta] = true
+	}
+}
+
+func dayOf(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func weekOf(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
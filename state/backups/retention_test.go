@@ -0,0 +1,89 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+)
+
+type retentionSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&retentionSuite{})
+
+// dailyBackups returns a backup taken at 02:30 on each day of March
+// 2021 up to and including the given day, oldest first.
+func dailyBackups(lastDay int) []*backups.Metadata {
+	var metas []*backups.Metadata
+	for day := 1; day <= lastDay; day++ {
+		metas = append(metas, backupAt(time.Date(2021, 3, day, 2, 30, 0, 0, time.UTC)))
+	}
+	return metas
+}
+
+func backupAt(t time.Time) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.Started = t
+	meta.SetID(t.Format(time.RFC3339))
+	return meta
+}
+
+func ids(metas []*backups.Metadata) []string {
+	result := make([]string, len(metas))
+	for i, meta := range metas {
+		result[i] = meta.ID()
+	}
+	return result
+}
+
+func (s *retentionSuite) TestExpired(c *gc.C) {
+	// The 21st of March 2021 is a Sunday, the last day of ISO week 11.
+	metas := append(dailyBackups(21), backupAt(time.Date(2021, 3, 21, 1, 0, 0, 0, time.UTC)))
+	policy := backups.RetentionPolicy{KeepDaily: 3, KeepWeekly: 2}
+
+	var expected []string
+	expected = append(expected, "2021-03-21T01:00:00Z")
+	for _, day := range []string{"18", "17", "16", "15", "13", "12", "11", "10", "09", "08", "07", "06", "05", "04", "03", "02", "01"} {
+		expected = append(expected, "2021-03-"+day+"T02:30:00Z")
+	}
+	c.Assert(ids(policy.Expired(metas)), gc.DeepEquals, expected)
+}
+
+func (s *retentionSuite) TestExpiredDailyOnly(c *gc.C) {
+	policy := backups.RetentionPolicy{KeepDaily: 2}
+	c.Assert(ids(policy.Expired(dailyBackups(4))), gc.DeepEquals, []string{
+		"2021-03-02T02:30:00Z",
+		"2021-03-01T02:30:00Z",
+	})
+}
+
+func (s *retentionSuite) TestExpiredWeeklyOnly(c *gc.C) {
+	// The 8th of March 2021 is a Monday.
+	policy := backups.RetentionPolicy{KeepWeekly: 1}
+	c.Assert(ids(policy.Expired(dailyBackups(9))), gc.DeepEquals, []string{
+		"2021-03-08T02:30:00Z",
+		"2021-03-07T02:30:00Z",
+		"2021-03-06T02:30:00Z",
+		"2021-03-05T02:30:00Z",
+		"2021-03-04T02:30:00Z",
+		"2021-03-03T02:30:00Z",
+		"2021-03-02T02:30:00Z",
+		"2021-03-01T02:30:00Z",
+	})
+}
+
+func (s *retentionSuite) TestExpiredKeepsEverythingWithEmptyPolicy(c *gc.C) {
+	c.Assert(backups.RetentionPolicy{}.Expired(dailyBackups(10)), gc.HasLen, 0)
+}
+
+func (s *retentionSuite) TestExpiredFewerBackupsThanPolicy(c *gc.C) {
+	policy := backups.RetentionPolicy{KeepDaily: 7, KeepWeekly: 4}
+	c.Assert(policy.Expired(dailyBackups(3)), gc.HasLen, 0)
+}
@@ -47,6 +47,11 @@ type storageMetaDoc struct {
 	Finished int64  `bson:"finished,minsize"`
 	Notes    string `bson:"notes,omitempty"`
 
+	// archive
+
+	Encrypted bool   `bson:"encrypted,omitempty"`
+	Storage   string `bson:"storage,omitempty"`
+
 	// origin
 
 	Model    string         `bson:"model"`
@@ -123,6 +128,8 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Encrypted = doc.Encrypted
+	meta.Storage = doc.Storage
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -174,6 +181,8 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Encrypted = meta.Encrypted
+	doc.Storage = meta.Storage
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -383,6 +392,9 @@ func setStorageStoredTime(dbWrap *storageDBWrapper, id string, stored time.Time)
 
 type backupsDocStorage struct {
 	dbWrap *storageDBWrapper
+
+	// location is recorded as the storage of the backups added.
+	location string
 }
 
 type backupsMetadataStorage struct {
@@ -391,10 +403,10 @@ type backupsMetadataStorage struct {
 	modelUUID string
 }
 
-func newMetadataStorage(dbWrap *storageDBWrapper) *backupsMetadataStorage {
+func newMetadataStorage(dbWrap *storageDBWrapper, location string) *backupsMetadataStorage {
 	dbWrap = dbWrap.Copy()
 
-	docStor := backupsDocStorage{dbWrap}
+	docStor := backupsDocStorage{dbWrap: dbWrap, location: location}
 	stor := backupsMetadataStorage{
 		MetadataDocStorage: filestorage.MetadataDocStorage{&docStor},
 		db:                 dbWrap.db,
@@ -409,6 +421,7 @@ func (s *backupsDocStorage) AddDoc(doc filestorage.Document) (string, error) {
 	if !ok {
 		return "", errors.Errorf("doc must be of type *backups.Metadata")
 	}
+	metadata.Storage = s.location
 	metaDoc := newStorageMetaDoc(metadata)
 
 	dbWrap := s.dbWrap.Copy()
@@ -555,14 +568,25 @@ type DB interface {
 }
 
 // NewStorage returns a new FileStorage to use for storing backup
-// archives (and metadata).
-func NewStorage(st DB) filestorage.FileStorage {
+// archives (and metadata). The metadata is stored in the controller
+// database, and new archives are stored at the location set in the
+// backup-storage controller config.
+func NewStorage(st DB) (filestorage.FileStorage, error) {
+	controllerCfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting controller config")
+	}
+	location := controllerCfg.BackupStorage()
+
 	modelUUID := st.ModelTag().Id()
 	db := st.MongoSession().DB(storageDBName)
 	dbWrap := newStorageDBWrapper(db, storageMetaName, modelUUID)
 	defer dbWrap.Close()
 
-	files := newFileStorage(dbWrap, backupStorageRoot)
-	docs := newMetadataStorage(dbWrap)
-	return filestorage.NewFileStorage(docs, files)
+	files, err := newArchiveStorage(dbWrap, location)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening backup storage")
+	}
+	docs := newMetadataStorage(dbWrap, location)
+	return filestorage.NewFileStorage(docs, files), nil
 }
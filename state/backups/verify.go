@@ -0,0 +1,296 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v2/bson"
+	"github.com/juju/utils/v2/hash"
+	"github.com/juju/utils/v2/tar"
+
+	"github.com/juju/juju/agent"
+)
+
+const (
+	// jujuDBName is the name of the database holding juju state.
+	jujuDBName = "juju"
+
+	// modelsCollection is the name of the collection holding the
+	// controller's models.
+	modelsCollection = "models"
+
+	// maxBSONDocumentSize is the largest document mongodump writes,
+	// which is the largest document mongo stores plus the space it
+	// reserves for internal use.
+	maxBSONDocumentSize = 16*1024*1024 + 16*1024
+)
+
+// VerifiedArchive describes the contents of a backup archive that
+// VerifyArchive has found to be complete.
+type VerifiedArchive struct {
+	// Metadata is the metadata stored in the archive.
+	Metadata *Metadata
+
+	// Size is the size of the archive file.
+	Size int64
+
+	// Checksum is the checksum of the archive file, in the same format
+	// as the checksum recorded when the backup was created.
+	Checksum string
+
+	// Databases holds the names of the dumped databases.
+	Databases []string
+
+	// Agents holds the tags of the agents whose config is archived.
+	Agents []string
+
+	// Models describes the models in the dumped juju database.
+	Models []ArchivedModel
+}
+
+// ArchivedModel describes a model in a backup archive.
+type ArchivedModel struct {
+	UUID  string `bson:"_id"`
+	Name  string `bson:"name"`
+	Owner string `bson:"owner"`
+}
+
+// VerifyArchive reads a complete backup archive and checks that it
+// could be restored. It unpacks the archive into a workspace and checks
+// the metadata, every document in the database dump and the controller
+// agent config. An error is returned if the archive is truncated or
+// corrupt, or is missing any of these.
+func VerifyArchive(archive io.Reader) (*VerifiedArchive, error) {
+	ws, err := newArchiveWorkspace()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer ws.Close()
+
+	// The checksum covers the whole file, including anything after
+	// the end of the compressed stream.
+	hasher := hash.NewHashingWriter(ioutil.Discard, sha1.New())
+	source := &recordingReader{r: io.TeeReader(archive, hasher)}
+	if err := verifyUnpack(ws.RootDir, source); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := io.Copy(ioutil.Discard, source); err != nil {
+		return nil, errors.Annotate(err, "reading backup archive")
+	}
+
+	result := &VerifiedArchive{
+		Size:     source.n,
+		Checksum: hasher.Base64Sum(),
+	}
+	if result.Metadata, err = ws.Metadata(); os.IsNotExist(errors.Cause(err)) {
+		return nil, errors.New("backup archive has no metadata")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "reading backup metadata")
+	}
+	if result.Databases, result.Models, err = verifyDBDump(ws.DBDumpDir); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Agents, err = verifyFilesBundle(ws); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// verifyUnpack unpacks the compressed archive into the directory,
+// reading to the end of the compressed stream so that its checksum
+// is verified.
+func verifyUnpack(targetDir string, archive io.Reader) error {
+	gzr, err := gzip.NewReader(archive)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errors.New("backup archive is truncated")
+	} else if err != nil {
+		return errors.Annotate(err, "backup archive is corrupt")
+	}
+	defer gzr.Close()
+
+	contents := &recordingReader{r: gzr}
+	err = tar.UntarFiles(contents, targetDir)
+	if err == nil {
+		_, err = io.Copy(ioutil.Discard, contents)
+	}
+	if contents.err == io.ErrUnexpectedEOF {
+		return errors.New("backup archive is truncated")
+	}
+	return errors.Annotate(err, "backup archive is corrupt")
+}
+
+// recordingReader counts the bytes read from the underlying reader,
+// and records the first error other than io.EOF that it returned, so
+// that a truncated archive can be told apart from a corrupt one.
+type recordingReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+// Read is part of io.Reader.
+func (r *recordingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if err != nil && err != io.EOF && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
+
+// verifyDBDump checks every document in the database dump, and
+// returns the names of the dumped databases and the models in the
+// juju database.
+func verifyDBDump(dumpDir string) ([]string, []ArchivedModel, error) {
+	entries, err := ioutil.ReadDir(dumpDir)
+	if os.IsNotExist(err) {
+		return nil, nil, errors.New("backup archive has no database dump")
+	} else if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	var databases []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			// The oplog is dumped next to the databases.
+			if strings.HasSuffix(entry.Name(), ".bson") {
+				err := verifyBSONFile(filepath.Join(dumpDir, entry.Name()), nil)
+				if err != nil {
+					return nil, nil, errors.Trace(err)
+				}
+			}
+			continue
+		}
+		databases = append(databases, entry.Name())
+	}
+
+	var models []ArchivedModel
+	foundModels := false
+	for _, db := range databases {
+		files, err := filepath.Glob(filepath.Join(dumpDir, db, "*.bson"))
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		for _, filename := range files {
+			var each func([]byte) error
+			if db == jujuDBName && filepath.Base(filename) == modelsCollection+".bson" {
+				foundModels = true
+				each = func(doc []byte) error {
+					var model ArchivedModel
+					if err := bson.Unmarshal(doc, &model); err != nil {
+						return errors.Trace(err)
+					}
+					models = append(models, model)
+					return nil
+				}
+			}
+			if err := verifyBSONFile(filename, each); err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+		}
+	}
+	if !foundModels {
+		return nil, nil, errors.Errorf("database dump has no %q collection in the %q database", modelsCollection, jujuDBName)
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].Name < models[j].Name
+	})
+	return databases, models, nil
+}
+
+// verifyBSONFile checks that the file holds a sequence of complete and
+// valid BSON documents, as written by mongodump. Each document is
+// passed to each, if it is not nil.
+func verifyBSONFile(filename string, each func([]byte) error) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+
+	name := filepath.Base(filepath.Dir(filename)) + "/" + filepath.Base(filename)
+	r := bufio.NewReader(f)
+	for count := 1; ; count++ {
+		var header [4]byte
+		if _, err := io.ReadFull(r, header[:]); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Errorf("database dump %q is truncated at document %d", name, count)
+		}
+		size := int(binary.LittleEndian.Uint32(header[:]))
+		if size < 5 || size > maxBSONDocumentSize {
+			return errors.Errorf("database dump %q has invalid document %d size %d", name, count, size)
+		}
+		doc := make([]byte, size)
+		copy(doc, header[:])
+		if _, err := io.ReadFull(r, doc[4:]); err != nil {
+			return errors.Errorf("database dump %q is truncated at document %d", name, count)
+		}
+		var fields bson.D
+		if doc[size-1] != 0 || bson.Unmarshal(doc, &fields) != nil {
+			return errors.Errorf("database dump %q has corrupt document %d", name, count)
+		}
+		if each != nil {
+			if err := each(doc); err != nil {
+				return errors.Annotatef(err, "database dump %q document %d", name, count)
+			}
+		}
+	}
+}
+
+// verifyFilesBundle unpacks the archived files bundle and checks that
+// it holds at least one readable machine agent config, returning the
+// tags of the agents.
+func verifyFilesBundle(ws *ArchiveWorkspace) ([]string, error) {
+	filesDir := filepath.Join(ws.RootDir, "files")
+	if err := ws.UnpackFilesBundle(filesDir); os.IsNotExist(errors.Cause(err)) {
+		return nil, errors.New("backup archive has no files bundle")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "files bundle is corrupt")
+	}
+
+	var agents []string
+	err := filepath.Walk(filesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Trace(err)
+		}
+		agentDir := filepath.Dir(path)
+		if info.IsDir() ||
+			info.Name() != agent.AgentConfigFilename ||
+			filepath.Base(filepath.Dir(agentDir)) != agentsDir {
+			return nil
+		}
+		if matched, _ := filepath.Match(agentsConfs, filepath.Base(agentDir)); !matched {
+			return nil
+		}
+		config, err := agent.ReadConfig(path)
+		if err != nil {
+			return errors.Annotate(err, "agent config is corrupt")
+		}
+		if _, ok := config.StateServingInfo(); !ok {
+			return errors.Errorf("agent config for %q has no controller serving info", config.Tag())
+		}
+		agents = append(agents, config.Tag().String())
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(agents) == 0 {
+		return nil, errors.New("backup archive has no controller agent config")
+	}
+	sort.Strings(agents)
+	return agents, nil
+}
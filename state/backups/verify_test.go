@@ -0,0 +1,179 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/mgo/v2/bson"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type verifySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+// modelsDumper dumps a juju database holding the given model
+// documents, which are written as they are.
+type modelsDumper struct {
+	models [][]byte
+}
+
+func (d *modelsDumper) Dump(dumpDir string) error {
+	if err := os.MkdirAll(filepath.Join(dumpDir, "juju"), 0700); err != nil {
+		return err
+	}
+	var data bytes.Buffer
+	for _, doc := range d.models {
+		data.Write(doc)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dumpDir, "juju", "models.bson"), data.Bytes(), 0600); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dumpDir, "oplog.bson"), nil, 0600)
+}
+
+func (s *verifySuite) modelDoc(c *gc.C, uuid, name string) []byte {
+	doc, err := bson.Marshal(bson.M{"_id": uuid, "name": name, "owner": "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+	return doc
+}
+
+func (s *verifySuite) writeAgentConfig(c *gc.C) string {
+	dataDir := c.MkDir()
+	config, err := agent.NewStateMachineConfig(agent.AgentConfigParams{
+		Paths:             agent.Paths{DataDir: dataDir},
+		Tag:               names.NewMachineTag("0"),
+		UpgradedToVersion: jujuversion.Current,
+		Password:          "sekrit",
+		CACert:            "ca cert",
+		APIAddresses:      []string{"localhost:17070"},
+		Nonce:             "a nonce",
+		Controller:        testing.ControllerTag,
+		Model:             testing.ModelTag,
+	}, controller.StateServingInfo{
+		Cert:         "cert",
+		PrivateKey:   "key",
+		CAPrivateKey: "ca key",
+		StatePort:    37017,
+		APIPort:      17070,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config.Write(), jc.ErrorIsNil)
+	return filepath.Join(dataDir, "agents")
+}
+
+// createArchive creates a backup archive of the files, using the
+// dumper, and returns its contents and checksum.
+func (s *verifySuite) createArchive(c *gc.C, files []string, dumper backups.DBDumper) ([]byte, string) {
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := backups.NewTestCreateArgs(c.MkDir(), files, dumper, metadataFile, true)
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	archiveFile, _, checksum, _ := backups.ExposeCreateResult(result)
+	defer archiveFile.Close()
+
+	data, err := ioutil.ReadAll(archiveFile)
+	c.Assert(err, jc.ErrorIsNil)
+	return data, checksum
+}
+
+func (s *verifySuite) validArchive(c *gc.C) ([]byte, string) {
+	return s.createArchive(c, []string{s.writeAgentConfig(c)}, &modelsDumper{
+		models: [][]byte{
+			s.modelDoc(c, "deadbeef-0bad-400d-8000-4b1d0d06f00d", "default"),
+			s.modelDoc(c, "deadbeef-0bad-400d-8000-5b1d0d06f00d", "controller"),
+		},
+	})
+}
+
+func (s *verifySuite) TestVerifyArchive(c *gc.C) {
+	data, checksum := s.validArchive(c)
+
+	result, err := backups.VerifyArchive(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Size, gc.Equals, int64(len(data)))
+	c.Check(result.Checksum, gc.Equals, checksum)
+	c.Check(result.Metadata.Origin.Version, gc.Equals, jujuversion.Current)
+	c.Check(result.Databases, jc.DeepEquals, []string{"juju"})
+	c.Check(result.Agents, jc.DeepEquals, []string{"machine-0"})
+	c.Check(result.Models, jc.DeepEquals, []backups.ArchivedModel{{
+		UUID:  "deadbeef-0bad-400d-8000-5b1d0d06f00d",
+		Name:  "controller",
+		Owner: "admin",
+	}, {
+		UUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Name:  "default",
+		Owner: "admin",
+	}})
+}
+
+func (s *verifySuite) TestVerifyArchiveTruncated(c *gc.C) {
+	data, _ := s.validArchive(c)
+
+	_, err := backups.VerifyArchive(bytes.NewReader(data[:len(data)/2]))
+	c.Assert(err, gc.ErrorMatches, "backup archive is truncated")
+	_, err = backups.VerifyArchive(bytes.NewReader(nil))
+	c.Assert(err, gc.ErrorMatches, "backup archive is truncated")
+}
+
+func (s *verifySuite) TestVerifyArchiveCorrupt(c *gc.C) {
+	data, _ := s.validArchive(c)
+
+	// Damage the checksum of the uncompressed data, which is stored
+	// just before the size at the end of the file.
+	data[len(data)-8] ^= 0xff
+	_, err := backups.VerifyArchive(bytes.NewReader(data))
+	c.Assert(err, gc.ErrorMatches, "backup archive is corrupt: .*invalid checksum")
+}
+
+func (s *verifySuite) TestVerifyArchiveCorruptDocument(c *gc.C) {
+	doc := s.modelDoc(c, "deadbeef-0bad-400d-8000-4b1d0d06f00d", "default")
+	doc[len(doc)-1] = 1
+	data, _ := s.createArchive(c, []string{s.writeAgentConfig(c)}, &modelsDumper{
+		models: [][]byte{s.modelDoc(c, "deadbeef-0bad-400d-8000-5b1d0d06f00d", "controller"), doc},
+	})
+
+	_, err := backups.VerifyArchive(bytes.NewReader(data))
+	c.Assert(err, gc.ErrorMatches, `database dump "juju/models.bson" has corrupt document 2`)
+}
+
+func (s *verifySuite) TestVerifyArchiveTruncatedDocument(c *gc.C) {
+	doc := s.modelDoc(c, "deadbeef-0bad-400d-8000-4b1d0d06f00d", "default")
+	data, _ := s.createArchive(c, []string{s.writeAgentConfig(c)}, &modelsDumper{
+		models: [][]byte{doc[:len(doc)-3]},
+	})
+
+	_, err := backups.VerifyArchive(bytes.NewReader(data))
+	c.Assert(err, gc.ErrorMatches, `database dump "juju/models.bson" is truncated at document 1`)
+}
+
+func (s *verifySuite) TestVerifyArchiveNoAgentConfig(c *gc.C) {
+	dataDir := c.MkDir()
+	nonce := filepath.Join(dataDir, "nonce.txt")
+	c.Assert(ioutil.WriteFile(nonce, []byte("a nonce"), 0600), jc.ErrorIsNil)
+	data, _ := s.createArchive(c, []string{nonce}, &modelsDumper{
+		models: [][]byte{s.modelDoc(c, "deadbeef-0bad-400d-8000-4b1d0d06f00d", "default")},
+	})
+
+	_, err := backups.VerifyArchive(bytes.NewReader(data))
+	c.Assert(err, gc.ErrorMatches, "backup archive has no controller agent config")
+}
@@ -0,0 +1,41 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/mongo/utils"
+)
+
+// backupsGlobalKey is the key under which the outcomes of scheduled
+// controller backups are recorded in the status history.
+const backupsGlobalKey = "backups"
+
+// RecordBackupStatus adds the outcome of a scheduled backup to the
+// status history of the controller's backups.
+func (st *State) RecordBackupStatus(info status.StatusInfo) error {
+	doc := statusDoc{
+		Status:     info.Status,
+		StatusInfo: info.Message,
+		StatusData: utils.EscapeKeys(info.Data),
+		Updated:    timeOrNow(info.Since, st.clock()).UnixNano(),
+	}
+	if _, err := probablyUpdateStatusHistory(st.db(), backupsGlobalKey, doc); err != nil {
+		return errors.Annotate(err, "cannot record backup status")
+	}
+	return nil
+}
+
+// BackupStatusHistory returns the outcomes of scheduled backups,
+// newest first, as selected by the filter.
+func (st *State) BackupStatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	args := &statusHistoryArgs{
+		db:        st.db(),
+		globalKey: backupsGlobalKey,
+		filter:    filter,
+	}
+	return statusHistory(args)
+}
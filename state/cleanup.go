@@ -61,6 +61,7 @@ const (
 	cleanupStorageForDyingModel  cleanupKind = "modelStorage"
 	cleanupForceStorage          cleanupKind = "forceStorage"
 	cleanupBranchesForDyingModel cleanupKind = "branches"
+	cleanupSecretRevisions       cleanupKind = "secretRevisions"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -206,6 +207,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupForceStorage(args)
 		case cleanupBranchesForDyingModel:
 			err = st.cleanupBranchesForDyingModel(args)
+		case cleanupSecretRevisions:
+			err = st.removeSecretRevisions(doc.Prefix)
 		default:
 			err = errors.Errorf("unknown cleanup kind %q", doc.Kind)
 		}
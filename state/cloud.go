@@ -327,6 +327,7 @@ func regionSettingsGlobalKey(cloud, region string) string {
 // RemoveCloud removes a cloud and any credentials for that cloud.
 // If the cloud is in use, ie has models deployed to it, the operation fails.
 func (st *State) RemoveCloud(name string) error {
+	var removedCreds []cloudCredentialDoc
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if _, err := st.Cloud(name); err != nil {
 			// Fail with not found error on first attempt if cloud doesn't exist.
@@ -337,9 +338,23 @@ func (st *State) RemoveCloud(name string) error {
 			}
 			return nil, errors.Trace(err)
 		}
+		var err error
+		if removedCreds, err = st.cloudCredentialDocs(name); err != nil {
+			return nil, errors.Trace(err)
+		}
 		return st.removeCloudOps(name)
 	}
-	return st.db().Run(buildTxn)
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	// The attributes of the removed credentials may be held by an
+	// external secret backend, which the transaction can't touch.
+	for _, doc := range removedCreds {
+		if doc.AttributesRef != "" {
+			st.deleteCredentialAttributes(doc)
+		}
+	}
+	return nil
 }
 
 // removeCloudOp returns a list of txn.Ops that will remove
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/mgo/v2/txn"
 	"github.com/juju/names/v4"
 	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/v2"
 
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/permission"
@@ -45,6 +46,12 @@ type cloudCredentialDoc struct {
 	AuthType   string            `bson:"auth-type"`
 	Attributes map[string]string `bson:"attributes,omitempty"`
 
+	// AttributesBackend is the type of the external secret backend
+	// holding the attributes at AttributesRef, when they aren't held
+	// in Attributes.
+	AttributesBackend string `bson:"attributes-backend,omitempty"`
+	AttributesRef     string `bson:"attributes-ref,omitempty"`
+
 	// Invalid stores flag that indicates if a credential is invalid.
 	// Note that the credential is valid:
 	//  * if the flag is explicitly set to 'false'; or
@@ -62,22 +69,82 @@ type cloudCredentialDoc struct {
 	InvalidReason string `bson:"invalid-reason,omitempty"`
 }
 
+// credentialAttributesRef records where the attributes of a cloud
+// credential are stored in an external secret backend. The zero value
+// means they are stored in the credential's document.
+type credentialAttributesRef struct {
+	Backend string
+	Path    string
+}
+
+// newCredentialContentPath returns a new path at which an external
+// secret backend holds the attributes of a cloud credential. Each write
+// uses a new path, so that the attributes referenced by the credential
+// are only replaced once the update is committed.
+func newCredentialContentPath(controllerUUID string, tag names.CloudCredentialTag) (string, error) {
+	id, err := utils.NewUUID()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("juju/%s/credentials/%s/%s/%s/%s",
+		controllerUUID, tag.Cloud().Id(), tag.Owner().Id(), tag.Name(), id), nil
+}
+
 // CloudCredential returns the cloud credential for the given tag.
 func (st *State) CloudCredential(tag names.CloudCredentialTag) (Credential, error) {
+	doc, err := st.cloudCredentialDoc(tag)
+	if err != nil {
+		return Credential{}, errors.Trace(err)
+	}
+	return st.credentialFromDoc(doc)
+}
+
+func (st *State) cloudCredentialDoc(tag names.CloudCredentialTag) (cloudCredentialDoc, error) {
 	coll, cleanup := st.db().GetCollection(cloudCredentialsC)
 	defer cleanup()
 
 	var doc cloudCredentialDoc
 	err := coll.FindId(cloudCredentialDocID(tag)).One(&doc)
 	if err == mgo.ErrNotFound {
-		return Credential{}, errors.NotFoundf(
+		return cloudCredentialDoc{}, errors.NotFoundf(
 			"cloud credential %q", tag.Id(),
 		)
 	} else if err != nil {
-		return Credential{}, errors.Annotatef(
+		return cloudCredentialDoc{}, errors.Annotatef(
 			err, "getting cloud credential %q", tag.Id(),
 		)
 	}
+	return doc, nil
+}
+
+// cloudCredentialDocs returns the documents of all the credentials for
+// the cloud.
+func (st *State) cloudCredentialDocs(cloudName string) ([]cloudCredentialDoc, error) {
+	coll, cleanup := st.db().GetCollection(cloudCredentialsC)
+	defer cleanup()
+
+	var docs []cloudCredentialDoc
+	if err := coll.Find(bson.D{{"cloud", cloudName}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "getting cloud credentials for %q", cloudName)
+	}
+	return docs, nil
+}
+
+// credentialFromDoc returns the credential recorded by the document,
+// fetching its attributes from the secret backend holding them.
+func (st *State) credentialFromDoc(doc cloudCredentialDoc) (Credential, error) {
+	if doc.AttributesRef == "" {
+		return Credential{doc}, nil
+	}
+	backend, err := st.credentialsBackend(doc.AttributesBackend)
+	if err != nil {
+		return Credential{}, errors.Annotatef(err, "getting attributes of cloud credential %q", doc.DocID)
+	}
+	attrs, err := backend.GetContent(doc.AttributesRef)
+	if err != nil {
+		return Credential{}, errors.Annotatef(err, "getting attributes of cloud credential %q", doc.DocID)
+	}
+	doc.Attributes = attrs
 	return Credential{doc}, nil
 }
 
@@ -100,7 +167,11 @@ func (st *State) CloudCredentials(user names.UserTag, cloudName string) (map[str
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		credentials[tag.Id()] = Credential{doc}
+		credential, err := st.credentialFromDoc(doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		credentials[tag.Id()] = credential
 	}
 	if err := iter.Close(); err != nil {
 		return nil, errors.Annotatef(
@@ -146,11 +217,30 @@ func (st *State) UpdateCloudCredential(tag names.CloudCredentialTag, credential
 	}
 	annotationMsg := "updating cloud credentials"
 
-	existing, err := st.CloudCredential(tag)
+	existing, err := st.cloudCredentialDoc(tag)
 	if err != nil && !errors.IsNotFound(err) {
 		return errors.Annotatef(err, "fetching cloud credentials")
 	}
 	exists := err == nil
+
+	backend, err := st.credentialsBackend("")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var ref credentialAttributesRef
+	if backend != nil {
+		path, err := newCredentialContentPath(st.ControllerUUID(), tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ref = credentialAttributesRef{
+			Backend: backend.Type(),
+			Path:    path,
+		}
+		if err := backend.SaveContent(ref.Path, credential.Attributes()); err != nil {
+			return errors.Annotate(err, "saving cloud credential attributes")
+		}
+	}
 	var revert map[*Model]func() error
 	if exists {
 		// Existing credential will become valid after this call, and
@@ -174,19 +264,27 @@ func (st *State) UpdateCloudCredential(tag names.CloudCredentialTag, credential
 			return nil, errors.Trace(err)
 		}
 		if exists {
-			ops = append(ops, updateCloudCredentialOp(tag, credential))
+			ops = append(ops, updateCloudCredentialOp(tag, credential, ref))
 		} else {
 			annotationMsg = "creating cloud credential"
 			if credential.Invalid || credential.InvalidReason != "" {
 				return nil, errors.NotSupportedf("adding invalid credential")
 			}
-			ops = append(ops, createCloudCredentialOp(tag, credential))
+			ops = append(ops, createCloudCredentialOp(tag, credential, ref))
 		}
 		return ops, nil
 	}
 	if err := st.db().Run(buildTxn); err != nil {
+		if backend != nil {
+			deleteSecretContent(backend, ref.Path)
+		}
 		return errors.Annotate(err, annotationMsg)
 	}
+	// The previous attributes are no longer referenced once the
+	// update is committed.
+	if exists && existing.AttributesRef != "" {
+		st.deleteCredentialAttributes(existing)
+	}
 	if len(revert) > 0 {
 		for m, closer := range revert {
 			if err := m.maybeRevertModelStatus(); err != nil {
@@ -269,50 +367,77 @@ func (st *State) InvalidateCloudCredential(tag names.CloudCredentialTag, reason
 
 // RemoveCloudCredential removes a cloud credential with the given tag.
 func (st *State) RemoveCloudCredential(tag names.CloudCredentialTag) error {
+	var removed *cloudCredentialDoc
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		_, err := st.CloudCredential(tag)
+		doc, err := st.cloudCredentialDoc(tag)
 		if errors.IsNotFound(err) {
 			return nil, jujutxn.ErrNoOperations
 		}
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		removed = &doc
 		return removeCloudCredentialOps(tag), nil
 	}
 	if err := st.db().Run(buildTxn); err != nil {
 		return errors.Annotate(err, "removing cloud credential")
 	}
+	if removed != nil && removed.AttributesRef != "" {
+		st.deleteCredentialAttributes(*removed)
+	}
 	return nil
 }
 
+// deleteCredentialAttributes removes the attributes of a credential
+// which are no longer referenced from the secret backend holding them.
+func (st *State) deleteCredentialAttributes(doc cloudCredentialDoc) {
+	backend, err := st.credentialsBackend(doc.AttributesBackend)
+	if err != nil {
+		logger.Warningf("cannot delete attributes of cloud credential %q: %v", doc.DocID, err)
+		return
+	}
+	deleteSecretContent(backend, doc.AttributesRef)
+}
+
 // createCloudCredentialOp returns a txn.Op that will create
-// a cloud credential.
-func createCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential) txn.Op {
+// a cloud credential, whose attributes are stored at ref if set.
+func createCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential, ref credentialAttributesRef) txn.Op {
+	doc := &cloudCredentialDoc{
+		Owner:             tag.Owner().Id(),
+		Cloud:             tag.Cloud().Id(),
+		Name:              tag.Name(),
+		AuthType:          string(cred.AuthType()),
+		AttributesBackend: ref.Backend,
+		AttributesRef:     ref.Path,
+		Revoked:           cred.Revoked,
+	}
+	if ref.Path == "" {
+		doc.Attributes = cred.Attributes()
+	}
 	return txn.Op{
 		C:      cloudCredentialsC,
 		Id:     cloudCredentialDocID(tag),
 		Assert: txn.DocMissing,
-		Insert: &cloudCredentialDoc{
-			Owner:      tag.Owner().Id(),
-			Cloud:      tag.Cloud().Id(),
-			Name:       tag.Name(),
-			AuthType:   string(cred.AuthType()),
-			Attributes: cred.Attributes(),
-			Revoked:    cred.Revoked,
-		},
+		Insert: doc,
 	}
 }
 
 // updateCloudCredentialOp returns a txn.Op that will update
-// a cloud credential.
-func updateCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential) txn.Op {
+// a cloud credential, whose attributes are stored at ref if set.
+func updateCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential, ref credentialAttributesRef) txn.Op {
+	var attrs map[string]string
+	if ref.Path == "" {
+		attrs = cred.Attributes()
+	}
 	return txn.Op{
 		C:      cloudCredentialsC,
 		Id:     cloudCredentialDocID(tag),
 		Assert: txn.DocExists,
 		Update: bson.D{{"$set", bson.D{
 			{"auth-type", string(cred.AuthType())},
-			{"attributes", cred.Attributes()},
+			{"attributes", attrs},
+			{"attributes-backend", ref.Backend},
+			{"attributes-ref", ref.Path},
 			{"revoked", cred.Revoked},
 			{"invalid", cred.Invalid},
 			{"invalid-reason", cred.InvalidReason},
@@ -438,7 +563,11 @@ func (st *State) AllCloudCredentials(user names.UserTag) ([]Credential, error) {
 
 	credentials := make([]Credential, len(docs))
 	for i, doc := range docs {
-		credentials[i] = Credential{doc}
+		credential, err := st.credentialFromDoc(doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		credentials[i] = credential
 	}
 	return credentials, nil
 }
@@ -9,12 +9,14 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/mgo/v2/bson"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/status"
+	vaulttesting "github.com/juju/juju/secrets/backend/vault/testing"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing/factory"
@@ -468,6 +470,136 @@ func (s *CloudCredentialsSuite) TestRemoveCredentials(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *CloudCredentialsSuite) TestCredentialAttributesInExternalBackend(c *gc.C) {
+	server := vaulttesting.NewServer("t0ken")
+	defer server.Close()
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=" + server.URL + ";token=t0ken",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AddCloud(cloud.Cloud{
+		Name:      "stratus",
+		Type:      "low",
+		AuthTypes: cloud.AuthTypes{cloud.AccessKeyAuthType, cloud.UserPassAuthType},
+	}, s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	tag := names.NewCloudCredentialTag("stratus/bob/bobcred1")
+	attrs := map[string]string{"foo": "foo val", "bar": "bar val"}
+	err = s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.AccessKeyAuthType, attrs))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The attributes don't reach the controller database.
+	coll, closer := state.GetRawCollection(s.State, "cloudCredentials")
+	defer closer()
+	var raw bson.M
+	err = coll.FindId("stratus#bob#bobcred1").One(&raw)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(raw["attributes"], gc.IsNil)
+	c.Assert(raw["attributes-backend"], gc.Equals, "vault")
+
+	path := raw["attributes-ref"].(string)
+	c.Assert(path, jc.HasPrefix, fmt.Sprintf("juju/%s/credentials/stratus/bob/bobcred1/", s.State.ControllerUUID()))
+	stored, ok := server.Content(path)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(stored, jc.DeepEquals, attrs)
+
+	out, err := s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Attributes, jc.DeepEquals, attrs)
+
+	err = s.State.RemoveCloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = server.Content(path)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *CloudCredentialsSuite) TestRemoveCloudDeletesExternalAttributes(c *gc.C) {
+	server := vaulttesting.NewServer("t0ken")
+	defer server.Close()
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=" + server.URL + ";token=t0ken",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AddCloud(cloud.Cloud{
+		Name:      "stratus",
+		Type:      "low",
+		AuthTypes: cloud.AuthTypes{cloud.AccessKeyAuthType},
+	}, s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewCloudCredentialTag("stratus/bob/bobcred1")
+	err = s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{"foo": "foo val"}))
+	c.Assert(err, jc.ErrorIsNil)
+	path := s.credentialAttributesRef(c, tag)
+	_, ok := server.Content(path)
+	c.Assert(ok, jc.IsTrue)
+
+	err = s.State.RemoveCloud("stratus")
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = server.Content(path)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCredentialAttributesInExternalBackend(c *gc.C) {
+	server := vaulttesting.NewServer("t0ken")
+	defer server.Close()
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=" + server.URL + ";token=t0ken",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AddCloud(cloud.Cloud{
+		Name:      "stratus",
+		Type:      "low",
+		AuthTypes: cloud.AuthTypes{cloud.AccessKeyAuthType},
+	}, s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewCloudCredentialTag("stratus/bob/bobcred1")
+	attrs := map[string]string{"foo": "foo val"}
+	err = s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.AccessKeyAuthType, attrs))
+	c.Assert(err, jc.ErrorIsNil)
+	oldPath := s.credentialAttributesRef(c, tag)
+
+	// A failed update leaves the stored attributes untouched.
+	err = s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "bob", "password": "n3w",
+	}))
+	c.Assert(err, gc.ErrorMatches, `updating cloud credentials: validating credential "stratus/bob/bobcred1" for cloud "stratus": supported auth-types \["access-key"\], "userpass" not supported`)
+	c.Assert(s.credentialAttributesRef(c, tag), gc.Equals, oldPath)
+	c.Assert(server.Paths(), jc.DeepEquals, []string{oldPath})
+	out, err := s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Attributes, jc.DeepEquals, attrs)
+
+	// A successful one replaces them.
+	newAttrs := map[string]string{"foo": "new foo val"}
+	err = s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.AccessKeyAuthType, newAttrs))
+	c.Assert(err, jc.ErrorIsNil)
+	newPath := s.credentialAttributesRef(c, tag)
+	c.Assert(newPath, gc.Not(gc.Equals), oldPath)
+	c.Assert(server.Paths(), jc.DeepEquals, []string{newPath})
+	out, err = s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Attributes, jc.DeepEquals, newAttrs)
+}
+
+// credentialAttributesRef returns the path of the credential's
+// attributes in the external secret backend holding them.
+func (s *CloudCredentialsSuite) credentialAttributesRef(c *gc.C, tag names.CloudCredentialTag) string {
+	coll, closer := state.GetRawCollection(s.State, "cloudCredentials")
+	defer closer()
+	var raw bson.M
+	err := coll.FindId(fmt.Sprintf("%s#%s#%s", tag.Cloud().Id(), tag.Owner().Id(), tag.Name())).One(&raw)
+	c.Assert(err, jc.ErrorIsNil)
+	path, _ := raw["attributes-ref"].(string)
+	return path
+}
+
 func (s *CloudCredentialsSuite) createCredentialWatcher(c *gc.C, st *state.State, cred names.CloudCredentialTag) (
 	state.NotifyWatcher, statetesting.NotifyWatcherC,
 ) {
@@ -538,6 +538,7 @@ func MakeLogDoc(
 	location string,
 	level loggo.Level,
 	msg string,
+	labels ...string,
 ) *logDoc {
 	return &logDoc{
 		Id:       bson.NewObjectId(),
@@ -548,6 +549,7 @@ func MakeLogDoc(
 		Location: location,
 		Level:    int(level),
 		Message:  msg,
+		Labels:   labels,
 	}
 }
 
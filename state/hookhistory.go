@@ -0,0 +1,180 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/mgo/v2/bson"
+)
+
+// maxHookRunStderr is the most of the end of a hook's stderr which
+// is kept in its hook history record.
+const maxHookRunStderr = 4096
+
+// HookRun records a hook run by a unit agent.
+type HookRun struct {
+	// Hook is the name of the hook, eg "db-relation-changed".
+	Hook string
+
+	// Relation identifies the relation a relation hook ran for,
+	// eg "db:2", and is otherwise empty.
+	Relation string
+
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode is the hook's exit code, or -1 if the hook was
+	// interrupted or couldn't be started.
+	ExitCode int
+
+	// Stderr holds the end of what the hook wrote to stderr.
+	Stderr string
+}
+
+// HookHistoryFilter restricts the hook runs returned by
+// Unit.HookHistory. The zero value matches every run.
+type HookHistoryFilter struct {
+	// Hook, if set, matches runs of the hook with that name.
+	Hook string
+
+	// Relation, if set, matches runs for that relation.
+	Relation string
+
+	// Since, if set, matches runs started after that time.
+	Since *time.Time
+
+	// FailedOnly matches runs which didn't exit successfully.
+	FailedOnly bool
+
+	// Size, if positive, is the most runs returned.
+	Size int
+}
+
+// Validate checks that the filter is valid.
+func (f HookHistoryFilter) Validate() error {
+	if f.Size < 0 {
+		return errors.NotValidf("negative size %d", f.Size)
+	}
+	return nil
+}
+
+type hookRunDoc struct {
+	ModelUUID string `bson:"model-uuid"`
+	Unit      string `bson:"unit"`
+	Hook      string `bson:"hook"`
+	Relation  string `bson:"relation,omitempty"`
+	Started   int64  `bson:"started"`
+	Finished  int64  `bson:"finished"`
+	ExitCode  int    `bson:"exit-code"`
+	Stderr    string `bson:"stderr,omitempty"`
+}
+
+// RecordHookRun adds a hook run to the unit's hook history.
+func (u *Unit) RecordHookRun(run HookRun) error {
+	stderr := run.Stderr
+	if n := len(stderr) - maxHookRunStderr; n > 0 {
+		// Don't keep part of a multi-byte character.
+		for n < len(stderr) && !utf8.RuneStart(stderr[n]) {
+			n++
+		}
+		stderr = stderr[n:]
+	}
+	doc := &hookRunDoc{
+		Unit:     u.Name(),
+		Hook:     run.Hook,
+		Relation: run.Relation,
+		Started:  run.Started.UnixNano(),
+		Finished: run.Finished.UnixNano(),
+		ExitCode: run.ExitCode,
+		Stderr:   stderr,
+	}
+	history, closer := u.st.db().GetCollection(hookHistoryC)
+	defer closer()
+
+	err := history.Writeable().Insert(doc)
+	return errors.Annotatef(err, "recording %s hook run for unit %q", run.Hook, u.Name())
+}
+
+// HookHistory returns the hooks run by the unit which match the
+// filter, most recent first.
+func (u *Unit) HookHistory(filter HookHistoryFilter) ([]HookRun, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating arguments")
+	}
+	history, closer := u.st.db().GetCollection(hookHistoryC)
+	defer closer()
+
+	query := bson.D{{"unit", u.Name()}}
+	if filter.Hook != "" {
+		query = append(query, bson.DocElem{"hook", filter.Hook})
+	}
+	if filter.Relation != "" {
+		query = append(query, bson.DocElem{"relation", filter.Relation})
+	}
+	if filter.Since != nil {
+		query = append(query, bson.DocElem{"started", bson.M{"$gt": filter.Since.UnixNano()}})
+	}
+	if filter.FailedOnly {
+		query = append(query, bson.DocElem{"exit-code", bson.M{"$ne": 0}})
+	}
+	q := history.Find(query).Sort("-started")
+	if filter.Size > 0 {
+		q = q.Limit(filter.Size)
+	}
+	var docs []hookRunDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook history for unit %q", u.Name())
+	}
+	runs := make([]HookRun, len(docs))
+	for i, doc := range docs {
+		runs[i] = HookRun{
+			Hook:     doc.Hook,
+			Relation: doc.Relation,
+			Started:  time.Unix(0, doc.Started).UTC(),
+			Finished: time.Unix(0, doc.Finished).UTC(),
+			ExitCode: doc.ExitCode,
+			Stderr:   doc.Stderr,
+		}
+	}
+	return runs, nil
+}
+
+// PruneHookHistory prunes the hook history collection.
+func PruneHookHistory(stop <-chan struct{}, st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	coll, closer := st.db().GetRawCollection(hookHistoryC)
+	defer closer()
+
+	err := pruneCollection(stop, st, maxHistoryTime, maxHistoryMB, coll, "started", nil, NanoSeconds)
+	return errors.Trace(err)
+}
+
+// eraseHookHistory removes all hook history documents for the named
+// unit. The documents are removed in batches, as status history is.
+func eraseHookHistory(stop <-chan struct{}, mb modelBackend, unitName string) error {
+	history, closer := mb.db().GetCollection(hookHistoryC)
+	defer closer()
+
+	iter := history.Find(bson.D{{"unit", unitName}}).Select(bson.M{"_id": 1}).Iter()
+	defer iter.Close()
+
+	logFormat := "deleted %d hook history documents for " + fmt.Sprintf("%q", unitName)
+	deleted, err := deleteInBatches(
+		stop,
+		history.Writeable().Underlying(), nil, "", iter,
+		logFormat, loggo.DEBUG,
+		noEarlyFinish,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if deleted > 0 {
+		logger.Debugf(logFormat, deleted)
+	}
+	return nil
+}
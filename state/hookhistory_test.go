@@ -0,0 +1,155 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type HookHistorySuite struct {
+	statetesting.StateSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.InitialTime = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.StateSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *HookHistorySuite) recordRuns(c *gc.C) []state.HookRun {
+	runs := []state.HookRun{{
+		Hook:     "install",
+		Started:  s.InitialTime.Add(-3 * time.Hour),
+		Finished: s.InitialTime.Add(-3*time.Hour + time.Minute),
+	}, {
+		Hook:     "db-relation-changed",
+		Relation: "db:2",
+		Started:  s.InitialTime.Add(-2 * time.Hour),
+		Finished: s.InitialTime.Add(-2*time.Hour + time.Second),
+		ExitCode: 1,
+		Stderr:   "cannot connect",
+	}, {
+		Hook:     "update-status",
+		Started:  s.InitialTime.Add(-time.Hour),
+		Finished: s.InitialTime.Add(-time.Hour + time.Second),
+	}}
+	for _, run := range runs {
+		err := s.unit.RecordHookRun(run)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return runs
+}
+
+func (s *HookHistorySuite) TestHookHistory(c *gc.C) {
+	runs := s.recordRuns(c)
+	history, err := s.unit.HookHistory(state.HookHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookRun{runs[2], runs[1], runs[0]})
+}
+
+func (s *HookHistorySuite) TestHookHistoryOtherUnit(c *gc.C) {
+	s.recordRuns(c)
+	other := s.Factory.MakeUnit(c, nil)
+	history, err := other.HookHistory(state.HookHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *HookHistorySuite) TestHookHistoryFilters(c *gc.C) {
+	runs := s.recordRuns(c)
+	since := s.InitialTime.Add(-150 * time.Minute)
+	for i, test := range []struct {
+		filter   state.HookHistoryFilter
+		expected []state.HookRun
+	}{{
+		filter:   state.HookHistoryFilter{Hook: "install"},
+		expected: []state.HookRun{runs[0]},
+	}, {
+		filter:   state.HookHistoryFilter{Relation: "db:2"},
+		expected: []state.HookRun{runs[1]},
+	}, {
+		filter:   state.HookHistoryFilter{Since: &since},
+		expected: []state.HookRun{runs[2], runs[1]},
+	}, {
+		filter:   state.HookHistoryFilter{FailedOnly: true},
+		expected: []state.HookRun{runs[1]},
+	}, {
+		filter:   state.HookHistoryFilter{Size: 1},
+		expected: []state.HookRun{runs[2]},
+	}} {
+		c.Logf("test %d: %+v", i, test.filter)
+		history, err := s.unit.HookHistory(test.filter)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(history, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *HookHistorySuite) TestHookHistoryInvalidFilter(c *gc.C) {
+	_, err := s.unit.HookHistory(state.HookHistoryFilter{Size: -1})
+	c.Assert(err, gc.ErrorMatches, "validating arguments: negative size -1 not valid")
+}
+
+func (s *HookHistorySuite) TestRecordHookRunTruncatesStderr(c *gc.C) {
+	err := s.unit.RecordHookRun(state.HookRun{
+		Hook:     "start",
+		Started:  s.InitialTime,
+		Finished: s.InitialTime,
+		ExitCode: 1,
+		Stderr:   strings.Repeat("a", 5000) + "the end",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	history, err := s.unit.HookHistory(state.HookHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Stderr, gc.HasLen, 4096)
+	c.Assert(strings.HasSuffix(history[0].Stderr, "the end"), jc.IsTrue)
+}
+
+func (s *HookHistorySuite) TestRecordHookRunTruncatesStderrOnRuneBoundary(c *gc.C) {
+	// Truncating to 4096 bytes would start inside the second "é".
+	err := s.unit.RecordHookRun(state.HookRun{
+		Hook:     "start",
+		Started:  s.InitialTime,
+		Finished: s.InitialTime,
+		ExitCode: 1,
+		Stderr:   strings.Repeat("é", 2049) + "!",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	history, err := s.unit.HookHistory(state.HookHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Stderr, gc.Equals, strings.Repeat("é", 2047)+"!")
+}
+
+func (s *HookHistorySuite) TestPruneHookHistoryByAge(c *gc.C) {
+	runs := s.recordRuns(c)
+
+	var stop <-chan struct{}
+	err := state.PruneHookHistory(stop, s.State, 90*time.Minute, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(state.HookHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookRun{runs[2]})
+}
+
+func (s *HookHistorySuite) TestHookHistoryErasedWithUnit(c *gc.C) {
+	s.recordRuns(c)
+	err := s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(state.HookHistoryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
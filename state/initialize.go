@@ -281,7 +281,7 @@ func Initialize(args InitializeParams) (_ *Controller, err error) {
 	}
 
 	for tag, cred := range args.CloudCredentials {
-		ops = append(ops, createCloudCredentialOp(tag, cred))
+		ops = append(ops, createCloudCredentialOp(tag, cred, credentialAttributesRef{}))
 	}
 	ops = append(ops, modelOps...)
 	ops = append(ops, storagePoolOps...)
@@ -391,6 +391,12 @@ func (st *State) modelSetupOps(controllerUUID string, args ModelArgs, inherited
 	}
 	// Some values require marshalling before storage.
 	modelCfg = config.CoerceForStorage(modelCfg)
+	// The secret backend's credentials are stored apart from the config.
+	credentialsOps, err := st.secretBackendCredentialsOps(modelCfg)
+	if err != nil {
+		return nil, modelStatusDoc, errors.Trace(err)
+	}
+	ops = append(ops, credentialsOps...)
 	ops = append(ops,
 		createSettingsOp(settingsC, modelGlobalKey, modelCfg),
 		createModelEntityRefsOp(modelUUID),
@@ -26,6 +26,7 @@ import (
 	"gopkg.in/tomb.v2"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/mongo"
 )
 
@@ -72,10 +73,27 @@ var logIndexes = [][]string{
 	{"n"},
 }
 
+// retainedLogIndexes defines the indexes we need on the retained log
+// collection, which is also pruned by severity.
+var retainedLogIndexes = [][]string{
+	{"t", "_id"},
+	{"v", "t"},
+}
+
 func logCollectionName(modelUUID string) string {
 	return logsCPrefix + modelUUID
 }
 
+// retainedLogCollectionName returns the name of the collection holding
+// copies of the model's log records at or above
+// config.MinRetainedLogLevel. Unlike the model's logs collection, it is
+// not capped, so records are only removed when they are pruned
+// according to the model's log-retention policy and maximum retained
+// log size.
+func retainedLogCollectionName(modelUUID string) string {
+	return logCollectionName(modelUUID) + ".retained"
+}
+
 // InitDbLogs sets up the capped collections for the logging, along with the
 // indexes for the logs collection. It should be called as state is opened. It
 // is idempotent.
@@ -160,6 +178,17 @@ func modelUUIDs(session *mgo.Session) ([]string, error) {
 // This function also ensures that the logs collection is capped at the right
 // size.
 func InitDbLogsForModel(session *mgo.Session, modelUUID string, size int) error {
+	// The retained logs collection is created when it is first
+	// written to, but it needs its indices whatever the size of
+	// the logs collection.
+	retainedColl := session.DB(logsDB).C(retainedLogCollectionName(modelUUID))
+	for _, key := range retainedLogIndexes {
+		err := retainedColl.EnsureIndex(mgo.Index{Key: key})
+		if err != nil {
+			return errors.Annotatef(err, "cannot create index for logs collection %v", retainedColl.Name)
+		}
+	}
+
 	// Get the collection from the logs DB.
 	logsColl := session.DB(logsDB).C(logCollectionName(modelUUID))
 
@@ -321,18 +350,21 @@ type logDoc struct {
 	Location string        `bson:"l"` // "filename:lineno"
 	Level    int           `bson:"v"`
 	Message  string        `bson:"x"`
+	Labels   []string      `bson:"c,omitempty"`
 }
 
 type DbLogger struct {
-	logsColl  *mgo.Collection
-	modelUUID string
+	logsColl     *mgo.Collection
+	retainedColl *mgo.Collection
+	modelUUID    string
 }
 
 func NewDbLogger(st ModelSessioner) *DbLogger {
 	_, logsColl := initLogsSession(st)
 	return &DbLogger{
-		logsColl:  logsColl,
-		modelUUID: st.ModelUUID(),
+		logsColl:     logsColl,
+		retainedColl: logsColl.Database.C(retainedLogCollectionName(st.ModelUUID())),
+		modelUUID:    st.ModelUUID(),
 	}
 }
 
@@ -344,6 +376,9 @@ func NewDbLogger(st ModelSessioner) *DbLogger {
 // The ModelUUID and ID fields of records are ignored;
 // DbLogger is scoped to a single model, and ID is
 // controlled by the DbLogger code.
+//
+// Records at or above config.MinRetainedLogLevel are
+// also written to the model's retained logs collection.
 func (logger *DbLogger) Log(records []LogRecord) error {
 	for _, r := range records {
 		if err := validateInputLogRecord(r); err != nil {
@@ -351,12 +386,14 @@ func (logger *DbLogger) Log(records []LogRecord) error {
 		}
 	}
 	bulk := logger.logsColl.Bulk()
+	retainedBulk := logger.retainedColl.Bulk()
+	retained := 0
 	for _, r := range records {
 		var versionString string
 		if r.Version != version.Zero {
 			versionString = r.Version.String()
 		}
-		bulk.Insert(&logDoc{
+		doc := &logDoc{
 			// TODO(axw) Use a controller-global int
 			// sequence for Id, so we can order by
 			// insertion.
@@ -368,10 +405,75 @@ func (logger *DbLogger) Log(records []LogRecord) error {
 			Location: r.Location,
 			Level:    int(r.Level),
 			Message:  r.Message,
+			Labels:   r.Labels,
+		}
+		bulk.Insert(doc)
+		if r.Level >= config.MinRetainedLogLevel {
+			retainedBulk.Insert(doc)
+			retained++
+		}
+	}
+	if _, err := bulk.Run(); err != nil {
+		return errors.Annotatef(err, "inserting %d log record(s)", len(records))
+	}
+	if retained == 0 {
+		return nil
+	}
+	_, err := retainedBulk.Run()
+	return errors.Annotatef(err, "inserting %d retained log record(s)", retained)
+}
+
+// PruneRetainedLogs removes the records from the model's retained logs
+// collection that are older than the retention policy allows. Records
+// at each severity are kept for the longest duration set in the policy
+// for that severity or any lower one, and are removed as soon as they
+// are pruned if there is none. If the collection is still larger than
+// maxSizeMB, the oldest records of the lowest severity are removed
+// until it fits; zero means the size isn't limited. It returns the
+// number of records removed.
+func PruneRetainedLogs(st ModelSessioner, now time.Time, retention map[loggo.Level]time.Duration, maxSizeMB int) (int, error) {
+	session, db := initLogsSessionDB(st)
+	defer session.Close()
+	retainedColl := db.C(retainedLogCollectionName(st.ModelUUID()))
+
+	removed := 0
+	var keep time.Duration
+	for level := config.MinRetainedLogLevel; level <= loggo.CRITICAL; level++ {
+		if d := retention[level]; d > keep {
+			keep = d
+		}
+		info, err := retainedColl.RemoveAll(bson.D{
+			{"v", int(level)},
+			{"t", bson.M{"$lt": now.Add(-keep).UnixNano()}},
 		})
+		if err != nil {
+			return removed, errors.Annotatef(err, "pruning retained %s log records", level)
+		}
+		removed += info.Removed
+	}
+	if maxSizeMB <= 0 {
+		return removed, nil
+	}
+
+	toDelete, err := (&collectionPruner{}).toDeleteCalculator(retainedColl, maxSizeMB, 1.0)
+	if err != nil {
+		return removed, errors.Annotate(err, "calculating retained log records to delete")
+	}
+	if toDelete <= 0 {
+		return removed, nil
 	}
-	_, err := bulk.Run()
-	return errors.Annotatef(err, "inserting %d log record(s)", len(records))
+	iter := retainedColl.Find(nil).Sort("v", "t").Limit(toDelete).Select(bson.M{"_id": 1}).Iter()
+	defer iter.Close()
+	template := fmt.Sprintf("%s size pruning: deleted %%d of %d (estimated)", retainedColl.Name, toDelete)
+	deleted, err := deleteInBatches(nil, retainedColl, nil, "", iter, template, loggo.INFO, func() (bool, error) {
+		collKB, err := getCollectionKB(retainedColl)
+		if err != nil {
+			return false, errors.Annotatef(err, "retrieving %s collection size", retainedColl.Name)
+		}
+		return collKB <= maxSizeMB*humanize.KiByte, nil
+	})
+	removed += deleted
+	return removed, errors.Annotate(err, "pruning retained log records by size")
 }
 
 func validateInputLogRecord(r LogRecord) error {
@@ -427,6 +529,7 @@ type LogRecord struct {
 	Module   string
 	Location string
 	Message  string
+	Labels   []string
 }
 
 // LogTailerParams specifies the filtering a LogTailer should apply to
@@ -442,6 +545,30 @@ type LogTailerParams struct {
 	IncludeModule []string
 	ExcludeModule []string
 	Oplog         *mgo.Collection // For testing only
+
+	// EndTime, if set, excludes records logged at or after it.
+	EndTime time.Time
+
+	// IncludeApplication and ExcludeApplication filter by the
+	// application that the unit or application agent logging
+	// the record belongs to.
+	IncludeApplication []string
+	ExcludeApplication []string
+
+	// IncludeLabel and ExcludeLabel filter by the labels attached
+	// to the log records.
+	IncludeLabel []string
+	ExcludeLabel []string
+
+	// MessageRegex, if set, only includes records whose message
+	// matches the regular expression.
+	MessageRegex string
+
+	// Retained, if set, reads the records from the model's retained
+	// logs collection, which holds records at or above
+	// config.MinRetainedLogLevel for as long as the model's
+	// log-retention policy keeps them.
+	Retained bool
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -478,10 +605,14 @@ type LogTailerState interface {
 // parameters given.
 func NewLogTailer(st LogTailerState, params LogTailerParams) (LogTailer, error) {
 	session := st.MongoSession().Copy()
+	collName := logCollectionName(st.ModelUUID())
+	if params.Retained {
+		collName = retainedLogCollectionName(st.ModelUUID())
+	}
 	t := &logTailer{
 		modelUUID:       st.ModelUUID(),
 		session:         session,
-		logsColl:        session.DB(logsDB).C(logCollectionName(st.ModelUUID())).With(session),
+		logsColl:        session.DB(logsDB).C(collName).With(session),
 		params:          params,
 		logCh:           make(chan *LogRecord),
 		recentIds:       newRecentIdTracker(maxRecentLogIds),
@@ -667,7 +798,7 @@ func (t *logTailer) tailOplog() error {
 	newParams := t.params
 	newParams.StartID = t.lastID // (t.lastID + 1) once Id is a sequential int.
 	oplogSel := append(t.paramsToSelector(newParams, "o."),
-		bson.DocElem{"ns", logsDB + "." + logCollectionName(t.modelUUID)},
+		bson.DocElem{"ns", logsDB + "." + t.logsColl.Name},
 	)
 
 	oplog := t.params.Oplog
@@ -738,8 +869,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeRange := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeRange["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeRange["$lt"] = params.EndTime.UnixNano()
+	}
+	if len(timeRange) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeRange})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -760,6 +898,23 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if len(params.IncludeApplication) > 0 {
+		sel = append(sel,
+			bson.DocElem{"n", bson.RegEx{Pattern: makeApplicationPattern(params.IncludeApplication)}})
+	}
+	if len(params.ExcludeApplication) > 0 {
+		sel = append(sel,
+			bson.DocElem{"n", bson.M{"$not": bson.RegEx{Pattern: makeApplicationPattern(params.ExcludeApplication)}}})
+	}
+	if len(params.IncludeLabel) > 0 {
+		sel = append(sel, bson.DocElem{"c", bson.M{"$in": params.IncludeLabel}})
+	}
+	if len(params.ExcludeLabel) > 0 {
+		sel = append(sel, bson.DocElem{"c", bson.M{"$nin": params.ExcludeLabel}})
+	}
+	if params.MessageRegex != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.MessageRegex}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -778,6 +933,16 @@ func makeEntityPattern(entities []string) string {
 	return `^(` + strings.Join(patterns, "|") + `)$`
 }
 
+// makeApplicationPattern matches the unit agents of the applications
+// on IAAS models, and the application agents on CAAS models.
+func makeApplicationPattern(applications []string) string {
+	var patterns []string
+	for _, application := range applications {
+		patterns = append(patterns, regexp.QuoteMeta(application))
+	}
+	return `^(unit-(` + strings.Join(patterns, "|") + `)-[0-9]+|application-(` + strings.Join(patterns, "|") + `))$`
+}
+
 func makeModulePattern(modules []string) string {
 	var patterns []string
 	for _, module := range modules {
@@ -861,6 +1026,7 @@ func logDocToRecord(modelUUID string, doc *logDoc) (*LogRecord, error) {
 		Module:   doc.Module,
 		Location: doc.Location,
 		Message:  doc.Message,
+		Labels:   doc.Labels,
 	}
 	return rec, nil
 }
@@ -996,6 +1162,10 @@ func removeModelLogs(session *mgo.Session, modelUUID string) error {
 	if err := logsColl.DropCollection(); err != nil {
 		return errors.Trace(err)
 	}
+	retainedColl := logsDB.C(retainedLogCollectionName(modelUUID))
+	if err := retainedColl.DropCollection(); err != nil && !isMgoNamespaceNotFound(err) {
+		return errors.Trace(err)
+	}
 
 	// Also remove the tracked high-water times.
 	trackersColl := logsDB.C(forwardedC)
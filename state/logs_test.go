@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"fmt"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -184,6 +185,202 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
 }
 
+func (s *LogsSuite) retainedLogCollFor(st *state.State) *mgo.Collection {
+	session := st.MongoSession()
+	return session.DB("logs").C("logs." + st.ModelUUID() + ".retained")
+}
+
+func (s *LogsSuite) TestRetainedIndexesCreated(c *gc.C) {
+	indexes, err := s.retainedLogCollFor(s.State).Indexes()
+	c.Assert(err, jc.ErrorIsNil)
+	var keys []string
+	for _, index := range indexes {
+		keys = append(keys, strings.Join(index.Key, "-"))
+	}
+	c.Assert(keys, jc.SameContents, []string{
+		"_id",   // default index
+		"t-_id", // timestamp and ID
+		"v-t",   // level and timestamp
+	})
+}
+
+func (s *LogsSuite) TestDbLoggerRetainsWarnings(c *gc.C) {
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+
+	t0 := coretesting.ZeroTime().Truncate(time.Millisecond)
+	err := logger.Log([]state.LogRecord{{
+		Time:    t0,
+		Entity:  "unit-chatty-0",
+		Module:  "some.where",
+		Level:   loggo.DEBUG,
+		Message: "la la la",
+	}, {
+		Time:    t0.Add(time.Second),
+		Entity:  "unit-chatty-0",
+		Module:  "some.where",
+		Level:   loggo.WARNING,
+		Message: "hmm",
+	}, {
+		Time:    t0.Add(2 * time.Second),
+		Entity:  "machine-0",
+		Module:  "else.where",
+		Level:   loggo.ERROR,
+		Message: "oh noes",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := s.logsColl.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 3)
+
+	var docs []bson.M
+	err = s.retainedLogCollFor(s.State).Find(nil).Sort("t").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 2)
+	c.Check(docs[0]["v"], gc.Equals, int(loggo.WARNING))
+	c.Check(docs[0]["x"], gc.Equals, "hmm")
+	c.Check(docs[1]["v"], gc.Equals, int(loggo.ERROR))
+	c.Check(docs[1]["x"], gc.Equals, "oh noes")
+}
+
+func (s *LogsSuite) TestPruneRetainedLogs(c *gc.C) {
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+
+	now := coretesting.NonZeroTime().Truncate(time.Millisecond)
+	record := func(level loggo.Level, age time.Duration) state.LogRecord {
+		return state.LogRecord{
+			Time:    now.Add(-age),
+			Entity:  "machine-0",
+			Module:  "some.where",
+			Level:   level,
+			Message: fmt.Sprintf("%s %v old", level, age),
+		}
+	}
+	err := logger.Log([]state.LogRecord{
+		record(loggo.WARNING, 2*time.Hour),
+		record(loggo.WARNING, 30*time.Minute),
+		record(loggo.ERROR, 5*time.Hour),
+		record(loggo.ERROR, 2*time.Hour),
+		record(loggo.CRITICAL, 5*time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Critical records are kept for as long as errors are.
+	removed, err := state.PruneRetainedLogs(s.State, now, map[loggo.Level]time.Duration{
+		loggo.WARNING: time.Hour,
+		loggo.ERROR:   3 * time.Hour,
+	}, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(removed, gc.Equals, 3)
+
+	var docs []bson.M
+	err = s.retainedLogCollFor(s.State).Find(nil).Sort("t").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	var messages []string
+	for _, doc := range docs {
+		messages = append(messages, doc["x"].(string))
+	}
+	c.Check(messages, jc.DeepEquals, []string{"ERROR 2h0m0s old", "WARNING 30m0s old"})
+
+	// Records at severities without a policy aren't kept.
+	removed, err = state.PruneRetainedLogs(s.State, now, nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(removed, gc.Equals, 2)
+}
+
+func (s *LogsSuite) TestPruneRetainedLogsBySize(c *gc.C) {
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+
+	now := coretesting.NonZeroTime().Truncate(time.Millisecond)
+	message := strings.Repeat("x", 1024)
+	var records []state.LogRecord
+	for i := 0; i < 5000; i++ {
+		level := loggo.WARNING
+		if i%50 == 0 {
+			level = loggo.ERROR
+		}
+		records = append(records, state.LogRecord{
+			Time:    now.Add(time.Duration(i-5000) * time.Second),
+			Entity:  "machine-0",
+			Module:  "some.where",
+			Level:   level,
+			Message: message,
+		})
+	}
+	err := logger.Log(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// None of the records are old enough to be pruned by age, but the
+	// collection is pruned to about 1MB, losing warnings before errors.
+	retention := map[loggo.Level]time.Duration{loggo.WARNING: 24 * time.Hour}
+	removed, err := state.PruneRetainedLogs(s.State, now, retention, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(removed, jc.GreaterThan, 3000)
+
+	coll := s.retainedLogCollFor(s.State)
+	count, err := coll.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 5000-removed)
+	numErrors, err := coll.Find(bson.D{{"v", int(loggo.ERROR)}}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(numErrors, gc.Equals, 100)
+
+	// The oldest warnings go first.
+	for age, kept := range map[time.Duration]bool{
+		4999 * time.Second: false,
+		time.Second:        true,
+	} {
+		n, err := coll.Find(bson.D{{"t", now.Add(-age).UnixNano()}}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(n == 1, gc.Equals, kept, gc.Commentf("record %v old", age))
+	}
+}
+
+func (s *LogsSuite) TestLogTailerRetained(c *gc.C) {
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+
+	t0 := coretesting.ZeroTime().Truncate(time.Millisecond)
+	err := logger.Log([]state.LogRecord{{
+		Time:    t0,
+		Entity:  "unit-chatty-0",
+		Module:  "some.where",
+		Level:   loggo.DEBUG,
+		Message: "la la la",
+	}, {
+		Time:    t0.Add(time.Second),
+		Entity:  "machine-0",
+		Module:  "else.where",
+		Level:   loggo.ERROR,
+		Message: "oh noes",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	tailer, err := state.NewLogTailer(s.State, state.LogTailerParams{
+		Retained: true,
+		NoTail:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	var messages []string
+	for {
+		select {
+		case rec, ok := <-tailer.Logs():
+			if !ok {
+				c.Check(messages, jc.DeepEquals, []string{"oh noes"})
+				return
+			}
+			messages = append(messages, rec.Message)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for log records")
+		}
+	}
+}
+
 type LogTailerSuite struct {
 	ConnWithWallClockSuite
 	oplogColl            *mgo.Collection
@@ -627,6 +824,110 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestEndTimeFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5, want)
+	s.writeLogsT(c, s.otherUUID, threshT, threshT.Add(5*time.Second), 5, logTemplate{Message: "dont want"})
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		StartTime: threshT.Add(-3 * time.Second),
+		EndTime:   threshT,
+		NoTail:    true,
+		Oplog:     s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 3, want)
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
+func (s *LogTailerSuite) TestIncludeApplication(c *gc.C) {
+	machine0 := logTemplate{Entity: "machine-0"}
+	foo0 := logTemplate{Entity: "unit-foo-0"}
+	fooBar0 := logTemplate{Entity: "unit-foo-bar-0"}
+	bar := logTemplate{Entity: "application-bar"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, machine0)
+		s.writeLogs(c, s.otherUUID, 2, foo0)
+		s.writeLogs(c, s.otherUUID, 1, fooBar0)
+		s.writeLogs(c, s.otherUUID, 1, bar)
+	}
+	params := state.LogTailerParams{
+		IncludeApplication: []string{"foo", "bar"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 2, foo0)
+		s.assertTailer(c, tailer, 1, bar)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestExcludeApplication(c *gc.C) {
+	machine0 := logTemplate{Entity: "machine-0"}
+	foo0 := logTemplate{Entity: "unit-foo-0"}
+	fooBar0 := logTemplate{Entity: "unit-foo-bar-0"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, machine0)
+		s.writeLogs(c, s.otherUUID, 2, foo0)
+		s.writeLogs(c, s.otherUUID, 1, fooBar0)
+	}
+	params := state.LogTailerParams{
+		ExcludeApplication: []string{"foo"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, machine0)
+		s.assertTailer(c, tailer, 1, fooBar0)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeExcludeLabel(c *gc.C) {
+	unlabelled := logTemplate{Message: "unlabelled"}
+	http := logTemplate{Message: "http", Labels: []string{"http"}}
+	httpDebug := logTemplate{Message: "http debug", Labels: []string{"http", "noisy"}}
+	charmhub := logTemplate{Message: "charmhub", Labels: []string{"charmhub"}}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, unlabelled)
+		s.writeLogs(c, s.otherUUID, 2, http)
+		s.writeLogs(c, s.otherUUID, 1, httpDebug)
+		s.writeLogs(c, s.otherUUID, 1, charmhub)
+	}
+	params := state.LogTailerParams{
+		IncludeLabel: []string{"http", "charmhub"},
+		ExcludeLabel: []string{"noisy"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 2, http)
+		s.assertTailer(c, tailer, 1, charmhub)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestMessageRegex(c *gc.C) {
+	hookFailed := logTemplate{Message: `hook "install" failed: exit status 1`}
+	hookRan := logTemplate{Message: `ran "install" hook`}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 2, hookRan)
+		s.writeLogs(c, s.otherUUID, 1, hookFailed)
+		s.writeLogs(c, s.otherUUID, 2, hookRan)
+	}
+	params := state.LogTailerParams{
+		MessageRegex: `hook ".*" failed`,
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, hookFailed)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -656,6 +957,7 @@ type logTemplate struct {
 	Location string
 	Level    loggo.Level
 	Message  string
+	Labels   []string
 }
 
 // emptyTag gives us an explicit way to specify an empty tag for the
@@ -744,6 +1046,7 @@ func (s *LogTailerSuite) logTemplateToDoc(lt logTemplate, t time.Time) interface
 		lt.Location,
 		lt.Level,
 		lt.Message,
+		lt.Labels...,
 	)
 }
 
@@ -764,6 +1067,7 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 			c.Assert(log.Location, gc.Equals, lt.Location)
 			c.Assert(log.Level, gc.Equals, lt.Level)
 			c.Assert(log.Message, gc.Equals, lt.Message)
+			c.Assert(log.Labels, jc.DeepEquals, lt.Labels)
 			count++
 			if count == expectedCount {
 				return
@@ -176,6 +176,20 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
 
+		// Secret values are encrypted with a controller specific key,
+		// so secrets aren't migrated yet. There is a precheck to ensure
+		// that a model being migrated has no secrets.
+		secretMetadataC,
+		secretRevisionsC,
+		secretPermissionsC,
+		secretConsumersC,
+		secretContentC,
+		secretsKeyC,
+
+		// Hook history records what unit agents did on the source
+		// controller, and isn't migrated.
+		hookHistoryC,
+
 		// Global settings store controller specific configuration settings
 		// and are not to be migrated.
 		globalSettingsC,
@@ -424,6 +438,8 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Application",
 		// Resolved is not migrated as we check that all is good before we start.
 		"Resolved",
+		// A hook cancel request only applies to the running hook.
+		"HookCancelRequested",
 		// Series and CharmURL also come from the application.
 		"Series",
 		"CharmURL",
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/controller"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
+	secretbackend "github.com/juju/juju/secrets/backend"
 )
 
 type attrValues map[string]interface{}
@@ -166,6 +167,9 @@ func (model *Model) modelConfigValues(modelCfg attrValues) (config.ConfigValues,
 
 // UpdateModelConfigDefaultValues updates the inherited settings used when creating a new model.
 func (st *State) UpdateModelConfigDefaultValues(updateAttrs map[string]interface{}, removeAttrs []string, regionSpec *environscloudspec.CloudRegionSpec) error {
+	if err := checkNoSecretBackendCredentials(updateAttrs); err != nil {
+		return errors.Trace(err)
+	}
 	var key string
 
 	if regionSpec != nil {
@@ -306,6 +310,23 @@ func checkControllerInheritedConfig(attrs attrValues) error {
 	return nil
 }
 
+// checkNoSecretBackendCredentials returns an error if the attributes set
+// any secret backend credentials. These are only stored, encrypted, for
+// a model, so can't be inherited.
+func checkNoSecretBackendCredentials(attrs map[string]interface{}) error {
+	value, _ := attrs[config.SecretBackendConfigKey].(string)
+	backendAttrs, err := config.ParseSecretBackendConfig(value)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for key := range backendAttrs {
+		if secretbackend.IsCredentialKey(key) {
+			return errors.NotValidf("secret backend credential %q in model defaults", key)
+		}
+	}
+	return nil
+}
+
 func (st *State) buildAndValidateModelConfig(updateAttrs attrValues, removeAttrs []string, oldConfig *config.Config) (*config.Config, error) {
 	newConfig, err := oldConfig.Apply(updateAttrs)
 	if err != nil {
@@ -395,9 +416,18 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 	// Some values require marshalling before storage.
 	validAttrs = config.CoerceForStorage(validAttrs)
 
+	// The secret backend's credentials are stored apart from the config.
+	credentialsOps, err := st.secretBackendCredentialsOps(validAttrs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.checkSecretBackendChange(oldConfig, validAttrs); err != nil {
+		return errors.Trace(err)
+	}
+
 	modelSettings.Update(validAttrs)
 	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	return modelSettings.write(append(ops, credentialsOps...))
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
@@ -0,0 +1,359 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v2"
+	"github.com/juju/mgo/v2/bson"
+	"github.com/juju/mgo/v2/txn"
+	jujutxn "github.com/juju/txn"
+
+	"github.com/juju/juju/environs/config"
+	secretbackend "github.com/juju/juju/secrets/backend"
+)
+
+// secretContentDoc holds content stored by the internal secret backend,
+// encrypted with the controller's secrets key.
+type secretContentDoc struct {
+	DocID string `bson:"_id"`
+	Value []byte `bson:"value"`
+}
+
+// mongoSecretsBackend is the internal secret backend, which stores
+// content in the model's secretContent collection.
+type mongoSecretsBackend struct {
+	store *secretsStore
+}
+
+// Type implements secretbackend.Backend.
+func (b *mongoSecretsBackend) Type() string {
+	return secretbackend.Internal
+}
+
+// SaveContent implements secretbackend.Backend.
+func (b *mongoSecretsBackend) SaveContent(path string, content map[string]string) error {
+	value, err := b.store.encrypt(content)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	st := b.store.st
+	coll, closer := st.db().GetCollection(secretContentC)
+	defer closer()
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		n, err := coll.FindId(path).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return []txn.Op{{
+				C:      secretContentC,
+				Id:     path,
+				Assert: txn.DocMissing,
+				Insert: &secretContentDoc{DocID: path, Value: value},
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      secretContentC,
+			Id:     path,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"value", value}}}},
+		}}, nil
+	}
+	return errors.Annotatef(st.db().Run(buildTxn), "saving content at %q", path)
+}
+
+// GetContent implements secretbackend.Backend.
+func (b *mongoSecretsBackend) GetContent(path string) (map[string]string, error) {
+	coll, closer := b.store.st.db().GetCollection(secretContentC)
+	defer closer()
+
+	var doc secretContentDoc
+	err := coll.FindId(path).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("content at %q", path)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	content, err := b.store.decrypt(doc.Value)
+	return content, errors.Annotatef(err, "decrypting content at %q", path)
+}
+
+// DeleteContent implements secretbackend.Backend.
+func (b *mongoSecretsBackend) DeleteContent(path string) error {
+	st := b.store.st
+	coll, closer := st.db().GetCollection(secretContentC)
+	defer closer()
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		n, err := coll.FindId(path).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      secretContentC,
+			Id:     path,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	return errors.Annotatef(st.db().Run(buildTxn), "deleting content at %q", path)
+}
+
+// secretsBackend returns the secret backend configured for the model.
+func (st *State) secretsBackend() (secretbackend.Backend, error) {
+	cfg, err := getModelConfig(st.db(), st.ModelUUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.secretsBackendForConfig(cfg)
+}
+
+// secretsBackendOfType returns the secret backend of the given type,
+// which content was saved to. Content held by an external backend is
+// read using the model's current backend config, which can't change
+// while it holds any.
+func (st *State) secretsBackendOfType(backendType string) (secretbackend.Backend, error) {
+	if backendType == secretbackend.Internal {
+		return &mongoSecretsBackend{store: NewSecrets(st)}, nil
+	}
+	backend, err := st.secretsBackend()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if backend.Type() != backendType {
+		return nil, errors.NotFoundf("%s secret backend", backendType)
+	}
+	return backend, nil
+}
+
+func (st *State) secretsBackendForConfig(cfg *config.Config) (secretbackend.Backend, error) {
+	backendType := cfg.SecretBackend()
+	if backendType == secretbackend.Internal {
+		return &mongoSecretsBackend{store: NewSecrets(st)}, nil
+	}
+	attrs, err := st.secretBackendConfig(st.db(), cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend, err := secretbackend.NewExternal(backendType, attrs)
+	return backend, errors.Annotate(err, "creating secret backend")
+}
+
+// secretBackendCredentialsKey identifies the document in a model's
+// secretContent collection holding the credentials of its secret
+// backend, which are kept out of the model config.
+const secretBackendCredentialsKey = "secret-backend-credentials"
+
+// secretBackendCredentials returns the stored credentials of the secret
+// backend of the model whose database is given.
+func (st *State) secretBackendCredentials(db Database) (map[string]string, error) {
+	coll, closer := db.GetCollection(secretContentC)
+	defer closer()
+
+	var doc secretContentDoc
+	err := coll.FindId(secretBackendCredentialsKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	creds, err := NewSecrets(st).decrypt(doc.Value)
+	return creds, errors.Annotate(err, "decrypting secret backend credentials")
+}
+
+// secretBackendConfig returns the configuration of the secret backend
+// of the model whose database and config are given, including its
+// stored credentials.
+func (st *State) secretBackendConfig(db Database, cfg *config.Config) (map[string]string, error) {
+	creds, err := st.secretBackendCredentials(db)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs := cfg.SecretBackendConfig()
+	for _, key := range secretbackend.CredentialKeys(cfg.SecretBackend()) {
+		if value, ok := creds[key]; ok {
+			attrs[key] = value
+		}
+	}
+	return attrs, nil
+}
+
+// secretBackendCredentialsOps removes the credentials from the secret
+// backend config in the model config attributes about to be stored,
+// returning the operations to store them encrypted apart from the rest
+// of the config. Credentials already stored for the backend are kept if
+// the attributes don't hold any, so that the rest of the backend config
+// can be changed without repeating them.
+func (st *State) secretBackendCredentialsOps(attrs map[string]interface{}) ([]txn.Op, error) {
+	backendType, _ := attrs[config.SecretBackendKey].(string)
+	if backendType == "" {
+		backendType = config.DefaultSecretBackend
+	}
+	value, _ := attrs[config.SecretBackendConfigKey].(string)
+	backendAttrs, err := config.ParseSecretBackendConfig(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	creds := make(map[string]string)
+	for _, key := range secretbackend.CredentialKeys(backendType) {
+		if value, ok := backendAttrs[key]; ok {
+			creds[key] = value
+			delete(backendAttrs, key)
+		}
+	}
+	if len(creds) > 0 {
+		attrs[config.SecretBackendConfigKey] = config.FormatSecretBackendConfig(backendAttrs)
+	}
+
+	stored, err := st.secretBackendCredentials(st.db())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(creds) == 0 {
+		for _, key := range secretbackend.CredentialKeys(backendType) {
+			if value, ok := stored[key]; ok {
+				creds[key] = value
+			}
+		}
+	}
+	fullAttrs := make(map[string]string)
+	for key, value := range backendAttrs {
+		fullAttrs[key] = value
+	}
+	for key, value := range creds {
+		fullAttrs[key] = value
+	}
+	if err := secretbackend.ValidateConfig(backendType, fullAttrs); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	switch {
+	case len(creds) == 0 && stored == nil:
+		return nil, nil
+	case len(creds) == 0:
+		return []txn.Op{{
+			C:      secretContentC,
+			Id:     secretBackendCredentialsKey,
+			Remove: true,
+		}}, nil
+	case reflect.DeepEqual(creds, stored):
+		return nil, nil
+	}
+	encrypted, err := NewSecrets(st).encrypt(creds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if stored == nil {
+		return []txn.Op{{
+			C:      secretContentC,
+			Id:     secretBackendCredentialsKey,
+			Assert: txn.DocMissing,
+			Insert: &secretContentDoc{DocID: secretBackendCredentialsKey, Value: encrypted},
+		}}, nil
+	}
+	return []txn.Op{{
+		C:      secretContentC,
+		Id:     secretBackendCredentialsKey,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"value", encrypted}}}},
+	}}, nil
+}
+
+// checkSecretBackendChange returns an error if the attributes about to
+// be stored would change the model's external secret backend, or where
+// it keeps content, while secret revisions or cloud credentials saved in
+// it are still referenced, as they would no longer be readable. Only
+// the credentials of the backend may be changed then, which have been
+// removed from the attributes.
+func (st *State) checkSecretBackendChange(oldConfig *config.Config, attrs map[string]interface{}) error {
+	oldType := oldConfig.SecretBackend()
+	if oldType == secretbackend.Internal {
+		return nil
+	}
+	newType, _ := attrs[config.SecretBackendKey].(string)
+	if newType == "" {
+		newType = config.DefaultSecretBackend
+	}
+	value, _ := attrs[config.SecretBackendConfigKey].(string)
+	newBackendAttrs, err := config.ParseSecretBackendConfig(value)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	oldBackendAttrs := oldConfig.SecretBackendConfig()
+	if newType == oldType && (len(newBackendAttrs) == 0 && len(oldBackendAttrs) == 0 ||
+		reflect.DeepEqual(newBackendAttrs, oldBackendAttrs)) {
+		return nil
+	}
+
+	revisions, closer := st.db().GetCollection(secretRevisionsC)
+	defer closer()
+	numRevisions, err := revisions.Find(bson.D{{"backend", oldType}}).Count()
+	if err != nil {
+		return errors.Annotate(err, "counting secret revisions")
+	}
+	var numCredentials int
+	if st.IsController() {
+		// The controller model's backend holds cloud credentials.
+		credentials, closer := st.db().GetCollection(cloudCredentialsC)
+		defer closer()
+		numCredentials, err = credentials.Find(bson.D{{"attributes-backend", oldType}}).Count()
+		if err != nil {
+			return errors.Annotate(err, "counting cloud credentials")
+		}
+	}
+	if numRevisions+numCredentials == 0 {
+		return nil
+	}
+	return errors.Errorf(
+		"cannot change %s secret backend while it holds %d secret revision(s) and %d cloud credential(s), only its credentials can be changed",
+		oldType, numRevisions, numCredentials,
+	)
+}
+
+// credentialsBackend returns the external secret backend of the given
+// type configured for the controller model, which holds the attributes
+// of cloud credentials when one is used. An empty type means whichever
+// backend is configured. It returns nil if the attributes are kept in
+// the controller database.
+func (st *State) credentialsBackend(backendType string) (secretbackend.Backend, error) {
+	uuid := st.ControllerModelUUID()
+	db, closer := st.db().CopyForModel(uuid)
+	defer closer()
+	cfg, err := getModelConfig(db, uuid)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if backendType == "" {
+		backendType = cfg.SecretBackend()
+	}
+	if backendType == secretbackend.Internal {
+		return nil, nil
+	}
+	if cfg.SecretBackend() != backendType {
+		return nil, errors.NotFoundf("%s secret backend", backendType)
+	}
+	attrs, err := st.secretBackendConfig(db, cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend, err := secretbackend.NewExternal(backendType, attrs)
+	return backend, errors.Annotate(err, "creating secret backend")
+}
+
+// deleteSecretContent removes content which is no longer referenced,
+// logging rather than returning any error.
+func deleteSecretContent(backend secretbackend.Backend, path string) {
+	if err := backend.DeleteContent(path); err != nil {
+		logger.Warningf("cannot delete secret content at %q: %v", path, err)
+	}
+}
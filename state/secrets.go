@@ -0,0 +1,972 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v2"
+	"github.com/juju/mgo/v2/bson"
+	"github.com/juju/mgo/v2/txn"
+	"github.com/juju/names/v4"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/v2"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/core/secrets"
+	secretbackend "github.com/juju/juju/secrets/backend"
+	"github.com/juju/juju/state/watcher"
+)
+
+// CreateSecretParams are used to create a secret.
+type CreateSecretParams struct {
+	// Owner is the application or unit which owns the secret.
+	Owner        names.Tag
+	Description  string
+	Label        string
+	RotatePolicy secrets.RotatePolicy
+	Data         map[string]string
+}
+
+// UpdateSecretParams are used to update a secret. Only the non nil
+// attributes are changed, and a new revision is added if Data is set.
+type UpdateSecretParams struct {
+	Description  *string
+	Label        *string
+	RotatePolicy *secrets.RotatePolicy
+	Data         map[string]string
+}
+
+// SecretsFilter is used when listing secrets.
+type SecretsFilter struct {
+	// OwnerTag, if set, limits the secrets to those owned by the entity.
+	OwnerTag *string
+}
+
+// SecretAccessParams are used to grant or revoke access to a secret.
+type SecretAccessParams struct {
+	// Subject is the application or unit getting access.
+	Subject names.Tag
+	Role    secrets.SecretRole
+}
+
+// SecretConsumerMetadata holds what a consumer of a secret knows about
+// it.
+type SecretConsumerMetadata struct {
+	// Label is the label the consumer uses to refer to the secret.
+	Label string
+	// CurrentRevision is the revision of the value the consumer reads.
+	CurrentRevision int
+}
+
+// SecretsStore instances provide access to the secrets in a model.
+type SecretsStore interface {
+	CreateSecret(*secrets.URI, CreateSecretParams) (*secrets.SecretMetadata, error)
+	UpdateSecret(*secrets.URI, UpdateSecretParams) (*secrets.SecretMetadata, error)
+	GetSecret(*secrets.URI) (*secrets.SecretMetadata, error)
+	GetSecretValue(*secrets.URI, int) (secrets.SecretValue, error)
+	ListSecrets(SecretsFilter) ([]*secrets.SecretMetadata, error)
+	SecretRotated(*secrets.URI, time.Time) error
+
+	GrantSecretAccess(*secrets.URI, SecretAccessParams) error
+	RevokeSecretAccess(*secrets.URI, SecretAccessParams) error
+	SecretAccess(*secrets.URI, names.Tag) (secrets.SecretRole, error)
+
+	GetSecretConsumer(*secrets.URI, names.Tag) (*SecretConsumerMetadata, error)
+	SaveSecretConsumer(*secrets.URI, names.Tag, *SecretConsumerMetadata) error
+	GetURIByConsumerLabel(string, names.Tag) (*secrets.URI, error)
+}
+
+type secretMetadataDoc struct {
+	DocID string `bson:"_id"`
+
+	OwnerTag       string     `bson:"owner-tag"`
+	Description    string     `bson:"description"`
+	Label          string     `bson:"label"`
+	RotatePolicy   string     `bson:"rotate-policy"`
+	NextRotateTime *time.Time `bson:"next-rotate-time,omitempty"`
+	LatestRevision int        `bson:"latest-revision"`
+	CreateTime     time.Time  `bson:"create-time"`
+	UpdateTime     time.Time  `bson:"update-time"`
+}
+
+// secretRevisionDoc records a secret revision, whose value is held by
+// the secret backend of the given type at ValueRef.
+type secretRevisionDoc struct {
+	DocID string `bson:"_id"`
+
+	SecretID   string    `bson:"secret-id"`
+	Revision   int       `bson:"revision"`
+	Backend    string    `bson:"backend"`
+	ValueRef   string    `bson:"value-ref"`
+	CreateTime time.Time `bson:"create-time"`
+}
+
+type secretPermissionDoc struct {
+	DocID string `bson:"_id"`
+
+	SecretID   string `bson:"secret-id"`
+	SubjectTag string `bson:"subject-tag"`
+	Role       string `bson:"role"`
+}
+
+type secretConsumerDoc struct {
+	DocID string `bson:"_id"`
+
+	SecretID        string `bson:"secret-id"`
+	ConsumerTag     string `bson:"consumer-tag"`
+	Label           string `bson:"label"`
+	CurrentRevision int    `bson:"current-revision"`
+}
+
+func secretRevisionKey(uri *secrets.URI, revision int) string {
+	return fmt.Sprintf("%s/%d", uri.ID, revision)
+}
+
+// newSecretContentPath returns a new path at which to save a value of
+// the secret in the secret backend. Each value gets its own path, so
+// that a value saved by a failed write can be deleted without touching
+// any value saved by a concurrent one.
+func newSecretContentPath(modelUUID string, uri *secrets.URI) (string, error) {
+	id, err := utils.NewUUID()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("juju/%s/secrets/%s/%s", modelUUID, uri.ID, id), nil
+}
+
+func secretSubjectKey(uri *secrets.URI, tag names.Tag) string {
+	return fmt.Sprintf("%s#%s", uri.ID, tag.String())
+}
+
+type secretsStore struct {
+	st *State
+}
+
+// NewSecrets creates a new SecretsStore backed by the state.
+func NewSecrets(st *State) *secretsStore {
+	return &secretsStore{st: st}
+}
+
+func (s *secretsStore) toSecretMetadata(doc *secretMetadataDoc) *secrets.SecretMetadata {
+	md := &secrets.SecretMetadata{
+		URI:            &secrets.URI{ID: s.st.localID(doc.DocID)},
+		OwnerTag:       doc.OwnerTag,
+		Description:    doc.Description,
+		Label:          doc.Label,
+		RotatePolicy:   secrets.RotatePolicy(doc.RotatePolicy),
+		LatestRevision: doc.LatestRevision,
+		CreateTime:     doc.CreateTime,
+		UpdateTime:     doc.UpdateTime,
+	}
+	if doc.NextRotateTime != nil {
+		next := *doc.NextRotateTime
+		md.NextRotateTime = &next
+	}
+	return md
+}
+
+func validateSecretData(data map[string]string) error {
+	for key := range data {
+		if key == "" || strings.ContainsAny(key, " \t\n=#") {
+			return errors.NotValidf("secret key %q", key)
+		}
+	}
+	return nil
+}
+
+// CreateSecret creates a new secret with its first revision holding the
+// given data.
+func (s *secretsStore) CreateSecret(uri *secrets.URI, p CreateSecretParams) (*secrets.SecretMetadata, error) {
+	if p.Owner == nil {
+		return nil, errors.NotValidf("secret without owner")
+	}
+	if len(p.Data) == 0 {
+		return nil, errors.NotValidf("secret without data")
+	}
+	if err := validateSecretData(p.Data); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if p.RotatePolicy == "" {
+		p.RotatePolicy = secrets.RotateNever
+	}
+	if !p.RotatePolicy.IsValid() {
+		return nil, errors.NotValidf("secret rotate policy %q", p.RotatePolicy)
+	}
+	if _, err := s.GetSecret(uri); err == nil {
+		return nil, errors.AlreadyExistsf("secret %s", uri)
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	backend, err := s.st.secretsBackend()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	valueRef, err := newSecretContentPath(s.st.ModelUUID(), uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := backend.SaveContent(valueRef, p.Data); err != nil {
+		return nil, errors.Annotatef(err, "cannot save value of secret %s", uri)
+	}
+	now := s.st.nowToTheSecond()
+	doc := &secretMetadataDoc{
+		DocID:          uri.ID,
+		OwnerTag:       p.Owner.String(),
+		Description:    p.Description,
+		Label:          p.Label,
+		RotatePolicy:   string(p.RotatePolicy),
+		NextRotateTime: p.RotatePolicy.NextRotateTime(now),
+		LatestRevision: 1,
+		CreateTime:     now,
+		UpdateTime:     now,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := s.GetSecret(uri); err == nil {
+				return nil, errors.AlreadyExistsf("secret %s", uri)
+			}
+		}
+		if err := s.checkLabelUnique(doc.OwnerTag, uri, p.Label); err != nil {
+			return nil, errors.Trace(err)
+		}
+		model, err := s.st.Model()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{
+			model.assertActiveOp(),
+			{
+				C:      secretMetadataC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}, {
+				C:      secretRevisionsC,
+				Id:     secretRevisionKey(uri, 1),
+				Assert: txn.DocMissing,
+				Insert: &secretRevisionDoc{
+					DocID:      secretRevisionKey(uri, 1),
+					SecretID:   uri.ID,
+					Revision:   1,
+					Backend:    backend.Type(),
+					ValueRef:   valueRef,
+					CreateTime: now,
+				},
+			},
+		}, nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		deleteSecretContent(backend, valueRef)
+		return nil, errors.Annotatef(err, "cannot create secret %s", uri)
+	}
+	return s.GetSecret(uri)
+}
+
+// checkLabelUnique returns an error if another secret of the owner
+// already uses the label.
+func (s *secretsStore) checkLabelUnique(ownerTag string, uri *secrets.URI, label string) error {
+	if label == "" {
+		return nil
+	}
+	coll, closer := s.st.db().GetCollection(secretMetadataC)
+	defer closer()
+	var doc secretMetadataDoc
+	err := coll.Find(bson.D{{"owner-tag", ownerTag}, {"label", label}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.st.localID(doc.DocID) != uri.ID {
+		return errors.AlreadyExistsf("secret with label %q", label)
+	}
+	return nil
+}
+
+// UpdateSecret updates the attributes of a secret, adding a new revision
+// if new data is given.
+func (s *secretsStore) UpdateSecret(uri *secrets.URI, p UpdateSecretParams) (*secrets.SecretMetadata, error) {
+	if p.Description == nil && p.Label == nil && p.RotatePolicy == nil && len(p.Data) == 0 {
+		return nil, errors.New("must specify a new value or metadata to update a secret")
+	}
+	if err := validateSecretData(p.Data); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if p.RotatePolicy != nil && !p.RotatePolicy.IsValid() {
+		return nil, errors.NotValidf("secret rotate policy %q", *p.RotatePolicy)
+	}
+	var (
+		backend  secretbackend.Backend
+		valueRef string
+	)
+	if len(p.Data) > 0 {
+		var err error
+		if backend, err = s.st.secretsBackend(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if valueRef, err = newSecretContentPath(s.st.ModelUUID(), uri); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := backend.SaveContent(valueRef, p.Data); err != nil {
+			return nil, errors.Annotatef(err, "cannot save value of secret %s", uri)
+		}
+	}
+	coll, closer := s.st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		var doc secretMetadataDoc
+		if err := coll.FindId(uri.ID).One(&doc); err == mgo.ErrNotFound {
+			return nil, errors.NotFoundf("secret %s", uri)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		now := s.st.nowToTheSecond()
+		updates := bson.D{{"update-time", now}}
+		if p.Description != nil {
+			updates = append(updates, bson.DocElem{"description", *p.Description})
+		}
+		if p.Label != nil {
+			if err := s.checkLabelUnique(doc.OwnerTag, uri, *p.Label); err != nil {
+				return nil, errors.Trace(err)
+			}
+			updates = append(updates, bson.DocElem{"label", *p.Label})
+		}
+		if p.RotatePolicy != nil && string(*p.RotatePolicy) != doc.RotatePolicy {
+			updates = append(updates,
+				bson.DocElem{"rotate-policy", string(*p.RotatePolicy)},
+				bson.DocElem{"next-rotate-time", p.RotatePolicy.NextRotateTime(now)},
+			)
+		}
+		ops := []txn.Op{{
+			C:      secretMetadataC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"latest-revision", doc.LatestRevision}},
+		}}
+		if backend != nil {
+			revision := doc.LatestRevision + 1
+			updates = append(updates, bson.DocElem{"latest-revision", revision})
+			ops = append(ops, txn.Op{
+				C:      secretRevisionsC,
+				Id:     secretRevisionKey(uri, revision),
+				Assert: txn.DocMissing,
+				Insert: &secretRevisionDoc{
+					DocID:      secretRevisionKey(uri, revision),
+					SecretID:   uri.ID,
+					Revision:   revision,
+					Backend:    backend.Type(),
+					ValueRef:   valueRef,
+					CreateTime: now,
+				},
+			})
+		}
+		ops[0].Update = bson.D{{"$set", updates}}
+		return ops, nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		if backend != nil {
+			deleteSecretContent(backend, valueRef)
+		}
+		return nil, errors.Annotatef(err, "cannot update secret %s", uri)
+	}
+	return s.GetSecret(uri)
+}
+
+// GetSecret returns the metadata of a secret.
+func (s *secretsStore) GetSecret(uri *secrets.URI) (*secrets.SecretMetadata, error) {
+	coll, closer := s.st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	var doc secretMetadataDoc
+	err := coll.FindId(uri.ID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %s", uri)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.toSecretMetadata(&doc), nil
+}
+
+// GetSecretValue returns the value of a secret revision.
+func (s *secretsStore) GetSecretValue(uri *secrets.URI, revision int) (secrets.SecretValue, error) {
+	coll, closer := s.st.db().GetCollection(secretRevisionsC)
+	defer closer()
+
+	var doc secretRevisionDoc
+	err := coll.FindId(secretRevisionKey(uri, revision)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %s revision %d", uri, revision)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend, err := s.st.secretsBackendOfType(doc.Backend)
+	if err != nil {
+		return nil, errors.Annotatef(err, "secret %s revision %d", uri, revision)
+	}
+	data, err := backend.GetContent(doc.ValueRef)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting value of secret %s revision %d", uri, revision)
+	}
+	return secrets.NewSecretValue(data), nil
+}
+
+// ListSecrets returns the metadata of the secrets matching the filter.
+func (s *secretsStore) ListSecrets(filter SecretsFilter) ([]*secrets.SecretMetadata, error) {
+	coll, closer := s.st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	query := bson.D{}
+	if filter.OwnerTag != nil {
+		query = append(query, bson.DocElem{"owner-tag", *filter.OwnerTag})
+	}
+	var docs []secretMetadataDoc
+	if err := coll.Find(query).Sort("create-time").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*secrets.SecretMetadata, len(docs))
+	for i := range docs {
+		result[i] = s.toSecretMetadata(&docs[i])
+	}
+	return result, nil
+}
+
+// SecretRotated records that the secret was rotated at the given time,
+// scheduling the next rotation according to its rotate policy.
+func (s *secretsStore) SecretRotated(uri *secrets.URI, when time.Time) error {
+	md, err := s.GetSecret(uri)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      secretMetadataC,
+		Id:     uri.ID,
+		Assert: bson.D{{"rotate-policy", string(md.RotatePolicy)}},
+		Update: bson.D{{"$set", bson.D{
+			{"next-rotate-time", md.RotatePolicy.NextRotateTime(when.UTC())},
+		}}},
+	}}
+	if err := s.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot record rotation of secret %s", uri)
+	}
+	return nil
+}
+
+// GrantSecretAccess gives the subject access to the secret.
+func (s *secretsStore) GrantSecretAccess(uri *secrets.URI, p SecretAccessParams) error {
+	if !p.Role.IsValid() {
+		return errors.NotValidf("secret role %q", p.Role)
+	}
+	if err := checkSecretSubject(p.Subject); err != nil {
+		return errors.Trace(err)
+	}
+	key := secretSubjectKey(uri, p.Subject)
+	buildTxn := func(int) ([]txn.Op, error) {
+		if _, err := s.GetSecret(uri); err != nil {
+			return nil, errors.Trace(err)
+		}
+		role, err := s.SecretAccess(uri, p.Subject)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      secretMetadataC,
+			Id:     uri.ID,
+			Assert: txn.DocExists,
+		}}
+		if role != secrets.RoleNone {
+			return append(ops, txn.Op{
+				C:      secretPermissionsC,
+				Id:     key,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"role", string(p.Role)}}}},
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      secretPermissionsC,
+			Id:     key,
+			Assert: txn.DocMissing,
+			Insert: &secretPermissionDoc{
+				DocID:      key,
+				SecretID:   uri.ID,
+				SubjectTag: p.Subject.String(),
+				Role:       string(p.Role),
+			},
+		}), nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot grant access to secret %s", uri)
+	}
+	return nil
+}
+
+// RevokeSecretAccess removes the subject's access to the secret.
+func (s *secretsStore) RevokeSecretAccess(uri *secrets.URI, p SecretAccessParams) error {
+	if err := checkSecretSubject(p.Subject); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      secretPermissionsC,
+		Id:     secretSubjectKey(uri, p.Subject),
+		Remove: true,
+	}}
+	if err := s.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot revoke access to secret %s", uri)
+	}
+	return nil
+}
+
+func checkSecretSubject(subject names.Tag) error {
+	switch subject.(type) {
+	case names.ApplicationTag, names.UnitTag:
+		return nil
+	}
+	return errors.NotValidf("secret access subject %q", subject)
+}
+
+// SecretAccess returns the role the subject has been granted on the
+// secret.
+func (s *secretsStore) SecretAccess(uri *secrets.URI, subject names.Tag) (secrets.SecretRole, error) {
+	coll, closer := s.st.db().GetCollection(secretPermissionsC)
+	defer closer()
+
+	var doc secretPermissionDoc
+	err := coll.FindId(secretSubjectKey(uri, subject)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return secrets.RoleNone, nil
+	}
+	if err != nil {
+		return secrets.RoleNone, errors.Trace(err)
+	}
+	return secrets.SecretRole(doc.Role), nil
+}
+
+// GetSecretConsumer returns what the consumer knows about the secret.
+func (s *secretsStore) GetSecretConsumer(uri *secrets.URI, consumer names.Tag) (*SecretConsumerMetadata, error) {
+	coll, closer := s.st.db().GetCollection(secretConsumersC)
+	defer closer()
+
+	var doc secretConsumerDoc
+	err := coll.FindId(secretSubjectKey(uri, consumer)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("consumer %q of secret %s", consumer.Id(), uri)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &SecretConsumerMetadata{
+		Label:           doc.Label,
+		CurrentRevision: doc.CurrentRevision,
+	}, nil
+}
+
+// SaveSecretConsumer records what the consumer knows about the secret.
+func (s *secretsStore) SaveSecretConsumer(uri *secrets.URI, consumer names.Tag, md *SecretConsumerMetadata) error {
+	key := secretSubjectKey(uri, consumer)
+	buildTxn := func(int) ([]txn.Op, error) {
+		_, err := s.GetSecretConsumer(uri, consumer)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if err == nil {
+			return []txn.Op{{
+				C:      secretConsumersC,
+				Id:     key,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"label", md.Label},
+					{"current-revision", md.CurrentRevision},
+				}}},
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      secretMetadataC,
+			Id:     uri.ID,
+			Assert: txn.DocExists,
+		}, {
+			C:      secretConsumersC,
+			Id:     key,
+			Assert: txn.DocMissing,
+			Insert: &secretConsumerDoc{
+				DocID:           key,
+				SecretID:        uri.ID,
+				ConsumerTag:     consumer.String(),
+				Label:           md.Label,
+				CurrentRevision: md.CurrentRevision,
+			},
+		}}, nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot save consumer of secret %s", uri)
+	}
+	return nil
+}
+
+// GetURIByConsumerLabel returns the URI of the secret the consumer gave
+// the label to.
+func (s *secretsStore) GetURIByConsumerLabel(label string, consumer names.Tag) (*secrets.URI, error) {
+	coll, closer := s.st.db().GetCollection(secretConsumersC)
+	defer closer()
+
+	var doc secretConsumerDoc
+	err := coll.Find(bson.D{{"consumer-tag", consumer.String()}, {"label", label}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret with label %q", label)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &secrets.URI{ID: doc.SecretID}, nil
+}
+
+// HasSecrets returns whether the model has any secrets.
+func (st *State) HasSecrets() (bool, error) {
+	coll, closer := st.db().GetCollection(secretMetadataC)
+	defer closer()
+	n, err := coll.Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return n > 0, nil
+}
+
+// removeSecretsOps returns the operations to remove the secrets owned
+// by the application or unit, along with the access it has been granted
+// to and what it knows about other secrets, so that nothing is inherited
+// by an entity later created with the same name. The revisions of the
+// removed secrets are left for a cleanup, since their values may need to
+// be deleted from an external backend.
+func (st *State) removeSecretsOps(tag names.Tag) ([]txn.Op, error) {
+	metadataColl, closer := st.db().GetCollection(secretMetadataC)
+	defer closer()
+	var owned []secretMetadataDoc
+	err := metadataColl.Find(bson.D{{"owner-tag", tag.String()}}).Select(bson.D{{"_id", 1}}).All(&owned)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading secrets owned by %q", tag.Id())
+	}
+	secretIDs := make([]string, len(owned))
+	var ops []txn.Op
+	for i, doc := range owned {
+		secretIDs[i] = st.localID(doc.DocID)
+		ops = append(ops, txn.Op{
+			C:      secretMetadataC,
+			Id:     doc.DocID,
+			Remove: true,
+		}, newCleanupOp(cleanupSecretRevisions, secretIDs[i]))
+	}
+
+	query := bson.D{{"$or", []bson.D{
+		{{"subject-tag", tag.String()}},
+		{{"secret-id", bson.D{{"$in", secretIDs}}}},
+	}}}
+	permissionsColl, closer := st.db().GetCollection(secretPermissionsC)
+	defer closer()
+	var permissions []secretPermissionDoc
+	if err := permissionsColl.Find(query).Select(bson.D{{"_id", 1}}).All(&permissions); err != nil {
+		return nil, errors.Annotatef(err, "reading secret access of %q", tag.Id())
+	}
+	for _, doc := range permissions {
+		ops = append(ops, txn.Op{
+			C:      secretPermissionsC,
+			Id:     doc.DocID,
+			Remove: true,
+		})
+	}
+
+	query = bson.D{{"$or", []bson.D{
+		{{"consumer-tag", tag.String()}},
+		{{"secret-id", bson.D{{"$in", secretIDs}}}},
+	}}}
+	consumersColl, closer := st.db().GetCollection(secretConsumersC)
+	defer closer()
+	var consumers []secretConsumerDoc
+	if err := consumersColl.Find(query).Select(bson.D{{"_id", 1}}).All(&consumers); err != nil {
+		return nil, errors.Annotatef(err, "reading secrets consumed by %q", tag.Id())
+	}
+	for _, doc := range consumers {
+		ops = append(ops, txn.Op{
+			C:      secretConsumersC,
+			Id:     doc.DocID,
+			Remove: true,
+		})
+	}
+	return ops, nil
+}
+
+// removeSecretRevisions deletes the values of the revisions of a
+// removed secret from the backends holding them, and then the revisions.
+func (st *State) removeSecretRevisions(secretID string) error {
+	coll, closer := st.db().GetCollection(secretRevisionsC)
+	defer closer()
+	var docs []secretRevisionDoc
+	if err := coll.Find(bson.D{{"secret-id", secretID}}).All(&docs); err != nil {
+		return errors.Annotatef(err, "reading revisions of secret %s", secretID)
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		backend, err := st.secretsBackendOfType(doc.Backend)
+		if err != nil {
+			logger.Warningf("cannot delete value of secret %s revision %d: %v", secretID, doc.Revision, err)
+		} else {
+			deleteSecretContent(backend, doc.ValueRef)
+		}
+		ops[i] = txn.Op{
+			C:      secretRevisionsC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return errors.Trace(st.db().RunTransaction(ops))
+}
+
+// deleteExternalSecretContent deletes the values of all the model's
+// secret revisions from the external backends holding them.
+func (st *State) deleteExternalSecretContent() error {
+	coll, closer := st.db().GetCollection(secretRevisionsC)
+	defer closer()
+	var docs []secretRevisionDoc
+	err := coll.Find(bson.D{{"backend", bson.D{{"$ne", secretbackend.Internal}}}}).All(&docs)
+	if err != nil {
+		return errors.Annotate(err, "reading secret revisions")
+	}
+	for _, doc := range docs {
+		backend, err := st.secretsBackendOfType(doc.Backend)
+		if err != nil {
+			logger.Warningf("cannot delete value of secret %s revision %d: %v", doc.SecretID, doc.Revision, err)
+			continue
+		}
+		deleteSecretContent(backend, doc.ValueRef)
+	}
+	return nil
+}
+
+const secretsKeyKey = "secretsKey"
+
+type secretsKeyDoc struct {
+	DocID string `bson:"_id"`
+	Key   string `bson:"key"`
+}
+
+// secretsKey returns the key used to encrypt secret values at rest,
+// creating it the first time it is needed.
+//
+// The key is held in its own collection of the controller database,
+// which is left out of backups, so a backup archive alone can't be used
+// to read the secrets and secret backend credentials it holds. Anyone
+// with direct access to the database can still read the key.
+func (s *secretsStore) secretsKey() ([]byte, error) {
+	keys, closer := s.st.db().GetCollection(secretsKeyC)
+	defer closer()
+
+	var doc secretsKeyDoc
+	buildTxn := func(int) ([]txn.Op, error) {
+		err := keys.FindId(secretsKeyKey).One(&doc)
+		if err == nil {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if err != mgo.ErrNotFound {
+			return nil, errors.Trace(err)
+		}
+		key := make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc = secretsKeyDoc{
+			DocID: secretsKeyKey,
+			Key:   base64.StdEncoding.EncodeToString(key),
+		}
+		return []txn.Op{{
+			C:      secretsKeyC,
+			Id:     secretsKeyKey,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "cannot create secrets key")
+	}
+	key, err := base64.StdEncoding.DecodeString(doc.Key)
+	return key, errors.Trace(err)
+}
+
+func (s *secretsStore) cipher() (cipher.AEAD, error) {
+	key, err := s.secretsKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt returns the data encrypted with the secrets key, prefixed by
+// the nonce used.
+func (s *secretsStore) encrypt(data map[string]string) ([]byte, error) {
+	plain, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	gcm, err := s.cipher()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return gcm.Seal(nonce, nonce, plain, nil), nil
+}
+
+func (s *secretsStore) decrypt(value []byte) (map[string]string, error) {
+	gcm, err := s.cipher()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(value) < gcm.NonceSize() {
+		return nil, errors.NotValidf("encrypted secret value")
+	}
+	nonce, sealed := value[:gcm.NonceSize()], value[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var data map[string]string
+	if err := json.Unmarshal(plain, &data); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+// WatchConsumedSecretsChanges returns a watcher which notifies of the
+// URIs of the secrets read by the consumer which have a revision newer
+// than the one the consumer reads.
+func (st *State) WatchConsumedSecretsChanges(consumer names.Tag) StringsWatcher {
+	store := NewSecrets(st)
+	return newSecretsWatcher(st, func(ids []string) ([]string, error) {
+		var changed []string
+		for _, id := range ids {
+			uri := &secrets.URI{ID: id}
+			consumed, err := store.GetSecretConsumer(uri, consumer)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			md, err := store.GetSecret(uri)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if md.LatestRevision > consumed.CurrentRevision {
+				changed = append(changed, uri.String())
+			}
+		}
+		return changed, nil
+	})
+}
+
+// WatchSecretsRotationChanges returns a watcher which notifies of the
+// URIs of the secrets owned by the owner whose rotation schedule may have
+// changed.
+func (st *State) WatchSecretsRotationChanges(owner names.Tag) StringsWatcher {
+	store := NewSecrets(st)
+	return newSecretsWatcher(st, func(ids []string) ([]string, error) {
+		var changed []string
+		for _, id := range ids {
+			uri := &secrets.URI{ID: id}
+			md, err := store.GetSecret(uri)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if md.OwnerTag == owner.String() {
+				changed = append(changed, uri.String())
+			}
+		}
+		return changed, nil
+	})
+}
+
+// secretsWatcher notifies of changes to the secrets in a model, limited
+// to the secrets selected by a filter.
+type secretsWatcher struct {
+	commonWatcher
+	source StringsWatcher
+	filter func([]string) ([]string, error)
+	out    chan []string
+}
+
+func newSecretsWatcher(st *State, filter func([]string) ([]string, error)) StringsWatcher {
+	w := &secretsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		source:        newCollectionWatcher(st, colWCfg{col: secretMetadataC}),
+		filter:        filter,
+		out:           make(chan []string),
+	}
+	w.tomb.Go(func() error {
+		defer close(w.out)
+		return w.loop()
+	})
+	return w
+}
+
+// Changes implements StringsWatcher.
+func (w *secretsWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+func (w *secretsWatcher) loop() error {
+	defer watcher.Stop(w.source, &w.tomb)
+
+	var (
+		changes = set.NewStrings()
+		out     chan<- []string
+		initial = true
+	)
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case ids, ok := <-w.source.Changes():
+			if !ok {
+				return watcher.EnsureErr(w.source)
+			}
+			filtered, err := w.filter(ids)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, id := range filtered {
+				changes.Add(id)
+			}
+			// The initial event is always sent, even if it is empty.
+			if initial || !changes.IsEmpty() {
+				out = w.out
+			}
+			initial = false
+		case out <- changes.SortedValues():
+			changes = set.NewStrings()
+			out = nil
+		}
+	}
+}
@@ -0,0 +1,576 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v2/bson"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/secrets"
+	vaulttesting "github.com/juju/juju/secrets/backend/vault/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	store state.SecretsStore
+	owner names.Tag
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.store = state.NewSecrets(s.State)
+	s.owner = names.NewApplicationTag("mysql")
+}
+
+func (s *SecretsSuite) createSecret(c *gc.C, data map[string]string) *secrets.URI {
+	uri := secrets.NewURI()
+	_, err := s.store.CreateSecret(uri, state.CreateSecretParams{
+		Owner:        s.owner,
+		Label:        "password",
+		RotatePolicy: secrets.RotateDaily,
+		Data:         data,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return uri
+}
+
+func (s *SecretsSuite) TestCreate(c *gc.C) {
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+
+	md, err := s.store.GetSecret(uri)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.URI, jc.DeepEquals, uri)
+	c.Assert(md.OwnerTag, gc.Equals, "application-mysql")
+	c.Assert(md.Label, gc.Equals, "password")
+	c.Assert(md.LatestRevision, gc.Equals, 1)
+	c.Assert(md.NextRotateTime, gc.NotNil)
+	c.Assert(*md.NextRotateTime, gc.Equals, md.CreateTime.Add(24*time.Hour))
+
+	value, err := s.store.GetSecretValue(uri, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value.Values(), jc.DeepEquals, map[string]string{"password": "s3cret"})
+}
+
+func (s *SecretsSuite) TestHasSecrets(c *gc.C) {
+	has, err := s.State.HasSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(has, jc.IsFalse)
+
+	s.createSecret(c, map[string]string{"password": "s3cret"})
+	has, err = s.State.HasSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(has, jc.IsTrue)
+}
+
+func (s *SecretsSuite) TestCreateDuplicateLabel(c *gc.C) {
+	s.createSecret(c, map[string]string{"password": "s3cret"})
+	_, err := s.store.CreateSecret(secrets.NewURI(), state.CreateSecretParams{
+		Owner: s.owner,
+		Label: "password",
+		Data:  map[string]string{"password": "other"},
+	})
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *SecretsSuite) TestCreateInvalid(c *gc.C) {
+	_, err := s.store.CreateSecret(secrets.NewURI(), state.CreateSecretParams{Owner: s.owner})
+	c.Assert(err, gc.ErrorMatches, "secret without data not valid")
+	_, err = s.store.CreateSecret(secrets.NewURI(), state.CreateSecretParams{
+		Owner:        s.owner,
+		RotatePolicy: "sometimes",
+		Data:         map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, gc.ErrorMatches, `secret rotate policy "sometimes" not valid`)
+}
+
+func (s *SecretsSuite) TestValueEncryptedAtRest(c *gc.C) {
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+
+	revisions, closer := state.GetCollection(s.State, "secretRevisions")
+	defer closer()
+	var revision bson.M
+	err := revisions.Find(bson.D{{"secret-id", uri.ID}}).One(&revision)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revision["backend"], gc.Equals, "internal")
+
+	content, closer := state.GetCollection(s.State, "secretContent")
+	defer closer()
+	var raw bson.M
+	err = content.FindId(revision["value-ref"]).One(&raw)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(raw["value"].([]byte)), gc.Not(jc.Contains), "s3cret")
+}
+
+func (s *SecretsSuite) TestValueInExternalBackend(c *gc.C) {
+	server := vaulttesting.NewServer("t0ken")
+	defer server.Close()
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=" + server.URL + ";token=t0ken",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+	_, err = s.store.UpdateSecret(uri, state.UpdateSecretParams{Data: map[string]string{"password": "n3w"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	revisions, closer := state.GetCollection(s.State, "secretRevisions")
+	defer closer()
+	for revision, password := range map[int]string{1: "s3cret", 2: "n3w"} {
+		var doc bson.M
+		err := revisions.Find(bson.D{{"secret-id", uri.ID}, {"revision", revision}}).One(&doc)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(doc["backend"], gc.Equals, "vault")
+		path := doc["value-ref"].(string)
+		c.Assert(path, jc.HasPrefix, fmt.Sprintf("juju/%s/secrets/%s/", s.State.ModelUUID(), uri.ID))
+		stored, ok := server.Content(path)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(stored, jc.DeepEquals, map[string]string{"password": password})
+
+		value, err := s.store.GetSecretValue(uri, revision)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(value.Values(), jc.DeepEquals, map[string]string{"password": password})
+	}
+
+	// Nothing is kept in the controller database.
+	content, closer2 := state.GetCollection(s.State, "secretContent")
+	defer closer2()
+	n, err := content.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+}
+
+func (s *SecretsSuite) TestBackendCredentialsKeptOutOfModelConfig(c *gc.C) {
+	server := vaulttesting.NewServer("t0ken")
+	defer server.Close()
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=" + server.URL + ";token=t0ken",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["secret-backend-config"], gc.Equals, "address="+server.URL)
+	values, err := s.Model.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["secret-backend-config"].Value, gc.Equals, "address="+server.URL)
+
+	settings, closer := state.GetCollection(s.State, "settings")
+	defer closer()
+	var raw bson.M
+	err = settings.FindId("e").One(&raw)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fmt.Sprint(raw), gc.Not(jc.Contains), "t0ken")
+	content, closer2 := state.GetCollection(s.State, "secretContent")
+	defer closer2()
+	err = content.FindId("secret-backend-credentials").One(&raw)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(raw["value"].([]byte)), gc.Not(jc.Contains), "t0ken")
+
+	// The stored token is still used once the rest of the config changes.
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend-config": "address=" + server.URL + ";mount-path=secret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+	value, err := s.store.GetSecretValue(uri, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value.Values(), jc.DeepEquals, map[string]string{"password": "s3cret"})
+
+}
+
+func (s *SecretsSuite) TestBackendCredentialsRemovedWithBackend(c *gc.C) {
+	server := vaulttesting.NewServer("t0ken")
+	defer server.Close()
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=" + server.URL + ";token=t0ken",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend": "internal",
+	}, []string{"secret-backend-config"})
+	c.Assert(err, jc.ErrorIsNil)
+	content, closer := state.GetCollection(s.State, "secretContent")
+	defer closer()
+	n, err := content.FindId("secret-backend-credentials").Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+}
+
+func (s *SecretsSuite) TestChangeBackendRefusedWhileInUse(c *gc.C) {
+	server := vaulttesting.NewServer("t0ken")
+	defer server.Close()
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=" + server.URL + ";token=t0ken",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+	err = s.State.AddCloud(cloud.Cloud{
+		Name:      "stratus",
+		Type:      "low",
+		AuthTypes: cloud.AuthTypes{cloud.AccessKeyAuthType},
+	}, s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewCloudCredentialTag("stratus/bob/bobcred1")
+	attrs := map[string]string{"foo": "foo val"}
+	err = s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.AccessKeyAuthType, attrs))
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		update map[string]interface{}
+		remove []string
+	}{{
+		update: map[string]interface{}{"secret-backend": "internal"},
+		remove: []string{"secret-backend-config"},
+	}, {
+		update: map[string]interface{}{"secret-backend-config": "address=https://elsewhere.example.com:8200"},
+	}, {
+		update: map[string]interface{}{"secret-backend-config": "address=" + server.URL + ";mount-path=other"},
+	}} {
+		c.Logf("test %d: %v %v", i, test.update, test.remove)
+		err = s.Model.UpdateModelConfig(test.update, test.remove)
+		c.Check(err, gc.ErrorMatches, `cannot change vault secret backend while it holds 1 secret revision\(s\) and 1 cloud credential\(s\), only its credentials can be changed`)
+	}
+
+	// The backend's credentials can still be changed.
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend-config": "address=" + server.URL + ";token=t0ken",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SecretBackend(), gc.Equals, "vault")
+	value, err := s.store.GetSecretValue(uri, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value.Values(), jc.DeepEquals, map[string]string{"password": "s3cret"})
+	cred, err := s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cred.Attributes, jc.DeepEquals, attrs)
+}
+
+func (s *SecretsSuite) TestBackendCredentialsRequired(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=http://vault:8200",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, "vault secret backend without token not valid")
+}
+
+func (s *SecretsSuite) TestBackendCredentialsNotModelDefaults(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"secret-backend-config": "address=http://vault:8200;token=t0ken",
+	}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `secret backend credential "token" in model defaults not valid`)
+}
+
+func (s *SecretsSuite) TestValueUnavailableAfterBackendChange(c *gc.C) {
+	server := vaulttesting.NewServer("t0ken")
+	defer server.Close()
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=" + server.URL + ";token=t0ken",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend": "internal",
+	}, []string{"secret-backend-config"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.store.GetSecretValue(uri, 1)
+	c.Assert(err, gc.ErrorMatches, `secret .* revision 1: vault secret backend not found`)
+}
+
+func (s *SecretsSuite) TestUpdate(c *gc.C) {
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+
+	description := "the database password"
+	md, err := s.store.UpdateSecret(uri, state.UpdateSecretParams{
+		Description: &description,
+		Data:        map[string]string{"password": "n3w"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.Description, gc.Equals, description)
+	c.Assert(md.LatestRevision, gc.Equals, 2)
+
+	value, err := s.store.GetSecretValue(uri, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value.Values(), jc.DeepEquals, map[string]string{"password": "s3cret"})
+	value, err = s.store.GetSecretValue(uri, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value.Values(), jc.DeepEquals, map[string]string{"password": "n3w"})
+}
+
+func (s *SecretsSuite) TestUpdateNotFound(c *gc.C) {
+	description := "foo"
+	_, err := s.store.UpdateSecret(secrets.NewURI(), state.UpdateSecretParams{Description: &description})
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+	_, err := s.store.CreateSecret(secrets.NewURI(), state.CreateSecretParams{
+		Owner: names.NewApplicationTag("wordpress"),
+		Data:  map[string]string{"token": "t0ken"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.store.ListSecrets(state.SecretsFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+
+	owner := s.owner.String()
+	owned, err := s.store.ListSecrets(state.SecretsFilter{OwnerTag: &owner})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owned, gc.HasLen, 1)
+	c.Assert(owned[0].URI, jc.DeepEquals, uri)
+}
+
+func (s *SecretsSuite) TestGrantRevoke(c *gc.C) {
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+	subject := names.NewApplicationTag("wordpress")
+
+	role, err := s.store.SecretAccess(uri, subject)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleNone)
+
+	err = s.store.GrantSecretAccess(uri, state.SecretAccessParams{Subject: subject, Role: secrets.RoleView})
+	c.Assert(err, jc.ErrorIsNil)
+	role, err = s.store.SecretAccess(uri, subject)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleView)
+
+	err = s.store.RevokeSecretAccess(uri, state.SecretAccessParams{Subject: subject})
+	c.Assert(err, jc.ErrorIsNil)
+	role, err = s.store.SecretAccess(uri, subject)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleNone)
+}
+
+func (s *SecretsSuite) TestGrantMissingSecret(c *gc.C) {
+	err := s.store.GrantSecretAccess(secrets.NewURI(), state.SecretAccessParams{
+		Subject: names.NewUnitTag("wordpress/0"),
+		Role:    secrets.RoleView,
+	})
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestSecretConsumer(c *gc.C) {
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+	consumer := names.NewUnitTag("wordpress/0")
+
+	_, err := s.store.GetSecretConsumer(uri, consumer)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.store.SaveSecretConsumer(uri, consumer, &state.SecretConsumerMetadata{Label: "db", CurrentRevision: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	md, err := s.store.GetSecretConsumer(uri, consumer)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md, jc.DeepEquals, &state.SecretConsumerMetadata{Label: "db", CurrentRevision: 1})
+
+	found, err := s.store.GetURIByConsumerLabel("db", consumer)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, uri)
+	_, err = s.store.GetURIByConsumerLabel("db", names.NewUnitTag("wordpress/1"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestSecretRotated(c *gc.C) {
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+	when := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	err := s.store.SecretRotated(uri, when)
+	c.Assert(err, jc.ErrorIsNil)
+
+	md, err := s.store.GetSecret(uri)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.NextRotateTime.UTC(), gc.Equals, when.Add(24*time.Hour))
+}
+
+func (s *SecretsSuite) TestWatchConsumedSecretsChanges(c *gc.C) {
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+	consumer := names.NewUnitTag("wordpress/0")
+	err := s.store.SaveSecretConsumer(uri, consumer, &state.SecretConsumerMetadata{CurrentRevision: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchConsumedSecretsChanges(consumer)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	_, err = s.store.UpdateSecret(uri, state.UpdateSecretParams{Data: map[string]string{"password": "n3w"}})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(uri.String())
+	wc.AssertNoChange()
+
+	// Secrets the unit doesn't read aren't reported.
+	other := secrets.NewURI()
+	_, err = s.store.CreateSecret(other, state.CreateSecretParams{
+		Owner: s.owner,
+		Data:  map[string]string{"token": "t0ken"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *SecretsSuite) TestWatchSecretsRotationChanges(c *gc.C) {
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+
+	w := s.State.WatchSecretsRotationChanges(s.owner)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(uri.String())
+	wc.AssertNoChange()
+
+	err := s.store.SecretRotated(uri, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(uri.String())
+	wc.AssertNoChange()
+
+	_, err = s.store.CreateSecret(secrets.NewURI(), state.CreateSecretParams{
+		Owner: names.NewApplicationTag("wordpress"),
+		Data:  map[string]string{"token": "t0ken"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *SecretsSuite) TestRemovedApplicationSecretsNotInherited(c *gc.C) {
+	ch := s.AddTestingCharm(c, "mysql")
+	app := s.AddTestingApplication(c, "mysql", ch)
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+	err := s.store.GrantSecretAccess(uri, state.SecretAccessParams{
+		Subject: names.NewApplicationTag("wordpress"),
+		Role:    secrets.RoleView,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A secret owned by another application, which mysql can see.
+	other := secrets.NewURI()
+	_, err = s.store.CreateSecret(other, state.CreateSecretParams{
+		Owner: names.NewApplicationTag("wordpress"),
+		Data:  map[string]string{"token": "t0ken"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.GrantSecretAccess(other, state.SecretAccessParams{Subject: s.owner, Role: secrets.RoleView})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SaveSecretConsumer(other, s.owner, &state.SecretConsumerMetadata{Label: "token", CurrentRevision: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.State.Cleanup(), jc.ErrorIsNil)
+
+	// Redeploy under the same name.
+	s.AddTestingApplication(c, "mysql", ch)
+
+	_, err = s.store.GetSecret(uri)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	owner := s.owner.String()
+	owned, err := s.store.ListSecrets(state.SecretsFilter{OwnerTag: &owner})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owned, gc.HasLen, 0)
+	role, err := s.store.SecretAccess(uri, names.NewApplicationTag("wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleNone)
+
+	role, err = s.store.SecretAccess(other, s.owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleNone)
+	_, err = s.store.GetSecretConsumer(other, s.owner)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.store.GetURIByConsumerLabel("token", s.owner)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// The new application can reuse the label.
+	s.createSecret(c, map[string]string{"password": "n3w"})
+
+	// The removed secret's revision and value are cleaned up.
+	revisions, closer := state.GetCollection(s.State, "secretRevisions")
+	defer closer()
+	n, err := revisions.Find(bson.D{{"secret-id", uri.ID}}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+	content, closer2 := state.GetCollection(s.State, "secretContent")
+	defer closer2()
+	n, err = content.Find(bson.D{{"_id", bson.D{{"$regex", uri.ID}}}}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+}
+
+func (s *SecretsSuite) TestRemovedSecretValuesDeletedFromExternalBackend(c *gc.C) {
+	server := vaulttesting.NewServer("t0ken")
+	defer server.Close()
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"secret-backend":        "vault",
+		"secret-backend-config": "address=" + server.URL + ";token=t0ken",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	uri := s.createSecret(c, map[string]string{"password": "s3cret"})
+
+	revisions, closer := state.GetCollection(s.State, "secretRevisions")
+	defer closer()
+	var doc bson.M
+	err = revisions.Find(bson.D{{"secret-id", uri.ID}}).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	path := doc["value-ref"].(string)
+	_, ok := server.Content(path)
+	c.Assert(ok, jc.IsTrue)
+
+	c.Assert(app.Destroy(), jc.ErrorIsNil)
+	c.Assert(s.State.Cleanup(), jc.ErrorIsNil)
+	_, ok = server.Content(path)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestRemovedUnitSecretsRemoved(c *gc.C) {
+	app := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	owned := secrets.NewURI()
+	_, err = s.store.CreateSecret(owned, state.CreateSecretParams{
+		Owner: unit.Tag(),
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	granted := s.createSecret(c, map[string]string{"password": "s3cret"})
+	err = s.store.GrantSecretAccess(granted, state.SecretAccessParams{Subject: unit.Tag(), Role: secrets.RoleView})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SaveSecretConsumer(granted, unit.Tag(), &state.SecretConsumerMetadata{CurrentRevision: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(unit.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(unit.Remove(), jc.ErrorIsNil)
+
+	_, err = s.store.GetSecret(owned)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	role, err := s.store.SecretAccess(granted, unit.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleNone)
+	_, err = s.store.GetSecretConsumer(granted, unit.Tag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// The application's own secret is untouched.
+	_, err = s.store.GetSecret(granted)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -216,6 +216,11 @@ func (st *State) RemoveDyingModel() error {
 			return errors.Trace(err)
 		}
 	}
+	// Secret values held by an external backend aren't removed along
+	// with the model's documents.
+	if err := st.deleteExternalSecretContent(); err != nil {
+		return errors.Trace(err)
+	}
 	err = st.removeAllModelDocs(bson.D{{"life", Dead}})
 	if errors.Cause(err) == txn.ErrAborted {
 		return errors.Wrap(err, errors.New("can't remove model: model not dead"))
@@ -330,3 +330,34 @@ func (s *StatusHistorySuite) TestSameValueNotRepeated(c *gc.C) {
 	c.Assert(history[0].Message, gc.Equals, "current status")
 	c.Assert(history[1].Message, gc.Equals, "waiting for machine")
 }
+
+func (s *StatusHistorySuite) TestBackupStatusHistory(c *gc.C) {
+	first := s.Clock.Now().Add(-time.Hour)
+	err := s.State.RecordBackupStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "while creating backup archive: boom",
+		Since:   &first,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RecordBackupStatus(status.StatusInfo{
+		Status:  status.Available,
+		Message: "created backup",
+		Data:    map[string]interface{}{"id": "20210301-023000.deadbeef"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.State.BackupStatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Status, gc.Equals, status.Available)
+	c.Check(history[0].Message, gc.Equals, "created backup")
+	c.Check(history[0].Data, jc.DeepEquals, map[string]interface{}{"id": "20210301-023000.deadbeef"})
+	c.Check(history[1].Status, gc.Equals, status.Error)
+	c.Check(history[1].Message, gc.Equals, "while creating backup archive: boom")
+	c.Check(history[1].Since.Equal(first), jc.IsTrue)
+
+	history, err = s.State.BackupStatusHistory(status.StatusHistoryFilter{Size: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Check(history[0].Status, gc.Equals, status.Available)
+}
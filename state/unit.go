@@ -82,6 +82,7 @@ type unitDoc struct {
 	StorageAttachmentCount int `bson:"storageattachmentcount"`
 	MachineId              string
 	Resolved               ResolvedMode
+	HookCancelRequested    bool         `bson:"hookcancelrequested,omitempty"`
 	Tools                  *tools.Tools `bson:",omitempty"`
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
@@ -583,6 +584,12 @@ func (op *DestroyUnitOperation) eraseHistory() error {
 			return one
 		}
 	}
+	if err := eraseHookHistory(stop, op.unit.st, op.unit.Name()); err != nil {
+		one := errors.Annotate(err, "hooks")
+		if op.FatalError(one) {
+			return one
+		}
+	}
 	return nil
 }
 
@@ -1071,6 +1078,12 @@ func (u *Unit) Resolved() ResolvedMode {
 	return u.doc.Resolved
 }
 
+// HookCancelRequested returns whether cancellation of the hook the unit
+// is running has been requested.
+func (u *Unit) HookCancelRequested() bool {
+	return u.doc.HookCancelRequested
+}
+
 // IsPrincipal returns whether the unit is deployed in its own container,
 // and can therefore have subordinate applications deployed alongside it.
 func (u *Unit) IsPrincipal() bool {
@@ -2863,6 +2876,55 @@ func (u *Unit) ClearResolved() error {
 	return nil
 }
 
+// RequestHookCancel asks the unit agent to interrupt the hook the unit
+// is running, which then fails as if it had errored.
+func (u *Unit) RequestHookCancel() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot cancel hook for unit %q", u)
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"hookcancelrequested", true}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err == nil {
+		u.doc.HookCancelRequested = true
+		return nil
+	} else if err != txn.ErrAborted {
+		return err
+	}
+	if ok, err := isNotDead(u.st, unitsC, u.doc.DocID); err != nil {
+		return err
+	} else if !ok {
+		return stateerrors.ErrDead
+	}
+	return errors.NotFoundf("unit")
+}
+
+// ClearHookCancel removes any request to cancel the unit's hook.
+func (u *Unit) ClearHookCancel() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot clear hook cancel request for unit %q", u)
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$unset", bson.D{{"hookcancelrequested", nil}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err == nil {
+		u.doc.HookCancelRequested = false
+		return nil
+	} else if err != txn.ErrAborted {
+		return err
+	}
+	units, closer := u.st.db().GetCollection(unitsC)
+	defer closer()
+	if n, err := units.FindId(u.doc.DocID).Count(); err != nil {
+		return err
+	} else if n > 0 {
+		return errors.Errorf("unit changed while clearing hook cancel request")
+	}
+	return errors.NotFoundf("unit")
+}
+
 // StorageConstraints returns the unit's storage constraints.
 func (u *Unit) StorageConstraints() (map[string]StorageConstraints, error) {
 	if u.doc.CharmURL == nil {
@@ -1773,6 +1773,43 @@ func (s *UnitSuite) TestGetSetClearResolved(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot set resolved mode for unit "wordpress/0": invalid error resolution mode: "foo"`)
 }
 
+func (s *UnitSuite) TestRequestClearHookCancel(c *gc.C) {
+	c.Assert(s.unit.HookCancelRequested(), jc.IsFalse)
+
+	err := s.unit.RequestHookCancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.HookCancelRequested(), jc.IsTrue)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.HookCancelRequested(), jc.IsTrue)
+
+	err = s.unit.ClearHookCancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.HookCancelRequested(), jc.IsFalse)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.HookCancelRequested(), jc.IsFalse)
+	err = s.unit.ClearHookCancel()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitSuite) TestRequestHookCancelDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RequestHookCancel()
+	c.Assert(err, gc.ErrorMatches, `cannot cancel hook for unit "wordpress/0": not found or dead`)
+}
+
+func (s *UnitSuite) TestClearHookCancelRemovedUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.ClearHookCancel()
+	c.Assert(err, gc.ErrorMatches, `cannot clear hook cancel request for unit "wordpress/0": unit not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *UnitSuite) TesOpenedPorts(c *gc.C) {
 	// Accessing the port ranges for the unit should fail if it's not assigned to a machine.
 	_, err := s.unit.OpenedPortRanges()
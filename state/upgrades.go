@@ -472,7 +472,7 @@ func updateLegacyLXDCredentialsOps(st *State, cred cloud.Credential) ([]txn.Op,
 		if c.Type != "lxd" {
 			continue
 		}
-		op := updateCloudCredentialOp(cloudCredentialTag, cred)
+		op := updateCloudCredentialOp(cloudCredentialTag, cred, credentialAttributesRef{})
 		upgradesLogger.Infof("updating credential %q: %v", cloudCredentialTag, op)
 		ops = append(ops, op)
 	}
@@ -0,0 +1,14 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/juju/controller"
+)
+
+var NewTraceExporter = newTraceExporter
+
+func UpdateTraceExporter(e *traceExporter, controllerConfig controller.Config) error {
+	return e.update(controllerConfig)
+}
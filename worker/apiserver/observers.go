@@ -13,7 +13,9 @@ import (
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/observer/metricobserver"
+	"github.com/juju/juju/apiserver/observer/tracingobserver"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/tracing"
 )
 
 func newObserverFn(
@@ -22,6 +24,7 @@ func newObserverFn(
 	clock clock.Clock,
 	hub *pubsub.StructuredHub,
 	metricsCollector *apiserver.Collector,
+	traceExporter tracing.Exporter,
 ) (observer.ObserverFactory, error) {
 
 	var observerFactories []observer.ObserverFactory
@@ -47,6 +50,17 @@ func newObserverFn(
 	}
 	observerFactories = append(observerFactories, metricObserver)
 
+	// Tracing observer. Spans are dropped unless a collector is
+	// configured.
+	tracingObserver, err := tracingobserver.NewObserverFactory(tracingobserver.Config{
+		Clock:    clock,
+		Exporter: traceExporter,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating tracing observer factory")
+	}
+	observerFactories = append(observerFactories, tracingObserver)
+
 	return observer.ObserverFactoryMultiplexer(observerFactories...), nil
 }
 
@@ -0,0 +1,106 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sync"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/tracing"
+)
+
+// traceExporter sends API request spans to the OpenTelemetry collector
+// in the controller config, replacing its OTLP exporter whenever the
+// configured endpoint changes. Spans are dropped while no collector is
+// configured.
+type traceExporter struct {
+	agentConfig    agent.Config
+	controllerUUID string
+	clock          clock.Clock
+
+	mu       sync.Mutex
+	endpoint string
+	exporter *tracing.OTLPExporter
+}
+
+// newTraceExporter returns a trace exporter using the collector
+// configured in controllerConfig.
+func newTraceExporter(
+	agentConfig agent.Config,
+	controllerConfig controller.Config,
+	clock clock.Clock,
+) (*traceExporter, error) {
+	e := &traceExporter{
+		agentConfig:    agentConfig,
+		controllerUUID: controllerConfig.ControllerUUID(),
+		clock:          clock,
+	}
+	if err := e.update(controllerConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return e, nil
+}
+
+// update starts sending spans to the collector configured in
+// controllerConfig, if it has changed.
+func (e *traceExporter) update(controllerConfig controller.Config) error {
+	endpoint := controllerConfig.TracingOTLPEndpoint()
+	e.mu.Lock()
+	if endpoint == e.endpoint {
+		e.mu.Unlock()
+		return nil
+	}
+	var exporter *tracing.OTLPExporter
+	if endpoint != "" {
+		var err error
+		exporter, err = tracing.NewOTLPExporter(tracing.OTLPConfig{
+			Endpoint:    endpoint,
+			ServiceName: "jujud",
+			ResourceAttributes: map[string]string{
+				"juju.controller.uuid": e.controllerUUID,
+				"juju.agent":           e.agentConfig.Tag().String(),
+			},
+			Clock: e.clock,
+		})
+		if err != nil {
+			e.mu.Unlock()
+			return errors.Trace(err)
+		}
+	}
+	old := e.exporter
+	e.endpoint, e.exporter = endpoint, exporter
+	e.mu.Unlock()
+
+	if old != nil {
+		_ = old.Close()
+	}
+	return nil
+}
+
+// ExportSpan implements tracing.Exporter.
+func (e *traceExporter) ExportSpan(span tracing.Span) {
+	e.mu.Lock()
+	exporter := e.exporter
+	e.mu.Unlock()
+	if exporter != nil {
+		exporter.ExportSpan(span)
+	}
+}
+
+// Close stops sending spans to the collector.
+func (e *traceExporter) Close() error {
+	e.mu.Lock()
+	exporter := e.exporter
+	e.endpoint, e.exporter = "", nil
+	e.mu.Unlock()
+
+	if exporter != nil {
+		return exporter.Close()
+	}
+	return nil
+}
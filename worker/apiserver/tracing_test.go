@@ -0,0 +1,110 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/tracing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/apiserver"
+)
+
+type TraceExporterSuite struct {
+	testing.IsolationSuite
+
+	clock    *testclock.Clock
+	server   *httptest.Server
+	requests chan string
+}
+
+var _ = gc.Suite(&TraceExporterSuite{})
+
+func (s *TraceExporterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Time{})
+	s.requests = make(chan string, 10)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.requests <- req.URL.Path
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *TraceExporterSuite) controllerConfig(endpoint string) controller.Config {
+	cfg := controller.Config{
+		controller.ControllerUUIDKey: coretesting.ControllerTag.Id(),
+	}
+	if endpoint != "" {
+		cfg[controller.TracingOTLPEndpoint] = endpoint
+	}
+	return cfg
+}
+
+func (s *TraceExporterSuite) exportSpan(e tracing.Exporter) {
+	e.ExportSpan(tracing.Span{
+		Name:    "Client.FullStatus",
+		Context: tracing.NewRootContext(),
+		Kind:    tracing.SpanKindServer,
+	})
+}
+
+func (s *TraceExporterSuite) assertExported(c *gc.C) {
+	err := s.clock.WaitAdvance(tracing.DefaultFlushInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case path := <-s.requests:
+		c.Assert(path, gc.Equals, "/v1/traces")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for spans to be exported")
+	}
+}
+
+func (s *TraceExporterSuite) assertNotExported(c *gc.C) {
+	select {
+	case path := <-s.requests:
+		c.Fatalf("unexpected export to %q", path)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *TraceExporterSuite) TestFollowsConfiguredEndpoint(c *gc.C) {
+	e, err := apiserver.NewTraceExporter(&mockAgentConfig{}, s.controllerConfig(""), s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { _ = e.Close() }()
+
+	// Spans are dropped until a collector is configured.
+	s.exportSpan(e)
+	s.assertNotExported(c)
+
+	err = apiserver.UpdateTraceExporter(e, s.controllerConfig(s.server.URL))
+	c.Assert(err, jc.ErrorIsNil)
+	s.exportSpan(e)
+	s.assertExported(c)
+
+	err = apiserver.UpdateTraceExporter(e, s.controllerConfig(""))
+	c.Assert(err, jc.ErrorIsNil)
+	s.exportSpan(e)
+	s.assertNotExported(c)
+}
+
+func (s *TraceExporterSuite) TestInvalidEndpoint(c *gc.C) {
+	e, err := apiserver.NewTraceExporter(&mockAgentConfig{}, s.controllerConfig(s.server.URL), s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { _ = e.Close() }()
+
+	err = apiserver.UpdateTraceExporter(e, s.controllerConfig("ftp://collector"))
+	c.Assert(err, gc.ErrorMatches, `OTLP endpoint "ftp://collector" not valid`)
+
+	// The previous collector is still used.
+	s.exportSpan(e)
+	s.assertExported(c)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/pubsub"
 	"github.com/juju/worker/v2"
 
@@ -20,9 +21,13 @@ import (
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/presence"
+	controllermsg "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/common"
 )
 
+var logger = loggo.GetLogger("juju.worker.apiserver")
+
 // Config is the configuration required for running an API server worker.
 type Config struct {
 	AgentConfig                       agent.Config
@@ -110,14 +115,42 @@ func NewWorker(config Config) (worker.Worker, error) {
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	traceExporter, err := newTraceExporter(config.AgentConfig, controllerConfig, config.Clock)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create trace exporter")
+	}
+	// The collector can be changed in the controller config while the
+	// API server is running.
+	unsubscribe, err := config.Hub.Subscribe(
+		controllermsg.ConfigChanged,
+		func(topic string, data controllermsg.ConfigChangedMessage, err error) {
+			if err != nil {
+				logger.Criticalf("programming error in %s message data: %v", topic, err)
+				return
+			}
+			if err := traceExporter.update(data.Config); err != nil {
+				logger.Errorf("cannot update trace exporter: %v", err)
+			}
+		})
+	if err != nil {
+		_ = traceExporter.Close()
+		return nil, errors.Annotate(err, "cannot subscribe to controller config changes")
+	}
+	closeTraceExporter := func() {
+		unsubscribe()
+		_ = traceExporter.Close()
+	}
+
 	observerFactory, err := newObserverFn(
 		config.AgentConfig,
 		controllerConfig,
 		config.Clock,
 		config.Hub,
 		config.MetricsCollector,
+		traceExporter,
 	)
 	if err != nil {
+		closeTraceExporter()
 		return nil, errors.Annotate(err, "cannot create RPC observer factory")
 	}
 
@@ -144,7 +177,12 @@ func NewWorker(config Config) (worker.Worker, error) {
 		LeaseManager:                  config.LeaseManager,
 		ExecEmbeddedCommand:           config.EmbeddedCommand,
 	}
-	return config.NewServer(serverConfig)
+	w, err := config.NewServer(serverConfig)
+	if err != nil {
+		closeTraceExporter()
+		return nil, errors.Trace(err)
+	}
+	return common.NewCleanupWorker(w, closeTraceExporter), nil
 }
 
 func newServerShim(config apiserver.ServerConfig) (worker.Worker, error) {
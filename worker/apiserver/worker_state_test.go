@@ -112,7 +112,7 @@ func (s *WorkerStateSuite) TestStart(c *gc.C) {
 		Tag:                 s.agentConfig.Tag(),
 		DataDir:             s.agentConfig.DataDir(),
 		LogDir:              s.agentConfig.LogDir(),
-		Hub:                 &s.hub,
+		Hub:                 s.hub,
 		PublicDNSName:       "",
 		AllowModelAccess:    false,
 		LogSinkConfig:       &logSinkConfig,
@@ -33,7 +33,7 @@ type workerFixture struct {
 	authenticator        *mockAuthenticator
 	clock                *testclock.Clock
 	controller           *cache.Controller
-	hub                  pubsub.StructuredHub
+	hub                  *pubsub.StructuredHub
 	mux                  *apiserverhttp.Mux
 	prometheusRegisterer stubPrometheusRegisterer
 	leaseManager         lease.Manager
@@ -55,6 +55,7 @@ func (s *workerFixture) SetUpTest(c *gc.C) {
 	}
 	s.authenticator = &mockAuthenticator{}
 	s.clock = testclock.NewClock(time.Time{})
+	s.hub = pubsub.NewStructuredHub(nil)
 	controller, err := cache.NewController(cache.ControllerConfig{
 		Changes: make(chan interface{}),
 	})
@@ -72,7 +73,7 @@ func (s *workerFixture) SetUpTest(c *gc.C) {
 		Authenticator:                     s.authenticator,
 		Clock:                             s.clock,
 		Controller:                        s.controller,
-		Hub:                               &s.hub,
+		Hub:                               s.hub,
 		Presence:                          presence.New(s.clock),
 		Mux:                               s.mux,
 		MultiwatcherFactory:               s.multiwatcherFactory,
@@ -4,6 +4,9 @@
 package auditconfigupdater
 
 import (
+	"path/filepath"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
@@ -74,11 +77,17 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	}()
 
 	logDir := agent.CurrentConfig().LogDir()
+	bufferDir := filepath.Join(agent.CurrentConfig().DataDir(), "audit-webhook-buffer")
 
 	st := statePool.SystemState()
 
+	// The log file is wrapped in a fanout so that entries can also be
+	// streamed to the remote targets set in controller config.
 	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		return auditlog.NewFanout(
+			auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups),
+			auditlog.NewRemoteOpener(bufferDir, clock.WallClock),
+		)
 	}
 	auditConfig, err := initialConfig(st)
 	if err != nil {
@@ -86,6 +95,9 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	}
 	if auditConfig.Enabled {
 		auditConfig.Target = logFactory(auditConfig)
+		if err := configureRemote(auditConfig.Target, auditConfig.Remote); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	w, err := config.NewWorker(st, auditConfig, logFactory)
@@ -120,12 +132,5 @@ func initialConfig(source ConfigSource) (auditlog.Config, error) {
 	if err != nil {
 		return auditlog.Config{}, errors.Trace(err)
 	}
-	result := auditlog.Config{
-		Enabled:        cfg.AuditingEnabled(),
-		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
-		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
-		MaxBackups:     cfg.AuditLogMaxBackups(),
-		ExcludeMethods: cfg.AuditLogExcludeMethods(),
-	}
-	return result, nil
+	return auditConfigFromController(cfg), nil
 }
@@ -46,6 +46,7 @@ func (s *manifoldSuite) SetUpTest(c *gc.C) {
 
 	s.agent = &mockAgent{}
 	s.agent.conf.logDir = c.MkDir()
+	s.agent.conf.dataDir = c.MkDir()
 
 	s.stateTracker = stubStateTracker{
 		pool: s.StatePool,
@@ -126,11 +127,38 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 		ExcludeMethods: set.NewStrings("This.Method"),
 		MaxSizeMB:      10,
 		MaxBackups:     10,
+		Remote: auditlog.RemoteConfig{
+			WebhookBatchSize:    100,
+			WebhookBufferSizeMB: 100,
+		},
 	})
 
 	c.Assert(args[2], gc.NotNil)
 }
 
+func (s *manifoldSuite) TestStartWithRemoteTargets(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"audit-log-syslog-address": "udp://10.0.0.1:514",
+		"audit-log-webhook-url":    "https://audit.example.com/ingest",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	args := s.stub.Calls()[0].Args
+	auditConfig := args[1].(auditlog.Config)
+	c.Assert(auditConfig.Remote, gc.DeepEquals, auditlog.RemoteConfig{
+		SyslogAddress:       "udp://10.0.0.1:514",
+		WebhookURL:          "https://audit.example.com/ingest",
+		WebhookBatchSize:    100,
+		WebhookBufferSizeMB: 100,
+	})
+	target, ok := auditConfig.Target.(*auditlog.Fanout)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(target.Close(), jc.ErrorIsNil)
+}
+
 func (s *manifoldSuite) TestStartWithAuditingDisabled(c *gc.C) {
 	err := s.State.UpdateControllerConfig(map[string]interface{}{
 		"auditing-enabled": false,
@@ -201,13 +229,18 @@ func (ma *mockAgent) CurrentConfig() agent.Config {
 
 type mockAgentConfig struct {
 	agent.Config
-	logDir string
+	logDir  string
+	dataDir string
 }
 
 func (c *mockAgentConfig) LogDir() string {
 	return c.logDir
 }
 
+func (c *mockAgentConfig) DataDir() string {
+	return c.dataDir
+}
+
 type stubStateTracker struct {
 	testing.Stub
 	pool *state.StatePool
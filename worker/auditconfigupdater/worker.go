@@ -89,13 +89,7 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 	if err != nil {
 		return auditlog.Config{}, errors.Trace(err)
 	}
-	result := auditlog.Config{
-		Enabled:        cfg.AuditingEnabled(),
-		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
-		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
-		MaxBackups:     cfg.AuditLogMaxBackups(),
-		ExcludeMethods: cfg.AuditLogExcludeMethods(),
-	}
+	result := auditConfigFromController(cfg)
 	if result.Enabled && u.current.Target == nil {
 		result.Target = u.logFactory(result)
 	} else {
@@ -104,9 +98,44 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 		// because enabled is false.
 		result.Target = u.current.Target
 	}
+	if result.Enabled {
+		if err := configureRemote(result.Target, result.Remote); err != nil {
+			return auditlog.Config{}, errors.Trace(err)
+		}
+	}
 	return result, nil
 }
 
+// remoteConfigurer is implemented by audit log targets that also
+// stream entries to remote targets, which can be reconfigured while
+// the target is in use.
+type remoteConfigurer interface {
+	Configure(auditlog.RemoteConfig) error
+}
+
+func configureRemote(target auditlog.AuditLog, cfg auditlog.RemoteConfig) error {
+	if configurer, ok := target.(remoteConfigurer); ok {
+		return errors.Trace(configurer.Configure(cfg))
+	}
+	return nil
+}
+
+func auditConfigFromController(cfg controller.Config) auditlog.Config {
+	return auditlog.Config{
+		Enabled:        cfg.AuditingEnabled(),
+		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
+		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
+		MaxBackups:     cfg.AuditLogMaxBackups(),
+		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Remote: auditlog.RemoteConfig{
+			SyslogAddress:       cfg.AuditLogSyslogAddress(),
+			WebhookURL:          cfg.AuditLogWebhookURL(),
+			WebhookBatchSize:    cfg.AuditLogWebhookBatchSize(),
+			WebhookBufferSizeMB: cfg.AuditLogWebhookBufferSizeMB(),
+		},
+	}
+}
+
 func (u *updater) update(newConfig auditlog.Config) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
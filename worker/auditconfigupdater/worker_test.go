@@ -188,6 +188,57 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestReconfiguresRemoteTargets(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	target := &configurableTarget{}
+	initial := auditlog.Config{
+		Enabled: true,
+		Target:  target,
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-webhook-url"] = "https://audit.example.com/ingest"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Remote.WebhookURL != ""
+	})
+	c.Assert(newConfig.Target, gc.Equals, auditlog.AuditLog(target))
+	c.Assert(target.configured(), jc.DeepEquals, []auditlog.RemoteConfig{{
+		WebhookURL:          "https://audit.example.com/ingest",
+		WebhookBatchSize:    100,
+		WebhookBufferSizeMB: 100,
+	}})
+}
+
+type configurableTarget struct {
+	apitesting.FakeAuditLog
+	mu      sync.Mutex
+	configs []auditlog.RemoteConfig
+}
+
+func (t *configurableTarget) Configure(cfg auditlog.RemoteConfig) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.configs = append(t.configs, cfg)
+	return nil
+}
+
+func (t *configurableTarget) configured() []auditlog.RemoteConfig {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.configs
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
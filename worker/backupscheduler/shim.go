@@ -0,0 +1,143 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// haReadyTimeout is how long, in seconds, a scheduled backup waits for
+// the mongo replicaset to be ready, as with backups made on demand.
+const haReadyTimeout = 60
+
+// NewStateBackups returns Backups that back up the controller state
+// to the machine running the given agent, and store the archives in
+// the controller.
+func NewStateBackups(st *state.State, agentConfig agent.Config) (Backups, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &stateBackups{
+		db:          backupsDB{State: st, model: model},
+		st:          st,
+		agentConfig: agentConfig,
+	}, nil
+}
+
+// backupsDB implements backups.DB.
+type backupsDB struct {
+	*state.State
+	model *state.Model
+}
+
+// ModelTag is part of the backups.DB interface.
+func (db backupsDB) ModelTag() names.ModelTag {
+	return db.model.ModelTag()
+}
+
+// ModelConfig is part of the backups.DB interface.
+func (db backupsDB) ModelConfig() (*config.Config, error) {
+	return db.model.ModelConfig()
+}
+
+type stateBackups struct {
+	db          backupsDB
+	st          *state.State
+	agentConfig agent.Config
+}
+
+// Create is part of the Backups interface. It follows the Backups
+// facade's Create method.
+func (b *stateBackups) Create(notes string) (*backups.Metadata, error) {
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+	if err := replicaset.WaitUntilReady(session, haReadyTimeout); err != nil {
+		return nil, errors.Annotate(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return nil, errors.New("getting mongo info: not a controller agent")
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	machineID := b.agentConfig.Tag().Id()
+	machine, err := b.st.Machine(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.db, machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = notes
+	meta.Controller.MachineID = machineID
+	instanceID, err := machine.InstanceId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Controller.MachineInstanceID = string(instanceID)
+	nodes, err := b.st.ControllerNodes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Controller.HANodes = int64(len(nodes))
+
+	modelConfig, err := b.db.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+
+	controllerBackups, closer, err := backups.NewControllerBackups(b.db)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer.Close()
+	if _, err := controllerBackups.Create(meta, &paths, dbInfo, true, true); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]*backups.Metadata, error) {
+	controllerBackups, closer, err := backups.NewControllerBackups(b.db)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer.Close()
+	metas, err := controllerBackups.List()
+	return metas, errors.Trace(err)
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	controllerBackups, closer, err := backups.NewControllerBackups(b.db)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer.Close()
+	return errors.Trace(controllerBackups.Remove(id))
+}
@@ -0,0 +1,230 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// Logger defines the logging methods used by the worker.
+type Logger interface {
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Backend provides the controller config that holds the backup
+// schedule, and records the outcome of scheduled backups. (Primary
+// implementation is State.)
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	WatchControllerConfig() state.NotifyWatcher
+	RecordBackupStatus(status.StatusInfo) error
+}
+
+// Backups creates and removes the controller's stored backups.
+type Backups interface {
+	// Create creates and stores a backup of the controller, with
+	// the given notes, and returns its metadata.
+	Create(notes string) (*backups.Metadata, error)
+
+	// List returns the metadata of all the stored backups.
+	List() ([]*backups.Metadata, error)
+
+	// Remove deletes the stored backup with the given ID.
+	Remove(id string) error
+}
+
+// Config holds the dependencies of a backup scheduler worker.
+type Config struct {
+	Backend Backend
+	Backups Backups
+	Clock   clock.Clock
+	Logger  Logger
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that creates backups of the controller on
+// the schedule set in controller config, and removes the scheduled
+// backups that the configured retention policy no longer keeps.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &scheduler{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type scheduler struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *scheduler) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *scheduler) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *scheduler) loop() error {
+	watcher := w.config.Backend.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		currentSpec string
+		schedule    *cron.Schedule
+		policy      backups.RetentionPolicy
+		timer       <-chan time.Time
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			cfg, err := w.config.Backend.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "getting controller config")
+			}
+			if spec := cfg.BackupSchedule(); spec != currentSpec {
+				if schedule, err = scheduleFromSpec(spec); err != nil {
+					return errors.Trace(err)
+				}
+				if schedule == nil {
+					w.config.Logger.Infof("scheduled backups disabled")
+				} else {
+					w.config.Logger.Infof("backup schedule set to %q", spec)
+				}
+				currentSpec = spec
+			}
+			policy = backups.RetentionPolicy{
+				KeepDaily:  cfg.BackupKeepDaily(),
+				KeepWeekly: cfg.BackupKeepWeekly(),
+			}
+		case <-timer:
+			w.backup(policy)
+		}
+
+		timer = nil
+		if schedule != nil {
+			now := w.config.Clock.Now()
+			timer = w.config.Clock.After(schedule.Next(now).Sub(now))
+		}
+	}
+}
+
+// scheduleFromSpec returns the backup schedule set in controller
+// config, or nil if scheduled backups are disabled.
+func scheduleFromSpec(spec string) (*cron.Schedule, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	schedule, err := cron.Parse(spec)
+	return schedule, errors.Annotate(err, "parsing backup schedule")
+}
+
+// backup creates a scheduled backup, records the outcome, and then
+// removes the scheduled backups that have expired. Failures are
+// recorded rather than stopping the worker, so the next scheduled
+// backup is still attempted.
+func (w *scheduler) backup(policy backups.RetentionPolicy) {
+	meta, err := w.config.Backups.Create(backups.ScheduledNotes)
+	if err != nil {
+		w.config.Logger.Errorf("scheduled backup failed: %v", err)
+		w.recordStatus(status.Error, err.Error(), nil)
+		return
+	}
+	w.config.Logger.Infof("created scheduled backup %q", meta.ID())
+	w.recordStatus(status.Available, "created backup", map[string]interface{}{
+		"id": meta.ID(),
+	})
+
+	if err := w.prune(policy); err != nil {
+		w.config.Logger.Errorf("removing expired backups: %v", err)
+	}
+}
+
+func (w *scheduler) recordStatus(s status.Status, message string, data map[string]interface{}) {
+	now := w.config.Clock.Now()
+	err := w.config.Backend.RecordBackupStatus(status.StatusInfo{
+		Status:  s,
+		Message: message,
+		Data:    data,
+		Since:   &now,
+	})
+	if err != nil {
+		w.config.Logger.Warningf("recording scheduled backup status: %v", err)
+	}
+}
+
+// prune removes the scheduled backups not kept by the retention
+// policy. Backups created on demand are never removed.
+func (w *scheduler) prune(policy backups.RetentionPolicy) error {
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return errors.Annotate(err, "listing backups")
+	}
+	var scheduled []*backups.Metadata
+	for _, meta := range all {
+		if meta.Notes == backups.ScheduledNotes {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	var failed []string
+	for _, meta := range policy.Expired(scheduled) {
+		if err := w.config.Backups.Remove(meta.ID()); err != nil {
+			w.config.Logger.Warningf("removing backup %q: %v", meta.ID(), err)
+			failed = append(failed, meta.ID())
+			continue
+		}
+		w.config.Logger.Infof("removed expired backup %q", meta.ID())
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("could not remove %d backups", len(failed))
+	}
+	return nil
+}
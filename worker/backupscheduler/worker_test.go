@@ -0,0 +1,265 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock         *testclock.Clock
+	configChanged chan struct{}
+	backend       *fakeBackend
+	backups       *fakeBackups
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2021, 3, 1, 2, 0, 0, 0, time.UTC))
+	s.configChanged = make(chan struct{}, 1)
+	s.backend = &fakeBackend{
+		watcher:  watchertest.NewNotifyWatcher(s.configChanged),
+		statuses: make(chan status.StatusInfo, 10),
+	}
+	s.backups = &fakeBackups{clock: s.clock}
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C, attrs map[string]interface{}) worker.Worker {
+	s.setConfig(c, attrs)
+	w, err := backupscheduler.NewWorker(backupscheduler.Config{
+		Backend: s.backend,
+		Backups: s.backups,
+		Clock:   s.clock,
+		Logger:  loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) setConfig(c *gc.C, attrs map[string]interface{}) {
+	cfg, err := controller.NewConfig(coretesting.ControllerTag.Id(), coretesting.CACert, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.setConfig(cfg)
+	s.configChanged <- struct{}{}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := backupscheduler.NewWorker(backupscheduler.Config{
+		Backups: s.backups,
+		Clock:   s.clock,
+		Logger:  loggo.GetLogger("test"),
+	})
+	c.Check(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestCreatesBackupOnSchedule(c *gc.C) {
+	w := s.newWorker(c, map[string]interface{}{
+		"backup-schedule": "30 2 * * *",
+	})
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.clock.WaitAdvance(29*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertNoStatus(c)
+
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	info := s.nextStatus(c)
+	c.Check(info.Status, gc.Equals, status.Available)
+	c.Check(info.Message, gc.Equals, "created backup")
+	c.Check(info.Data, jc.DeepEquals, map[string]interface{}{"id": "2021-03-01T02:30:00Z"})
+	c.Check(info.Since.Equal(time.Date(2021, 3, 1, 2, 30, 0, 0, time.UTC)), jc.IsTrue)
+	s.backups.CheckCall(c, 0, "Create", backups.ScheduledNotes)
+
+	// The next backup is a day later.
+	c.Assert(s.clock.WaitAdvance(24*time.Hour-time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertNoStatus(c)
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	info = s.nextStatus(c)
+	c.Check(info.Data, jc.DeepEquals, map[string]interface{}{"id": "2021-03-02T02:30:00Z"})
+}
+
+func (s *WorkerSuite) TestRemovesExpiredScheduledBackups(c *gc.C) {
+	s.backups.metas = []*backups.Metadata{
+		backupAt("2021-02-26T02:30:00Z", backups.ScheduledNotes),
+		backupAt("2021-02-27T02:30:00Z", backups.ScheduledNotes),
+		backupAt("2021-02-28T02:30:00Z", backups.ScheduledNotes),
+		backupAt("2021-02-25T12:00:00Z", "before upgrade"),
+	}
+	w := s.newWorker(c, map[string]interface{}{
+		"backup-schedule":    "30 2 * * *",
+		"backup-keep-daily":  2,
+		"backup-keep-weekly": 0,
+	})
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.nextStatus(c)
+	// Wait for the next backup to be scheduled.
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+
+	s.backups.CheckCallNames(c, "Create", "List", "Remove", "Remove")
+	s.backups.CheckCall(c, 2, "Remove", "2021-02-27T02:30:00Z")
+	s.backups.CheckCall(c, 3, "Remove", "2021-02-26T02:30:00Z")
+}
+
+func (s *WorkerSuite) TestRecordsFailure(c *gc.C) {
+	s.backups.SetErrors(errors.New("while creating backup archive: boom"))
+	w := s.newWorker(c, map[string]interface{}{
+		"backup-schedule": "@hourly",
+	})
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1), jc.ErrorIsNil)
+	info := s.nextStatus(c)
+	c.Check(info.Status, gc.Equals, status.Error)
+	c.Check(info.Message, gc.Equals, "while creating backup archive: boom")
+
+	// The failure doesn't stop the next backup.
+	c.Assert(s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1), jc.ErrorIsNil)
+	info = s.nextStatus(c)
+	c.Check(info.Status, gc.Equals, status.Available)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestScheduleDisabled(c *gc.C) {
+	w := s.newWorker(c, nil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.clock.WaitAdvance(time.Hour, coretesting.ShortWait, 1), gc.NotNil)
+	s.backups.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestScheduleChanged(c *gc.C) {
+	w := s.newWorker(c, nil)
+	defer workertest.CleanKill(c, w)
+
+	s.setConfig(c, map[string]interface{}{
+		"backup-schedule": "0 3 * * *",
+	})
+	c.Assert(s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1), jc.ErrorIsNil)
+	info := s.nextStatus(c)
+	c.Check(info.Data, jc.DeepEquals, map[string]interface{}{"id": "2021-03-01T03:00:00Z"})
+
+	s.setConfig(c, nil)
+	s.backend.waitForConfigRead(c, 3)
+	s.clock.Advance(24 * time.Hour)
+	s.assertNoStatus(c)
+}
+
+func (s *WorkerSuite) nextStatus(c *gc.C) status.StatusInfo {
+	select {
+	case info := <-s.backend.statuses:
+		return info
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for backup status")
+	}
+	return status.StatusInfo{}
+}
+
+func (s *WorkerSuite) assertNoStatus(c *gc.C) {
+	select {
+	case info := <-s.backend.statuses:
+		c.Fatalf("unexpected backup status %#v", info)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func backupAt(started, notes string) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.Started, _ = time.Parse(time.RFC3339, started)
+	meta.Notes = notes
+	meta.SetID(started)
+	return meta
+}
+
+type fakeBackend struct {
+	mu          sync.Mutex
+	config      controller.Config
+	configReads int
+	watcher     *watchertest.NotifyWatcher
+	statuses    chan status.StatusInfo
+}
+
+func (b *fakeBackend) setConfig(cfg controller.Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.config = cfg
+}
+
+func (b *fakeBackend) waitForConfigRead(c *gc.C, n int) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		b.mu.Lock()
+		reads := b.configReads
+		b.mu.Unlock()
+		if reads >= n {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for controller config to be read")
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.configReads++
+	return b.config, nil
+}
+
+func (b *fakeBackend) WatchControllerConfig() state.NotifyWatcher {
+	return b.watcher
+}
+
+func (b *fakeBackend) RecordBackupStatus(info status.StatusInfo) error {
+	b.statuses <- info
+	return nil
+}
+
+type fakeBackups struct {
+	testing.Stub
+	clock *testclock.Clock
+	metas []*backups.Metadata
+}
+
+func (b *fakeBackups) Create(notes string) (*backups.Metadata, error) {
+	b.AddCall("Create", notes)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	meta := backupAt(b.clock.Now().Format(time.RFC3339), notes)
+	b.metas = append(b.metas, meta)
+	return meta, nil
+}
+
+func (b *fakeBackups) List() ([]*backups.Metadata, error) {
+	b.AddCall("List")
+	return b.metas, b.NextErr()
+}
+
+func (b *fakeBackups) Remove(id string) error {
+	b.AddCall("Remove", id)
+	return b.NextErr()
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
@@ -73,6 +74,10 @@ type ManifoldConfig struct {
 	LoadOperatorInfo func(paths Paths) (*caas.OperatorInfo, error)
 
 	NewContainerStartWatcherClient func(Client) ContainerStartWatcher
+
+	// PrometheusRegisterer, if set, is used to register the metrics
+	// of the units' uniters.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 func (config ManifoldConfig) Validate() error {
@@ -238,6 +243,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				HookRetryStrategy:    hookRetryStrategy,
 				TranslateResolverErr: config.TranslateResolverErr,
 				Logger:               wCfg.Logger.Child("uniter"),
+				PrometheusRegisterer: config.PrometheusRegisterer,
 			}
 			wCfg.UniterParams.SocketConfig, err = socketConfig(operatorInfo)
 			if err != nil {
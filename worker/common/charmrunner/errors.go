@@ -5,6 +5,7 @@ package charmrunner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -47,3 +48,29 @@ func IsMissingHookError(err error) bool {
 func NewMissingHookError(hookName string) error {
 	return &missingHookError{hookName}
 }
+
+// ErrHookCancelled is returned when a running hook was interrupted
+// because its cancellation was requested.
+var ErrHookCancelled = errors.New("hook cancelled")
+
+type hookTimedOutError struct {
+	hookName string
+	timeout  time.Duration
+}
+
+func (e *hookTimedOutError) Error() string {
+	return fmt.Sprintf("%s timed out after %v", e.hookName, e.timeout)
+}
+
+// IsHookTimedOutError returns whether err was returned because a hook
+// ran for longer than the hook timeout.
+func IsHookTimedOutError(err error) bool {
+	_, ok := err.(*hookTimedOutError)
+	return ok
+}
+
+// NewHookTimedOutError returns an error indicating that the named hook
+// was killed after running for longer than timeout.
+func NewHookTimedOutError(hookName string, timeout time.Duration) error {
+	return &hookTimedOutError{hookName, timeout}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
@@ -34,6 +35,10 @@ type ManifoldConfig struct {
 	UnitEngineConfig func() dependency.EngineConfig
 	SetupLogging     func(*loggo.Context, agent.Config)
 	NewDeployContext func(ContextConfig) (Context, error)
+
+	// PrometheusRegisterer, if set, is used by the deployed units'
+	// workers to register Prometheus metric collectors.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // TODO: add ManifoleConfig.Validate.
@@ -68,6 +73,8 @@ func (config ManifoldConfig) newWorker(a agent.Agent, apiCaller base.APICaller)
 		UnitEngineConfig: config.UnitEngineConfig,
 		SetupLogging:     config.SetupLogging,
 		UnitManifolds:    UnitManifolds,
+
+		PrometheusRegisterer: config.PrometheusRegisterer,
 	}
 
 	context, err := config.NewDeployContext(contextConfig)
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
 	"github.com/kr/pretty"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/juju/juju/agent"
 	agenterrors "github.com/juju/juju/cmd/jujud/agent/errors"
@@ -81,6 +82,10 @@ type ContextConfig struct {
 	SetupLogging             func(*loggo.Context, agent.Config)
 	UnitManifolds            func(config UnitManifoldsConfig) dependency.Manifolds
 	RebootMonitorStatePurger RebootMonitorStatePurger
+
+	// PrometheusRegisterer, if set, is used by the units' workers to
+	// register Prometheus metric collectors.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // Validate ensures all the required values are set.
@@ -128,6 +133,8 @@ func NewNestedContext(config ContextConfig) (Context, error) {
 			UnitEngineConfig: config.UnitEngineConfig,
 			UnitManifolds:    config.UnitManifolds,
 			SetupLogging:     config.SetupLogging,
+
+			PrometheusRegisterer: config.PrometheusRegisterer,
 		},
 
 		units:  make(map[string]*UnitAgent),
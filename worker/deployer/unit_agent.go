@@ -17,6 +17,7 @@ import (
 	"github.com/juju/version"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/juju/juju/agent"
@@ -44,6 +45,8 @@ type UnitAgent struct {
 	unitEngineConfig func() dependency.EngineConfig
 	unitManifolds    func(UnitManifoldsConfig) dependency.Manifolds
 
+	prometheusRegisterer prometheus.Registerer
+
 	// Able to disable running units.
 	workerRunning bool
 }
@@ -58,6 +61,10 @@ type UnitAgentConfig struct {
 	UnitEngineConfig func() dependency.EngineConfig
 	UnitManifolds    func(UnitManifoldsConfig) dependency.Manifolds
 	SetupLogging     func(*loggo.Context, agent.Config)
+
+	// PrometheusRegisterer, if set, is used by the unit's workers to
+	// register Prometheus metric collectors.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // Validate ensures all the required values are set.
@@ -139,6 +146,8 @@ func NewUnitAgent(config UnitAgentConfig) (*UnitAgent, error) {
 		setupLogging:     config.SetupLogging,
 		unitEngineConfig: config.UnitEngineConfig,
 		unitManifolds:    config.UnitManifolds,
+
+		prometheusRegisterer: config.PrometheusRegisterer,
 	}
 	// Update the 'upgradedToVersion' in the agent.conf file if it is
 	// different to the current version.
@@ -184,15 +193,16 @@ func (a *UnitAgent) start() (worker.Worker, error) {
 	// construct unit agent manifold
 	a.logger.Tracef("creating unit manifolds for %q", a.name)
 	manifolds := a.unitManifolds(UnitManifoldsConfig{
-		LoggingContext:      loggingContext,
-		Agent:               a,
-		LogSource:           bufferedLogger.Logs(),
-		LeadershipGuarantee: 30 * time.Second,
-		AgentConfigChanged:  a.configChangedVal,
-		ValidateMigration:   a.validateMigration,
-		UpdateLoggerConfig:  updateAgentConfLogging,
-		MachineLock:         machineLock,
-		Clock:               a.clock,
+		LoggingContext:       loggingContext,
+		Agent:                a,
+		LogSource:            bufferedLogger.Logs(),
+		LeadershipGuarantee:  30 * time.Second,
+		AgentConfigChanged:   a.configChangedVal,
+		ValidateMigration:    a.validateMigration,
+		UpdateLoggerConfig:   updateAgentConfLogging,
+		MachineLock:          machineLock,
+		Clock:                a.clock,
+		PrometheusRegisterer: a.prometheusRegisterer,
 	})
 	depEngineConfig := a.unitEngineConfig()
 	// TODO: tweak IsFatal error func, maybe?
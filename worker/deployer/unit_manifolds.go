@@ -11,6 +11,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/v2/voyeur"
 	"github.com/juju/worker/v2/dependency"
+	"github.com/prometheus/client_golang/prometheus"
 
 	coreagent "github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
@@ -77,6 +78,10 @@ type UnitManifoldsConfig struct {
 
 	// Clock supplies timekeeping services to various workers.
 	Clock clock.Clock
+
+	// PrometheusRegisterer is a prometheus.Registerer that may be used
+	// by workers to register Prometheus metric collectors.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // UnitManifolds returns a set of co-configured manifolds covering the various
@@ -229,6 +234,7 @@ func UnitManifolds(config UnitManifoldsConfig) dependency.Manifolds {
 			HookRetryStrategyName: hookRetryStrategyName,
 			TranslateResolverErr:  uniter.TranslateFortressErrors,
 			Logger:                config.LoggingContext.GetLogger("juju.worker.uniter"),
+			PrometheusRegisterer:  config.PrometheusRegisterer,
 		})),
 
 		// TODO (mattyw) should be added to machine agent.
@@ -0,0 +1,27 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/jsonhttp"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenHTTP returns a sink that posts batches of log records to an
+// HTTP endpoint, encoded as plain JSON or as OTLP log export requests.
+func OpenHTTP(cfg *jsonhttp.RawConfig) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := jsonhttp.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sink := &logforwarder.LogSink{
+		SendCloser: client,
+	}
+	return sink, nil
+}
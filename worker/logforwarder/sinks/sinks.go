@@ -0,0 +1,25 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/target"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// Open returns a sink used to receive log messages to be forwarded,
+// of the kind selected by the config's type.
+func Open(cfg *target.RawConfig) (*logforwarder.LogSink, error) {
+	switch cfg.Type {
+	case target.TypeSyslog:
+		sink, err := OpenSyslog(&cfg.Syslog)
+		return sink, errors.Trace(err)
+	case target.TypeHTTP, target.TypeOTLP:
+		sink, err := OpenHTTP(&cfg.HTTP)
+		return sink, errors.Trace(err)
+	}
+	return nil, errors.NotSupportedf("log forwarding type %q", string(cfg.Type))
+}
@@ -0,0 +1,54 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/jsonhttp"
+	"github.com/juju/juju/logfwd/target"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type SinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinksSuite{})
+
+func (s *SinksSuite) TestOpenHTTP(c *gc.C) {
+	sink, err := sinks.Open(&target.RawConfig{
+		Enabled: true,
+		Type:    target.TypeOTLP,
+		HTTP: jsonhttp.RawConfig{
+			Enabled: true,
+			Format:  jsonhttp.FormatOTLP,
+			URL:     "http://collector:4318/v1/logs",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client, ok := sink.SendCloser.(*jsonhttp.Client)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(client.URL, gc.Equals, "http://collector:4318/v1/logs")
+	c.Check(client.Format, gc.Equals, jsonhttp.FormatOTLP)
+}
+
+func (s *SinksSuite) TestOpenHTTPNotEnabled(c *gc.C) {
+	_, err := sinks.Open(&target.RawConfig{
+		Type: target.TypeHTTP,
+	})
+	c.Assert(err, gc.ErrorMatches, "log forwarding not enabled")
+}
+
+func (s *SinksSuite) TestOpenUnknownType(c *gc.C) {
+	_, err := sinks.Open(&target.RawConfig{
+		Enabled: true,
+		Type:    "carrier-pigeon",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
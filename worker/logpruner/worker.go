@@ -0,0 +1,148 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logpruner
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/logpruner"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
+)
+
+// Facade represents an API that implements retained log pruning.
+type Facade interface {
+	Prune(map[loggo.Level]time.Duration, int) error
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
+}
+
+// NewClient returns a new retained log pruner facade.
+func NewClient(caller base.APICaller) Facade {
+	return logpruner.NewClient(caller)
+}
+
+// Logger defines the methods used by the log pruner worker for logging.
+type Logger interface {
+	Infof(string, ...interface{})
+}
+
+// Config holds all necessary attributes to start a log pruner worker.
+type Config struct {
+	Facade        Facade
+	PruneInterval time.Duration
+	Clock         clock.Clock
+	Logger        Logger
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.PruneInterval <= 0 {
+		return errors.NotValidf("non-positive PruneInterval")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker prunes the model's retained log records at regular intervals,
+// according to the model's log retention policy and maximum retained
+// log size.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New creates a new log pruner.
+func New(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: conf}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	modelConfigWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		retention map[loggo.Level]time.Duration
+		maxSizeMB int
+		timer     clock.Timer
+		timerCh   <-chan time.Time
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model configuration watcher closed")
+			}
+			modelConfig, err := w.config.Facade.ModelConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load model configuration")
+			}
+			newRetention := modelConfig.LogRetention()
+			if !reflect.DeepEqual(newRetention, retention) {
+				w.config.Logger.Infof("log retention: %v for %s (%s)",
+					newRetention, modelConfig.Name(), modelConfig.UUID())
+				retention = newRetention
+			}
+			newMaxSizeMB := int(modelConfig.MaxRetainedLogSizeMB())
+			if newMaxSizeMB != maxSizeMB {
+				w.config.Logger.Infof("max retained log size: %dMB for %s (%s)",
+					newMaxSizeMB, modelConfig.Name(), modelConfig.UUID())
+				maxSizeMB = newMaxSizeMB
+			}
+			// The initial event must be received before pruning,
+			// so that the model's policy is known.
+			if timer == nil {
+				timer = w.config.Clock.NewTimer(w.config.PruneInterval)
+				timerCh = timer.Chan()
+			}
+		case <-timerCh:
+			if err := w.config.Facade.Prune(retention, maxSizeMB); err != nil {
+				return errors.Trace(err)
+			}
+			timer.Reset(w.config.PruneInterval)
+		}
+	}
+}
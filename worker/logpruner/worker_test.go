@@ -0,0 +1,164 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logpruner_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logpruner"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	facade *fakeFacade
+	clock  *testclock.Clock
+	config logpruner.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	attrs := coretesting.FakeConfig()
+	attrs["log-retention"] = "ERROR=24h;WARNING=1h"
+	cfg, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.facade = &fakeFacade{
+		pruned:      make(chan pruneCall, 1),
+		changes:     make(chan struct{}, 1),
+		modelConfig: cfg,
+	}
+	s.clock = testclock.NewClock(time.Time{})
+	s.config = logpruner.Config{
+		Facade:        s.facade,
+		PruneInterval: time.Minute,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := logpruner.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.facade.changes <- struct{}{}
+	return w
+}
+
+func (s *WorkerSuite) assertPruned(c *gc.C, expect map[loggo.Level]time.Duration, expectMaxSizeMB int) {
+	// NewTimer/Reset will have been called with the PruneInterval.
+	s.clock.WaitAdvance(time.Minute-time.Nanosecond, coretesting.LongWait, 1)
+	select {
+	case <-s.facade.pruned:
+		c.Fatal("unexpected call to Prune")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.clock.Advance(time.Nanosecond)
+	select {
+	case call := <-s.facade.pruned:
+		c.Assert(call.retention, jc.DeepEquals, expect)
+		c.Assert(call.maxSizeMB, gc.Equals, expectMaxSizeMB)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for call to Prune")
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.PruneInterval = 0
+	_, err := logpruner.New(s.config)
+	c.Check(err, gc.ErrorMatches, "non-positive PruneInterval not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	s.config.Facade = nil
+	_, err = logpruner.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestPrunes(c *gc.C) {
+	s.startWorker(c)
+	s.assertPruned(c, map[loggo.Level]time.Duration{
+		loggo.ERROR:   24 * time.Hour,
+		loggo.WARNING: time.Hour,
+	}, 1024)
+	s.assertPruned(c, map[loggo.Level]time.Duration{
+		loggo.ERROR:   24 * time.Hour,
+		loggo.WARNING: time.Hour,
+	}, 1024)
+}
+
+func (s *WorkerSuite) TestModelConfigChange(c *gc.C) {
+	s.startWorker(c)
+	s.assertPruned(c, map[loggo.Level]time.Duration{
+		loggo.ERROR:   24 * time.Hour,
+		loggo.WARNING: time.Hour,
+	}, 1024)
+
+	var err error
+	s.facade.modelConfig, err = s.facade.modelConfig.Apply(map[string]interface{}{
+		"log-retention":         "CRITICAL=48h",
+		"max-retained-log-size": "100M",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.changes <- struct{}{}
+
+	s.assertPruned(c, map[loggo.Level]time.Duration{
+		loggo.CRITICAL: 48 * time.Hour,
+	}, 100)
+}
+
+func (s *WorkerSuite) TestPruneError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w := s.startWorker(c)
+	s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	<-s.facade.pruned
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type pruneCall struct {
+	retention map[loggo.Level]time.Duration
+	maxSizeMB int
+}
+
+type fakeFacade struct {
+	pruned      chan pruneCall
+	changes     chan struct{}
+	modelConfig *config.Config
+	err         error
+}
+
+// Prune implements logpruner.Facade.
+func (f *fakeFacade) Prune(retention map[loggo.Level]time.Duration, maxSizeMB int) error {
+	select {
+	case f.pruned <- pruneCall{retention, maxSizeMB}:
+	case <-time.After(coretesting.LongWait):
+		return errors.New("timed out waiting for facade call Prune to run")
+	}
+	return f.err
+}
+
+// WatchForModelConfigChanges implements logpruner.Facade.
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+// ModelConfig implements logpruner.Facade.
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	return f.modelConfig, nil
+}
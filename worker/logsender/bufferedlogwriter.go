@@ -12,6 +12,8 @@ import (
 	"github.com/juju/collections/deque"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+
+	corelogger "github.com/juju/juju/core/logger"
 )
 
 // LogRecord represents a log message in an agent which is to be
@@ -22,6 +24,7 @@ type LogRecord struct {
 	Location string // e.g. "foo.go:42"
 	Level    loggo.Level
 	Message  string
+	Labels   []string
 
 	// Number of messages dropped after this one due to buffer limit.
 	DroppedAfter int
@@ -153,6 +156,7 @@ func (w *BufferedLogWriter) Write(entry loggo.Entry) {
 		Location: fmt.Sprintf("%s:%d", filepath.Base(entry.Filename), entry.Line),
 		Level:    entry.Level,
 		Message:  entry.Message,
+		Labels:   corelogger.ModuleLabels(entry.Module),
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	corelogger "github.com/juju/juju/core/logger"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/logsender/logsendertest"
@@ -167,6 +168,27 @@ func (s *bufferedLogWriterSuite) TestInstallBufferedLogWriter(c *gc.C) {
 	}
 }
 
+func (s *bufferedLogWriterSuite) TestLabels(c *gc.C) {
+	context := loggo.NewContext(loggo.INFO)
+	bufferedLogger, err := logsender.InstallBufferedLogWriter(context, 10)
+	c.Assert(err, jc.ErrorIsNil)
+	defer bufferedLogger.Close()
+
+	corelogger.GetLoggerWithLabels("juju.test.labelled", corelogger.HTTP)
+	context.GetLogger("juju.test.labelled.request").Infof("labelled")
+	context.GetLogger("juju.test.unlabelled").Infof("unlabelled")
+
+	logsCh := bufferedLogger.Logs()
+	for _, labels := range [][]string{{"http"}, nil} {
+		select {
+		case rec := <-logsCh:
+			c.Assert(rec.Labels, jc.DeepEquals, labels)
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out waiting for logs")
+		}
+	}
+}
+
 func (s *bufferedLogWriterSuite) TestUninstallBufferedLogWriter(c *gc.C) {
 	_, err := logsender.InstallBufferedLogWriter(loggo.DefaultContext(), 10)
 	c.Assert(err, jc.ErrorIsNil)
@@ -16,6 +16,7 @@ import (
 
 	"github.com/juju/juju/api"
 	apilogsender "github.com/juju/juju/api/logsender"
+	corelogger "github.com/juju/juju/core/logger"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
@@ -121,6 +122,33 @@ func (s *workerSuite) TestLogSending(c *gc.C) {
 	}
 }
 
+func (s *workerSuite) TestLogSendingLabels(c *gc.C) {
+	// Log through loggo, as the agents do.
+	context := loggo.NewContext(loggo.INFO)
+	bufferedLogger, err := logsender.InstallBufferedLogWriter(context, 10)
+	c.Assert(err, jc.ErrorIsNil)
+	defer bufferedLogger.Close()
+
+	worker := logsender.New(bufferedLogger.Logs(), s.logSenderAPI())
+	defer func() {
+		worker.Kill()
+		c.Check(worker.Wait(), jc.ErrorIsNil)
+	}()
+	corelogger.GetLoggerWithLabels("juju.test.logsender", corelogger.HTTP)
+	context.GetLogger("juju.test.logsender").Infof("served")
+
+	var doc bson.M
+	logsColl := s.logCollection()
+	for a := testing.LongAttempt.Start(); a.Next(); {
+		err := logsColl.Find(bson.M{"m": "juju.test.logsender", "x": "served"}).One(&doc)
+		if err == nil {
+			break
+		}
+		c.Assert(err, gc.Equals, mgo.ErrNotFound)
+	}
+	c.Assert(doc["c"], jc.DeepEquals, []interface{}{"http"})
+}
+
 func (s *workerSuite) logCollection() *mgo.Collection {
 	return s.State.MongoSession().DB("logs").C("logs." + s.State.ModelUUID())
 }
@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"math/rand"
 	"path"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/timers"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -108,6 +110,15 @@ func (ctx *limitedContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) ResetExecutionSetUnitStatus() {}
 
+// HookTimeout implements runner.Context.
+func (ctx *limitedContext) HookTimeout() time.Duration { return 0 }
+
+// HookCancel implements runner.Context.
+func (ctx *limitedContext) HookCancel() <-chan struct{} { return nil }
+
+// TimerChanges implements runner.Context.
+func (ctx *limitedContext) TimerChanges() map[string]*timers.Timer { return nil }
+
 // Id implements runner.Context.
 func (ctx *limitedContext) Id() string { return ctx.id }
 
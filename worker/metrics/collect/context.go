@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/timers"
 	"github.com/juju/juju/worker/metrics/spool"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -117,6 +118,15 @@ func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
 
+// HookTimeout implements runner.Context.
+func (ctx *hookContext) HookTimeout() time.Duration { return 0 }
+
+// HookCancel implements runner.Context.
+func (ctx *hookContext) HookCancel() <-chan struct{} { return nil }
+
+// TimerChanges implements runner.Context.
+func (ctx *hookContext) TimerChanges() map[string]*timers.Timer { return nil }
+
 // Id implements runner.Context.
 func (ctx *hookContext) Id() string { return ctx.id }
 
@@ -888,6 +888,7 @@ func (s *Suite) TestLogTransferSendsRecords(c *gc.C) {
 			Module:    "this one",
 			Location:  "nearby",
 			Message:   "ham shank",
+			Labels:    []string{"http"},
 		},
 	}
 	s.facade.logMessages = func(d chan<- common.LogMessage) {
@@ -919,6 +920,7 @@ func (s *Suite) TestLogTransferSendsRecords(c *gc.C) {
 			Level:    "warning",
 			Message:  "ham shank",
 			Entity:   "the mules",
+			Labels:   []string{"http"},
 		},
 	})
 	c.Assert(s.connection.logStream.closeCount, gc.Equals, 1)
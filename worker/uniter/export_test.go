@@ -0,0 +1,29 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+// NewMetrics returns the uniter metrics for the named unit.
+func NewMetrics(unitName string) prometheus.Collector {
+	return newMetrics(unitName)
+}
+
+// NewMeteredFactory returns a factory whose hook and action operations
+// record their execution in the supplied metrics.
+func NewMeteredFactory(factory operation.Factory, m prometheus.Collector, clock clock.Clock) operation.Factory {
+	return &meteredFactory{Factory: factory, metrics: m.(*metrics), clock: clock}
+}
+
+// NewMeteredResolver returns a resolver that counts its calls in the
+// supplied metrics.
+func NewMeteredResolver(r resolver.Resolver, m prometheus.Collector) resolver.Resolver {
+	return &meteredResolver{Resolver: r, iterations: m.(*metrics).resolverLoops}
+}
@@ -16,8 +16,22 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// SecretChanged is run when a secret read by the unit has a new revision.
+	SecretChanged hooks.Kind = "secret-changed"
+	// SecretRotate is run on the leader when a secret owned by the
+	// application is due to be rotated.
+	SecretRotate hooks.Kind = "secret-rotate"
+
+	// TimerFired is run when a timer scheduled by the charm is due.
+	TimerFired hooks.Kind = "timer-fired"
 )
 
+// IsSecret returns whether the hook kind is a secret hook.
+func IsSecret(kind hooks.Kind) bool {
+	return kind == SecretChanged || kind == SecretRotate
+}
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -48,6 +62,12 @@ type Info struct {
 
 	// WorkloadName is the name of the sidecar container or workload relevant to the hook.
 	WorkloadName string `yaml:"workload-name,omitempty"`
+
+	// SecretURI is the URI of the secret relevant to the hook.
+	SecretURI string `yaml:"secret-uri,omitempty"`
+
+	// TimerName is the name of the timer relevant to the hook.
+	TimerName string `yaml:"timer-name,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -88,6 +108,16 @@ func (hi Info) Validate() error {
 	// TODO(fwereade): define these in charm/hooks...
 	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
 		return nil
+	case SecretChanged, SecretRotate:
+		if hi.SecretURI == "" {
+			return fmt.Errorf("%q hook requires a secret URI", hi.Kind)
+		}
+		return nil
+	case TimerFired:
+		if hi.TimerName == "" {
+			return fmt.Errorf("%q hook requires a timer name", hi.Kind)
+		}
+		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
 }
@@ -65,6 +65,11 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.WorkloadReady, WorkloadName: "gitlab"}, ""},
+	{hook.Info{Kind: hook.SecretChanged}, `"secret-changed" hook requires a secret URI`},
+	{hook.Info{Kind: hook.SecretChanged, SecretURI: "secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"}, ""},
+	{hook.Info{Kind: hook.SecretRotate, SecretURI: "secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"}, ""},
+	{hook.Info{Kind: hook.TimerFired}, `"timer-fired" hook requires a timer name`},
+	{hook.Info{Kind: hook.TimerFired, TimerName: "backup"}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
@@ -10,9 +10,11 @@ import (
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/secretsmanager"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
@@ -53,6 +55,10 @@ type ManifoldConfig struct {
 	Embedded                     bool
 	EnforcedCharmModifiedVersion int
 	ContainerNames               []string
+
+	// PrometheusRegisterer, if set, is used to register the uniter's
+	// metrics.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // Validate ensures all the required values for the config are set.
@@ -127,6 +133,11 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return nil, errors.Errorf("expected a unit tag, got %v", tag)
 			}
 			uniterFacade := uniter.NewState(apiConn, unitTag)
+			// Older controllers don't support secrets.
+			var secretsClient SecretsClient
+			if apiConn.BestFacadeVersion("SecretsManager") > 0 {
+				secretsClient = secretsmanager.NewClient(apiConn)
+			}
 			uniter, err := NewUniter(&UniterParams{
 				UniterFacade:                 uniterFacade,
 				UnitTag:                      unitTag,
@@ -148,6 +159,8 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				Embedded:                     config.Embedded,
 				EnforcedCharmModifiedVersion: config.EnforcedCharmModifiedVersion,
 				ContainerNames:               config.ContainerNames,
+				PrometheusRegisterer:         config.PrometheusRegisterer,
+				SecretsClient:                secretsClient,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -0,0 +1,215 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+const (
+	metricsNamespace = "juju_uniter"
+
+	metricsLabelUnit    = "unit"
+	metricsLabelHook    = "hook"
+	metricsLabelOutcome = "outcome"
+
+	// outcomeSucceeded is recorded for hooks and actions that ran to
+	// completion, including hooks the charm doesn't implement.
+	outcomeSucceeded = "succeeded"
+
+	// outcomeFailed is recorded for hooks that exited with an error.
+	outcomeFailed = "failed"
+
+	// outcomeError is recorded when the uniter couldn't run a hook or
+	// action, or couldn't record the result.
+	outcomeError = "error"
+)
+
+// executionBuckets are the histogram buckets used for hook and action
+// durations, from 100ms to about 14 minutes.
+var executionBuckets = prometheus.ExponentialBuckets(0.1, 2, 14)
+
+// metrics is a prometheus.Collector holding the metrics about a
+// single unit's uniter.
+type metrics struct {
+	hookCount         *prometheus.CounterVec
+	hookDuration      *prometheus.HistogramVec
+	actionCount       *prometheus.CounterVec
+	actionDuration    *prometheus.HistogramVec
+	resolverLoops     prometheus.Counter
+	remoteStateEvents prometheus.Counter
+}
+
+// newMetrics returns the metrics for the uniter of the named unit.
+// All the metrics are labelled with the unit name, so the uniters of
+// several units can register their metrics with the same registry.
+func newMetrics(unitName string) *metrics {
+	unitLabel := prometheus.Labels{metricsLabelUnit: unitName}
+	return &metrics{
+		hookCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Name:        "hook_executions_total",
+			Help:        "The number of hooks run, by hook kind and outcome.",
+			ConstLabels: unitLabel,
+		}, []string{metricsLabelHook, metricsLabelOutcome}),
+		hookDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   metricsNamespace,
+			Name:        "hook_duration_seconds",
+			Help:        "The time taken to run hooks, by hook kind and outcome.",
+			ConstLabels: unitLabel,
+			Buckets:     executionBuckets,
+		}, []string{metricsLabelHook, metricsLabelOutcome}),
+		actionCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Name:        "action_executions_total",
+			Help:        "The number of actions run, by outcome.",
+			ConstLabels: unitLabel,
+		}, []string{metricsLabelOutcome}),
+		actionDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   metricsNamespace,
+			Name:        "action_duration_seconds",
+			Help:        "The time taken to run actions, by outcome.",
+			ConstLabels: unitLabel,
+			Buckets:     executionBuckets,
+		}, []string{metricsLabelOutcome}),
+		resolverLoops: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Name:        "resolver_loop_iterations_total",
+			Help:        "The number of times the resolver has been asked for the next operation.",
+			ConstLabels: unitLabel,
+		}),
+		remoteStateEvents: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Name:        "remote_state_events_total",
+			Help:        "The number of events handled by the remote state watcher.",
+			ConstLabels: unitLabel,
+		}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.hookCount.Describe(ch)
+	m.hookDuration.Describe(ch)
+	m.actionCount.Describe(ch)
+	m.actionDuration.Describe(ch)
+	m.resolverLoops.Describe(ch)
+	m.remoteStateEvents.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *metrics) Collect(ch chan<- prometheus.Metric) {
+	m.hookCount.Collect(ch)
+	m.hookDuration.Collect(ch)
+	m.actionCount.Collect(ch)
+	m.actionDuration.Collect(ch)
+	m.resolverLoops.Collect(ch)
+	m.remoteStateEvents.Collect(ch)
+}
+
+func (m *metrics) observeHook(kind string, duration time.Duration, err error) {
+	outcome := outcomeSucceeded
+	switch errors.Cause(err) {
+	case nil, operation.ErrNeedsReboot:
+	case operation.ErrHookFailed:
+		outcome = outcomeFailed
+	default:
+		outcome = outcomeError
+	}
+	m.hookCount.WithLabelValues(kind, outcome).Inc()
+	m.hookDuration.WithLabelValues(kind, outcome).Observe(duration.Seconds())
+}
+
+func (m *metrics) observeAction(duration time.Duration, err error) {
+	// Actions that the charm reports as failed still complete
+	// successfully as far as the uniter is concerned.
+	outcome := outcomeSucceeded
+	if err != nil {
+		outcome = outcomeError
+	}
+	m.actionCount.WithLabelValues(outcome).Inc()
+	m.actionDuration.WithLabelValues(outcome).Observe(duration.Seconds())
+}
+
+// meteredFactory is an operation.Factory whose hook and action
+// operations record their execution in the uniter metrics.
+type meteredFactory struct {
+	operation.Factory
+	metrics *metrics
+	clock   clock.Clock
+}
+
+// NewRunHook is part of the operation.Factory interface.
+func (f *meteredFactory) NewRunHook(hookInfo hook.Info) (operation.Operation, error) {
+	op, err := f.Factory.NewRunHook(hookInfo)
+	if err != nil {
+		return nil, err
+	}
+	kind := string(hookInfo.Kind)
+	return &meteredOperation{
+		Operation: op,
+		clock:     f.clock,
+		observe: func(duration time.Duration, err error) {
+			f.metrics.observeHook(kind, duration, err)
+		},
+	}, nil
+}
+
+// NewAction is part of the operation.Factory interface.
+func (f *meteredFactory) NewAction(actionId string) (operation.Operation, error) {
+	op, err := f.Factory.NewAction(actionId)
+	if err != nil {
+		return nil, err
+	}
+	return &meteredOperation{
+		Operation: op,
+		clock:     f.clock,
+		observe:   f.metrics.observeAction,
+	}, nil
+}
+
+// meteredOperation times the execution of the operation it wraps.
+type meteredOperation struct {
+	operation.Operation
+	clock   clock.Clock
+	observe func(time.Duration, error)
+}
+
+// Execute is part of the operation.Operation interface.
+func (op *meteredOperation) Execute(state operation.State) (*operation.State, error) {
+	start := op.clock.Now()
+	newState, err := op.Operation.Execute(state)
+	op.observe(op.clock.Now().Sub(start), err)
+	return newState, err
+}
+
+// WrappedOperation is part of the operation.WrappedOperation interface.
+func (op *meteredOperation) WrappedOperation() operation.Operation {
+	return op.Operation
+}
+
+// meteredResolver counts the iterations of the resolver loop.
+type meteredResolver struct {
+	resolver.Resolver
+	iterations prometheus.Counter
+}
+
+// NextOp is part of the resolver.Resolver interface.
+func (r *meteredResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	r.iterations.Inc()
+	return r.Resolver.NextOp(localState, remoteState, opFactory)
+}
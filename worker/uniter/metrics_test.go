@@ -0,0 +1,184 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/charm/v9/hooks"
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+type metricsSuite struct {
+	testing.IsolationSuite
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+}
+
+func (s *metricsSuite) TestHookExecutions(c *gc.C) {
+	metrics := uniter.NewMetrics("mysql/0")
+	factory := &fakeOpFactory{clock: s.clock}
+	metered := uniter.NewMeteredFactory(factory, metrics, s.clock)
+
+	for _, err := range []error{nil, operation.ErrHookFailed, operation.ErrNeedsReboot, errors.New("boom")} {
+		factory.err = err
+		op, opErr := metered.NewRunHook(hook.Info{Kind: hooks.Install})
+		c.Assert(opErr, jc.ErrorIsNil)
+		_, execErr := op.Execute(operation.State{})
+		c.Assert(execErr, gc.Equals, err)
+	}
+	factory.err = nil
+	op, err := metered.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = testutil.CollectAndCompare(metrics, strings.NewReader(`
+# HELP juju_uniter_hook_executions_total The number of hooks run, by hook kind and outcome.
+# TYPE juju_uniter_hook_executions_total counter
+juju_uniter_hook_executions_total{hook="config-changed",outcome="succeeded",unit="mysql/0"} 1
+juju_uniter_hook_executions_total{hook="install",outcome="error",unit="mysql/0"} 1
+juju_uniter_hook_executions_total{hook="install",outcome="failed",unit="mysql/0"} 1
+juju_uniter_hook_executions_total{hook="install",outcome="succeeded",unit="mysql/0"} 2
+`), "juju_uniter_hook_executions_total")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *metricsSuite) TestHookDuration(c *gc.C) {
+	metrics := uniter.NewMetrics("mysql/0")
+	factory := &fakeOpFactory{clock: s.clock, duration: 3 * time.Second}
+	metered := uniter.NewMeteredFactory(factory, metrics, s.clock)
+
+	op, err := metered.NewRunHook(hook.Info{Kind: hooks.Start})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = testutil.CollectAndCompare(metrics, strings.NewReader(`
+# HELP juju_uniter_hook_duration_seconds The time taken to run hooks, by hook kind and outcome.
+# TYPE juju_uniter_hook_duration_seconds histogram
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="0.1"} 0
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="0.2"} 0
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="0.4"} 0
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="0.8"} 0
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="1.6"} 0
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="3.2"} 1
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="6.4"} 1
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="12.8"} 1
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="25.6"} 1
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="51.2"} 1
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="102.4"} 1
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="204.8"} 1
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="409.6"} 1
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="819.2"} 1
+juju_uniter_hook_duration_seconds_bucket{hook="start",outcome="succeeded",unit="mysql/0",le="+Inf"} 1
+juju_uniter_hook_duration_seconds_sum{hook="start",outcome="succeeded",unit="mysql/0"} 3
+juju_uniter_hook_duration_seconds_count{hook="start",outcome="succeeded",unit="mysql/0"} 1
+`), "juju_uniter_hook_duration_seconds")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *metricsSuite) TestActionExecutions(c *gc.C) {
+	metrics := uniter.NewMetrics("mysql/0")
+	factory := &fakeOpFactory{clock: s.clock}
+	metered := uniter.NewMeteredFactory(factory, metrics, s.clock)
+
+	for _, err := range []error{nil, nil, errors.New("boom")} {
+		factory.err = err
+		op, opErr := metered.NewAction("1")
+		c.Assert(opErr, jc.ErrorIsNil)
+		_, _ = op.Execute(operation.State{})
+	}
+
+	err := testutil.CollectAndCompare(metrics, strings.NewReader(`
+# HELP juju_uniter_action_executions_total The number of actions run, by outcome.
+# TYPE juju_uniter_action_executions_total counter
+juju_uniter_action_executions_total{outcome="error",unit="mysql/0"} 1
+juju_uniter_action_executions_total{outcome="succeeded",unit="mysql/0"} 2
+`), "juju_uniter_action_executions_total")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *metricsSuite) TestMeteredOperationUnwraps(c *gc.C) {
+	factory := &fakeOpFactory{clock: s.clock}
+	metered := uniter.NewMeteredFactory(factory, uniter.NewMetrics("mysql/0"), s.clock)
+
+	op, err := metered.NewRunHook(hook.Info{Kind: hooks.Install})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "fake op")
+	c.Assert(operation.Unwrap(op), gc.Equals, factory.lastOp)
+}
+
+func (s *metricsSuite) TestResolverIterations(c *gc.C) {
+	metrics := uniter.NewMetrics("mysql/0")
+	r := uniter.NewMeteredResolver(resolver.ResolverFunc(func(
+		resolver.LocalState, remotestate.Snapshot, operation.Factory,
+	) (operation.Operation, error) {
+		return nil, resolver.ErrNoOperation
+	}), metrics)
+
+	for i := 0; i < 3; i++ {
+		_, err := r.NextOp(resolver.LocalState{}, remotestate.Snapshot{}, nil)
+		c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	}
+
+	err := testutil.CollectAndCompare(metrics, strings.NewReader(`
+# HELP juju_uniter_resolver_loop_iterations_total The number of times the resolver has been asked for the next operation.
+# TYPE juju_uniter_resolver_loop_iterations_total counter
+juju_uniter_resolver_loop_iterations_total{unit="mysql/0"} 3
+`), "juju_uniter_resolver_loop_iterations_total")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type fakeOpFactory struct {
+	operation.Factory
+	clock    *testclock.Clock
+	duration time.Duration
+	err      error
+	lastOp   *fakeOp
+}
+
+func (f *fakeOpFactory) NewRunHook(hook.Info) (operation.Operation, error) {
+	f.lastOp = &fakeOp{clock: f.clock, duration: f.duration, err: f.err}
+	return f.lastOp, nil
+}
+
+func (f *fakeOpFactory) NewAction(string) (operation.Operation, error) {
+	f.lastOp = &fakeOp{clock: f.clock, duration: f.duration, err: f.err}
+	return f.lastOp, nil
+}
+
+type fakeOp struct {
+	operation.Operation
+	clock    *testclock.Clock
+	duration time.Duration
+	err      error
+}
+
+func (op *fakeOp) String() string {
+	return "fake op"
+}
+
+func (op *fakeOp) Execute(operation.State) (*operation.State, error) {
+	op.clock.Advance(op.duration)
+	return nil, op.err
+}
@@ -72,3 +72,8 @@ func (r *mockRunner) RunHook(hookName string) (runner.HookHandlerType, error) {
 	}
 	return runner.ExplicitHookHandler, err
 }
+
+// HookStderr exists to satisfy the Runner interface.
+func (r *mockRunner) HookStderr() string {
+	return ""
+}
@@ -32,6 +32,8 @@ func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 	switch {
 	case hi.Kind.IsWorkload():
 		name = fmt.Sprintf("%s-%s", hi.WorkloadName, hi.Kind)
+	case hi.Kind == hook.TimerFired:
+		name = fmt.Sprintf("%s-%s", hi.TimerName, hi.Kind)
 	case hi.Kind.IsRelation():
 		var err error
 		name, err = opc.u.relationStateTracker.PrepareHook(hi)
@@ -5,7 +5,9 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -32,6 +34,8 @@ type executor struct {
 	state              *State
 	acquireMachineLock func(string, string) (func(), error)
 	logger             Logger
+	recordHookRun      func(HookRun) error
+	clock              clock.Clock
 }
 
 // ExecutorConfig defines configuration for an Executor.
@@ -40,6 +44,14 @@ type ExecutorConfig struct {
 	InitialState    State
 	AcquireLock     func(string, string) (func(), error)
 	Logger          Logger
+
+	// RecordHookRun, if set, is called with each hook run by an
+	// operation, so it can be added to the unit's hook history.
+	RecordHookRun func(HookRun) error
+
+	// Clock is used to time hook runs. It's only required
+	// if RecordHookRun is set.
+	Clock clock.Clock
 }
 
 func (e ExecutorConfig) validate() error {
@@ -49,6 +61,9 @@ func (e ExecutorConfig) validate() error {
 	if e.Logger == nil {
 		return errors.NotValidf("executor config with nil logger")
 	}
+	if e.RecordHookRun != nil && e.Clock == nil {
+		return errors.NotValidf("executor config with nil clock")
+	}
 	return nil
 }
 
@@ -72,6 +87,8 @@ func NewExecutor(unitName string, cfg ExecutorConfig) (Executor, error) {
 		state:              state,
 		acquireMachineLock: cfg.AcquireLock,
 		logger:             cfg.Logger,
+		recordHookRun:      cfg.RecordHookRun,
+		clock:              cfg.Clock,
 	}, nil
 }
 
@@ -113,7 +130,13 @@ func (x *executor) Run(op Operation, remoteStateChange <-chan remotestate.Snapsh
 				}
 			}
 		}()
-		if err := x.do(op, stepExecute); err != nil {
+		var started time.Time
+		if x.recordHookRun != nil {
+			started = x.clock.Now()
+		}
+		err := x.do(op, stepExecute)
+		x.reportHookRun(op, started)
+		if err != nil {
 			close(done)
 			return err
 		}
@@ -130,6 +153,28 @@ func (x *executor) Skip(op Operation) error {
 	return x.do(op, stepCommit)
 }
 
+// reportHookRun records the hook run by the operation's Execute step,
+// if it ran one, in the unit's hook history. Failing to do so isn't
+// fatal; the hook has run regardless.
+func (x *executor) reportHookRun(op Operation, started time.Time) {
+	if x.recordHookRun == nil {
+		return
+	}
+	reporter, ok := UnwrapAll(op).(HookRunReporter)
+	if !ok {
+		return
+	}
+	run := reporter.HookRun()
+	if run == nil {
+		return
+	}
+	run.Started = started
+	run.Finished = x.clock.Now()
+	if err := x.recordHookRun(*run); err != nil {
+		x.logger.Warningf("cannot record %q hook run for %s: %v", run.Hook, x.unitName, err)
+	}
+}
+
 func (x *executor) do(op Operation, step executorStep) (err error) {
 	message := step.message(op, x.unitName)
 	x.logger.Debugf(message)
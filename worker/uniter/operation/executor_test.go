@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/juju/charm/v9/hooks"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
@@ -111,6 +112,19 @@ func (s *NewExecutorSuite) TestNewExecutorValidFile(c *gc.C) {
 	})
 }
 
+func (s *NewExecutorSuite) TestNewExecutorRecordHookRunNeedsClock(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	cfg := operation.ExecutorConfig{
+		StateReadWriter: s.mockStateRW,
+		InitialState:    operation.State{Step: operation.Queued},
+		AcquireLock:     failAcquireLock,
+		Logger:          loggo.GetLogger("test"),
+		RecordHookRun:   func(operation.HookRun) error { return nil },
+	}
+	_, err := operation.NewExecutor("test", cfg)
+	c.Assert(err, gc.ErrorMatches, "executor config with nil clock not valid")
+}
+
 type ExecutorSuite struct {
 	testing.IsolationSuite
 	mockStateRW *mocks.MockUnitStateReadWriter
@@ -198,6 +212,30 @@ func (s *ExecutorSuite) newExecutor(c *gc.C, st *operation.State) operation.Exec
 	return executor
 }
 
+func (s *ExecutorSuite) newRecordingExecutor(
+	c *gc.C, st *operation.State, clock *testclock.Clock, recordErr error,
+) (operation.Executor, *[]operation.HookRun) {
+	// ensure s.setupMocks called first.
+	c.Assert(s.mockStateRW, gc.NotNil)
+
+	s.expectState(c, *st)
+	var runs []operation.HookRun
+	cfg := operation.ExecutorConfig{
+		StateReadWriter: s.mockStateRW,
+		InitialState:    operation.State{Step: operation.Queued},
+		AcquireLock:     failAcquireLock,
+		Logger:          loggo.GetLogger("test"),
+		RecordHookRun: func(run operation.HookRun) error {
+			runs = append(runs, run)
+			return recordErr
+		},
+		Clock: clock,
+	}
+	executor, err := operation.NewExecutor("test", cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return executor, &runs
+}
+
 func justInstalledState() operation.State {
 	return operation.State{
 		Kind: operation.Continue,
@@ -420,6 +458,78 @@ func (s *ExecutorSuite) TestFailExecuteWithStateChange(c *gc.C) {
 	c.Assert(executor.State(), gc.DeepEquals, *execute.newState)
 }
 
+func (s *ExecutorSuite) TestRecordsHookRun(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	started := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := testclock.NewClock(started)
+	initialState := justInstalledState()
+	executor, runs := s.newRecordingExecutor(c, &initialState, clock, nil)
+
+	execute := mockStepFunc(func(state operation.State) (*operation.State, error) {
+		clock.Advance(time.Second)
+		return nil, errors.New("splat")
+	})
+	op := &mockHookOperation{
+		mockOperation: &mockOperation{
+			prepare: newStep(nil, nil),
+			execute: execute,
+		},
+		run: &operation.HookRun{Hook: "install", ExitCode: 1, Stderr: "oops"},
+	}
+
+	err := executor.Run(op, nil)
+	c.Assert(err, gc.ErrorMatches, `executing operation "mock operation" for test: splat`)
+	c.Assert(*runs, jc.DeepEquals, []operation.HookRun{{
+		Hook:     "install",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		ExitCode: 1,
+		Stderr:   "oops",
+	}})
+}
+
+func (s *ExecutorSuite) TestRecordHookRunErrorIgnored(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	clock := testclock.NewClock(time.Now())
+	initialState := justInstalledState()
+	executor, runs := s.newRecordingExecutor(c, &initialState, clock, errors.New("boom"))
+
+	op := &mockHookOperation{
+		mockOperation: &mockOperation{
+			prepare: newStep(nil, nil),
+			execute: newStep(nil, nil),
+			commit:  newStep(nil, nil),
+		},
+		run: &operation.HookRun{Hook: "start"},
+	}
+
+	err := executor.Run(op, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*runs, gc.HasLen, 1)
+}
+
+func (s *ExecutorSuite) TestNoHookRunNotRecorded(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	clock := testclock.NewClock(time.Now())
+	initialState := justInstalledState()
+	executor, runs := s.newRecordingExecutor(c, &initialState, clock, nil)
+
+	op := &mockHookOperation{
+		mockOperation: &mockOperation{
+			prepare: newStep(nil, nil),
+			execute: newStep(nil, nil),
+			commit:  newStep(nil, nil),
+		},
+	}
+
+	err := executor.Run(op, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*runs, gc.HasLen, 0)
+}
+
 func (s *ExecutorSuite) TestFailCommitNoStateChange(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -691,3 +801,12 @@ func (op *mockOperation) RemoteStateChanged(snapshot remotestate.Snapshot) {
 		op.remoteStateFunc(snapshot)
 	}
 }
+
+type mockHookOperation struct {
+	*mockOperation
+	run *operation.HookRun
+}
+
+func (op *mockHookOperation) HookRun() *operation.HookRun {
+	return op.run
+}
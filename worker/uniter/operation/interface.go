@@ -4,6 +4,8 @@
 package operation
 
 import (
+	"time"
+
 	corecharm "github.com/juju/charm/v9"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -96,6 +98,34 @@ func UnwrapAll(op Operation) Operation {
 	return unwrapped
 }
 
+// HookRun describes a hook run by an operation, for the unit's hook
+// history.
+type HookRun struct {
+	// Hook is the name of the hook, eg "db-relation-changed".
+	Hook string
+
+	// Relation identifies the relation a relation hook ran for,
+	// eg "db:2", and is otherwise empty.
+	Relation string
+
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode is the hook's exit code, or -1 if the hook was
+	// interrupted or couldn't be started.
+	ExitCode int
+
+	// Stderr holds the end of what the hook wrote to stderr.
+	Stderr string
+}
+
+// HookRunReporter is implemented by operations which run hooks.
+type HookRunReporter interface {
+	// HookRun returns the hook run by the operation's Execute step,
+	// or nil if no hook was run. The executor fills in the times.
+	HookRun() *HookRun
+}
+
 // Executor records and exposes uniter state, and applies suitable changes as
 // operations are run or skipped.
 type Executor interface {
@@ -5,6 +5,8 @@ package operation
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/juju/charm/v9/hooks"
 	"github.com/juju/errors"
@@ -12,6 +14,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/timers"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -31,6 +34,10 @@ type runHook struct {
 	logger Logger
 
 	hookFound bool
+	run       *HookRun
+
+	cancel     chan struct{}
+	cancelOnce sync.Once
 
 	RequiresMachineLock
 }
@@ -49,6 +56,8 @@ func (rh *runHook) String() string {
 		}
 	case rh.info.Kind.IsStorage():
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
+	case rh.info.Kind == hook.TimerFired:
+		suffix = fmt.Sprintf(" (%s)", rh.info.TimerName)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
 }
@@ -60,7 +69,8 @@ func (rh *runHook) Prepare(state State) (*State, error) {
 	if err != nil {
 		return nil, err
 	}
-	rnr, err := rh.runnerFactory.NewHookRunner(rh.info)
+	rh.cancel = make(chan struct{})
+	rnr, err := rh.runnerFactory.NewHookRunner(rh.info, rh.cancel)
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +135,9 @@ func (rh *runHook) Execute(state State) (*State, error) {
 
 	handlerType, err := rh.runner.RunHook(rh.name)
 	cause := errors.Cause(err)
+	if !charmrunner.IsMissingHookError(cause) {
+		rh.run = rh.newHookRun(err)
+	}
 	switch {
 	case charmrunner.IsMissingHookError(cause):
 		rh.hookFound = false
@@ -135,6 +148,17 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case cause == context.ErrReboot:
 		err = ErrNeedsReboot
 	case err == nil:
+	case charmrunner.IsHookTimedOutError(cause), cause == charmrunner.ErrHookCancelled:
+		// Record why the hook was interrupted, so the error status
+		// reported while the hook awaits resolution explains it.
+		rh.logger.Errorf("hook %q (via %s) interrupted: %v", rh.name, handlerType, err)
+		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		return stateChange{
+			Kind:        RunHook,
+			Step:        Pending,
+			Hook:        &rh.info,
+			HookFailure: rh.hookFailure(cause),
+		}.apply(state), ErrHookFailed
 	default:
 		rh.logger.Errorf("hook %q (via %s) failed: %v", rh.name, handlerType, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
@@ -153,12 +177,69 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	if hasRunStatusSet, afterHookErr = rh.afterHook(state); afterHookErr != nil {
 		return nil, afterHookErr
 	}
-	return stateChange{
+	newState := stateChange{
 		Kind:            RunHook,
 		Step:            step,
 		Hook:            &rh.info,
 		HasRunStatusSet: hasRunStatusSet,
-	}.apply(state), err
+	}.apply(state)
+	newState.Timers = applyTimerChanges(newState.Timers, rh.runner.Context().TimerChanges())
+	return newState, err
+}
+
+// HookRun is part of the HookRunReporter interface.
+func (rh *runHook) HookRun() *HookRun {
+	return rh.run
+}
+
+// newHookRun returns a record of the hook's run, which ended with
+// the supplied error.
+func (rh *runHook) newHookRun(err error) *HookRun {
+	run := &HookRun{
+		Hook:     rh.name,
+		ExitCode: hookExitCode(err),
+		Stderr:   rh.runner.HookStderr(),
+	}
+	if rh.info.Kind.IsRelation() {
+		endpoint := strings.TrimSuffix(rh.name, "-"+string(rh.info.Kind))
+		run.Relation = fmt.Sprintf("%s:%d", endpoint, rh.info.RelationId)
+	}
+	return run
+}
+
+// hookExitCode returns the exit code of a hook which ended with the
+// supplied error, or -1 if it didn't exit by itself.
+func hookExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := errors.Cause(err).(interface{ ExitCode() int }); ok {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// applyTimerChanges returns a copy of current updated with the timers
+// scheduled or deleted (recorded as nil) by the charm while the hook ran.
+func applyTimerChanges(current map[string]timers.Timer, changes map[string]*timers.Timer) map[string]timers.Timer {
+	if len(changes) == 0 {
+		return current
+	}
+	result := make(map[string]timers.Timer)
+	for name, t := range current {
+		result[name] = t
+	}
+	for name, t := range changes {
+		if t == nil {
+			delete(result, name)
+			continue
+		}
+		result[name] = *t
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
 }
 
 func (rh *runHook) beforeHook(state State) error {
@@ -307,7 +388,21 @@ func (rh *runHook) Commit(state State) (*State, error) {
 	return newState, nil
 }
 
+// hookFailure describes why the hook was interrupted.
+func (rh *runHook) hookFailure(err error) string {
+	if err == charmrunner.ErrHookCancelled {
+		return "cancelled"
+	}
+	return fmt.Sprintf("timed out after %v", rh.runner.Context().HookTimeout())
+}
+
 // RemoteStateChanged is called when the remote state changed during execution
 // of the operation.
 func (rh *runHook) RemoteStateChanged(snapshot remotestate.Snapshot) {
+	if snapshot.HookCancelRequested {
+		rh.cancelOnce.Do(func() {
+			rh.logger.Infof("cancelling %q hook", rh.name)
+			close(rh.cancel)
+		})
+	}
 }
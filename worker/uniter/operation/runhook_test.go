@@ -4,6 +4,9 @@
 package operation_test
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/juju/charm/v9/hooks"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -11,9 +14,11 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/core/timers"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -279,6 +284,68 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteTimedOutError(c *gc.C) {
+	runErr := charmrunner.NewHookTimedOutError("config-changed", 10*time.Minute)
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, runErr,
+		func(ctx *MockContext) {
+			ctx.hookTimeout = 10 * time.Minute
+		},
+	)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:        operation.RunHook,
+		Step:        operation.Pending,
+		Hook:        &hook.Info{Kind: hooks.ConfigChanged},
+		HookFailure: "timed out after 10m0s",
+	})
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+	c.Assert(runnerFactory.MockNewHookRunner.gotCancel, gc.NotNil)
+}
+
+func (s *RunHookSuite) TestExecuteCancelledError(c *gc.C) {
+	runErr := errors.Trace(charmrunner.ErrHookCancelled)
+	op, callbacks, _ := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:        operation.RunHook,
+		Step:        operation.Pending,
+		Hook:        &hook.Info{Kind: hooks.ConfigChanged},
+		HookFailure: "cancelled",
+	})
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+}
+
+func (s *RunHookSuite) TestRemoteStateChangedCancelsHook(c *gc.C) {
+	op, _, runnerFactory := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, nil)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	cancel := runnerFactory.MockNewHookRunner.gotCancel
+
+	op.RemoteStateChanged(remotestate.Snapshot{})
+	select {
+	case <-cancel:
+		c.Fatalf("hook cancelled unexpectedly")
+	default:
+	}
+
+	op.RemoteStateChanged(remotestate.Snapshot{HookCancelRequested: true})
+	op.RemoteStateChanged(remotestate.Snapshot{HookCancelRequested: true})
+	select {
+	case <-cancel:
+	default:
+		c.Fatalf("hook not cancelled")
+	}
+}
+
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
 	op, callbacks, f := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.Install, nil)
 	err := f.MockNewHookRunner.runner.Context().SetUnitStatus(jujuc.StatusInfo{Status: "blocked", Info: "no database"})
@@ -464,6 +531,106 @@ func (s *RunHookSuite) TestExecuteHookWithSetStatus(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) TestExecuteAppliesTimerChanges(c *gc.C) {
+	due := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	op, _, _ := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, nil, func(ctx *MockContext) {
+		ctx.timerChanges = map[string]*timers.Timer{
+			"backup":  {Due: due, Next: due, Every: time.Hour},
+			"cleanup": nil,
+		}
+	})
+	before := operation.State{
+		Timers: map[string]timers.Timer{
+			"cleanup": {Due: due, Next: due},
+			"report":  {Due: due, Next: due},
+		},
+	}
+	midState, err := op.Prepare(before)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState.Timers, jc.DeepEquals, map[string]timers.Timer{
+		"backup": {Due: due, Next: due, Every: time.Hour},
+		"report": {Due: due, Next: due},
+	})
+	// The state the hook started with is left untouched.
+	c.Assert(before.Timers, gc.HasLen, 2)
+}
+
+type exitCodeError int
+
+func (e exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+func (e exitCodeError) ExitCode() int {
+	return int(e)
+}
+
+func (s *RunHookSuite) TestExecuteReportsHookRun(c *gc.C) {
+	for i, test := range []struct {
+		runErr   error
+		exitCode int
+	}{
+		{nil, 0},
+		{errors.Trace(exitCodeError(2)), 2},
+		{errors.Trace(charmrunner.ErrHookCancelled), -1},
+	} {
+		c.Logf("test %d: %v", i, test.runErr)
+		op, _, runnerFactory := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, test.runErr)
+		runnerFactory.MockNewHookRunner.runner.MockRunHook.stderr = "oops\n"
+		_, err := op.Prepare(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+
+		_, _ = op.Execute(operation.State{})
+		c.Check(op.(operation.HookRunReporter).HookRun(), jc.DeepEquals, &operation.HookRun{
+			Hook:     "some-hook-name",
+			ExitCode: test.exitCode,
+			Stderr:   "oops\n",
+		})
+	}
+}
+
+func (s *RunHookSuite) TestExecuteReportsRelationHookRun(c *gc.C) {
+	runnerFactory := NewRunHookRunnerFactory(nil)
+	callbacks := &ExecuteHookCallbacks{
+		PrepareHookCallbacks: &PrepareHookCallbacks{
+			MockPrepareHook: &MockPrepareHook{nil, "db-relation-changed", nil},
+		},
+		MockNotifyHookCompleted: &MockNotify{},
+		MockNotifyHookFailed:    &MockNotify{},
+	}
+	factory := newOpFactory(runnerFactory, callbacks)
+	op, err := factory.NewRunHook(hook.Info{
+		Kind:              hooks.RelationChanged,
+		RelationId:        2,
+		RemoteUnit:        "mysql/0",
+		RemoteApplication: "mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.(operation.HookRunReporter).HookRun(), jc.DeepEquals, &operation.HookRun{
+		Hook:     "db-relation-changed",
+		Relation: "db:2",
+	})
+}
+
+func (s *RunHookSuite) TestExecuteMissingHookReportsNoHookRun(c *gc.C) {
+	runErr := charmrunner.NewMissingHookError("blah-blah")
+	op, _, _ := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.(operation.HookRunReporter).HookRun(), gc.IsNil)
+}
+
 func (s *RunHookSuite) testCommitError(c *gc.C, newHook newHook) {
 	callbacks := &CommitHookCallbacks{
 		MockCommitHook: &MockCommitHook{nil, errors.New("pow")},
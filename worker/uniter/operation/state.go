@@ -9,6 +9,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/timers"
 	"github.com/juju/juju/worker/uniter/hook"
 )
 
@@ -91,6 +92,10 @@ type State struct {
 	// upgrade is complete (instead of running an upgrade-charm hook).
 	Hook *hook.Info `yaml:"hook,omitempty"`
 
+	// HookFailure describes why the hook held in Hook was interrupted, if
+	// it failed because it timed out or was cancelled.
+	HookFailure string `yaml:"hook-failure,omitempty"`
+
 	// ActionId holds action information relevant to the current operation. If
 	// Kind is Continue, it holds the last action that was executed; if Kind is
 	// RunAction, it holds the running action.
@@ -114,6 +119,15 @@ type State struct {
 	// machine/container addresses - it's used to determine whether we
 	// need to run config-changed.
 	AddressesHash string `yaml:"addresses-hash,omitempty"`
+
+	// SecretRevisions records the revisions of the secrets read by the
+	// unit for which a secret-changed hook has been run - it's used
+	// to determine whether we need to run secret-changed.
+	SecretRevisions map[string]int `yaml:"secret-revisions,omitempty"`
+
+	// Timers holds the timers scheduled by the charm, keyed by name.
+	// A timer-fired hook is run for each when it comes due.
+	Timers map[string]timers.Timer `yaml:"timers,omitempty"`
 }
 
 // Validate returns an error if the state violates expectations.
@@ -190,12 +204,14 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookFailure     string
 }
 
 func (change stateChange) apply(state State) *State {
 	state.Kind = change.Kind
 	state.Step = change.Step
 	state.Hook = change.Hook
+	state.HookFailure = change.HookFailure
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
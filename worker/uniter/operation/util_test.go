@@ -5,6 +5,7 @@ package operation_test
 
 import (
 	"sync"
+	"time"
 
 	corecharm "github.com/juju/charm/v9"
 	"github.com/juju/charm/v9/hooks"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/core/timers"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -262,13 +264,15 @@ func (mock *MockNewActionWaitRunner) Call(actionId string, cancel <-chan struct{
 }
 
 type MockNewHookRunner struct {
-	gotHook *hook.Info
-	runner  *MockRunner
-	err     error
+	gotHook   *hook.Info
+	gotCancel <-chan struct{}
+	runner    *MockRunner
+	err       error
 }
 
-func (mock *MockNewHookRunner) Call(hookInfo hook.Info) (runner.Runner, error) {
+func (mock *MockNewHookRunner) Call(hookInfo hook.Info, cancel <-chan struct{}) (runner.Runner, error) {
 	mock.gotHook = &hookInfo
+	mock.gotCancel = cancel
 	return mock.runner, mock.err
 }
 
@@ -293,8 +297,8 @@ func (f *MockRunnerFactory) NewActionRunner(action *uniter.Action, cancel <-chan
 	return f.MockNewActionRunner.Call(action.ID(), cancel)
 }
 
-func (f *MockRunnerFactory) NewHookRunner(hookInfo hook.Info) (runner.Runner, error) {
-	return f.MockNewHookRunner.Call(hookInfo)
+func (f *MockRunnerFactory) NewHookRunner(hookInfo hook.Info, cancel <-chan struct{}) (runner.Runner, error) {
+	return f.MockNewHookRunner.Call(hookInfo, cancel)
 }
 
 func (f *MockRunnerFactory) NewCommandRunner(commandInfo context.CommandInfo) (runner.Runner, error) {
@@ -318,6 +322,8 @@ type MockContext struct {
 	status          jujuc.StatusInfo
 	isLeader        bool
 	relation        *MockRelation
+	hookTimeout     time.Duration
+	timerChanges    map[string]*timers.Timer
 }
 
 func (mock *MockContext) ActionData() (*context.ActionData, error) {
@@ -327,6 +333,14 @@ func (mock *MockContext) ActionData() (*context.ActionData, error) {
 	return mock.actionData, nil
 }
 
+func (mock *MockContext) HookTimeout() time.Duration {
+	return mock.hookTimeout
+}
+
+func (mock *MockContext) TimerChanges() map[string]*timers.Timer {
+	return mock.timerChanges
+}
+
 func (mock *MockContext) HasExecutionSetUnitStatus() bool {
 	return mock.setStatusCalled
 }
@@ -404,6 +418,7 @@ type MockRunHook struct {
 	gotName         *string
 	err             error
 	setStatusCalled bool
+	stderr          string
 }
 
 func (mock *MockRunHook) Call(hookName string) error {
@@ -435,6 +450,10 @@ func (r *MockRunner) RunHook(hookName string) (runner.HookHandlerType, error) {
 	return runner.ExplicitHookHandler, r.MockRunHook.Call(hookName)
 }
 
+func (r *MockRunner) HookStderr() string {
+	return r.MockRunHook.stderr
+}
+
 type MockActionWaitRunner struct {
 	runner.Runner
 
@@ -5,6 +5,10 @@ package remotestate
 
 import (
 	"github.com/juju/worker/v2"
+
+	"github.com/juju/juju/api/secretsmanager"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/watcher"
 )
 
 type Watcher interface {
@@ -17,3 +21,23 @@ type Watcher interface {
 
 	worker.Worker
 }
+
+// SecretsClient provides access to the secrets read or owned by the unit.
+type SecretsClient interface {
+	// WatchConsumedSecretsChanges returns a watcher which notifies of
+	// the URIs of the secrets read by the unit which have new revisions.
+	WatchConsumedSecretsChanges(unitName string) (watcher.StringsWatcher, error)
+
+	// GetLatestSecretsRevisionInfo returns the latest revisions of the
+	// specified secrets read by the unit.
+	GetLatestSecretsRevisionInfo(unitName string, uris []string) (map[string]secretsmanager.SecretRevisionInfo, error)
+
+	// WatchSecretsRotationChanges returns a watcher which notifies of
+	// the URIs of the secrets owned by the application whose rotation
+	// schedule may have changed.
+	WatchSecretsRotationChanges(appName string) (watcher.StringsWatcher, error)
+
+	// GetSecretMetadata returns the metadata of the secrets owned by
+	// the application.
+	GetSecretMetadata() ([]secrets.SecretMetadata, error)
+}
@@ -9,10 +9,12 @@ import (
 
 	"github.com/juju/charm/v9"
 
+	"github.com/juju/juju/api/secretsmanager"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/names/v4"
@@ -217,6 +219,7 @@ type mockUnit struct {
 	life                             life.Value
 	providerID                       string
 	resolved                         params.ResolvedMode
+	hookCancelRequested              bool
 	application                      mockApplication
 	unitWatcher                      *mockNotifyWatcher
 	addressesWatcher                 *mockStringsWatcher
@@ -250,6 +253,10 @@ func (u *mockUnit) Resolved() params.ResolvedMode {
 	return u.resolved
 }
 
+func (u *mockUnit) HookCancelRequested() bool {
+	return u.hookCancelRequested
+}
+
 func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.application, nil
 }
@@ -398,3 +405,54 @@ func (t *mockTicket) Ready() <-chan struct{} {
 func (t *mockTicket) Wait() bool {
 	return t.result
 }
+
+type mockEventCounter struct {
+	mu     sync.Mutex
+	events int
+}
+
+func (c *mockEventCounter) Inc() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events++
+}
+
+func (c *mockEventCounter) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.events
+}
+
+type mockSecretsClient struct {
+	mu                     sync.Mutex
+	consumedSecretsWatcher *mockStringsWatcher
+	secretsRotationWatcher *mockStringsWatcher
+	revisions              map[string]int
+	metadata               []secrets.SecretMetadata
+}
+
+func (c *mockSecretsClient) WatchConsumedSecretsChanges(unitName string) (watcher.StringsWatcher, error) {
+	return c.consumedSecretsWatcher, nil
+}
+
+func (c *mockSecretsClient) GetLatestSecretsRevisionInfo(unitName string, uris []string) (map[string]secretsmanager.SecretRevisionInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result := make(map[string]secretsmanager.SecretRevisionInfo)
+	for _, uri := range uris {
+		if rev, ok := c.revisions[uri]; ok {
+			result[uri] = secretsmanager.SecretRevisionInfo{Revision: rev}
+		}
+	}
+	return result, nil
+}
+
+func (c *mockSecretsClient) WatchSecretsRotationChanges(appName string) (watcher.StringsWatcher, error) {
+	return c.secretsRotationWatcher, nil
+}
+
+func (c *mockSecretsClient) GetSecretMetadata() ([]secrets.SecretMetadata, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.metadata, nil
+}
@@ -41,6 +41,10 @@ type Snapshot struct {
 	// hook execution errors.
 	ResolvedMode params.ResolvedMode
 
+	// HookCancelRequested reports whether the hook
+	// currently running should be cancelled.
+	HookCancelRequested bool
+
 	// ProviderID is the cloud container's provider ID.
 	ProviderID string
 
@@ -106,6 +110,15 @@ type Snapshot struct {
 	// WorkloadEvents is a list of IDs of workload events that need to be
 	// processed.
 	WorkloadEvents []string
+
+	// ConsumedSecretInfo holds the latest revisions of the secrets read
+	// by the unit which have changed since the unit last tracked them,
+	// keyed by secret URI.
+	ConsumedSecretInfo map[string]int
+
+	// SecretRotations is a list of URIs of the secrets owned by the
+	// application which are due to be rotated.
+	SecretRotations []string
 }
 
 // RelationSnapshot tracks the state of a relationship from the viewpoint of the local unit.
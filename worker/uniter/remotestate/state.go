@@ -40,6 +40,7 @@ type Unit interface {
 	Refresh() error
 	ProviderID() string
 	Resolved() params.ResolvedMode
+	HookCancelRequested() bool
 	Application() (Application, error)
 	Tag() names.UnitTag
 	Watch() (watcher.NotifyWatcher, error)
@@ -5,9 +5,12 @@ package remotestate
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
@@ -53,11 +56,19 @@ type RemoteStateWatcher struct {
 	updateStatusChannel           UpdateStatusTimerFunc
 	commandChannel                <-chan string
 	retryHookChannel              watcher.NotifyChannel
+	charmTimerChannel             watcher.NotifyChannel
 	applicationChannel            watcher.NotifyChannel
 	containerRunningStatusChannel watcher.NotifyChannel
 	containerRunningStatusFunc    ContainerRunningStatusFunc
 	canApplyCharmProfile          bool
 	workloadEventChannel          <-chan string
+	eventCounter                  EventCounter
+	secretsClient                 SecretsClient
+	clock                         clock.Clock
+
+	// secretRotateTimes holds when each secret owned by the
+	// application is next due to be rotated.
+	secretRotateTimes map[string]time.Time
 
 	catacomb catacomb.Catacomb
 
@@ -96,6 +107,24 @@ type WatcherConfig struct {
 	Logger                        Logger
 	CanApplyCharmProfile          bool
 	WorkloadEventChannel          <-chan string
+
+	// CharmTimerChannel, if set, is signalled when a timer scheduled
+	// by the charm is due.
+	CharmTimerChannel watcher.NotifyChannel
+
+	// EventCounter, if set, is incremented for each event the
+	// watcher handles.
+	EventCounter EventCounter
+
+	// SecretsClient, if set, is used to watch the secrets read and
+	// owned by the unit. Clock must be set along with it.
+	SecretsClient SecretsClient
+	Clock         clock.Clock
+}
+
+// EventCounter counts the events handled by the remote state watcher.
+type EventCounter interface {
+	Inc()
 }
 
 func (w WatcherConfig) validate() error {
@@ -115,6 +144,9 @@ func (w WatcherConfig) validate() error {
 	if w.Logger == nil {
 		return errors.NotValidf("nil Logger")
 	}
+	if w.SecretsClient != nil && w.Clock == nil {
+		return errors.NotValidf("watcher config with secrets client and nil clock")
+	}
 	return nil
 }
 
@@ -134,6 +166,7 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		updateStatusChannel:           config.UpdateStatusChannel,
 		commandChannel:                config.CommandChannel,
 		retryHookChannel:              config.RetryHookChannel,
+		charmTimerChannel:             config.CharmTimerChannel,
 		applicationChannel:            config.ApplicationChannel,
 		containerRunningStatusChannel: config.ContainerRunningStatusChannel,
 		containerRunningStatusFunc:    config.ContainerRunningStatusFunc,
@@ -150,10 +183,16 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 			Storage:        make(map[names.StorageTag]StorageSnapshot),
 			ActionsBlocked: config.ContainerRunningStatusChannel != nil,
 			ActionChanged:  make(map[string]int),
+
+			ConsumedSecretInfo: make(map[string]int),
 		},
 		embedded:                     config.Embedded,
 		enforcedCharmModifiedVersion: config.EnforcedCharmModifiedVersion,
 		workloadEventChannel:         config.WorkloadEventChannel,
+		eventCounter:                 config.EventCounter,
+		secretsClient:                config.SecretsClient,
+		clock:                        config.Clock,
+		secretRotateTimes:            make(map[string]time.Time),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -215,6 +254,12 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	for k, v := range w.current.ActionChanged {
 		snapshot.ActionChanged[k] = v
 	}
+	snapshot.ConsumedSecretInfo = make(map[string]int)
+	for k, v := range w.current.ConsumedSecretInfo {
+		snapshot.ConsumedSecretInfo[k] = v
+	}
+	snapshot.SecretRotations = make([]string, len(w.current.SecretRotations))
+	copy(snapshot.SecretRotations, w.current.SecretRotations)
 	return snapshot
 }
 
@@ -224,6 +269,12 @@ func (w *RemoteStateWatcher) ClearResolvedMode() {
 	w.mu.Unlock()
 }
 
+func (w *RemoteStateWatcher) ClearHookCancel() {
+	w.mu.Lock()
+	w.current.HookCancelRequested = false
+	w.mu.Unlock()
+}
+
 func (w *RemoteStateWatcher) CommandCompleted(completed string) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -254,6 +305,23 @@ func (w *RemoteStateWatcher) WorkloadEventCompleted(workloadEventID string) {
 	}
 }
 
+// RotateSecretCompleted is called when a secret-rotate hook has been
+// run for the specified secret.
+func (w *RemoteStateWatcher) RotateSecretCompleted(uri string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, rotated := range w.current.SecretRotations {
+		if rotated != uri {
+			continue
+		}
+		w.current.SecretRotations = append(
+			w.current.SecretRotations[:i],
+			w.current.SecretRotations[i+1:]...,
+		)
+		break
+	}
+}
+
 func (w *RemoteStateWatcher) setUp(unitTag names.UnitTag) (err error) {
 	// TODO(axw) move this logic
 	defer func() {
@@ -439,6 +507,34 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var (
+		seenConsumedSecretsChange bool
+		seenSecretsRotationChange bool
+		consumedSecretsChanges    watcher.StringsChannel
+		secretsRotationChanges    watcher.StringsChannel
+	)
+	if w.secretsClient != nil {
+		consumedSecretsw, err := w.secretsClient.WatchConsumedSecretsChanges(unitTag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := w.catacomb.Add(consumedSecretsw); err != nil {
+			return errors.Trace(err)
+		}
+		consumedSecretsChanges = consumedSecretsw.Changes()
+		requiredEvents++
+
+		secretsRotationw, err := w.secretsClient.WatchSecretsRotationChanges(w.application.Tag().Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := w.catacomb.Add(secretsRotationw); err != nil {
+			return errors.Trace(err)
+		}
+		secretsRotationChanges = secretsRotationw.Changes()
+		requiredEvents++
+	}
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -488,6 +584,14 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		updateStatusTimer = w.updateStatusChannel(updateStatusInterval).After()
 	}
 
+	var secretRotateTimer <-chan time.Time
+	resetSecretRotateTimer := func() {
+		secretRotateTimer = nil
+		if next, ok := w.nextSecretRotateTime(); ok {
+			secretRotateTimer = w.clock.After(next.Sub(w.clock.Now()))
+		}
+	}
+
 	for {
 		select {
 		case <-w.catacomb.Dying():
@@ -693,9 +797,44 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			w.logger.Debugf("retry hook timer triggered for %s", w.unit.Tag().Id())
 			w.retryHookTimerTriggered()
+
+		case _, ok := <-w.charmTimerChannel:
+			if !ok {
+				return errors.New("charmTimerChannel closed")
+			}
+			w.logger.Debugf("charm timer triggered for %s", w.unit.Tag().Id())
+
+		case uris, ok := <-consumedSecretsChanges:
+			w.logger.Debugf("got consumed secrets change for %s: %v ok=%t", w.unit.Tag().Id(), uris, ok)
+			if !ok {
+				return errors.New("consumed secrets watcher closed")
+			}
+			if err := w.consumedSecretsChanged(unitTag.Id(), uris); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenConsumedSecretsChange)
+
+		case uris, ok := <-secretsRotationChanges:
+			w.logger.Debugf("got secrets rotation change for %s: %v ok=%t", w.unit.Tag().Id(), uris, ok)
+			if !ok {
+				return errors.New("secrets rotation watcher closed")
+			}
+			if err := w.secretsRotationChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			resetSecretRotateTimer()
+			observedEvent(&seenSecretsRotationChange)
+
+		case <-secretRotateTimer:
+			w.logger.Debugf("secret rotate timer triggered for %s", w.unit.Tag().Id())
+			w.secretsRotationDue()
+			resetSecretRotateTimer()
 		}
 
 		// Something changed.
+		if w.eventCounter != nil {
+			w.eventCounter.Inc()
+		}
 		fire()
 	}
 }
@@ -732,6 +871,88 @@ func (w *RemoteStateWatcher) upgradeSeriesStatus() (model.UpgradeSeriesStatus, e
 	return status, nil
 }
 
+// consumedSecretsChanged is called when secrets read by the unit have
+// new revisions.
+func (w *RemoteStateWatcher) consumedSecretsChanged(unitName string, uris []string) error {
+	if len(uris) == 0 {
+		return nil
+	}
+	info, err := w.secretsClient.GetLatestSecretsRevisionInfo(unitName, uris)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, uri := range uris {
+		if revInfo, ok := info[uri]; ok {
+			w.current.ConsumedSecretInfo[uri] = revInfo.Revision
+		} else {
+			delete(w.current.ConsumedSecretInfo, uri)
+		}
+	}
+	return nil
+}
+
+// secretsRotationChanged is called when the rotation schedule of the
+// secrets owned by the application may have changed.
+func (w *RemoteStateWatcher) secretsRotationChanged() error {
+	mds, err := w.secretsClient.GetSecretMetadata()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rotateTimes := make(map[string]time.Time)
+	for _, md := range mds {
+		if md.NextRotateTime == nil || !md.RotatePolicy.WillRotate() {
+			continue
+		}
+		rotateTimes[md.URI.String()] = *md.NextRotateTime
+	}
+	w.secretRotateTimes = rotateTimes
+	w.secretsRotationDue()
+	return nil
+}
+
+// secretsRotationDue records the secrets which are due to be rotated.
+func (w *RemoteStateWatcher) secretsRotationDue() {
+	now := w.clock.Now()
+	var due []string
+	for uri, when := range w.secretRotateTimes {
+		if !when.After(now) {
+			due = append(due, uri)
+			// The secret is rescheduled once it has been rotated.
+			delete(w.secretRotateTimes, uri)
+		}
+	}
+	if len(due) == 0 {
+		return
+	}
+	sort.Strings(due)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	pending := set.NewStrings(w.current.SecretRotations...)
+	for _, uri := range due {
+		if !pending.Contains(uri) {
+			w.current.SecretRotations = append(w.current.SecretRotations, uri)
+		}
+	}
+}
+
+// nextSecretRotateTime returns when the next secret is due to be rotated.
+func (w *RemoteStateWatcher) nextSecretRotateTime() (time.Time, bool) {
+	var (
+		next  time.Time
+		found bool
+	)
+	for _, when := range w.secretRotateTimes {
+		if !found || when.Before(next) {
+			next = when
+			found = true
+		}
+	}
+	return next, found
+}
+
 // updateStatusChanged is called when the update status timer expires.
 func (w *RemoteStateWatcher) updateStatusChanged() {
 	w.mu.Lock()
@@ -769,6 +990,7 @@ func (w *RemoteStateWatcher) unitChanged() error {
 	defer w.mu.Unlock()
 	w.current.Life = w.unit.Life()
 	w.current.ResolvedMode = w.unit.Resolved()
+	w.current.HookCancelRequested = w.unit.HookCancelRequested()
 	// It's ok to sync provider ID by watching unit rather than
 	// cloud container because it will not change once pod created.
 	w.current.ProviderID = w.unit.ProviderID()
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -38,6 +39,7 @@ type WatcherSuite struct {
 	running              *remotestate.ContainerRunningStatus
 
 	workloadEventChannel chan string
+	eventCounter         *mockEventCounter
 }
 
 type WatcherSuiteIAAS struct {
@@ -123,6 +125,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 	s.clock = testclock.NewClock(time.Now())
 
 	s.workloadEventChannel = make(chan string)
+	s.eventCounter = &mockEventCounter{}
 }
 
 func (s *WatcherSuiteIAAS) SetUpTest(c *gc.C) {
@@ -184,6 +187,7 @@ func (s *WatcherSuite) setupWatcherConfig() remotestate.WatcherConfig {
 		UpdateStatusChannel:          statusTicker,
 		CanApplyCharmProfile:         s.modelType == model.IAAS,
 		WorkloadEventChannel:         s.workloadEventChannel,
+		EventCounter:                 s.eventCounter,
 	}
 }
 
@@ -474,6 +478,18 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 }
 
+func (s *WatcherSuite) TestEventsCounted(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
+	initial := s.eventCounter.count()
+	c.Assert(initial, jc.GreaterThan, 0)
+
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.eventCounter.count(), gc.Equals, initial+1)
+}
+
 func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	s.signalAll()
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -515,6 +531,19 @@ func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	c.Assert(snap.ResolvedMode, gc.Equals, params.ResolvedNone)
 }
 
+func (s *WatcherSuite) TestClearHookCancel(c *gc.C) {
+	s.st.unit.hookCancelRequested = true
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	snap := s.watcher.Snapshot()
+	c.Assert(snap.HookCancelRequested, jc.IsTrue)
+
+	s.watcher.ClearHookCancel()
+	snap = s.watcher.Snapshot()
+	c.Assert(snap.HookCancelRequested, jc.IsFalse)
+}
+
 func (s *WatcherSuite) TestLeadershipChanged(c *gc.C) {
 	s.leadership.claimTicket.result = false
 	s.signalAll()
@@ -1083,3 +1112,96 @@ func (s *WatcherSuite) TestWorkloadSignal(c *gc.C) {
 	snap = s.watcher.Snapshot()
 	c.Assert(snap.WorkloadEvents, gc.HasLen, 0)
 }
+
+func (s *WatcherSuite) TestCharmTimerSignal(c *gc.C) {
+	// Replace the watcher started by SetUpTest with one signalled
+	// by charm timers.
+	s.watcher.Kill()
+	c.Assert(s.watcher.Wait(), jc.ErrorIsNil)
+
+	charmTimerChannel := make(chan struct{})
+	cfg := s.setupWatcherConfig()
+	cfg.CharmTimerChannel = charmTimerChannel
+	w, err := remotestate.NewWatcher(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	s.watcher = w
+
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	select {
+	case charmTimerChannel <- struct{}{}:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting to signal charm timer channel")
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+}
+
+func (s *WatcherSuiteIAAS) newSecretsWatcher(c *gc.C, client *mockSecretsClient) {
+	// Replace the watcher started by SetUpTest with one watching secrets.
+	s.watcher.Kill()
+	c.Assert(s.watcher.Wait(), jc.ErrorIsNil)
+
+	cfg := s.setupWatcherConfig()
+	cfg.SecretsClient = client
+	cfg.Clock = s.clock
+	w, err := remotestate.NewWatcher(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	s.watcher = w
+}
+
+func (s *WatcherSuiteIAAS) TestConsumedSecretsChanged(c *gc.C) {
+	client := &mockSecretsClient{
+		consumedSecretsWatcher: newMockStringsWatcher(),
+		secretsRotationWatcher: newMockStringsWatcher(),
+		revisions:              map[string]int{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30": 2},
+	}
+	s.newSecretsWatcher(c, client)
+	s.signalAll()
+	client.consumedSecretsWatcher.changes <- []string{}
+	client.secretsRotationWatcher.changes <- []string{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().ConsumedSecretInfo, gc.HasLen, 0)
+
+	client.consumedSecretsWatcher.changes <- []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().ConsumedSecretInfo, jc.DeepEquals, map[string]int{
+		"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30": 2,
+	})
+}
+
+func (s *WatcherSuiteIAAS) TestSecretsRotationDue(c *gc.C) {
+	uri := secrets.NewURI()
+	next := s.clock.Now().Add(time.Hour)
+	client := &mockSecretsClient{
+		consumedSecretsWatcher: newMockStringsWatcher(),
+		secretsRotationWatcher: newMockStringsWatcher(),
+		metadata: []secrets.SecretMetadata{{
+			URI:            uri,
+			RotatePolicy:   secrets.RotateHourly,
+			NextRotateTime: &next,
+		}},
+	}
+	s.newSecretsWatcher(c, client)
+	s.signalAll()
+	client.consumedSecretsWatcher.changes <- []string{}
+	client.secretsRotationWatcher.changes <- []string{uri.String()}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, gc.HasLen, 0)
+
+	s.waitAlarmsStable(c)
+	s.clock.Advance(time.Hour)
+	// The update status timer fires too, so wait for the rotation.
+	timeout := time.After(coretesting.LongWait)
+	for len(s.watcher.Snapshot().SecretRotations) == 0 {
+		select {
+		case <-s.watcher.RemoteStateChanged():
+		case <-timeout:
+			c.Fatalf("timed out waiting for secret rotation")
+		}
+	}
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, []string{uri.String()})
+
+	s.watcher.RotateSecretCompleted(uri.String())
+	c.Assert(s.watcher.Snapshot().SecretRotations, gc.HasLen, 0)
+}
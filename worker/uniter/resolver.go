@@ -23,7 +23,8 @@ import (
 type ResolverConfig struct {
 	ModelType           model.ModelType
 	ClearResolved       func() error
-	ReportHookError     func(hook.Info) error
+	ClearHookCancel     func() error
+	ReportHookError     func(hook.Info, string) error
 	ShouldRetryHooks    bool
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
@@ -63,6 +64,15 @@ func (s *uniterResolver) NextOp(
 	}
 	logger := s.config.Logger
 
+	// A request to cancel a hook only applies to the hook running when
+	// it was made, so any request still pending once that hook has
+	// finished is dropped.
+	if remoteState.HookCancelRequested {
+		if err := s.config.ClearHookCancel(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	// Operations for series-upgrade need to be resolved early,
 	// in particular because no other operations should be run when the unit
 	// has completed preparation and is waiting for upgrade completion.
@@ -230,7 +240,7 @@ func (s *uniterResolver) nextOpHookError(
 ) (operation.Operation, error) {
 
 	// Report the hook error.
-	if err := s.config.ReportHookError(*localState.Hook); err != nil {
+	if err := s.config.ReportHookError(*localState.Hook, localState.HookFailure); err != nil {
 		return nil, errors.Trace(err)
 	}
 
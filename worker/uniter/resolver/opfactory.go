@@ -176,6 +176,22 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func(*operation.State) {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.SecretChanged:
+		uri := info.SecretURI
+		revision := s.RemoteState.ConsumedSecretInfo[uri]
+		op = onCommitWrapper{op, func(state *operation.State) {
+			if state == nil {
+				return
+			}
+			// Copy the map so the committed state doesn't share
+			// it with the previous state.
+			revisions := make(map[string]int, len(state.SecretRevisions)+1)
+			for k, v := range state.SecretRevisions {
+				revisions[k] = v
+			}
+			revisions[uri] = revision
+			state.SecretRevisions = revisions
+		}}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
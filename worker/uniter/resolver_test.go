@@ -41,7 +41,8 @@ type baseResolverSuite struct {
 	resolverConfig uniter.ResolverConfig
 
 	clearResolved   func() error
-	reportHookError func(hook.Info) error
+	clearHookCancel func() error
+	reportHookError func(hook.Info, string) error
 
 	workloadEvents        container.WorkloadEvents
 	firstOptionalResolver *fakeResolver
@@ -102,7 +103,8 @@ func (s *baseResolverSuite) SetUpTest(c *gc.C, modelType model.ModelType, reboot
 	s.lastOptionalResolver = &fakeResolver{}
 	s.resolverConfig = uniter.ResolverConfig{
 		ClearResolved:       func() error { return s.clearResolved() },
-		ReportHookError:     func(info hook.Info) error { return s.reportHookError(info) },
+		ClearHookCancel:     func() error { return s.clearHookCancel() },
+		ReportHookError:     func(info hook.Info, failure string) error { return s.reportHookError(info, failure) },
 		StartRetryHookTimer: func() { s.stub.AddCall("StartRetryHookTimer") },
 		StopRetryHookTimer:  func() { s.stub.AddCall("StopRetryHookTimer") },
 		ShouldRetryHooks:    true,
@@ -140,7 +142,11 @@ func (s *baseResolverSuite) SetUpTest(c *gc.C, modelType model.ModelType, reboot
 		}
 	}
 
-	s.reportHookError = func(hook.Info) error {
+	s.clearHookCancel = func() error {
+		return errors.New("unexpected hook cancel")
+	}
+
+	s.reportHookError = func(hook.Info, string) error {
 		return nil
 		//return errors.New("unexpected report hook error")
 	}
@@ -253,7 +259,7 @@ func (s *iaasResolverSuite) TestUniterIdlesWhenRemoteStateIsUpgradeSeriesComplet
 func (s *resolverSuite) TestHookErrorDoesNotStartRetryTimerIfShouldRetryFalse(c *gc.C) {
 	s.resolverConfig.ShouldRetryHooks = false
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, string) error { return nil }
 	localState := resolver.LocalState{
 		CharmURL: s.charmURL,
 		State: operation.State{
@@ -273,7 +279,7 @@ func (s *resolverSuite) TestHookErrorDoesNotStartRetryTimerIfShouldRetryFalse(c
 }
 
 func (s *resolverSuite) TestHookErrorStartRetryTimer(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, string) error { return nil }
 	localState := resolver.LocalState{
 		CharmURL: s.charmURL,
 		State: operation.State{
@@ -298,7 +304,7 @@ func (s *resolverSuite) TestHookErrorStartRetryTimer(c *gc.C) {
 }
 
 func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, string) error { return nil }
 	localState := resolver.LocalState{
 		CharmURL: s.charmURL,
 		State: operation.State{
@@ -328,6 +334,51 @@ func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StartRetryHookTimer")
 }
 
+func (s *resolverSuite) TestHookErrorReportsFailure(c *gc.C) {
+	var reported string
+	s.reportHookError = func(_ hook.Info, failure string) error {
+		reported = failure
+		return nil
+	}
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:        operation.RunHook,
+			Step:        operation.Pending,
+			Installed:   true,
+			Started:     true,
+			Hook:        &hook.Info{Kind: hooks.ConfigChanged},
+			HookFailure: "timed out after 10m0s",
+		},
+	}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(reported, gc.Equals, "timed out after 10m0s")
+}
+
+func (s *resolverSuite) TestHookCancelRequestCleared(c *gc.C) {
+	cleared := false
+	s.clearHookCancel = func() error {
+		cleared = true
+		return nil
+	}
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:        operation.RunHook,
+			Step:        operation.Pending,
+			Installed:   true,
+			Started:     true,
+			Hook:        &hook.Info{Kind: hooks.ConfigChanged},
+			HookFailure: "cancelled",
+		},
+	}
+	s.remoteState.HookCancelRequested = true
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(cleared, jc.IsTrue)
+}
+
 func (s *resolverSuite) TestResolvedRetryHooksStopRetryTimer(c *gc.C) {
 	// Resolving a failed hook should stop the retry timer.
 	s.testResolveHookErrorStopRetryTimer(c, params.ResolvedRetryHooks)
@@ -341,7 +392,7 @@ func (s *resolverSuite) TestResolvedNoHooksStopRetryTimer(c *gc.C) {
 func (s *resolverSuite) testResolveHookErrorStopRetryTimer(c *gc.C, mode params.ResolvedMode) {
 	s.stub.ResetCalls()
 	s.clearResolved = func() error { return nil }
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, string) error { return nil }
 	localState := resolver.LocalState{
 		CharmURL: s.charmURL,
 		State: operation.State{
@@ -366,7 +417,7 @@ func (s *resolverSuite) testResolveHookErrorStopRetryTimer(c *gc.C, mode params.
 }
 
 func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, string) error { return nil }
 	localState := resolver.LocalState{
 		CharmURL: s.charmURL,
 		State: operation.State{
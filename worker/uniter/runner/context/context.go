@@ -28,7 +28,9 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/timers"
 	"github.com/juju/juju/juju/sockets"
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/worker/common/charmrunner"
@@ -76,6 +78,9 @@ type Paths interface {
 
 // Clock defines the methods of the full clock.Clock that are needed here.
 type Clock interface {
+	// Now returns the current clock time.
+	Now() time.Time
+
 	// After waits for the duration to elapse and then sends the
 	// current time on the returned channel.
 	After(time.Duration) <-chan time.Time
@@ -302,6 +307,28 @@ type HookContext struct {
 	// workloadName is the name of the container which the hook is in relation to.
 	workloadName string
 
+	// secrets provides access to the secrets api facade.
+	secrets SecretsAccessor
+
+	// secretURI is the URI of the secret relevant to a secret hook.
+	secretURI string
+
+	// timerName is the name of the timer relevant to a timer hook.
+	timerName string
+
+	// timerChanges holds the timers set by the hook, keyed by name,
+	// with nil recording a deleted timer. They are recorded in the
+	// operation state once the hook has run successfully.
+	timerChanges map[string]*timers.Timer
+
+	// hookTimeout is how long a hook may run for before it is killed,
+	// or zero if hooks aren't limited.
+	hookTimeout time.Duration
+
+	// hookCancel is closed when cancellation of the running hook is
+	// requested.
+	hookCancel <-chan struct{}
+
 	mu sync.Mutex
 }
 
@@ -486,6 +513,26 @@ func (ctx *HookContext) SetProcess(process HookProcess) {
 	ctx.process = process
 }
 
+// HookTimeout returns how long a hook run in this context may run for
+// before it is killed, or zero if it isn't limited.
+// Implements runner.Context.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
+// HookCancel returns a channel which is closed when cancellation of the
+// hook run in this context is requested.
+// Implements runner.Context.
+func (ctx *HookContext) HookCancel() <-chan struct{} {
+	return ctx.hookCancel
+}
+
+// SetHookCancel records the channel which is closed when cancellation of
+// the hook run in this context is requested.
+func (ctx *HookContext) SetHookCancel(cancel <-chan struct{}) {
+	ctx.hookCancel = cancel
+}
+
 // Id returns an integer which uniquely identifies the relation.
 // Implements jujuc.HookContext.ContextRelation, part of runner.Context.
 func (ctx *HookContext) Id() string {
@@ -732,6 +779,141 @@ func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	return result, nil
 }
 
+// GetSecret returns the value of the specified secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) GetSecret(uri *secrets.URI, label string, update, peek bool) (secrets.SecretValue, error) {
+	if ctx.secrets == nil {
+		return nil, errors.NotSupportedf("secrets")
+	}
+	return ctx.secrets.GetValue(uri, label, peek, update)
+}
+
+// CreateSecret creates a secret with the specified data.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) CreateSecret(args *jujuc.SecretUpsertArgs) (*secrets.URI, error) {
+	if ctx.secrets == nil {
+		return nil, errors.NotSupportedf("secrets")
+	}
+	if args.Value == nil || args.Value.IsEmpty() {
+		return nil, errors.NotValidf("empty secret value")
+	}
+	arg := params.CreateSecretArg{
+		OwnerTag: names.NewApplicationTag(ctx.unit.ApplicationName()).String(),
+		Data:     args.Value.Values(),
+	}
+	if args.Description != nil {
+		arg.Description = *args.Description
+	}
+	if args.Label != nil {
+		arg.Label = *args.Label
+	}
+	if args.RotatePolicy != nil {
+		arg.RotatePolicy = string(*args.RotatePolicy)
+	}
+	return ctx.secrets.Create(arg)
+}
+
+// UpdateSecret creates a new revision of a secret and/or updates its
+// metadata.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) UpdateSecret(uri *secrets.URI, args *jujuc.SecretUpsertArgs) error {
+	if ctx.secrets == nil {
+		return errors.NotSupportedf("secrets")
+	}
+	arg := params.UpdateSecretArg{
+		URI:         uri.String(),
+		Description: args.Description,
+		Label:       args.Label,
+	}
+	if args.Value != nil {
+		arg.Data = args.Value.Values()
+	}
+	if args.RotatePolicy != nil {
+		policy := string(*args.RotatePolicy)
+		arg.RotatePolicy = &policy
+	}
+	return ctx.secrets.Update(arg)
+}
+
+// GrantSecret grants access to the specified secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) GrantSecret(uri *secrets.URI, args *jujuc.SecretGrantRevokeArgs) error {
+	if ctx.secrets == nil {
+		return errors.NotSupportedf("secrets")
+	}
+	role := secrets.RoleView
+	if args.Role != nil {
+		role = *args.Role
+	}
+	return ctx.secrets.Grant(uri, secretSubjectTags(args), role)
+}
+
+// RevokeSecret revokes access to the specified secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) RevokeSecret(uri *secrets.URI, args *jujuc.SecretGrantRevokeArgs) error {
+	if ctx.secrets == nil {
+		return errors.NotSupportedf("secrets")
+	}
+	return ctx.secrets.Revoke(uri, secretSubjectTags(args))
+}
+
+// SetTimer schedules the named timer.
+// Implements jujuc.HookContext.ContextTimers, part of runner.Context.
+func (ctx *HookContext) SetTimer(name string, delay, every, jitter time.Duration) error {
+	if err := ctx.checkTimerName(name); err != nil {
+		return errors.Trace(err)
+	}
+	timer, err := timers.New(ctx.clock.Now(), delay, every, jitter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ctx.timerChanges == nil {
+		ctx.timerChanges = make(map[string]*timers.Timer)
+	}
+	ctx.timerChanges[name] = &timer
+	return nil
+}
+
+// DeleteTimer cancels the named timer.
+// Implements jujuc.HookContext.ContextTimers, part of runner.Context.
+func (ctx *HookContext) DeleteTimer(name string) error {
+	if err := ctx.checkTimerName(name); err != nil {
+		return errors.Trace(err)
+	}
+	if ctx.timerChanges == nil {
+		ctx.timerChanges = make(map[string]*timers.Timer)
+	}
+	ctx.timerChanges[name] = nil
+	return nil
+}
+
+// checkTimerName returns an error if the timer name is not valid, or if
+// the context is not running a hook. Timer changes are recorded when a
+// hook completes, so they can't be made by actions or commands.
+func (ctx *HookContext) checkTimerName(name string) error {
+	if ctx.hookName == "" {
+		return errors.NotSupportedf("timers outside of hooks")
+	}
+	return timers.ValidateName(name)
+}
+
+// TimerChanges returns the timers set and deleted by the hook, keyed
+// by name. A deleted timer has a nil value.
+func (ctx *HookContext) TimerChanges() map[string]*timers.Timer {
+	return ctx.timerChanges
+}
+
+func secretSubjectTags(args *jujuc.SecretGrantRevokeArgs) []string {
+	var tags []string
+	if args.ApplicationName != nil {
+		tags = append(tags, names.NewApplicationTag(*args.ApplicationName).String())
+	}
+	if args.UnitName != nil {
+		tags = append(tags, names.NewUnitTag(*args.UnitName).String())
+	}
+	return tags
+}
+
 // GoalState returns the goal state for the current unit.
 // Implements jujuc.HookContext.ContextUnit, part of runner.Context.
 func (ctx *HookContext) GoalState() (*application.GoalState, error) {
@@ -1014,6 +1196,12 @@ func (ctx *HookContext) HookVars(paths Paths, remote bool, getEnv GetEnvFunc) ([
 	if ctx.workloadName != "" {
 		vars = append(vars, "JUJU_WORKLOAD_NAME="+ctx.workloadName)
 	}
+	if ctx.secretURI != "" {
+		vars = append(vars, "JUJU_SECRET_ID="+ctx.secretURI)
+	}
+	if ctx.timerName != "" {
+		vars = append(vars, "JUJU_TIMER_NAME="+ctx.timerName)
+	}
 	return append(vars, OSDependentEnvVars(paths, getEnv)...), nil
 }
 
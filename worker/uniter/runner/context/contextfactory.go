@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
@@ -59,6 +60,25 @@ type StorageContextAccessor interface {
 	Storage(names.StorageTag) (jujuc.ContextStorageAttachment, error)
 }
 
+// SecretsAccessor is used by the hook context to access the secrets
+// api facade.
+type SecretsAccessor interface {
+	// Create creates a new secret owned by the unit's application.
+	Create(params.CreateSecretArg) (*secrets.URI, error)
+
+	// Update updates a secret owned by the unit's application.
+	Update(params.UpdateSecretArg) error
+
+	// GetValue returns the value of a secret.
+	GetValue(uri *secrets.URI, label string, peek, update bool) (secrets.SecretValue, error)
+
+	// Grant gives the applications or units access to a secret.
+	Grant(uri *secrets.URI, subjectTags []string, role secrets.SecretRole) error
+
+	// Revoke removes the access the applications or units have to a secret.
+	Revoke(uri *secrets.URI, subjectTags []string) error
+}
+
 // RelationsFunc is used to get snapshots of relation membership at context
 // creation time.
 type RelationsFunc func() map[int]*RelationInfo
@@ -78,6 +98,7 @@ type contextFactory struct {
 	modelType  model.ModelType
 	machineTag names.MachineTag
 	storage    StorageContextAccessor
+	secrets    SecretsAccessor
 	clock      Clock
 	zone       string
 	principal  string
@@ -98,6 +119,7 @@ type FactoryConfig struct {
 	Tracker          leadership.Tracker
 	GetRelationInfos RelationsFunc
 	Storage          StorageContextAccessor
+	Secrets          SecretsAccessor
 	Paths            Paths
 	Clock            Clock
 	Logger           loggo.Logger
@@ -144,6 +166,7 @@ func NewContextFactory(config FactoryConfig) (ContextFactory, error) {
 		getRelationInfos: config.GetRelationInfos,
 		relationCaches:   map[int]*RelationCache{},
 		storage:          config.Storage,
+		secrets:          config.Secrets,
 		rand:             rand.New(rand.NewSource(time.Now().Unix())),
 		clock:            config.Clock,
 		zone:             zone,
@@ -178,6 +201,7 @@ func (f *contextFactory) coreContext() (*HookContext, error) {
 		relations:          f.getContextRelations(),
 		relationId:         -1,
 		storage:            f.storage,
+		secrets:            f.secrets,
 		clock:              f.clock,
 		logger:             f.logger,
 		componentDir:       f.paths.ComponentDir,
@@ -246,6 +270,13 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		ctx.workloadName = hookInfo.WorkloadName
 		hookName = fmt.Sprintf("%s-%s", hookInfo.WorkloadName, hookName)
 	}
+	if hook.IsSecret(hookInfo.Kind) {
+		ctx.secretURI = hookInfo.SecretURI
+	}
+	if hookInfo.Kind == hook.TimerFired {
+		ctx.timerName = hookInfo.TimerName
+		hookName = fmt.Sprintf("%s-%s", hookInfo.TimerName, hookName)
+	}
 	ctx.id = f.newId(hookName)
 	ctx.hookName = hookName
 	return ctx, nil
@@ -330,6 +361,7 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 	}
 	ctx.legacyProxySettings = modelConfig.LegacyProxySettings()
 	ctx.jujuProxySettings = modelConfig.JujuProxySettings()
+	ctx.hookTimeout = modelConfig.HookTimeout(f.unit.ApplicationName())
 
 	statusCode, statusInfo, err := f.unit.MeterStatus()
 	if err != nil {
@@ -5,6 +5,7 @@ package context_test
 
 import (
 	"os"
+	"strings"
 	"time"
 
 	"github.com/juju/charm/v9/hooks"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/timers"
 	"github.com/juju/juju/environs"
 	environscontext "github.com/juju/juju/environs/context"
 	"github.com/juju/juju/feature"
@@ -220,6 +222,55 @@ func (s *ContextFactorySuite) TestWorkloadHookContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestSecretHookContext(c *gc.C) {
+	hi := hook.Info{
+		Kind:      hook.SecretChanged,
+		SecretURI: "secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30",
+	}
+	ctx, err := s.factory.HookContext(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertCoreContext(c, ctx)
+	s.AssertNotActionContext(c, ctx)
+	s.AssertNotRelationContext(c, ctx)
+	s.AssertNotStorageContext(c, ctx)
+	s.AssertNotWorkloadContext(c, ctx)
+
+	vars, err := ctx.HookVars(s.paths, false, func(string) string { return "" })
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.Join(vars, "\n"), jc.Contains, "JUJU_SECRET_ID=secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30")
+}
+
+func (s *ContextFactorySuite) TestTimerHookContext(c *gc.C) {
+	hi := hook.Info{
+		Kind:      hook.TimerFired,
+		TimerName: "backup",
+	}
+	ctx, err := s.factory.HookContext(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertCoreContext(c, ctx)
+	s.AssertNotActionContext(c, ctx)
+	s.AssertNotRelationContext(c, ctx)
+	s.AssertNotStorageContext(c, ctx)
+	s.AssertNotWorkloadContext(c, ctx)
+
+	vars, err := ctx.HookVars(s.paths, false, func(string) string { return "" })
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.Join(vars, "\n"), jc.Contains, "JUJU_TIMER_NAME=backup")
+
+	err = ctx.SetTimer("backup", 10*time.Minute, 0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteTimer("cleanup")
+	c.Assert(err, jc.ErrorIsNil)
+	due := time.Time{}.Add(10 * time.Minute).UTC()
+	c.Assert(ctx.TimerChanges(), jc.DeepEquals, map[string]*timers.Timer{
+		"backup":  {Due: due, Next: due},
+		"cleanup": nil,
+	})
+
+	err = ctx.SetTimer("backup", 0, 0, 0)
+	c.Assert(err, gc.ErrorMatches, "timer needs a delay or an interval")
+}
+
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
 	// We need to set up a unit that has storage metadata defined.
 	ch := s.AddTestingCharm(c, "storage-block")
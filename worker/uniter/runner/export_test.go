@@ -17,3 +17,7 @@ var (
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
+
+func RunHookOnRemote(rnr Runner, hookName string) (HookHandlerType, error) {
+	return rnr.(*runner).runCharmHookWithLocation(hookName, "hooks", runOnRemote)
+}
@@ -24,8 +24,9 @@ type Factory interface {
 	NewCommandRunner(commandInfo context.CommandInfo) (Runner, error)
 
 	// NewHookRunner returns an execution context suitable for running the
-	// supplied hook definition (which must be valid).
-	NewHookRunner(hookInfo hook.Info) (Runner, error)
+	// supplied hook definition (which must be valid). The hook is
+	// interrupted if cancel is closed.
+	NewHookRunner(hookInfo hook.Info, cancel <-chan struct{}) (Runner, error)
 
 	// NewActionRunner returns an execution context suitable for running the action.
 	NewActionRunner(action *uniter.Action, cancel <-chan struct{}) (Runner, error)
@@ -71,7 +72,7 @@ func (f *factory) NewCommandRunner(commandInfo context.CommandInfo) (Runner, err
 }
 
 // NewHookRunner exists to satisfy the Factory interface.
-func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
+func (f *factory) NewHookRunner(hookInfo hook.Info, cancel <-chan struct{}) (Runner, error) {
 	if err := hookInfo.Validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -80,6 +81,7 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	ctx.SetHookCancel(cancel)
 	runner := f.newProcessRunner(ctx, f.paths, f.remoteExecutor)
 	return runner, nil
 }
@@ -109,13 +109,13 @@ func (s *FactorySuite) TestNewCommandRunnerInferRemoteUnit(c *gc.C) {
 }
 
 func (s *FactorySuite) TestNewHookRunner(c *gc.C) {
-	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.AssertPaths(c, rnr)
 }
 
 func (s *FactorySuite) TestNewHookRunnerWithBadHook(c *gc.C) {
-	rnr, err := s.factory.NewHookRunner(hook.Info{})
+	rnr, err := s.factory.NewHookRunner(hook.Info{}, nil)
 	c.Assert(rnr, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, `unknown hook kind ""`)
 }
@@ -189,7 +189,7 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 	rnr, err := factory.NewHookRunner(hook.Info{
 		Kind:      hooks.StorageAttached,
 		StorageId: "data/0",
-	})
+	}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.AssertPaths(c, rnr)
 	ctx := rnr.Context()
@@ -200,7 +200,7 @@ func (s *FactorySuite) TestNewHookRunnerWithRelation(c *gc.C) {
 	rnr, err := s.factory.NewHookRunner(hook.Info{
 		Kind:       hooks.RelationBroken,
 		RelationId: 1,
-	})
+	}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.AssertPaths(c, rnr)
 }
@@ -209,7 +209,7 @@ func (s *FactorySuite) TestNewHookRunnerWithBadRelation(c *gc.C) {
 	rnr, err := s.factory.NewHookRunner(hook.Info{
 		Kind:       hooks.RelationBroken,
 		RelationId: 12345,
-	})
+	}, nil)
 	c.Assert(rnr, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, `unknown relation id: 12345`)
 }
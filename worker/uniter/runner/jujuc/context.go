@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/storage"
 )
 
@@ -41,6 +42,8 @@ type HookContext interface {
 	ContextNetworking
 	ContextLeadership
 	ContextMetrics
+	ContextSecrets
+	ContextTimers
 	ContextStorage
 	ContextComponents
 	ContextRelations
@@ -228,6 +231,56 @@ type ContextLeadership interface {
 	WriteLeaderSettings(map[string]string) error
 }
 
+// SecretUpsertArgs specifies args used to create or update a secret.
+// Nil values are not included in the update.
+type SecretUpsertArgs struct {
+	Value        secrets.SecretValue
+	RotatePolicy *secrets.RotatePolicy
+	Description  *string
+	Label        *string
+}
+
+// SecretGrantRevokeArgs specify the args used to grant or revoke access
+// to a secret.
+type SecretGrantRevokeArgs struct {
+	ApplicationName *string
+	UnitName        *string
+	Role            *secrets.SecretRole
+}
+
+// ContextSecrets is the part of a hook context related to secrets.
+type ContextSecrets interface {
+	// GetSecret returns the value of the specified secret, identified
+	// either by its URI or by the label the unit gave it.
+	GetSecret(uri *secrets.URI, label string, update, peek bool) (secrets.SecretValue, error)
+
+	// CreateSecret creates a secret with the specified data.
+	CreateSecret(args *SecretUpsertArgs) (*secrets.URI, error)
+
+	// UpdateSecret creates a new revision of a secret and/or updates
+	// its metadata.
+	UpdateSecret(uri *secrets.URI, args *SecretUpsertArgs) error
+
+	// GrantSecret grants access to the specified secret.
+	GrantSecret(uri *secrets.URI, args *SecretGrantRevokeArgs) error
+
+	// RevokeSecret revokes access to the specified secret.
+	RevokeSecret(uri *secrets.URI, args *SecretGrantRevokeArgs) error
+}
+
+// ContextTimers is the part of a hook context related to the timers
+// scheduled by the charm.
+type ContextTimers interface {
+	// SetTimer schedules the named timer, replacing any timer with the
+	// same name. The timer first fires after delay, or after every if
+	// delay is zero, and repeats at every if that is non-zero. Each
+	// firing is delayed by a random amount no greater than jitter.
+	SetTimer(name string, delay, every, jitter time.Duration) error
+
+	// DeleteTimer cancels the named timer.
+	DeleteTimer(name string) error
+}
+
 // ContextMetrics is the part of a hook context related to metrics.
 type ContextMetrics interface {
 	// AddMetric records a metric to return after hook execution.
@@ -18,6 +18,7 @@ type ContextInfo struct {
 	NetworkInterface
 	Leadership
 	Metrics
+	SecretsContext
 	Storage
 	Components
 	Relations
@@ -65,6 +66,8 @@ type Context struct {
 	ContextNetworking
 	ContextLeader
 	ContextMetrics
+	ContextSecrets
+	ContextTimers
 	ContextStorage
 	ContextComponents
 	ContextRelations
@@ -89,6 +92,9 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextLeader.info = &info.Leadership
 	ctx.ContextMetrics.stub = stub
 	ctx.ContextMetrics.info = &info.Metrics
+	ctx.ContextSecrets.stub = stub
+	ctx.ContextSecrets.info = &info.SecretsContext
+	ctx.ContextTimers.stub = stub
 	ctx.ContextStorage.stub = stub
 	ctx.ContextStorage.info = &info.Storage
 	ctx.ContextComponents.stub = stub
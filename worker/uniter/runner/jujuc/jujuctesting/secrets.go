@@ -0,0 +1,59 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// SecretsContext holds the values for the hook context.
+type SecretsContext struct {
+	SecretValue secrets.SecretValue
+	SecretURI   *secrets.URI
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *SecretsContext
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(uri *secrets.URI, label string, update, peek bool) (secrets.SecretValue, error) {
+	c.stub.AddCall("GetSecret", uri, label, update, peek)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.info.SecretValue, nil
+}
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) CreateSecret(args *jujuc.SecretUpsertArgs) (*secrets.URI, error) {
+	c.stub.AddCall("CreateSecret", args)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.info.SecretURI, nil
+}
+
+// UpdateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) UpdateSecret(uri *secrets.URI, args *jujuc.SecretUpsertArgs) error {
+	c.stub.AddCall("UpdateSecret", uri, args)
+	return errors.Trace(c.stub.NextErr())
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(uri *secrets.URI, args *jujuc.SecretGrantRevokeArgs) error {
+	c.stub.AddCall("GrantSecret", uri, args)
+	return errors.Trace(c.stub.NextErr())
+}
+
+// RevokeSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) RevokeSecret(uri *secrets.URI, args *jujuc.SecretGrantRevokeArgs) error {
+	c.stub.AddCall("RevokeSecret", uri, args)
+	return errors.Trace(c.stub.NextErr())
+}
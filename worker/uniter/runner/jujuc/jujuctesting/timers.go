@@ -0,0 +1,27 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// ContextTimers is a test double for jujuc.ContextTimers.
+type ContextTimers struct {
+	contextBase
+}
+
+// SetTimer implements jujuc.ContextTimers.
+func (c *ContextTimers) SetTimer(name string, delay, every, jitter time.Duration) error {
+	c.stub.AddCall("SetTimer", name, delay, every, jitter)
+	return errors.Trace(c.stub.NextErr())
+}
+
+// DeleteTimer implements jujuc.ContextTimers.
+func (c *ContextTimers) DeleteTimer(name string) error {
+	c.stub.AddCall("DeleteTimer", name)
+	return errors.Trace(c.stub.NextErr())
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/secrets"
 )
 
 // ErrRestrictedContext indicates a method is not implemented in the given context.
@@ -137,6 +138,39 @@ func (*RestrictedContext) LeaderSettings() (map[string]string, error) {
 // WriteLeaderSettings implements hooks.Context.
 func (*RestrictedContext) WriteLeaderSettings(map[string]string) error { return ErrRestrictedContext }
 
+// GetSecret implements hooks.Context.
+func (*RestrictedContext) GetSecret(*secrets.URI, string, bool, bool) (secrets.SecretValue, error) {
+	return nil, ErrRestrictedContext
+}
+
+// CreateSecret implements hooks.Context.
+func (*RestrictedContext) CreateSecret(*SecretUpsertArgs) (*secrets.URI, error) {
+	return nil, ErrRestrictedContext
+}
+
+// UpdateSecret implements hooks.Context.
+func (*RestrictedContext) UpdateSecret(*secrets.URI, *SecretUpsertArgs) error {
+	return ErrRestrictedContext
+}
+
+// GrantSecret implements hooks.Context.
+func (*RestrictedContext) GrantSecret(*secrets.URI, *SecretGrantRevokeArgs) error {
+	return ErrRestrictedContext
+}
+
+// RevokeSecret implements hooks.Context.
+func (*RestrictedContext) RevokeSecret(*secrets.URI, *SecretGrantRevokeArgs) error {
+	return ErrRestrictedContext
+}
+
+// SetTimer implements hooks.Context.
+func (*RestrictedContext) SetTimer(string, time.Duration, time.Duration, time.Duration) error {
+	return ErrRestrictedContext
+}
+
+// DeleteTimer implements hooks.Context.
+func (*RestrictedContext) DeleteTimer(string) error { return ErrRestrictedContext }
+
 // AddMetric implements hooks.Context.
 func (*RestrictedContext) AddMetric(string, string, time.Time) error { return ErrRestrictedContext }
 
@@ -0,0 +1,129 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/v2/keyvalues"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretUpsertCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	rotatePolicy string
+	description  string
+	label        string
+	data         map[string]string
+}
+
+func (c *secretUpsertCommand) setFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.rotatePolicy, "rotate", "", "the secret rotation policy")
+	f.StringVar(&c.description, "description", "", "the secret description")
+	f.StringVar(&c.label, "label", "", "a label used to identify the secret in hooks")
+}
+
+func (c *secretUpsertCommand) init(args []string) (err error) {
+	if c.rotatePolicy != "" && !secrets.RotatePolicy(c.rotatePolicy).IsValid() {
+		return errors.NotValidf("rotate policy %q", c.rotatePolicy)
+	}
+	c.data, err = keyvalues.Parse(args, true)
+	return err
+}
+
+// upsertArgs returns the args used to create or update the secret,
+// only including the attributes which were specified.
+func (c *secretUpsertCommand) upsertArgs(f func(string) bool) *SecretUpsertArgs {
+	args := &SecretUpsertArgs{}
+	if len(c.data) > 0 {
+		args.Value = secrets.NewSecretValue(c.data)
+	}
+	if f("rotate") {
+		policy := secrets.RotatePolicy(c.rotatePolicy)
+		args.RotatePolicy = &policy
+	}
+	if f("description") {
+		args.Description = &c.description
+	}
+	if f("label") {
+		args.Label = &c.label
+	}
+	return args
+}
+
+type secretAddCommand struct {
+	secretUpsertCommand
+	flags *gnuflag.FlagSet
+}
+
+// NewSecretAddCommand returns a command to add a secret.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &secretAddCommand{secretUpsertCommand: secretUpsertCommand{ctx: ctx}}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretAddCommand) Info() *cmd.Info {
+	doc := `
+Add a secret with a list of key values.
+
+The secret is owned by the unit's application, and only the leader
+unit may add secrets. The URI of the new secret is printed.
+
+Examples:
+    secret-add token=34ae35facd4
+    secret-add --rotate monthly password=s3cret
+    secret-add --label db-password --description "my database" password=s3cret
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-add",
+		Args:    "<key>=<value> [...]",
+		Purpose: "add a new secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.setFlags(f)
+	c.flags = f
+}
+
+// Init implements cmd.Command.
+func (c *secretAddCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("missing secret value")
+	}
+	return c.init(args)
+}
+
+// Run implements cmd.Command.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	uri, err := c.ctx.CreateSecret(c.upsertArgs(flagSet(c.flags)))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(ctx.Stdout, uri.String())
+	return nil
+}
+
+// flagSet returns a func reporting whether the named flag was
+// explicitly set on the command line.
+func flagSet(f *gnuflag.FlagSet) func(string) bool {
+	set := make(map[string]bool)
+	if f != nil {
+		f.Visit(func(fl *gnuflag.Flag) {
+			set[fl.Name] = true
+		})
+	}
+	return func(name string) bool {
+		return set[name]
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretAddSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretAddSuite{})
+
+func (s *SecretAddSuite) TestAddSecretInvalidArgs(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{},
+			err:  "ERROR missing secret value",
+		}, {
+			args: []string{"--rotate", "foo", "data"},
+			err:  `ERROR rotate policy "foo" not valid`,
+		}, {
+			args: []string{"data"},
+			err:  `ERROR expected "key=value", got "data"`,
+		},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Assert(code, gc.Equals, 2)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, t.err+"\n")
+	}
+}
+
+func (s *SecretAddSuite) TestAddSecret(c *gc.C) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SecretURI = &secrets.URI{ID: "5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"}
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"--rotate", "daily", "--description", "sssshhhh", "--label", "foobar",
+		"data=secret",
+	})
+
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30\n")
+	policy := secrets.RotateDaily
+	description := "sssshhhh"
+	label := "foobar"
+	s.Stub.CheckCallNames(c, "CreateSecret")
+	args := s.Stub.Calls()[0].Args[0].(*jujuc.SecretUpsertArgs)
+	c.Assert(args.Value.Values(), jc.DeepEquals, map[string]string{"data": "secret"})
+	c.Assert(args.RotatePolicy, jc.DeepEquals, &policy)
+	c.Assert(args.Description, jc.DeepEquals, &description)
+	c.Assert(args.Label, jc.DeepEquals, &label)
+}
+
+func (s *SecretAddSuite) TestAddSecretOnlyValue(c *gc.C) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SecretURI = &secrets.URI{ID: "5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"}
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"data=secret"})
+
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCallNames(c, "CreateSecret")
+	args := s.Stub.Calls()[0].Args[0].(*jujuc.SecretUpsertArgs)
+	c.Assert(args.Value.Values(), jc.DeepEquals, map[string]string{"data": "secret"})
+	c.Assert(args.RotatePolicy, gc.IsNil)
+	c.Assert(args.Description, gc.IsNil)
+	c.Assert(args.Label, gc.IsNil)
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+
+	secretURI *secrets.URI
+	label     string
+	key       string
+	peek      bool
+	update    bool
+}
+
+// NewSecretGetCommand returns a command to get a secret value.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretGetCommand) Info() *cmd.Info {
+	doc := `
+Get the content of a secret, either by its URI or by the label given
+to it when it was first read or created.
+
+The first time a unit reads a secret, it is tracked at the latest
+revision. New revisions are not returned until the unit asks for them:
+--peek returns the latest revision without tracking it, and --update
+returns the latest revision and tracks it from then on.
+
+Examples:
+    secret-get secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30
+    secret-get secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30 token
+    secret-get secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30 --label db-password
+    secret-get --label db-password --update
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-get",
+		Args:    "[<ID>] [<key>]",
+		Purpose: "get the content of a secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters.Formatters())
+	f.StringVar(&c.label, "label", "", "a label used to identify the secret in hooks")
+	f.BoolVar(&c.peek, "peek", false, "get the latest revision without tracking it")
+	f.BoolVar(&c.update, "update", false, "get the latest revision and track it from now on")
+}
+
+// Init implements cmd.Command.
+func (c *secretGetCommand) Init(args []string) (err error) {
+	if len(args) == 0 && c.label == "" {
+		return errors.New("require either a secret URI or label")
+	}
+	if c.peek && c.update {
+		return errors.New("specify one of --peek or --update but not both")
+	}
+	if len(args) > 0 {
+		if c.secretURI, err = secrets.ParseURI(args[0]); err != nil {
+			return errors.Trace(err)
+		}
+		args = args[1:]
+	}
+	if len(args) > 0 {
+		c.key = args[0]
+		args = args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	value, err := c.ctx.GetSecret(c.secretURI, c.label, c.update, c.peek)
+	if err != nil {
+		return err
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, value.Values())
+	}
+	val, err := value.KeyValue(c.key)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, val)
+}
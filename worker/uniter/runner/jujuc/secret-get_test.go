@@ -0,0 +1,105 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+func (s *SecretGetSuite) TestGetSecretInvalidArgs(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{},
+			err:  "ERROR require either a secret URI or label",
+		}, {
+			args: []string{"foo"},
+			err:  `ERROR secret URI "foo" not valid`,
+		}, {
+			args: []string{"--label", "foo", "--peek", "--update"},
+			err:  "ERROR specify one of --peek or --update but not both",
+		}, {
+			args: []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "key", "extra"},
+			err:  `ERROR unrecognized args: \["extra"\]`,
+		},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Assert(code, gc.Equals, 2)
+		c.Assert(bufferString(ctx.Stderr), gc.Matches, t.err+"\n")
+	}
+}
+
+func (s *SecretGetSuite) TestGetSecret(c *gc.C) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SecretValue = secrets.NewSecretValue(map[string]string{"cert": "abcd", "key": "xyz"})
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "--format", "yaml",
+	})
+
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "cert: abcd\nkey: xyz\n")
+	s.Stub.CheckCalls(c, []testing.StubCall{{
+		"GetSecret", []interface{}{&secrets.URI{ID: "5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"}, "", false, false},
+	}})
+}
+
+func (s *SecretGetSuite) TestGetSecretKey(c *gc.C) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SecretValue = secrets.NewSecretValue(map[string]string{"cert": "abcd", "key": "xyz"})
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "key", "--update",
+	})
+
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "xyz\n")
+	s.Stub.CheckCalls(c, []testing.StubCall{{
+		"GetSecret", []interface{}{&secrets.URI{ID: "5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"}, "", true, false},
+	}})
+}
+
+func (s *SecretGetSuite) TestGetSecretByLabel(c *gc.C) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SecretValue = secrets.NewSecretValue(map[string]string{"cert": "abcd"})
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"--label", "db-cert", "--peek",
+	})
+
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCalls(c, []testing.StubCall{{
+		"GetSecret", []interface{}{(*secrets.URI)(nil), "db-cert", false, true},
+	}})
+}
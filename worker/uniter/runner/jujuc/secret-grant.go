@@ -0,0 +1,102 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretGrantRevokeCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	secretURI *secrets.URI
+	app       string
+	unit      string
+}
+
+func (c *secretGrantRevokeCommand) setFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.app, "app", "", "the application to which access is granted or revoked")
+	f.StringVar(&c.unit, "unit", "", "the unit to which access is granted or revoked")
+}
+
+func (c *secretGrantRevokeCommand) init(args []string) (err error) {
+	if len(args) < 1 {
+		return errors.New("missing secret URI")
+	}
+	if c.secretURI, err = secrets.ParseURI(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	if (c.app == "") == (c.unit == "") {
+		return errors.New("specify one of --app or --unit")
+	}
+	if c.app != "" && !names.IsValidApplication(c.app) {
+		return errors.NotValidf("application %q", c.app)
+	}
+	if c.unit != "" && !names.IsValidUnit(c.unit) {
+		return errors.NotValidf("unit %q", c.unit)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *secretGrantRevokeCommand) grantRevokeArgs() *SecretGrantRevokeArgs {
+	args := &SecretGrantRevokeArgs{}
+	if c.app != "" {
+		args.ApplicationName = &c.app
+	}
+	if c.unit != "" {
+		args.UnitName = &c.unit
+	}
+	return args
+}
+
+type secretGrantCommand struct {
+	secretGrantRevokeCommand
+}
+
+// NewSecretGrantCommand returns a command to grant access to a secret.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	return &secretGrantCommand{secretGrantRevokeCommand{ctx: ctx}}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretGrantCommand) Info() *cmd.Info {
+	doc := `
+Grant an application or unit access to read a secret.
+
+Examples:
+    secret-grant secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30 --app mediawiki
+    secret-grant secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30 --unit mediawiki/6
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<ID>",
+		Purpose: "grant access to a secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretGrantCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.setFlags(f)
+}
+
+// Init implements cmd.Command.
+func (c *secretGrantCommand) Init(args []string) error {
+	return c.init(args)
+}
+
+// Run implements cmd.Command.
+func (c *secretGrantCommand) Run(_ *cmd.Context) error {
+	args := c.grantRevokeArgs()
+	role := secrets.RoleView
+	args.Role = &role
+	return c.ctx.GrantSecret(c.secretURI, args)
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGrantSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGrantSuite{})
+
+func (s *SecretGrantSuite) TestGrantSecretInvalidArgs(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{},
+			err:  "ERROR missing secret URI",
+		}, {
+			args: []string{"foo"},
+			err:  `ERROR secret URI "foo" not valid`,
+		}, {
+			args: []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"},
+			err:  "ERROR specify one of --app or --unit",
+		}, {
+			args: []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "--app", "foo", "--unit", "foo/0"},
+			err:  "ERROR specify one of --app or --unit",
+		}, {
+			args: []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "--app", "foo/0"},
+			err:  `ERROR application "foo/0" not valid`,
+		}, {
+			args: []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "--unit", "foo"},
+			err:  `ERROR unit "foo" not valid`,
+		},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Assert(code, gc.Equals, 2)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, t.err+"\n")
+	}
+}
+
+func (s *SecretGrantSuite) TestGrantSecretApp(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "--app", "mediawiki",
+	})
+
+	c.Assert(code, gc.Equals, 0)
+	app := "mediawiki"
+	role := secrets.RoleView
+	s.Stub.CheckCalls(c, []testing.StubCall{{
+		"GrantSecret", []interface{}{
+			&secrets.URI{ID: "5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"},
+			&jujuc.SecretGrantRevokeArgs{ApplicationName: &app, Role: &role},
+		},
+	}})
+}
+
+func (s *SecretGrantSuite) TestGrantSecretUnit(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "--unit", "mediawiki/6",
+	})
+
+	c.Assert(code, gc.Equals, 0)
+	unit := "mediawiki/6"
+	role := secrets.RoleView
+	s.Stub.CheckCalls(c, []testing.StubCall{{
+		"GrantSecret", []interface{}{
+			&secrets.URI{ID: "5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"},
+			&jujuc.SecretGrantRevokeArgs{UnitName: &unit, Role: &role},
+		},
+	}})
+}
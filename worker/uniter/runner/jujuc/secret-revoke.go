@@ -0,0 +1,52 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+type secretRevokeCommand struct {
+	secretGrantRevokeCommand
+}
+
+// NewSecretRevokeCommand returns a command to revoke access to a secret.
+func NewSecretRevokeCommand(ctx Context) (cmd.Command, error) {
+	return &secretRevokeCommand{secretGrantRevokeCommand{ctx: ctx}}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretRevokeCommand) Info() *cmd.Info {
+	doc := `
+Revoke access to a secret previously granted to an application or unit.
+
+Examples:
+    secret-revoke secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30 --app mediawiki
+    secret-revoke secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30 --unit mediawiki/6
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-revoke",
+		Args:    "<ID>",
+		Purpose: "revoke access to a secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretRevokeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.setFlags(f)
+}
+
+// Init implements cmd.Command.
+func (c *secretRevokeCommand) Init(args []string) error {
+	return c.init(args)
+}
+
+// Run implements cmd.Command.
+func (c *secretRevokeCommand) Run(_ *cmd.Context) error {
+	return c.ctx.RevokeSecret(c.secretURI, c.grantRevokeArgs())
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretRevokeSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretRevokeSuite{})
+
+func (s *SecretRevokeSuite) TestRevokeSecretInvalidArgs(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-revoke"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"})
+	c.Assert(code, gc.Equals, 2)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "ERROR specify one of --app or --unit\n")
+}
+
+func (s *SecretRevokeSuite) TestRevokeSecret(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-revoke"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "--app", "mediawiki",
+	})
+
+	c.Assert(code, gc.Equals, 0)
+	app := "mediawiki"
+	s.Stub.CheckCalls(c, []testing.StubCall{{
+		"RevokeSecret", []interface{}{
+			&secrets.URI{ID: "5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"},
+			&jujuc.SecretGrantRevokeArgs{ApplicationName: &app},
+		},
+	}})
+}
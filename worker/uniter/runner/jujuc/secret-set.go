@@ -0,0 +1,71 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretSetCommand struct {
+	secretUpsertCommand
+	flags *gnuflag.FlagSet
+
+	secretURI *secrets.URI
+}
+
+// NewSecretSetCommand returns a command to update a secret.
+func NewSecretSetCommand(ctx Context) (cmd.Command, error) {
+	return &secretSetCommand{secretUpsertCommand: secretUpsertCommand{ctx: ctx}}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretSetCommand) Info() *cmd.Info {
+	doc := `
+Update a secret with a new value and/or new metadata.
+Setting a new value creates a new revision of the secret; units reading
+the secret are notified with the secret-changed hook.
+
+Examples:
+    secret-set secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30 token=34ae35facd4
+    secret-set secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30 --rotate monthly
+    secret-set secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30 --description "my database"
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-set",
+		Args:    "<ID> [<key>=<value> [...]]",
+		Purpose: "update an existing secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretSetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.setFlags(f)
+	c.flags = f
+}
+
+// Init implements cmd.Command.
+func (c *secretSetCommand) Init(args []string) (err error) {
+	if len(args) < 1 {
+		return errors.New("missing secret URI")
+	}
+	if c.secretURI, err = secrets.ParseURI(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	return c.init(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *secretSetCommand) Run(_ *cmd.Context) error {
+	args := c.upsertArgs(flagSet(c.flags))
+	if args.Value == nil && args.RotatePolicy == nil && args.Description == nil && args.Label == nil {
+		return errors.New("nothing to update: specify a new value or new metadata")
+	}
+	return c.ctx.UpdateSecret(c.secretURI, args)
+}
@@ -0,0 +1,103 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretSetSuite{})
+
+func (s *SecretSetSuite) TestSetSecretInvalidArgs(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{},
+			err:  "ERROR missing secret URI",
+		}, {
+			args: []string{"foo"},
+			err:  `ERROR secret URI "foo" not valid`,
+		}, {
+			args: []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "--rotate", "foo"},
+			err:  `ERROR rotate policy "foo" not valid`,
+		}, {
+			args: []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "data"},
+			err:  `ERROR expected "key=value", got "data"`,
+		},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Assert(code, gc.Equals, 2)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, t.err+"\n")
+	}
+}
+
+func (s *SecretSetSuite) TestSetSecretNothingToUpdate(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"})
+
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "ERROR nothing to update: specify a new value or new metadata\n")
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *SecretSetSuite) TestSetSecret(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "--description", "", "data=secret",
+	})
+
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCallNames(c, "UpdateSecret")
+	call := s.Stub.Calls()[0]
+	c.Assert(call.Args[0], jc.DeepEquals, &secrets.URI{ID: "5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"})
+	args := call.Args[1].(*jujuc.SecretUpsertArgs)
+	c.Assert(args.Value.Values(), jc.DeepEquals, map[string]string{"data": "secret"})
+	description := ""
+	c.Assert(args.Description, jc.DeepEquals, &description)
+	c.Assert(args.RotatePolicy, gc.IsNil)
+	c.Assert(args.Label, gc.IsNil)
+}
+
+func (s *SecretSetSuite) TestSetSecretRotatePolicy(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30", "--rotate", "monthly",
+	})
+
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCallNames(c, "UpdateSecret")
+	args := s.Stub.Calls()[0].Args[1].(*jujuc.SecretUpsertArgs)
+	policy := secrets.RotateMonthly
+	c.Assert(args.Value, gc.IsNil)
+	c.Assert(args.RotatePolicy, jc.DeepEquals, &policy)
+}
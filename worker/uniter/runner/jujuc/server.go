@@ -104,6 +104,19 @@ var leaderCommands = map[string]creator{
 	"leader-set" + cmdSuffix: NewLeaderSetCommand,
 }
 
+var secretCommands = map[string]creator{
+	"secret-add" + cmdSuffix:    NewSecretAddCommand,
+	"secret-get" + cmdSuffix:    NewSecretGetCommand,
+	"secret-set" + cmdSuffix:    NewSecretSetCommand,
+	"secret-grant" + cmdSuffix:  NewSecretGrantCommand,
+	"secret-revoke" + cmdSuffix: NewSecretRevokeCommand,
+}
+
+var timerCommands = map[string]creator{
+	"timer-set" + cmdSuffix:    NewTimerSetCommand,
+	"timer-delete" + cmdSuffix: NewTimerDeleteCommand,
+}
+
 func allEnabledCommands() map[string]creator {
 	all := map[string]creator{}
 	add := func(m map[string]creator) {
@@ -114,6 +127,8 @@ func allEnabledCommands() map[string]creator {
 	add(baseCommands)
 	add(storageCommands)
 	add(leaderCommands)
+	add(secretCommands)
+	add(timerCommands)
 	add(registeredCommands)
 	return all
 }
@@ -0,0 +1,58 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/timers"
+)
+
+type timerDeleteCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	name string
+}
+
+// NewTimerDeleteCommand returns a command to cancel a charm timer.
+func NewTimerDeleteCommand(ctx Context) (cmd.Command, error) {
+	return &timerDeleteCommand{ctx: ctx}, nil
+}
+
+// Info implements cmd.Command.
+func (c *timerDeleteCommand) Info() *cmd.Info {
+	doc := `
+Cancel a timer scheduled with timer-set. Deleting a timer which
+doesn't exist is not an error.
+
+Examples:
+    timer-delete backup
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "timer-delete",
+		Args:    "<name>",
+		Purpose: "cancel a timer",
+		Doc:     doc,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *timerDeleteCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no timer name specified")
+	}
+	c.name = args[0]
+	if err := timers.ValidateName(c.name); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *timerDeleteCommand) Run(_ *cmd.Context) error {
+	return c.ctx.DeleteTimer(c.name)
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/timers"
+)
+
+type timerSetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	name   string
+	delay  time.Duration
+	every  time.Duration
+	jitter time.Duration
+}
+
+// NewTimerSetCommand returns a command to schedule a charm timer.
+func NewTimerSetCommand(ctx Context) (cmd.Command, error) {
+	return &timerSetCommand{ctx: ctx}, nil
+}
+
+// Info implements cmd.Command.
+func (c *timerSetCommand) Info() *cmd.Info {
+	doc := `
+Schedule a timer which runs the <name>-timer-fired hook when it is due.
+The hook is run with JUJU_TIMER_NAME set to the name of the timer.
+
+The timer fires once after --in, or repeats at --every. When both are
+given, the timer first fires after --in and then repeats. Setting a timer
+replaces any timer with the same name.
+
+Each firing is delayed by a random amount up to --jitter, so that the
+units of an application don't all fire at once. Timers are kept across
+unit agent restarts; firings missed while the agent was down are run once
+when it starts.
+
+Examples:
+    timer-set cleanup --in 10m
+    timer-set backup --every 24h --jitter 1h
+    timer-set report --in 5m --every 1h
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "timer-set",
+		Args:    "<name>",
+		Purpose: "schedule a timer",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *timerSetCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.DurationVar(&c.delay, "in", 0, "fire once after this delay")
+	f.DurationVar(&c.every, "every", 0, "fire repeatedly at this interval")
+	f.DurationVar(&c.jitter, "jitter", 0, "delay each firing by a random amount up to this")
+}
+
+// Init implements cmd.Command.
+func (c *timerSetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no timer name specified")
+	}
+	c.name = args[0]
+	if err := timers.ValidateName(c.name); err != nil {
+		return errors.Trace(err)
+	}
+	if err := timers.ValidateSchedule(c.delay, c.every, c.jitter); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *timerSetCommand) Run(_ *cmd.Context) error {
+	return c.ctx.SetTimer(c.name, c.delay, c.every, c.jitter)
+}
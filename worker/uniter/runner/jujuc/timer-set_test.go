@@ -0,0 +1,70 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type TimerSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&TimerSetSuite{})
+
+func (s *TimerSetSuite) TestTimerSetInvalidArgs(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{},
+			err:  "ERROR no timer name specified",
+		}, {
+			args: []string{"Backup", "--in", "1m"},
+			err:  `ERROR timer name "Backup" not valid`,
+		}, {
+			args: []string{"backup"},
+			err:  "ERROR timer needs a delay or an interval",
+		}, {
+			args: []string{"backup", "--every", "10s"},
+			err:  "ERROR interval 10s shorter than 1m0s not valid",
+		}, {
+			args: []string{"backup", "--in", "1m", "extra"},
+			err:  `ERROR unrecognized args: \["extra"\]`,
+		},
+	} {
+		hctx, _ := s.ContextSuite.NewHookContext()
+		com, err := jujuc.NewCommand(hctx, cmdString("timer-set"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Assert(code, gc.Equals, 2)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, t.err+"\n")
+	}
+}
+
+func (s *TimerSetSuite) TestTimerSet(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("timer-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"backup", "--in", "5m", "--every", "24h", "--jitter", "1h",
+	})
+
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCalls(c, []testing.StubCall{{
+		"SetTimer", []interface{}{"backup", 5 * time.Minute, 24 * time.Hour, time.Hour},
+	}})
+}
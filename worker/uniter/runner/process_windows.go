@@ -0,0 +1,24 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build windows
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// setProcessGroup does nothing on windows, where taskkill finds the
+// processes started by the command.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessTree kills the process and every process it started.
+func killProcessTree(p *os.Process) error {
+	if err := exec.Command("taskkill", "/F", "/T", "/PID", strconv.Itoa(p.Pid)).Run(); err != nil {
+		return p.Kill()
+	}
+	return nil
+}
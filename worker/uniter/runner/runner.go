@@ -28,6 +28,7 @@ import (
 
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/timers"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
@@ -104,6 +105,10 @@ type Runner interface {
 
 	// RunCommands executes the supplied script.
 	RunCommands(commands string, runLocation RunLocation) (*utilexec.ExecResponse, error)
+
+	// HookStderr returns the end of what the last hook run by this
+	// runner wrote to stderr.
+	HookStderr() string
 }
 
 // Context exposes hooks.Context, and additional methods needed by Runner.
@@ -113,9 +118,12 @@ type Context interface {
 	HookVars(paths context.Paths, remote bool, getEnvFunc context.GetEnvFunc) ([]string, error)
 	ActionData() (*context.ActionData, error)
 	SetProcess(process context.HookProcess)
+	HookTimeout() time.Duration
+	HookCancel() <-chan struct{}
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
 	ModelType() model.ModelType
+	TimerChanges() map[string]*timers.Timer
 
 	Prepare() error
 	Flush(badge string, failure error) error
@@ -128,7 +136,11 @@ type NewRunnerFunc func(context Context, paths context.Paths, remoteExecutor Exe
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths, remoteExecutor ExecFunc) Runner {
-	return &runner{context, paths, remoteExecutor}
+	return &runner{
+		context:        context,
+		paths:          paths,
+		remoteExecutor: remoteExecutor,
+	}
 }
 
 // ExecParams holds all the necessary parameters for ExecFunc.
@@ -176,6 +188,8 @@ type runner struct {
 	paths   context.Paths
 	// remoteExecutor executes commands on a remote workload pod for CAAS.
 	remoteExecutor ExecFunc
+	// hookStderr holds the end of the last local hook's stderr.
+	hookStderr string
 }
 
 func (runner *runner) logger() loggo.Logger {
@@ -186,6 +200,11 @@ func (runner *runner) Context() Context {
 	return runner.context
 }
 
+// HookStderr implements Runner.
+func (runner *runner) HookStderr() string {
+	return runner.hookStderr
+}
+
 func (runner *runner) getExecutor(rMode runMode) (ExecFunc, error) {
 	switch rMode {
 	case runOnLocal:
@@ -522,7 +541,47 @@ func (b *bufferAdaptor) Bytes() []byte {
 	return b.outCopy.Bytes()
 }
 
-func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, env []string) error {
+// maxHookStderr is the most of the end of a hook's stderr which is
+// kept by tailAdaptor.
+const maxHookStderr = 4096
+
+// tailAdaptor implements MessageReceiver and keeps
+// the end of the messages it receives.
+type tailAdaptor struct {
+	mu   sync.Mutex
+	tail []byte
+}
+
+// Messagef implements the charmrunner MessageReceiver interface
+func (t *tailAdaptor) Messagef(isPrefix bool, message string, args ...interface{}) {
+	formattedMessage := message
+	if len(args) > 0 {
+		formattedMessage = fmt.Sprintf(message, args...)
+	}
+	if !isPrefix {
+		formattedMessage += "\n"
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tail = append(t.tail, formattedMessage...)
+	if n := len(t.tail) - maxHookStderr; n > 0 {
+		// Don't keep part of a multi-byte character.
+		for n < len(t.tail) && !utf8.RuneStart(t.tail[n]) {
+			n++
+		}
+		t.tail = append(t.tail[:0], t.tail[n:]...)
+	}
+}
+
+// String returns the end of the messages received.
+func (t *tailAdaptor) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.tail)
+}
+
+func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, env []string) (err error) {
 	var cancel <-chan struct{}
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
@@ -538,29 +597,38 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 	defer hookOutLogger.Stop()
 	go hookOutLogger.Run()
 
-	// When running an action, We capture stdout and stderr
-	// separately to pass back.
-	var actionErr = actionOut
-	var hookErrLogger *charmrunner.HookLogger
+	// Stderr is captured separately, to pass back when running an
+	// action and to keep the end of when running a hook.
+	errReader, errWriter, err := os.Pipe()
+	if err != nil {
+		return errors.Errorf("cannot make stderr logging pipe: %v", err)
+	}
+	defer func() { _ = errWriter.Close() }()
+
+	actionErr := &bufferAdaptor{ReadWriter: errWriter}
+	hookErrLogger := charmrunner.NewHookLogger(errReader,
+		&loggerAdaptor{Logger: runner.getLogger(hookName), level: loggo.WARNING},
+	)
+	defer hookErrLogger.Stop()
+
+	var hookErr *tailAdaptor
 	actionData, err := runner.context.ActionData()
 	runningAction := err == nil && actionData != nil
 	if runningAction {
 		cancel = actionData.Cancel
-
-		errReader, errWriter, err := os.Pipe()
-		if err != nil {
-			return errors.Errorf("cannot make stderr logging pipe: %v", err)
-		}
-		defer func() { _ = errWriter.Close() }()
-
-		actionErr = &bufferAdaptor{ReadWriter: errWriter}
-		hookErrLogger = charmrunner.NewHookLogger(errReader,
-			&loggerAdaptor{Logger: runner.getLogger(hookName), level: loggo.WARNING},
-			actionErr,
-		)
-		defer hookErrLogger.Stop()
-		go hookErrLogger.Run()
+		hookErrLogger.AddReceiver(actionErr)
+	} else {
+		hookErr = &tailAdaptor{}
+		hookErrLogger.AddReceiver(hookErr)
+		var stopWatching func() error
+		cancel, stopWatching = runner.watchHookInterrupt(hookName)
+		defer func() {
+			if reason := stopWatching(); reason != nil && err != nil {
+				err = reason
+			}
+		}()
 	}
+	go hookErrLogger.Run()
 
 	executor, err := runner.getExecutor(runOnRemote)
 	if err != nil {
@@ -579,6 +647,13 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 		},
 	)
 
+	// Ensure the stderr logger is stopped before reading the end of
+	// stderr so all the output is captured.
+	hookErrLogger.Stop()
+	if hookErr != nil {
+		runner.hookStderr = hookErr.String()
+	}
+
 	// If we are running an action, record stdout and stderr.
 	if runningAction && resp != nil {
 		if err := runner.updateActionResults(resp); err != nil {
@@ -595,6 +670,9 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	// Run the hook in its own process group, so that any processes it
+	// starts are killed along with it if it's interrupted.
+	setProcessGroup(ps)
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -622,8 +700,10 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	go hookErrLogger.Run()
 
 	var cancel <-chan struct{}
+	var stopWatching func() error
 	var actionOut *bufferAdaptor
 	var actionErr *bufferAdaptor
+	var hookErr *tailAdaptor
 	actionData, err := runner.context.ActionData()
 	runningAction := err == nil && actionData != nil
 	if runningAction {
@@ -632,6 +712,10 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 		actionErr = &bufferAdaptor{ReadWriter: errWriter}
 		hookErrLogger.AddReceiver(actionErr)
 		cancel = actionData.Cancel
+	} else {
+		hookErr = &tailAdaptor{}
+		hookErrLogger.AddReceiver(hookErr)
+		cancel, stopWatching = runner.watchHookInterrupt(hookName)
 	}
 
 	err = ps.Start()
@@ -642,7 +726,9 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 			go func() {
 				select {
 				case <-cancel:
-					_ = ps.Process.Kill()
+					if err := killProcessTree(ps.Process); err != nil {
+						runner.logger().Warningf("cannot kill %s process tree: %v", hookName, err)
+					}
 				case <-done:
 				}
 			}()
@@ -655,12 +741,22 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	} else {
 		exitErr = err
 	}
+	if stopWatching != nil {
+		// An interrupted hook fails because it was killed; report why.
+		if reason := stopWatching(); reason != nil && exitErr != nil {
+			exitErr = reason
+		}
+	}
 
 	// Ensure hook loggers are stopped before reading stdout/stderr
 	// so all the output is captured.
 	hookOutLogger.Stop()
 	hookErrLogger.Stop()
 
+	if hookErr != nil {
+		runner.hookStderr = hookErr.String()
+	}
+
 	// If we are running an action, record stdout and stderr.
 	if runningAction {
 		resp := &utilexec.ExecResponse{
@@ -676,6 +772,47 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	return errors.Trace(exitErr)
 }
 
+// watchHookInterrupt returns a channel which is closed when the named
+// hook should be interrupted, either because its cancellation was
+// requested or because it ran for longer than the hook timeout. The
+// returned func stops watching, and returns the reason the hook was
+// interrupted, if it was.
+func (runner *runner) watchHookInterrupt(hookName string) (<-chan struct{}, func() error) {
+	var timer clock.Timer
+	var timeout <-chan time.Time
+	hookTimeout := runner.context.HookTimeout()
+	if hookTimeout > 0 {
+		timer = clock.WallClock.NewTimer(hookTimeout)
+		timeout = timer.Chan()
+	}
+
+	interrupt := make(chan struct{})
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	var reason error
+	go func() {
+		defer close(finished)
+		select {
+		case <-runner.context.HookCancel():
+			reason = charmrunner.ErrHookCancelled
+		case <-timeout:
+			reason = charmrunner.NewHookTimedOutError(hookName, hookTimeout)
+		case <-done:
+			return
+		}
+		runner.logger().Warningf("interrupting %s hook: %v", hookName, reason)
+		close(interrupt)
+	}()
+	return interrupt, func() error {
+		close(done)
+		<-finished
+		if timer != nil {
+			timer.Stop()
+		}
+		return reason
+	}
+}
+
 // discoverHookHandler checks to see if the dispatch script exists, if not,
 // check for the given hookName.  Based on what is discovered, return the
 // HookHandlerType and the actual script to be run.
@@ -192,6 +192,7 @@ func (s *RunHookSuite) TestRunHook(c *gc.C) {
 		if t.spec.stderr != "" {
 			c.Check(writer.Buffer.String(), jc.Contains,
 				fmt.Sprintf("WARNING unit.u/0.something-happened %s\n", t.spec.stderr))
+			c.Check(rnr.HookStderr(), gc.Equals, t.spec.stderr+"\n")
 		}
 	}
 }
@@ -226,6 +227,8 @@ type MockContext struct {
 	flushFailure    error
 	flushResult     error
 	modelType       model.ModelType
+	hookTimeout     time.Duration
+	hookCancel      chan struct{}
 }
 
 func (ctx *MockContext) GetLogger(module string) loggo.Logger {
@@ -280,6 +283,14 @@ func (ctx *MockContext) UpdateActionResults(keys []string, value interface{}) er
 	return nil
 }
 
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
+func (ctx *MockContext) HookCancel() <-chan struct{} {
+	return ctx.hookCancel
+}
+
 func (ctx *MockContext) ModelType() model.ModelType {
 	if ctx.modelType == "" {
 		return model.IAAS
@@ -342,6 +353,44 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookTimedOut(c *gc.C) {
+	ctx := &MockContext{
+		hookTimeout: 100 * time.Millisecond,
+	}
+	makeCharm(c, hookSpec{
+		dir:   "hooks",
+		name:  hookName,
+		perm:  0700,
+		sleep: 10,
+	}, s.paths.GetCharmDir())
+	start := time.Now()
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(start) < 10*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(charmrunner.IsHookTimedOutError(ctx.flushFailure), jc.IsTrue)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "something-happened timed out after 100ms")
+}
+
+func (s *RunMockContextSuite) TestRunHookCancelled(c *gc.C) {
+	ctx := &MockContext{
+		hookCancel: make(chan struct{}),
+	}
+	close(ctx.hookCancel)
+	makeCharm(c, hookSpec{
+		dir:   "hooks",
+		name:  hookName,
+		perm:  0700,
+		sleep: 10,
+	}, s.paths.GetCharmDir())
+	start := time.Now()
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(start) < 10*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.Equals, charmrunner.ErrHookCancelled)
+}
+
 func (s *RunHookSuite) TestRunActionDispatchingHookHandler(c *gc.C) {
 	ctx := &MockContext{
 		actionData:    &context.ActionData{},
@@ -735,6 +784,64 @@ export PATH='important-path'
 	c.Assert(ctx.actionResults["stderr"], gc.Equals, nil)
 }
 
+func (s *RunMockContextSuite) TestRunHookCAASKeepsStderr(c *gc.C) {
+	ctx := &MockContext{
+		modelType: model.CAAS,
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	execCount := 0
+	execFunc := func(params runner.ExecParams) (*exec.ExecResponse, error) {
+		execCount++
+		switch execCount {
+		case 1:
+			return &exec.ExecResponse{}, nil
+		case 2:
+			_, err := params.Stderr.Write([]byte("hello\nworld\n"))
+			c.Assert(err, jc.ErrorIsNil)
+			params.StderrLogger.Stop()
+			return &exec.ExecResponse{}, nil
+		}
+		c.Fatal("invalid count")
+		return nil, nil
+	}
+	rnr := runner.NewRunner(ctx, s.paths, execFunc)
+	_, err := runner.RunHookOnRemote(rnr, "something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(execCount, gc.Equals, 2)
+	c.Assert(rnr.HookStderr(), gc.Equals, "hello\nworld\n")
+}
+
+func (s *RunMockContextSuite) TestRunHookCAASTrimsStderrOnRuneBoundary(c *gc.C) {
+	ctx := &MockContext{
+		modelType: model.CAAS,
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	// 4201 bytes, so trimming to 4096 would start inside an "é".
+	stderr := strings.Repeat("é", 2100) + "\n"
+	execCount := 0
+	execFunc := func(params runner.ExecParams) (*exec.ExecResponse, error) {
+		execCount++
+		if execCount == 2 {
+			_, err := params.Stderr.Write([]byte(stderr))
+			c.Assert(err, jc.ErrorIsNil)
+			params.StderrLogger.Stop()
+		}
+		return &exec.ExecResponse{}, nil
+	}
+	rnr := runner.NewRunner(ctx, s.paths, execFunc)
+	_, err := runner.RunHookOnRemote(rnr, "something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rnr.HookStderr(), gc.Equals, strings.Repeat("é", 2047)+"\n")
+}
+
 func (s *RunMockContextSuite) TestRunActionOnWorkloadIgnoredIAAS(c *gc.C) {
 	params := map[string]interface{}{
 		"command":          "echo 1",
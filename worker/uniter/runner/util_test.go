@@ -206,6 +206,8 @@ type hookSpec struct {
 	background string
 	// missingShebang will omit the '#!/bin/bash' line
 	missingShebang bool
+	// sleep holds the number of seconds to sleep before exiting.
+	sleep int
 }
 
 // makeCharm constructs a fake charm dir containing a single named hook
@@ -250,6 +252,9 @@ func makeCharm(c *gc.C, spec hookSpec, charmDir string) {
 		// expected.
 		printf("(sleep 0.2; echo %s; sleep 10) &", spec.background)
 	}
+	if spec.sleep > 0 {
+		printf("sleep %d", spec.sleep)
+	}
 	printf("exit %d", spec.code)
 }
 
@@ -0,0 +1,105 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"sort"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+// Logger defines the logging methods used by the secrets package.
+type Logger interface {
+	Debugf(string, ...interface{})
+}
+
+// SecretRotatedFunc is called when a secret-rotate hook has
+// been successfully run for the specified secret.
+type SecretRotatedFunc func(uri string)
+
+type secretsResolver struct {
+	logger        Logger
+	secretRotated SecretRotatedFunc
+}
+
+// NewSecretsResolver returns a new resolver which runs the
+// secret-changed and secret-rotate hooks.
+func NewSecretsResolver(logger Logger, secretRotated SecretRotatedFunc) resolver.Resolver {
+	return &secretsResolver{
+		logger:        logger,
+		secretRotated: secretRotated,
+	}
+}
+
+// NextOp is defined on the Resolver interface.
+func (s *secretsResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	// Nothing to do if the unit isn't ready or is busy with
+	// something else.
+	if !localState.Installed || localState.Kind != operation.Continue || remoteState.Life != life.Alive {
+		return nil, resolver.ErrNoOperation
+	}
+
+	// Only the leader rotates the application's secrets.
+	if remoteState.Leader && len(remoteState.SecretRotations) > 0 {
+		uri := remoteState.SecretRotations[0]
+		s.logger.Debugf("%s: running secret-rotate hook", uri)
+		op, err := opFactory.NewRunHook(hook.Info{
+			Kind:      hook.SecretRotate,
+			SecretURI: uri,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &secretRotatedOp{Operation: op, uri: uri, secretRotated: s.secretRotated}, nil
+	}
+
+	// Run secret-changed for any secret with a revision the unit
+	// hasn't been told about. Iterate in a stable order.
+	uris := make([]string, 0, len(remoteState.ConsumedSecretInfo))
+	for uri := range remoteState.ConsumedSecretInfo {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	for _, uri := range uris {
+		if localState.SecretRevisions[uri] == remoteState.ConsumedSecretInfo[uri] {
+			continue
+		}
+		s.logger.Debugf("%s: running secret-changed hook", uri)
+		return opFactory.NewRunHook(hook.Info{
+			Kind:      hook.SecretChanged,
+			SecretURI: uri,
+		})
+	}
+	return nil, resolver.ErrNoOperation
+}
+
+// secretRotatedOp calls secretRotated once the secret-rotate
+// hook has been committed.
+type secretRotatedOp struct {
+	operation.Operation
+	uri           string
+	secretRotated SecretRotatedFunc
+}
+
+// Commit is part of the Operation interface.
+func (op *secretRotatedOp) Commit(state operation.State) (*operation.State, error) {
+	newState, err := op.Operation.Commit(state)
+	if err == nil && op.secretRotated != nil {
+		op.secretRotated(op.uri)
+	}
+	return newState, err
+}
+
+// WrappedOperation is part of the WrappedOperation interface.
+func (op *secretRotatedOp) WrappedOperation() operation.Operation {
+	return op.Operation
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/secrets"
+)
+
+type resolverSuite struct {
+	rotated []string
+}
+
+var _ = gc.Suite(&resolverSuite{})
+
+func (s *resolverSuite) SetUpTest(c *gc.C) {
+	s.rotated = nil
+}
+
+func (s *resolverSuite) newResolver() resolver.Resolver {
+	return secrets.NewSecretsResolver(loggo.GetLogger("test"), func(uri string) {
+		s.rotated = append(s.rotated, uri)
+	})
+}
+
+func (s *resolverSuite) localState() resolver.LocalState {
+	return resolver.LocalState{
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+		},
+	}
+}
+
+func (s *resolverSuite) TestNoOpNotInstalled(c *gc.C) {
+	localState := s.localState()
+	localState.Installed = false
+	remoteState := remotestate.Snapshot{
+		Life:               life.Alive,
+		ConsumedSecretInfo: map[string]int{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30": 2},
+	}
+	_, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestSecretChanged(c *gc.C) {
+	remoteState := remotestate.Snapshot{
+		Life:               life.Alive,
+		ConsumedSecretInfo: map[string]int{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30": 2},
+	}
+	op, err := s.newResolver().NextOp(s.localState(), remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.(*mockRunHookOp).hookInfo, jc.DeepEquals, hook.Info{
+		Kind:      hook.SecretChanged,
+		SecretURI: "secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30",
+	})
+}
+
+func (s *resolverSuite) TestSecretChangedAlreadyRun(c *gc.C) {
+	localState := s.localState()
+	localState.SecretRevisions = map[string]int{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30": 2}
+	remoteState := remotestate.Snapshot{
+		Life:               life.Alive,
+		ConsumedSecretInfo: map[string]int{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30": 2},
+	}
+	_, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestSecretRotate(c *gc.C) {
+	remoteState := remotestate.Snapshot{
+		Life:            life.Alive,
+		Leader:          true,
+		SecretRotations: []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"},
+	}
+	op, err := s.newResolver().NextOp(s.localState(), remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.(operation.WrappedOperation).WrappedOperation().(*mockRunHookOp).hookInfo, jc.DeepEquals, hook.Info{
+		Kind:      hook.SecretRotate,
+		SecretURI: "secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30",
+	})
+	c.Assert(s.rotated, gc.HasLen, 0)
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rotated, jc.DeepEquals, []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"})
+}
+
+func (s *resolverSuite) TestSecretRotateNotLeader(c *gc.C) {
+	remoteState := remotestate.Snapshot{
+		Life:            life.Alive,
+		SecretRotations: []string{"secret:5c7e0a3b-9d2f-4e81-b6a4-2f1d8c9e7b30"},
+	}
+	_, err := s.newResolver().NextOp(s.localState(), remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
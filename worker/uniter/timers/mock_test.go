@@ -0,0 +1,33 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package timers_test
+
+import (
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type mockOperations struct {
+	operation.Factory
+}
+
+func (m *mockOperations) NewRunHook(hookInfo hook.Info) (operation.Operation, error) {
+	return &mockRunHookOp{hookInfo: hookInfo}, nil
+}
+
+type mockRunHookOp struct {
+	operation.Operation
+	hookInfo hook.Info
+}
+
+func (op *mockRunHookOp) String() string {
+	return "hook op"
+}
+
+func (op *mockRunHookOp) Prepare(state operation.State) (*operation.State, error) {
+	state.Kind = operation.RunHook
+	state.Step = operation.Pending
+	state.Hook = &op.hookInfo
+	return &state, nil
+}
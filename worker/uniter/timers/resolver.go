@@ -0,0 +1,111 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package timers
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+
+	"github.com/juju/juju/core/life"
+	coretimers "github.com/juju/juju/core/timers"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+// Logger defines the logging methods used by the timers package.
+type Logger interface {
+	Debugf(string, ...interface{})
+}
+
+// WakeFunc is called with the time the next timer is due, so that
+// the resolver can be run again at that time.
+type WakeFunc func(time.Time)
+
+type timersResolver struct {
+	logger Logger
+	clock  clock.Clock
+	wake   WakeFunc
+}
+
+// NewResolver returns a new resolver which runs the timer-fired hook
+// for the timers scheduled by the charm when they are due.
+func NewResolver(logger Logger, clock clock.Clock, wake WakeFunc) resolver.Resolver {
+	return &timersResolver{
+		logger: logger,
+		clock:  clock,
+		wake:   wake,
+	}
+}
+
+// NextOp is defined on the Resolver interface.
+func (r *timersResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	// Nothing to do if the unit isn't ready or is busy with
+	// something else.
+	if !localState.Installed || localState.Kind != operation.Continue || remoteState.Life != life.Alive {
+		return nil, resolver.ErrNoOperation
+	}
+
+	name, ok := coretimers.Earliest(localState.Timers)
+	if !ok {
+		return nil, resolver.ErrNoOperation
+	}
+	next := localState.Timers[name].Next
+	if next.After(r.clock.Now()) {
+		r.wake(next)
+		return nil, resolver.ErrNoOperation
+	}
+
+	r.logger.Debugf("%s: running timer-fired hook", name)
+	op, err := opFactory.NewRunHook(hook.Info{
+		Kind:      hook.TimerFired,
+		TimerName: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &timerFiredOp{Operation: op, name: name, clock: r.clock}, nil
+}
+
+// timerFiredOp reschedules or removes the timer when the timer-fired
+// hook is prepared, so the timer isn't fired again if the hook fails
+// or the agent restarts while it runs.
+type timerFiredOp struct {
+	operation.Operation
+	name  string
+	clock clock.Clock
+}
+
+// Prepare is part of the Operation interface.
+func (op *timerFiredOp) Prepare(state operation.State) (*operation.State, error) {
+	newState, err := op.Operation.Prepare(state)
+	if err != nil {
+		return newState, err
+	}
+	timers := make(map[string]coretimers.Timer)
+	for name, t := range newState.Timers {
+		if name != op.name {
+			timers[name] = t
+		}
+	}
+	if t, ok := newState.Timers[op.name].Reschedule(op.clock.Now()); ok {
+		timers[op.name] = t
+	}
+	if len(timers) == 0 {
+		timers = nil
+	}
+	newState.Timers = timers
+	return newState, nil
+}
+
+// WrappedOperation is part of the WrappedOperation interface.
+func (op *timerFiredOp) WrappedOperation() operation.Operation {
+	return op.Operation
+}
@@ -0,0 +1,146 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package timers_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/life"
+	coretimers "github.com/juju/juju/core/timers"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/timers"
+)
+
+type resolverSuite struct {
+	clock *testclock.Clock
+	woken []time.Time
+}
+
+var _ = gc.Suite(&resolverSuite{})
+
+var now = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *resolverSuite) SetUpTest(c *gc.C) {
+	s.clock = testclock.NewClock(now)
+	s.woken = nil
+}
+
+func (s *resolverSuite) newResolver() resolver.Resolver {
+	return timers.NewResolver(loggo.GetLogger("test"), s.clock, func(t time.Time) {
+		s.woken = append(s.woken, t)
+	})
+}
+
+func (s *resolverSuite) localState(charmTimers map[string]coretimers.Timer) resolver.LocalState {
+	return resolver.LocalState{
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Timers:    charmTimers,
+		},
+	}
+}
+
+func (s *resolverSuite) TestNoTimers(c *gc.C) {
+	remoteState := remotestate.Snapshot{Life: life.Alive}
+	_, err := s.newResolver().NextOp(s.localState(nil), remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(s.woken, gc.HasLen, 0)
+}
+
+func (s *resolverSuite) TestNoOpNotInstalled(c *gc.C) {
+	localState := s.localState(map[string]coretimers.Timer{
+		"backup": {Due: now, Next: now},
+	})
+	localState.Installed = false
+	remoteState := remotestate.Snapshot{Life: life.Alive}
+	_, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestNoOpDying(c *gc.C) {
+	localState := s.localState(map[string]coretimers.Timer{
+		"backup": {Due: now, Next: now},
+	})
+	remoteState := remotestate.Snapshot{Life: life.Dying}
+	_, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestTimerNotDue(c *gc.C) {
+	localState := s.localState(map[string]coretimers.Timer{
+		"backup":  {Due: now.Add(time.Hour), Next: now.Add(time.Hour)},
+		"cleanup": {Due: now.Add(time.Minute), Next: now.Add(2 * time.Minute)},
+	})
+	remoteState := remotestate.Snapshot{Life: life.Alive}
+	_, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(s.woken, jc.DeepEquals, []time.Time{now.Add(2 * time.Minute)})
+}
+
+func (s *resolverSuite) TestTimerFired(c *gc.C) {
+	localState := s.localState(map[string]coretimers.Timer{
+		"backup":  {Due: now.Add(-time.Minute), Next: now.Add(-time.Minute)},
+		"cleanup": {Due: now.Add(time.Hour), Next: now.Add(time.Hour)},
+	})
+	remoteState := remotestate.Snapshot{Life: life.Alive}
+	op, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.(operation.WrappedOperation).WrappedOperation().(*mockRunHookOp).hookInfo, jc.DeepEquals, hook.Info{
+		Kind:      hook.TimerFired,
+		TimerName: "backup",
+	})
+
+	newState, err := op.Prepare(localState.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState.Timers, jc.DeepEquals, map[string]coretimers.Timer{
+		"cleanup": {Due: now.Add(time.Hour), Next: now.Add(time.Hour)},
+	})
+	// The local state is left untouched.
+	c.Assert(localState.Timers, gc.HasLen, 2)
+}
+
+func (s *resolverSuite) TestLastTimerFired(c *gc.C) {
+	localState := s.localState(map[string]coretimers.Timer{
+		"backup": {Due: now, Next: now},
+	})
+	remoteState := remotestate.Snapshot{Life: life.Alive}
+	op, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Prepare(localState.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState.Timers, gc.IsNil)
+}
+
+func (s *resolverSuite) TestRepeatingTimerRescheduled(c *gc.C) {
+	localState := s.localState(map[string]coretimers.Timer{
+		"backup": {
+			Due:   now.Add(-150 * time.Minute),
+			Next:  now.Add(-150 * time.Minute),
+			Every: time.Hour,
+		},
+	})
+	remoteState := remotestate.Snapshot{Life: life.Alive}
+	op, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Prepare(localState.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState.Timers, jc.DeepEquals, map[string]coretimers.Timer{
+		"backup": {
+			Due:   now.Add(30 * time.Minute),
+			Next:  now.Add(30 * time.Minute),
+			Every: time.Hour,
+		},
+	})
+}
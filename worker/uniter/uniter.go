@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	corecharm "github.com/juju/charm/v9"
 	"github.com/juju/clock"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/utils/v2/exec"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/catacomb"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/api/uniter"
@@ -43,7 +45,9 @@ import (
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	unitersecrets "github.com/juju/juju/worker/uniter/secrets"
 	"github.com/juju/juju/worker/uniter/storage"
+	unitertimers "github.com/juju/juju/worker/uniter/timers"
 	"github.com/juju/juju/worker/uniter/upgradeseries"
 	"github.com/juju/juju/worker/uniter/verifycharmprofile"
 )
@@ -162,6 +166,25 @@ type Uniter struct {
 	// rebooted so we can notify the charms accordingly.
 	rebootQuerier RebootQuerier
 	logger        Logger
+
+	// metrics holds the unit's hook, action and resolver metrics,
+	// which are registered with prometheusRegisterer, if set, while
+	// the uniter is running.
+	metrics              *metrics
+	prometheusRegisterer prometheus.Registerer
+
+	// secretsClient is used to access the secrets the unit reads
+	// and owns. Secret hooks aren't run if it is nil.
+	secretsClient SecretsClient
+}
+
+// SecretsClient provides the access to secrets needed by the uniter.
+type SecretsClient interface {
+	remotestate.SecretsClient
+	context.SecretsAccessor
+
+	// SecretRotated records when a secret was rotated.
+	SecretRotated(uri string, when time.Time) error
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -199,6 +222,8 @@ type UniterParams struct {
 	EnforcedCharmModifiedVersion int
 	ContainerNames               []string
 	NewPebbleClient              NewPebbleClientFunc
+	PrometheusRegisterer         prometheus.Registerer
+	SecretsClient                SecretsClient
 }
 
 // NewOperationExecutorFunc is a func which returns an operations.Executor.
@@ -266,6 +291,9 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 			enforcedCharmModifiedVersion:  uniterParams.EnforcedCharmModifiedVersion,
 			containerNames:                uniterParams.ContainerNames,
 			newPebbleClient:               uniterParams.NewPebbleClient,
+			metrics:                       newMetrics(uniterParams.UnitTag.Id()),
+			prometheusRegisterer:          uniterParams.PrometheusRegisterer,
+			secretsClient:                 uniterParams.SecretsClient,
 		}
 		plan := catacomb.Plan{
 			Site: &u.catacomb,
@@ -309,6 +337,14 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		u.logger.Infof("unit %q shutting down: %s", unitTag.Id(), errorString)
 	}()
 
+	if u.prometheusRegisterer != nil {
+		if err := u.prometheusRegisterer.Register(u.metrics); err != nil {
+			u.logger.Warningf("cannot register uniter metrics: %v", err)
+		} else {
+			defer u.prometheusRegisterer.Unregister(u.metrics)
+		}
+	}
+
 	if err := u.init(unitTag); err != nil {
 		switch cause := errors.Cause(err); cause {
 		case resolver.ErrLoopAborted:
@@ -365,6 +401,27 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		retryHookTimer.Reset()
 	}()
 
+	// The timers resolver asks to be woken when the next timer
+	// scheduled by the charm is due.
+	charmTimerChan := make(chan struct{}, 1)
+	var charmTimer clock.Timer
+	wakeForCharmTimer := func(due time.Time) {
+		if charmTimer != nil {
+			charmTimer.Stop()
+		}
+		charmTimer = u.clock.AfterFunc(due.Sub(u.clock.Now()), func() {
+			select {
+			case charmTimerChan <- struct{}{}:
+			default:
+			}
+		})
+	}
+	defer func() {
+		if charmTimer != nil {
+			charmTimer.Stop()
+		}
+	}()
+
 	restartWatcher := func() error {
 		if watcher != nil {
 			// watcher added to catacomb, will kill uniter if there's an error.
@@ -379,6 +436,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UpdateStatusChannel:           u.updateStatusAt,
 				CommandChannel:                u.commandChannel,
 				RetryHookChannel:              retryHookChan,
+				CharmTimerChannel:             charmTimerChan,
 				ApplicationChannel:            u.applicationChannel,
 				ContainerRunningStatusChannel: u.containerRunningStatusChannel,
 				ContainerRunningStatusFunc:    u.containerRunningStatusFunc,
@@ -388,6 +446,9 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				Embedded:                      u.embedded,
 				EnforcedCharmModifiedVersion:  u.enforcedCharmModifiedVersion,
 				WorkloadEventChannel:          u.workloadEventChannel,
+				EventCounter:                  u.metrics.remoteStateEvents,
+				SecretsClient:                 u.secretsClient,
+				Clock:                         u.clock,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -418,6 +479,14 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		return nil
 	}
 
+	clearHookCancel := func() error {
+		if err := u.unit.ClearHookCancel(); err != nil {
+			return errors.Trace(err)
+		}
+		watcher.ClearHookCancel()
+		return nil
+	}
+
 	if u.modelType == model.CAAS && u.isRemoteUnit {
 		if u.containerRunningStatusChannel == nil {
 			return errors.NotValidf("ContainerRunningStatusChannel missing for CAAS remote unit")
@@ -444,6 +513,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		cfg := ResolverConfig{
 			ModelType:           u.modelType,
 			ClearResolved:       clearResolved,
+			ClearHookCancel:     clearHookCancel,
 			ReportHookError:     u.reportHookError,
 			ShouldRetryHooks:    u.hookRetryStrategy.ShouldRetry,
 			StartRetryHookTimer: retryHookTimer.Start,
@@ -482,7 +552,21 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				watcher.WorkloadEventCompleted),
 			)
 		}
-		uniterResolver := NewUniterResolver(cfg)
+		if u.secretsClient != nil {
+			cfg.OptionalResolvers = append(cfg.OptionalResolvers, unitersecrets.NewSecretsResolver(
+				u.logger.Child("secrets"),
+				u.secretRotated(watcher),
+			))
+		}
+		cfg.OptionalResolvers = append(cfg.OptionalResolvers, unitertimers.NewResolver(
+			u.logger.Child("timers"),
+			u.clock,
+			wakeForCharmTimer,
+		))
+		uniterResolver := &meteredResolver{
+			Resolver:   NewUniterResolver(cfg),
+			iterations: u.metrics.resolverLoops,
+		}
 
 		// We should not do anything until there has been a change
 		// to the remote state. The watcher will trigger at least
@@ -808,6 +892,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Tracker:          u.leadershipTracker,
 		GetRelationInfos: u.relationStateTracker.GetInfo,
 		Storage:          u.storage,
+		Secrets:          u.secretsClient,
 		Paths:            u.paths,
 		Clock:            u.clock,
 		Logger:           u.logger.Child("context"),
@@ -825,15 +910,19 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	u.operationFactory = operation.NewFactory(operation.FactoryParams{
-		Deployer:       deployer,
-		RunnerFactory:  runnerFactory,
-		Callbacks:      &operationCallbacks{u},
-		State:          u.st,
-		Abort:          u.catacomb.Dying(),
-		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
-		Logger:         u.logger.Child("operation"),
-	})
+	u.operationFactory = &meteredFactory{
+		Factory: operation.NewFactory(operation.FactoryParams{
+			Deployer:       deployer,
+			RunnerFactory:  runnerFactory,
+			Callbacks:      &operationCallbacks{u},
+			State:          u.st,
+			Abort:          u.catacomb.Dying(),
+			MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
+			Logger:         u.logger.Child("operation"),
+		}),
+		metrics: u.metrics,
+		clock:   u.clock,
+	}
 
 	charmURL, err := u.getApplicationCharmURL()
 	if err != nil {
@@ -851,6 +940,8 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		InitialState:    initialState,
 		AcquireLock:     u.acquireExecutionLock,
 		Logger:          u.logger.Child("operation"),
+		RecordHookRun:   u.recordHookRun,
+		Clock:           u.clock,
 	})
 	if err != nil {
 		return errors.Trace(err)
@@ -921,6 +1012,18 @@ func (u *Uniter) RunCommands(args RunCommandsArgs) (results *exec.ExecResponse,
 	return u.localRunListener.RunCommands(args)
 }
 
+// recordHookRun adds a hook run to the unit's hook history.
+func (u *Uniter) recordHookRun(run operation.HookRun) error {
+	return u.unit.RecordHookRun(params.HookRun{
+		Hook:     run.Hook,
+		Relation: run.Relation,
+		Started:  run.Started,
+		Finished: run.Finished,
+		ExitCode: run.ExitCode,
+		Stderr:   run.Stderr,
+	})
+}
+
 // acquireExecutionLock acquires the machine-level execution lock, and
 // returns a func that must be called to unlock it. It's used by operation.Executor
 // when running operations that execute external code.
@@ -940,7 +1043,18 @@ func (u *Uniter) acquireExecutionLock(action, executionGroup string) (func(), er
 	return releaser, nil
 }
 
-func (u *Uniter) reportHookError(hookInfo hook.Info) error {
+// secretRotated returns a func which records that a secret-rotate hook
+// has been run, so the secret is rescheduled for its next rotation.
+func (u *Uniter) secretRotated(w *remotestate.RemoteStateWatcher) unitersecrets.SecretRotatedFunc {
+	return func(uri string) {
+		if err := u.secretsClient.SecretRotated(uri, u.clock.Now()); err != nil {
+			u.logger.Errorf("cannot record rotation of secret %q: %v", uri, err)
+		}
+		w.RotateSecretCompleted(uri)
+	}
+}
+
+func (u *Uniter) reportHookError(hookInfo hook.Info, failure string) error {
 	// Set the agent status to "error". We must do this here in case the
 	// hook is interrupted (e.g. unit agent crashes), rather than immediately
 	// after attempting a runHookOp.
@@ -959,5 +1073,10 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if failure != "" {
+		// The hook was interrupted rather than exiting with an error.
+		statusData["reason"] = failure
+		statusMessage = fmt.Sprintf("%s (%s)", statusMessage, failure)
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }